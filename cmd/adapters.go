@@ -0,0 +1,80 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/spf13/cobra"
+)
+
+// newAdaptersCmd builds the `sbommv adapters` command and its subcommands
+func newAdaptersCmd() *cobra.Command {
+	adaptersCmd := &cobra.Command{
+		Use:   "adapters",
+		Short: "Inspect the adapters supported by sbommv",
+	}
+
+	adaptersListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List input and output adapters with their flags, credentials and capabilities",
+		Example: `  sbommv adapters list
+  sbommv adapters list --json`,
+		Args: cobra.NoArgs,
+		RunE: listAdapters,
+	}
+
+	adaptersCmd.AddCommand(adaptersListCmd)
+
+	adaptersListCmd.Flags().Bool("json", false, "Print the adapter catalog as JSON")
+
+	return adaptersCmd
+}
+
+func listAdapters(cmd *cobra.Command, args []string) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+	infos := adapter.Catalog()
+
+	if asJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(struct {
+			Adapters []adapter.AdapterInfo `json:"adapters"`
+		}{Adapters: infos}); err != nil {
+			return fmt.Errorf("failed to encode adapter catalog: %w", err)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tROLE\tCAPABILITIES\tCREDENTIALS\tDESCRIPTION")
+	for _, info := range infos {
+		var credentials []string
+		for _, c := range info.Credentials {
+			if c.EnvVar != "" {
+				credentials = append(credentials, c.EnvVar)
+			} else {
+				credentials = append(credentials, "--"+c.Flag)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", info.Name, info.Role, strings.Join(info.Capabilities, ","), strings.Join(credentials, ","), info.Description)
+	}
+
+	return w.Flush()
+}
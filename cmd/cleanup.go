@@ -0,0 +1,97 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/engine"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// newCleanupCmd builds the `sbommv cleanup` command
+func newCleanupCmd() *cobra.Command {
+	cleanupCmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Remove destination projects created by a specific transfer run",
+		Long: `Remove the projects a transfer run created at the destination, identified by the run's --run-id.
+Projects that existed before the run are left untouched, even if the run uploaded SBOMs to them.`,
+		Example: `  # list the projects created by a run
+  sbommv cleanup --output-adapter=dtrack --out-dtrack-url="http://localhost:8080" --run-id=test-run-1 --dry-run
+
+  # delete them
+  sbommv cleanup --output-adapter=dtrack --out-dtrack-url="http://localhost:8080" --run-id=test-run-1
+
+  # deactivate them instead of deleting
+  sbommv cleanup --output-adapter=dtrack --out-dtrack-url="http://localhost:8080" --run-id=test-run-1 --deactivate`,
+		Args: cobra.NoArgs,
+		RunE: cleanupRun,
+	}
+
+	cleanupCmd.Flags().BoolP("debug", "D", false, "Enable debug logging")
+	cleanupCmd.Flags().Bool("dry-run", false, "List the projects that would be removed without removing them")
+	cleanupCmd.Flags().String("run-id", "", "Identifier of the transfer run whose projects are removed")
+	cleanupCmd.Flags().Bool("deactivate", false, "Deactivate the projects instead of deleting them")
+	cleanupCmd.Flags().String("output-adapter", "", "Output adapter type (dtrack)")
+
+	adapter.RegisterCleanupFlags(cleanupCmd)
+
+	return cleanupCmd
+}
+
+func cleanupRun(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	debug, _ := cmd.Flags().GetBool("debug")
+	logger.InitLogger(debug, false, logger.WithWriter(cmd.OutOrStdout()))
+	defer logger.DeinitLogger()
+	defer logger.Sync()
+
+	ctx := logger.WithLogger(context.Background())
+
+	initConfig()
+
+	outputType, _ := cmd.Flags().GetString("output-adapter")
+	runID, _ := cmd.Flags().GetString("run-id")
+	deactivate, _ := cmd.Flags().GetBool("deactivate")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	missingFlags := []string{}
+	if outputType == "" {
+		missingFlags = append(missingFlags, "--output-adapter")
+	}
+	if runID == "" {
+		missingFlags = append(missingFlags, "--run-id")
+	}
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing required flags: %v\n\nUse 'sbommv cleanup --help' for usage details.", missingFlags)
+	}
+
+	config := types.Config{
+		DestinationAdapter: outputType,
+		ProcessingStrategy: string(types.FetchSequential),
+	}
+	opts := types.CleanupOptions{
+		RunID:      runID,
+		Deactivate: deactivate,
+		DryRun:     dryRun,
+	}
+
+	return engine.CleanupRun(ctx, cmd, config, opts)
+}
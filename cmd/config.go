@@ -0,0 +1,152 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// newConfigCmd builds the `sbommv config` command and its subcommands
+func newConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Work with transfer config files",
+	}
+
+	configValidateCmd := &cobra.Command{
+		Use:   "validate <file>",
+		Short: "Validate a transfer config file",
+		Long: `Check a transfer config file without running the transfer: the file parses, every key is a
+transfer flag with a valid value, the environment variables it references are set, and the
+adapters and their flags fit together. Adapters are not contacted, so credentials and
+connectivity are checked when the transfer runs.`,
+		Example: `  sbommv config validate transfer.yaml`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    validateConfigFile,
+	}
+
+	configCmd.AddCommand(configValidateCmd)
+
+	return configCmd
+}
+
+// envReference matches the ${VAR} references interpolated in config file values
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// applyConfigFile sets the flags of a transfer command from a YAML or JSON config file whose
+// keys are flag names without leading dashes, e.g. `in-folder-path: sboms`. Flags set on the
+// command line take precedence over the file. ${VAR} in values is replaced with the
+// environment variable VAR, which must be set.
+func applyConfigFile(cmd *cobra.Command, path string) error {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("reading config file %s: %w", path, err)
+	}
+
+	settings := v.AllSettings()
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var invalid []string
+	for _, key := range keys {
+		flag := cmd.Flags().Lookup(key)
+		if flag == nil || key == "config" {
+			invalid = append(invalid, fmt.Sprintf("%s: unknown transfer flag", key))
+			continue
+		}
+		if flag.Changed {
+			// the command line overrides the file
+			continue
+		}
+
+		value := settings[key]
+		if _, ok := value.(map[string]any); ok {
+			invalid = append(invalid, fmt.Sprintf("%s: must be a value or a list", key))
+			continue
+		}
+
+		expanded, err := expandEnv(flagValue(value))
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		if err := cmd.Flags().Set(key, expanded); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid config file %s:\n- %s", path, strings.Join(invalid, "\n- "))
+	}
+	return nil
+}
+
+// expandEnv replaces the ${VAR} references of a value with their environment variable
+func expandEnv(value string) (string, error) {
+	var missing []string
+	expanded := envReference.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envReference.FindStringSubmatch(ref)[1]
+		env, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return env
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+func validateConfigFile(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	path := args[0]
+
+	transferCmd := &cobra.Command{Use: "transfer"}
+	addTransferFlags(transferCmd)
+	transferCmd.SetContext(cmd.Context())
+
+	if err := applyConfigFile(transferCmd, path); err != nil {
+		return err
+	}
+
+	config, err := parseConfig(transferCmd)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.FlagValidation(transferCmd, types.AdapterType(config.SourceAdapter), types.InputAdapterFlagPrefix); err != nil {
+		return err
+	}
+	if err := utils.FlagValidation(transferCmd, types.AdapterType(config.DestinationAdapter), types.OutputAdapterFlagPrefix); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ %s is valid: %s → %s\n", path, config.SourceAdapter, config.DestinationAdapter)
+	return nil
+}
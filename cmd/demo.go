@@ -0,0 +1,179 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/interlynk-io/sbommv/pkg/demo"
+	"github.com/interlynk-io/sbommv/pkg/engine"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/target/dependencytrack"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// newDemoCmd builds the `sbommv demo` command
+func newDemoCmd() *cobra.Command {
+	demoCmd := &cobra.Command{
+		Use:   "demo",
+		Short: "Run a local Dependency-Track and transfer sample SBOMs into it",
+		Long: `Start a local Dependency-Track with docker compose, transfer a folder of sample SBOMs
+(CycloneDX and SPDX) into it and print what happened. Requires docker.
+
+The compose file and the sample SBOMs are written to --dir. Dependency-Track is left running
+for you to browse the uploaded projects, unless --down is set. The command fails when not
+every sample SBOM reaches Dependency-Track, so it doubles as an end-to-end smoke test.`,
+		Example: `  # try sbommv end to end
+  sbommv demo
+
+  # smoke test, removing the containers and their data afterwards
+  sbommv demo --down`,
+		Args: cobra.NoArgs,
+		RunE: demoRun,
+	}
+
+	demoCmd.Flags().String("dir", "sbommv-demo", "Directory the compose file and sample SBOMs are written to")
+	demoCmd.Flags().Bool("down", false, "Stop and remove the Dependency-Track containers and data after the transfer")
+	demoCmd.Flags().String("timeout", "10m", "How long to wait for Dependency-Track to start (e.g. '300s', '10m')")
+	demoCmd.Flags().BoolP("debug", "D", false, "Enable debug logging")
+
+	return demoCmd
+}
+
+func demoRun(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	debug, _ := cmd.Flags().GetBool("debug")
+	logger.InitLogger(debug, false, logger.WithWriter(cmd.OutOrStdout()))
+	defer logger.DeinitLogger()
+	defer logger.Sync()
+
+	dir, _ := cmd.Flags().GetString("dir")
+	down, _ := cmd.Flags().GetBool("down")
+	timeoutStr, _ := cmd.Flags().GetString("timeout")
+
+	timeoutSeconds, err := utils.ParseDuration(timeoutStr)
+	if err != nil {
+		return fmt.Errorf("invalid flag usage: --timeout=%s: %w", timeoutStr, err)
+	}
+
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("invalid flag usage: --dir: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(logger.WithLogger(context.Background()), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("📁 Writing the compose file and %d sample SBOMs to %s\n", demo.SampleCount(), dir)
+	if err := demo.WriteAssets(dir); err != nil {
+		return fmt.Errorf("failed to write demo files: %w", err)
+	}
+
+	fmt.Println("🐳 Starting Dependency-Track")
+	if err := demo.Compose(ctx, dir, "up", "-d"); err != nil {
+		return err
+	}
+	if down {
+		defer func() {
+			fmt.Println("🧹 Removing Dependency-Track")
+			if err := demo.Compose(context.Background(), dir, "down", "-v"); err != nil {
+				logger.LogError(ctx, err, "Failed to remove Dependency-Track")
+			}
+		}()
+	}
+
+	fmt.Printf("⏳ Waiting for Dependency-Track at %s, the first start takes a few minutes\n", demo.APIURL)
+	about, err := demo.WaitForDTrack(ctx, time.Duration(timeoutSeconds)*time.Second)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Dependency-Track %s is up\n", about.Version)
+
+	apiKey, admin, err := demo.Bootstrap(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to set up Dependency-Track: %w", err)
+	}
+
+	transferCmd, err := newAPITransferCommand(ctx, map[string]any{
+		"input-adapter":  "folder",
+		"in-folder-path": filepath.Join(dir, demo.SBOMDir),
+		"output-adapter": "dtrack",
+		"out-dtrack-url": demo.APIURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	config, err := parseConfig(transferCmd)
+	if err != nil {
+		return err
+	}
+
+	// the demo instance wins over a Dependency-Track configured in the environment or .env
+	viper.Set("DTRACK_API_URL", demo.APIURL)
+	viper.Set("DTRACK_API_KEY", apiKey)
+
+	fmt.Printf("🚚 Transferring %s to Dependency-Track (run %s)\n", filepath.Join(dir, demo.SBOMDir), config.RunID)
+	summary, err := engine.TransferRunWithReport(ctx, transferCmd, config)
+	if err != nil {
+		return fmt.Errorf("demo transfer failed: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("📦 SBOMs transferred: %d of %d (%s)\n", summary.Total.SBOMs, demo.SampleCount(), utils.FormatByteSize(summary.Total.Bytes))
+	formats := make([]string, 0, len(summary.ByFormat))
+	for format := range summary.ByFormat {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	for _, format := range formats {
+		fmt.Printf("   - %s: %d\n", format, summary.ByFormat[format].SBOMs)
+	}
+	for _, failure := range summary.Failures {
+		fmt.Printf("❌ %s: %s\n", failure.File, failure.Reason)
+	}
+
+	projects, err := admin.Project.GetAllByTag(ctx, dependencytrack.RunTag(config.RunID), false, false, dtrack.PageOptions{PageNumber: 1, PageSize: 100})
+	if err != nil {
+		logger.LogError(ctx, err, "Failed to list the projects created by the demo")
+	} else {
+		fmt.Printf("📌 Projects created: %d\n", len(projects.Items))
+		for _, project := range projects.Items {
+			fmt.Printf("   - %s@%s  %s/projects/%s\n", project.Name, project.Version, demo.FrontendURL, project.UUID)
+		}
+	}
+	fmt.Println()
+
+	if summary.Total.SBOMs != demo.SampleCount() {
+		return fmt.Errorf("demo transferred %d of %d sample SBOMs", summary.Total.SBOMs, demo.SampleCount())
+	}
+
+	if !down {
+		fmt.Printf("🌐 Browse the projects at %s (user %q, password %q)\n", demo.FrontendURL, "admin", demo.Password)
+		fmt.Printf("🛑 Stop Dependency-Track with: docker compose --project-directory %s down -v\n", dir)
+	}
+	return nil
+}
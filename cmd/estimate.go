@@ -0,0 +1,107 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/engine"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// newEstimateCmd builds the `sbommv estimate` command
+func newEstimateCmd() *cobra.Command {
+	estimateCmd := &cobra.Command{
+		Use:   "estimate",
+		Short: "Estimate the scope of a transfer without downloading SBOMs",
+		Long: `Count the SBOMs a transfer would fetch (release assets, S3 objects or folder files matching
+SBOM naming conventions), their estimated total size and what they'd become at the destination,
+without downloading any SBOM. Use it to sanity-check the scope of a big transfer before running it.`,
+		Example: `  # all release SBOMs of an organization
+  sbommv estimate --input-adapter=github --in-github-url="https://github.com/interlynk-io" --in-github-version="*" --output-adapter=dtrack
+
+  # SBOMs under an S3 prefix
+  sbommv estimate --input-adapter=s3 --in-s3-bucket-name="sboms" --in-s3-prefix="prod" --output-adapter=folder`,
+		Args: cobra.NoArgs,
+		RunE: estimateRun,
+	}
+
+	estimateCmd.Flags().BoolP("debug", "D", false, "Enable debug logging")
+	estimateCmd.Flags().String("input-adapter", "", "Input adapter type (github, folder, s3, azblob, gcs, harbor, ecr, oci, interlynk), comma-separated to read SBOMs from several")
+	estimateCmd.Flags().String("output-adapter", "", "Output adapter type the SBOMs would go to (folder, s3, azblob, gcs, dtrack, interlynk), comma-separated for several, optional")
+	estimateCmd.Flags().StringSlice("include-formats", nil, "Only count SBOMs of these formats")
+	estimateCmd.Flags().StringSlice("exclude-formats", nil, "Don't count SBOMs of these formats")
+
+	adapter.RegisterEstimateFlags(estimateCmd)
+
+	return estimateCmd
+}
+
+func estimateRun(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	debug, _ := cmd.Flags().GetBool("debug")
+	logger.InitLogger(debug, false, logger.WithWriter(cmd.OutOrStdout()))
+	defer logger.DeinitLogger()
+	defer logger.Sync()
+
+	ctx := logger.WithLogger(context.Background())
+
+	initConfig()
+
+	inputType, _ := cmd.Flags().GetString("input-adapter")
+	outputType, _ := cmd.Flags().GetString("output-adapter")
+	includeFormats, _ := cmd.Flags().GetStringSlice("include-formats")
+	excludeFormats, _ := cmd.Flags().GetStringSlice("exclude-formats")
+
+	missingFlags := []string{}
+	invalidFlags := []string{}
+	if inputType == "" {
+		missingFlags = append(missingFlags, "--input-adapter")
+	}
+
+	validOutputAdapter := map[string]bool{"interlynk": true, "folder": true, "dtrack": true, "s3": true, "azblob": true, "gcs": true}
+	for _, output := range (types.Config{DestinationAdapter: outputType}).DestinationAdapters() {
+		if !validOutputAdapter[output] {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: folder, s3, azblob, gcs, dtrack, interlynk)", "--output-adapter", output))
+		}
+	}
+
+	formatFilter, err := sbom.ParseFormatFilter(includeFormats, excludeFormats)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--include-formats/--exclude-formats: %v", err))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid flags: %v\n\nUse 'sbommv estimate --help' for usage details.", invalidFlags)
+	}
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing required flags: %v\n\nUse 'sbommv estimate --help' for usage details.", missingFlags)
+	}
+
+	config := types.Config{
+		SourceAdapter:      inputType,
+		DestinationAdapter: outputType,
+		ProcessingStrategy: string(types.FetchSequential),
+		FormatFilter:       formatFilter,
+	}
+
+	return engine.EstimateRun(ctx, cmd, config)
+}
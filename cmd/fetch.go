@@ -0,0 +1,117 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/engine"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// newFetchCmd builds the `sbommv fetch` command
+func newFetchCmd() *cobra.Command {
+	fetchCmd := &cobra.Command{
+		Use:   "fetch",
+		Short: "Download SBOMs from an input adapter, without an output adapter",
+		Long: `Fetch the SBOMs of an input adapter and write them, unchanged, to a local directory under their
+source file names, or to stdout when no directory is given. A thinner alternative to a transfer to
+the folder output adapter: SBOMs aren't converted, validated or enriched.
+
+SBOMs written to stdout follow one another, each ending with a newline; the progress lines go to stderr.`,
+		Example: `  # release SBOMs of a repository into ./sboms
+  sbommv fetch --input-adapter=github --in-github-url="https://github.com/interlynk-io/sbomqs" --output-dir=./sboms
+
+  # an SBOM of a bucket to stdout
+  sbommv fetch --input-adapter=s3 --in-s3-bucket-name="sboms" --in-s3-prefix="app/sbom.cdx.json" | jq .metadata`,
+		Args: cobra.NoArgs,
+		RunE: fetchRun,
+	}
+
+	fetchCmd.Flags().BoolP("debug", "D", false, "Enable debug logging")
+	fetchCmd.Flags().String("input-adapter", "", "Input adapter type (github, folder, s3, azblob, gcs, harbor, ecr, oci, interlynk), comma-separated to read SBOMs from several")
+	fetchCmd.Flags().StringP("output-dir", "o", "", "Directory the SBOMs are written to, created when missing (default: stdout, also with \"-\")")
+	fetchCmd.Flags().StringSlice("include-formats", nil, "Only fetch SBOMs of these formats")
+	fetchCmd.Flags().StringSlice("exclude-formats", nil, "Don't fetch SBOMs of these formats")
+	fetchCmd.Flags().Int("max-iterator-errors", 5, "Consecutive errors fetching SBOMs tolerated before the fetch is aborted (0: unlimited)")
+
+	adapter.RegisterInputFlags(fetchCmd)
+
+	return fetchCmd
+}
+
+func fetchRun(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	if outputDir == "-" {
+		outputDir = ""
+	}
+
+	// logs go to stderr when the SBOMs are written to stdout
+	logWriter := cmd.OutOrStdout()
+	if outputDir == "" {
+		logWriter = cmd.ErrOrStderr()
+	}
+
+	debug, _ := cmd.Flags().GetBool("debug")
+	logger.InitLogger(debug, false, logger.WithWriter(logWriter))
+	defer logger.DeinitLogger()
+	defer logger.Sync()
+
+	ctx := logger.WithLogger(context.Background())
+
+	initConfig()
+
+	inputType, _ := cmd.Flags().GetString("input-adapter")
+	includeFormats, _ := cmd.Flags().GetStringSlice("include-formats")
+	excludeFormats, _ := cmd.Flags().GetStringSlice("exclude-formats")
+	maxIteratorErrors, _ := cmd.Flags().GetInt("max-iterator-errors")
+
+	missingFlags := []string{}
+	invalidFlags := []string{}
+	if inputType == "" {
+		missingFlags = append(missingFlags, "--input-adapter")
+	}
+
+	if maxIteratorErrors < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%d (must be 0 or greater)", "--max-iterator-errors", maxIteratorErrors))
+	}
+
+	formatFilter, err := sbom.ParseFormatFilter(includeFormats, excludeFormats)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--include-formats/--exclude-formats: %v", err))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid flags: %v\n\nUse 'sbommv fetch --help' for usage details.", invalidFlags)
+	}
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing required flags: %v\n\nUse 'sbommv fetch --help' for usage details.", missingFlags)
+	}
+
+	config := types.Config{
+		SourceAdapter:      inputType,
+		ProcessingStrategy: string(types.FetchSequential),
+		MaxIteratorErrors:  maxIteratorErrors,
+		FormatFilter:       formatFilter,
+	}
+
+	return engine.FetchRun(ctx, cmd, config, outputDir)
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// log formats of --log-format
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// addLogFlags adds the flags choosing the format and destination of the log
+func addLogFlags(cmd *cobra.Command) {
+	cmd.Flags().String("log-format", logFormatText, "Log format (text, json)")
+	cmd.Flags().String("log-file", "", "Append the log to this file instead of writing it to stdout")
+}
+
+// initLogger initializes the logger per the --debug and log flags of the command, writing to w
+// unless --log-file is set. Quiet logs only errors to w, a log file still gets the full log.
+// The returned function closes the log file.
+func initLogger(cmd *cobra.Command, w io.Writer, quiet bool) (func(), error) {
+	debug, _ := cmd.Flags().GetBool("debug")
+	format, _ := cmd.Flags().GetString("log-format")
+	logFile, _ := cmd.Flags().GetString("log-file")
+
+	if format != logFormatText && format != logFormatJSON {
+		return nil, fmt.Errorf("invalid flag usage: --log-format=%s (must be %s or %s)", format, logFormatText, logFormatJSON)
+	}
+
+	opts := []logger.Option{logger.WithWriter(w)}
+	closeLog := func() {}
+	if logFile == "" && quiet {
+		opts = append(opts, logger.WithQuiet())
+	}
+	if logFile != "" {
+		f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		opts = append(opts, logger.WithWriter(f), logger.WithoutColor())
+		closeLog = func() { f.Close() }
+	}
+
+	logger.InitLogger(debug, format == logFormatJSON, opts...)
+	return func() {
+		logger.DeinitLogger()
+		closeLog()
+	}, nil
+}
@@ -15,24 +15,76 @@
 package cmd
 
 import (
+	"errors"
 	"os"
 
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/spf13/cobra"
 )
 
-var rootCmd = &cobra.Command{
-	Use:   "sbommv",
-	Short: "sbommv is a CLI tool for transferring SBOMs between systems",
-	Long:  `sbommv helps in transferring SBOMs from GitHub repositories to Interlynk or other systems.`,
+// newRootCmd builds the sbommv command with all its subcommands. Each call returns a new
+// command tree, so flags set by one execution don't carry over to the next.
+func newRootCmd() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:   "sbommv",
+		Short: "sbommv is a CLI tool for transferring SBOMs between systems",
+		Long:  `sbommv helps in transferring SBOMs from GitHub repositories to Interlynk or other systems.`,
+	}
+	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+
+	rootCmd.AddCommand(
+		newTransferCmd(),
+		newValidateCmd(),
+		newFetchCmd(),
+		newUploadCmd(),
+		newEstimateCmd(),
+		newCleanupCmd(),
+		newConfigCmd(),
+		newAdaptersCmd(),
+		newServeCmd(),
+		newStatusCmd(),
+		newDemoCmd(),
+		newVersionCmd(),
+	)
+	return rootCmd
 }
 
+// exit codes, distinguishing an aborted transfer and the class of an error from ordinary
+// failures, for CI to tell them apart without parsing the log
+const (
+	exitCodeFailure        = 1
+	exitCodeIteratorBudget = 3 // too many consecutive errors fetching SBOMs
+	exitCodeAuth           = 4 // credentials missing or rejected by the source or destination
+	exitCodeNotFound       = 5 // no SBOMs found, or the repository, bucket or project doesn't exist
+	exitCodeRateLimit      = 6 // API rate limit exceeded, or too low for the transfer
+	exitCodeValidation     = 7 // SBOMs failing validation
+	exitCodeUnreachable    = 8 // source or destination unreachable or unavailable
+)
+
 func Execute() {
-	err := rootCmd.Execute()
-	if err != nil {
-		os.Exit(1)
+	if err := newRootCmd().Execute(); err != nil {
+		os.Exit(exitCode(err))
 	}
 }
 
-func init() {
-	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+// exitCode returns the exit code of the error a command failed with
+func exitCode(err error) int {
+	if errors.Is(err, iterator.ErrErrorBudgetExhausted) {
+		return exitCodeIteratorBudget
+	}
+
+	switch errdefs.KindOf(err) {
+	case errdefs.KindAuth:
+		return exitCodeAuth
+	case errdefs.KindNotFound:
+		return exitCodeNotFound
+	case errdefs.KindRateLimit:
+		return exitCodeRateLimit
+	case errdefs.KindValidation:
+		return exitCodeValidation
+	case errdefs.KindUnreachable:
+		return exitCodeUnreachable
+	}
+	return exitCodeFailure
 }
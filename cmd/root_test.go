@@ -0,0 +1,58 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	base := errors.New("failed")
+	limited := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	limited.Header.Set("X-RateLimit-Remaining", "0")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"other", base, exitCodeFailure},
+		{"iterator budget", fmt.Errorf("fetching SBOMs: %w", iterator.ErrErrorBudgetExhausted), exitCodeIteratorBudget},
+		{"iterator budget over auth", errors.Join(errdefs.Auth(base), iterator.ErrErrorBudgetExhausted), exitCodeIteratorBudget},
+		{"auth", fmt.Errorf("listing releases: %w", errdefs.Auth(base)), exitCodeAuth},
+		{"status 401", &retry.HTTPError{StatusCode: http.StatusUnauthorized}, exitCodeAuth},
+		{"not found", errdefs.NotFound(base), exitCodeNotFound},
+		{"rate limit", errdefs.RateLimit(base), exitCodeRateLimit},
+		{"status 429", &retry.HTTPError{StatusCode: http.StatusTooManyRequests}, exitCodeRateLimit},
+		{"GitHub rate limit 403", errdefs.FromResponse(limited, base), exitCodeRateLimit},
+		{"validation", errdefs.Validation(base), exitCodeValidation},
+		{"unreachable", errdefs.Unreachable(base), exitCodeUnreachable},
+		{"network", &net.OpError{Op: "dial", Net: "tcp", Err: base}, exitCodeUnreachable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCode(tt.err))
+		})
+	}
+}
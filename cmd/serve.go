@@ -0,0 +1,432 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/engine"
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// newServeCmd builds the `sbommv serve` command
+func newServeCmd() *cobra.Command {
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve an HTTP API to trigger transfers on demand",
+		Long: `Serve a small HTTP API wrapping the transfer engine:
+
+  POST /transfers        start a transfer, the body holds the transfer flags
+  GET  /transfers/{id}   status and report of a transfer
+  GET  /healthz          liveness check
+
+Credentials (GITHUB_TOKEN, DTRACK_API_KEY, ...) are read from the server's environment.`,
+		Example: `  sbommv serve --addr=127.0.0.1:8090
+
+  curl -X POST localhost:8090/transfers -d '{"flags": {
+    "input-adapter": "github", "in-github-url": "https://github.com/interlynk-io/sbomqs",
+    "output-adapter": "dtrack", "out-dtrack-url": "http://localhost:8081"}}'
+
+  curl localhost:8090/transfers/<id>`,
+		Args: cobra.NoArgs,
+		RunE: serve,
+	}
+
+	serveCmd.Flags().String("addr", "127.0.0.1:8090", "Address the API listens on")
+	serveCmd.Flags().Int("max-running-transfers", 1, "Transfers run at the same time, others wait as pending")
+	serveCmd.Flags().Duration("finished-transfer-ttl", 24*time.Hour, "How long the status of a finished transfer is kept")
+	serveCmd.Flags().BoolP("debug", "D", false, "Enable debug logging")
+	addLogFlags(serveCmd)
+
+	return serveCmd
+}
+
+// transferRequest is the body of POST /transfers. Flags holds the flags of `sbommv transfer`
+// without leading dashes, e.g. {"input-adapter": "folder", "in-folder-recursive": true}.
+type transferRequest struct {
+	Flags map[string]any `json:"flags"`
+}
+
+// Transfer states reported by GET /transfers/{id}
+const (
+	transferPending   = "pending"
+	transferRunning   = "running"
+	transferSucceeded = "succeeded"
+	transferFailed    = "failed"
+)
+
+// transferStatus is the state of a transfer triggered through the API
+type transferStatus struct {
+	ID         string          `json:"id"`
+	Status     string          `json:"status"`
+	Input      string          `json:"input_adapter"`
+	Output     string          `json:"output_adapter"`
+	Error      string          `json:"error,omitempty"`
+	ErrorKind  errdefs.Kind    `json:"error_kind,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+	Report     *report.Summary `json:"report,omitempty"`
+}
+
+// maxFinishedTransfers caps the statuses of finished transfers kept, the oldest are evicted first
+const maxFinishedTransfers = 1000
+
+// transferServer keeps the transfers triggered through the API in memory
+type transferServer struct {
+	ctx         context.Context
+	slots       chan struct{}
+	finishedTTL time.Duration
+	parseMu     sync.Mutex // flag parsing touches process-wide viper state
+	mu          sync.Mutex
+	statuses    map[string]*transferStatus
+	wg          sync.WaitGroup
+}
+
+func serve(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	closeLog, err := initLogger(cmd, cmd.OutOrStdout(), false)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+	defer logger.Sync()
+
+	addr, _ := cmd.Flags().GetString("addr")
+	maxRunning, _ := cmd.Flags().GetInt("max-running-transfers")
+	if maxRunning < 1 {
+		return fmt.Errorf("invalid flag usage: --max-running-transfers=%d (must be 1 or greater)", maxRunning)
+	}
+	finishedTTL, _ := cmd.Flags().GetDuration("finished-transfer-ttl")
+	if finishedTTL <= 0 {
+		return fmt.Errorf("invalid flag usage: --finished-transfer-ttl=%s (must be greater than 0)", finishedTTL)
+	}
+
+	ctx, stop := signal.NotifyContext(logger.WithLogger(context.Background()), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s := &transferServer{
+		ctx:         ctx,
+		slots:       make(chan struct{}, maxRunning),
+		finishedTTL: finishedTTL,
+		statuses:    make(map[string]*transferStatus),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealthz)
+	mux.HandleFunc("POST /transfers", s.handleCreateTransfer)
+	mux.HandleFunc("GET /transfers/{id}", s.handleGetTransfer)
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logger.LogInfo(ctx, "Serving transfer API", "addr", addr)
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("transfer API server failed: %w", err)
+		}
+	case <-ctx.Done():
+		logger.LogInfo(ctx, "Shutting down transfer API, cancelling running transfers")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.LogError(ctx, err, "Failed to shut down transfer API cleanly")
+		}
+	}
+
+	s.wg.Wait()
+	return nil
+}
+
+func (s *transferServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *transferServer) handleCreateTransfer(w http.ResponseWriter, r *http.Request) {
+	var req transferRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	decoder.UseNumber()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	transferCmd, err := newAPITransferCommand(s.ctx, req.Flags)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.parseMu.Lock()
+	config, err := parseConfig(transferCmd)
+	s.parseMu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// the folder adapters would read and write the server's own filesystem
+	folder := string(types.FolderAdapterType)
+	if slices.Contains(config.SourceAdapters(), folder) || slices.Contains(config.DestinationAdapters(), folder) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("the %s adapter is not supported through the API", folder))
+		return
+	}
+	// API transfers run side by side and can't be resumed, they keep no checkpoint
+	config.CheckpointFile = ""
+
+	status := &transferStatus{
+		ID:        config.RunID,
+		Status:    transferPending,
+		Input:     config.SourceAdapter,
+		Output:    config.DestinationAdapter,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	s.evictFinished(status.CreatedAt)
+	if _, exists := s.statuses[status.ID]; exists {
+		s.mu.Unlock()
+		writeError(w, http.StatusConflict, fmt.Errorf("transfer %s already exists", status.ID))
+		return
+	}
+	s.statuses[status.ID] = status
+	snapshot := *status
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		select {
+		case s.slots <- struct{}{}:
+			defer func() { <-s.slots }()
+		case <-s.ctx.Done():
+			s.finish(status.ID, report.Summary{}, s.ctx.Err())
+			return
+		}
+
+		s.update(status.ID, func(st *transferStatus) {
+			now := time.Now().UTC()
+			st.Status = transferRunning
+			st.StartedAt = &now
+		})
+		logger.LogInfo(s.ctx, "Transfer started", "id", status.ID, "input", config.SourceAdapter, "output", config.DestinationAdapter)
+
+		summary, err := engine.TransferRunWithReport(s.ctx, transferCmd, config)
+		s.finish(status.ID, summary, err)
+	}()
+
+	writeJSON(w, http.StatusAccepted, snapshot)
+}
+
+func (s *transferServer) handleGetTransfer(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	s.mu.Lock()
+	status, ok := s.statuses[id]
+	var snapshot transferStatus
+	if ok {
+		snapshot = *status
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("transfer %s not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+// evictFinished drops the statuses of transfers finished longer than the TTL ago, and the
+// oldest finished ones beyond maxFinishedTransfers. Pending and running transfers are kept.
+// The caller holds s.mu.
+func (s *transferServer) evictFinished(now time.Time) {
+	var finished []*transferStatus
+	for id, status := range s.statuses {
+		if status.FinishedAt == nil {
+			continue
+		}
+		if now.Sub(*status.FinishedAt) > s.finishedTTL {
+			delete(s.statuses, id)
+			continue
+		}
+		finished = append(finished, status)
+	}
+
+	if len(finished) <= maxFinishedTransfers {
+		return
+	}
+	slices.SortFunc(finished, func(a, b *transferStatus) int { return a.FinishedAt.Compare(*b.FinishedAt) })
+	for _, status := range finished[:len(finished)-maxFinishedTransfers] {
+		delete(s.statuses, status.ID)
+	}
+}
+
+func (s *transferServer) update(id string, fn func(*transferStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.statuses[id])
+}
+
+func (s *transferServer) finish(id string, summary report.Summary, err error) {
+	s.update(id, func(st *transferStatus) {
+		now := time.Now().UTC()
+		st.FinishedAt = &now
+		st.Report = &summary
+		st.Status = transferSucceeded
+		if err != nil {
+			st.Status = transferFailed
+			st.Error = err.Error()
+			st.ErrorKind = errdefs.KindOf(err)
+		}
+	})
+
+	if err != nil {
+		logger.LogError(s.ctx, err, "Transfer failed", "id", id)
+		return
+	}
+	logger.LogInfo(s.ctx, "Transfer finished", "id", id)
+}
+
+// apiTransferFlags are the transfer flags an API request may set. Flags naming files on the
+// server (--config, --errors-file, --report-file, --checkpoint-file, key files), the cache,
+// signing and flags about the process itself (--daemon, --debug, ...) are left out.
+var apiTransferFlags = map[string]bool{
+	"input-adapter":             true,
+	"output-adapter":            true,
+	"run-id":                    true,
+	"dry-run":                   true,
+	"processing-mode":           true,
+	"overwrite":                 true,
+	"retries":                   true,
+	"retry-backoff":             true,
+	"max-concurrent-transfers":  true,
+	"include-formats":           true,
+	"exclude-formats":           true,
+	"include-spec-versions":     true,
+	"exclude-spec-versions":     true,
+	"batch-size":                true,
+	"pipeline-buffer":           true,
+	"max-iterator-errors":       true,
+	"validate":                  true,
+	"spdx-upgrade":              true,
+	"output-format":             true,
+	"conversion-target-version": true,
+	"preflight":                 true,
+	"limit":                     true,
+	"dedup":                     true,
+	"detection":                 true,
+	"verify-signatures":         true,
+	"verify-cert-identity":      true,
+	"verify-cert-oidc-issuer":   true,
+	"merge-per-project":         true,
+	"split-by-component":        true,
+	"enrich-supplier":           true,
+	"enrich-author":             true,
+	"enrich-tool":               true,
+	"enrich-timestamp":          true,
+	"enrich-override":           true,
+}
+
+// apiAdapterFlagPrefixes are the prefixes of the adapter flags an API request may set, those
+// of the adapters reaching remote systems. The folder adapters read and write the server's
+// own filesystem and can't be configured through the API.
+var apiAdapterFlagPrefixes = []string{
+	"in-github-", "in-interlynk-", "in-s3-", "in-gcs-", "in-azblob-", "in-ecr-", "in-harbor-", "in-oci-",
+	"out-dtrack-", "out-interlynk-", "out-s3-", "out-gcs-", "out-azblob-",
+}
+
+// apiFlagAllowed reports whether an API request may set the flag
+func apiFlagAllowed(name string) bool {
+	if apiTransferFlags[name] {
+		return true
+	}
+	// credentials files are read from the server, like the credentials in its environment
+	if strings.HasSuffix(name, "-file") {
+		return false
+	}
+	for _, prefix := range apiAdapterFlagPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// newAPITransferCommand builds a transfer command with the flags of an API request set,
+// so the request goes through the same validation as `sbommv transfer`. Only the flags
+// apiFlagAllowed lets through can be set.
+func newAPITransferCommand(ctx context.Context, flags map[string]any) (*cobra.Command, error) {
+	cmd := &cobra.Command{Use: "transfer"}
+	addTransferFlags(cmd)
+	cmd.SetContext(ctx)
+
+	for name, value := range flags {
+		if !apiFlagAllowed(name) {
+			return nil, fmt.Errorf("flag %q is not supported through the API", name)
+		}
+
+		if err := cmd.Flags().Set(name, flagValue(value)); err != nil {
+			return nil, fmt.Errorf("invalid flag %q: %w", name, err)
+		}
+	}
+	return cmd, nil
+}
+
+// flagValue renders a JSON value as a command line flag value, lists as comma-separated values
+func flagValue(value any) string {
+	if list, ok := value.([]any); ok {
+		values := make([]string, 0, len(list))
+		for _, v := range list {
+			values = append(values, fmt.Sprint(v))
+		}
+		return strings.Join(values, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
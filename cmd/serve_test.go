@@ -0,0 +1,82 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITransferCommandFlags(t *testing.T) {
+	tests := []struct {
+		flag    string
+		value   any
+		allowed bool
+	}{
+		{"input-adapter", "github", true},
+		{"in-github-url", "https://github.com/interlynk-io/sbomqs", true},
+		{"out-dtrack-project-name", "sbomqs", true},
+		{"include-formats", []any{"cyclonedx", "spdx"}, true},
+		{"config", "/etc/sbommv.yaml", false},
+		{"checkpoint-file", "/tmp/checkpoint.db", false},
+		{"out-folder-path", "/srv/sboms", false},
+		{"in-folder-path", "/etc", false},
+		{"in-gcs-credentials-file", "/root/key.json", false},
+		{"errors-file", "/tmp/errors.json", false},
+		{"daemon", true, false},
+		{"sign-key", "/root/cosign.key", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			_, err := newAPITransferCommand(context.Background(), map[string]any{tt.flag: tt.value})
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, "is not supported through the API")
+			}
+		})
+	}
+}
+
+func TestEvictFinishedTransfers(t *testing.T) {
+	now := time.Now().UTC()
+	s := &transferServer{finishedTTL: time.Hour, statuses: make(map[string]*transferStatus)}
+
+	finishedAt := func(ago time.Duration) *time.Time {
+		at := now.Add(-ago)
+		return &at
+	}
+	s.statuses["expired"] = &transferStatus{ID: "expired", Status: transferSucceeded, FinishedAt: finishedAt(2 * time.Hour)}
+	s.statuses["running"] = &transferStatus{ID: "running", Status: transferRunning}
+	for i := 0; i < maxFinishedTransfers+1; i++ {
+		id := fmt.Sprintf("finished-%d", i)
+		// finished-0 finished first
+		s.statuses[id] = &transferStatus{ID: id, Status: transferFailed, FinishedAt: finishedAt(time.Duration(maxFinishedTransfers-i) * time.Second)}
+	}
+
+	s.evictFinished(now)
+
+	require.Len(t, s.statuses, maxFinishedTransfers+1)
+	assert.NotContains(t, s.statuses, "expired")
+	assert.NotContains(t, s.statuses, "finished-0")
+	assert.Contains(t, s.statuses, "finished-1")
+	assert.Contains(t, s.statuses, "running")
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/status"
+	"github.com/spf13/cobra"
+)
+
+// newStatusCmd builds the `sbommv status` command
+func newStatusCmd() *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of a running daemon",
+		Long: `Query a transfer running in daemon mode over its status socket: the repositories, folders or
+buckets it watches and when they were last polled, the SBOMs fetched but not yet uploaded,
+the uploads in flight and the most recent errors.`,
+		Example: `  # daemon started from the current directory
+  sbommv status
+
+  # daemon started with --status-socket=/run/sbommv/status.sock
+  sbommv status --socket=/run/sbommv/status.sock --json`,
+		Args: cobra.NoArgs,
+		RunE: statusRun,
+	}
+
+	statusCmd.Flags().String("socket", status.DefaultSocket, "Status socket of the daemon, its --status-socket")
+	statusCmd.Flags().Bool("json", false, "Print the status as JSON")
+
+	return statusCmd
+}
+
+func statusRun(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	socket, _ := cmd.Flags().GetString("socket")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	s, err := status.Query(cmd.Context(), socket)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(s)
+	}
+
+	printStatus(out, s)
+	return nil
+}
+
+func printStatus(out io.Writer, s status.Status) {
+	now := time.Now()
+
+	fmt.Fprintf(out, "📌 Run %s: %s → %s, running for %s\n", s.RunID, s.Input, s.Output, now.Sub(s.StartedAt).Round(time.Second))
+
+	fmt.Fprintf(out, "\n👀 Watching %d:\n", len(s.Watched))
+	for _, w := range s.Watched {
+		lastPoll := "never polled"
+		if w.LastPoll != nil {
+			lastPoll = fmt.Sprintf("last polled %s ago", now.Sub(*w.LastPoll).Round(time.Second))
+		}
+		fmt.Fprintf(out, "   - %s (%s)\n", w.Name, lastPoll)
+		if w.LastError != "" {
+			fmt.Fprintf(out, "     ❌ %s\n", w.LastError)
+		}
+	}
+
+	fmt.Fprintf(out, "\n📦 Pending SBOMs: %d\n", s.PendingSBOMs)
+	fmt.Fprintf(out, "📤 Uploads in flight: %d, queued: %d\n", s.InFlightUploads, s.QueuedUploads)
+	fmt.Fprintf(out, "📊 Transferred: %d, failed: %d\n", s.Transferred, s.Failed)
+
+	if len(s.RecentErrors) == 0 {
+		return
+	}
+	fmt.Fprintln(out, "\n❌ Recent errors:")
+	for _, e := range s.RecentErrors {
+		fmt.Fprintf(out, "   - %s %s failed: %s", e.Time.Local().Format(time.RFC3339), e.Stage, e.Error)
+		if e.File != "" {
+			fmt.Fprintf(out, " (%s)", e.File)
+		}
+		fmt.Fprintln(out)
+	}
+}
@@ -17,24 +17,37 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
 	"strings"
+	"syscall"
 	"text/template"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/cache"
+	"github.com/interlynk-io/sbommv/pkg/checkpoint"
+	"github.com/interlynk-io/sbommv/pkg/converter"
 	"github.com/interlynk-io/sbommv/pkg/engine"
-	ifolder "github.com/interlynk-io/sbommv/pkg/source/folder"
-	is3 "github.com/interlynk-io/sbommv/pkg/source/s3"
-	"github.com/interlynk-io/sbommv/pkg/target/dependencytrack"
-	ofolder "github.com/interlynk-io/sbommv/pkg/target/folder"
-	os3 "github.com/interlynk-io/sbommv/pkg/target/s3"
-
-	"github.com/interlynk-io/sbommv/pkg/source/github"
-	"github.com/interlynk-io/sbommv/pkg/target/interlynk"
+	"github.com/interlynk-io/sbommv/pkg/progress"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/retry"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/schedule"
+	"github.com/interlynk-io/sbommv/pkg/sign"
+	"github.com/interlynk-io/sbommv/pkg/status"
 	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/interlynk-io/sbommv/pkg/verify"
 
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	version "sigs.k8s.io/release-utils/version"
 )
 
 // FlagData holds information about a flag for template rendering
@@ -45,30 +58,18 @@ type FlagData struct {
 	ValueType string
 }
 
-var transferCmd = &cobra.Command{
-	Use:   "transfer",
-	Short: "Transfer SBOMs between systems",
-	Long:  `Transfer SBOMs from a source system (e.g., GitHub) to a target system (e.g., Interlynk).`,
-	Args:  cobra.NoArgs,
-	RunE:  transferSBOM,
-}
-
-func init() {
-	rootCmd.AddCommand(transferCmd)
-
-	// General Flags
-	transferCmd.Flags().BoolP("daemon", "d", false, "Enable daemon mode")
-	transferCmd.Flags().BoolP("debug", "D", false, "Enable debug logging")
-	transferCmd.Flags().Bool("dry-run", false, "Simulate transfer without executing")
-	transferCmd.Flags().String("processing-mode", "sequential", "Processing strategy (sequential, parallel)")
-	transferCmd.Flags().Bool("overwrite", false, "Overwrite existing SBOMs at destination")
-	transferCmd.Flags().Bool("guide", false, "Show beginner-friendly guide")
-
-	// Input and Output Adapter Flags(both required)
-	transferCmd.Flags().String("input-adapter", "", "Input adapter type (github, folder, s3)")
-	transferCmd.Flags().String("output-adapter", "", "Output adapter type (folder, s3, dtrack, interlynk)")
+// newTransferCmd builds the `sbommv transfer` command
+func newTransferCmd() *cobra.Command {
+	transferCmd := &cobra.Command{
+		Use:   "transfer",
+		Short: "Transfer SBOMs between systems",
+		Long:  `Transfer SBOMs from a source system (e.g., GitHub) to a target system (e.g., Interlynk).`,
+		Args:  cobra.NoArgs,
+		RunE:  transferSBOM,
+	}
 
-	registerAdapterFlags(transferCmd)
+	addTransferFlags(transferCmd)
+	transferCmd.Flags().String("config", "", "YAML or JSON file declaring the transfer flags, flags on the command line take precedence")
 
 	// Define custom template functions
 	funcMap := template.FuncMap{
@@ -109,6 +110,9 @@ Examples:
   sbommv transfer --input-adapter=github --in-github-url="https://github.com/interlynk-io/sbomqs" \
                   --output-adapter=interlynk --out-interlynk-url="http://localhost:3000/lynkapi" --out-interlynk-project-name="sbomqs"
 
+  # Transfer declared in a config file, see 'sbommv config validate'
+  sbommv transfer --config=transfer.yaml
+
 General Flags:
 {{- range .Flags}}
 {{- if and (not (or (prefix .Name "in-") (prefix .Name "out-"))) (not (eq .Name "input-adapter")) (not (eq .Name "output-adapter"))}}
@@ -117,7 +121,7 @@ General Flags:
 {{- end}}
 
 Input Adapter Flags(required):
-  --input-adapter string  Input adapter type (github, folder, s3)
+  --input-adapter string  Input adapter type (github, folder, s3, azblob, gcs, harbor, ecr, oci, interlynk), comma-separated to read SBOMs from several
 
   GitHub Input Adapter:
 {{- range .Flags}}
@@ -138,10 +142,52 @@ Input Adapter Flags(required):
 {{- if prefix .Name "in-s3-"}}
     --{{.Name}} {{.ValueType}}  {{.Usage}}
 {{- end}}
+{{- end}}
+
+  Azure Blob Storage Input Adapter:
+{{- range .Flags}}
+{{- if prefix .Name "in-azblob-"}}
+    --{{.Name}} {{.ValueType}}  {{.Usage}}
+{{- end}}
+{{- end}}
+
+  Google Cloud Storage Input Adapter:
+{{- range .Flags}}
+{{- if prefix .Name "in-gcs-"}}
+    --{{.Name}} {{.ValueType}}  {{.Usage}}
+{{- end}}
+{{- end}}
+
+  Harbor Input Adapter:
+{{- range .Flags}}
+{{- if prefix .Name "in-harbor-"}}
+    --{{.Name}} {{.ValueType}}  {{.Usage}}
+{{- end}}
+{{- end}}
+
+  ECR Input Adapter:
+{{- range .Flags}}
+{{- if prefix .Name "in-ecr-"}}
+    --{{.Name}} {{.ValueType}}  {{.Usage}}
+{{- end}}
+{{- end}}
+
+  OCI Input Adapter:
+{{- range .Flags}}
+{{- if prefix .Name "in-oci-"}}
+    --{{.Name}} {{if eq .ValueType "bool"}}{{else}}{{.ValueType}}{{end}}  {{.Usage}}
+{{- end}}
+{{- end}}
+
+  Interlynk Input Adapter:
+{{- range .Flags}}
+{{- if prefix .Name "in-interlynk-"}}
+    --{{.Name}} {{.ValueType}}  {{.Usage}}
+{{- end}}
 {{- end}}
 
 Output Adapter Flags(required):
-  --output-adapter string  Output adapter type (folder, s3, dtrack, interlynk)
+  --output-adapter string  Output adapter type (folder, s3, azblob, gcs, dtrack, interlynk), comma-separated to send SBOMs to several
 
   Folder Output Adapter:
 {{- range .Flags}}
@@ -155,6 +201,20 @@ Output Adapter Flags(required):
 {{- if prefix .Name "out-s3-"}}
     --{{.Name}} {{.ValueType}}  {{.Usage}}
 {{- end}}
+{{- end}}
+
+  Azure Blob Storage Output Adapter:
+{{- range .Flags}}
+{{- if prefix .Name "out-azblob-"}}
+    --{{.Name}} {{if eq .ValueType "bool"}}{{else}}{{.ValueType}}{{end}}  {{.Usage}}
+{{- end}}
+{{- end}}
+
+  Google Cloud Storage Output Adapter:
+{{- range .Flags}}
+{{- if prefix .Name "out-gcs-"}}
+    --{{.Name}} {{if eq .ValueType "bool"}}{{else}}{{.ValueType}}{{end}}  {{.Usage}}
+{{- end}}
 {{- end}}
 
   Dependency Track Output Adapter:
@@ -208,36 +268,81 @@ Run 'sbommv transfer --guide' for a beginner-friendly guide or visit https://git
 			fmt.Fprintf(cmd.ErrOrStderr(), "Error rendering help template: %v\n", err)
 		}
 	})
-}
 
-// registerAdapterFlags dynamically adds flags for the selected adapters after flag parsing
-func registerAdapterFlags(cmd *cobra.Command) {
-	// Register GitHub Adapter Flags
-	githubAdapter := &github.GitHubAdapter{}
-	githubAdapter.AddCommandParams(cmd)
-
-	// Register Input Folder Adapter Flags
-	folderInputAdapter := &ifolder.FolderAdapter{}
-	folderInputAdapter.AddCommandParams(cmd)
+	return transferCmd
+}
 
-	// Register Input S3 Adapter Flags
-	s3InputAdapter := &is3.S3Adapter{}
-	s3InputAdapter.AddCommandParams(cmd)
+// addTransferFlags adds the general and adapter flags of a transfer to the command
+func addTransferFlags(cmd *cobra.Command) {
+	// the defaults of transfers embedded through engine.Run too
+	defaults := engine.DefaultConfig()
 
-	// Register Output Interlynk Adapter Flags
-	interlynkAdapter := &interlynk.InterlynkAdapter{}
-	interlynkAdapter.AddCommandParams(cmd)
+	// General Flags
+	cmd.Flags().BoolP("daemon", "d", false, "Enable daemon mode")
+	cmd.Flags().BoolP("debug", "D", false, "Enable debug logging")
+	addLogFlags(cmd)
+	cmd.Flags().BoolP("quiet", "q", false, "Only print the summary of the transfer and errors")
+	cmd.Flags().Bool("dry-run", false, "Simulate transfer without executing")
+	cmd.Flags().String("processing-mode", defaults.ProcessingStrategy, "Processing strategy (sequential, parallel)")
+	cmd.Flags().Bool("overwrite", false, "Overwrite existing SBOMs at destination")
+	cmd.Flags().Bool("guide", false, "Show beginner-friendly guide")
+	cmd.Flags().String("run-id", "", "Identifier for this transfer run, attached to logs and uploaded SBOMs (default: generated UUID)")
+	cmd.Flags().Int("retries", defaults.Retries, "Retries of an upload failing with a transient error (network failure, HTTP 429 or 5xx), 0 disables retrying")
+	cmd.Flags().String("retry-backoff", defaults.RetryBackoff.String(), "Wait before the first retry of an upload, doubled for each next one, e.g. '500ms', '2s' (a longer Retry-After from the server wins)")
+	cmd.Flags().Int("max-concurrent-transfers", 0, "Maximum SBOM uploads in flight across all workers, extra uploads are queued (default: 0, unlimited)")
+	cmd.Flags().StringSlice("include-formats", nil, "Only transfer SBOMs of these formats, e.g. cyclonedx-json,spdx (default: all)")
+	cmd.Flags().StringSlice("exclude-formats", nil, "Skip SBOMs of these formats, e.g. spdx-tag")
+	cmd.Flags().StringSlice("include-spec-versions", nil, "Only transfer SBOMs of these spec versions, e.g. 1.5,cyclonedx-1.6,spdx-2.3 (default: all)")
+	cmd.Flags().StringSlice("exclude-spec-versions", nil, "Skip SBOMs of these spec versions, e.g. cyclonedx-1.3,spdx-2.2")
+	cmd.Flags().Int("batch-size", 0, "Upload SBOMs in batches of this size to output adapters that support it (0 disables batching)")
+	cmd.Flags().Int("pipeline-buffer", defaults.PipelineBuffer, "SBOMs fetched and processed ahead of the uploads, fetching waits once the buffer is full (0 fetches each SBOM when it is uploaded)")
+	cmd.Flags().String("schedule", "", "Repeat the transfer on a cron schedule, e.g. '0 2 * * *' or '@hourly' (runs until interrupted)")
+	cmd.Flags().Int("max-iterator-errors", defaults.MaxIteratorErrors, "Consecutive errors fetching SBOMs tolerated before the transfer is aborted (0: unlimited)")
+	cmd.Flags().String("errors-file", "", "Write every SBOM that failed to transfer, with its stage and error, to this JSON file")
+	cmd.Flags().String("report-file", "", "Write a JSON report of every SBOM of the transfer, with its source, destination, format, conversion and status, to this file")
+	cmd.Flags().String("validate", "", "Validate SBOMs against their spec schema before transfer: skip invalid SBOMs (skip) or stop the transfer at the first one (fail)")
+	cmd.Flags().Lookup("validate").NoOptDefVal = string(types.ValidationSkip)
+	cmd.Flags().String("spdx-upgrade", string(types.SPDXUpgradeOn), "Upgrade SPDX 2.2 documents to SPDX 2.3 before converting them: on, off (fail their conversion instead), or diff (log every field changed)")
+	cmd.Flags().String("output-format", string(types.OutputFormatOriginal), "Spec SBOMs are converted to before upload: original (keep their spec), spdx or cyclonedx")
+	cmd.Flags().String("conversion-target-version", converter.DefaultCycloneDXTargetVersion, "CycloneDX version SBOMs are converted to: 1.4, 1.5 or 1.6")
+	cmd.Flags().String("status-socket", status.DefaultSocket, "In daemon mode, unix socket serving the daemon's status to 'sbommv status' (empty disables it)")
+	cmd.Flags().String("cache-backend", string(cache.BackendSQLite), "In daemon mode, where watchers cache the SBOMs they transferred across restarts: sqlite (.sbommv/*.db) or file (.sbommv/*.json)")
+	cmd.Flags().Duration("cache-ttl", 0, "In daemon mode, drop cache entries not updated for this long when the daemon starts, e.g. 720h (0: keep them)")
+	cmd.Flags().Bool("resume", false, "Resume an interrupted transfer, skipping the SBOMs its checkpoint records as transferred")
+	cmd.Flags().String("checkpoint-file", "", "File recording the SBOMs transferred so far, for --resume (default: .sbommv/checkpoint_<input>_<output>_<hash of the adapter flags>.db)")
+	cmd.Flags().String("progress", string(types.ProgressAuto), "Show transfer progress: auto (a bar on a terminal, log lines otherwise, off with --debug), bar, log or off")
+	cmd.Flags().String("preflight", string(types.PreflightWarn), "Check the input's API rate limit covers the transfer before fetching: warn, strict (abort when it can't), or off")
+	cmd.Flags().Int("limit", 0, "Stop after this many SBOMs are fetched and handed to the output, e.g. to try a transfer on a few SBOMs of a large org or bucket (0: no limit)")
+	cmd.Flags().Bool("dedup", false, "Skip SBOMs whose content is identical to an SBOM already transferred in this run, e.g. the same SBOM attached to several releases")
+	cmd.Flags().Bool("verify-signatures", false, "Verify the cosign signature (.sig) or in-toto attestation (.intoto.jsonl) published next to each SBOM, skipping SBOMs that don't verify")
+	cmd.Flags().String("verify-key", "", "Public key signatures are verified against, e.g. cosign.pub")
+	cmd.Flags().String("verify-cert-roots", "", "For keyless signatures, PEM certificates of the CAs issuing signing certificates (.pem), e.g. Fulcio's root and intermediate")
+	cmd.Flags().String("verify-cert-identity", "", "For keyless signatures, email or URI the signing certificate must be issued to")
+	cmd.Flags().String("verify-cert-oidc-issuer", "", "For keyless signatures, OIDC issuer the signing certificate must record, e.g. https://token.actions.githubusercontent.com")
+	cmd.Flags().Bool("merge-per-project", false, "Merge the SBOMs of each repository release, or other source namespace and version, into one CycloneDX SBOM before upload, e.g. for one Dependency-Track project per release")
+	cmd.Flags().Bool("split-by-component", false, "Split each SBOM into one CycloneDX SBOM per top-level component before upload, e.g. for one Dependency-Track project per deployable unit of a monorepo")
+	cmd.Flags().Float64("min-ntia-score", 0, "Skip SBOMs scoring below this NTIA minimum elements score, 0 to 10, as uploaded; scores are added to the transfer report (0: don't score)")
+	cmd.Flags().String("sign-key", "", "Private key SBOMs written to folder or S3 outputs are signed with, a signature file (.sig) is written next to each; encrypted cosign keys are decrypted with COSIGN_PASSWORD")
+	cmd.Flags().Bool("sign-keyless", false, "Sign SBOMs written to folder or S3 outputs keyless, with a short-lived certificate (.pem) issued by Fulcio to the identity of an OIDC token")
+	cmd.Flags().String("sign-fulcio-url", "https://fulcio.sigstore.dev", "Fulcio instance keyless signing certificates are requested from")
+	cmd.Flags().String("sign-identity-token", "", "OIDC token keyless signing certificates are issued for (default: SIGSTORE_ID_TOKEN, or requested from GitHub Actions)")
+	cmd.Flags().String("enrich-supplier", "", "Supplier name set on SBOMs lacking one: CycloneDX metadata.supplier and metadata.component.supplier, the supplier of the packages an SPDX document describes")
+	cmd.Flags().StringSlice("enrich-author", nil, "Author set on SBOMs lacking one, repeatable: CycloneDX metadata.authors, SPDX creationInfo.creators")
+	cmd.Flags().Bool("enrich-tool", false, "Record sbommv among the tools of each SBOM: CycloneDX metadata.tools, SPDX creationInfo.creators")
+	cmd.Flags().Bool("enrich-timestamp", false, "Normalize the creation time of SBOMs to UTC RFC 3339, setting it to the time of transfer when missing")
+	cmd.Flags().Bool("enrich-override", false, "Replace the supplier and authors SBOMs already have with --enrich-supplier and --enrich-author")
+	cmd.Flags().String("detection", string(types.DetectionLenient), "How file contents are recognized as SBOMs: lenient, or strict to require the spec's identifying fields and a known version")
 
-	// Register Output Folder Adapter Flags
-	folderOutputAdapter := &ofolder.FolderAdapter{}
-	folderOutputAdapter.AddCommandParams(cmd)
+	// Input and Output Adapter Flags(both required)
+	cmd.Flags().String("input-adapter", "", "Input adapter type (github, folder, s3, azblob, gcs, harbor, ecr, oci, interlynk), comma-separated to read SBOMs from several")
+	cmd.Flags().String("output-adapter", "", "Output adapter type (folder, s3, azblob, gcs, dtrack, interlynk), comma-separated to send SBOMs to several")
 
-	dtrackAdapter := &dependencytrack.DependencyTrackAdapter{}
-	dtrackAdapter.AddCommandParams(cmd)
-	// similarly for all other Adapters
+	registerAdapterFlags(cmd)
+}
 
-	s3OutputAdapter := &os3.S3Adapter{}
-	s3OutputAdapter.AddCommandParams(cmd)
+// registerAdapterFlags adds the flags of every adapter in the adapter catalog
+func registerAdapterFlags(cmd *cobra.Command) {
+	adapter.RegisterFlags(cmd)
 }
 
 func transferSBOM(cmd *cobra.Command, args []string) error {
@@ -268,10 +373,19 @@ Explore examples at https://github.com/interlynk-io/sbommv/tree/main/examples.`)
 	// Suppress automatic usage message for non-flag errors
 	cmd.SilenceUsage = true
 
-	// Initialize logger based on debug flag
-	debug, _ := cmd.Flags().GetBool("debug")
-	logger.InitLogger(debug, false)
-	defer logger.DeinitLogger()
+	if configFile, _ := cmd.Flags().GetString("config"); configFile != "" {
+		if err := applyConfigFile(cmd, configFile); err != nil {
+			return err
+		}
+	}
+
+	// Initialize logger based on debug and log flags, --quiet leaves stdout to the summary
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	closeLog, err := initLogger(cmd, progress.LogWriter(cmd.OutOrStdout()), quiet)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
 	defer logger.Sync()
 
 	ctx := logger.WithLogger(context.Background())
@@ -286,6 +400,28 @@ Explore examples at https://github.com/interlynk-io/sbommv/tree/main/examples.`)
 
 	logger.LogDebug(ctx, "configuration", "value", config)
 
+	// the first Ctrl+C cancels the run, fetchers and uploaders stop after the current SBOM
+	// and report what they transferred; a second one kills the process right away
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if config.Schedule != nil {
+		return engine.ScheduledTransferRun(ctx, cmd, config)
+	}
+
+	if quiet {
+		summary, err := engine.TransferRunWithReport(ctx, cmd, config)
+		// a transfer failing before any SBOM has nothing to sum up, only its error
+		if err == nil || summary.Total.SBOMs > 0 || len(summary.Failures) > 0 {
+			printSummary(cmd.OutOrStdout(), summary)
+		}
+		return err
+	}
+
 	if err := engine.TransferRun(ctx, cmd, config); err != nil {
 		return fmt.Errorf("%w", err)
 	}
@@ -293,6 +429,15 @@ Explore examples at https://github.com/interlynk-io/sbommv/tree/main/examples.`)
 	return nil
 }
 
+// printSummary prints the outcome of a transfer in one line, e.g. for --quiet
+func printSummary(w io.Writer, s report.Summary) {
+	line := fmt.Sprintf("Transferred %d SBOMs (%s) to %s", s.Total.SBOMs, utils.FormatByteSize(s.Total.Bytes), s.Destination)
+	if failed := len(s.Failures); failed > 0 {
+		line += fmt.Sprintf(", %d failed", failed)
+	}
+	fmt.Fprintln(w, line)
+}
+
 func parseConfig(cmd *cobra.Command) (types.Config, error) {
 	// Init configuration
 	initConfig()
@@ -303,9 +448,50 @@ func parseConfig(cmd *cobra.Command) (types.Config, error) {
 	processingMode, _ := cmd.Flags().GetString("processing-mode")
 	daemon, _ := cmd.Flags().GetBool("daemon")
 	overwrite, _ := cmd.Flags().GetBool("overwrite")
-
-	validInputAdapter := map[string]bool{"github": true, "folder": true, "s3": true}
-	validOutputAdapter := map[string]bool{"interlynk": true, "folder": true, "dtrack": true, "s3": true}
+	runID, _ := cmd.Flags().GetString("run-id")
+	maxConcurrentTransfers, _ := cmd.Flags().GetInt("max-concurrent-transfers")
+	retries, _ := cmd.Flags().GetInt("retries")
+	retryBackoffStr, _ := cmd.Flags().GetString("retry-backoff")
+	maxIteratorErrors, _ := cmd.Flags().GetInt("max-iterator-errors")
+	includeFormats, _ := cmd.Flags().GetStringSlice("include-formats")
+	excludeFormats, _ := cmd.Flags().GetStringSlice("exclude-formats")
+	includeSpecVersions, _ := cmd.Flags().GetStringSlice("include-spec-versions")
+	excludeSpecVersions, _ := cmd.Flags().GetStringSlice("exclude-spec-versions")
+	scheduleExpr, _ := cmd.Flags().GetString("schedule")
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+	pipelineBuffer, _ := cmd.Flags().GetInt("pipeline-buffer")
+	errorsFile, _ := cmd.Flags().GetString("errors-file")
+	reportFile, _ := cmd.Flags().GetString("report-file")
+	validate, _ := cmd.Flags().GetString("validate")
+	detection, _ := cmd.Flags().GetString("detection")
+	spdxUpgrade, _ := cmd.Flags().GetString("spdx-upgrade")
+	preflight, _ := cmd.Flags().GetString("preflight")
+	progressFlag, _ := cmd.Flags().GetString("progress")
+	debug, _ := cmd.Flags().GetBool("debug")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	resume, _ := cmd.Flags().GetBool("resume")
+	dedup, _ := cmd.Flags().GetBool("dedup")
+	limit, _ := cmd.Flags().GetInt("limit")
+	verifySignatures, _ := cmd.Flags().GetBool("verify-signatures")
+	signKey, _ := cmd.Flags().GetString("sign-key")
+	signKeyless, _ := cmd.Flags().GetBool("sign-keyless")
+	minNTIAScore, _ := cmd.Flags().GetFloat64("min-ntia-score")
+	mergePerProject, _ := cmd.Flags().GetBool("merge-per-project")
+	splitByComponent, _ := cmd.Flags().GetBool("split-by-component")
+	enrichSupplier, _ := cmd.Flags().GetString("enrich-supplier")
+	enrichAuthors, _ := cmd.Flags().GetStringSlice("enrich-author")
+	enrichTool, _ := cmd.Flags().GetBool("enrich-tool")
+	enrichTimestamp, _ := cmd.Flags().GetBool("enrich-timestamp")
+	enrichOverride, _ := cmd.Flags().GetBool("enrich-override")
+	checkpointFile, _ := cmd.Flags().GetString("checkpoint-file")
+	outputFormat, _ := cmd.Flags().GetString("output-format")
+	conversionTargetVersion, _ := cmd.Flags().GetString("conversion-target-version")
+	statusSocket, _ := cmd.Flags().GetString("status-socket")
+	cacheBackend, _ := cmd.Flags().GetString("cache-backend")
+	cacheTTL, _ := cmd.Flags().GetDuration("cache-ttl")
+
+	validInputAdapter := map[string]bool{"github": true, "folder": true, "s3": true, "azblob": true, "gcs": true, "harbor": true, "ecr": true, "oci": true, "interlynk": true}
+	validOutputAdapter := map[string]bool{"interlynk": true, "folder": true, "dtrack": true, "s3": true, "azblob": true, "gcs": true}
 
 	// Custom validation for required flags
 	missingFlags := []string{}
@@ -315,15 +501,213 @@ func parseConfig(cmd *cobra.Command) (types.Config, error) {
 		missingFlags = append(missingFlags, "--input-adapter")
 	}
 
+	// several inputs, e.g. --input-adapter=folder,s3, are read into a single stream
+	inputTypes := (types.Config{SourceAdapter: inputType}).SourceAdapters()
+	seenInputs := map[string]bool{}
+	for _, input := range inputTypes {
+		if seenInputs[input] {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (%s is listed more than once)", "--input-adapter", inputType, input))
+		}
+		seenInputs[input] = true
+	}
+
 	if outputType == "" {
 		missingFlags = append(missingFlags, "--output-adapter")
 	}
 
+	// several outputs, e.g. --output-adapter=dtrack,s3, each receive every SBOM
+	outputTypes := (types.Config{DestinationAdapter: outputType}).DestinationAdapters()
+	seenOutputs := map[string]bool{}
+	for _, output := range outputTypes {
+		if seenOutputs[output] {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (%s is listed more than once)", "--output-adapter", outputType, output))
+		}
+		seenOutputs[output] = true
+	}
+
 	validModes := map[string]bool{"sequential": true, "parallel": true}
 	if !validModes[processingMode] {
 		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: sequential, parallel)", "--processing-mode", processingMode))
 	}
 
+	if maxConcurrentTransfers < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%d (must be 0 or greater)", "--max-concurrent-transfers", maxConcurrentTransfers))
+	}
+
+	if retries < 0 || retries > retry.MaxRetries {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%d (must be between 0 and %d)", "--retries", retries, retry.MaxRetries))
+	}
+
+	retryBackoff, err := time.ParseDuration(retryBackoffStr)
+	if err != nil || retryBackoff < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be a duration like '500ms', '2s' or '1m')", "--retry-backoff", retryBackoffStr))
+	}
+
+	if maxIteratorErrors < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%d (must be 0 or greater)", "--max-iterator-errors", maxIteratorErrors))
+	}
+
+	if batchSize < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%d (must be 0 or greater)", "--batch-size", batchSize))
+	}
+
+	if pipelineBuffer < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%d (must be 0 or greater)", "--pipeline-buffer", pipelineBuffer))
+	}
+
+	validationMode := types.ValidationMode(validate)
+	if validationMode != types.ValidationOff && validationMode != types.ValidationSkip && validationMode != types.ValidationFail {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: skip, fail)", "--validate", validate))
+	}
+
+	detectionMode := types.DetectionMode(detection)
+	if detectionMode != types.DetectionLenient && detectionMode != types.DetectionStrict {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: lenient, strict)", "--detection", detection))
+	}
+
+	if limit < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%d (must be 0 or greater)", "--limit", limit))
+	}
+
+	if limit > 0 && daemon {
+		invalidFlags = append(invalidFlags, "--limit can't be used with --daemon, daemon mode transfers SBOMs until stopped")
+	}
+
+	if resume && daemon {
+		invalidFlags = append(invalidFlags, "--resume can't be used with --daemon, daemon mode keeps track of transferred SBOMs in its own caches")
+	}
+
+	progressMode := types.ProgressMode(progressFlag)
+	switch progressMode {
+	case types.ProgressAuto:
+		// debug logging is detailed enough, and would scroll the bar away; quiet prints none
+		if debug || quiet {
+			progressMode = types.ProgressOff
+		}
+	case types.ProgressBar, types.ProgressLog, types.ProgressOff:
+	default:
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: auto, bar, log, off)", "--progress", progressFlag))
+	}
+
+	preflightMode := types.PreflightMode(preflight)
+	if preflightMode != types.PreflightWarn && preflightMode != types.PreflightStrict && preflightMode != types.PreflightOff {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: warn, strict, off)", "--preflight", preflight))
+	}
+
+	spdxUpgradeMode := types.SPDXUpgradeMode(spdxUpgrade)
+	if spdxUpgradeMode != types.SPDXUpgradeOn && spdxUpgradeMode != types.SPDXUpgradeOff && spdxUpgradeMode != types.SPDXUpgradeDiff {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: on, off, diff)", "--spdx-upgrade", spdxUpgrade))
+	}
+
+	outputFormatSpec := types.OutputFormat(outputFormat)
+	switch outputFormatSpec {
+	case types.OutputFormatOriginal, types.OutputFormatCycloneDX:
+	case types.OutputFormatSPDX:
+		if seenOutputs[string(types.DtrackAdapterType)] {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (Dependency-Track only accepts CycloneDX)", "--output-format", outputFormat))
+		}
+	default:
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: original, spdx, cyclonedx)", "--output-format", outputFormat))
+	}
+
+	if !converter.IsCycloneDXTargetVersion(conversionTargetVersion) {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: 1.4, 1.5, 1.6)", "--conversion-target-version", conversionTargetVersion))
+	}
+
+	formatFilter, err := sbom.ParseFormatFilter(includeFormats, excludeFormats)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--include-formats/--exclude-formats: %v", err))
+	}
+
+	specVersionFilter, err := sbom.ParseSpecVersionFilter(includeSpecVersions, excludeSpecVersions)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--include-spec-versions/--exclude-spec-versions: %v", err))
+	}
+
+	var sched *schedule.Schedule
+	if scheduleExpr != "" {
+		sched, err = schedule.Parse(scheduleExpr)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--schedule: %v", err))
+		}
+		if daemon {
+			invalidFlags = append(invalidFlags, "--schedule can't be combined with --daemon")
+		}
+		if quiet {
+			invalidFlags = append(invalidFlags, "--quiet can't be combined with --schedule, it prints the summary of a single transfer")
+		}
+	}
+
+	var verifyOptions *verify.Options
+	if verifySignatures {
+		verifyOptions = &verify.Options{}
+		verifyOptions.KeyPath, _ = cmd.Flags().GetString("verify-key")
+		verifyOptions.RootsPath, _ = cmd.Flags().GetString("verify-cert-roots")
+		verifyOptions.Identity, _ = cmd.Flags().GetString("verify-cert-identity")
+		verifyOptions.OIDCIssuer, _ = cmd.Flags().GetString("verify-cert-oidc-issuer")
+		if _, err := verify.New(*verifyOptions); err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--verify-signatures: %v", err))
+		}
+		for _, input := range inputTypes {
+			if input != "folder" && input != "github" {
+				invalidFlags = append(invalidFlags, fmt.Sprintf("--verify-signatures: the %s input doesn't fetch signatures, only folder and github do", input))
+			}
+		}
+	}
+
+	cacheOptions := cache.Options{Backend: cache.Backend(cacheBackend), TTL: cacheTTL}
+	if err := cacheOptions.Validate(); err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--cache-backend/--cache-ttl: %v", err))
+	}
+
+	if mergePerProject && daemon {
+		invalidFlags = append(invalidFlags, "--merge-per-project can't be used with --daemon, SBOMs are merged once all of them are fetched")
+	}
+
+	if mergePerProject && resume {
+		invalidFlags = append(invalidFlags, "--merge-per-project can't be used with --resume, merged SBOMs aren't recorded in the checkpoint")
+	}
+
+	if splitByComponent && mergePerProject {
+		invalidFlags = append(invalidFlags, "--split-by-component can't be used with --merge-per-project")
+	}
+
+	if splitByComponent && resume {
+		invalidFlags = append(invalidFlags, "--split-by-component can't be used with --resume, split SBOMs aren't recorded in the checkpoint")
+	}
+
+	if minNTIAScore < 0 || minNTIAScore > 10 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%g (must be between 0 and 10)", "--min-ntia-score", minNTIAScore))
+	}
+
+	var enrichment *sbom.Enrichment
+	if enrichSupplier != "" || len(enrichAuthors) > 0 || enrichTool || enrichTimestamp {
+		enrichment = &sbom.Enrichment{
+			Supplier:           enrichSupplier,
+			Authors:            enrichAuthors,
+			NormalizeTimestamp: enrichTimestamp,
+			Override:           enrichOverride,
+		}
+		if enrichTool {
+			enrichment.Tool, enrichment.ToolVersion = "sbommv", version.GetVersionInfo().GitVersion
+		}
+	} else if enrichOverride {
+		invalidFlags = append(invalidFlags, "--enrich-override requires --enrich-supplier or --enrich-author")
+	}
+
+	var signOptions *sign.Options
+	if signKey != "" || signKeyless {
+		signOptions = &sign.Options{KeyPath: signKey, Keyless: signKeyless}
+		signOptions.FulcioURL, _ = cmd.Flags().GetString("sign-fulcio-url")
+		signOptions.IdentityToken, _ = cmd.Flags().GetString("sign-identity-token")
+		if _, err := sign.New(*signOptions); err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--sign-key/--sign-keyless: %v", err))
+		}
+		if !slices.ContainsFunc(outputTypes, func(output string) bool { return output == "folder" || output == "s3" }) {
+			invalidFlags = append(invalidFlags, "--sign-key/--sign-keyless: signatures are written by the folder and s3 outputs only")
+		}
+	}
+
 	// Show error message if required flags are missing
 	if len(invalidFlags) > 0 {
 		return types.Config{}, fmt.Errorf("missing required flags: %v\n\nUse 'sbommv transfer --help' for usage details.", invalidFlags)
@@ -334,20 +718,62 @@ func parseConfig(cmd *cobra.Command) (types.Config, error) {
 		return types.Config{}, fmt.Errorf("missing required flags: %v\n\nUse 'sbommv transfer --help' for usage details.", missingFlags)
 	}
 
-	if !validInputAdapter[inputType] {
-		return types.Config{}, fmt.Errorf("input adapter must be one of type: github, folder")
+	for _, input := range inputTypes {
+		if !validInputAdapter[input] {
+			return types.Config{}, fmt.Errorf("input adapter must be one of type: github, folder")
+		}
 	}
 
-	if !validOutputAdapter[outputType] {
-		return types.Config{}, fmt.Errorf("output adapter must be one of type: dtrack, interlynk, folder")
+	for _, output := range outputTypes {
+		if !validOutputAdapter[output] {
+			return types.Config{}, fmt.Errorf("output adapter must be one of type: dtrack, interlynk, folder")
+		}
 	}
 	config := types.Config{
-		SourceAdapter:      inputType,
-		DestinationAdapter: outputType,
-		DryRun:             dr,
-		ProcessingStrategy: processingMode,
-		Daemon:             daemon,
-		Overwrite:          overwrite,
+		SourceAdapter:           strings.Join(inputTypes, ","),
+		DestinationAdapter:      strings.Join(outputTypes, ","),
+		DryRun:                  dr,
+		ProcessingStrategy:      processingMode,
+		Daemon:                  daemon,
+		Overwrite:               overwrite,
+		RunID:                   runID,
+		MaxConcurrentTransfers:  maxConcurrentTransfers,
+		Retries:                 retries,
+		RetryBackoff:            retryBackoff,
+		MaxIteratorErrors:       maxIteratorErrors,
+		FormatFilter:            formatFilter,
+		SpecVersionFilter:       specVersionFilter,
+		Dedup:                   dedup,
+		Limit:                   limit,
+		VerifySignatures:        verifyOptions,
+		Signing:                 signOptions,
+		Enrichment:              enrichment,
+		MinNTIAScore:            minNTIAScore,
+		MergePerProject:         mergePerProject,
+		SplitByComponent:        splitByComponent,
+		Schedule:                sched,
+		BatchSize:               batchSize,
+		PipelineBuffer:          pipelineBuffer,
+		ErrorsFile:              errorsFile,
+		ReportFile:              reportFile,
+		Validate:                validationMode,
+		Detection:               detectionMode,
+		SPDXUpgrade:             spdxUpgradeMode,
+		Preflight:               preflightMode,
+		Progress:                progressMode,
+		OutputFormat:            outputFormatSpec,
+		ConversionTargetVersion: conversionTargetVersion,
+		StatusSocket:            statusSocket,
+		Cache:                   cacheOptions,
+		CheckpointFile:          checkpointFile,
+		Resume:                  resume,
+	}
+	if config.CheckpointFile == "" {
+		config.CheckpointFile = checkpoint.DefaultPath(config.SourceAdapter, config.DestinationAdapter, adapter.Settings(cmd))
+	}
+
+	if config.RunID == "" {
+		config.RunID = uuid.NewString()
 	}
 
 	return config, nil
@@ -18,7 +18,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -115,11 +114,12 @@ func TestUploadGithubAPIToDTrack(t *testing.T) {
 	os.Setenv("DTRACK_API_KEY", "dummy-key")
 	defer os.Unsetenv("DTRACK_API_KEY")
 
-	cmd := rootCmd
+	cmd := newRootCmd()
 	cmd.SetArgs([]string{
 		"transfer",
 		"--input-adapter=github",
 		"--in-github-url=" + githubServer.URL + "/interlynk-io/sbomqs",
+		"--in-github-api-url=" + githubServer.URL,
 		"--output-adapter=dtrack",
 		"--out-dtrack-url=" + dtrackServer.URL,
 		"-D",
@@ -128,27 +128,12 @@ func TestUploadGithubAPIToDTrack(t *testing.T) {
 	outBuf := bytes.NewBuffer(nil)
 	errBuf := bytes.NewBuffer(nil)
 
-	origStdout := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
-		t.Fatalf("Failed to create pipe: %v", err)
-	}
-	os.Stdout = w
-
 	cmd.SetOut(outBuf)
 	cmd.SetErr(errBuf)
 
 	t.Log("Before Execute")
 	err = cmd.Execute()
 
-	w.Close()
-	os.Stdout = origStdout
-
-	_, err = io.Copy(outBuf, r)
-	if err != nil {
-		t.Fatalf("Failed to copy pipe output: %v", err)
-	}
-
 	t.Logf("Execute error: %v", err)
 	t.Log("Output:", outBuf.String())
 	t.Log("Errors:", errBuf.String())
@@ -161,11 +146,11 @@ func TestUploadGithubAPIToDTrack(t *testing.T) {
 	assert.Contains(t, outBuf.String(), `{"OutputAdapter": "dtrack"}`, "Expected Output adapter")
 
 	assert.Contains(t, outBuf.String(), "Fetching SBOM Details", "Expected SBOM fetching message")
-	assert.Contains(t, outBuf.String(), `{"repository": "sbomqs", "owner": "interlynk-io", "repo_url": "https://github.com/interlynk-io/sbomqs"}`, "Expected SBOM fetching details")
+	assert.Contains(t, outBuf.String(), `{"repository": "sbomqs", "owner": "interlynk-io", "repo_url": "`+githubServer.URL+`/interlynk-io/sbomqs"}`, "Expected SBOM fetching details")
 
 	assert.Contains(t, outBuf.String(), "Fetching SBOM via GitHub API", "Expected github fetch method")
-	assert.Contains(t, outBuf.String(), "Total SBOMs fetched from all repos", "Expected SBOM fetched message")
-	assert.Contains(t, outBuf.String(), `{"count": 1}`, "Expected total SBOM fetched details")
+	assert.Contains(t, outBuf.String(), "Fetched SBOMs", "Expected SBOM fetched message")
+	assert.Contains(t, outBuf.String(), `{"repo": "sbomqs", "method": "api", "count": 1}`, "Expected total SBOM fetched details")
 
 	assert.Contains(t, outBuf.String(), "Initializing SBOMs uploading to Dependency-Track sequentially", "Expected upload start")
 
@@ -257,11 +242,12 @@ func TestUploadGithubAPIToDTrack_WithProjectName(t *testing.T) {
 	os.Setenv("DTRACK_API_KEY", "dummy-key")
 	defer os.Unsetenv("DTRACK_API_KEY")
 
-	cmd := rootCmd
+	cmd := newRootCmd()
 	cmd.SetArgs([]string{
 		"transfer",
 		"--input-adapter=github",
 		"--in-github-url=" + githubServer.URL + "/interlynk-io/sbomqs",
+		"--in-github-api-url=" + githubServer.URL,
 		"--in-github-method=api",
 		"--output-adapter=dtrack",
 		"--out-dtrack-url=" + dtrackServer.URL,
@@ -272,27 +258,12 @@ func TestUploadGithubAPIToDTrack_WithProjectName(t *testing.T) {
 	outBuf := bytes.NewBuffer(nil)
 	errBuf := bytes.NewBuffer(nil)
 
-	origStdout := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
-		t.Fatalf("Failed to create pipe: %v", err)
-	}
-	os.Stdout = w
-
 	cmd.SetOut(outBuf)
 	cmd.SetErr(errBuf)
 
 	t.Log("Before Execute")
 	err = cmd.Execute()
 
-	w.Close()
-	os.Stdout = origStdout
-
-	_, err = io.Copy(outBuf, r)
-	if err != nil {
-		t.Fatalf("Failed to copy pipe output: %v", err)
-	}
-
 	t.Logf("Execute error: %v", err)
 	t.Log("Output:", outBuf.String())
 	t.Log("Errors:", errBuf.String())
@@ -395,11 +366,12 @@ func TestUploadGithubAPIToDTrack_WithProjectNameAndVersion(t *testing.T) {
 	os.Setenv("DTRACK_API_KEY", "dummy-key")
 	defer os.Unsetenv("DTRACK_API_KEY")
 
-	cmd := rootCmd
+	cmd := newRootCmd()
 	cmd.SetArgs([]string{
 		"transfer",
 		"--input-adapter=github",
 		"--in-github-url=" + githubServer.URL + "/interlynk-io/sbomqs",
+		"--in-github-api-url=" + githubServer.URL,
 		"--output-adapter=dtrack",
 		"--out-dtrack-url=" + dtrackServer.URL,
 		"--out-dtrack-project-name=test-project",
@@ -410,27 +382,12 @@ func TestUploadGithubAPIToDTrack_WithProjectNameAndVersion(t *testing.T) {
 	outBuf := bytes.NewBuffer(nil)
 	errBuf := bytes.NewBuffer(nil)
 
-	origStdout := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
-		t.Fatalf("Failed to create pipe: %v", err)
-	}
-	os.Stdout = w
-
 	cmd.SetOut(outBuf)
 	cmd.SetErr(errBuf)
 
 	t.Log("Before Execute")
 	err = cmd.Execute()
 
-	w.Close()
-	os.Stdout = origStdout
-
-	_, err = io.Copy(outBuf, r)
-	if err != nil {
-		t.Fatalf("Failed to copy pipe output: %v", err)
-	}
-
 	t.Logf("Execute error: %v", err)
 	t.Log("Output:", outBuf.String())
 	t.Log("Errors:", errBuf.String())
@@ -500,7 +457,7 @@ func TestUploadFolderToDTrack(t *testing.T) {
 	defer os.Unsetenv("DTRACK_API_KEY")
 
 	// Setup command
-	cmd := rootCmd
+	cmd := newRootCmd()
 	cmd.SetArgs([]string{
 		"transfer",
 		"--input-adapter=folder",
@@ -514,31 +471,13 @@ func TestUploadFolderToDTrack(t *testing.T) {
 	outBuf := bytes.NewBuffer(nil)
 	errBuf := bytes.NewBuffer(nil)
 
-	// Create a pipe to capture os.Stdout (logger output)
-	origStdout := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
-		t.Fatalf("Failed to create pipe: %v", err)
-	}
-	os.Stdout = w
-
-	// Redirect command output/error (optional, for completeness)
+	// Capture command output/error, the logger writes to the command output
 	cmd.SetOut(outBuf)
 	cmd.SetErr(errBuf)
 
 	// Run the command
 	t.Log("Before Execute")
-	err = cmd.Execute()
-
-	// Close the writer and restore os.Stdout
-	w.Close()
-	os.Stdout = origStdout
-
-	// Copy pipe contents to outBuf
-	_, err = io.Copy(outBuf, r)
-	if err != nil {
-		t.Fatalf("Failed to copy pipe output: %v", err)
-	}
+	err := cmd.Execute()
 
 	t.Logf("Execute error: %v", err)
 	t.Log("Output:", outBuf.String())
@@ -609,7 +548,7 @@ func TestUploadFolderToDTrack_WithProjectName(t *testing.T) {
 	defer os.Unsetenv("DTRACK_API_KEY")
 
 	// Setup command
-	cmd := rootCmd
+	cmd := newRootCmd()
 	cmd.SetArgs([]string{
 		"transfer",
 		"--input-adapter=folder",
@@ -625,31 +564,13 @@ func TestUploadFolderToDTrack_WithProjectName(t *testing.T) {
 	outBuf := bytes.NewBuffer(nil)
 	errBuf := bytes.NewBuffer(nil)
 
-	// Create a pipe to capture os.Stdout (logger output)
-	origStdout := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
-		t.Fatalf("Failed to create pipe: %v", err)
-	}
-	os.Stdout = w
-
-	// Redirect command output/error (optional, for completeness)
+	// Capture command output/error, the logger writes to the command output
 	cmd.SetOut(outBuf)
 	cmd.SetErr(errBuf)
 
 	// Run the command
 	t.Log("Before Execute")
-	err = cmd.Execute()
-
-	// Close the writer and restore os.Stdout
-	w.Close()
-	os.Stdout = origStdout
-
-	// Copy pipe contents to outBuf
-	_, err = io.Copy(outBuf, r)
-	if err != nil {
-		t.Fatalf("Failed to copy pipe output: %v", err)
-	}
+	err := cmd.Execute()
 
 	t.Logf("Execute error: %v", err)
 	t.Log("Output:", outBuf.String())
@@ -711,7 +632,7 @@ func TestUploadFolderToDTrack_WithProjectNameAndVersion(t *testing.T) {
 	defer os.Unsetenv("DTRACK_API_KEY")
 
 	// Setup command
-	cmd := rootCmd
+	cmd := newRootCmd()
 	cmd.SetArgs([]string{
 		"transfer",
 		"--input-adapter=folder",
@@ -728,31 +649,13 @@ func TestUploadFolderToDTrack_WithProjectNameAndVersion(t *testing.T) {
 	outBuf := bytes.NewBuffer(nil)
 	errBuf := bytes.NewBuffer(nil)
 
-	// Create a pipe to capture os.Stdout (logger output)
-	origStdout := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
-		t.Fatalf("Failed to create pipe: %v", err)
-	}
-	os.Stdout = w
-
-	// Redirect command output/error (optional, for completeness)
+	// Capture command output/error, the logger writes to the command output
 	cmd.SetOut(outBuf)
 	cmd.SetErr(errBuf)
 
 	// Run the command
 	t.Log("Before Execute")
-	err = cmd.Execute()
-
-	// Close the writer and restore os.Stdout
-	w.Close()
-	os.Stdout = origStdout
-
-	// Copy pipe contents to outBuf
-	_, err = io.Copy(outBuf, r)
-	if err != nil {
-		t.Fatalf("Failed to copy pipe output: %v", err)
-	}
+	err := cmd.Execute()
 
 	t.Logf("Execute error: %v", err)
 	t.Log("Output:", outBuf.String())
@@ -0,0 +1,222 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/converter"
+	"github.com/interlynk-io/sbommv/pkg/engine"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/progress"
+	"github.com/interlynk-io/sbommv/pkg/retry"
+	"github.com/interlynk-io/sbommv/pkg/source/files"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// newUploadCmd builds the `sbommv upload` command
+func newUploadCmd() *cobra.Command {
+	uploadCmd := &cobra.Command{
+		Use:   "upload [file...]",
+		Short: "Upload SBOM files, or an SBOM piped to stdin, to an output adapter",
+		Long: `Upload the SBOM files given as arguments to an output adapter, converting them for it like a transfer
+does. With no files, or "-", a single SBOM is read from stdin. A thinner alternative to a transfer from
+the folder input adapter, for scripts pushing one-off SBOMs.`,
+		Example: `  # two SBOMs to Dependency-Track
+  sbommv upload --output-adapter=dtrack --out-dtrack-url="http://localhost:8081" app.cdx.json lib.cdx.json
+
+  # an SBOM generated on the fly
+  syft -o cyclonedx-json . | sbommv upload --output-adapter=dtrack --out-dtrack-url="http://localhost:8081"`,
+		RunE: uploadRun,
+	}
+
+	defaults := engine.DefaultConfig()
+
+	uploadCmd.Flags().BoolP("debug", "D", false, "Enable debug logging")
+	addLogFlags(uploadCmd)
+	uploadCmd.Flags().BoolP("quiet", "q", false, "Only print the summary of the upload and errors")
+	uploadCmd.Flags().Bool("dry-run", false, "Simulate the upload without executing it")
+	uploadCmd.Flags().Bool("overwrite", false, "Overwrite existing SBOMs at destination")
+	uploadCmd.Flags().String("run-id", "", "Identifier for this upload, attached to logs and uploaded SBOMs (default: generated UUID)")
+	uploadCmd.Flags().Int("retries", defaults.Retries, "Retries of an upload failing with a transient error (network failure, HTTP 429 or 5xx), 0 disables retrying")
+	uploadCmd.Flags().String("retry-backoff", defaults.RetryBackoff.String(), "Wait before the first retry of an upload, doubled for each next one, e.g. '500ms', '2s' (a longer Retry-After from the server wins)")
+	uploadCmd.Flags().String("errors-file", "", "Write every SBOM that failed to upload, with its stage and error, to this JSON file")
+	uploadCmd.Flags().String("report-file", "", "Write a JSON report of every SBOM of the upload to this file")
+	uploadCmd.Flags().String("validate", "", "Validate SBOMs against their spec schema before upload: skip invalid SBOMs (skip) or stop at the first one (fail)")
+	uploadCmd.Flags().Lookup("validate").NoOptDefVal = string(types.ValidationSkip)
+	uploadCmd.Flags().String("spdx-upgrade", string(types.SPDXUpgradeOn), "Upgrade SPDX 2.2 documents to SPDX 2.3 before converting them: on, off (fail their conversion instead), or diff (log every field changed)")
+	uploadCmd.Flags().String("output-format", string(types.OutputFormatOriginal), "Spec SBOMs are converted to before upload: original (keep their spec), spdx or cyclonedx")
+	uploadCmd.Flags().String("conversion-target-version", converter.DefaultCycloneDXTargetVersion, "CycloneDX version SBOMs are converted to: 1.4, 1.5 or 1.6")
+	uploadCmd.Flags().String("output-adapter", "", "Output adapter type (folder, s3, azblob, gcs, dtrack, interlynk), comma-separated to send SBOMs to several")
+
+	adapter.RegisterOutputFlags(uploadCmd)
+
+	return uploadCmd
+}
+
+func uploadRun(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	closeLog, err := initLogger(cmd, progress.LogWriter(cmd.OutOrStdout()), quiet)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+	defer logger.Sync()
+
+	ctx := logger.WithLogger(context.Background())
+
+	config, err := parseUploadConfig(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	// the first Ctrl+C cancels the upload after the current SBOM, a second one kills the process
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	input := files.NewFilesAdapter(args, cmd.InOrStdin())
+	summary, err := engine.UploadRun(ctx, cmd, config, input)
+	// an upload failing before any SBOM has nothing to sum up, only its error
+	if quiet && (err == nil || summary.Total.SBOMs > 0 || len(summary.Failures) > 0) {
+		printSummary(cmd.OutOrStdout(), summary)
+	}
+	return err
+}
+
+func parseUploadConfig(cmd *cobra.Command, args []string) (types.Config, error) {
+	initConfig()
+
+	outputType, _ := cmd.Flags().GetString("output-adapter")
+	dr, _ := cmd.Flags().GetBool("dry-run")
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+	runID, _ := cmd.Flags().GetString("run-id")
+	retries, _ := cmd.Flags().GetInt("retries")
+	retryBackoffStr, _ := cmd.Flags().GetString("retry-backoff")
+	errorsFile, _ := cmd.Flags().GetString("errors-file")
+	reportFile, _ := cmd.Flags().GetString("report-file")
+	validate, _ := cmd.Flags().GetString("validate")
+	spdxUpgrade, _ := cmd.Flags().GetString("spdx-upgrade")
+	outputFormat, _ := cmd.Flags().GetString("output-format")
+	conversionTargetVersion, _ := cmd.Flags().GetString("conversion-target-version")
+
+	missingFlags := []string{}
+	invalidFlags := []string{}
+
+	if outputType == "" {
+		missingFlags = append(missingFlags, "--output-adapter")
+	}
+
+	// reading a terminal would wait for an SBOM to be typed in
+	if len(args) == 0 && isTerminal(cmd.InOrStdin()) {
+		missingFlags = append(missingFlags, "SBOM files, or an SBOM piped to stdin")
+	}
+
+	outputTypes := (types.Config{DestinationAdapter: outputType}).DestinationAdapters()
+	seenOutputs := map[string]bool{}
+	for _, output := range outputTypes {
+		if seenOutputs[output] {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (%s is listed more than once)", "--output-adapter", outputType, output))
+		}
+		seenOutputs[output] = true
+	}
+
+	if retries < 0 || retries > retry.MaxRetries {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%d (must be between 0 and %d)", "--retries", retries, retry.MaxRetries))
+	}
+
+	retryBackoff, err := time.ParseDuration(retryBackoffStr)
+	if err != nil || retryBackoff < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be a duration like '500ms', '2s' or '1m')", "--retry-backoff", retryBackoffStr))
+	}
+
+	validationMode := types.ValidationMode(validate)
+	if validationMode != types.ValidationOff && validationMode != types.ValidationSkip && validationMode != types.ValidationFail {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: skip, fail)", "--validate", validate))
+	}
+
+	spdxUpgradeMode := types.SPDXUpgradeMode(spdxUpgrade)
+	if spdxUpgradeMode != types.SPDXUpgradeOn && spdxUpgradeMode != types.SPDXUpgradeOff && spdxUpgradeMode != types.SPDXUpgradeDiff {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: on, off, diff)", "--spdx-upgrade", spdxUpgrade))
+	}
+
+	outputFormatSpec := types.OutputFormat(outputFormat)
+	switch outputFormatSpec {
+	case types.OutputFormatOriginal, types.OutputFormatCycloneDX:
+	case types.OutputFormatSPDX:
+		if seenOutputs[string(types.DtrackAdapterType)] {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (Dependency-Track only accepts CycloneDX)", "--output-format", outputFormat))
+		}
+	default:
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: original, spdx, cyclonedx)", "--output-format", outputFormat))
+	}
+
+	if !converter.IsCycloneDXTargetVersion(conversionTargetVersion) {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: 1.4, 1.5, 1.6)", "--conversion-target-version", conversionTargetVersion))
+	}
+
+	if len(invalidFlags) > 0 {
+		return types.Config{}, fmt.Errorf("invalid flags: %v\n\nUse 'sbommv upload --help' for usage details.", invalidFlags)
+	}
+	if len(missingFlags) > 0 {
+		return types.Config{}, fmt.Errorf("missing required flags: %v\n\nUse 'sbommv upload --help' for usage details.", missingFlags)
+	}
+
+	// uploads of a few given files have no checkpoint to resume from and no progress display
+	config := engine.DefaultConfig()
+	config.SourceAdapter = string(types.FilesAdapterType)
+	config.DestinationAdapter = strings.Join(outputTypes, ",")
+	config.DryRun = dr
+	config.Overwrite = overwrite
+	config.RunID = runID
+	config.Retries = retries
+	config.RetryBackoff = retryBackoff
+	config.ErrorsFile = errorsFile
+	config.ReportFile = reportFile
+	config.Validate = validationMode
+	config.SPDXUpgrade = spdxUpgradeMode
+	config.OutputFormat = outputFormatSpec
+	config.ConversionTargetVersion = conversionTargetVersion
+
+	if config.RunID == "" {
+		config.RunID = uuid.NewString()
+	}
+
+	return config, nil
+}
+
+// isTerminal reports whether r is an interactive terminal rather than a pipe or file
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/engine"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// newValidateCmd builds the `sbommv validate` command
+func newValidateCmd() *cobra.Command {
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate SBOMs against their spec schema",
+		Long: `Fetch the SBOMs of an input adapter and validate each against the schema of its spec version,
+printing a pass/fail line per SBOM with the validation errors. Nothing is transferred.
+
+Supported: CycloneDX 1.4, 1.5 and 1.6 (JSON, XML and protobuf) and SPDX 2.2 and 2.3 (JSON and YAML).
+Other formats and versions are listed as not validated. The command fails when any SBOM is invalid.`,
+		Example: `  # SBOMs in a folder
+  sbommv validate --input-adapter=folder --in-folder-path="sboms" --in-folder-recursive
+
+  # release SBOMs of a repository
+  sbommv validate --input-adapter=github --in-github-url="https://github.com/interlynk-io/sbomqs"`,
+		Args: cobra.NoArgs,
+		RunE: validateRun,
+	}
+
+	validateCmd.Flags().BoolP("debug", "D", false, "Enable debug logging")
+	validateCmd.Flags().String("input-adapter", "", "Input adapter type (github, folder, s3, azblob, gcs, harbor, ecr, oci, interlynk), comma-separated to read SBOMs from several")
+	validateCmd.Flags().StringSlice("include-formats", nil, "Only validate SBOMs of these formats")
+	validateCmd.Flags().StringSlice("exclude-formats", nil, "Don't validate SBOMs of these formats")
+	validateCmd.Flags().Int("max-iterator-errors", 5, "Consecutive errors fetching SBOMs tolerated before validation is aborted (0: unlimited)")
+
+	adapter.RegisterInputFlags(validateCmd)
+
+	return validateCmd
+}
+
+func validateRun(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	debug, _ := cmd.Flags().GetBool("debug")
+	logger.InitLogger(debug, false, logger.WithWriter(cmd.OutOrStdout()))
+	defer logger.DeinitLogger()
+	defer logger.Sync()
+
+	ctx := logger.WithLogger(context.Background())
+
+	initConfig()
+
+	inputType, _ := cmd.Flags().GetString("input-adapter")
+	includeFormats, _ := cmd.Flags().GetStringSlice("include-formats")
+	excludeFormats, _ := cmd.Flags().GetStringSlice("exclude-formats")
+	maxIteratorErrors, _ := cmd.Flags().GetInt("max-iterator-errors")
+
+	missingFlags := []string{}
+	invalidFlags := []string{}
+	if inputType == "" {
+		missingFlags = append(missingFlags, "--input-adapter")
+	}
+
+	if maxIteratorErrors < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%d (must be 0 or greater)", "--max-iterator-errors", maxIteratorErrors))
+	}
+
+	formatFilter, err := sbom.ParseFormatFilter(includeFormats, excludeFormats)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--include-formats/--exclude-formats: %v", err))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid flags: %v\n\nUse 'sbommv validate --help' for usage details.", invalidFlags)
+	}
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing required flags: %v\n\nUse 'sbommv validate --help' for usage details.", missingFlags)
+	}
+
+	config := types.Config{
+		SourceAdapter:      inputType,
+		ProcessingStrategy: string(types.FetchSequential),
+		MaxIteratorErrors:  maxIteratorErrors,
+		FormatFilter:       formatFilter,
+	}
+
+	return engine.ValidateRun(ctx, cmd, config)
+}
@@ -16,9 +16,96 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/spf13/cobra"
 	version "sigs.k8s.io/release-utils/version"
 )
 
-func init() {
-	rootCmd.AddCommand(version.Version())
+// latestReleaseURL is the GitHub API endpoint of the latest sbommv release
+var latestReleaseURL = "https://api.github.com/repos/interlynk-io/sbommv/releases/latest"
+
+// newVersionCmd builds the `sbommv version` command
+func newVersionCmd() *cobra.Command {
+	versionCmd := version.Version()
+	versionCmd.Short = "Print the version, commit, build date and Go version of sbommv"
+	versionCmd.Example = `  sbommv version
+
+  # also check GitHub for a newer release
+  sbommv version --check-update`
+
+	printVersion := versionCmd.RunE
+	versionCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := printVersion(cmd, args); err != nil {
+			return err
+		}
+		if checkUpdate, _ := cmd.Flags().GetBool("check-update"); !checkUpdate {
+			return nil
+		}
+		cmd.SilenceUsage = true
+
+		// keep --json output parseable
+		out := cmd.OutOrStdout()
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			out = cmd.ErrOrStderr()
+		}
+		return checkForUpdate(cmd.Context(), out, version.GetVersionInfo().GitVersion)
+	}
+	versionCmd.Flags().Bool("check-update", false, "Check GitHub releases for a newer version of sbommv")
+
+	return versionCmd
+}
+
+// checkForUpdate compares the running version with the latest sbommv release on GitHub
+func checkForUpdate(ctx context.Context, out io.Writer, current string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return fmt.Errorf("checking for updates: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "sbommv")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("checking for updates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("checking for updates: GitHub returned %s", resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return fmt.Errorf("checking for updates: parsing latest release: %w", err)
+	}
+
+	latestVersion, err := semver.ParseTolerant(release.TagName)
+	if err != nil {
+		return fmt.Errorf("checking for updates: latest release %q is not a semantic version: %w", release.TagName, err)
+	}
+	currentVersion, err := semver.ParseTolerant(current)
+	if err != nil {
+		// development builds carry no release version to compare
+		fmt.Fprintf(out, "\nℹ️  Latest release is %s, this is a development build (%s): %s\n", release.TagName, current, release.HTMLURL)
+		return nil
+	}
+
+	if latestVersion.GT(currentVersion) {
+		fmt.Fprintf(out, "\n⬆️  sbommv %s is available, this is %s: %s\n", release.TagName, current, release.HTMLURL)
+		return nil
+	}
+	fmt.Fprintf(out, "\n✅ sbommv %s is the latest release\n", current)
+	return nil
 }
@@ -0,0 +1,27 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// BatchAdapter is implemented by output adapters that can upload several SBOMs in one go,
+// e.g. through a batch endpoint. With --batch-size the engine hands them chunks of SBOMs
+// instead of the iterator; other adapters keep uploading one SBOM at a time.
+type BatchAdapter interface {
+	UploadBatch(ctx tcontext.TransferMetadata, sboms []*iterator.SBOM) error
+}
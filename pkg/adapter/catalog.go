@@ -0,0 +1,368 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -------------------------------------------------------------------------
+
+package adapter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/monitor"
+	iazblob "github.com/interlynk-io/sbommv/pkg/source/azblob"
+	"github.com/interlynk-io/sbommv/pkg/source/ecr"
+	ifolder "github.com/interlynk-io/sbommv/pkg/source/folder"
+	igcs "github.com/interlynk-io/sbommv/pkg/source/gcs"
+	"github.com/interlynk-io/sbommv/pkg/source/github"
+	"github.com/interlynk-io/sbommv/pkg/source/harbor"
+	iinterlynk "github.com/interlynk-io/sbommv/pkg/source/interlynk"
+	"github.com/interlynk-io/sbommv/pkg/source/oci"
+	is3 "github.com/interlynk-io/sbommv/pkg/source/s3"
+	oazblob "github.com/interlynk-io/sbommv/pkg/target/azblob"
+	"github.com/interlynk-io/sbommv/pkg/target/dependencytrack"
+	ofolder "github.com/interlynk-io/sbommv/pkg/target/folder"
+	ogcs "github.com/interlynk-io/sbommv/pkg/target/gcs"
+	"github.com/interlynk-io/sbommv/pkg/target/interlynk"
+	os3 "github.com/interlynk-io/sbommv/pkg/target/s3"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Capabilities advertised by adapters in the catalog
+const (
+	CapabilityDryRun   = "dry-run"
+	CapabilityDaemon   = "daemon"
+	CapabilityParallel = "parallel"
+	CapabilityCleanup  = "cleanup"
+	CapabilityEstimate = "estimate"
+)
+
+// CredentialInfo describes a credential an adapter reads from the environment or flags
+type CredentialInfo struct {
+	EnvVar      string `json:"env_var,omitempty"`
+	Flag        string `json:"flag,omitempty"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+// FlagInfo describes a single CLI flag exposed by an adapter
+type FlagInfo struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+	Type      string `json:"type"`
+	Default   string `json:"default"`
+	Usage     string `json:"usage"`
+}
+
+// AdapterInfo is the machine-readable description of a registered adapter
+type AdapterInfo struct {
+	Name         string           `json:"name"`
+	Role         string           `json:"role"`
+	Description  string           `json:"description"`
+	Flags        []FlagInfo       `json:"flags"`
+	Credentials  []CredentialInfo `json:"credentials"`
+	Capabilities []string         `json:"capabilities"`
+}
+
+// catalogEntry ties an adapter type and role to its constructor and static metadata
+type catalogEntry struct {
+	adapterType  types.AdapterType
+	role         types.AdapterRole
+	description  string
+	credentials  []CredentialInfo
+	capabilities []string
+	newAdapter   func() Adapter
+}
+
+// catalog lists every adapter sbommv knows about, in input-then-output order
+var catalog = []catalogEntry{
+	{
+		adapterType: types.GithubAdapterType,
+		role:        types.InputAdapterRole,
+		description: "Fetch SBOMs from GitHub repositories (releases, dependency graph API or generated via tools)",
+		credentials: []CredentialInfo{
+			{EnvVar: "GITHUB_TOKEN", Flag: "in-github-token", Description: "GitHub token, raises API rate limits and grants access to private repositories"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &github.GitHubAdapter{} },
+	},
+	{
+		adapterType:  types.FolderAdapterType,
+		role:         types.InputAdapterRole,
+		description:  "Read SBOMs from a local directory",
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &ifolder.FolderAdapter{} },
+	},
+	{
+		adapterType: types.S3AdapterType,
+		role:        types.InputAdapterRole,
+		description: "Read SBOMs from an AWS S3 bucket",
+		credentials: []CredentialInfo{
+			{Flag: "in-s3-access-key", EnvVar: "AWS_ACCESS_KEY_ID", Description: "AWS access key, falls back to the default AWS credential chain"},
+			{Flag: "in-s3-secret-key", EnvVar: "AWS_SECRET_ACCESS_KEY", Description: "AWS secret key, falls back to the default AWS credential chain"},
+			{Flag: "in-s3-session-token", EnvVar: "AWS_SESSION_TOKEN", Description: "AWS session token for temporary credentials"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &is3.S3Adapter{} },
+	},
+	{
+		adapterType: types.AzureBlobAdapterType,
+		role:        types.InputAdapterRole,
+		description: "Read SBOMs from an Azure Blob Storage container",
+		credentials: []CredentialInfo{
+			{Flag: "in-azblob-sas-token", EnvVar: "AZURE_STORAGE_SAS_TOKEN", Description: "Shared access signature, falls back to the default Azure credential chain (e.g. managed identity)"},
+			{Flag: "in-azblob-account-key", EnvVar: "AZURE_STORAGE_KEY", Description: "Storage account key"},
+			{Flag: "in-azblob-connection-string", EnvVar: "AZURE_STORAGE_CONNECTION_STRING", Description: "Storage account connection string"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &iazblob.AzureBlobAdapter{} },
+	},
+	{
+		adapterType: types.GCSAdapterType,
+		role:        types.InputAdapterRole,
+		description: "Read SBOMs from a Google Cloud Storage bucket",
+		credentials: []CredentialInfo{
+			{Flag: "in-gcs-credentials-file", EnvVar: "GOOGLE_APPLICATION_CREDENTIALS", Description: "Service account key file, falls back to Application Default Credentials (e.g. the GCP metadata server)"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &igcs.GCSAdapter{} },
+	},
+	{
+		adapterType: types.HarborAdapterType,
+		role:        types.InputAdapterRole,
+		description: "Fetch the SBOM accessories of artifacts in a Harbor registry",
+		credentials: []CredentialInfo{
+			{EnvVar: "HARBOR_USERNAME", Flag: "in-harbor-username", Description: "Harbor robot account name, needed for private projects"},
+			{EnvVar: "HARBOR_PASSWORD", Flag: "in-harbor-password", Description: "Harbor robot account secret"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &harbor.HarborAdapter{} },
+	},
+	{
+		adapterType: types.ECRAdapterType,
+		role:        types.InputAdapterRole,
+		description: "Collect the SBOMs Amazon Inspector exports for ECR container images",
+		credentials: []CredentialInfo{
+			{Flag: "in-ecr-access-key", EnvVar: "AWS_ACCESS_KEY_ID", Description: "AWS access key, falls back to the default AWS credential chain"},
+			{Flag: "in-ecr-secret-key", EnvVar: "AWS_SECRET_ACCESS_KEY", Description: "AWS secret key, falls back to the default AWS credential chain"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &ecr.ECRAdapter{} },
+	},
+	{
+		adapterType: types.OCIAdapterType,
+		role:        types.InputAdapterRole,
+		description: "Fetch the SBOMs attached to container images in an OCI registry",
+		credentials: []CredentialInfo{
+			{EnvVar: "OCI_USERNAME", Flag: "in-oci-username", Description: "Registry username, needed for private images"},
+			{EnvVar: "OCI_PASSWORD", Flag: "in-oci-password", Description: "Registry password or access token"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &oci.OCIAdapter{} },
+	},
+	{
+		adapterType: types.InterlynkAdapterType,
+		role:        types.InputAdapterRole,
+		description: "Export the SBOMs of project groups from the Interlynk platform",
+		credentials: []CredentialInfo{
+			{EnvVar: "INTERLYNK_SECURITY_TOKEN", Required: true, Description: "Interlynk security token"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &iinterlynk.InterlynkAdapter{} },
+	},
+	{
+		adapterType:  types.FolderAdapterType,
+		role:         types.OutputAdapterRole,
+		description:  "Write SBOMs to a local directory",
+		capabilities: []string{},
+		newAdapter:   func() Adapter { return &ofolder.FolderAdapter{} },
+	},
+	{
+		adapterType: types.S3AdapterType,
+		role:        types.OutputAdapterRole,
+		description: "Upload SBOMs to an AWS S3 bucket",
+		credentials: []CredentialInfo{
+			{Flag: "out-s3-access-key", EnvVar: "AWS_ACCESS_KEY_ID", Description: "AWS access key, falls back to the default AWS credential chain"},
+			{Flag: "out-s3-secret-key", EnvVar: "AWS_SECRET_ACCESS_KEY", Description: "AWS secret key, falls back to the default AWS credential chain"},
+			{Flag: "out-s3-session-token", EnvVar: "AWS_SESSION_TOKEN", Description: "AWS session token for temporary credentials"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &os3.S3Adapter{} },
+	},
+	{
+		adapterType: types.AzureBlobAdapterType,
+		role:        types.OutputAdapterRole,
+		description: "Upload SBOMs to an Azure Blob Storage container",
+		credentials: []CredentialInfo{
+			{Flag: "out-azblob-sas-token", EnvVar: "AZURE_STORAGE_SAS_TOKEN", Description: "Shared access signature, falls back to the default Azure credential chain (e.g. managed identity)"},
+			{Flag: "out-azblob-account-key", EnvVar: "AZURE_STORAGE_KEY", Description: "Storage account key"},
+			{Flag: "out-azblob-connection-string", EnvVar: "AZURE_STORAGE_CONNECTION_STRING", Description: "Storage account connection string"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &oazblob.AzureBlobAdapter{} },
+	},
+	{
+		adapterType: types.GCSAdapterType,
+		role:        types.OutputAdapterRole,
+		description: "Upload SBOMs to a Google Cloud Storage bucket",
+		credentials: []CredentialInfo{
+			{Flag: "out-gcs-credentials-file", EnvVar: "GOOGLE_APPLICATION_CREDENTIALS", Description: "Service account key file, falls back to Application Default Credentials (e.g. the GCP metadata server)"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &ogcs.GCSAdapter{} },
+	},
+	{
+		adapterType: types.DtrackAdapterType,
+		role:        types.OutputAdapterRole,
+		description: "Upload SBOMs to Dependency-Track projects",
+		credentials: []CredentialInfo{
+			{EnvVar: "DTRACK_API_KEY", Required: true, Description: "Dependency-Track API key with BOM_UPLOAD and PROJECT_CREATION_UPLOAD permissions"},
+		},
+		capabilities: []string{CapabilityParallel},
+		newAdapter:   func() Adapter { return &dependencytrack.DependencyTrackAdapter{} },
+	},
+	{
+		adapterType: types.InterlynkAdapterType,
+		role:        types.OutputAdapterRole,
+		description: "Upload SBOMs to the Interlynk platform",
+		credentials: []CredentialInfo{
+			{EnvVar: "INTERLYNK_SECURITY_TOKEN", Required: true, Description: "Interlynk security token"},
+		},
+		capabilities: []string{},
+		newAdapter:   func() Adapter { return &interlynk.InterlynkAdapter{} },
+	},
+}
+
+// RegisterFlags adds the CLI flags of every cataloged adapter to the command
+func RegisterFlags(cmd *cobra.Command) {
+	for _, entry := range catalog {
+		entry.newAdapter().AddCommandParams(cmd)
+	}
+}
+
+// RegisterCleanupFlags adds the CLI flags of every cataloged output adapter supporting cleanup
+func RegisterCleanupFlags(cmd *cobra.Command) {
+	for _, entry := range catalog {
+		adp := entry.newAdapter()
+		if _, ok := adp.(CleanupAdapter); ok && entry.role == types.OutputAdapterRole {
+			adp.AddCommandParams(cmd)
+		}
+	}
+}
+
+// RegisterInputFlags adds the CLI flags of every cataloged input adapter
+func RegisterInputFlags(cmd *cobra.Command) {
+	for _, entry := range catalog {
+		if entry.role == types.InputAdapterRole {
+			entry.newAdapter().AddCommandParams(cmd)
+		}
+	}
+}
+
+// RegisterOutputFlags adds the CLI flags of every cataloged output adapter
+func RegisterOutputFlags(cmd *cobra.Command) {
+	for _, entry := range catalog {
+		if entry.role == types.OutputAdapterRole {
+			entry.newAdapter().AddCommandParams(cmd)
+		}
+	}
+}
+
+// Settings returns the adapter flags set on the command as "name=value", e.g. to tell
+// transfers with other sources or destinations apart
+func Settings(cmd *cobra.Command) []string {
+	var settings []string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if strings.HasPrefix(f.Name, string(types.InputAdapterFlagPrefix)+"-") || strings.HasPrefix(f.Name, string(types.OutputAdapterFlagPrefix)+"-") {
+			settings = append(settings, f.Name+"="+f.Value.String())
+		}
+	})
+	return settings
+}
+
+// RegisterEstimateFlags adds the CLI flags of every cataloged input adapter supporting estimates
+func RegisterEstimateFlags(cmd *cobra.Command) {
+	for _, entry := range catalog {
+		adp := entry.newAdapter()
+		if _, ok := adp.(EstimateAdapter); ok && entry.role == types.InputAdapterRole {
+			adp.AddCommandParams(cmd)
+		}
+	}
+}
+
+// Catalog returns the description of all registered adapters, including the flags
+// each one exposes, the credentials it needs and the capabilities it supports.
+func Catalog() []AdapterInfo {
+	infos := make([]AdapterInfo, 0, len(catalog))
+
+	for _, entry := range catalog {
+		adp := entry.newAdapter()
+
+		// register flags on a scratch command to discover them without touching real commands
+		scratch := &cobra.Command{Use: "scratch"}
+		adp.AddCommandParams(scratch)
+
+		prefix := fmt.Sprintf("%s-%s-", flagPrefix(entry.role), entry.adapterType)
+		flags := []FlagInfo{}
+		scratch.Flags().VisitAll(func(f *pflag.Flag) {
+			if !strings.HasPrefix(f.Name, prefix) {
+				return
+			}
+			flags = append(flags, FlagInfo{
+				Name:      f.Name,
+				Shorthand: f.Shorthand,
+				Type:      f.Value.Type(),
+				Default:   f.DefValue,
+				Usage:     f.Usage,
+			})
+		})
+
+		capabilities := append([]string{CapabilityDryRun}, entry.capabilities...)
+		if _, ok := adp.(monitor.MonitorAdapter); ok && entry.role == types.InputAdapterRole {
+			capabilities = append(capabilities, CapabilityDaemon)
+		}
+		if _, ok := adp.(CleanupAdapter); ok && entry.role == types.OutputAdapterRole {
+			capabilities = append(capabilities, CapabilityCleanup)
+		}
+		if _, ok := adp.(EstimateAdapter); ok && entry.role == types.InputAdapterRole {
+			capabilities = append(capabilities, CapabilityEstimate)
+		}
+		sort.Strings(capabilities)
+
+		credentials := entry.credentials
+		if credentials == nil {
+			credentials = []CredentialInfo{}
+		}
+
+		infos = append(infos, AdapterInfo{
+			Name:         string(entry.adapterType),
+			Role:         string(entry.role),
+			Description:  entry.description,
+			Flags:        flags,
+			Credentials:  credentials,
+			Capabilities: capabilities,
+		})
+	}
+
+	return infos
+}
+
+func flagPrefix(role types.AdapterRole) types.FlagPrefix {
+	if role == types.InputAdapterRole {
+		return types.InputAdapterFlagPrefix
+	}
+	return types.OutputAdapterFlagPrefix
+}
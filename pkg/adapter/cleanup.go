@@ -0,0 +1,26 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// CleanupAdapter is implemented by output adapters that can remove what a transfer run
+// created at the destination, e.g. projects left behind by test runs.
+type CleanupAdapter interface {
+	Cleanup(ctx tcontext.TransferMetadata, opts types.CleanupOptions) error
+}
@@ -17,15 +17,24 @@ package adapter
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/target/dependencytrack"
 	ofolder "github.com/interlynk-io/sbommv/pkg/target/folder"
 
+	iazblob "github.com/interlynk-io/sbommv/pkg/source/azblob"
+	"github.com/interlynk-io/sbommv/pkg/source/ecr"
 	ifolder "github.com/interlynk-io/sbommv/pkg/source/folder"
+	igcs "github.com/interlynk-io/sbommv/pkg/source/gcs"
 	"github.com/interlynk-io/sbommv/pkg/source/github"
+	"github.com/interlynk-io/sbommv/pkg/source/harbor"
+	iinterlynk "github.com/interlynk-io/sbommv/pkg/source/interlynk"
+	"github.com/interlynk-io/sbommv/pkg/source/oci"
 	is3 "github.com/interlynk-io/sbommv/pkg/source/s3"
+	oazblob "github.com/interlynk-io/sbommv/pkg/target/azblob"
+	ogcs "github.com/interlynk-io/sbommv/pkg/target/gcs"
 	os3 "github.com/interlynk-io/sbommv/pkg/target/s3"
 
 	"github.com/interlynk-io/sbommv/pkg/target/interlynk"
@@ -57,64 +66,126 @@ func NewAdapter(ctx tcontext.TransferMetadata, config types.Config) (map[types.A
 	adapters := make(map[types.AdapterRole]Adapter)
 	var inputAdp, outputAdp string
 
-	processingMode := types.ProcessingMode(config.ProcessingStrategy)
-
 	// Initialize Input Adapter
 	if config.SourceAdapter != "" {
 		logger.LogDebug(ctx.Context, "Initializing Input Adapter", "InputAdapter", config.SourceAdapter)
 
-		switch types.AdapterType(config.SourceAdapter) {
-
-		case types.GithubAdapterType:
-			adapters[types.InputAdapterRole] = &github.GitHubAdapter{Role: types.InputAdapterRole, Config: &github.GithubConfig{ProcessingMode: processingMode, Daemon: config.Daemon}}
-			inputAdp = "github"
-
-		case types.FolderAdapterType:
-			adapters[types.InputAdapterRole] = &ifolder.FolderAdapter{Role: types.InputAdapterRole, Config: &ifolder.FolderConfig{ProcessingMode: processingMode, Daemon: config.Daemon}}
-			inputAdp = "folder"
-
-		case types.S3AdapterType:
-			adapters[types.InputAdapterRole] = &is3.S3Adapter{Role: types.InputAdapterRole, ProcessingMode: processingMode}
-			inputAdp = "s3"
-
-		default:
-			return nil, "", "", fmt.Errorf("unsupported input adapter type: %s", config.SourceAdapter)
+		sources := config.SourceAdapters()
+		if len(sources) > 1 {
+			multi := &MultiInputAdapter{}
+			for _, name := range sources {
+				input, err := NewInputAdapter(config, name)
+				if err != nil {
+					return nil, "", "", err
+				}
+				multi.Inputs = append(multi.Inputs, NamedAdapter{Name: name, Adapter: input})
+			}
+			adapters[types.InputAdapterRole] = multi
+		} else {
+			input, err := NewInputAdapter(config, config.SourceAdapter)
+			if err != nil {
+				return nil, "", "", err
+			}
+			adapters[types.InputAdapterRole] = input
 		}
+		inputAdp = strings.Join(sources, ",")
 	}
 
 	// Initialize Output Adapter
 	if config.DestinationAdapter != "" {
 		logger.LogDebug(ctx.Context, "Initializing Output Adapter", "OutputAdapter", config.DestinationAdapter)
 
-		switch types.AdapterType(config.DestinationAdapter) {
+		destinations := config.DestinationAdapters()
+		if len(destinations) > 1 {
+			multi := &MultiOutputAdapter{}
+			for _, name := range destinations {
+				output, err := NewOutputAdapter(config, name)
+				if err != nil {
+					return nil, "", "", err
+				}
+				multi.Outputs = append(multi.Outputs, NamedAdapter{Name: name, Adapter: output})
+			}
+			adapters[types.OutputAdapterRole] = multi
+		} else {
+			output, err := NewOutputAdapter(config, config.DestinationAdapter)
+			if err != nil {
+				return nil, "", "", err
+			}
+			adapters[types.OutputAdapterRole] = output
+		}
+		outputAdp = strings.Join(destinations, ",")
+	}
 
-		case types.FolderAdapterType:
-			adapters[types.OutputAdapterRole] = &ofolder.FolderAdapter{Role: types.OutputAdapterRole, Uploader: &ofolder.SequentialUploader{}, Overwrite: config.Overwrite}
-			outputAdp = "folder"
+	if len(adapters) == 0 {
+		return nil, "", "", fmt.Errorf("no valid adapters found")
+	}
 
-		case types.InterlynkAdapterType:
+	return adapters, inputAdp, outputAdp, nil
+}
 
-			// TODO: hard-coded, processing mode as sequential. Currently it doesn't support parallel processing-mode.
-			adapters[types.OutputAdapterRole] = &interlynk.InterlynkAdapter{Role: types.OutputAdapterRole, ProcessingMode: types.ProcessingMode("sequential"), Overwrite: config.Overwrite}
-			outputAdp = "interlynk"
+// NewInputAdapter initializes the input adapter of the given type
+func NewInputAdapter(config types.Config, name string) (Adapter, error) {
+	processingMode := types.ProcessingMode(config.ProcessingStrategy)
 
-		case types.DtrackAdapterType:
-			adapters[types.OutputAdapterRole] = &dependencytrack.DependencyTrackAdapter{Role: types.OutputAdapterRole, ProcessingMode: processingMode, Overwrite: config.Overwrite}
+	switch types.AdapterType(name) {
 
-			outputAdp = "dtrack"
+	case types.GithubAdapterType:
+		return &github.GitHubAdapter{Role: types.InputAdapterRole, Config: &github.GithubConfig{ProcessingMode: processingMode, Daemon: config.Daemon}}, nil
 
-		case types.S3AdapterType:
-			adapters[types.OutputAdapterRole] = &os3.S3Adapter{Role: types.OutputAdapterRole, ProcessingMode: processingMode}
-			outputAdp = "s3"
+	case types.FolderAdapterType:
+		return &ifolder.FolderAdapter{Role: types.InputAdapterRole, Config: &ifolder.FolderConfig{ProcessingMode: processingMode, Daemon: config.Daemon}}, nil
 
-		default:
-			return nil, "", "", fmt.Errorf("unsupported output adapter type: %s", config.DestinationAdapter)
-		}
-	}
+	case types.S3AdapterType:
+		return &is3.S3Adapter{Role: types.InputAdapterRole, ProcessingMode: processingMode, Daemon: config.Daemon}, nil
 
-	if len(adapters) == 0 {
-		return nil, "", "", fmt.Errorf("no valid adapters found")
+	case types.AzureBlobAdapterType:
+		return &iazblob.AzureBlobAdapter{Role: types.InputAdapterRole, ProcessingMode: processingMode}, nil
+
+	case types.GCSAdapterType:
+		return &igcs.GCSAdapter{Role: types.InputAdapterRole, ProcessingMode: processingMode}, nil
+
+	case types.HarborAdapterType:
+		return &harbor.HarborAdapter{Role: types.InputAdapterRole, ProcessingMode: processingMode}, nil
+
+	case types.ECRAdapterType:
+		return &ecr.ECRAdapter{Role: types.InputAdapterRole, ProcessingMode: processingMode}, nil
+
+	case types.OCIAdapterType:
+		return &oci.OCIAdapter{Role: types.InputAdapterRole, ProcessingMode: processingMode}, nil
+
+	case types.InterlynkAdapterType:
+		return &iinterlynk.InterlynkAdapter{Role: types.InputAdapterRole, ProcessingMode: processingMode}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported input adapter type: %s", name)
 	}
+}
 
-	return adapters, inputAdp, outputAdp, nil
+// NewOutputAdapter initializes the output adapter of the given type
+func NewOutputAdapter(config types.Config, name string) (Adapter, error) {
+	processingMode := types.ProcessingMode(config.ProcessingStrategy)
+
+	switch types.AdapterType(name) {
+
+	case types.FolderAdapterType:
+		return &ofolder.FolderAdapter{Role: types.OutputAdapterRole, Uploader: &ofolder.SequentialUploader{}, Overwrite: config.Overwrite, Daemon: config.Daemon}, nil
+
+	case types.InterlynkAdapterType:
+		return &interlynk.InterlynkAdapter{Role: types.OutputAdapterRole, ProcessingMode: processingMode, Overwrite: config.Overwrite}, nil
+
+	case types.DtrackAdapterType:
+		return &dependencytrack.DependencyTrackAdapter{Role: types.OutputAdapterRole, ProcessingMode: processingMode, Overwrite: config.Overwrite, Daemon: config.Daemon}, nil
+
+	case types.S3AdapterType:
+		return &os3.S3Adapter{Role: types.OutputAdapterRole, ProcessingMode: processingMode}, nil
+
+	case types.AzureBlobAdapterType:
+		return &oazblob.AzureBlobAdapter{Role: types.OutputAdapterRole, ProcessingMode: processingMode}, nil
+
+	case types.GCSAdapterType:
+		return &ogcs.GCSAdapter{Role: types.OutputAdapterRole, ProcessingMode: processingMode, Overwrite: config.Overwrite}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported output adapter type: %s", name)
+	}
 }
@@ -0,0 +1,179 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// NamedAdapter is one output adapter of a transfer with several, and its adapter type
+type NamedAdapter struct {
+	Name    string
+	Adapter Adapter
+}
+
+// MultiOutputAdapter sends every SBOM to several output adapters, e.g. --output-adapter=dtrack,s3.
+// Each output uploads concurrently with its own copy of the transfer context, where "destination"
+// is its adapter type and the report collector is its own, so each destination succeeds or fails
+// on its own and is accounted separately.
+type MultiOutputAdapter struct {
+	Outputs []NamedAdapter
+
+	// Prepare wraps the SBOMs handed to an output, e.g. to convert them for it. Nil hands them as is.
+	Prepare func(ctx tcontext.TransferMetadata, name string, sboms iterator.SBOMIterator) iterator.SBOMIterator
+
+	// Upload hands the SBOMs to an output. Nil calls the output's UploadSBOMs.
+	Upload func(ctx tcontext.TransferMetadata, name string, output Adapter, sboms iterator.SBOMIterator) error
+}
+
+// AddCommandParams adds the flags of every output
+func (m *MultiOutputAdapter) AddCommandParams(cmd *cobra.Command) {
+	for _, output := range m.Outputs {
+		output.Adapter.AddCommandParams(cmd)
+	}
+}
+
+// ParseAndValidateParams parses and validates the flags of every output
+func (m *MultiOutputAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	for _, output := range m.Outputs {
+		if err := output.Adapter.ParseAndValidateParams(cmd); err != nil {
+			return fmt.Errorf("%s: %w", output.Name, err)
+		}
+	}
+	return nil
+}
+
+// FetchSBOMs is not supported, several adapters are only allowed as outputs
+func (m *MultiOutputAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	return nil, fmt.Errorf("multiple adapters are not supported as input")
+}
+
+// UploadSBOMs hands every SBOM to all outputs, uploading to them concurrently. An output failing
+// doesn't stop the others; the returned error joins the errors of all outputs that failed.
+func (m *MultiOutputAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, sboms iterator.SBOMIterator) error {
+	tee := iterator.NewTee(sboms, len(m.Outputs))
+	branches := tee.Branches()
+
+	errs := make([]error, len(m.Outputs))
+	var wg sync.WaitGroup
+	for i, output := range m.Outputs {
+		outputCtx := m.outputContext(ctx, output.Name)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer tee.Release(i)
+
+			if err := m.upload(outputCtx, output, m.prepare(outputCtx, output.Name, branches[i])); err != nil {
+				logger.LogError(ctx.Context, err, "Output failed", "output", output.Name)
+				errs[i] = fmt.Errorf("%s: %w", output.Name, err)
+				return
+			}
+			logger.LogDebug(ctx.Context, "Output completed", "output", output.Name)
+		}()
+	}
+
+	tee.Run(ctx)
+	wg.Wait()
+
+	if err := tee.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// DryRun previews the SBOMs for every output in turn
+func (m *MultiOutputAdapter) DryRun(ctx tcontext.TransferMetadata, sboms iterator.SBOMIterator) error {
+	var all []*iterator.SBOM
+	for {
+		sbom, err := sboms.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		all = append(all, sbom)
+	}
+
+	var errs []error
+	for i, output := range m.Outputs {
+		fmt.Printf("\n🔀 Output %d of %d: %s\n", i+1, len(m.Outputs), output.Name)
+
+		copies := make([]*iterator.SBOM, len(all))
+		for j, sbom := range all {
+			copies[j] = sbom.Copy()
+		}
+
+		outputCtx := m.outputContext(ctx, output.Name)
+		if err := output.Adapter.DryRun(*outputCtx, m.prepare(outputCtx, output.Name, iterator.NewMemoryIterator(copies))); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", output.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Cleanup cleans up every output that supports it
+func (m *MultiOutputAdapter) Cleanup(ctx tcontext.TransferMetadata, opts types.CleanupOptions) error {
+	var errs []error
+	cleaned := 0
+	for _, output := range m.Outputs {
+		cleaner, ok := output.Adapter.(CleanupAdapter)
+		if !ok {
+			logger.LogInfo(ctx.Context, "Output adapter doesn't support cleanup, skipping", "output", output.Name)
+			continue
+		}
+		cleaned++
+		if err := cleaner.Cleanup(*m.outputContext(ctx, output.Name), opts); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", output.Name, err))
+		}
+	}
+	if cleaned == 0 {
+		return fmt.Errorf("none of the output adapters support cleanup")
+	}
+	return errors.Join(errs...)
+}
+
+// outputContext returns the transfer context of one output, with its own destination and report
+func (m *MultiOutputAdapter) outputContext(ctx tcontext.TransferMetadata, name string) *tcontext.TransferMetadata {
+	outputCtx := ctx.Clone()
+	outputCtx.SetDestinationAdapter(name)
+	report.Attach(outputCtx, report.FromContext(ctx).Destination(name))
+	return outputCtx
+}
+
+func (m *MultiOutputAdapter) prepare(ctx *tcontext.TransferMetadata, name string, sboms iterator.SBOMIterator) iterator.SBOMIterator {
+	if m.Prepare == nil {
+		return sboms
+	}
+	return m.Prepare(*ctx, name, sboms)
+}
+
+func (m *MultiOutputAdapter) upload(ctx *tcontext.TransferMetadata, output NamedAdapter, sboms iterator.SBOMIterator) error {
+	if m.Upload == nil {
+		return output.Adapter.UploadSBOMs(*ctx, sboms)
+	}
+	return m.Upload(*ctx, output.Name, output.Adapter, sboms)
+}
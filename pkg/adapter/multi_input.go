@@ -0,0 +1,199 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/monitor"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// MultiInputAdapter reads SBOMs from several input adapters, e.g. --input-adapter=folder,s3,
+// into a single stream. One-shot transfers read the inputs one after the other; daemon mode
+// watches all of them at once. Each SBOM records the input it came from, so outputs name
+// projects as they would for that input alone.
+type MultiInputAdapter struct {
+	Inputs []NamedAdapter
+}
+
+// AddCommandParams adds the flags of every input
+func (m *MultiInputAdapter) AddCommandParams(cmd *cobra.Command) {
+	for _, input := range m.Inputs {
+		input.Adapter.AddCommandParams(cmd)
+	}
+}
+
+// ParseAndValidateParams parses and validates the flags of every input
+func (m *MultiInputAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	for _, input := range m.Inputs {
+		if err := input.Adapter.ParseAndValidateParams(cmd); err != nil {
+			return fmt.Errorf("%s: %w", input.Name, err)
+		}
+	}
+	return nil
+}
+
+// FetchSBOMs chains the SBOMs of the inputs, in order. An input is only fetched from once the
+// previous one is exhausted; an input failing to fetch is reported as an iterator error and skipped.
+func (m *MultiInputAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	iters := make([]iterator.SBOMIterator, 0, len(m.Inputs))
+	for _, input := range m.Inputs {
+		iters = append(iters, &fetchingIterator{input: input, ctx: inputContext(ctx, input.Name)})
+	}
+	return iterator.NewChainIterator(iters...), nil
+}
+
+// Monitor watches every input at once, all of them must support daemon mode
+func (m *MultiInputAdapter) Monitor(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	iters := make([]iterator.SBOMIterator, 0, len(m.Inputs))
+	for _, input := range m.Inputs {
+		ma, ok := input.Adapter.(monitor.MonitorAdapter)
+		if !ok {
+			return nil, fmt.Errorf("input adapter %s does not support daemon mode", input.Name)
+		}
+
+		iter, err := ma.Monitor(*inputContext(ctx, input.Name))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", input.Name, err)
+		}
+		iters = append(iters, &sourceIterator{inner: iter, source: input.Name})
+	}
+	return iterator.NewMergeIterator(iters...), nil
+}
+
+// UploadSBOMs is not supported, several adapters are only allowed as inputs
+func (m *MultiInputAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, sboms iterator.SBOMIterator) error {
+	return fmt.Errorf("multiple adapters are not supported as output")
+}
+
+// DryRun previews the SBOMs of every input with the input's own dry-run
+func (m *MultiInputAdapter) DryRun(ctx tcontext.TransferMetadata, sboms iterator.SBOMIterator) error {
+	bySource := map[string][]*iterator.SBOM{}
+	for {
+		sbom, err := sboms.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		bySource[sbom.Source] = append(bySource[sbom.Source], sbom)
+	}
+
+	var errs []error
+	for i, input := range m.Inputs {
+		fmt.Printf("\n🔀 Input %d of %d: %s\n", i+1, len(m.Inputs), input.Name)
+		if err := input.Adapter.DryRun(*inputContext(ctx, input.Name), iterator.NewMemoryIterator(bySource[input.Name])); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", input.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Estimate lists the candidates of every input that supports estimates
+func (m *MultiInputAdapter) Estimate(ctx tcontext.TransferMetadata) ([]types.SBOMCandidate, error) {
+	var candidates []types.SBOMCandidate
+	estimated := 0
+	for _, input := range m.Inputs {
+		estimator, ok := input.Adapter.(EstimateAdapter)
+		if !ok {
+			logger.LogInfo(ctx.Context, "Input adapter doesn't support estimates, skipping", "input", input.Name)
+			continue
+		}
+		estimated++
+
+		inputCandidates, err := estimator.Estimate(*inputContext(ctx, input.Name))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", input.Name, err)
+		}
+		candidates = append(candidates, inputCandidates...)
+	}
+	if estimated == 0 {
+		return nil, fmt.Errorf("none of the input adapters support estimates")
+	}
+	return candidates, nil
+}
+
+// Preflight checks the rate limits of every input that supports it
+func (m *MultiInputAdapter) Preflight(ctx tcontext.TransferMetadata) ([]types.RateLimitCheck, error) {
+	var checks []types.RateLimitCheck
+	for _, input := range m.Inputs {
+		preflighter, ok := input.Adapter.(PreflightAdapter)
+		if !ok {
+			continue
+		}
+
+		inputChecks, err := preflighter.Preflight(*inputContext(ctx, input.Name))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", input.Name, err)
+		}
+		checks = append(checks, inputChecks...)
+	}
+	return checks, nil
+}
+
+// inputContext returns the transfer context of one input, whose "source" is the input alone
+func inputContext(ctx tcontext.TransferMetadata, name string) *tcontext.TransferMetadata {
+	inputCtx := ctx.Clone()
+	inputCtx.SetSourceAdapter(name)
+	return inputCtx
+}
+
+// fetchingIterator fetches the SBOMs of an input on first use
+type fetchingIterator struct {
+	input NamedAdapter
+	ctx   *tcontext.TransferMetadata
+	iter  iterator.SBOMIterator
+	done  bool
+}
+
+func (fi *fetchingIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
+	if fi.done {
+		return nil, io.EOF
+	}
+
+	if fi.iter == nil {
+		logger.LogInfo(ctx.Context, "Fetching SBOMs from input", "input", fi.input.Name)
+		iter, err := fi.input.Adapter.FetchSBOMs(*fi.ctx)
+		if err != nil {
+			fi.done = true
+			return nil, fmt.Errorf("failed to fetch SBOMs from input %s: %w", fi.input.Name, err)
+		}
+		fi.iter = &sourceIterator{inner: iter, source: fi.input.Name}
+	}
+
+	return fi.iter.Next(ctx)
+}
+
+// sourceIterator records the input adapter its SBOMs came from
+type sourceIterator struct {
+	inner  iterator.SBOMIterator
+	source string
+}
+
+func (si *sourceIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
+	sbom, err := si.inner.Next(ctx)
+	if sbom != nil {
+		sbom.Source = si.source
+	}
+	return sbom, err
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// PreflightAdapter is implemented by input adapters fetching from rate limited APIs. Preflight
+// estimates the API calls a transfer needs, without fetching any SBOM, against the rate limit
+// left to the credentials in use.
+type PreflightAdapter interface {
+	Preflight(ctx tcontext.TransferMetadata) ([]types.RateLimitCheck, error)
+}
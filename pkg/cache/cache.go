@@ -0,0 +1,184 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache is the persistent state of daemon-mode watchers: what they transferred,
+// so restarts don't transfer it again. Each watcher opens a store and keeps its entries
+// in namespaces of its own, on the backend selected with --cache-backend.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// contextKey is the TransferMetadata key under which the engine stores the cache options
+const contextKey = "cache_options"
+
+// DefaultDir is the directory stores are created in
+const DefaultDir = ".sbommv"
+
+// Backend is where a store keeps its entries
+type Backend string
+
+const (
+	BackendSQLite Backend = "sqlite" // an embedded SQLite database, <name>.db
+	BackendFile   Backend = "file"   // a JSON file rewritten as it changes, <name>.json
+)
+
+// ErrUnknownBackend is returned for a backend other than sqlite and file
+var ErrUnknownBackend = errors.New("unknown cache backend")
+
+// Options are how watchers open their stores. The zero Options opens SQLite stores in
+// DefaultDir that keep their entries forever.
+type Options struct {
+	Backend Backend
+	Dir     string        // directory stores are created in, DefaultDir when empty
+	TTL     time.Duration // entries not updated for this long are pruned as a store opens, 0 keeps them
+}
+
+// Store is a persistent key-value store. Keys are unique within a namespace, such as one per
+// adapter or per watched folder; namespaces don't see each other's entries. Stores are
+// safe for concurrent use.
+type Store interface {
+	// Get returns the value of the key, and whether the namespace has it
+	Get(ctx context.Context, namespace, key string) ([]byte, bool, error)
+
+	// Put sets the value of the key, marking the entry as updated now
+	Put(ctx context.Context, namespace, key string, value []byte) error
+
+	// Delete deletes the key, if the namespace has it
+	Delete(ctx context.Context, namespace, key string) error
+
+	// DeletePrefix deletes the key and every key it is a prefix of
+	DeletePrefix(ctx context.Context, namespace, prefix string) error
+
+	// Entries returns the values of every key of the namespace
+	Entries(ctx context.Context, namespace string) (map[string][]byte, error)
+
+	// Prune deletes the entries, of every namespace, last updated before the time, returning
+	// how many it deleted
+	Prune(ctx context.Context, before time.Time) (int, error)
+
+	Close() error
+}
+
+// Attach stores the cache options in the transfer context for watchers to open stores with
+func Attach(ctx *tcontext.TransferMetadata, opts Options) {
+	ctx.WithValue(contextKey, opts)
+}
+
+// FromContext returns the cache options of the transfer, the zero Options when none are attached
+func FromContext(ctx tcontext.TransferMetadata) Options {
+	opts, _ := ctx.Value(contextKey).(Options)
+	return opts
+}
+
+// Validate returns an error for a backend other than sqlite and file, or a negative TTL
+func (o Options) Validate() error {
+	switch o.Backend {
+	case "", BackendSQLite, BackendFile:
+	default:
+		return fmt.Errorf("%w %q, must be sqlite or file", ErrUnknownBackend, o.Backend)
+	}
+	if o.TTL < 0 {
+		return fmt.Errorf("cache TTL %s is negative", o.TTL)
+	}
+	return nil
+}
+
+// Path returns the file of the store name, e.g. .sbommv/cache_dtrack_s3.db
+func (o Options) Path(name string) string {
+	dir := o.Dir
+	if dir == "" {
+		dir = DefaultDir
+	}
+	if o.Backend == BackendFile {
+		return filepath.Join(dir, name+".json")
+	}
+	return filepath.Join(dir, name+".db")
+}
+
+// Open opens, or creates, the store name with the options of the transfer, pruning the
+// entries older than their TTL
+func Open(ctx tcontext.TransferMetadata, name string) (Store, error) {
+	opts := FromContext(ctx)
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	path := opts.Path(name)
+	logger.LogDebug(ctx.Context, "Opening cache", "path", path, "backend", opts.Backend)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	var store Store
+	var err error
+	if opts.Backend == BackendFile {
+		store, err = openFile(path)
+	} else {
+		store, err = openSQLite(ctx.Context, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.TTL > 0 {
+		pruned, err := store.Prune(ctx.Context, time.Now().Add(-opts.TTL))
+		if err != nil {
+			store.Close()
+			return nil, fmt.Errorf("failed to prune cache: %w", err)
+		}
+		logger.LogDebug(ctx.Context, "Pruned expired cache entries", "path", path, "ttl", opts.TTL, "pruned", pruned)
+	}
+	return store, nil
+}
+
+// legacyImporter is implemented by stores reading the tables earlier versions of sbommv kept
+// their caches in, which were SQLite databases with a table per adapter
+type legacyImporter interface {
+	importLegacy(ctx context.Context, namespace, query string, args ...any) (int, error)
+}
+
+// ImportLegacy fills an empty namespace with the key and value rows the query selects from a
+// table of an earlier version's cache, so upgrading doesn't transfer everything again. It
+// imports nothing from a namespace that has entries, a store that isn't SQLite, or a
+// database without the table.
+func ImportLegacy(ctx tcontext.TransferMetadata, store Store, namespace, query string, args ...any) error {
+	importer, ok := store.(legacyImporter)
+	if !ok {
+		return nil
+	}
+
+	entries, err := store.Entries(ctx.Context, namespace)
+	if err != nil || len(entries) > 0 {
+		return err
+	}
+
+	imported, err := importer.importLegacy(ctx.Context, namespace, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to import earlier cache: %w", err)
+	}
+	if imported > 0 {
+		logger.LogInfo(ctx.Context, "Imported entries of earlier cache", "namespace", namespace, "entries", imported)
+	}
+	return nil
+}
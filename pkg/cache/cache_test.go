@@ -0,0 +1,137 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openStore opens the store name of the backend in a temporary directory
+func openStore(t *testing.T, backend Backend, dir string) Store {
+	t.Helper()
+	ctx := tcontext.NewTransferMetadata(context.Background())
+	Attach(ctx, Options{Backend: backend, Dir: dir})
+	store, err := Open(*ctx, "cache_test")
+	require.NoError(t, err)
+	return store
+}
+
+func TestStore(t *testing.T) {
+	for _, backend := range []Backend{BackendSQLite, BackendFile} {
+		t.Run(string(backend), func(t *testing.T) {
+			ctx := context.Background()
+			dir := t.TempDir()
+			store := openStore(t, backend, dir)
+
+			// Put and Get
+			require.NoError(t, store.Put(ctx, "repos", "interlynk-io/sbomqs", []byte("v1.0.0")))
+			require.NoError(t, store.Put(ctx, "repos", "interlynk-io/sbomqs", []byte("v1.1.0")))
+			value, ok, err := store.Get(ctx, "repos", "interlynk-io/sbomqs")
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, "v1.1.0", string(value))
+
+			_, ok, err = store.Get(ctx, "sboms", "interlynk-io/sbomqs")
+			require.NoError(t, err)
+			assert.False(t, ok, "namespaces don't share keys")
+
+			// DeletePrefix deletes the prefix and the keys it is a prefix of, in its namespace only
+			for _, key := range []string{"sbomqs", "sbomqs/v1", "sbomqs/v2", "sbomasm", "sbömqs/v1", "sbömqs/v2", "sböm"} {
+				require.NoError(t, store.Put(ctx, "sboms", key, []byte("1")))
+			}
+			require.NoError(t, store.Put(ctx, "other", "sbomqs/v1", []byte("1")))
+			require.NoError(t, store.DeletePrefix(ctx, "sboms", "sbomqs"))
+			require.NoError(t, store.DeletePrefix(ctx, "sboms", "sbömqs/"))
+
+			entries, err := store.Entries(ctx, "sboms")
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]byte{"sbomasm": []byte("1"), "sböm": []byte("1")}, entries)
+			_, ok, err = store.Get(ctx, "other", "sbomqs/v1")
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			// Prune deletes the entries updated before the time, of every namespace
+			pruned, err := store.Prune(ctx, time.Now().Add(-time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, 0, pruned)
+			pruned, err = store.Prune(ctx, time.Now().Add(time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, 4, pruned)
+			entries, err = store.Entries(ctx, "repos")
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+
+			// entries outlive the store
+			require.NoError(t, store.Put(ctx, "repos", "interlynk-io/sbommv", []byte("v0.1.0")))
+			require.NoError(t, store.Close())
+
+			store = openStore(t, backend, dir)
+			defer store.Close()
+			value, ok, err = store.Get(ctx, "repos", "interlynk-io/sbommv")
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, "v0.1.0", string(value))
+		})
+	}
+}
+
+func TestFileStoreWritesChangesWithoutClose(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := openStore(t, BackendFile, dir)
+	defer store.Close()
+
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, store.Put(ctx, "sboms", key, []byte("1")))
+	}
+
+	// the burst of Puts is written once saveDelay passed
+	require.Eventually(t, func() bool {
+		reopened, err := openFile(filepath.Join(dir, "cache_test.json"))
+		return err == nil && len(reopened.entries["sboms"]) == 3
+	}, 5*saveDelay, saveDelay/10)
+}
+
+func TestImportLegacy(t *testing.T) {
+	ctx := context.Background()
+	store, err := openSQLite(ctx, filepath.Join(t.TempDir(), "cache.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	// caches created before the entries table have nothing to import
+	imported, err := store.importLegacy(ctx, "sboms", "SELECT key, value FROM sboms WHERE adapter = ?", "dtrack")
+	require.NoError(t, err)
+	assert.Equal(t, 0, imported)
+
+	_, err = store.db.ExecContext(ctx, "CREATE TABLE sboms (adapter TEXT, key TEXT, value BLOB)")
+	require.NoError(t, err)
+	_, err = store.db.ExecContext(ctx, "INSERT INTO sboms VALUES ('dtrack', 'sbomqs/v1', 'true'), ('s3', 'sbomqs/v2', 'true')")
+	require.NoError(t, err)
+
+	imported, err = store.importLegacy(ctx, "sboms", "SELECT key, value FROM sboms WHERE adapter = ?", "dtrack")
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+
+	entries, err := store.Entries(ctx, "sboms")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"sbomqs/v1": []byte("true")}, entries)
+}
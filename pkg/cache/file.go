@@ -0,0 +1,211 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// saveDelay is how long a fileStore gathers changes before it rewrites its file, so a burst
+// of Puts, e.g. a poll recording every SBOM it transferred, costs a single rewrite
+const saveDelay = time.Second
+
+// fileStore keeps its entries in memory, and in a JSON file rewritten at most every saveDelay
+// and on Close. Changes made within saveDelay of the process ending without Close are lost,
+// which costs a transfer of what they recorded again. As the whole file is rewritten, it suits
+// caches of up to a few thousand entries on filesystems SQLite doesn't lock well, such as
+// network shares.
+type fileStore struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]map[string]fileEntry // namespace to key to entry
+	dirty   bool                            // entries changed since the file was last written
+	flush   *time.Timer                     // pending write of the changes, nil when none is
+	saveErr error                           // error of the last pending write, returned by the next change or Close
+}
+
+type fileEntry struct {
+	Value     []byte    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func openFile(path string) (*fileStore, error) {
+	s := &fileStore{path: path, entries: map[string]map[string]fileEntry{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return nil, fmt.Errorf("failed to parse cache file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *fileStore) Get(_ context.Context, namespace, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[namespace][key]
+	return entry.Value, ok, nil
+}
+
+func (s *fileStore) Put(_ context.Context, namespace, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries[namespace] == nil {
+		s.entries[namespace] = map[string]fileEntry{}
+	}
+	s.entries[namespace][key] = fileEntry{Value: value, UpdatedAt: time.Now().UTC()}
+	return s.changed()
+}
+
+func (s *fileStore) Delete(_ context.Context, namespace, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[namespace][key]; !ok {
+		return nil
+	}
+	delete(s.entries[namespace], key)
+	return s.changed()
+}
+
+func (s *fileStore) DeletePrefix(_ context.Context, namespace, prefix string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleted := 0
+	for key := range s.entries[namespace] {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.entries[namespace], key)
+			deleted++
+		}
+	}
+	if deleted == 0 {
+		return nil
+	}
+	return s.changed()
+}
+
+func (s *fileStore) Entries(_ context.Context, namespace string) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make(map[string][]byte, len(s.entries[namespace]))
+	for key, entry := range s.entries[namespace] {
+		entries[key] = entry.Value
+	}
+	return entries, nil
+}
+
+func (s *fileStore) Prune(_ context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pruned := 0
+	for _, entries := range s.entries {
+		for key, entry := range entries {
+			if entry.UpdatedAt.Before(before) {
+				delete(entries, key)
+				pruned++
+			}
+		}
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+	return pruned, s.changed()
+}
+
+// Close writes the changes not written yet
+func (s *fileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.flush != nil {
+		s.flush.Stop()
+		s.flush = nil
+	}
+	if err := s.saveDirty(); err != nil {
+		return err
+	}
+	err := s.saveErr
+	s.saveErr = nil
+	return err
+}
+
+// changed schedules writing the entries to the file, unless a write is already pending. It
+// returns the error of the last pending write, if it failed. The caller holds s.mu.
+func (s *fileStore) changed() error {
+	s.dirty = true
+	if s.flush == nil {
+		s.flush = time.AfterFunc(saveDelay, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.flush = nil
+			s.saveErr = s.saveDirty()
+		})
+	}
+	err := s.saveErr
+	s.saveErr = nil
+	return err
+}
+
+// saveDirty writes the entries to the file if they changed. The caller holds s.mu.
+func (s *fileStore) saveDirty() error {
+	if !s.dirty {
+		return nil
+	}
+	if err := s.save(); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// save writes the entries to a temporary file renamed over the cache file, so a crash
+// never leaves it half-written
+func (s *fileStore) save() error {
+	data, err := json.Marshal(s.entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const createEntriesTable string = `
+	CREATE TABLE IF NOT EXISTS entries (
+		namespace TEXT,
+		key TEXT,
+		value BLOB,
+		updated_at INTEGER,
+		PRIMARY KEY (namespace, key)
+	);
+`
+
+// sqliteStore keeps its entries in a table of an embedded SQLite database
+type sqliteStore struct {
+	db *sql.DB
+}
+
+func openSQLite(ctx context.Context, path string) (*sqliteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+	// one connection serializes writers, which would otherwise fail with "database is locked"
+	db.SetMaxOpenConns(1)
+
+	dbCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := db.ExecContext(dbCtx, createEntriesTable); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) Get(ctx context.Context, namespace, key string) ([]byte, bool, error) {
+	var value []byte
+	err := s.db.QueryRowContext(ctx, "SELECT value FROM entries WHERE namespace = ? AND key = ?", namespace, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cache entry %s: %w", key, err)
+	}
+	return value, true, nil
+}
+
+func (s *sqliteStore) Put(ctx context.Context, namespace, key string, value []byte) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO entries (namespace, key, value, updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (namespace, key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+		namespace, key, value, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to save cache entry %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *sqliteStore) Delete(ctx context.Context, namespace, key string) error {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM entries WHERE namespace = ? AND key = ?", namespace, key); err != nil {
+		return fmt.Errorf("failed to delete cache entry %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *sqliteStore) DeletePrefix(ctx context.Context, namespace, prefix string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM entries WHERE namespace = ? AND substr(key, 1, length(?)) = ?`,
+		namespace, prefix, prefix)
+	if err != nil {
+		return fmt.Errorf("failed to delete cache entries %s: %w", prefix, err)
+	}
+	return nil
+}
+
+func (s *sqliteStore) Entries(ctx context.Context, namespace string) (map[string][]byte, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT key, value FROM entries WHERE namespace = ?", namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cache: %w", err)
+	}
+	defer rows.Close()
+
+	entries := map[string][]byte{}
+	for rows.Next() {
+		var key string
+		var value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to load cache: %w", err)
+		}
+		entries[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load cache: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *sqliteStore) Prune(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM entries WHERE updated_at < ?", before.Unix())
+	if err != nil {
+		return 0, err
+	}
+	pruned, err := result.RowsAffected()
+	return int(pruned), err
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *sqliteStore) importLegacy(ctx context.Context, namespace, query string, args ...any) (int, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such table") {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	entries := map[string][]byte{}
+	for rows.Next() {
+		var key string
+		var value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		entries[key] = value
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for key, value := range entries {
+		if err := s.Put(ctx, namespace, key, value); err != nil {
+			return 0, err
+		}
+	}
+	return len(entries), nil
+}
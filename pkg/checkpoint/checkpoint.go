@@ -0,0 +1,240 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	_ "modernc.org/sqlite"
+)
+
+// DefaultPath is the checkpoint file of transfers between the given input and output adapters
+// with the given settings, e.g. "in-github-url=..." for every adapter flag set. Transfers of
+// other repositories, buckets or projects get a checkpoint of their own, while running the
+// same transfer again finds the checkpoint to resume from.
+func DefaultPath(input, output string, settings []string) string {
+	name := strings.ReplaceAll(input+"_"+output, ",", "-")
+
+	sorted := slices.Clone(settings)
+	slices.Sort(sorted)
+	h := sha256.New()
+	for _, setting := range sorted {
+		h.Write([]byte(setting))
+		h.Write([]byte{0})
+	}
+	return filepath.Join(".sbommv", fmt.Sprintf("checkpoint_%s_%s.db", name, hex.EncodeToString(h.Sum(nil))[:12]))
+}
+
+const createTransferredTable string = `
+	CREATE TABLE IF NOT EXISTS transferred (
+		destination TEXT,
+		sbom TEXT,
+		file TEXT,
+		transferred_at TEXT,
+		PRIMARY KEY (destination, sbom)
+	);
+`
+
+// Checkpoint records the SBOMs a transfer has uploaded, so a transfer interrupted or crashed
+// midway can be run again with --resume and skip them. An SBOM is identified by its source,
+// name and content as fetched: one changed since is transferred again.
+// A nil *Checkpoint records nothing and skips nothing.
+type Checkpoint struct {
+	mu           sync.Mutex
+	db           *sql.DB
+	path         string
+	destinations []string
+	done         map[string]map[string]bool // SBOMs transferred by the previous run, by destination
+	skipped      int
+}
+
+// Open opens the checkpoint at path for a transfer to the given destinations. With resume,
+// the SBOMs recorded by the previous run are kept to be skipped, otherwise the checkpoint
+// starts empty.
+func Open(ctx context.Context, path string, destinations []string, resume bool) (*Checkpoint, error) {
+	logger.LogDebug(ctx, "Initializing transfer checkpoint", "path", path, "resume", resume)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint database: %w", err)
+	}
+
+	cp := &Checkpoint{db: db, path: path, destinations: destinations, done: map[string]map[string]bool{}}
+	if err := cp.lock(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := cp.load(ctx, resume); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return cp, nil
+}
+
+// lock takes an exclusive lock on the checkpoint, held by its only connection until Close, so
+// that a concurrent transfer with the same checkpoint fails to open it instead of resetting
+// it or removing it when done. The operating system releases the lock of a crashed transfer.
+func (cp *Checkpoint) lock(ctx context.Context) error {
+	cp.db.SetMaxOpenConns(1)
+
+	dbCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	for _, stmt := range []string{"PRAGMA locking_mode=EXCLUSIVE", "BEGIN EXCLUSIVE", "COMMIT"} {
+		if _, err := cp.db.ExecContext(dbCtx, stmt); err != nil {
+			if strings.Contains(err.Error(), "SQLITE_BUSY") || strings.Contains(err.Error(), "database is locked") {
+				return fmt.Errorf("checkpoint %s is in use by another transfer with the same adapters and settings, use --checkpoint-file to give this one its own", cp.path)
+			}
+			return fmt.Errorf("failed to lock checkpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+func (cp *Checkpoint) load(ctx context.Context, resume bool) error {
+	dbCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := cp.db.ExecContext(dbCtx, createTransferredTable); err != nil {
+		return fmt.Errorf("failed to create tables: %w", err)
+	}
+	if !resume {
+		if _, err := cp.db.ExecContext(dbCtx, "DELETE FROM transferred"); err != nil {
+			return fmt.Errorf("failed to reset checkpoint: %w", err)
+		}
+		return nil
+	}
+
+	rows, err := cp.db.QueryContext(dbCtx, "SELECT destination, sbom FROM transferred")
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var destination, key string
+		if err := rows.Scan(&destination, &key); err != nil {
+			return fmt.Errorf("failed to load checkpoint: %w", err)
+		}
+		cp.markDone(destination, key)
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	logger.LogInfo(ctx, "Resuming transfer from checkpoint", "path", cp.path, "transferred", count)
+	return nil
+}
+
+func (cp *Checkpoint) markDone(destination, key string) {
+	if cp.done[destination] == nil {
+		cp.done[destination] = map[string]bool{}
+	}
+	cp.done[destination][key] = true
+}
+
+// Key identifies the SBOM by its source namespace, version, name and content
+func Key(doc *iterator.SBOM) string {
+	h := sha256.New()
+	for _, part := range []string{doc.Source, doc.Namespace, doc.Version, doc.Path} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(doc.Data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Skip is the processing stage dropping SBOMs the checkpoint recorded as transferred to every
+// destination. The others are keyed for Record to find them once transferred.
+func (cp *Checkpoint) Skip(ctx tcontext.TransferMetadata, doc *iterator.SBOM) (*iterator.SBOM, error) {
+	doc.Checkpoint = Key(doc)
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	for _, destination := range cp.destinations {
+		if !cp.done[destination][doc.Checkpoint] {
+			return doc, nil
+		}
+	}
+
+	cp.skipped++
+	logger.LogDebug(ctx.Context, "Skipping SBOM transferred before the checkpoint", "file", doc.Path, "namespace", doc.Namespace)
+	return nil, nil
+}
+
+// Skipped returns the number of SBOMs skipped as transferred by the previous run
+func (cp *Checkpoint) Skipped() int {
+	if cp == nil {
+		return 0
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.skipped
+}
+
+// Record records the SBOM as transferred to the destination, for the next run to skip; SBOMs
+// of this run are not skipped by it. Failures are logged only, as they only cost uploading
+// the SBOM again on resume.
+func (cp *Checkpoint) Record(ctx tcontext.TransferMetadata, destination string, doc *iterator.SBOM) {
+	if cp == nil || doc.Checkpoint == "" {
+		return
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	_, err := cp.db.ExecContext(ctx.Context, `
+		INSERT INTO transferred (destination, sbom, file, transferred_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (destination, sbom) DO UPDATE SET file = excluded.file, transferred_at = excluded.transferred_at`,
+		destination, doc.Checkpoint, doc.Path, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		logger.LogError(ctx.Context, err, "Failed to save SBOM to checkpoint", "file", doc.Path)
+	}
+}
+
+// Close closes the checkpoint. A transfer that completed without failures has nothing left
+// to resume, so its checkpoint is removed.
+func (cp *Checkpoint) Close(completed bool) error {
+	if cp == nil {
+		return nil
+	}
+	if err := cp.db.Close(); err != nil {
+		return err
+	}
+	if completed {
+		return os.Remove(cp.path)
+	}
+	return nil
+}
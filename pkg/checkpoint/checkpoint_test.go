@@ -0,0 +1,70 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPathDependsOnAdapterSettings(t *testing.T) {
+	a := DefaultPath("github", "dtrack", []string{"in-github-url=https://github.com/o/a", "out-dtrack-url=http://dt"})
+	b := DefaultPath("github", "dtrack", []string{"in-github-url=https://github.com/o/b", "out-dtrack-url=http://dt"})
+	assert.NotEqual(t, a, b)
+
+	// the same transfer finds its checkpoint again, whatever the order of its flags
+	assert.Equal(t, a, DefaultPath("github", "dtrack", []string{"out-dtrack-url=http://dt", "in-github-url=https://github.com/o/a"}))
+	assert.True(t, strings.HasPrefix(DefaultPath("folder,s3", "dtrack", nil), filepath.Join(".sbommv", "checkpoint_folder-s3_dtrack_")))
+}
+
+func TestOverlappingRunsDontShareCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	tctx := tcontext.NewTransferMetadata(ctx)
+	path := filepath.Join(t.TempDir(), "checkpoint.db")
+	doc := &iterator.SBOM{Path: "app.cdx.json", Data: []byte(`{"bomFormat":"CycloneDX"}`)}
+
+	first, err := Open(ctx, path, []string{"dtrack"}, false)
+	require.NoError(t, err)
+	_, err = first.Skip(*tctx, doc)
+	require.NoError(t, err)
+	first.Record(*tctx, "dtrack", doc)
+
+	// a second run with the same checkpoint neither resets nor resumes the first one's
+	_, err = Open(ctx, path, []string{"dtrack"}, false)
+	require.ErrorContains(t, err, "in use by another transfer")
+	_, err = Open(ctx, path, []string{"dtrack"}, true)
+	require.ErrorContains(t, err, "in use by another transfer")
+
+	// the first run left off midway: its progress is kept for --resume
+	require.NoError(t, first.Close(false))
+	resumed, err := Open(ctx, path, []string{"dtrack"}, true)
+	require.NoError(t, err)
+	skipped, err := resumed.Skip(*tctx, &iterator.SBOM{Path: doc.Path, Data: doc.Data})
+	require.NoError(t, err)
+	assert.Nil(t, skipped)
+	assert.Equal(t, 1, resumed.Skipped())
+
+	require.NoError(t, resumed.Close(true))
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
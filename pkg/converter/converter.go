@@ -23,6 +23,7 @@ import (
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
 	"github.com/protobom/protobom/pkg/formats"
 	"github.com/protobom/protobom/pkg/reader"
 	"github.com/protobom/protobom/pkg/sbom"
@@ -43,7 +44,7 @@ func newBufferWriteCloser() *bufferWriteCloser {
 	return &bufferWriteCloser{&bytes.Buffer{}}
 }
 
-// ConvertSBOM converts SBOM from SPDX to CDX format using protobom
+// ConvertSBOM converts SBOM from SPDX to CDX format, or from CDX to SPDX, using protobom
 func ConvertSBOM(ctx tcontext.TransferMetadata, sbomData []byte, targetFormat sbomd.FormatSpec) ([]byte, error) {
 	logger.LogDebug(ctx.Context, "Iniatializing for SBOM conversion from SPDX to CDX")
 
@@ -51,6 +52,27 @@ func ConvertSBOM(ctx tcontext.TransferMetadata, sbomData []byte, targetFormat sb
 	logrus.SetLevel(logrus.ErrorLevel)   // Only ERROR and above from protobom
 	defer logrus.SetLevel(originalLevel) // Restore after
 
+	// CycloneDX protobuf and SPDX YAML are re-encoded as JSON before anything else
+	sbomData, reencoded, err := sbomd.ToJSON(sbomData)
+	if err != nil {
+		return nil, fmt.Errorf("ConvertSBOM: %w", err)
+	}
+	if reencoded {
+		logger.LogDebug(ctx.Context, "Re-encoded SBOM as JSON for conversion")
+	}
+
+	// protobom reads CycloneDX JSON only
+	if sbomd.DetectFormat(sbomData) == sbomd.FormatCycloneDXXML {
+		sbomData, err = sbomd.CycloneDXXMLToJSON(sbomData)
+		if err != nil {
+			return nil, fmt.Errorf("ConvertSBOM: %w", err)
+		}
+		if sbomData == nil {
+			return nil, fmt.Errorf("ConvertSBOM: CycloneDX XML of this version can't be converted")
+		}
+		logger.LogDebug(ctx.Context, "Re-encoded CycloneDX XML as JSON for conversion")
+	}
+
 	spec, version, err := sbomd.DetectSBOMSpecAndVersion(sbomData)
 	if err != nil {
 		return nil, fmt.Errorf("ConvertSBOM: %w", err)
@@ -61,6 +83,10 @@ func ConvertSBOM(ctx tcontext.TransferMetadata, sbomData []byte, targetFormat sb
 		return sbomData, nil
 	}
 
+	if spec == sbomd.FormatSpecCycloneDX && targetFormat == sbomd.FormatSpecSPDX {
+		return convertCycloneDXToSPDX(ctx, sbomData, version)
+	}
+
 	if spec != sbomd.FormatSpecSPDX {
 		return nil, fmt.Errorf("conversion layer is provided with SBOM other than SPDX, therefore no conversion will take place")
 	}
@@ -76,6 +102,9 @@ func ConvertSBOM(ctx tcontext.TransferMetadata, sbomData []byte, targetFormat sb
 		return nil, fmt.Errorf("unsupported conversion from SPDX 2.1 to %s", targetFormat)
 
 	case sbomd.FormatSpecVersionSPDXV2_2:
+		if SPDXUpgradeFromContext(ctx) == types.SPDXUpgradeOff {
+			return nil, fmt.Errorf("converting SPDX 2.2 to %s requires upgrading it to SPDX 2.3, disabled by --spdx-upgrade=off", targetFormat)
+		}
 		spdx23SbomData, err = ConvertSPDX22ToSPDX23(ctx, sbomData)
 		if err != nil {
 			return nil, fmt.Errorf("converting SPDX 2.2 to 2.3: %w", err)
@@ -105,6 +134,23 @@ func ConvertSBOM(ctx tcontext.TransferMetadata, sbomData []byte, targetFormat sb
 	return nil, fmt.Errorf("unsupported conversion to %s", targetFormat)
 }
 
+// convertCycloneDXToSPDX converts a CycloneDX JSON SBOM to SPDX 2.3 JSON through protobom
+func convertCycloneDXToSPDX(ctx tcontext.TransferMetadata, sbomData []byte, version string) ([]byte, error) {
+	logger.LogDebug(ctx.Context, "Detected CycloneDX SBOM", "version", version)
+
+	doc, err := parseSBOM(sbomData)
+	if err != nil {
+		return nil, fmt.Errorf("Conversion: %w", err)
+	}
+
+	logger.LogDebug(ctx.Context, "Converting SBOM", "source", sbomd.FormatSpecCycloneDX, "source version", version, "target", sbomd.FormatSpecSPDX)
+	spdxData, err := serialize(ctx, doc, formats.SPDX23JSON)
+	if err != nil {
+		return nil, err
+	}
+	return fixConvertedSPDX(spdxData)
+}
+
 // isValidCycloneDXSerialNumber checks if the serial number matches the required UUID pattern
 func isValidCycloneDXSerialNumber(serial string) bool {
 	pattern := `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
@@ -157,8 +203,53 @@ func parseSBOM(sbomData []byte) (*sbom.Document, error) {
 // 	return json.Marshal(sbom)
 // }
 
+// targetVersionKey is the TransferMetadata key under which the engine stores --conversion-target-version
+const targetVersionKey = "conversion_target_version"
+
+// DefaultCycloneDXTargetVersion is the CycloneDX version SBOMs are converted to by default
+const DefaultCycloneDXTargetVersion = "1.5"
+
+// cycloneDXTargetFormats are the protobom writer formats of the CycloneDX versions SBOMs can be converted to
+var cycloneDXTargetFormats = map[string]formats.Format{
+	"1.4": formats.CDX14JSON,
+	"1.5": formats.CDX15JSON,
+	"1.6": formats.CDX16JSON,
+}
+
+// IsCycloneDXTargetVersion reports whether SBOMs can be converted to this CycloneDX version
+func IsCycloneDXTargetVersion(version string) bool {
+	_, ok := cycloneDXTargetFormats[version]
+	return ok
+}
+
+// AttachCycloneDXTargetVersion stores the CycloneDX version conversions write in the transfer context
+func AttachCycloneDXTargetVersion(ctx *tcontext.TransferMetadata, version string) {
+	if version != "" {
+		ctx.WithValue(targetVersionKey, version)
+	}
+}
+
+// CycloneDXTargetVersionFromContext returns the CycloneDX version conversions write, 1.5 by default
+func CycloneDXTargetVersionFromContext(ctx tcontext.TransferMetadata) string {
+	if version, ok := ctx.Value(targetVersionKey).(string); ok && IsCycloneDXTargetVersion(version) {
+		return version
+	}
+	return DefaultCycloneDXTargetVersion
+}
+
 func serializeToCycloneDX(ctx tcontext.TransferMetadata, doc *sbom.Document) ([]byte, error) {
-	logger.LogDebug(ctx.Context, "Initializing protobom serialization of SBOM from SPDX to CycloneDX")
+	version := CycloneDXTargetVersionFromContext(ctx)
+	logger.LogDebug(ctx.Context, "Serializing SBOM to CycloneDX", "spec_version", version)
+	cdxData, err := serialize(ctx, doc, cycloneDXTargetFormats[version])
+	if err != nil {
+		return nil, err
+	}
+	return fixConvertedCycloneDX(cdxData)
+}
+
+// serialize writes the protobom document in the given format
+func serialize(ctx tcontext.TransferMetadata, doc *sbom.Document, format formats.Format) ([]byte, error) {
+	logger.LogDebug(ctx.Context, "Initializing protobom serialization of SBOM", "format", format)
 	w := writer.New()
 	buf := &bytes.Buffer{}
 
@@ -170,7 +261,7 @@ func serializeToCycloneDX(ctx tcontext.TransferMetadata, doc *sbom.Document) ([]
 
 	go func(buffer *bytes.Buffer) {
 		logger.LogDebug(ctx.Context, "Starting WriteStreamWithOptions", "nodeCount", len(doc.NodeList.Nodes))
-		err := w.WriteStreamWithOptions(doc, buffer, &writer.Options{Format: formats.CDX15JSON})
+		err := w.WriteStreamWithOptions(doc, buffer, &writer.Options{Format: format})
 		data := buffer.Bytes()
 		resultChan <- struct {
 			data []byte
@@ -187,9 +278,9 @@ func serializeToCycloneDX(ctx tcontext.TransferMetadata, doc *sbom.Document) ([]
 		logger.LogDebug(ctx.Context, "Finished WriteStreamWithOptions")
 		data := res.data
 		if len(data) == 0 {
-			return nil, fmt.Errorf("empty protobom serialized CycloneDX SBOM")
+			return nil, fmt.Errorf("empty protobom serialized SBOM")
 		}
-		logger.LogDebug(ctx.Context, "Successfully protobom serialization of SBOM from SPDX to CycloneDX")
+		logger.LogDebug(ctx.Context, "Successfully protobom serialization of SBOM", "format", format)
 		return data, nil
 	case <-time.After(30 * time.Second): // 30 seconds timeout
 		return nil, fmt.Errorf("Conversion: serialization timed out after 30 seconds")
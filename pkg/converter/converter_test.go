@@ -0,0 +1,265 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spdxFixture is an SPDX 2.3 document of an application depending on a library
+const spdxFixture = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "sbomqs",
+  "documentNamespace": "https://interlynk.io/sbomqs-1.0.0",
+  "creationInfo": {"created": "2025-01-15T10:00:00Z", "creators": ["Tool: sbomqs-1.0.0"]},
+  "packages": [
+    {"name": "sbomqs", "SPDXID": "SPDXRef-sbomqs", "versionInfo": "1.0.0", "downloadLocation": "NOASSERTION",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/interlynk-io/sbomqs@1.0.0"}]},
+    {"name": "cobra", "SPDXID": "SPDXRef-cobra", "versionInfo": "1.8.1", "downloadLocation": "NOASSERTION",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/spf13/cobra@1.8.1"}]}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-sbomqs"},
+    {"spdxElementId": "SPDXRef-sbomqs", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-cobra"}
+  ]
+}`
+
+// cycloneDXFixture is a CycloneDX 1.6 document of an application with two dependencies
+const cycloneDXFixture = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "version": 1,
+  "metadata": {
+    "timestamp": "2025-01-15T10:00:00Z",
+    "component": {"bom-ref": "sbommv", "type": "application", "name": "sbommv", "version": "0.1.0",
+      "purl": "pkg:golang/github.com/interlynk-io/sbommv@0.1.0"}
+  },
+  "components": [
+    {"bom-ref": "zap", "type": "library", "name": "zap", "version": "1.27.0", "purl": "pkg:golang/go.uber.org/zap@1.27.0"},
+    {"bom-ref": "viper", "type": "library", "name": "viper", "version": "1.19.0", "purl": "pkg:golang/github.com/spf13/viper@1.19.0"}
+  ],
+  "dependencies": [
+    {"ref": "sbommv", "dependsOn": ["viper", "zap"]}
+  ]
+}`
+
+// component is what a conversion must carry over of a package or component
+type component struct {
+	Version string
+	PURL    string
+}
+
+// requireValid fails the test unless the converted SBOM passes schema validation
+func requireValid(t *testing.T, data []byte) {
+	t.Helper()
+	result := sbomd.Validate(data)
+	require.True(t, result.Valid(), "converted SBOM is invalid: %v", result.Errors)
+}
+
+// spdxPackages returns the packages of an SPDX JSON document by name, and its DEPENDS_ON
+// relationships as "from -> to" package names
+func spdxPackages(t *testing.T, data []byte) (map[string]component, []string) {
+	t.Helper()
+	var doc struct {
+		SPDXVersion string `json:"spdxVersion"`
+		Packages    []struct {
+			ID           string `json:"SPDXID"`
+			Name         string `json:"name"`
+			Version      string `json:"versionInfo"`
+			ExternalRefs []struct {
+				Type    string `json:"referenceType"`
+				Locator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+		Relationships []struct {
+			From string `json:"spdxElementId"`
+			Type string `json:"relationshipType"`
+			To   string `json:"relatedSpdxElement"`
+		} `json:"relationships"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+
+	packages := map[string]component{}
+	names := map[string]string{}
+	for _, p := range doc.Packages {
+		c := component{Version: p.Version}
+		for _, ref := range p.ExternalRefs {
+			if ref.Type == "purl" {
+				c.PURL = ref.Locator
+			}
+		}
+		packages[p.Name] = c
+		names[p.ID] = p.Name
+	}
+
+	var dependencies []string
+	for _, r := range doc.Relationships {
+		if r.Type == "DEPENDS_ON" {
+			dependencies = append(dependencies, names[r.From]+" -> "+names[r.To])
+		}
+	}
+	return packages, dependencies
+}
+
+// cycloneDXComponents returns the spec version of a CycloneDX JSON document, its metadata
+// component and components by name, and its dependencies as "from -> to" component names
+func cycloneDXComponents(t *testing.T, data []byte) (string, map[string]component, []string) {
+	t.Helper()
+	type cdxComponent struct {
+		Ref     string `json:"bom-ref"`
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		PURL    string `json:"purl"`
+	}
+	var doc struct {
+		SpecVersion string `json:"specVersion"`
+		Metadata    struct {
+			Component cdxComponent `json:"component"`
+		} `json:"metadata"`
+		Components   []cdxComponent `json:"components"`
+		Dependencies []struct {
+			Ref       string   `json:"ref"`
+			DependsOn []string `json:"dependsOn"`
+		} `json:"dependencies"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	components := map[string]component{}
+	names := map[string]string{}
+	for _, c := range append([]cdxComponent{doc.Metadata.Component}, doc.Components...) {
+		components[c.Name] = component{Version: c.Version, PURL: c.PURL}
+		names[c.Ref] = c.Name
+	}
+
+	var dependencies []string
+	for _, d := range doc.Dependencies {
+		for _, to := range d.DependsOn {
+			dependencies = append(dependencies, names[d.Ref]+" -> "+names[to])
+		}
+	}
+	return doc.SpecVersion, components, dependencies
+}
+
+func TestConvertSPDXRoundTrip(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	packages, dependencies := spdxPackages(t, []byte(spdxFixture))
+
+	cdx, err := ConvertSBOM(ctx, []byte(spdxFixture), sbomd.FormatSpecCycloneDX)
+	require.NoError(t, err)
+	requireValid(t, cdx)
+	specVersion, components, cdxDependencies := cycloneDXComponents(t, cdx)
+	assert.Equal(t, DefaultCycloneDXTargetVersion, specVersion)
+	assert.Equal(t, packages, components)
+	assert.ElementsMatch(t, dependencies, cdxDependencies)
+
+	spdx, err := ConvertSBOM(ctx, cdx, sbomd.FormatSpecSPDX)
+	require.NoError(t, err)
+	requireValid(t, spdx)
+	roundTripped, roundTrippedDependencies := spdxPackages(t, spdx)
+	assert.Equal(t, packages, roundTripped)
+	assert.ElementsMatch(t, dependencies, roundTrippedDependencies)
+}
+
+func TestConvertCycloneDXRoundTrip(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	_, components, dependencies := cycloneDXComponents(t, []byte(cycloneDXFixture))
+
+	spdx, err := ConvertSBOM(ctx, []byte(cycloneDXFixture), sbomd.FormatSpecSPDX)
+	require.NoError(t, err)
+	requireValid(t, spdx)
+	packages, spdxDependencies := spdxPackages(t, spdx)
+	assert.Equal(t, components, packages)
+	assert.ElementsMatch(t, dependencies, spdxDependencies)
+
+	// the SPDX document has both CONTAINS and DEPENDS_ON relationships between the same packages
+	cdx, err := ConvertSBOM(ctx, spdx, sbomd.FormatSpecCycloneDX)
+	require.NoError(t, err)
+	requireValid(t, cdx)
+	_, roundTripped, roundTrippedDependencies := cycloneDXComponents(t, cdx)
+	assert.Equal(t, components, roundTripped)
+	assert.ElementsMatch(t, dependencies, roundTrippedDependencies)
+}
+
+func TestConvertSameSpec(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	data, err := ConvertSBOM(ctx, []byte(cycloneDXFixture), sbomd.FormatSpecCycloneDX)
+	require.NoError(t, err)
+	assert.Equal(t, cycloneDXFixture, string(data))
+}
+
+func TestConversionTargetVersion(t *testing.T) {
+	for _, version := range []string{"", "1.4", "1.5", "1.6"} {
+		t.Run("version "+version, func(t *testing.T) {
+			ctx := tcontext.NewTransferMetadata(context.Background())
+			AttachCycloneDXTargetVersion(ctx, version)
+
+			cdx, err := ConvertSBOM(*ctx, []byte(spdxFixture), sbomd.FormatSpecCycloneDX)
+			require.NoError(t, err)
+
+			requireValid(t, cdx)
+			specVersion, components, _ := cycloneDXComponents(t, cdx)
+			want := version
+			if want == "" {
+				want = DefaultCycloneDXTargetVersion
+			}
+			assert.Equal(t, want, specVersion)
+			assert.Len(t, components, 2)
+		})
+	}
+}
+
+func TestSPDXUpgrade(t *testing.T) {
+	spdx22 := strings.Replace(spdxFixture, `"SPDX-2.3"`, `"SPDX-2.2"`, 1)
+	packages, _ := spdxPackages(t, []byte(spdxFixture))
+
+	tests := []struct {
+		mode    types.SPDXUpgradeMode
+		wantErr string
+	}{
+		{types.SPDXUpgradeOn, ""},
+		{types.SPDXUpgradeDiff, ""},
+		{types.SPDXUpgradeOff, "disabled by --spdx-upgrade=off"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			ctx := tcontext.NewTransferMetadata(context.Background())
+			AttachSPDXUpgrade(ctx, tt.mode)
+
+			cdx, err := ConvertSBOM(*ctx, []byte(spdx22), sbomd.FormatSpecCycloneDX)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			_, components, _ := cycloneDXComponents(t, cdx)
+			assert.Equal(t, packages, components)
+		})
+	}
+}
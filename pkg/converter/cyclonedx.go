@@ -0,0 +1,81 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// fixConvertedCycloneDX repairs what protobom gets wrong writing SPDX as CycloneDX: it writes
+// a dependency per relationship, so a package that both CONTAINS and DEPENDS_ON another gets
+// the same ref several times, which the CycloneDX schema rejects. Dependencies of the same
+// ref are merged, in the order they first appear. Documents without such duplicates are
+// returned as they are.
+func fixConvertedCycloneDX(data []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling converted CycloneDX: %w", err)
+	}
+
+	dependencies, _ := doc["dependencies"].([]any)
+	merged := make([]any, 0, len(dependencies))
+	byRef := map[string]map[string]any{}
+	seen := map[string]map[string]bool{}
+	duplicates := false
+
+	for _, d := range dependencies {
+		dependency, ok := d.(map[string]any)
+		if !ok {
+			merged = append(merged, d)
+			continue
+		}
+		ref, _ := dependency["ref"].(string)
+		dependsOn, _ := dependency["dependsOn"].([]any)
+
+		first, ok := byRef[ref]
+		if !ok {
+			first = dependency
+			byRef[ref] = dependency
+			seen[ref] = map[string]bool{}
+			merged = append(merged, dependency)
+			dependency["dependsOn"] = []any{}
+		} else {
+			duplicates = true
+		}
+
+		for _, on := range dependsOn {
+			target, _ := on.(string)
+			if seen[ref][target] {
+				duplicates = true
+				continue
+			}
+			seen[ref][target] = true
+			first["dependsOn"] = append(first["dependsOn"].([]any), on)
+		}
+	}
+	if !duplicates {
+		return data, nil
+	}
+
+	for _, d := range merged {
+		if dependency, ok := d.(map[string]any); ok {
+			if dependsOn, _ := dependency["dependsOn"].([]any); len(dependsOn) == 0 {
+				delete(dependency, "dependsOn")
+			}
+		}
+	}
+	doc["dependencies"] = merged
+	return json.MarshalIndent(doc, "", "  ")
+}
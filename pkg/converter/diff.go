@@ -0,0 +1,109 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Kinds of FieldChange
+const (
+	FieldAdded    = "added"
+	FieldRemoved  = "removed"
+	FieldModified = "modified"
+)
+
+// FieldChange is a field that differs between two JSON documents, e.g. one changed while
+// upgrading an SPDX 2.2 document to SPDX 2.3. Path locates it, e.g. "packages[2].supplier".
+type FieldChange struct {
+	Path string `json:"path"`
+	Op   string `json:"op"`
+	From any    `json:"from,omitempty"`
+	To   any    `json:"to,omitempty"`
+}
+
+// DiffJSON returns the fields added, removed or modified from before to after, ordered by path.
+// Arrays are compared element by element.
+func DiffJSON(before, after []byte) ([]FieldChange, error) {
+	var a, b any
+	if err := json.Unmarshal(before, &a); err != nil {
+		return nil, fmt.Errorf("unmarshaling original document: %w", err)
+	}
+	if err := json.Unmarshal(after, &b); err != nil {
+		return nil, fmt.Errorf("unmarshaling changed document: %w", err)
+	}
+
+	var changes []FieldChange
+	diffValues("", a, b, &changes)
+	return changes, nil
+}
+
+func diffValues(path string, a, b any, changes *[]FieldChange) {
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			keys := make([]string, 0, len(av)+len(bv))
+			for k := range av {
+				keys = append(keys, k)
+			}
+			for k := range bv {
+				if _, ok := av[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				child := k
+				if path != "" {
+					child = path + "." + k
+				}
+				va, inA := av[k]
+				vb, inB := bv[k]
+				switch {
+				case !inA:
+					*changes = append(*changes, FieldChange{Path: child, Op: FieldAdded, To: vb})
+				case !inB:
+					*changes = append(*changes, FieldChange{Path: child, Op: FieldRemoved, From: va})
+				default:
+					diffValues(child, va, vb, changes)
+				}
+			}
+			return
+		}
+
+	case []any:
+		if bv, ok := b.([]any); ok {
+			for i := 0; i < len(av) || i < len(bv); i++ {
+				child := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(av):
+					*changes = append(*changes, FieldChange{Path: child, Op: FieldAdded, To: bv[i]})
+				case i >= len(bv):
+					*changes = append(*changes, FieldChange{Path: child, Op: FieldRemoved, From: av[i]})
+				default:
+					diffValues(child, av[i], bv[i], changes)
+				}
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, FieldChange{Path: path, Op: FieldModified, From: a, To: b})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// invalidSPDXIDChars are the characters SPDX identifiers can't hold after "SPDXRef-"
+var invalidSPDXIDChars = regexp.MustCompile(`[^A-Za-z0-9.\-]`)
+
+// fixConvertedSPDX repairs what protobom gets wrong writing CycloneDX as SPDX: element IDs
+// derived from bom-refs such as purls hold characters SPDX doesn't allow, and the document
+// name is left empty. IDs are rewritten consistently across packages, files and relationships,
+// and the document is named after the package it describes.
+func fixConvertedSPDX(data []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling converted SPDX: %w", err)
+	}
+
+	ids := map[string]string{}
+	used := map[string]bool{}
+	fixID := func(id string) string {
+		if fixed, ok := ids[id]; ok {
+			return fixed
+		}
+		if !strings.HasPrefix(id, "SPDXRef-") || id == "SPDXRef-DOCUMENT" {
+			return id
+		}
+		fixed := "SPDXRef-" + invalidSPDXIDChars.ReplaceAllString(strings.TrimPrefix(id, "SPDXRef-"), "-")
+		for base, n := fixed, 2; used[fixed]; n++ {
+			fixed = fmt.Sprintf("%s-%d", base, n)
+		}
+		ids[id] = fixed
+		used[fixed] = true
+		return fixed
+	}
+
+	for _, section := range []string{"packages", "files", "snippets"} {
+		elements, _ := doc[section].([]any)
+		for _, e := range elements {
+			element, ok := e.(map[string]any)
+			if !ok {
+				continue
+			}
+			if id, ok := element["SPDXID"].(string); ok {
+				element["SPDXID"] = fixID(id)
+			}
+			fixIDList(element, "hasFiles", fixID)
+		}
+	}
+
+	relationships, _ := doc["relationships"].([]any)
+	for _, r := range relationships {
+		relationship, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"spdxElementId", "relatedSpdxElement"} {
+			if id, ok := relationship[key].(string); ok {
+				relationship[key] = fixID(id)
+			}
+		}
+	}
+	fixIDList(doc, "documentDescribes", fixID)
+
+	if name, _ := doc["name"].(string); name == "" {
+		doc["name"] = describedPackageName(doc)
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func fixIDList(element map[string]any, key string, fixID func(string) string) {
+	list, _ := element[key].([]any)
+	for i, v := range list {
+		if id, ok := v.(string); ok {
+			list[i] = fixID(id)
+		}
+	}
+}
+
+// describedPackageName returns the name of the package the document describes, directly or
+// through a DESCRIBES relationship, or of its first package
+func describedPackageName(doc map[string]any) string {
+	described := ""
+	if list, _ := doc["documentDescribes"].([]any); len(list) > 0 {
+		described, _ = list[0].(string)
+	}
+	if described == "" {
+		relationships, _ := doc["relationships"].([]any)
+		for _, r := range relationships {
+			relationship, _ := r.(map[string]any)
+			if relationship["spdxElementId"] == "SPDXRef-DOCUMENT" && relationship["relationshipType"] == "DESCRIBES" {
+				described, _ = relationship["relatedSpdxElement"].(string)
+				break
+			}
+		}
+	}
+
+	packages, _ := doc["packages"].([]any)
+	for _, p := range packages {
+		pkg, _ := p.(map[string]any)
+		if name, _ := pkg["name"].(string); name != "" && (described == "" || pkg["SPDXID"] == described) {
+			return name
+		}
+	}
+	return "converted-sbom"
+}
@@ -20,11 +20,30 @@ import (
 
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
 	"github.com/spdx/tools-golang/convert"
 	"github.com/spdx/tools-golang/spdx/v2/v2_2"
 	"github.com/spdx/tools-golang/spdx/v2/v2_3"
 )
 
+// spdxUpgradeKey is the TransferMetadata key under which the engine stores the --spdx-upgrade mode
+const spdxUpgradeKey = "spdx_upgrade"
+
+// AttachSPDXUpgrade stores the --spdx-upgrade mode in the transfer context
+func AttachSPDXUpgrade(ctx *tcontext.TransferMetadata, mode types.SPDXUpgradeMode) {
+	if mode != "" {
+		ctx.WithValue(spdxUpgradeKey, mode)
+	}
+}
+
+// SPDXUpgradeFromContext returns the --spdx-upgrade mode of the transfer, on by default
+func SPDXUpgradeFromContext(ctx tcontext.TransferMetadata) types.SPDXUpgradeMode {
+	if mode, ok := ctx.Value(spdxUpgradeKey).(types.SPDXUpgradeMode); ok {
+		return mode
+	}
+	return types.SPDXUpgradeOn
+}
+
 func ConvertSPDX22ToSPDX23(ctx tcontext.TransferMetadata, sbomData []byte) ([]byte, error) {
 	logger.LogDebug(ctx.Context, "Converting SPDX 2.2 to 2.3")
 
@@ -64,5 +83,36 @@ func ConvertSPDX22ToSPDX23(ctx tcontext.TransferMetadata, sbomData []byte) ([]by
 
 	logger.LogDebug(ctx.Context, "Conversion successful from SPDX 2.2 to SPDX 2.3")
 
+	if SPDXUpgradeFromContext(ctx) == types.SPDXUpgradeDiff {
+		logSPDXUpgradeDiff(ctx, sbomData, newSBOMData)
+	}
+
 	return newSBOMData, err
 }
+
+// logSPDXUpgradeDiff logs every field the upgrade added, removed or modified, identifying the
+// document by its namespace
+func logSPDXUpgradeDiff(ctx tcontext.TransferMetadata, original, upgraded []byte) {
+	changes, err := DiffJSON(original, upgraded)
+	if err != nil {
+		logger.LogError(ctx.Context, err, "Failed to diff SPDX 2.2 to 2.3 upgrade")
+		return
+	}
+
+	var doc struct {
+		Namespace string `json:"documentNamespace"`
+	}
+	_ = json.Unmarshal(original, &doc)
+
+	logger.LogInfo(ctx.Context, "SPDX 2.2 upgraded to 2.3", "document_namespace", doc.Namespace, "changes", len(changes))
+	for _, c := range changes {
+		fields := []interface{}{"document_namespace", doc.Namespace, "path", c.Path, "op", c.Op}
+		if c.Op != FieldAdded {
+			fields = append(fields, "from", c.From)
+		}
+		if c.Op != FieldRemoved {
+			fields = append(fields, "to", c.To)
+		}
+		logger.LogInfo(ctx.Context, "SPDX upgrade change", fields...)
+	}
+}
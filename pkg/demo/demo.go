@@ -0,0 +1,190 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package demo runs the quickstart sandbox of `sbommv demo`: a local Dependency-Track
+// started with docker compose and a folder of sample SBOMs to transfer into it.
+package demo
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+)
+
+const (
+	// APIURL and FrontendURL are where the compose file publishes Dependency-Track
+	APIURL      = "http://localhost:8081"
+	FrontendURL = "http://localhost:8080"
+
+	// SBOMDir is the folder of sample SBOMs inside the demo directory
+	SBOMDir = "sboms"
+
+	// Password is set on the Dependency-Track admin account in place of the default one
+	Password = "sbommv-demo"
+
+	defaultPassword = "admin"
+	adminUser       = "admin"
+	automationTeam  = "Automation"
+)
+
+//go:embed assets
+var assets embed.FS
+
+// WriteAssets writes the compose file and the sample SBOMs into dir, replacing earlier copies
+func WriteAssets(dir string) error {
+	root, err := fs.Sub(assets, "assets")
+	if err != nil {
+		return err
+	}
+
+	return fs.WalkDir(root, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+
+		data, err := fs.ReadFile(root, path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		return nil
+	})
+}
+
+// SampleCount is the number of sample SBOMs shipped with the demo
+func SampleCount() int {
+	entries, _ := assets.ReadDir("assets/" + SBOMDir)
+	return len(entries)
+}
+
+// Compose runs `docker compose <args>` on the compose file in dir, falling back to the
+// standalone docker-compose binary
+func Compose(ctx context.Context, dir string, args ...string) error {
+	name, cmdArgs := "docker", append([]string{"compose"}, args...)
+	if _, err := exec.LookPath("docker"); err != nil {
+		if _, err := exec.LookPath("docker-compose"); err != nil {
+			return fmt.Errorf("docker is required to run the demo, neither docker nor docker-compose found in PATH")
+		}
+		name, cmdArgs = "docker-compose", args
+	}
+
+	cmd := exec.CommandContext(ctx, name, cmdArgs...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	logger.LogDebug(ctx, "Running docker compose", "dir", dir, "command", cmd.String())
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", cmd.String(), err)
+	}
+	return nil
+}
+
+// WaitForDTrack waits until the Dependency-Track API answers, which takes a few minutes on
+// the first start while the vulnerability databases are set up
+func WaitForDTrack(ctx context.Context, timeout time.Duration) (dtrack.About, error) {
+	client, err := dtrack.NewClient(APIURL, dtrack.WithTimeout(10*time.Second))
+	if err != nil {
+		return dtrack.About{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		about, err := client.About.Get(ctx)
+		if err == nil {
+			return about, nil
+		}
+		logger.LogDebug(ctx, "Dependency-Track not ready yet", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return dtrack.About{}, fmt.Errorf("Dependency-Track did not start within %s: %w", timeout, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Bootstrap logs in as admin, replacing the default password on the first run, and returns
+// a new API key of the Automation team, with the permissions sbommv needs, along with a
+// client authenticated as admin
+func Bootstrap(ctx context.Context) (string, *dtrack.Client, error) {
+	anonymous, err := dtrack.NewClient(APIURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	token, err := anonymous.User.Login(ctx, adminUser, Password)
+	if err != nil {
+		// first run, the admin still has the default password
+		if err := anonymous.User.ForceChangePassword(ctx, adminUser, defaultPassword, Password); err != nil {
+			return "", nil, fmt.Errorf("failed to set the admin password: %w", err)
+		}
+		if token, err = anonymous.User.Login(ctx, adminUser, Password); err != nil {
+			return "", nil, fmt.Errorf("failed to log in as admin: %w", err)
+		}
+	}
+
+	admin, err := dtrack.NewClient(APIURL, dtrack.WithBearerToken(token))
+	if err != nil {
+		return "", nil, err
+	}
+
+	teams, err := admin.Team.GetAll(ctx, dtrack.PageOptions{PageNumber: 1, PageSize: 100})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	for _, team := range teams.Items {
+		if team.Name != automationTeam {
+			continue
+		}
+
+		for _, permission := range []string{dtrack.PermissionBOMUpload, dtrack.PermissionProjectCreationUpload, dtrack.PermissionPortfolioManagement, dtrack.PermissionViewPortfolio} {
+			_, err := admin.Permission.AddPermissionToTeam(ctx, dtrack.Permission{Name: permission}, team.UUID)
+			var apiErr *dtrack.APIError
+			// 304 when the team already has the permission
+			if err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == 304) {
+				return "", nil, fmt.Errorf("failed to grant %s to the %s team: %w", permission, automationTeam, err)
+			}
+		}
+
+		key, err := admin.Team.GenerateAPIKey(ctx, team.UUID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to create an API key: %w", err)
+		}
+		return key.Key, admin, nil
+	}
+
+	return "", nil, fmt.Errorf("team %s not found in Dependency-Track", automationTeam)
+}
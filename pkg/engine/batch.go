@@ -0,0 +1,82 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -------------------------------------------------------------------------
+
+package engine
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// uploadInBatches drains the iterator into chunks of size SBOMs and hands each chunk to the
+// output adapter. A failed batch is logged and the remaining batches are still uploaded,
+// like a failed SBOM on the per-item path.
+func uploadInBatches(ctx tcontext.TransferMetadata, output adapter.BatchAdapter, iter iterator.SBOMIterator, size int) error {
+	logger.LogDebug(ctx.Context, "Uploading SBOMs in batches", "batch_size", size)
+
+	var batch []*iterator.SBOM
+	batches, sboms, failedBatches := 0, 0, 0
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		batches++
+		sboms += len(batch)
+
+		logger.LogDebug(ctx.Context, "Uploading batch", "batch", batches, "size", len(batch))
+		if err := output.UploadBatch(ctx, batch); err != nil {
+			failedBatches++
+			logger.LogError(ctx.Context, err, "Failed to upload batch", "batch", batches, "size", len(batch))
+		}
+
+		// adapters may hold on to the slice, start a new one
+		batch = make([]*iterator.SBOM, 0, size)
+	}
+
+	for {
+		sbom, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
+			continue
+		}
+
+		batch = append(batch, sbom)
+		if len(batch) >= size {
+			flush()
+		}
+	}
+
+	if ctx.Err() == nil {
+		flush()
+	}
+
+	logger.LogInfo(ctx.Context, "upload", "batches", batches, "sboms", sboms, "failed_batches", failedBatches)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d batches: %w", batches, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -------------------------------------------------------------------------
+
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	adapter "github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// CleanupRun removes from the output adapter what the transfer run opts.RunID created there
+func CleanupRun(ctx context.Context, cmd *cobra.Command, config types.Config, opts types.CleanupOptions) error {
+	logger.LogDebug(ctx, "Starting cleanup process", "run_id", opts.RunID)
+
+	transferCtx := tcontext.NewTransferMetadata(ctx)
+
+	adapters, _, oAdp, err := adapter.NewAdapter(*transferCtx, config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize adapters: %w", err)
+	}
+	transferCtx.SetDestinationAdapter(oAdp)
+
+	outputAdapterInstance := adapters[types.OutputAdapterRole]
+	if outputAdapterInstance == nil {
+		return fmt.Errorf("failed to initialize output adapter")
+	}
+
+	cleaner, ok := outputAdapterInstance.(adapter.CleanupAdapter)
+	if !ok {
+		return fmt.Errorf("output adapter %s does not support cleanup", config.DestinationAdapter)
+	}
+
+	if err := outputAdapterInstance.ParseAndValidateParams(cmd); err != nil {
+		return fmt.Errorf("output adapter error: %w", err)
+	}
+
+	if err := cleaner.Cleanup(*transferCtx, opts); err != nil {
+		return fmt.Errorf("cleanup failed: %w", err)
+	}
+
+	logger.LogDebug(ctx, "Cleanup process completed", "run_id", opts.RunID)
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -------------------------------------------------------------------------
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	adapter "github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// EstimateRun lists the SBOMs the input adapter would fetch, without downloading them, and
+// prints their count, estimated size and what they'd become at the destination adapter
+func EstimateRun(ctx context.Context, cmd *cobra.Command, config types.Config) error {
+	logger.LogDebug(ctx, "Starting estimate", "input", config.SourceAdapter, "output", config.DestinationAdapter)
+
+	transferCtx := tcontext.NewTransferMetadata(ctx)
+
+	// only the input adapter is initialized, the destination is never contacted
+	adapters, iAdp, _, err := adapter.NewAdapter(*transferCtx, types.Config{
+		SourceAdapter:      config.SourceAdapter,
+		ProcessingStrategy: config.ProcessingStrategy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize adapters: %w", err)
+	}
+	transferCtx.SetSourceAdapter(iAdp)
+	source.AttachFormatFilter(transferCtx, config.FormatFilter)
+
+	inputAdapterInstance := adapters[types.InputAdapterRole]
+	if inputAdapterInstance == nil {
+		return fmt.Errorf("failed to initialize input adapter")
+	}
+
+	estimator, ok := inputAdapterInstance.(adapter.EstimateAdapter)
+	if !ok {
+		return fmt.Errorf("input adapter %s does not support estimates", config.SourceAdapter)
+	}
+
+	if err := inputAdapterInstance.ParseAndValidateParams(cmd); err != nil {
+		return fmt.Errorf("input adapter error: %w", err)
+	}
+
+	candidates, err := estimator.Estimate(*transferCtx)
+	if err != nil {
+		return fmt.Errorf("estimate failed: %w", err)
+	}
+
+	printEstimate(config, candidates)
+	return nil
+}
+
+// printEstimate prints the totals of the candidates, per source and at the destination
+func printEstimate(config types.Config, candidates []types.SBOMCandidate) {
+	type sourceTotal struct {
+		sboms int
+		bytes int64
+	}
+
+	var totalBytes int64
+	unknownSize := 0
+	bySource := map[string]*sourceTotal{}
+	for _, c := range candidates {
+		totalBytes += c.Size
+		if c.Size == 0 {
+			unknownSize++
+		}
+
+		st, ok := bySource[c.Namespace]
+		if !ok {
+			st = &sourceTotal{}
+			bySource[c.Namespace] = st
+		}
+		st.sboms++
+		st.bytes += c.Size
+	}
+
+	sources := make([]string, 0, len(bySource))
+	for name := range bySource {
+		sources = append(sources, name)
+	}
+	sort.Strings(sources)
+
+	fmt.Println()
+	fmt.Printf("📦 Input adapter: %s\n", config.SourceAdapter)
+	fmt.Printf("📊 Candidate SBOMs: %d\n", len(candidates))
+	fmt.Printf("💾 Estimated size: %s", utils.FormatByteSize(totalBytes))
+	if unknownSize > 0 {
+		fmt.Printf(" (plus %d SBOMs of unknown size, generated during the transfer)", unknownSize)
+	}
+	fmt.Println()
+
+	fmt.Printf("📂 Sources: %d\n", len(sources))
+	for _, name := range sources {
+		st := bySource[name]
+		fmt.Printf("   - %s: %d SBOMs, %s\n", name, st.sboms, utils.FormatByteSize(st.bytes))
+	}
+
+	for _, destination := range config.DestinationAdapters() {
+		switch types.AdapterType(destination) {
+		case types.FolderAdapterType:
+			fmt.Printf("🎯 Expected at destination (folder): %d files\n", len(candidates))
+		case types.S3AdapterType:
+			fmt.Printf("🎯 Expected at destination (s3): %d objects\n", len(candidates))
+		case types.AzureBlobAdapterType:
+			fmt.Printf("🎯 Expected at destination (azblob): %d blobs\n", len(candidates))
+		case types.GCSAdapterType:
+			fmt.Printf("🎯 Expected at destination (gcs): %d objects\n", len(candidates))
+		case types.DtrackAdapterType:
+			fmt.Printf("🎯 Expected at destination (dtrack): up to %d project versions\n", len(candidates))
+		case types.InterlynkAdapterType:
+			fmt.Printf("🎯 Expected at destination (interlynk): up to %d project groups, %d SBOM versions\n", len(candidates), len(candidates))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("ℹ️  Candidates are judged by name, SBOMs whose content turns out invalid are skipped during the transfer.")
+	fmt.Println()
+}
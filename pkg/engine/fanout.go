@@ -0,0 +1,52 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// prepareOutputs has each output of a transfer with several convert and sign the SBOMs for
+// itself and upload them like a single output would, in batches where it supports them. The
+// SBOMs reach the outputs enriched and scored, gatingStages run once ahead of the fan-out.
+func prepareOutputs(multi *adapter.MultiOutputAdapter, config types.Config) {
+	outputConfig := func(name string) types.Config {
+		c := config
+		c.DestinationAdapter = name
+		return c
+	}
+
+	multi.Prepare = func(ctx tcontext.TransferMetadata, name string, sboms iterator.SBOMIterator) iterator.SBOMIterator {
+		// conversion errors are skipped up to the budget of each output
+		c := outputConfig(name)
+		stages := append(processingStages(ctx, c), signingStages(ctx, c)...)
+		converted := iterator.NewErrorBudgetIterator(iterator.Pipeline(sboms, stages...), config.MaxIteratorErrors)
+		converted.OnError(recordIteratorFailure)
+		return converted
+	}
+
+	multi.Upload = func(ctx tcontext.TransferMetadata, name string, output adapter.Adapter, sboms iterator.SBOMIterator) error {
+		if err := uploadSBOMs(ctx, outputConfig(name), output, sboms); err != nil {
+			return err
+		}
+		if budget, ok := sboms.(*iterator.ErrorBudgetIterator); ok {
+			return budget.Err()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	adapter "github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// FetchRun fetches the SBOMs of the input adapter and writes them, unchanged, to outputDir
+// under their source file names. With no outputDir they are written one after another to
+// the command's stdout, and the progress lines go to its stderr.
+func FetchRun(ctx context.Context, cmd *cobra.Command, config types.Config, outputDir string) error {
+	logger.LogDebug(ctx, "Starting fetch", "input", config.SourceAdapter, "output_dir", outputDir)
+
+	transferCtx := tcontext.NewTransferMetadata(ctx)
+
+	// only the input adapter is initialized, there is no output
+	adapters, iAdp, _, err := adapter.NewAdapter(*transferCtx, types.Config{
+		SourceAdapter:      config.SourceAdapter,
+		ProcessingStrategy: config.ProcessingStrategy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize adapters: %w", err)
+	}
+	transferCtx.SetSourceAdapter(iAdp)
+	source.AttachFormatFilter(transferCtx, config.FormatFilter)
+
+	inputAdapterInstance := adapters[types.InputAdapterRole]
+	if inputAdapterInstance == nil {
+		return fmt.Errorf("failed to initialize input adapter")
+	}
+
+	if err := inputAdapterInstance.ParseAndValidateParams(cmd); err != nil {
+		return fmt.Errorf("input adapter error: %w", err)
+	}
+
+	sbomIterator, err := inputAdapterInstance.FetchSBOMs(*transferCtx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch SBOMs: %w", err)
+	}
+	if config.FormatFilter != nil {
+		sbomIterator = iterator.Transform(sbomIterator, iterator.FilterFormats(config.FormatFilter))
+	}
+
+	budgetIterator := iterator.NewErrorBudgetIterator(sbomIterator, config.MaxIteratorErrors)
+
+	// the SBOMs own stdout when no directory is given
+	status := cmd.OutOrStdout()
+	if outputDir == "" {
+		status = cmd.ErrOrStderr()
+	} else if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
+	}
+
+	collisions := utils.NewNameCollisions()
+	var fetched int
+	var size int64
+	for {
+		doc, err := budgetIterator.Next(*transferCtx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if outputDir == "" {
+			if err := writeSBOM(cmd.OutOrStdout(), doc.Data); err != nil {
+				return fmt.Errorf("failed to write SBOM %s to stdout: %w", doc.Path, err)
+			}
+		} else {
+			name := fetchedFileName(doc)
+			if resolved, collided := collisions.Resolve(name, doc.Data); collided {
+				logger.LogInfo(ctx, "Duplicate file name with different content, writing with content-hash suffix", "file", name, "written_as", resolved, "namespace", doc.Namespace)
+				name = resolved
+			}
+
+			outputFile := filepath.Join(outputDir, name)
+			if err := os.WriteFile(outputFile, doc.Data, 0o644); err != nil {
+				return fmt.Errorf("failed to write SBOM %s: %w", outputFile, err)
+			}
+			fmt.Fprintf(status, "📥 %s\n", outputFile)
+		}
+
+		fetched++
+		size += int64(len(doc.Data))
+	}
+
+	fmt.Fprintln(status)
+	fmt.Fprintf(status, "📊 SBOMs fetched: %d (%s)\n", fetched, utils.FormatByteSize(size))
+	if skipped := budgetIterator.Errors(); skipped > 0 {
+		fmt.Fprintf(status, "⚠️  SBOMs that couldn't be fetched: %d\n", skipped)
+	}
+
+	return budgetIterator.Err()
+}
+
+// fetchedFileName returns the name an SBOM is written as in the output directory: the base
+// name of its source path, or one derived from its content when it has none
+func fetchedFileName(doc *iterator.SBOM) string {
+	// source paths may be object keys or asset URLs, only their last element is kept
+	name := path.Base(filepath.ToSlash(doc.Path))
+	if doc.Path == "" || name == "." || name == "/" || name == ".." {
+		name = fmt.Sprintf("sbom-%s.json", sbom.ComputeContentHash(doc.Data)[:12])
+	}
+	return name
+}
+
+// writeSBOM writes an SBOM to w, ending it with a newline so consecutive SBOMs don't run together
+func writeSBOM(w io.Writer, data []byte) error {
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		_, err := io.WriteString(w, "\n")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	adapter "github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// runPreflight checks that the rate limits of the input's APIs cover the transfer before
+// anything is fetched. A transfer that can't complete is aborted with PreflightStrict and only
+// warned about otherwise. Failing to run the check never stops the transfer.
+func runPreflight(ctx tcontext.TransferMetadata, mode types.PreflightMode, input adapter.Adapter) error {
+	preflighter, ok := input.(adapter.PreflightAdapter)
+	if mode == types.PreflightOff || !ok {
+		return nil
+	}
+
+	checks, err := preflighter.Preflight(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logger.LogInfo(ctx.Context, "Unable to check API rate limits, transferring anyway", "error", err)
+		return nil
+	}
+
+	var insufficient []string
+	for _, check := range checks {
+		if check.Sufficient() {
+			logger.LogDebug(ctx.Context, "Rate limit covers the transfer", "source", check.Source, "calls", check.Calls, "remaining", check.Remaining, "limit", check.Limit)
+			continue
+		}
+
+		reason := fmt.Sprintf("%s needs about %d API calls but %d of %d are left until %s", check.Source, check.Calls, check.Remaining, check.Limit, check.Reset.Format(time.RFC3339))
+		if check.Calls > check.Limit {
+			reason += ", more than a full rate limit window"
+		}
+		insufficient = append(insufficient, reason)
+		logger.LogInfo(ctx.Context, "Rate limit too low for the transfer to complete", "source", check.Source, "calls", check.Calls, "remaining", check.Remaining, "limit", check.Limit, "reset", check.Reset.Format(time.RFC3339))
+	}
+
+	if len(insufficient) > 0 && mode == types.PreflightStrict {
+		return errdefs.RateLimit(fmt.Errorf("preflight failed: %s (use --preflight=warn to transfer anyway)", strings.Join(insufficient, "; ")))
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -------------------------------------------------------------------------
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	adapter "github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/checkpoint"
+	"github.com/interlynk-io/sbommv/pkg/converter"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// envOnce makes adapters read their credentials from the environment, as the CLI has them do
+var envOnce sync.Once
+
+// Options configure a transfer started with Run, for Go programs embedding sbommv
+type Options struct {
+	// adapters SBOMs are fetched from and uploaded to
+	Input  AdapterOptions
+	Output AdapterOptions
+
+	// settings of the transfer, start from DefaultConfig. SourceAdapter and DestinationAdapter
+	// are set from Input and Output, and Schedule isn't supported: call Run on a schedule instead.
+	Transfer types.Config
+}
+
+// AdapterOptions select an adapter and its settings
+type AdapterOptions struct {
+	Type types.AdapterType
+
+	// settings of the adapter, named after its flags without the "in-<type>-" or "out-<type>-"
+	// prefix, e.g. {"path": "sboms", "recursive": "true"} for the folder input. Credentials
+	// are read from the environment, as the CLI does.
+	Params map[string]string
+}
+
+// DefaultConfig returns the transfer settings `sbommv transfer` uses when no flag changes them,
+// without a progress display
+func DefaultConfig() types.Config {
+	return types.Config{
+		ProcessingStrategy:      string(types.FetchSequential),
+		Retries:                 3,
+		RetryBackoff:            time.Second,
+		MaxIteratorErrors:       5,
+		PipelineBuffer:          16,
+		Detection:               types.DetectionLenient,
+		SPDXUpgrade:             types.SPDXUpgradeOn,
+		OutputFormat:            types.OutputFormatOriginal,
+		ConversionTargetVersion: converter.DefaultCycloneDXTargetVersion,
+		Preflight:               types.PreflightWarn,
+		Progress:                types.ProgressOff,
+	}
+}
+
+// Run transfers SBOMs from the input to the output adapter and returns the volume it
+// transferred, as `sbommv transfer` does. It logs through the logger of ctx, see
+// logger.WithLogger, and logs nothing without one. Runs don't share state and may run
+// concurrently, though identical runs share their default checkpoint file and can't overlap.
+func Run(ctx context.Context, opts Options) (report.Summary, error) {
+	config := opts.Transfer
+	config.SourceAdapter = string(opts.Input.Type)
+	config.DestinationAdapter = string(opts.Output.Type)
+	if config.Schedule != nil {
+		return report.Summary{}, fmt.Errorf("schedules aren't supported by Run, call it on a schedule instead")
+	}
+
+	// settings left empty take their default, zero numbers are taken as they are
+	defaults := DefaultConfig()
+	if config.ProcessingStrategy == "" {
+		config.ProcessingStrategy = defaults.ProcessingStrategy
+	}
+	if config.Detection == "" {
+		config.Detection = defaults.Detection
+	}
+	if config.SPDXUpgrade == "" {
+		config.SPDXUpgrade = defaults.SPDXUpgrade
+	}
+	if config.OutputFormat == "" {
+		config.OutputFormat = defaults.OutputFormat
+	}
+	if config.ConversionTargetVersion == "" {
+		config.ConversionTargetVersion = defaults.ConversionTargetVersion
+	}
+	if config.Preflight == "" {
+		config.Preflight = defaults.Preflight
+	}
+	if config.Progress == "" {
+		config.Progress = defaults.Progress
+	}
+	if config.RunID == "" {
+		config.RunID = uuid.NewString()
+	}
+
+	envOnce.Do(viper.AutomaticEnv)
+	cmd, err := adapterCommand(ctx, opts)
+	if err != nil {
+		return report.Summary{}, err
+	}
+	if config.CheckpointFile == "" {
+		config.CheckpointFile = checkpoint.DefaultPath(config.SourceAdapter, config.DestinationAdapter, adapter.Settings(cmd))
+	}
+	return TransferRunWithReport(ctx, cmd, config)
+}
+
+// adapterCommand returns a command carrying the settings of the adapters as flags, for them
+// to parse and validate as they do on the command line
+func adapterCommand(ctx context.Context, opts Options) (*cobra.Command, error) {
+	cmd := &cobra.Command{Use: "transfer"}
+	adapter.RegisterFlags(cmd)
+	cmd.SetContext(ctx)
+
+	for _, a := range []struct {
+		role   types.AdapterRole
+		prefix types.FlagPrefix
+		opts   AdapterOptions
+	}{
+		{types.InputAdapterRole, types.InputAdapterFlagPrefix, opts.Input},
+		{types.OutputAdapterRole, types.OutputAdapterFlagPrefix, opts.Output},
+	} {
+		// in a fixed order, for the first invalid setting to be reported consistently
+		names := make([]string, 0, len(a.opts.Params))
+		for name := range a.opts.Params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			flag := fmt.Sprintf("%s-%s-%s", a.prefix, a.opts.Type, name)
+			if cmd.Flags().Lookup(flag) == nil {
+				return nil, fmt.Errorf("unknown setting %q of the %s %s adapter", name, a.opts.Type, a.role)
+			}
+			if err := cmd.Flags().Set(flag, a.opts.Params[name]); err != nil {
+				return nil, fmt.Errorf("invalid setting %q of the %s %s adapter: %w", name, a.opts.Type, a.role, err)
+			}
+		}
+	}
+	return cmd, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -------------------------------------------------------------------------
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// ScheduledTransferRun runs the configured transfer every time config.Schedule fires, until
+// ctx is cancelled. Each run gets its own run ID, config.RunID suffixed with its start time.
+// A failed run is logged and the next one still happens.
+func ScheduledTransferRun(ctx context.Context, cmd *cobra.Command, config types.Config) error {
+	sched := config.Schedule
+	baseRunID := config.RunID
+
+	for {
+		next := sched.Next(time.Now())
+		if next.IsZero() {
+			return fmt.Errorf("schedule %q never fires", sched)
+		}
+		logger.LogInfo(ctx, "Next scheduled transfer", "schedule", sched.String(), "at", next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			logger.LogInfo(ctx, "Scheduled transfers stopped")
+			return nil
+		case <-timer.C:
+		}
+
+		runConfig := config
+		runConfig.RunID = fmt.Sprintf("%s-%s", baseRunID, next.UTC().Format("20060102T150405Z"))
+
+		if err := TransferRun(ctx, cmd, runConfig); err != nil {
+			if ctx.Err() != nil {
+				logger.LogInfo(ctx, "Scheduled transfers stopped")
+				return nil
+			}
+			logger.LogError(ctx, err, "Scheduled transfer failed", "run_id", runConfig.RunID)
+		}
+	}
+}
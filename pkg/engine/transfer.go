@@ -19,20 +19,57 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"time"
 
 	adapter "github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/cache"
+	"github.com/interlynk-io/sbommv/pkg/checkpoint"
+	"github.com/interlynk-io/sbommv/pkg/converter"
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
 	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/limiter"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/monitor"
+	"github.com/interlynk-io/sbommv/pkg/progress"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/retry"
 	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/sign"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/status"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/timing"
 	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/interlynk-io/sbommv/pkg/verify"
 	"github.com/spf13/cobra"
 )
 
 func TransferRun(ctx context.Context, cmd *cobra.Command, config types.Config) error {
+	return transferRun(ctx, cmd, config, nil, report.NewCollector(config.DestinationAdapter))
+}
+
+// TransferRunWithReport runs a transfer like TransferRun and also returns the volume it transferred
+func TransferRunWithReport(ctx context.Context, cmd *cobra.Command, config types.Config) (report.Summary, error) {
+	volume := report.NewCollector(config.DestinationAdapter)
+	err := transferRun(ctx, cmd, config, nil, volume)
+	return volume.Summary(), err
+}
+
+// UploadRun transfers the SBOMs read by input, e.g. the files given to `sbommv upload`, to
+// the output adapters of config. config.SourceAdapter names the input in logs and tags only.
+func UploadRun(ctx context.Context, cmd *cobra.Command, config types.Config, input adapter.Adapter) (report.Summary, error) {
+	volume := report.NewCollector(config.DestinationAdapter)
+	err := transferRun(ctx, cmd, config, input, volume)
+	return volume.Summary(), err
+}
+
+// transferRun runs a transfer from the input adapter of config, or from input when it is given
+func transferRun(ctx context.Context, cmd *cobra.Command, config types.Config, input adapter.Adapter, volume *report.Collector) error {
 	logger.LogDebug(ctx, "Starting SBOM transfer process....")
 
 	// Initialize shared context with metadata support
@@ -41,19 +78,98 @@ func TransferRun(ctx context.Context, cmd *cobra.Command, config types.Config) e
 	var inputAdapterInstance, outputAdapterInstance adapter.Adapter
 	var err error
 
-	if config.SourceAdapter == "github" && config.Daemon {
-		config.Overwrite = true
-		logger.LogDebug(transferCtx.Context, "overwrite flag set to true for github adapter", "overwrite_value", config.Overwrite)
+	if config.Daemon {
+		for _, source := range config.SourceAdapters() {
+			if source == "github" || source == "s3" {
+				config.Overwrite = true
+				logger.LogDebug(transferCtx.Context, "overwrite flag set to true for daemon mode", "input", source, "overwrite_value", config.Overwrite)
+				break
+			}
+		}
 	}
 
-	adapters, iAdp, oAdp, err := adapter.NewAdapter(*transferCtx, config)
+	adapterConfig := config
+	if input != nil {
+		// the input is given, only the output adapters are initialized
+		adapterConfig.SourceAdapter = ""
+	}
+	adapters, iAdp, oAdp, err := adapter.NewAdapter(*transferCtx, adapterConfig)
 	if err != nil {
-		return fmt.Errorf("failed to initialize adapters: %v", err)
+		return fmt.Errorf("failed to initialize adapters: %w", err)
+	}
+	if input != nil {
+		adapters[types.InputAdapterRole] = input
+		iAdp = config.SourceAdapter
 	}
 
 	// store source adapter type and destination adapter using ctx for later use
-	transferCtx.WithValue("source", iAdp)
-	transferCtx.WithValue("destination", oAdp)
+	transferCtx.SetSourceAdapter(iAdp)
+	transferCtx.SetDestinationAdapter(oAdp)
+	transferCtx.SetRunID(config.RunID)
+
+	retry.Attach(transferCtx, retry.Policy{Retries: config.Retries, Backoff: config.RetryBackoff})
+	cache.Attach(transferCtx, config.Cache)
+
+	// engine-wide budget of uploads in flight, shared by all uploader workers
+	transferLimiter := limiter.New(config.MaxConcurrentTransfers)
+	if transferLimiter == nil && config.Daemon && config.StatusSocket != "" {
+		// count the uploads in flight for the status socket, without limiting them
+		transferLimiter = limiter.NewUnlimited()
+	}
+	limiter.Attach(transferCtx, transferLimiter)
+	if config.MaxConcurrentTransfers > 0 {
+		logger.LogDebug(transferCtx.Context, "Transfer concurrency limited", "max_concurrent_transfers", config.MaxConcurrentTransfers)
+		if config.Daemon {
+			go reportTransferQueue(*transferCtx, transferLimiter, transferQueueReportInterval)
+		}
+	}
+
+	// bytes transferred and estimated storage, broken down by format and source
+	report.Attach(transferCtx, volume)
+	if config.Daemon {
+		go reportTransferVolume(*transferCtx, volume, transferQueueReportInterval)
+	}
+
+	// per-stage timings of each SBOM, in the debug log
+	timings := timing.NewRecorder()
+	timing.Attach(transferCtx, timings)
+
+	// sources skip SBOMs of unwanted formats before downloading them where they can
+	source.AttachFormatFilter(transferCtx, config.FormatFilter)
+	source.AttachDetection(transferCtx, config.Detection, report.RecordRejection)
+	converter.AttachSPDXUpgrade(transferCtx, config.SPDXUpgrade)
+
+	// SBOMs scoring below --min-ntia-score are skipped, by every output
+	var compliance *iterator.ComplianceGate
+	if config.MinNTIAScore > 0 {
+		compliance = iterator.NewComplianceGate(config.MinNTIAScore)
+		compliance.OnRejected(recordNonCompliantSBOM)
+		transferCtx.WithValue(complianceGateKey, compliance)
+	}
+
+	// SBOMs are signed before they are stored, dry runs don't sign them
+	if config.Signing != nil && !config.DryRun {
+		signer, err := sign.New(*config.Signing)
+		if err != nil {
+			return fmt.Errorf("failed to set up signing: %w", err)
+		}
+		sign.Attach(transferCtx, signer)
+	}
+
+	// sources fetch the signatures published along with SBOMs, for them to be verified
+	var signatures *iterator.SignatureChecker
+	if config.VerifySignatures != nil {
+		verifier, err := verify.New(*config.VerifySignatures)
+		if err != nil {
+			return fmt.Errorf("failed to set up signature verification: %w", err)
+		}
+		signatures = iterator.NewSignatureChecker(verifier)
+		signatures.OnRejected(recordUnverifiedSBOM)
+		source.AttachSignatureFetching(transferCtx)
+	}
+	converter.AttachCycloneDXTargetVersion(transferCtx, config.ConversionTargetVersion)
+
+	logger.LogInfo(transferCtx.Context, "Starting transfer run", "run_id", config.RunID, "input", iAdp, "output", oAdp)
 
 	// Extract input and output adapters using predefined roles
 	inputAdapterInstance = adapters[types.InputAdapterRole]
@@ -77,10 +193,36 @@ func TransferRun(ctx context.Context, cmd *cobra.Command, config types.Config) e
 
 	logger.LogDebug(transferCtx.Context, "Output adapter instance config", "value", outputAdapterInstance)
 
+	// record the SBOMs transferred, for an interrupted transfer to be resumed; uploads of given
+	// files have no checkpoint
+	var cp *checkpoint.Checkpoint
+	var completed bool
+	if !config.Daemon && !config.DryRun && config.CheckpointFile != "" {
+		cp, err = checkpoint.Open(ctx, config.CheckpointFile, config.DestinationAdapters(), config.Resume)
+		if err != nil {
+			return fmt.Errorf("failed to open checkpoint: %w", err)
+		}
+		defer func() {
+			if err := cp.Close(completed); err != nil {
+				logger.LogError(ctx, err, "Failed to close checkpoint", "path", config.CheckpointFile)
+			}
+		}()
+		volume.OnTransfer(func(destination string, doc *iterator.SBOM) {
+			cp.Record(*transferCtx, destination, doc)
+		})
+	}
+
 	var sbomIterator iterator.SBOMIterator
+	var fetchProgress *progress.Tracker
 
 	// fetch SBOMs in daemon mode
 	if config.Daemon {
+		if config.StatusSocket != "" {
+			tracker := status.NewTracker()
+			status.Attach(transferCtx, tracker)
+			go serveStatus(*transferCtx, config, tracker, transferLimiter, volume)
+		}
+
 		if ma, ok := inputAdapterInstance.(monitor.MonitorAdapter); ok {
 			sbomIterator, err = ma.Monitor(*transferCtx)
 			if err != nil {
@@ -90,40 +232,334 @@ func TransferRun(ctx context.Context, cmd *cobra.Command, config types.Config) e
 			return fmt.Errorf("input adapter %s does not support daemon mode", config.SourceAdapter)
 		}
 	} else {
+		// fail before fetching when the input's rate limit can't cover the transfer
+		if err := runPreflight(*transferCtx, config.Preflight, inputAdapterInstance); err != nil {
+			return err
+		}
+
 		// fetch SBOMs in one go
+		fetchStart := time.Now()
 		sbomIterator, err = inputAdapterInstance.FetchSBOMs(*transferCtx)
 		if err != nil {
 			return fmt.Errorf("failed to fetch SBOMs: %w", err)
 		}
+		timing.RecordSetup(*transferCtx, time.Since(fetchStart))
+
+		// count the SBOMs fetched, for the progress display
+		if !config.DryRun {
+			fetchProgress = progress.NewTracker(volume)
+			sbomIterator = fetchProgress.Count(sbomIterator)
+		}
+
+		// daemon iterators wait for new SBOMs, only one-shot fetches are timed per SBOM
+		sbomIterator = iterator.NewTimedIterator(sbomIterator)
+	}
+	defer timings.Log(*transferCtx)
+
+	// hash SBOMs as fetched, for destinations to tell whether they changed since the last run
+	sbomIterator = iterator.Transform(sbomIterator, iterator.HashContent)
+
+	// skip SBOMs transferred before the checkpoint
+	if cp != nil {
+		sbomIterator = iterator.Transform(sbomIterator, cp.Skip)
+	}
+
+	// drop SBOMs of unwanted formats the sources couldn't tell from their names
+	if config.FormatFilter != nil {
+		sbomIterator = iterator.Transform(sbomIterator, iterator.FilterFormats(config.FormatFilter))
 	}
 
-	// process SBOMs for conversion
-	convertedIterator := sbomProcessing(*transferCtx, config, sbomIterator)
+	// drop SBOMs of unwanted spec versions, before they are converted to another one
+	if config.SpecVersionFilter != nil {
+		sbomIterator = iterator.Transform(sbomIterator, iterator.FilterSpecVersions(config.SpecVersionFilter))
+	}
+
+	// drop SBOMs whose signature doesn't verify, as fetched before anything changes them
+	if signatures != nil {
+		sbomIterator = iterator.Transform(sbomIterator, signatures.Skip)
+	}
+
+	// skip SBOMs with the same content as one seen before, ahead of validating and converting them
+	var dedup *iterator.Deduplicator
+	if config.Dedup {
+		dedup = iterator.NewDeduplicator()
+		sbomIterator = iterator.Transform(sbomIterator, dedup.Skip)
+	}
+
+	// check SBOMs against their schema before they are converted
+	var validator *iterator.ValidatingIterator
+	if config.Validate != types.ValidationOff {
+		validator = iterator.NewValidatingIterator(sbomIterator, config.Validate == types.ValidationFail)
+		validator.OnInvalid(recordInvalidSBOM)
+		sbomIterator = validator
+	}
+
+	// stop after --limit SBOMs, counting those left after filtering and validation
+	var limitIter *iterator.LimitIterator
+	if config.Limit > 0 {
+		limitIter = iterator.NewLimitIterator(sbomIterator, config.Limit)
+		sbomIterator = limitIter
+		fetchProgress.Limit(config.Limit)
+	}
+
+	// merge the SBOMs of each project into one, once all of them are fetched
+	if config.MergePerProject {
+		sbomIterator = iterator.NewProjectMergeIterator(sbomIterator, report.StageMerge)
+	}
+
+	// split monorepo SBOMs into one SBOM per top-level component
+	if config.SplitByComponent {
+		sbomIterator = iterator.NewComponentSplitIterator(sbomIterator, report.StageSplit)
+	}
+
+	// process SBOMs for conversion, with several outputs they are enriched and scored once
+	// and each output converts them for itself
+	var convertedIterator iterator.SBOMIterator
+	if multi, ok := outputAdapterInstance.(*adapter.MultiOutputAdapter); ok {
+		convertedIterator = iterator.Pipeline(sbomIterator, gatingStages(*transferCtx, config)...)
+		prepareOutputs(multi, config)
+	} else {
+		convertedIterator = sbomProcessing(*transferCtx, config, sbomIterator)
+	}
+
+	// iterator errors are skipped up to the budget, uploaders only count upload results
+	budgetIterator := iterator.NewErrorBudgetIterator(convertedIterator, config.MaxIteratorErrors)
+	budgetIterator.OnError(recordIteratorFailure)
 
 	if config.DryRun {
 		if config.Daemon {
 		}
 		logger.LogDebug(transferCtx.Context, "Dry-run mode enabled: Displaying retrieved SBOMs", "values", config.DryRun)
-		dryRun(*transferCtx, convertedIterator, inputAdapterInstance, outputAdapterInstance, config)
-		return nil
+		dryRun(*transferCtx, budgetIterator, inputAdapterInstance, outputAdapterInstance, config)
+		if rejected := signatures.Rejected(); rejected > 0 {
+			logger.LogInfo(ctx, "SBOMs rejected by signature verification", "count", rejected)
+		}
+		logCompliance(ctx, compliance, config.MinNTIAScore)
+		if duplicates := dedup.Duplicates(); duplicates > 0 {
+			logger.LogInfo(ctx, "SBOMs skipped as duplicates", "count", duplicates)
+		}
+		if limitIter.Reached() {
+			logger.LogInfo(ctx, "Dry run stopped at --limit", "limit", config.Limit)
+		}
+		if err := validator.Err(); err != nil {
+			return errdefs.Validation(err)
+		}
+		return budgetIterator.Err()
 	}
 
-	// Process & Upload SBOMs Sequentially
-	if err := outputAdapterInstance.UploadSBOMs(*transferCtx, convertedIterator); err != nil {
+	// Process & Upload SBOMs, in batches where the output adapter supports it, while the next ones are fetched
+	stopProgress := progress.Show(*transferCtx, fetchProgress, config.Progress, os.Stderr)
+	err = uploadPipelined(*transferCtx, config, outputAdapterInstance, budgetIterator)
+	stopProgress()
+	if err != nil {
+		if ctx.Err() != nil {
+			// report what was transferred before the run was cancelled
+			volume.Log(*transferCtx)
+			reportResults(*transferCtx, config, volume)
+			logger.LogInfo(ctx, "Transfer run interrupted", "run_id", config.RunID)
+		} else if errors.Is(err, report.ErrRejectedByDestination) {
+			volume.Log(*transferCtx)
+			reportResults(*transferCtx, config, volume)
+		}
 		return fmt.Errorf("%w", err)
 	}
 
+	if skipped := budgetIterator.Errors(); skipped > 0 {
+		logger.LogInfo(ctx, "SBOMs skipped due to iterator errors", "count", skipped)
+	}
+
+	if err := validator.Err(); err != nil {
+		reportResults(*transferCtx, config, volume)
+		return errdefs.Validation(fmt.Errorf("stopped at invalid SBOM: %w", err))
+	}
+
+	if invalid := validator.Invalid(); invalid > 0 {
+		logger.LogInfo(ctx, "SBOMs skipped due to failed validation", "count", invalid)
+	}
+
+	if err := budgetIterator.Err(); err != nil {
+		reportResults(*transferCtx, config, volume)
+		return err
+	}
+
+	volume.Log(*transferCtx)
+	reportResults(*transferCtx, config, volume)
+
+	if transferLimiter != nil {
+		stats := transferLimiter.Stats()
+		logger.LogDebug(ctx, "Transfer queue", "limit", stats.Limit, "completed", stats.Completed, "max_queued", stats.MaxQueued)
+	}
+
+	if skipped := cp.Skipped(); skipped > 0 {
+		logger.LogInfo(ctx, "SBOMs skipped, transferred before the checkpoint", "count", skipped)
+	}
+
+	if rejected := signatures.Rejected(); rejected > 0 {
+		logger.LogInfo(ctx, "SBOMs rejected by signature verification", "count", rejected)
+	}
+
+	logCompliance(ctx, compliance, config.MinNTIAScore)
+
+	if duplicates := dedup.Duplicates(); duplicates > 0 {
+		logger.LogInfo(ctx, "SBOMs skipped as duplicates", "count", duplicates)
+	}
+
+	// the checkpoint is kept, for --resume to go on with the next SBOMs
+	if limitIter.Reached() {
+		logger.LogInfo(ctx, "Transfer stopped at --limit", "limit", config.Limit)
+	}
+	completed = len(volume.Failures()) == 0 && !limitIter.Reached()
+
+	logger.LogInfo(ctx, "Transfer run completed", "run_id", config.RunID)
 	logger.LogDebug(ctx, "SBOM transfer process completed successfully ✅")
 	return nil
 }
 
+// uploadSBOMs hands the SBOMs to the output adapter in chunks of --batch-size when it supports
+// batch uploads, and as an iterator otherwise. Daemon mode always uploads SBOMs as they arrive.
+func uploadSBOMs(ctx tcontext.TransferMetadata, config types.Config, output adapter.Adapter, iter iterator.SBOMIterator) error {
+	if config.BatchSize > 0 && !config.Daemon {
+		if batcher, ok := output.(adapter.BatchAdapter); ok {
+			return uploadInBatches(ctx, batcher, iter, config.BatchSize)
+		}
+		logger.LogInfo(ctx.Context, "Output adapter doesn't support batch uploads, uploading SBOMs one at a time", "output", config.DestinationAdapter)
+	}
+	return output.UploadSBOMs(ctx, iter)
+}
+
+// uploadPipelined uploads the SBOMs while the next ones are fetched and processed in their own
+// goroutine, up to --pipeline-buffer SBOMs ahead of the output adapter. With no buffer the
+// output adapter pulls each SBOM through the whole chain itself.
+func uploadPipelined(ctx tcontext.TransferMetadata, config types.Config, output adapter.Adapter, iter iterator.SBOMIterator) error {
+	if config.PipelineBuffer <= 0 {
+		return uploadSBOMs(ctx, config, output, iter)
+	}
+
+	prefetcher := iterator.NewPrefetcher(iter, config.PipelineBuffer)
+	go prefetcher.Run(ctx)
+
+	// the fetch side is done with the iterators before the run reads their results
+	defer prefetcher.Stop()
+
+	logger.LogDebug(ctx.Context, "Pipelining fetch and upload", "pipeline_buffer", config.PipelineBuffer)
+	return uploadSBOMs(ctx, config, output, prefetcher)
+}
+
+// recordIteratorFailure records an SBOM the source or a processing stage failed to produce
+func recordIteratorFailure(ctx tcontext.TransferMetadata, err error) {
+	var stageErr *iterator.StageError
+	if errors.As(err, &stageErr) {
+		report.RecordFailure(ctx, report.Failure{File: stageErr.File, Namespace: stageErr.Namespace, Stage: stageErr.Stage}, stageErr.Err)
+		return
+	}
+	report.RecordFailure(ctx, report.Failure{Stage: report.StageDownload}, err)
+}
+
+// recordInvalidSBOM records an SBOM that failed schema validation
+func recordInvalidSBOM(ctx tcontext.TransferMetadata, err *iterator.ValidationError) {
+	report.RecordFailure(ctx, report.Failure{File: err.File, Namespace: err.Namespace, Stage: report.StageValidate}, err)
+}
+
+// recordUnverifiedSBOM records an SBOM that failed signature verification
+func recordUnverifiedSBOM(ctx tcontext.TransferMetadata, err *iterator.SignatureError) {
+	report.RecordFailure(ctx, report.Failure{File: err.File, Namespace: err.Namespace, Stage: report.StageVerify}, err.Err)
+}
+
+// complianceGateKey is the TransferMetadata key of the --min-ntia-score gate, shared by the
+// processing of every output
+const complianceGateKey = "compliance_gate"
+
+// recordNonCompliantSBOM records an SBOM scoring below --min-ntia-score
+func recordNonCompliantSBOM(ctx tcontext.TransferMetadata, err *iterator.ComplianceError) {
+	score := err.Result.Score
+	report.RecordFailure(ctx, report.Failure{File: err.File, Namespace: err.Namespace, Stage: report.StageComply, NTIAScore: &score}, err)
+}
+
+// logCompliance logs the NTIA scores of the run, when --min-ntia-score scored SBOMs
+func logCompliance(ctx context.Context, gate *iterator.ComplianceGate, minScore float64) {
+	if scored, average, rejected := gate.Stats(); scored > 0 {
+		logger.LogInfo(ctx, "NTIA minimum elements scores", "scored", scored, "average", fmt.Sprintf("%.1f", average), "min_score", minScore, "below_min_score", rejected)
+	}
+}
+
+// reportResults logs the failures of the run grouped by reason and, with --errors-file,
+// writes the full list to the file. With --report-file, every SBOM of the run is written
+// to the report file.
+func reportResults(ctx tcontext.TransferMetadata, config types.Config, volume *report.Collector) {
+	volume.LogRejections(ctx)
+	volume.LogFailures(ctx)
+
+	if config.ReportFile != "" {
+		if err := volume.WriteReport(ctx, config.ReportFile); err != nil {
+			logger.LogError(ctx.Context, err, "Failed to write report file")
+		} else {
+			logger.LogInfo(ctx.Context, "Transfer report written", "path", config.ReportFile)
+		}
+	}
+
+	if config.ErrorsFile == "" {
+		return
+	}
+	if err := volume.WriteFailures(config.ErrorsFile); err != nil {
+		logger.LogError(ctx.Context, err, "Failed to write errors file")
+		return
+	}
+	logger.LogInfo(ctx.Context, "Transfer failures written", "path", config.ErrorsFile, "failed", len(volume.Failures()))
+}
+
+// transferQueueReportInterval is how often daemon mode logs the transfer queue metrics
+const transferQueueReportInterval = time.Minute
+
+// reportTransferQueue periodically logs the transfer queue depth while transfers are active
+func reportTransferQueue(ctx tcontext.TransferMetadata, l *limiter.Limiter, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last limiter.Stats
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := l.Stats()
+			if stats == last {
+				continue
+			}
+			last = stats
+			logger.LogInfo(ctx.Context, "Transfer queue", "in_flight", stats.InFlight, "queued", stats.Queued, "max_queued", stats.MaxQueued, "completed", stats.Completed, "limit", stats.Limit)
+		}
+	}
+}
+
+// reportTransferVolume periodically logs the volume transferred so far in daemon mode
+func reportTransferVolume(ctx tcontext.TransferMetadata, c *report.Collector, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last report.Volume
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			total := c.Summary().Total
+			if total == last {
+				continue
+			}
+			last = total
+			logger.LogInfo(ctx.Context, "Transfer volume", "sboms", total.SBOMs, "transferred", utils.FormatByteSize(total.Bytes), "estimated_storage", utils.FormatByteSize(total.StoredBytes))
+		}
+	}
+}
+
 func dryRun(ctx tcontext.TransferMetadata, sbomIterator iterator.SBOMIterator, input, output adapter.Adapter, config types.Config) error {
 	// dry-run mode for daemon
 	if config.Daemon {
 		logger.LogDebug(ctx.Context, "Dry-run mode in daemon: Previewing SBOMs in real-time")
 		fmt.Println("\n------------------------------------------                                 ------------------------------------------")
 		fmt.Println("------------------------------------------🌐 DAEMON MODE DRY-RUN PREVIEW 🌐------------------------------------------")
-		fmt.Println("------------------------------------------                                 ------------------------------------------\n")
+		fmt.Println("------------------------------------------                                 ------------------------------------------")
+		fmt.Println()
 		fmt.Println()
 
 		for {
@@ -134,6 +570,10 @@ func dryRun(ctx tcontext.TransferMetadata, sbomIterator iterator.SBOMIterator, i
 
 			default:
 				sbom, err := sbomIterator.Next(ctx)
+				if err == io.EOF {
+					// the iterator error budget ended the run
+					return nil
+				}
 				if err != nil {
 					if err == context.Canceled || err == context.DeadlineExceeded {
 						fmt.Println("\n✅ Dry-run stopped due to context cancellation")
@@ -162,7 +602,8 @@ func dryRun(ctx tcontext.TransferMetadata, sbomIterator iterator.SBOMIterator, i
 					continue
 				}
 
-				fmt.Println("\n                              +-+-+-+-+-+-+ SBOM DRY-RUN COMPLETED +-+-+-+-+\n")
+				fmt.Println("\n                              +-+-+-+-+-+-+ SBOM DRY-RUN COMPLETED +-+-+-+-+")
+				fmt.Println()
 			}
 		}
 	} else {
@@ -184,31 +625,87 @@ func dryRun(ctx tcontext.TransferMetadata, sbomIterator iterator.SBOMIterator, i
 		fmt.Println("-----------------🌐 INPUT ADAPTER DRY-RUN OUTPUT 🌐-----------------")
 		// Step 2: Use stored SBOMs for input dry-run
 		if err := input.DryRun(ctx, iterator.NewMemoryIterator(sboms)); err != nil {
-			return fmt.Errorf("failed to execute dry-run mode for input adapter: %v", err)
+			return fmt.Errorf("failed to execute dry-run mode for input adapter: %w", err)
 		}
 		fmt.Println()
 		fmt.Println("-----------------🌐 OUTPUT ADAPTER DRY-RUN OUTPUT 🌐-----------------")
 
 		// Step 3: Use the same stored SBOMs for output dry-run
 		if err := output.DryRun(ctx, iterator.NewMemoryIterator(sboms)); err != nil {
-			return fmt.Errorf("failed to execute dry-run mode for output adapter: %v", err)
+			return fmt.Errorf("failed to execute dry-run mode for output adapter: %w", err)
 		}
 	}
 	return nil
 }
 
+// sbomProcessing shapes the SBOMs for the destination through the stages it needs
 func sbomProcessing(ctx tcontext.TransferMetadata, config types.Config, sbomIterator iterator.SBOMIterator) iterator.SBOMIterator {
+	stages := processingStages(ctx, config)
+	stages = append(stages, gatingStages(ctx, config)...)
+	stages = append(stages, signingStages(ctx, config)...)
+	return iterator.Pipeline(sbomIterator, stages...)
+}
+
+// gatingStages enrich and score the SBOMs. A single output has them run in the spec it
+// uploads; several outputs have them run once, on the SBOMs as fetched, ahead of the fan-out.
+func gatingStages(ctx tcontext.TransferMetadata, config types.Config) []iterator.Stage {
+	var stages []iterator.Stage
+
+	// enrich SBOMs, in the spec they are uploaded in when converted for a single output
+	if config.Enrichment != nil {
+		stages = append(stages, iterator.Stage{Name: report.StageEnrich, Apply: iterator.Enrich(*config.Enrichment)})
+	}
+
+	// score SBOMs enriched where --enrich-* filled in missing elements
+	if gate, ok := ctx.Value(complianceGateKey).(*iterator.ComplianceGate); ok {
+		stages = append(stages, iterator.Stage{Name: report.StageComply, Apply: gate.Check})
+	}
+	return stages
+}
+
+// signingStages sign SBOMs last, as they are stored, for destinations writing signatures next to them
+func signingStages(ctx tcontext.TransferMetadata, config types.Config) []iterator.Stage {
+	if signer := sign.FromContext(ctx); signer != nil && storesSignatures(config.DestinationAdapter) {
+		return []iterator.Stage{{Name: report.StageSign, Apply: iterator.Sign(signer)}}
+	}
+	return nil
+}
+
+// storesSignatures reports whether the output adapter writes the signature files of SBOMs
+func storesSignatures(output string) bool {
+	switch types.AdapterType(output) {
+	case types.FolderAdapterType, types.S3AdapterType:
+		return true
+	}
+	return false
+}
+
+// processingStages declares the transformations SBOMs undergo on their way to the destination
+func processingStages(ctx tcontext.TransferMetadata, config types.Config) []iterator.Stage {
 	logger.LogDebug(ctx.Context, "Checking adapter eligibility for undergoing conversion layer", "adapter type", config.DestinationAdapter)
 
-	// convert sbom to cdx for DTrack adapter only
-	if types.AdapterType(config.DestinationAdapter) == types.DtrackAdapterType {
+	// --output-format converts SBOMs for every adapter, renaming converted files after their new spec
+	switch config.OutputFormat {
+	case types.OutputFormatSPDX:
+		logger.LogDebug(ctx.Context, "Converting SBOMs to the requested output format", "format", config.OutputFormat)
+		return []iterator.Stage{{Name: report.StageConvert, Apply: iterator.Convert(sbom.FormatSpecSPDX, true)}}
+	case types.OutputFormatCycloneDX:
+		logger.LogDebug(ctx.Context, "Converting SBOMs to the requested output format", "format", config.OutputFormat)
+		return []iterator.Stage{{Name: report.StageConvert, Apply: iterator.Convert(sbom.FormatSpecCycloneDX, true)}}
+	}
 
+	switch types.AdapterType(config.DestinationAdapter) {
+	case types.DtrackAdapterType:
+		// convert sbom to cdx for DTrack adapter only
 		logger.LogDebug(ctx.Context, "Adapter is eligible for SBOM conversion", "adapter type", config.DestinationAdapter)
-		// convertedSBOMs := sbomConversion(sbomIterator, ctx)
-		return iterator.NewConvertedIterator(sbomIterator, sbom.FormatSpecCycloneDX)
-	} else {
+		return []iterator.Stage{{Name: report.StageConvert, Apply: iterator.Convert(sbom.FormatSpecCycloneDX, false)}}
+	case types.InterlynkAdapterType:
+		// interlynk accepts both specs, but not the protobuf and YAML encodings
+		logger.LogDebug(ctx.Context, "Adapter is eligible for JSON re-encoding", "adapter type", config.DestinationAdapter)
+		return []iterator.Stage{{Name: report.StageConvert, Apply: iterator.EncodeJSON}}
+	default:
 		logger.LogDebug(ctx.Context, "Adapter is not eligible for SBOM conversion", "adapter type", config.DestinationAdapter)
-		return sbomIterator
+		return nil
 	}
 }
 
@@ -247,3 +744,42 @@ func convertMinifiedJSON(transferCtx tcontext.TransferMetadata, data []byte, tot
 	}
 	return original, totalMinifiedSBOM, nil
 }
+
+// recentErrors is the number of most recent failures the status socket reports
+const recentErrors = 10
+
+// serveStatus serves the daemon's status on its status socket until the transfer ends.
+// The daemon keeps running when the socket can't be served.
+func serveStatus(ctx tcontext.TransferMetadata, config types.Config, tracker *status.Tracker, l *limiter.Limiter, volume *report.Collector) {
+	startedAt := time.Now().UTC()
+
+	snapshot := func() status.Status {
+		queue := l.Stats()
+		failures := volume.Failures()
+
+		s := status.Status{
+			RunID:           config.RunID,
+			Input:           config.SourceAdapter,
+			Output:          config.DestinationAdapter,
+			StartedAt:       startedAt,
+			Watched:         tracker.Watched(),
+			PendingSBOMs:    tracker.Pending(),
+			InFlightUploads: queue.InFlight,
+			QueuedUploads:   queue.Queued,
+			Transferred:     volume.Summary().Total.SBOMs,
+			Failed:          len(failures),
+		}
+		if len(failures) > recentErrors {
+			failures = failures[len(failures)-recentErrors:]
+		}
+		for i := len(failures) - 1; i >= 0; i-- {
+			f := failures[i]
+			s.RecentErrors = append(s.RecentErrors, status.RecentError{Time: f.Time, File: f.File, Stage: f.Stage, Error: f.Error})
+		}
+		return s
+	}
+
+	if err := status.Serve(ctx.Context, config.StatusSocket, snapshot); err != nil {
+		logger.LogError(ctx.Context, err, "Daemon status unavailable", "socket", config.StatusSocket)
+	}
+}
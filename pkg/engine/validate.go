@@ -0,0 +1,130 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	adapter "github.com/interlynk-io/sbommv/pkg/adapter"
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// ValidateRun fetches the SBOMs of the input adapter, validates each against the schema of
+// its spec version and prints a pass/fail line per SBOM with the validation errors.
+// It fails when any SBOM is invalid.
+func ValidateRun(ctx context.Context, cmd *cobra.Command, config types.Config) error {
+	logger.LogDebug(ctx, "Starting validation", "input", config.SourceAdapter)
+
+	transferCtx := tcontext.NewTransferMetadata(ctx)
+
+	// only the input adapter is initialized, nothing is transferred
+	adapters, iAdp, _, err := adapter.NewAdapter(*transferCtx, types.Config{
+		SourceAdapter:      config.SourceAdapter,
+		ProcessingStrategy: config.ProcessingStrategy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize adapters: %w", err)
+	}
+	transferCtx.SetSourceAdapter(iAdp)
+	source.AttachFormatFilter(transferCtx, config.FormatFilter)
+
+	inputAdapterInstance := adapters[types.InputAdapterRole]
+	if inputAdapterInstance == nil {
+		return fmt.Errorf("failed to initialize input adapter")
+	}
+
+	if err := inputAdapterInstance.ParseAndValidateParams(cmd); err != nil {
+		return fmt.Errorf("input adapter error: %w", err)
+	}
+
+	sbomIterator, err := inputAdapterInstance.FetchSBOMs(*transferCtx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch SBOMs: %w", err)
+	}
+	if config.FormatFilter != nil {
+		sbomIterator = iterator.Transform(sbomIterator, iterator.FilterFormats(config.FormatFilter))
+	}
+
+	budgetIterator := iterator.NewErrorBudgetIterator(sbomIterator, config.MaxIteratorErrors)
+
+	var passed, failed, unsupported int
+	fmt.Println()
+	for {
+		doc, err := budgetIterator.Next(*transferCtx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		result := sbom.Validate(doc.Data)
+		name := doc.Path
+		if name == "" {
+			name = doc.Namespace
+		}
+
+		switch result.Status {
+		case sbom.ValidationPass:
+			passed++
+			fmt.Printf("✅ PASS  %s (%s)\n", name, validatedAs(result))
+		case sbom.ValidationUnsupported:
+			unsupported++
+			fmt.Printf("➖ SKIP  %s (%s): %s\n", name, validatedAs(result), result.Errors[0])
+		default:
+			failed++
+			fmt.Printf("❌ FAIL  %s (%s)\n", name, validatedAs(result))
+			for _, e := range result.Errors {
+				fmt.Printf("         - %s\n", e)
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("📊 SBOMs validated: %d, passed: %d, failed: %d, not validated: %d\n", passed+failed+unsupported, passed, failed, unsupported)
+	if skipped := budgetIterator.Errors(); skipped > 0 {
+		fmt.Printf("⚠️  SBOMs that couldn't be fetched: %d\n", skipped)
+	}
+	fmt.Println()
+
+	if err := budgetIterator.Err(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errdefs.Validation(fmt.Errorf("%d of %d SBOMs failed validation", failed, passed+failed+unsupported))
+	}
+	return nil
+}
+
+// validatedAs describes the spec, version and encoding an SBOM was validated as
+func validatedAs(result sbom.ValidationResult) string {
+	if result.Version == "" {
+		return string(result.Format)
+	}
+	version := result.Version
+	if result.Spec == sbom.FormatSpecCycloneDX {
+		// SPDX versions read "SPDX-2.3" already
+		version = "CycloneDX " + version
+	}
+	return fmt.Sprintf("%s, %s", version, result.Format)
+}
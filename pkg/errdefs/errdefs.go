@@ -0,0 +1,213 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errdefs
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/interlynk-io/sbommv/pkg/retry"
+)
+
+// AuthError is a source or destination rejecting, or missing, the credentials sbommv used
+type AuthError struct {
+	Err error
+}
+
+func (e *AuthError) Error() string { return e.Err.Error() }
+func (e *AuthError) Unwrap() error { return e.Err }
+
+// NotFoundError is a source without any SBOM to transfer, or a repository, bucket, project
+// or other resource that doesn't exist
+type NotFoundError struct {
+	Err error
+}
+
+func (e *NotFoundError) Error() string { return e.Err.Error() }
+func (e *NotFoundError) Unwrap() error { return e.Err }
+
+// RateLimitError is an API rate limit that stopped, or would stop, the transfer
+type RateLimitError struct {
+	Err error
+}
+
+func (e *RateLimitError) Error() string { return e.Err.Error() }
+func (e *RateLimitError) Unwrap() error { return e.Err }
+
+// ValidationError is an SBOM failing validation, e.g. against the schema of its spec version
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string { return e.Err.Error() }
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// UnreachableError is a source or destination that couldn't be connected to, or that
+// answered it is unavailable
+type UnreachableError struct {
+	Err error
+}
+
+func (e *UnreachableError) Error() string { return e.Err.Error() }
+func (e *UnreachableError) Unwrap() error { return e.Err }
+
+// Auth returns err as an *AuthError, nil when err is nil
+func Auth(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &AuthError{Err: err}
+}
+
+// NotFound returns err as a *NotFoundError, nil when err is nil
+func NotFound(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &NotFoundError{Err: err}
+}
+
+// RateLimit returns err as a *RateLimitError, nil when err is nil
+func RateLimit(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &RateLimitError{Err: err}
+}
+
+// Validation returns err as a *ValidationError, nil when err is nil
+func Validation(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ValidationError{Err: err}
+}
+
+// Unreachable returns err as an *UnreachableError, nil when err is nil
+func Unreachable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &UnreachableError{Err: err}
+}
+
+// FromStatus returns err as the error of its HTTP status: 401 and 403 are an *AuthError, 404
+// a *NotFoundError, 429 a *RateLimitError and 502 to 504 an *UnreachableError. Errors of
+// other statuses are returned as they are.
+func FromStatus(code int, err error) error {
+	return fromKind(statusKind(code, nil), err)
+}
+
+// FromResponse returns err as the error of resp's status, as FromStatus does, except that a
+// 403 reporting no calls left in its X-RateLimit-Remaining header, GitHub's answer once its
+// rate limit is used up, is a *RateLimitError
+func FromResponse(resp *http.Response, err error) error {
+	return fromKind(statusKind(resp.StatusCode, resp.Header), err)
+}
+
+func fromKind(kind Kind, err error) error {
+	switch kind {
+	case KindAuth:
+		return Auth(err)
+	case KindNotFound:
+		return NotFound(err)
+	case KindRateLimit:
+		return RateLimit(err)
+	case KindUnreachable:
+		return Unreachable(err)
+	}
+	return err
+}
+
+// Kind is the class of an error, telling a bad token from an empty source or an
+// unreachable destination
+type Kind string
+
+const (
+	KindOther       Kind = "other"
+	KindAuth        Kind = "auth"
+	KindNotFound    Kind = "not_found"
+	KindRateLimit   Kind = "rate_limit"
+	KindValidation  Kind = "validation"
+	KindUnreachable Kind = "unreachable"
+)
+
+// KindOf classifies err by the errors above it wraps, the first of auth, rate limit,
+// unreachable, not found and validation errors. Errors that wrap none are classified by the
+// HTTP status they carry, as *retry.HTTPError and AWS response errors do, and network
+// failures are unreachable.
+func KindOf(err error) Kind {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return KindOther
+	}
+
+	var (
+		authErr        *AuthError
+		rateLimitErr   *RateLimitError
+		unreachableErr *UnreachableError
+		notFoundErr    *NotFoundError
+		validationErr  *ValidationError
+	)
+	switch {
+	case errors.As(err, &authErr):
+		return KindAuth
+	case errors.As(err, &rateLimitErr):
+		return KindRateLimit
+	case errors.As(err, &unreachableErr):
+		return KindUnreachable
+	case errors.As(err, &notFoundErr):
+		return KindNotFound
+	case errors.As(err, &validationErr):
+		return KindValidation
+	}
+
+	var httpErr *retry.HTTPError
+	if errors.As(err, &httpErr) {
+		return statusKind(httpErr.StatusCode, httpErr.Header)
+	}
+	// AWS SDK response errors
+	var statusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &statusErr) {
+		return statusKind(statusErr.HTTPStatusCode(), nil)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return KindUnreachable
+	}
+	return KindOther
+}
+
+// statusKind classifies an HTTP status, along with the response headers when they are known
+func statusKind(code int, header http.Header) Kind {
+	switch code {
+	case http.StatusUnauthorized:
+		return KindAuth
+	case http.StatusForbidden:
+		if header.Get("X-RateLimit-Remaining") == "0" {
+			return KindRateLimit
+		}
+		return KindAuth
+	case http.StatusNotFound:
+		return KindNotFound
+	case http.StatusTooManyRequests:
+		return KindRateLimit
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return KindUnreachable
+	}
+	return KindOther
+}
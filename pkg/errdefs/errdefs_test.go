@@ -0,0 +1,118 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errdefs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+// awsError stands for an AWS SDK response error
+type awsError struct{ code int }
+
+func (e awsError) Error() string       { return fmt.Sprintf("aws: status %d", e.code) }
+func (e awsError) HTTPStatusCode() int { return e.code }
+
+func rateLimitedHeader() http.Header {
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "0")
+	return header
+}
+
+func TestKindOf(t *testing.T) {
+	base := errors.New("failed")
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{"nil", nil, KindOther},
+		{"plain", base, KindOther},
+		{"cancelled", fmt.Errorf("fetching: %w", context.Canceled), KindOther},
+		{"cancelled auth", Auth(fmt.Errorf("fetching: %w", context.Canceled)), KindOther},
+		{"auth", fmt.Errorf("listing releases: %w", Auth(base)), KindAuth},
+		{"not found", fmt.Errorf("fetching: %w", NotFound(base)), KindNotFound},
+		{"rate limit", RateLimit(base), KindRateLimit},
+		{"validation", Validation(base), KindValidation},
+		{"unreachable", Unreachable(base), KindUnreachable},
+		{"auth before validation", errors.Join(Validation(base), Auth(base)), KindAuth},
+		{"rate limit before not found", errors.Join(NotFound(base), RateLimit(base)), KindRateLimit},
+		{"unreachable before not found", errors.Join(NotFound(base), Unreachable(base)), KindUnreachable},
+		{"wrapped type wins over status", Validation(&retry.HTTPError{StatusCode: http.StatusUnauthorized}), KindValidation},
+		{"status 401", fmt.Errorf("upload: %w", &retry.HTTPError{StatusCode: http.StatusUnauthorized}), KindAuth},
+		{"status 403", &retry.HTTPError{StatusCode: http.StatusForbidden}, KindAuth},
+		{"status 403 with calls left", &retry.HTTPError{StatusCode: http.StatusForbidden, Header: http.Header{"X-Ratelimit-Remaining": {"12"}}}, KindAuth},
+		{"status 403 rate limited", &retry.HTTPError{StatusCode: http.StatusForbidden, Header: rateLimitedHeader()}, KindRateLimit},
+		{"status 404", &retry.HTTPError{StatusCode: http.StatusNotFound}, KindNotFound},
+		{"status 429", &retry.HTTPError{StatusCode: http.StatusTooManyRequests}, KindRateLimit},
+		{"status 500", &retry.HTTPError{StatusCode: http.StatusInternalServerError}, KindOther},
+		{"status 503", &retry.HTTPError{StatusCode: http.StatusServiceUnavailable}, KindUnreachable},
+		{"aws status 403", fmt.Errorf("put object: %w", awsError{http.StatusForbidden}), KindAuth},
+		{"aws status 504", awsError{http.StatusGatewayTimeout}, KindUnreachable},
+		{"network", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, KindUnreachable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, KindOf(tt.err))
+		})
+	}
+}
+
+func TestConstructorsKeepNil(t *testing.T) {
+	assert.NoError(t, Auth(nil))
+	assert.NoError(t, NotFound(nil))
+	assert.NoError(t, RateLimit(nil))
+	assert.NoError(t, Validation(nil))
+	assert.NoError(t, Unreachable(nil))
+	assert.NoError(t, FromStatus(http.StatusUnauthorized, nil))
+}
+
+func TestFromStatus(t *testing.T) {
+	base := errors.New("failed")
+	assert.Equal(t, KindAuth, KindOf(FromStatus(http.StatusUnauthorized, base)))
+	assert.Equal(t, KindAuth, KindOf(FromStatus(http.StatusForbidden, base)))
+	assert.Equal(t, KindNotFound, KindOf(FromStatus(http.StatusNotFound, base)))
+	assert.Equal(t, KindRateLimit, KindOf(FromStatus(http.StatusTooManyRequests, base)))
+	assert.Equal(t, KindUnreachable, KindOf(FromStatus(http.StatusBadGateway, base)))
+	assert.Same(t, base, FromStatus(http.StatusInternalServerError, base))
+	assert.ErrorIs(t, FromStatus(http.StatusNotFound, base), base)
+}
+
+func TestFromResponse(t *testing.T) {
+	base := errors.New("failed")
+
+	// GitHub answers 403 once its rate limit is used up
+	limited := &http.Response{StatusCode: http.StatusForbidden, Header: rateLimitedHeader()}
+	var rateLimitErr *RateLimitError
+	assert.ErrorAs(t, FromResponse(limited, base), &rateLimitErr)
+
+	forbidden := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	var authErr *AuthError
+	assert.ErrorAs(t, FromResponse(forbidden, base), &authErr)
+
+	// the header only tells rate limits apart from other rejections
+	missing := &http.Response{StatusCode: http.StatusNotFound, Header: rateLimitedHeader()}
+	assert.Equal(t, KindNotFound, KindOf(FromResponse(missing, base)))
+
+	failed := &http.Response{StatusCode: http.StatusInternalServerError, Header: http.Header{}}
+	assert.Same(t, base, FromResponse(failed, base))
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// -------------------------------------------------------------------------
+
+package iterator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// ErrErrorBudgetExhausted is reported once too many consecutive iterator errors stopped the run
+var ErrErrorBudgetExhausted = errors.New("iterator error budget exhausted")
+
+// ErrorBudgetIterator skips SBOMs the inner iterator fails to produce, so uploaders only
+// ever see SBOMs, io.EOF or, once the transfer is cancelled, the context error, and count
+// upload results on their own. After maxConsecutive errors in a row it ends the iteration;
+// Err then reports the exhausted budget.
+type ErrorBudgetIterator struct {
+	inner          SBOMIterator
+	maxConsecutive int
+
+	mu          sync.Mutex
+	consecutive int
+	total       int
+	lastErr     error
+	exhausted   bool
+	onError     func(tcontext.TransferMetadata, error)
+}
+
+// NewErrorBudgetIterator wraps inner, allowing maxConsecutive iterator errors in a row (0 means unlimited)
+func NewErrorBudgetIterator(inner SBOMIterator, maxConsecutive int) *ErrorBudgetIterator {
+	return &ErrorBudgetIterator{
+		inner:          inner,
+		maxConsecutive: maxConsecutive,
+	}
+}
+
+func (bi *ErrorBudgetIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+
+	for {
+		if bi.exhausted {
+			return nil, io.EOF
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		sbom, err := bi.inner.Next(ctx)
+		if err == nil {
+			bi.consecutive = 0
+			return sbom, nil
+		}
+
+		if err == io.EOF || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+
+		bi.consecutive++
+		bi.total++
+		bi.lastErr = err
+		logger.LogInfo(ctx.Context, "Failed to retrieve SBOM, skipping", "error", err, "consecutive_errors", bi.consecutive)
+		if bi.onError != nil {
+			bi.onError(ctx, err)
+		}
+
+		if bi.maxConsecutive > 0 && bi.consecutive >= bi.maxConsecutive {
+			bi.exhausted = true
+			logger.LogError(ctx.Context, err, "Too many consecutive iterator errors, stopping transfer", "max_iterator_errors", bi.maxConsecutive)
+			return nil, io.EOF
+		}
+	}
+}
+
+// OnError sets a function called with every iterator error skipped
+func (bi *ErrorBudgetIterator) OnError(fn func(tcontext.TransferMetadata, error)) {
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+	bi.onError = fn
+}
+
+// Errors returns the total number of iterator errors skipped so far
+func (bi *ErrorBudgetIterator) Errors() int {
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+	return bi.total
+}
+
+// Err returns an error wrapping ErrErrorBudgetExhausted when the budget ended the iteration
+func (bi *ErrorBudgetIterator) Err() error {
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+
+	if !bi.exhausted {
+		return nil
+	}
+	return fmt.Errorf("%w: %d consecutive errors, last: %w", ErrErrorBudgetExhausted, bi.consecutive, bi.lastErr)
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// ChainIterator yields the SBOMs of several iterators, one iterator after the other
+type ChainIterator struct {
+	iters   []SBOMIterator
+	current int
+}
+
+// NewChainIterator chains iters in order
+func NewChainIterator(iters ...SBOMIterator) *ChainIterator {
+	return &ChainIterator{iters: iters}
+}
+
+func (ci *ChainIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	for ci.current < len(ci.iters) {
+		sbom, err := ci.iters[ci.current].Next(ctx)
+		if err == io.EOF {
+			ci.current++
+			continue
+		}
+		return sbom, err
+	}
+	return nil, io.EOF
+}
+
+// MergeIterator yields the SBOMs of several iterators as they arrive, reading them
+// concurrently. It suits daemon mode, where each iterator watches its source until the
+// transfer is cancelled.
+type MergeIterator struct {
+	iters   []SBOMIterator
+	results chan mergeResult
+	start   sync.Once
+}
+
+type mergeResult struct {
+	sbom *SBOM
+	err  error
+}
+
+// NewMergeIterator merges iters. They are read from the first call to Next on, with its context.
+func NewMergeIterator(iters ...SBOMIterator) *MergeIterator {
+	return &MergeIterator{iters: iters, results: make(chan mergeResult)}
+}
+
+func (mi *MergeIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	mi.start.Do(func() { mi.run(ctx) })
+
+	select {
+	case result, ok := <-mi.results:
+		if !ok {
+			return nil, io.EOF
+		}
+		return result.sbom, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run reads every iterator until it is exhausted or the context is cancelled, and ends
+// the merged iteration once all of them are
+func (mi *MergeIterator) run(ctx tcontext.TransferMetadata) {
+	var wg sync.WaitGroup
+	for _, it := range mi.iters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				sbom, err := it.Next(ctx)
+				if errors.Is(err, io.EOF) || ctx.Err() != nil {
+					return
+				}
+				select {
+				case mi.results <- mergeResult{sbom: sbom, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(mi.results)
+	}()
+}
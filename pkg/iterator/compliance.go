@@ -0,0 +1,107 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// ComplianceError is an SBOM whose NTIA minimum elements score is below --min-ntia-score
+type ComplianceError struct {
+	File      string
+	Namespace string
+	Result    sbom.NTIAResult
+	MinScore  float64
+}
+
+func (e *ComplianceError) Error() string {
+	return fmt.Sprintf("NTIA score %.1f below %.1f, missing %s", e.Result.Score, e.MinScore, strings.Join(e.Result.Missing(), ", "))
+}
+
+// ComplianceGate scores SBOMs against the NTIA minimum elements as they are uploaded, and
+// drops those scoring below the minimum. SBOMs it can't score are passed through.
+type ComplianceGate struct {
+	minScore float64
+
+	mu         sync.Mutex
+	scored     int
+	total      float64
+	rejected   int
+	onRejected func(tcontext.TransferMetadata, *ComplianceError)
+}
+
+// NewComplianceGate returns a gate letting SBOMs scoring minScore or more through
+func NewComplianceGate(minScore float64) *ComplianceGate {
+	return &ComplianceGate{minScore: minScore}
+}
+
+// Check is the processing stage scoring SBOMs, recording the result in SBOM.NTIA
+func (g *ComplianceGate) Check(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+	result, err := sbom.ScoreNTIA(doc.Data)
+	if errors.Is(err, sbom.ErrNTIAUnsupported) {
+		logger.LogDebug(ctx.Context, "SBOM not scored against NTIA minimum elements", "file", doc.Path, "format", sbom.DetectFormat(doc.Data))
+		return doc, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc.NTIA = &result
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.scored++
+	g.total += result.Score
+	if result.Score >= g.minScore {
+		logger.LogDebug(ctx.Context, "SBOM meets NTIA minimum elements score", "file", doc.Path, "score", result.Score, "missing", result.Missing())
+		return doc, nil
+	}
+
+	rejected := &ComplianceError{File: doc.Path, Namespace: doc.Namespace, Result: result, MinScore: g.minScore}
+	g.rejected++
+	if g.onRejected != nil {
+		g.onRejected(ctx, rejected)
+	}
+	logger.LogInfo(ctx.Context, "Skipping SBOM below NTIA minimum elements score", "file", doc.Path, "score", result.Score, "min_score", g.minScore, "missing", result.Missing())
+	return nil, nil
+}
+
+// OnRejected sets a function called with every SBOM scoring below the minimum
+func (g *ComplianceGate) OnRejected(fn func(tcontext.TransferMetadata, *ComplianceError)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onRejected = fn
+}
+
+// Stats returns the SBOMs scored, their average score and those rejected. A nil gate reports none.
+func (g *ComplianceGate) Stats() (scored int, average float64, rejected int) {
+	if g == nil {
+		return 0, 0, 0
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.scored > 0 {
+		average = g.total / float64(g.scored)
+	}
+	return g.scored, average, g.rejected
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComplianceGate(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	complete := &SBOM{Path: "complete.json", Data: []byte(`{
+		"bomFormat": "CycloneDX", "specVersion": "1.5",
+		"metadata": {"timestamp": "2025-01-15T10:00:00Z", "authors": [{"name": "Interlynk"}]},
+		"components": [{"bom-ref": "a", "name": "sbomqs", "version": "1.0.0", "supplier": {"name": "Interlynk"}, "purl": "pkg:golang/github.com/interlynk-io/sbomqs@1.0.0"}],
+		"dependencies": [{"ref": "a", "dependsOn": ["a"]}]
+	}`)}
+	bare := &SBOM{Path: "bare.json", Namespace: "interlynk-io/sbomqs", Data: []byte(`{
+		"bomFormat": "CycloneDX", "specVersion": "1.5",
+		"components": [{"name": "sbomqs"}]
+	}`)}
+	tagValue := &SBOM{Path: "sbom.spdx", Data: []byte("SPDXVersion: SPDX-2.3\nDataLicense: CC0-1.0\nSPDXID: SPDXRef-DOCUMENT\n")}
+
+	gate := NewComplianceGate(7)
+	var rejected []*ComplianceError
+	gate.OnRejected(func(_ tcontext.TransferMetadata, err *ComplianceError) {
+		rejected = append(rejected, err)
+	})
+
+	var passed []string
+	iter := Transform(NewMemoryIterator([]*SBOM{complete, bare, tagValue}), gate.Check)
+	for {
+		sbom, err := iter.Next(ctx)
+		if err != nil {
+			break
+		}
+		passed = append(passed, sbom.Path)
+	}
+
+	// SBOMs that can't be scored are passed through
+	assert.Equal(t, []string{"complete.json", "sbom.spdx"}, passed)
+	require.NotNil(t, complete.NTIA)
+	assert.Equal(t, 10.0, complete.NTIA.Score)
+	assert.Nil(t, tagValue.NTIA)
+
+	require.Len(t, rejected, 1)
+	assert.Equal(t, "bare.json", rejected[0].File)
+	assert.Equal(t, "interlynk-io/sbomqs", rejected[0].Namespace)
+	assert.Equal(t, 1.4, rejected[0].Result.Score)
+	assert.Contains(t, rejected[0].Error(), "NTIA score 1.4 below 7.0, missing supplier name (1 of 1 components)")
+
+	scored, average, rejectedCount := gate.Stats()
+	assert.Equal(t, 2, scored)
+	assert.InDelta(t, 5.7, average, 0.001)
+	assert.Equal(t, 1, rejectedCount)
+}
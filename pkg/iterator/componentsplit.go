@@ -0,0 +1,134 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/converter"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// ComponentSplitIterator splits each SBOM into one CycloneDX SBOM per top-level component,
+// see sbom.SplitCycloneDX, so monorepo SBOMs are uploaded as one SBOM per deployable unit.
+// SBOMs with fewer than two top-level components are yielded as they are.
+type ComponentSplitIterator struct {
+	inner SBOMIterator
+	stage string // reported with the SBOMs that can't be split
+
+	pending []*SBOM // parts of the last SBOM split, not yet yielded
+}
+
+// NewComponentSplitIterator wraps inner, reporting SBOMs it fails to split as a *StageError of stage
+func NewComponentSplitIterator(inner SBOMIterator, stage string) *ComponentSplitIterator {
+	return &ComponentSplitIterator{inner: inner, stage: stage}
+}
+
+func (cs *ComponentSplitIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	if len(cs.pending) > 0 {
+		part := cs.pending[0]
+		cs.pending = cs.pending[1:]
+		return part, nil
+	}
+
+	doc, err := cs.inner.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := cs.split(ctx, doc)
+	if err != nil {
+		return nil, &StageError{Stage: cs.stage, File: doc.Path, Namespace: doc.Namespace, Err: err}
+	}
+	if len(parts) == 0 {
+		return doc, nil
+	}
+	cs.pending = parts[1:]
+	return parts[0], nil
+}
+
+// split splits an SBOM, converting it to CycloneDX JSON first. It returns no parts when the
+// SBOM has fewer than two top-level components.
+func (cs *ComponentSplitIterator) split(ctx tcontext.TransferMetadata, doc *SBOM) ([]*SBOM, error) {
+	data := doc.Data
+	format := sbom.DetectFormat(data)
+	if format != sbom.FormatCycloneDXJSON {
+		converted, err := converter.ConvertSBOM(ctx, data, sbom.FormatSpecCycloneDX)
+		if err != nil {
+			return nil, fmt.Errorf("converting to CycloneDX: %w", err)
+		}
+		data = converted
+	}
+
+	parts, err := sbom.SplitCycloneDX(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		logger.LogDebug(ctx.Context, "SBOM has fewer than two top-level components, not split", "file", doc.Path, "namespace", doc.Namespace)
+		return nil, nil
+	}
+
+	base := strings.TrimSuffix(doc.Path, filepath.Ext(doc.Path))
+	base = strings.TrimSuffix(base, filepath.Ext(base)) // e.g. .cdx of .cdx.json
+	names := make(map[string]int, len(parts))
+
+	// an annotated project name would put every part back in one project
+	var annotations *Annotations
+	if doc.Annotations != nil {
+		annotations = &Annotations{Tags: doc.Annotations.Tags, Environment: doc.Annotations.Environment}
+	}
+
+	split := make([]*SBOM, 0, len(parts))
+	for _, part := range parts {
+		name := splitPartName(part.Name)
+		if names[name]++; names[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, names[name])
+		}
+		split = append(split, &SBOM{
+			Path:          base + "-" + name + ".cdx.json",
+			Data:          part.Data,
+			Namespace:     doc.Namespace,
+			Version:       doc.Version,
+			Branch:        doc.Branch,
+			Annotations:   annotations,
+			Source:        doc.Source,
+			ConvertedFrom: format,
+			ContentHash:   sbom.ComputeContentHash([]byte(doc.SourceHash() + "\n" + part.Name)),
+		})
+	}
+
+	logger.LogInfo(ctx.Context, "Split SBOM by component", "file", doc.Path, "namespace", doc.Namespace, "parts", len(split))
+	return split, nil
+}
+
+// splitPartName turns a component name into one safe in file names and object keys
+func splitPartName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '@', ' ', '*', '?', '"', '<', '>', '|':
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		return "component"
+	}
+	return name
+}
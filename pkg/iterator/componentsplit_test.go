@@ -0,0 +1,84 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// monorepoSBOM is a monorepo SBOM whose described component depends on two services
+const monorepoSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"component": {"bom-ref": "monorepo", "type": "application", "name": "monorepo", "version": "1.0.0"}},
+  "components": [
+    {"bom-ref": "api", "type": "application", "name": "api", "version": "1.0.0"},
+    {"bom-ref": "worker", "type": "application", "name": "svc/worker", "version": "1.0.0"},
+    {"bom-ref": "zap", "type": "library", "name": "zap", "version": "1.27.0"}
+  ],
+  "dependencies": [
+    {"ref": "monorepo", "dependsOn": ["api", "worker"]},
+    {"ref": "api", "dependsOn": ["zap"]}
+  ]
+}`
+
+func TestComponentSplitIterator(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	monorepo := &SBOM{
+		Path:        "releases/monorepo.cdx.json",
+		Data:        []byte(monorepoSBOM),
+		Namespace:   "interlynk-io/monorepo",
+		Version:     "v1.0.0",
+		Annotations: &Annotations{ProjectName: "monorepo", Tags: []string{"prod"}},
+	}
+	single := &SBOM{Path: "sbomqs.cdx.json", Data: serviceSBOM("sbomqs", "cobra"), Namespace: "interlynk-io/sbomqs"}
+	broken := &SBOM{Path: "broken.json", Data: []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5", "components": "none"}`)}
+
+	sboms, errs := drainAll(ctx, NewComponentSplitIterator(NewMemoryIterator([]*SBOM{monorepo, single, broken}), "split"))
+
+	require.Len(t, errs, 1)
+	var stageErr *StageError
+	require.ErrorAs(t, errs[0], &stageErr)
+	assert.Equal(t, "split", stageErr.Stage)
+	assert.Equal(t, "broken.json", stageErr.File)
+
+	require.Len(t, sboms, 3)
+	api, worker := sboms[0], sboms[1]
+	assert.Equal(t, "releases/monorepo-api.cdx.json", api.Path)
+	assert.Equal(t, "releases/monorepo-svc-worker.cdx.json", worker.Path)
+
+	for _, part := range []*SBOM{api, worker} {
+		assert.Equal(t, "interlynk-io/monorepo", part.Namespace)
+		assert.Equal(t, "v1.0.0", part.Version)
+		assert.Equal(t, sbom.FormatCycloneDXJSON, part.ConvertedFrom)
+		// an annotated project name would put the parts back in one project
+		require.NotNil(t, part.Annotations)
+		assert.Empty(t, part.Annotations.ProjectName)
+		assert.Equal(t, []string{"prod"}, part.Annotations.Tags)
+	}
+	assert.NotEqual(t, api.ContentHash, worker.ContentHash)
+	assert.Contains(t, string(api.Data), `"name": "zap"`)
+	assert.NotContains(t, string(worker.Data), `"name": "zap"`)
+
+	// SBOMs with a single top-level component are yielded as they are
+	assert.Same(t, single, sboms[2])
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// Deduplicator drops SBOMs whose content was already seen in the run, e.g. the same SBOM
+// attached to several releases or fetched from several inputs. SBOMs are compared by the
+// hash of their content as fetched, before conversion.
+type Deduplicator struct {
+	mu         sync.Mutex
+	seen       map[string]string // content hash -> path of the first SBOM with that content
+	duplicates int
+}
+
+// NewDeduplicator returns a deduplicator that has seen no SBOM yet
+func NewDeduplicator() *Deduplicator {
+	return &Deduplicator{seen: make(map[string]string)}
+}
+
+// Skip is the processing stage dropping SBOMs with the content of an SBOM seen before
+func (d *Deduplicator) Skip(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+	hash := sbom.ComputeContentHash(doc.Data)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	first, ok := d.seen[hash]
+	if !ok {
+		d.seen[hash] = doc.Path
+		return doc, nil
+	}
+
+	d.duplicates++
+	logger.LogDebug(ctx.Context, "Skipping duplicate SBOM", "file", doc.Path, "namespace", doc.Namespace, "duplicate_of", first)
+	return nil, nil
+}
+
+// Duplicates returns the number of SBOMs skipped as duplicates. A nil deduplicator reports none.
+func (d *Deduplicator) Duplicates() int {
+	if d == nil {
+		return 0
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.duplicates
+}
@@ -16,13 +16,12 @@
 package iterator
 
 import (
-	"context"
 	"io"
+	"time"
 
-	"github.com/interlynk-io/sbommv/pkg/converter"
-	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/timing"
 )
 
 // SBOM represents a single SBOM file
@@ -32,6 +31,100 @@ type SBOM struct {
 	Namespace string // It could be Repo, or Dir (helps track multi-repo or multi-folder processing)
 	Version   string // Version of the SBOM (e.g., "latest" or "v1.2.3")
 	Branch    string // github repo main, master, or any specific branch
+
+	// ExplicitNamespace is set when Namespace and Version were derived deterministically
+	// (e.g. from a namespace template), so destinations name projects after them.
+	ExplicitNamespace bool
+
+	// Annotations are the overrides read from the SBOM's sidecar metadata file, nil when it has none
+	Annotations *Annotations
+
+	// Signatures are the signature files published along with the SBOM, keyed by suffix
+	// (".sig", ".pem", ".intoto.jsonl"). Sources fetch them for --verify-signatures only.
+	Signatures map[string][]byte
+
+	// NTIA is the SBOM's NTIA minimum elements score, set when --min-ntia-score scored it
+	NTIA *sbom.NTIAResult
+
+	// Source is the input adapter the SBOM came from when the transfer has several, empty otherwise
+	Source string
+
+	// ConvertedFrom is the format the SBOM had before a processing stage converted or
+	// re-encoded it, empty when it reaches the destination as fetched
+	ConvertedFrom sbom.SBOMFormat
+
+	// Checkpoint is the key of the SBOM in the transfer checkpoint, set as it is fetched
+	Checkpoint string
+
+	// ContentHash is the SHA-256 of the SBOM as fetched, set by HashContent before any
+	// processing stage, so conversion regenerating serials and timestamps doesn't change it
+	ContentHash string
+}
+
+// SourceHash returns the content hash of the SBOM as fetched, or of its current content
+// when no HashContent stage hashed it
+func (s *SBOM) SourceHash() string {
+	if s.ContentHash != "" {
+		return s.ContentHash
+	}
+	return sbom.ComputeContentHash(s.Data)
+}
+
+// HashContent is the first stage of a transfer, setting the ContentHash of fetched SBOMs
+func HashContent(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+	if doc.ContentHash == "" {
+		doc.ContentHash = sbom.ComputeContentHash(doc.Data)
+	}
+	return doc, nil
+}
+
+// Copy returns a copy of the SBOM that doesn't share its content or signatures, for several
+// outputs to convert and rename it independently. Annotations and NTIA are shared, they are
+// not changed once set.
+func (s *SBOM) Copy() *SBOM {
+	copied := *s
+	if s.Data != nil {
+		copied.Data = append([]byte(nil), s.Data...)
+	}
+	if s.Signatures != nil {
+		copied.Signatures = make(map[string][]byte, len(s.Signatures))
+		for suffix, sig := range s.Signatures {
+			copied.Signatures[suffix] = append([]byte(nil), sig...)
+		}
+	}
+	return &copied
+}
+
+// SourceAdapter returns the input adapter the SBOM came from, the transfer's one unless it has several
+func (s *SBOM) SourceAdapter(ctx tcontext.TransferMetadata) string {
+	if s.Source != "" {
+		return s.Source
+	}
+	return ctx.SourceAdapter()
+}
+
+// Annotations carry per-SBOM overrides for destinations, read from a `<sbom>.meta.yaml`
+// sidecar file next to the SBOM. Empty fields leave the destination's own choice in place.
+type Annotations struct {
+	ProjectName    string   `json:"project_name,omitempty"`
+	ProjectVersion string   `json:"project_version,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Environment    string   `json:"environment,omitempty"`
+}
+
+// Project returns the project name and version with the annotated overrides applied.
+// It is safe to call on a nil *Annotations.
+func (a *Annotations) Project(name, version string) (string, string) {
+	if a == nil {
+		return name, version
+	}
+	if a.ProjectName != "" {
+		name = a.ProjectName
+	}
+	if a.ProjectVersion != "" {
+		version = a.ProjectVersion
+	}
+	return name, version
 }
 
 // SBOMIterator provides a way to lazily fetch SBOMs one by one
@@ -39,6 +132,12 @@ type SBOMIterator interface {
 	Next(ctx tcontext.TransferMetadata) (*SBOM, error) // Fetch the next SBOM
 }
 
+// Sized is implemented by iterators that know up front how many SBOMs they yield at most,
+// e.g. the files listed in a folder. Files that turn out not to be SBOMs are skipped.
+type Sized interface {
+	Total() int
+}
+
 // MemoryIterator is an iterator that iterates over a preloaded slice of SBOMs.
 type MemoryIterator struct {
 	sboms []*SBOM
@@ -64,36 +163,20 @@ func (it *MemoryIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
 	return sbom, nil
 }
 
-type ConvertedIterator struct {
-	inner        SBOMIterator
-	targetFormat sbom.FormatSpec
+// TimedIterator records the time the inner iterator takes to yield each SBOM as its fetch stage
+type TimedIterator struct {
+	inner SBOMIterator
 }
 
-func NewConvertedIterator(inner SBOMIterator, targetFormat sbom.FormatSpec) *ConvertedIterator {
-	return &ConvertedIterator{
-		inner:        inner,
-		targetFormat: targetFormat,
-	}
+func NewTimedIterator(inner SBOMIterator) *TimedIterator {
+	return &TimedIterator{inner: inner}
 }
 
-func (ci *ConvertedIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
-	sbom, err := ci.inner.Next(ctx)
-	if err != nil {
-		if err == io.EOF {
-			return nil, io.EOF // EOF for one-time mode
-		}
-		if err == context.Canceled || err == context.DeadlineExceeded {
-			logger.LogDebug(ctx.Context, "Iterator stopped due to context cancellation")
-			return nil, err // Exit for daemon mode
-		}
-		logger.LogInfo(ctx.Context, "error", "message", err)
-		return nil, err
+func (ti *TimedIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	start := time.Now()
+	doc, err := ti.inner.Next(ctx)
+	if err == nil {
+		timing.Record(ctx, timing.StageFetch, doc.Path, time.Since(start))
 	}
-	convertedData, err := converter.ConvertSBOM(ctx, sbom.Data, ci.targetFormat)
-	if err != nil {
-		logger.LogDebug(ctx.Context, "Failed to convert SBOM", "file", sbom.Path, "error", err)
-		return nil, err
-	}
-	sbom.Data = convertedData
-	return sbom, nil
+	return doc, err
 }
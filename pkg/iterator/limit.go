@@ -0,0 +1,73 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"io"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// LimitIterator ends the iteration once the inner iterator yielded limit SBOMs, without
+// asking it for more, so sources fetching lazily stop fetching too. Errors of the inner
+// iterator are passed on and don't count towards the limit.
+type LimitIterator struct {
+	inner SBOMIterator
+	limit int
+
+	mu      sync.Mutex
+	yielded int
+	stopped bool // an SBOM was asked for past the limit
+}
+
+// NewLimitIterator wraps inner, yielding at most limit SBOMs
+func NewLimitIterator(inner SBOMIterator, limit int) *LimitIterator {
+	return &LimitIterator{inner: inner, limit: limit}
+}
+
+func (li *LimitIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+
+	if li.yielded >= li.limit {
+		li.stopped = true
+		return nil, io.EOF
+	}
+
+	sbom, err := li.inner.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	li.yielded++
+	if li.yielded == li.limit {
+		logger.LogDebug(ctx.Context, "SBOM limit reached, not fetching further SBOMs", "limit", li.limit)
+	}
+	return sbom, nil
+}
+
+// Reached reports whether the limit ended the iteration, rather than the inner iterator
+// running out of SBOMs. A nil iterator never does.
+func (li *LimitIterator) Reached() bool {
+	if li == nil {
+		return false
+	}
+
+	li.mu.Lock()
+	defer li.mu.Unlock()
+	return li.stopped
+}
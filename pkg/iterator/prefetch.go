@@ -0,0 +1,118 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// Prefetcher reads an iterator ahead of its consumer, so SBOMs are fetched and processed
+// while earlier ones are still being uploaded. It holds at most buffer SBOMs: once the
+// buffer is full, the inner iterator waits for the consumer. Inner errors other than io.EOF
+// end the iteration; the inner iterator is expected to skip the SBOMs it fails to produce.
+type Prefetcher struct {
+	inner SBOMIterator
+	sboms chan *SBOM
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewPrefetcher reads inner ahead by up to buffer SBOMs. Run must be called to read it.
+func NewPrefetcher(inner SBOMIterator, buffer int) *Prefetcher {
+	return &Prefetcher{
+		inner: inner,
+		sboms: make(chan *SBOM, buffer),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// Run reads the inner iterator until it is exhausted, the context is cancelled or Stop is
+// called, then ends the iteration. The inner iterator reads under a context of its own that
+// Stop cancels, so a fetch still in flight when the consumer gives up is abandoned.
+func (p *Prefetcher) Run(ctx tcontext.TransferMetadata) {
+	defer close(p.done)
+	defer close(p.sboms)
+
+	var cancel context.CancelFunc
+	ctx.Context, cancel = context.WithCancel(ctx.Context)
+	defer cancel()
+	go func() {
+		select {
+		case <-p.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		sbom, err := p.inner.Next(ctx)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				p.mu.Lock()
+				p.err = err
+				p.mu.Unlock()
+			}
+			return
+		}
+
+		select {
+		case p.sboms <- sbom:
+		case <-p.stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop tells the prefetcher its SBOMs are no longer read, e.g. because the upload ended
+// early, cancels the inner iterator's context and waits for Run to return, so the inner
+// iterator is no longer in use
+func (p *Prefetcher) Stop() {
+	p.once.Do(func() { close(p.stop) })
+	<-p.done
+}
+
+// Err returns the error of the inner iterator that ended the iteration early, if any
+func (p *Prefetcher) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+func (p *Prefetcher) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	select {
+	case sbom, ok := <-p.sboms:
+		if ok {
+			return sbom, nil
+		}
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
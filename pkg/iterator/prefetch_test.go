@@ -0,0 +1,62 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingIterator yields one SBOM, then blocks in Next until its context is cancelled
+type blockingIterator struct {
+	yielded bool
+}
+
+func (b *blockingIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	if !b.yielded {
+		b.yielded = true
+		return &SBOM{Path: "first.json"}, nil
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestPrefetcherStopCancelsBlockedSource(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	prefetcher := NewPrefetcher(&blockingIterator{}, 1)
+	go prefetcher.Run(ctx)
+
+	sbom, err := prefetcher.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "first.json", sbom.Path)
+
+	stopped := make(chan struct{})
+	go func() {
+		prefetcher.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop did not return while the source was blocked in Next")
+	}
+	require.NoError(t, ctx.Err(), "stopping the prefetcher must not cancel the transfer")
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/converter"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// ProjectMergeIterator merges the SBOMs of each project, the namespace and version they were
+// fetched from such as a repository's release, into one CycloneDX SBOM describing the
+// project. It reads the whole inner iterator before yielding the first project, holding
+// every SBOM in memory. Projects with a single SBOM are yielded as they are.
+type ProjectMergeIterator struct {
+	inner SBOMIterator
+	stage string // reported with the SBOMs that can't be merged
+
+	drained  bool
+	projects []*projectSBOMs
+	next     int
+	failures []error // SBOMs of the project being merged that couldn't be merged
+}
+
+// projectSBOMs are the SBOMs of one project, in the order they were fetched
+type projectSBOMs struct {
+	namespace, version string
+	sboms              []*SBOM
+}
+
+// NewProjectMergeIterator wraps inner, reporting SBOMs it fails to merge as a *StageError of stage
+func NewProjectMergeIterator(inner SBOMIterator, stage string) *ProjectMergeIterator {
+	return &ProjectMergeIterator{inner: inner, stage: stage}
+}
+
+func (pm *ProjectMergeIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	if !pm.drained {
+		if err := pm.drain(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		if len(pm.failures) > 0 {
+			err := pm.failures[0]
+			pm.failures = pm.failures[1:]
+			return nil, err
+		}
+		if pm.next >= len(pm.projects) || ctx.Err() != nil {
+			return nil, io.EOF
+		}
+
+		project := pm.projects[pm.next]
+		pm.next++
+
+		merged, failures := pm.merge(ctx, project)
+		pm.failures = failures
+		if merged != nil {
+			return merged, nil
+		}
+	}
+}
+
+// drain groups the SBOMs of the inner iterator by project. Inner errors are returned as they
+// occur; the next call goes on draining.
+func (pm *ProjectMergeIterator) drain(ctx tcontext.TransferMetadata) error {
+	index := make(map[string]*projectSBOMs)
+	for _, p := range pm.projects {
+		index[p.namespace+"@"+p.version] = p
+	}
+
+	for {
+		doc, err := pm.inner.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			pm.drained = true
+			logger.LogDebug(ctx.Context, "SBOMs grouped by project for merging", "projects", len(pm.projects))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		key := doc.Namespace + "@" + doc.Version
+		project, ok := index[key]
+		if !ok {
+			project = &projectSBOMs{namespace: doc.Namespace, version: doc.Version}
+			index[key] = project
+			pm.projects = append(pm.projects, project)
+		}
+		project.sboms = append(project.sboms, doc)
+	}
+}
+
+// merge merges the SBOMs of a project, converting them to CycloneDX JSON first. SBOMs that
+// can't be converted are left out and returned as failures.
+func (pm *ProjectMergeIterator) merge(ctx tcontext.TransferMetadata, project *projectSBOMs) (*SBOM, []error) {
+	if len(project.sboms) == 1 {
+		return project.sboms[0], nil
+	}
+
+	version := project.version
+	if version == "" {
+		version = "latest"
+	}
+	name := strings.ReplaceAll(strings.Trim(project.namespace, "/"), "/", "-") + "-" + version + ".cdx.json"
+
+	var failures []error
+	var docs [][]byte
+	var members []*SBOM
+	for _, doc := range project.sboms {
+		data := doc.Data
+		if sbom.DetectFormat(data) != sbom.FormatCycloneDXJSON {
+			converted, err := converter.ConvertSBOM(ctx, data, sbom.FormatSpecCycloneDX)
+			if err != nil {
+				failures = append(failures, &StageError{Stage: pm.stage, File: doc.Path, Namespace: doc.Namespace, Err: fmt.Errorf("converting to CycloneDX: %w", err)})
+				continue
+			}
+			data = converted
+		}
+		docs = append(docs, data)
+		members = append(members, doc)
+	}
+	if len(docs) == 0 {
+		return nil, failures
+	}
+
+	data, err := sbom.MergeCycloneDX(project.namespace, version, docs, converter.CycloneDXTargetVersionFromContext(ctx))
+	if err != nil {
+		for _, doc := range members {
+			failures = append(failures, &StageError{Stage: pm.stage, File: doc.Path, Namespace: doc.Namespace, Err: err})
+		}
+		return nil, failures
+	}
+
+	// the merged document gets a new serial number, its hash is of the SBOMs merged
+	hashes := make([]string, 0, len(members))
+	for _, doc := range members {
+		hashes = append(hashes, doc.SourceHash())
+	}
+	slices.Sort(hashes)
+
+	first := project.sboms[0]
+	logger.LogInfo(ctx.Context, "Merged SBOMs of project", "project", project.namespace, "version", version, "sboms", len(docs), "file", name)
+	return &SBOM{
+		Path:              name,
+		Data:              data,
+		ContentHash:       sbom.ComputeContentHash([]byte(strings.Join(hashes, "\n"))),
+		Namespace:         project.namespace,
+		Version:           version,
+		Branch:            first.Branch,
+		ExplicitNamespace: true,
+		Source:            first.Source,
+	}, failures
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serviceSBOM returns a CycloneDX SBOM of a service depending on one library
+func serviceSBOM(name, library string) []byte {
+	return []byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"component": {"bom-ref": "` + name + `", "type": "application", "name": "` + name + `", "version": "1.0.0"}},
+  "components": [{"bom-ref": "` + library + `", "type": "library", "name": "` + library + `", "version": "1.0.0", "purl": "pkg:golang/` + library + `@1.0.0"}],
+  "dependencies": [{"ref": "` + name + `", "dependsOn": ["` + library + `"]}]
+}`)
+}
+
+// drainAll reads the iterator to the end, returning the SBOMs and errors it yielded
+func drainAll(ctx tcontext.TransferMetadata, iter SBOMIterator) ([]*SBOM, []error) {
+	var sboms []*SBOM
+	var errs []error
+	for {
+		doc, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return sboms, errs
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sboms = append(sboms, doc)
+	}
+}
+
+func TestProjectMergeIteratorFailure(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	api := &SBOM{Path: "api.cdx.json", Data: serviceSBOM("api", "zap"), Namespace: "interlynk-io/monorepo", Version: "v1.0.0"}
+	worker := &SBOM{Path: "worker.cdx.json", Data: serviceSBOM("worker", "viper"), Namespace: "interlynk-io/monorepo", Version: "v1.0.0"}
+	broken := &SBOM{Path: "broken.json", Data: []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5", "components": "none"}`), Namespace: "interlynk-io/monorepo", Version: "v1.0.0"}
+	other := &SBOM{Path: "sbomqs.cdx.json", Data: serviceSBOM("sbomqs", "cobra"), Namespace: "interlynk-io/sbomqs", Version: "v2.0.0"}
+
+	merged, errs := drainAll(ctx, NewProjectMergeIterator(NewMemoryIterator([]*SBOM{api, other, worker, broken}), "merge"))
+
+	// the document that can't be decoded fails the merge of its whole project
+	require.Len(t, errs, 3)
+	var stageErr *StageError
+	require.ErrorAs(t, errs[0], &stageErr)
+	assert.Equal(t, "merge", stageErr.Stage)
+
+	// a project with a single SBOM is yielded as it is
+	require.Len(t, merged, 1)
+	assert.Same(t, other, merged[0])
+}
+
+func TestProjectMergeIteratorMergesProjects(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	api := &SBOM{Path: "api.cdx.json", Data: serviceSBOM("api", "zap"), Namespace: "interlynk-io/monorepo", Version: "v1.0.0", Source: "github"}
+	worker := &SBOM{Path: "worker.cdx.json", Data: serviceSBOM("worker", "viper"), Namespace: "interlynk-io/monorepo", Version: "v1.0.0", Source: "github"}
+	other := &SBOM{Path: "sbomqs.cdx.json", Data: serviceSBOM("sbomqs", "cobra"), Namespace: "interlynk-io/sbomqs", Version: "v2.0.0"}
+
+	merged, errs := drainAll(ctx, NewProjectMergeIterator(NewMemoryIterator([]*SBOM{api, other, worker}), "merge"))
+	require.Empty(t, errs)
+	require.Len(t, merged, 2)
+
+	// projects are yielded in the order they were first fetched
+	project := merged[0]
+	assert.Equal(t, "interlynk-io-monorepo-v1.0.0.cdx.json", project.Path)
+	assert.Equal(t, "interlynk-io/monorepo", project.Namespace)
+	assert.Equal(t, "v1.0.0", project.Version)
+	assert.Equal(t, "github", project.Source)
+	assert.True(t, project.ExplicitNamespace)
+	assert.Same(t, other, merged[1])
+
+	result := sbom.Validate(project.Data)
+	assert.True(t, result.Valid(), "merged SBOM is invalid: %v", result.Errors)
+	for _, component := range []string{`"name": "api"`, `"name": "worker"`, `"name": "zap"`, `"name": "viper"`} {
+		assert.Contains(t, string(project.Data), component)
+	}
+
+	// the hash of the merged SBOM doesn't depend on the order its SBOMs were fetched in
+	reordered, _ := drainAll(ctx, NewProjectMergeIterator(NewMemoryIterator([]*SBOM{worker, api}), "merge"))
+	require.Len(t, reordered, 1)
+	assert.Equal(t, project.ContentHash, reordered[0].ContentHash)
+	assert.NotEqual(t, api.SourceHash(), project.ContentHash)
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sign"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/verify"
+)
+
+// SignatureError is an SBOM whose signature or attestation didn't verify
+type SignatureError struct {
+	File      string
+	Namespace string
+	Err       error
+}
+
+func (e *SignatureError) Error() string {
+	return fmt.Sprintf("%s failed signature verification: %v", e.File, e.Err)
+}
+
+func (e *SignatureError) Unwrap() error {
+	return e.Err
+}
+
+// SignatureChecker drops SBOMs that can't be verified against the signatures fetched along
+// with them, see SBOM.Signatures, before they are converted
+type SignatureChecker struct {
+	verifier *verify.Verifier
+
+	mu         sync.Mutex
+	rejected   int
+	onRejected func(tcontext.TransferMetadata, *SignatureError)
+}
+
+// NewSignatureChecker returns a checker verifying SBOMs with verifier
+func NewSignatureChecker(verifier *verify.Verifier) *SignatureChecker {
+	return &SignatureChecker{verifier: verifier}
+}
+
+// Skip is the processing stage dropping SBOMs whose signature doesn't verify
+func (c *SignatureChecker) Skip(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+	err := c.verifier.Verify(doc.Data, doc.Signatures)
+	if err == nil {
+		logger.LogDebug(ctx.Context, "SBOM signature verified", "file", doc.Path)
+		return doc, nil
+	}
+
+	rejected := &SignatureError{File: doc.Path, Namespace: doc.Namespace, Err: err}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rejected++
+	if c.onRejected != nil {
+		c.onRejected(ctx, rejected)
+	}
+	logger.LogInfo(ctx.Context, "Skipping SBOM failing signature verification", "file", doc.Path, "namespace", doc.Namespace, "error", err)
+	return nil, nil
+}
+
+// OnRejected sets a function called with every SBOM failing verification
+func (c *SignatureChecker) OnRejected(fn func(tcontext.TransferMetadata, *SignatureError)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onRejected = fn
+}
+
+// Rejected returns the number of SBOMs that failed verification. A nil checker reports none.
+func (c *SignatureChecker) Rejected() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rejected
+}
+
+// Sign returns the processing stage signing SBOMs as they reach the destination, replacing
+// the signatures they were fetched with
+func Sign(signer *sign.Signer) TransformFunc {
+	return func(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+		signatures, err := signer.Sign(ctx.Context, doc.Data)
+		if err != nil {
+			return nil, err
+		}
+		doc.Signatures = signatures
+		logger.LogDebug(ctx.Context, "Signed SBOM", "file", doc.Path)
+		return doc, nil
+	}
+}
+
+// SignatureSuffixes returns the suffixes of the SBOM's signature files, sorted, for
+// destinations writing them next to the SBOM
+func (s *SBOM) SignatureSuffixes() []string {
+	return slices.Sorted(maps.Keys(s.Signatures))
+}
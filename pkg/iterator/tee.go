@@ -0,0 +1,128 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// teeBuffer is how many SBOMs a branch of a Tee holds before it slows the inner iterator down
+const teeBuffer = 4
+
+// Tee hands every SBOM of an iterator to several branches, so each output of a fan-out
+// transfer receives all of them. Branches are read concurrently and buffer only a few
+// SBOMs, so the slowest branch paces the inner iterator. Inner errors other than io.EOF
+// end every branch; the inner iterator is expected to skip the SBOMs it fails to produce.
+type Tee struct {
+	inner    SBOMIterator
+	branches []*teeBranch
+
+	mu  sync.Mutex
+	err error
+}
+
+type teeBranch struct {
+	sboms    chan *SBOM
+	released chan struct{}
+	once     sync.Once
+}
+
+// NewTee splits inner into n branches. Run must be called to move SBOMs into them.
+func NewTee(inner SBOMIterator, n int) *Tee {
+	t := &Tee{inner: inner}
+	for i := 0; i < n; i++ {
+		t.branches = append(t.branches, &teeBranch{
+			sboms:    make(chan *SBOM, teeBuffer),
+			released: make(chan struct{}),
+		})
+	}
+	return t
+}
+
+// Branches returns the iterators of the branches, in order
+func (t *Tee) Branches() []SBOMIterator {
+	iters := make([]SBOMIterator, len(t.branches))
+	for i, b := range t.branches {
+		iters[i] = b
+	}
+	return iters
+}
+
+// Release tells the tee branch i is no longer read, e.g. because its upload failed, so
+// the other branches don't wait for it
+func (t *Tee) Release(i int) {
+	b := t.branches[i]
+	b.once.Do(func() { close(b.released) })
+}
+
+// Run reads the inner iterator until it is exhausted, the context is cancelled or every
+// branch is released, handing each SBOM to all branches, then ends the branches
+func (t *Tee) Run(ctx tcontext.TransferMetadata) {
+	defer func() {
+		for _, b := range t.branches {
+			close(b.sboms)
+		}
+	}()
+
+	for {
+		sbom, err := t.inner.Next(ctx)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.mu.Lock()
+				t.err = err
+				t.mu.Unlock()
+			}
+			return
+		}
+
+		active := 0
+		for _, b := range t.branches {
+			// branches get their own copy, as they may rename or convert it
+			select {
+			case b.sboms <- sbom.Copy():
+				active++
+			case <-b.released:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if active == 0 {
+			return
+		}
+	}
+}
+
+// Err returns the error of the inner iterator that ended the branches early, if any
+func (t *Tee) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+func (b *teeBranch) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	select {
+	case sbom, ok := <-b.sboms:
+		if !ok {
+			return nil, io.EOF
+		}
+		return sbom, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeeBranchesDontShareContent(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	fetched := &SBOM{
+		Path:       "sbom.json",
+		Data:       []byte(`{"bomFormat":"CycloneDX"}`),
+		Signatures: map[string][]byte{".sig": []byte("signature")},
+	}
+	tee := NewTee(NewMemoryIterator([]*SBOM{fetched}), 2)
+	branches := tee.Branches()
+
+	received := make([]*SBOM, len(branches))
+	var wg sync.WaitGroup
+	for i, branch := range branches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				sbom, err := branch.Next(ctx)
+				if err == io.EOF {
+					return
+				}
+				require.NoError(t, err)
+				received[i] = sbom
+			}
+		}()
+	}
+	tee.Run(ctx)
+	wg.Wait()
+	require.NoError(t, tee.Err())
+
+	// the first output converts its copy in place and drops the signatures
+	received[0].Data[0] = '['
+	received[0].Signatures[".sig"][0] = 'X'
+	delete(received[0].Signatures, ".sig")
+
+	require.Equal(t, `{"bomFormat":"CycloneDX"}`, string(received[1].Data))
+	require.Equal(t, "signature", string(received[1].Signatures[".sig"]))
+	require.Equal(t, `{"bomFormat":"CycloneDX"}`, string(fetched.Data))
+}
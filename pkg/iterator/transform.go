@@ -0,0 +1,205 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/converter"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/timing"
+)
+
+// TransformFunc reshapes an SBOM on its way to the destination: conversion, re-encoding,
+// renaming, enrichment or redaction. It returns the SBOM to pass on, usually the one it was
+// given, or nil to drop it. An error fails this SBOM only, the iteration goes on.
+type TransformFunc func(ctx tcontext.TransferMetadata, sbom *SBOM) (*SBOM, error)
+
+// TransformIterator applies a TransformFunc to every SBOM of the inner iterator
+type TransformIterator struct {
+	inner SBOMIterator
+	fn    TransformFunc
+}
+
+// Transform returns an iterator yielding the SBOMs of inner as transformed by fn
+func Transform(inner SBOMIterator, fn TransformFunc) *TransformIterator {
+	return &TransformIterator{inner: inner, fn: fn}
+}
+
+func (ti *TransformIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	for {
+		doc, err := ti.inner.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		transformed, err := ti.fn(ctx, doc)
+		if err != nil {
+			return nil, err
+		}
+		if transformed != nil {
+			return transformed, nil
+		}
+	}
+}
+
+// Stage is a named transformation of a pipeline. The name is reported with the SBOMs it
+// fails to transform, e.g. "conversion".
+type Stage struct {
+	Name  string
+	Apply TransformFunc
+}
+
+// StageError is returned by a pipeline for an SBOM one of its stages failed to transform
+type StageError struct {
+	Stage     string
+	File      string
+	Namespace string
+	Err       error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("%s of %s failed: %v", e.Stage, e.File, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// Pipeline chains the stages over inner, in order. An SBOM a stage fails on is returned as a
+// *StageError and skips the stages after it.
+func Pipeline(inner SBOMIterator, stages ...Stage) SBOMIterator {
+	iter := inner
+	for _, stage := range stages {
+		iter = Transform(iter, stage.transform)
+	}
+	return iter
+}
+
+func (s Stage) transform(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+	// the input of the stage, as the function may have changed the SBOM before failing
+	file, namespace := doc.Path, doc.Namespace
+
+	transformed, err := s.Apply(ctx, doc)
+	if err != nil {
+		logger.LogDebug(ctx.Context, "SBOM transformation failed", "stage", s.Name, "file", file, "error", err)
+		return nil, &StageError{Stage: s.Name, File: file, Namespace: namespace, Err: err}
+	}
+	return transformed, nil
+}
+
+// Convert converts SBOMs to the target spec. With rename, converted SBOMs are renamed after
+// their new spec, e.g. "app.cdx.json" to "app.spdx.json", for destinations storing SBOMs
+// under their name.
+func Convert(targetFormat sbom.FormatSpec, rename bool) TransformFunc {
+	return func(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+		start := time.Now()
+		convertedData, err := converter.ConvertSBOM(ctx, doc.Data, targetFormat)
+		timing.Record(ctx, timing.StageConvert, doc.Path, time.Since(start))
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(convertedData, doc.Data) {
+			doc.markConverted()
+			if rename && doc.Path != "" {
+				doc.Path = sbom.ConvertedName(doc.Path, targetFormat)
+			}
+		}
+		doc.Data = convertedData
+		return doc, nil
+	}
+}
+
+// EncodeJSON re-encodes CycloneDX protobuf and SPDX YAML SBOMs as JSON, for destinations
+// that only accept JSON. Other SBOMs pass through unchanged.
+func EncodeJSON(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+	start := time.Now()
+	data, reencoded, err := sbom.ToJSON(doc.Data)
+	timing.Record(ctx, timing.StageConvert, doc.Path, time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+
+	if reencoded {
+		logger.LogDebug(ctx.Context, "Re-encoded SBOM as JSON", "file", doc.Path)
+		doc.markConverted()
+		doc.Data = data
+	}
+	return doc, nil
+}
+
+// Enrich returns the processing stage adding the metadata of --enrich-* to SBOMs lacking it
+func Enrich(enrichment sbom.Enrichment) TransformFunc {
+	return func(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+		data, changed, err := sbom.Enrich(doc.Data, enrichment, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if len(changed) > 0 {
+			logger.LogDebug(ctx.Context, "Enriched SBOM", "file", doc.Path, "fields", changed)
+			doc.Data = data
+			// the signatures it was fetched with don't match the enriched SBOM
+			doc.Signatures = nil
+		}
+		return doc, nil
+	}
+}
+
+// markConverted remembers the format of the SBOM before its first conversion. The signatures
+// it was fetched with are dropped, they don't match the converted SBOM.
+func (s *SBOM) markConverted() {
+	s.Signatures = nil
+	if s.ConvertedFrom == "" {
+		s.ConvertedFrom = sbom.DetectFormat(s.Data)
+	}
+}
+
+// Conversion describes how processing converted the SBOM, e.g. "SPDX-JSON → CycloneDX-JSON",
+// or returns "" when it was passed on as fetched.
+func (s *SBOM) Conversion() string {
+	if s.ConvertedFrom == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s → %s", s.ConvertedFrom, sbom.DetectFormat(s.Data))
+}
+
+// FilterFormats drops SBOMs whose format is excluded by --include-formats/--exclude-formats.
+// Sources already skip what they can tell from file names; this catches the rest by content.
+func FilterFormats(filter *sbom.FormatFilter) TransformFunc {
+	return func(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+		if filter.AllowsContent(doc.Data) {
+			return doc, nil
+		}
+		logger.LogDebug(ctx.Context, "Skipping SBOM excluded by format filter", "file", doc.Path, "format", sbom.DetectFormat(doc.Data))
+		return nil, nil
+	}
+}
+
+// FilterSpecVersions returns a TransformFunc dropping SBOMs whose spec version the filter excludes
+func FilterSpecVersions(filter *sbom.SpecVersionFilter) TransformFunc {
+	return func(ctx tcontext.TransferMetadata, doc *SBOM) (*SBOM, error) {
+		if filter.AllowsContent(doc.Data) {
+			return doc, nil
+		}
+		spec, version := sbom.DetectSpecVersion(doc.Data)
+		logger.LogDebug(ctx.Context, "Skipping SBOM excluded by spec version filter", "file", doc.Path, "spec", spec, "spec_version", version)
+		return nil, nil
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package iterator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// ValidationError is an SBOM that failed schema validation
+type ValidationError struct {
+	File      string
+	Namespace string
+	Result    sbom.ValidationResult
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s failed %s %s schema validation: %s", e.File, e.Result.Spec, e.Result.Version, strings.Join(e.Result.Errors, "; "))
+}
+
+// ValidatingIterator checks SBOMs against the schema of their spec version before they are
+// converted. Invalid SBOMs are skipped or, with failFast, end the iteration; Err then reports
+// the first of them. SBOMs no schema covers are passed through.
+type ValidatingIterator struct {
+	inner    SBOMIterator
+	failFast bool
+
+	mu        sync.Mutex
+	invalid   int
+	err       error
+	onInvalid func(tcontext.TransferMetadata, *ValidationError)
+}
+
+// NewValidatingIterator wraps inner, stopping at the first invalid SBOM when failFast is set
+func NewValidatingIterator(inner SBOMIterator, failFast bool) *ValidatingIterator {
+	return &ValidatingIterator{inner: inner, failFast: failFast}
+}
+
+func (vi *ValidatingIterator) Next(ctx tcontext.TransferMetadata) (*SBOM, error) {
+	vi.mu.Lock()
+	defer vi.mu.Unlock()
+
+	for {
+		if vi.err != nil {
+			return nil, io.EOF
+		}
+
+		doc, err := vi.inner.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := sbom.Validate(doc.Data)
+		switch result.Status {
+		case sbom.ValidationPass:
+			return doc, nil
+		case sbom.ValidationUnsupported:
+			logger.LogDebug(ctx.Context, "SBOM not validated", "file", doc.Path, "reason", strings.Join(result.Errors, "; "))
+			return doc, nil
+		}
+
+		invalid := &ValidationError{File: doc.Path, Namespace: doc.Namespace, Result: result}
+		vi.invalid++
+		if vi.onInvalid != nil {
+			vi.onInvalid(ctx, invalid)
+		}
+
+		if vi.failFast {
+			vi.err = invalid
+			logger.LogError(ctx.Context, invalid, "Invalid SBOM, stopping transfer", "file", doc.Path)
+			return nil, io.EOF
+		}
+		logger.LogInfo(ctx.Context, "Skipping invalid SBOM", "file", doc.Path, "spec", result.Spec, "version", result.Version, "errors", len(result.Errors))
+	}
+}
+
+// OnInvalid sets a function called with every SBOM failing validation
+func (vi *ValidatingIterator) OnInvalid(fn func(tcontext.TransferMetadata, *ValidationError)) {
+	vi.mu.Lock()
+	defer vi.mu.Unlock()
+	vi.onInvalid = fn
+}
+
+// Invalid returns the number of SBOMs that failed validation. A nil iterator reports none.
+func (vi *ValidatingIterator) Invalid() int {
+	if vi == nil {
+		return 0
+	}
+	vi.mu.Lock()
+	defer vi.mu.Unlock()
+	return vi.invalid
+}
+
+// Err returns the invalid SBOM that ended the iteration in fail-fast mode
+func (vi *ValidatingIterator) Err() error {
+	if vi == nil {
+		return nil
+	}
+	vi.mu.Lock()
+	defer vi.mu.Unlock()
+	return vi.err
+}
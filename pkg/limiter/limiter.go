@@ -0,0 +1,142 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limiter
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// contextKey is the TransferMetadata key under which the engine stores the limiter
+const contextKey = "transfer_limiter"
+
+// Limiter is the engine-wide budget of SBOM transfers in flight. It is shared by every
+// uploader, independent of their own worker counts, so bursts (e.g. many repos releasing
+// at once in daemon mode) queue up instead of overwhelming the destination.
+// A nil *Limiter is valid and never blocks.
+type Limiter struct {
+	slots     chan struct{}
+	inFlight  atomic.Int64
+	queued    atomic.Int64
+	maxQueued atomic.Int64
+	completed atomic.Int64
+}
+
+// Stats is a snapshot of the limiter's queue metrics
+type Stats struct {
+	Limit     int   `json:"limit"`
+	InFlight  int64 `json:"in_flight"`
+	Queued    int64 `json:"queued"`
+	MaxQueued int64 `json:"max_queued"`
+	Completed int64 `json:"completed"`
+}
+
+// New returns a limiter allowing max concurrent transfers, or nil when max is 0 (unlimited)
+func New(max int) *Limiter {
+	if max <= 0 {
+		return nil
+	}
+	return &Limiter{slots: make(chan struct{}, max)}
+}
+
+// NewUnlimited returns a limiter that never blocks but keeps the queue metrics, so the
+// transfers in flight can be reported without limiting them
+func NewUnlimited() *Limiter {
+	return &Limiter{}
+}
+
+// Attach stores the limiter in the transfer context for uploaders to pick up
+func Attach(ctx *tcontext.TransferMetadata, l *Limiter) {
+	if l != nil {
+		ctx.WithValue(contextKey, l)
+	}
+}
+
+// FromContext returns the limiter of the transfer, or nil when none is configured
+func FromContext(ctx tcontext.TransferMetadata) *Limiter {
+	l, _ := ctx.Value(contextKey).(*Limiter)
+	return l
+}
+
+// Acquire waits for a transfer slot and returns the function releasing it.
+// It fails only when ctx is cancelled while waiting.
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if l.slots == nil {
+		l.inFlight.Add(1)
+		return func() {
+			l.inFlight.Add(-1)
+			l.completed.Add(1)
+		}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+	default:
+		// budget exhausted, queue up
+		depth := l.queued.Add(1)
+		for {
+			peak := l.maxQueued.Load()
+			if depth <= peak || l.maxQueued.CompareAndSwap(peak, depth) {
+				break
+			}
+		}
+		logger.LogDebug(ctx, "Transfer queued, concurrency budget exhausted", "queue_depth", depth, "limit", cap(l.slots))
+
+		select {
+		case l.slots <- struct{}{}:
+			l.queued.Add(-1)
+		case <-ctx.Done():
+			l.queued.Add(-1)
+			return nil, ctx.Err()
+		}
+	}
+
+	l.inFlight.Add(1)
+	return func() {
+		l.inFlight.Add(-1)
+		l.completed.Add(1)
+		<-l.slots
+	}, nil
+}
+
+// Stats returns the current queue metrics
+func (l *Limiter) Stats() Stats {
+	if l == nil {
+		return Stats{}
+	}
+	return Stats{
+		Limit:     cap(l.slots),
+		InFlight:  l.inFlight.Load(),
+		Queued:    l.queued.Load(),
+		MaxQueued: l.maxQueued.Load(),
+		Completed: l.completed.Load(),
+	}
+}
+
+// Transfer runs fn within a slot of the transfer's limiter, if any
+func Transfer(ctx tcontext.TransferMetadata, fn func() error) error {
+	release, err := FromContext(ctx).Acquire(ctx.Context)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return fn()
+}
@@ -16,21 +16,67 @@ package logger
 
 import (
 	"context"
+	"io"
+	"sync"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-var logger *zap.SugaredLogger
+var (
+	logger *zap.SugaredLogger
+	mu     sync.Mutex
+)
 
 type contextKey struct{}
 
+// Option customizes the logger built by InitLogger.
+type Option func(*options)
+
+type options struct {
+	writer  io.Writer
+	quiet   bool
+	noColor bool
+}
+
+// WithWriter sends log output to w instead of stdout, letting embedders and tests
+// capture logs without redirecting the process-wide stdout.
+func WithWriter(w io.Writer) Option {
+	return func(o *options) {
+		o.writer = w
+	}
+}
+
+// WithQuiet logs errors only, for commands that print a summary of their own instead
+func WithQuiet() Option {
+	return func(o *options) {
+		o.quiet = true
+	}
+}
+
+// WithoutColor writes plain level names, for log files and log shippers that don't render
+// terminal colors. JSON output never has colors.
+func WithoutColor() Option {
+	return func(o *options) {
+		o.noColor = true
+	}
+}
+
 // InitLogger initializes the logger with a specified log level and format (JSON or console).
-func InitLogger(debug bool, jsonFormat bool) {
+// It is safe to call from multiple goroutines, but panics if the logger is already initialized.
+func InitLogger(debug bool, jsonFormat bool, opts ...Option) {
+	mu.Lock()
+	defer mu.Unlock()
+
 	if logger != nil {
 		panic("logger already initialized")
 	}
 
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var config zap.Config
 	if debug {
 		config = zap.NewDevelopmentConfig()
@@ -52,10 +98,31 @@ func InitLogger(debug bool, jsonFormat bool) {
 	config.EncoderConfig.CallerKey = "caller"
 	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	if jsonFormat || o.noColor {
+		config.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	}
+	if o.quiet {
+		config.Level = zap.NewAtomicLevelAt(zapcore.ErrorLevel)
+	}
 	config.OutputPaths = []string{"stdout"}
 	config.ErrorOutputPaths = []string{"stderr"}
 
-	l, err := config.Build()
+	// report the caller of LogInfo and friends rather than this package
+	buildOpts := []zap.Option{zap.AddCallerSkip(1)}
+	if o.writer != nil {
+		var encoder zapcore.Encoder
+		if jsonFormat {
+			encoder = zapcore.NewJSONEncoder(config.EncoderConfig)
+		} else {
+			encoder = zapcore.NewConsoleEncoder(config.EncoderConfig)
+		}
+		sink := zapcore.Lock(zapcore.AddSync(o.writer))
+		buildOpts = append(buildOpts, zap.WrapCore(func(zapcore.Core) zapcore.Core {
+			return zapcore.NewCore(encoder, sink, config.Level)
+		}))
+	}
+
+	l, err := config.Build(buildOpts...)
 	if err != nil {
 		panic("failed to initialize logger: " + err.Error())
 	}
@@ -65,6 +132,8 @@ func InitLogger(debug bool, jsonFormat bool) {
 
 // WithLogger attaches the logger to the context.
 func WithLogger(ctx context.Context) context.Context {
+	mu.Lock()
+	defer mu.Unlock()
 	return context.WithValue(ctx, contextKey{}, logger)
 }
 
@@ -78,6 +147,8 @@ func FromContext(ctx context.Context) *zap.SugaredLogger {
 
 // Sync flushes any buffered log entries.
 func Sync() {
+	mu.Lock()
+	defer mu.Unlock()
 	if logger != nil {
 		_ = logger.Sync()
 	}
@@ -104,6 +175,8 @@ func LogInfo(ctx context.Context, msg string, keysAndValues ...interface{}) {
 
 // DeinitLogger deinitializes the logger by syncing and resetting it.
 func DeinitLogger() {
+	mu.Lock()
+	defer mu.Unlock()
 	if logger != nil {
 		_ = logger.Sync()
 		logger = nil
@@ -0,0 +1,228 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	barRefresh  = 250 * time.Millisecond
+	logInterval = 10 * time.Second
+	barWidth    = 20
+	clearLine   = "\r\033[K"
+)
+
+var (
+	// screenMu serializes the bar and the log lines written to the same terminal
+	screenMu sync.Mutex
+	// onScreen is the bar currently drawn, nil when there is none
+	onScreen *bar
+)
+
+// Show displays the progress of the tracker until the returned stop function is called:
+// as a bar redrawn on out when it is a terminal, or as a log line every few seconds.
+// Stop draws the final progress.
+func Show(ctx tcontext.TransferMetadata, t *Tracker, mode types.ProgressMode, out *os.File) (stop func()) {
+	if t == nil || mode == types.ProgressOff {
+		return func() {}
+	}
+	if mode == types.ProgressAuto {
+		mode = types.ProgressLog
+		if isatty.IsTerminal(out.Fd()) || isatty.IsCygwinTerminal(out.Fd()) {
+			mode = types.ProgressBar
+		}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	if mode == types.ProgressBar {
+		b := &bar{tracker: t, out: out}
+		go func() {
+			defer wg.Done()
+			b.run(ctx, done)
+		}()
+	} else {
+		go func() {
+			defer wg.Done()
+			logProgress(ctx, t, done)
+		}()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+}
+
+// LogWriter wraps the log output so log lines are written above the progress bar
+// rather than across it
+func LogWriter(w io.Writer) io.Writer {
+	return &logWriter{w: w}
+}
+
+type logWriter struct {
+	w io.Writer
+}
+
+func (lw *logWriter) Write(p []byte) (int, error) {
+	screenMu.Lock()
+	defer screenMu.Unlock()
+
+	if onScreen == nil {
+		return lw.w.Write(p)
+	}
+	onScreen.clear()
+	n, err := lw.w.Write(p)
+	onScreen.draw()
+	return n, err
+}
+
+// bar redraws the progress on one terminal line
+type bar struct {
+	tracker *Tracker
+	out     io.Writer
+	line    string
+}
+
+func (b *bar) run(ctx tcontext.TransferMetadata, done <-chan struct{}) {
+	ticker := time.NewTicker(barRefresh)
+	defer ticker.Stop()
+
+	b.refresh()
+	screenMu.Lock()
+	onScreen = b
+	screenMu.Unlock()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.refresh()
+		case <-ctx.Done():
+			b.finish()
+			return
+		case <-done:
+			b.finish()
+			return
+		}
+	}
+}
+
+// refresh redraws the bar with the current progress
+func (b *bar) refresh() {
+	line := formatBar(b.tracker.Snapshot())
+
+	screenMu.Lock()
+	defer screenMu.Unlock()
+	b.line = line
+	b.draw()
+}
+
+// finish draws the final progress and leaves it on screen
+func (b *bar) finish() {
+	line := formatBar(b.tracker.Snapshot())
+
+	screenMu.Lock()
+	defer screenMu.Unlock()
+	b.line = line
+	b.draw()
+	fmt.Fprintln(b.out)
+	onScreen = nil
+}
+
+// draw and clear are called with screenMu held
+func (b *bar) draw() {
+	fmt.Fprint(b.out, clearLine+b.line)
+}
+
+func (b *bar) clear() {
+	fmt.Fprint(b.out, clearLine)
+}
+
+// formatBar renders a snapshot as a one-line bar, with counts when the total is unknown
+func formatBar(s Snapshot) string {
+	var parts []string
+	if s.Total > 0 {
+		filled := barWidth * s.Done() / s.Total
+		parts = append(parts, fmt.Sprintf("[%s%s] %d/%d SBOMs", strings.Repeat("█", filled), strings.Repeat("░", barWidth-filled), s.Done(), s.Total))
+	} else {
+		parts = append(parts, fmt.Sprintf("%d SBOMs transferred", s.Transferred), fmt.Sprintf("%d fetched", s.Fetched))
+	}
+	if s.Failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", s.Failed))
+	}
+	if s.Sources > 1 {
+		parts = append(parts, fmt.Sprintf("%d sources", s.Sources))
+	}
+	parts = append(parts, utils.FormatByteSize(s.Bytes))
+	if s.ETA > 0 {
+		parts = append(parts, "ETA "+formatDuration(s.ETA))
+	} else {
+		parts = append(parts, formatDuration(s.Elapsed))
+	}
+	return "⏳ " + strings.Join(parts, " · ")
+}
+
+// logProgress logs the progress every few seconds while it changes
+func logProgress(ctx tcontext.TransferMetadata, t *Tracker, done <-chan struct{}) {
+	ticker := time.NewTicker(logInterval)
+	defer ticker.Stop()
+
+	var last Snapshot
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+			s := t.Snapshot()
+			if s.Fetched == last.Fetched && s.Transferred == last.Transferred && s.Failed == last.Failed {
+				continue
+			}
+			last = s
+
+			keysAndValues := []interface{}{"fetched", s.Fetched}
+			if s.Total > 0 {
+				keysAndValues = append(keysAndValues, "total", s.Total)
+			}
+			keysAndValues = append(keysAndValues, "transferred", s.Transferred, "failed", s.Failed, "sources", s.Sources, "bytes", utils.FormatByteSize(s.Bytes), "elapsed", formatDuration(s.Elapsed))
+			if s.ETA > 0 {
+				keysAndValues = append(keysAndValues, "eta", formatDuration(s.ETA))
+			}
+			logger.LogInfo(ctx.Context, "Transfer progress", keysAndValues...)
+		}
+	}
+}
+
+// formatDuration rounds d to the second, e.g. 1m20s
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
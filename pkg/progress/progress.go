@@ -0,0 +1,138 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// Tracker follows the progress of a one-shot transfer: it counts the SBOMs the source
+// yields, per namespace, while the transfer report counts those transferred and failed.
+// It is safe for concurrent use.
+type Tracker struct {
+	volume  *report.Collector
+	started time.Time
+	total   int // SBOMs the source yields at most, 0 when unknown
+	fetched atomic.Int64
+
+	mu      sync.Mutex
+	sources map[string]bool // namespaces SBOMs were fetched from, e.g. repositories
+}
+
+// NewTracker returns a tracker reading the transferred and failed SBOMs from volume
+func NewTracker(volume *report.Collector) *Tracker {
+	return &Tracker{
+		volume:  volume,
+		started: time.Now(),
+		sources: make(map[string]bool),
+	}
+}
+
+// Count wraps the iterator of the source, counting the SBOMs it yields. When it knows how
+// many SBOMs it yields, see iterator.Sized, the tracker estimates the time left.
+func (t *Tracker) Count(inner iterator.SBOMIterator) iterator.SBOMIterator {
+	if sized, ok := inner.(iterator.Sized); ok {
+		t.total = sized.Total()
+	}
+	return &countingIterator{inner: inner, tracker: t}
+}
+
+// Limit caps the SBOMs the tracker expects at limit, for transfers stopped by --limit.
+// A nil tracker ignores it.
+func (t *Tracker) Limit(limit int) {
+	if t == nil {
+		return
+	}
+	if t.total == 0 || limit < t.total {
+		t.total = limit
+	}
+}
+
+// Snapshot is the progress of a transfer at a point in time
+type Snapshot struct {
+	Fetched     int           // SBOMs yielded by the source
+	Total       int           // SBOMs the source yields at most, 0 when unknown
+	Sources     int           // namespaces SBOMs were fetched from
+	Transferred int           // SBOMs transferred to the destination
+	Failed      int           // SBOMs that failed to transfer
+	Bytes       int64         // bytes transferred
+	Elapsed     time.Duration // since the tracker was created
+	ETA         time.Duration // time left, 0 when unknown
+}
+
+// Snapshot returns the progress so far
+func (t *Tracker) Snapshot() Snapshot {
+	summary := t.volume.Summary()
+
+	t.mu.Lock()
+	sources := len(t.sources)
+	t.mu.Unlock()
+
+	s := Snapshot{
+		Fetched:     int(t.fetched.Load()),
+		Total:       t.total,
+		Sources:     sources,
+		Transferred: summary.Total.SBOMs,
+		Failed:      len(summary.Failures),
+		Bytes:       summary.Total.Bytes,
+		Elapsed:     time.Since(t.started),
+	}
+
+	if done := s.Done(); s.Total > 0 && done > 0 && done < s.Total {
+		s.ETA = time.Duration(float64(s.Elapsed) / float64(done) * float64(s.Total-done))
+	}
+	return s
+}
+
+// Done returns the SBOMs transferred or failed, at most Total when it is known. With several
+// outputs an SBOM counts once per output it was transferred to.
+func (s Snapshot) Done() int {
+	done := s.Transferred + s.Failed
+	if s.Total > 0 {
+		done = min(done, s.Total)
+	}
+	return done
+}
+
+func (t *Tracker) fetchedFrom(namespace string) {
+	t.fetched.Add(1)
+	if namespace == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sources[namespace] = true
+}
+
+// countingIterator counts the SBOMs of the iterator it wraps
+type countingIterator struct {
+	inner   iterator.SBOMIterator
+	tracker *Tracker
+}
+
+func (it *countingIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
+	sbom, err := it.inner.Next(ctx)
+	if err == nil && sbom != nil {
+		it.tracker.fetchedFrom(sbom.Namespace)
+	}
+	return sbom, err
+}
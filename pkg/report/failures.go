@@ -0,0 +1,226 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/retry"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// Stages of a transfer an SBOM can fail at
+const (
+	StageDownload = "download"
+	StageVerify   = "signature verification"
+	StageValidate = "validation"
+	StageMerge    = "merge"
+	StageSplit    = "split"
+	StageConvert  = "conversion"
+	StageEnrich   = "enrichment"
+	StageComply   = "NTIA compliance"
+	StageSign     = "signing"
+	StageProject  = "project creation"
+	StageMapping  = "project mapping" // to an existing project of the destination
+	StageUpload   = "upload"
+	StageProcess  = "processing" // by the destination, after the upload
+)
+
+// ErrRejectedByDestination is returned by output adapters that uploaded SBOMs the destination
+// then failed to process. The failures are recorded, the transfer itself completed.
+var ErrRejectedByDestination = errors.New("SBOMs rejected by the destination")
+
+// Failure is an SBOM that didn't make it to the destination
+type Failure struct {
+	File        string    `json:"file,omitempty"`
+	Namespace   string    `json:"namespace,omitempty"`
+	Project     string    `json:"project,omitempty"`
+	Stage       string    `json:"stage"`
+	Attempts    int       `json:"attempts,omitempty"` // set when retries were exhausted
+	Reason      string    `json:"reason"`
+	Error       string    `json:"error"`
+	Time        time.Time `json:"time"`
+	Destination string    `json:"destination,omitempty"` // set when SBOMs went to several outputs
+	NTIAScore   *float64  `json:"ntia_score,omitempty"`  // set when rejected by --min-ntia-score
+}
+
+// httpStatusPattern finds the HTTP status in errors such as "api error (status: 404)",
+// "unexpected status: 500" or "StatusCode: 403"
+var httpStatusPattern = regexp.MustCompile(`(?i)status(?: ?code)?:? *\(?([1-5][0-9]{2})\b`)
+
+// Reason classifies a failure by stage and, when the error carries one, HTTP status class,
+// e.g. "upload failed (HTTP 4xx)", noting when the upload gave up after retrying
+// e.g. "upload failed (HTTP 5xx, retries exhausted)"
+func Reason(stage string, err error) string {
+	reason := stage + " failed"
+	if err == nil {
+		return reason
+	}
+
+	var details []string
+	if m := httpStatusPattern.FindStringSubmatch(err.Error()); m != nil {
+		if code, _ := strconv.Atoi(m[1]); code >= 400 {
+			details = append(details, fmt.Sprintf("HTTP %dxx", code/100))
+		}
+	}
+	var exhausted *retry.ExhaustedError
+	if errors.As(err, &exhausted) {
+		details = append(details, "retries exhausted")
+	}
+	if len(details) > 0 {
+		reason += " (" + strings.Join(details, ", ") + ")"
+	}
+	return reason
+}
+
+// RecordFailure records an SBOM that failed at the given stage of the transfer
+func RecordFailure(ctx tcontext.TransferMetadata, f Failure, err error) {
+	FromContext(ctx).RecordFailure(f, err)
+}
+
+// RecordFailure is the package level RecordFailure on a specific collector
+func (c *Collector) RecordFailure(f Failure, err error) {
+	if c == nil {
+		return
+	}
+
+	if f.Reason == "" {
+		f.Reason = Reason(f.Stage, err)
+	}
+	if err != nil {
+		f.Error = err.Error()
+	}
+	var exhausted *retry.ExhaustedError
+	if errors.As(err, &exhausted) {
+		f.Attempts = exhausted.Attempts
+	}
+	f.Time = time.Now().UTC()
+	if c.output {
+		f.Destination = c.destination
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, f)
+}
+
+// Failures returns the failures recorded so far, including those of its destinations, in
+// the order they happened
+func (c *Collector) Failures() []Failure {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	failures := append([]Failure(nil), c.failures...)
+	c.mu.Unlock()
+
+	outputs := c.destinations()
+	if len(outputs) == 0 {
+		return failures
+	}
+	for _, output := range outputs {
+		failures = append(failures, output.Failures()...)
+	}
+	sort.SliceStable(failures, func(i, j int) bool { return failures[i].Time.Before(failures[j].Time) })
+	return failures
+}
+
+// LogFailures writes the failures grouped by reason to the log, with a few of the
+// affected files for each
+func (c *Collector) LogFailures(ctx tcontext.TransferMetadata) {
+	failures := c.Failures()
+	if len(failures) == 0 {
+		return
+	}
+
+	byReason := map[string][]Failure{}
+	for _, f := range failures {
+		byReason[f.Reason] = append(byReason[f.Reason], f)
+	}
+
+	reasons := make([]string, 0, len(byReason))
+	for reason := range byReason {
+		reasons = append(reasons, reason)
+	}
+	// most frequent first
+	sort.Slice(reasons, func(i, j int) bool {
+		if len(byReason[reasons[i]]) != len(byReason[reasons[j]]) {
+			return len(byReason[reasons[i]]) > len(byReason[reasons[j]])
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	const maxExamples = 3
+	logger.LogInfo(ctx.Context, "Transfer failures", "failed", len(failures), "reasons", len(reasons))
+	for _, reason := range reasons {
+		group := byReason[reason]
+		examples := make([]string, 0, maxExamples)
+		for _, f := range group {
+			if len(examples) == maxExamples {
+				break
+			}
+			if name := f.name(); name != "" {
+				examples = append(examples, name)
+			}
+		}
+		logger.LogInfo(ctx.Context, "Transfer failures by reason", "reason", reason, "count", len(group), "files", examples, "last_error", group[len(group)-1].Error)
+	}
+
+	// listed in full, as a later run may well transfer them
+	var exhausted []string
+	for _, f := range failures {
+		if f.Attempts > 0 {
+			exhausted = append(exhausted, f.name())
+		}
+	}
+	if len(exhausted) > 0 {
+		logger.LogInfo(ctx.Context, "Transfer failures after exhausting retries", "count", len(exhausted), "files", exhausted)
+	}
+}
+
+// name identifies the failed SBOM by file, falling back to its project
+func (f Failure) name() string {
+	if f.File != "" {
+		return f.File
+	}
+	return f.Project
+}
+
+// WriteFailures writes every recorded failure to path as a JSON array
+func (c *Collector) WriteFailures(path string) error {
+	failures := c.Failures()
+	if failures == nil {
+		failures = []Failure{}
+	}
+
+	data, err := json.MarshalIndent(failures, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode failures: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write errors file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// Statuses of the SBOMs in a transfer report
+const (
+	StatusTransferred = "transferred"
+	StatusFailed      = "failed"
+)
+
+// Entry is an SBOM of a transfer report, transferred or failed
+type Entry struct {
+	Status        string    `json:"status"`
+	File          string    `json:"file,omitempty"`
+	Source        string    `json:"source,omitempty"`
+	Destination   string    `json:"destination,omitempty"` // set when SBOMs went to several outputs
+	Target        string    `json:"target,omitempty"`      // file, object or project@version at the destination
+	Format        string    `json:"format,omitempty"`
+	Bytes         int64     `json:"bytes,omitempty"`
+	ConvertedFrom string    `json:"converted_from,omitempty"` // format before conversion, when converted
+	NTIAScore     *float64  `json:"ntia_score,omitempty"`     // NTIA minimum elements score, when scored
+	NTIAMissing   []string  `json:"ntia_missing,omitempty"`   // minimum elements the SBOM lacks, when scored
+	Stage         string    `json:"stage,omitempty"`
+	Attempts      int       `json:"attempts,omitempty"` // set when retries were exhausted
+	Reason        string    `json:"reason,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	Time          time.Time `json:"time"`
+}
+
+// File is the report of a transfer run written with --report-file
+type File struct {
+	RunID       string    `json:"run_id,omitempty"`
+	Source      string    `json:"source"`
+	Destination string    `json:"destination"`
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+	Transferred int       `json:"transferred"`
+	Failed      int       `json:"failed"`
+	Bytes       int64     `json:"bytes"`
+	SBOMs       []Entry   `json:"sboms"`
+}
+
+// Entries returns every SBOM transferred or failed so far, including those of its
+// destinations, in the order they happened
+func (c *Collector) Entries() []Entry {
+	if c == nil {
+		return nil
+	}
+
+	entries := c.transferEntries()
+	for _, f := range c.Failures() {
+		entries = append(entries, Entry{
+			Status:      StatusFailed,
+			File:        f.File,
+			Source:      f.Namespace,
+			Destination: f.Destination,
+			Target:      f.Project,
+			Stage:       f.Stage,
+			Attempts:    f.Attempts,
+			Reason:      f.Reason,
+			Error:       f.Error,
+			NTIAScore:   f.NTIAScore,
+			Time:        f.Time,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries
+}
+
+func (c *Collector) transferEntries() []Entry {
+	c.mu.Lock()
+	entries := append([]Entry(nil), c.transfers...)
+	c.mu.Unlock()
+
+	for _, output := range c.destinations() {
+		entries = append(entries, output.transferEntries()...)
+	}
+	return entries
+}
+
+// WriteReport writes the report of the run, listing every SBOM transferred or failed, to
+// path as JSON
+func (c *Collector) WriteReport(ctx tcontext.TransferMetadata, path string) error {
+	entries := c.Entries()
+	if entries == nil {
+		entries = []Entry{}
+	}
+
+	file := File{
+		Destination: c.destination,
+		StartedAt:   c.started,
+		FinishedAt:  time.Now().UTC(),
+		SBOMs:       entries,
+	}
+	file.RunID = ctx.RunID()
+	file.Source = ctx.SourceAdapter()
+	for _, e := range entries {
+		if e.Status == StatusTransferred {
+			file.Transferred++
+			file.Bytes += e.Bytes
+		} else {
+			file.Failed++
+		}
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode transfer report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write report file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package report
+
+import (
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// Rejection is a file that looked like an SBOM but wasn't accepted by --detection=strict
+type Rejection struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// RecordRejection records a file strict detection didn't accept as an SBOM
+func RecordRejection(ctx tcontext.TransferMetadata, name, reason string) {
+	FromContext(ctx).RecordRejection(name, reason)
+}
+
+// RecordRejection is the package level RecordRejection on a specific collector
+func (c *Collector) RecordRejection(name, reason string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rejections = append(c.rejections, Rejection{Name: name, Reason: reason})
+}
+
+// Rejections returns the files rejected so far, in the order they were seen
+func (c *Collector) Rejections() []Rejection {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Rejection(nil), c.rejections...)
+}
+
+// LogRejections writes the files rejected by strict detection to the log, one line each
+func (c *Collector) LogRejections(ctx tcontext.TransferMetadata) {
+	rejections := c.Rejections()
+	if len(rejections) == 0 {
+		return
+	}
+
+	logger.LogInfo(ctx.Context, "Files rejected by strict detection", "count", len(rejections))
+	for _, r := range rejections {
+		logger.LogInfo(ctx.Context, "Rejected file", "name", r.Name, "reason", r.Reason)
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+)
+
+// contextKey is the TransferMetadata key under which the engine stores the collector
+const contextKey = "transfer_report"
+
+// Volume is the amount of SBOM data moved to, and kept at, the destination
+type Volume struct {
+	SBOMs       int   `json:"sboms"`
+	Bytes       int64 `json:"bytes"`        // bytes transferred
+	StoredBytes int64 `json:"stored_bytes"` // estimated storage consumed at the destination
+}
+
+// Summary breaks the volume of a run down by SBOM format and source (namespace)
+type Summary struct {
+	Destination string            `json:"destination"`
+	Total       Volume            `json:"total"`
+	ByFormat    map[string]Volume `json:"by_format"`
+	BySource    map[string]Volume `json:"by_source"`
+	Failures    []Failure         `json:"failures,omitempty"`
+	Rejected    []Rejection       `json:"rejected,omitempty"`
+
+	// Destinations breaks the volume down by output when SBOMs went to several of them
+	Destinations []Summary `json:"destinations,omitempty"`
+}
+
+// stored is the last SBOM written under a destination key
+type stored struct {
+	size   int64
+	format string
+	source string
+}
+
+// Collector accumulates the volume and the failures of a transfer run. Uploaders record
+// every SBOM they actually transfer or fail to; skipped SBOMs are not recorded. It is safe
+// for concurrent use, and a nil *Collector ignores records.
+type Collector struct {
+	mu          sync.Mutex
+	destination string
+	total       Volume
+	byFormat    map[string]*Volume
+	bySource    map[string]*Volume
+	stored      map[string]stored
+	transfers   []Entry
+	failures    []Failure
+	rejections  []Rejection
+	started     time.Time
+
+	// outputs are the collectors of each destination of a fan-out transfer
+	outputs []*Collector
+	output  bool
+
+	// onTransfer is called for every SBOM recorded as transferred, see OnTransfer
+	onTransfer func(destination string, doc *iterator.SBOM)
+}
+
+// NewCollector returns an empty collector for the given destination adapter
+func NewCollector(destination string) *Collector {
+	return &Collector{
+		destination: destination,
+		byFormat:    map[string]*Volume{},
+		bySource:    map[string]*Volume{},
+		stored:      map[string]stored{},
+		started:     time.Now().UTC(),
+	}
+}
+
+// Destination returns a collector for one destination of a transfer sending SBOMs to several.
+// Its records count towards this collector's summary too, and its failures name the destination.
+func (c *Collector) Destination(name string) *Collector {
+	if c == nil {
+		return nil
+	}
+
+	child := NewCollector(name)
+	child.output = true
+	child.onTransfer = c.onTransfer
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.outputs = append(c.outputs, child)
+	return child
+}
+
+// OnTransfer registers fn to be called for every SBOM recorded as transferred, by this
+// collector and the destinations created after, e.g. to checkpoint the transfer
+func (c *Collector) OnTransfer(fn func(destination string, doc *iterator.SBOM)) {
+	if c != nil {
+		c.onTransfer = fn
+	}
+}
+
+// Attach stores the collector in the transfer context for uploaders to pick up
+func Attach(ctx *tcontext.TransferMetadata, c *Collector) {
+	if c != nil {
+		ctx.WithValue(contextKey, c)
+	}
+}
+
+// FromContext returns the collector of the transfer, or nil when none is attached
+func FromContext(ctx tcontext.TransferMetadata) *Collector {
+	c, _ := ctx.Value(contextKey).(*Collector)
+	return c
+}
+
+// RecordTransfer records an SBOM transferred to the destination under key, the file, object
+// or project the destination keeps it as. Transfers to the same key replace each other in the
+// storage estimate, as the destination overwrites them; an empty key is always counted as new.
+func RecordTransfer(ctx tcontext.TransferMetadata, doc *iterator.SBOM, key string) {
+	FromContext(ctx).Record(doc, key)
+}
+
+// Record is RecordTransfer on a specific collector
+func (c *Collector) Record(doc *iterator.SBOM, key string) {
+	if c == nil {
+		return
+	}
+
+	if c.onTransfer != nil {
+		c.onTransfer(c.destination, doc)
+	}
+
+	size := int64(len(doc.Data))
+	format := string(sbom.DetectFormat(doc.Data))
+	source := doc.Namespace
+	if source == "" {
+		source = "unknown"
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	transfer := Entry{
+		Status:        StatusTransferred,
+		File:          doc.Path,
+		Source:        source,
+		Target:        key,
+		Format:        format,
+		Bytes:         size,
+		ConvertedFrom: string(doc.ConvertedFrom),
+		Time:          time.Now().UTC(),
+	}
+	if doc.NTIA != nil {
+		transfer.NTIAScore = &doc.NTIA.Score
+		transfer.NTIAMissing = doc.NTIA.Missing()
+	}
+	if c.output {
+		transfer.Destination = c.destination
+	}
+	c.transfers = append(c.transfers, transfer)
+
+	formatVol := c.volume(c.byFormat, format)
+	sourceVol := c.volume(c.bySource, source)
+	for _, v := range []*Volume{&c.total, formatVol, sourceVol} {
+		v.SBOMs++
+		v.Bytes += size
+		v.StoredBytes += size
+	}
+
+	if key == "" {
+		return
+	}
+
+	// the destination replaced what was previously stored under this key
+	if prev, ok := c.stored[key]; ok {
+		c.total.StoredBytes -= prev.size
+		c.byFormat[prev.format].StoredBytes -= prev.size
+		c.bySource[prev.source].StoredBytes -= prev.size
+	}
+	c.stored[key] = stored{size: size, format: format, source: source}
+}
+
+func (c *Collector) volume(m map[string]*Volume, name string) *Volume {
+	v, ok := m[name]
+	if !ok {
+		v = &Volume{}
+		m[name] = v
+	}
+	return v
+}
+
+// Summary returns a snapshot of the recorded volume, including that of its destinations
+func (c *Collector) Summary() Summary {
+	if c == nil {
+		return Summary{}
+	}
+
+	s := c.ownSummary()
+	for _, output := range c.destinations() {
+		ds := output.Summary()
+		s.Total.add(ds.Total)
+		for name, v := range ds.ByFormat {
+			sum := s.ByFormat[name]
+			sum.add(v)
+			s.ByFormat[name] = sum
+		}
+		for name, v := range ds.BySource {
+			sum := s.BySource[name]
+			sum.add(v)
+			s.BySource[name] = sum
+		}
+		s.Destinations = append(s.Destinations, ds)
+	}
+	s.Failures = c.Failures()
+	return s
+}
+
+func (c *Collector) ownSummary() Summary {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := Summary{
+		Destination: c.destination,
+		Total:       c.total,
+		ByFormat:    make(map[string]Volume, len(c.byFormat)),
+		BySource:    make(map[string]Volume, len(c.bySource)),
+	}
+	for name, v := range c.byFormat {
+		s.ByFormat[name] = *v
+	}
+	for name, v := range c.bySource {
+		s.BySource[name] = *v
+	}
+	if len(c.rejections) > 0 {
+		s.Rejected = append([]Rejection(nil), c.rejections...)
+	}
+	return s
+}
+
+func (c *Collector) destinations() []*Collector {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*Collector(nil), c.outputs...)
+}
+
+func (v *Volume) add(o Volume) {
+	v.SBOMs += o.SBOMs
+	v.Bytes += o.Bytes
+	v.StoredBytes += o.StoredBytes
+}
+
+// Log writes the summary to the log, one line per format and source
+func (c *Collector) Log(ctx tcontext.TransferMetadata) {
+	s := c.Summary()
+	if s.Total.SBOMs == 0 {
+		return
+	}
+
+	logger.LogInfo(ctx.Context, "Transfer volume", "destination", s.Destination, "sboms", s.Total.SBOMs, "bytes", s.Total.Bytes, "transferred", utils.FormatByteSize(s.Total.Bytes), "estimated_storage", utils.FormatByteSize(s.Total.StoredBytes))
+
+	for _, name := range sortedKeys(s.ByFormat) {
+		v := s.ByFormat[name]
+		logger.LogInfo(ctx.Context, "Transfer volume by format", "format", name, "sboms", v.SBOMs, "bytes", v.Bytes, "stored_bytes", v.StoredBytes)
+	}
+	for _, name := range sortedKeys(s.BySource) {
+		v := s.BySource[name]
+		logger.LogInfo(ctx.Context, "Transfer volume by source", "source", name, "sboms", v.SBOMs, "bytes", v.Bytes, "stored_bytes", v.StoredBytes)
+	}
+	for _, d := range s.Destinations {
+		logger.LogInfo(ctx.Context, "Transfer volume by destination", "destination", d.Destination, "sboms", d.Total.SBOMs, "bytes", d.Total.Bytes, "stored_bytes", d.Total.StoredBytes, "failed", len(d.Failures))
+	}
+}
+
+func sortedKeys(m map[string]Volume) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
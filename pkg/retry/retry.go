@@ -0,0 +1,239 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// contextKey is the TransferMetadata key under which the engine stores the retry policy
+const contextKey = "retry_policy"
+
+const (
+	// MaxRetries caps the retries of an upload: with backoff capped at maxBackoff, more would
+	// keep an SBOM failing for hours
+	MaxRetries = 100
+
+	// maxBackoff caps the exponential backoff between two attempts
+	maxBackoff = time.Minute
+
+	// maxRetryAfter caps the wait a server may ask for with Retry-After
+	maxRetryAfter = 5 * time.Minute
+)
+
+// Policy is how uploads retry transient errors: network failures, timeouts, throttling
+// (429) and server errors (5xx). The zero Policy doesn't retry.
+type Policy struct {
+	Retries int           // retries after the first attempt
+	Backoff time.Duration // wait before the first retry, doubled for each next one
+}
+
+// Attach stores the policy in the transfer context for upload clients to pick up
+func Attach(ctx *tcontext.TransferMetadata, p Policy) {
+	ctx.WithValue(contextKey, p)
+}
+
+// FromContext returns the retry policy of the transfer, the zero Policy when none is attached
+func FromContext(ctx tcontext.TransferMetadata) Policy {
+	p, _ := ctx.Value(contextKey).(Policy)
+	return p
+}
+
+// HTTPError is an HTTP response with a failure status, along with the delay the server asked
+// for in its Retry-After header
+type HTTPError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	Header     http.Header // the response headers, if known
+	Err        error       // the client's own error for the response, if any
+}
+
+// NewHTTPError returns the error for a failed response
+func NewHTTPError(resp *http.Response) *HTTPError {
+	return &HTTPError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")), Header: resp.Header}
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("status: %d (retry after %s)", e.StatusCode, e.RetryAfter)
+	}
+	return fmt.Sprintf("status: %d", e.StatusCode)
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// ExhaustedError is returned for an operation that still failed on its last attempt
+type ExhaustedError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *ExhaustedError) Error() string {
+	return fmt.Sprintf("%v (gave up after %d attempts)", e.Err, e.Attempts)
+}
+
+func (e *ExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// Do runs fn, retrying it per the transfer's policy while it fails with a transient error.
+// When the retries run out the last error is returned as an *ExhaustedError.
+func Do(ctx tcontext.TransferMetadata, name string, fn func() error) error {
+	policy := FromContext(ctx)
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !Retryable(err) || ctx.Err() != nil {
+			return err
+		}
+		if attempt > policy.Retries {
+			if policy.Retries == 0 {
+				return err
+			}
+			return &ExhaustedError{Attempts: attempt, Err: err}
+		}
+
+		wait := policy.wait(attempt, err)
+		logger.LogDebug(ctx.Context, "Transient error, retrying", "target", name, "attempt", attempt, "retry_in", wait, "error", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// wait returns the delay before the given retry: the exponential backoff with jitter, or
+// longer when the server asked for it
+func (p Policy) wait(attempt int, err error) time.Duration {
+	var backoff time.Duration
+	switch {
+	case p.Backoff <= 0:
+	case attempt-1 >= bits.Len64(uint64(maxBackoff/p.Backoff)):
+		// doubling any further would go past the cap, and shifting far enough overflows
+		backoff = maxBackoff
+	default:
+		backoff = min(p.Backoff<<(attempt-1), maxBackoff)
+	}
+	if backoff > 0 {
+		// spread retries of concurrent uploads between half and the full backoff
+		backoff = backoff/2 + rand.N(backoff/2+1)
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > backoff {
+		return min(httpErr.RetryAfter, maxRetryAfter)
+	}
+	return backoff
+}
+
+// Retryable reports whether err is worth another attempt: a timeout, a connection refused,
+// reset or cut short, a DNS lookup failing temporarily, or an HTTP status saying the server
+// is overloaded or failed temporarily. Other network errors, e.g. TLS handshake failures
+// and malformed URLs, fail the same way on every attempt.
+func Retryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return retryableStatus(httpErr.StatusCode)
+	}
+	// AWS SDK response errors
+	var statusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &statusErr) {
+		return retryableStatus(statusErr.HTTPStatusCode())
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	return false
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter returns the delay of a Retry-After header, given in seconds or as a date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
+
+// Transport turns responses asking the client to retry later, 429 and 503 with a Retry-After
+// header, into an *HTTPError carrying the delay, for API clients that don't expose the
+// headers of failed responses
+type Transport struct {
+	Base http.RoundTripper
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return resp, err
+	}
+
+	httpErr := NewHTTPError(resp)
+	if httpErr.RetryAfter == 0 {
+		return resp, nil
+	}
+	resp.Body.Close()
+	return nil, httpErr
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requestError returns the error of a GET request to url
+func requestError(t *testing.T, url string) error {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err == nil {
+		resp.Body.Close()
+	}
+	require.Error(t, err)
+	return err
+}
+
+func TestRetryableNetworkErrors(t *testing.T) {
+	tlsServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	tlsServer.Config.ErrorLog = log.New(io.Discard, "", 0)
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+
+	// a port nothing listens on any more
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedURL := "http://" + listener.Addr().String()
+	listener.Close()
+
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"untrusted TLS certificate", requestError(t, tlsServer.URL), false},
+		{"unsupported URL scheme", requestError(t, "ftp://example.com/sbom.json"), false},
+		{"malformed URL", requestError(t, "http://[::1/sbom.json"), false},
+		{"connection refused", requestError(t, closedURL), true},
+		{"timeout", &net.OpError{Op: "dial", Err: &net.DNSError{IsTimeout: true}}, true},
+		{"temporary DNS failure", fmt.Errorf("lookup: %w", &net.DNSError{Err: "server misbehaving", IsTemporary: true}), true},
+		{"unknown host", fmt.Errorf("lookup: %w", &net.DNSError{Err: "no such host", IsNotFound: true}), false},
+		{"response cut short", fmt.Errorf("reading body: %w", io.ErrUnexpectedEOF), true},
+		{"cancelled", context.Canceled, false},
+		{"other error", errors.New("invalid SBOM"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.retryable, Retryable(tt.err), "error: %v", tt.err)
+		})
+	}
+}
+
+func TestRetryableStatus(t *testing.T) {
+	assert.True(t, Retryable(&HTTPError{StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, Retryable(&HTTPError{StatusCode: http.StatusTooManyRequests}))
+	assert.False(t, Retryable(&HTTPError{StatusCode: http.StatusUnauthorized}))
+	assert.False(t, Retryable(&HTTPError{StatusCode: http.StatusNotFound}))
+}
+
+func TestWaitCapsBackoff(t *testing.T) {
+	policy := Policy{Retries: MaxRetries, Backoff: time.Second}
+	for _, attempt := range []int{1, 6, 7, 35, 64, 65, MaxRetries} {
+		wait := policy.wait(attempt, errors.New("timeout"))
+		assert.Positive(t, wait, "attempt %d", attempt)
+		assert.LessOrEqual(t, wait, maxBackoff, "attempt %d", attempt)
+	}
+
+	// past the cap the backoff stays at it, jittered down to half
+	assert.GreaterOrEqual(t, policy.wait(MaxRetries, errors.New("timeout")), maxBackoff/2)
+
+	// a backoff above the cap is capped from the first retry
+	assert.LessOrEqual(t, Policy{Backoff: time.Hour}.wait(1, errors.New("timeout")), maxBackoff)
+	assert.Zero(t, Policy{}.wait(MaxRetries, errors.New("timeout")))
+}
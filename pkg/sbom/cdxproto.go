@@ -0,0 +1,490 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// CycloneDX protobuf encoding (bom-1.x.proto) decoding.
+// Only the commonly used parts of the schema are mapped: BOM identity, metadata,
+// components (recursively), hashes, licenses, external references, properties and
+// dependencies. Unknown fields are dropped.
+
+var cdxProtoSpecVersionRegex = regexp.MustCompile(`^1\.[0-9]+$`)
+
+// protoField is a single decoded field of a protobuf message
+type protoField struct {
+	num    protowire.Number
+	varint uint64
+	bytes  []byte
+	isLen  bool
+}
+
+func parseProtoMessage(b []byte) ([]protoField, error) {
+	var fields []protoField
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		f := protoField{num: num}
+		switch typ {
+		case protowire.VarintType:
+			f.varint, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(b)
+			f.isLen = true
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// IsCycloneDXProtobuf reports whether data is a CycloneDX BOM in protobuf encoding
+func IsCycloneDXProtobuf(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+
+	fields, err := parseProtoMessage(data)
+	if err != nil || len(fields) == 0 {
+		return false
+	}
+
+	// spec_version (field 1) is required and must look like "1.x"
+	for _, f := range fields {
+		if f.num == 1 && f.isLen {
+			return utf8.Valid(f.bytes) && cdxProtoSpecVersionRegex.Match(f.bytes)
+		}
+	}
+	return false
+}
+
+// CycloneDXProtobufSpecVersion returns the spec version of a CycloneDX protobuf BOM
+func CycloneDXProtobufSpecVersion(data []byte) (string, error) {
+	fields, err := parseProtoMessage(data)
+	if err != nil {
+		return "", fmt.Errorf("parsing CycloneDX protobuf: %w", err)
+	}
+	for _, f := range fields {
+		if f.num == 1 && f.isLen {
+			return string(f.bytes), nil
+		}
+	}
+	return "", fmt.Errorf("CycloneDX protobuf is missing spec version")
+}
+
+// CycloneDXProtobufToJSON converts a CycloneDX protobuf BOM into CycloneDX JSON
+func CycloneDXProtobufToJSON(data []byte) ([]byte, error) {
+	fields, err := parseProtoMessage(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CycloneDX protobuf: %w", err)
+	}
+
+	bom := map[string]any{
+		"bomFormat": "CycloneDX",
+		"version":   1,
+	}
+
+	var components, dependencies, externalRefs []any
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			bom["specVersion"] = string(f.bytes)
+		case 2:
+			bom["version"] = int32(f.varint)
+		case 3:
+			bom["serialNumber"] = string(f.bytes)
+		case 4:
+			metadata, err := decodeProtoMetadata(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			bom["metadata"] = metadata
+		case 5:
+			component, err := decodeProtoComponent(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			components = append(components, component)
+		case 7:
+			ref, err := decodeProtoExternalReference(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			externalRefs = append(externalRefs, ref)
+		case 8:
+			dependency, err := decodeProtoDependency(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	if _, ok := bom["specVersion"]; !ok {
+		return nil, fmt.Errorf("CycloneDX protobuf is missing spec version")
+	}
+	if components != nil {
+		bom["components"] = components
+	}
+	if dependencies != nil {
+		bom["dependencies"] = dependencies
+	}
+	if externalRefs != nil {
+		bom["externalReferences"] = externalRefs
+	}
+
+	out, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling CycloneDX JSON: %w", err)
+	}
+	return out, nil
+}
+
+func decodeProtoMetadata(b []byte) (map[string]any, error) {
+	fields, err := parseProtoMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+
+	metadata := map[string]any{}
+	var properties []any
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			ts, err := decodeProtoTimestamp(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			metadata["timestamp"] = ts
+		case 4:
+			component, err := decodeProtoComponent(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			metadata["component"] = component
+		case 6:
+			supplier, err := decodeProtoOrganizationalEntity(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			metadata["supplier"] = supplier
+		case 8:
+			property, err := decodeProtoProperty(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			properties = append(properties, property)
+		}
+	}
+	if properties != nil {
+		metadata["properties"] = properties
+	}
+	return metadata, nil
+}
+
+func decodeProtoComponent(b []byte) (map[string]any, error) {
+	fields, err := parseProtoMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing component: %w", err)
+	}
+
+	component := map[string]any{"type": "library"}
+	var hashes, licenses, externalRefs, properties, children []any
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			component["type"] = protoEnumName(cdxClassifications, f.varint, "library")
+		case 2:
+			component["mime-type"] = string(f.bytes)
+		case 3:
+			component["bom-ref"] = string(f.bytes)
+		case 4:
+			supplier, err := decodeProtoOrganizationalEntity(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			component["supplier"] = supplier
+		case 5:
+			component["author"] = string(f.bytes)
+		case 6:
+			component["publisher"] = string(f.bytes)
+		case 7:
+			component["group"] = string(f.bytes)
+		case 8:
+			component["name"] = string(f.bytes)
+		case 9:
+			component["version"] = string(f.bytes)
+		case 10:
+			component["description"] = string(f.bytes)
+		case 11:
+			if scope := protoEnumName(cdxScopes, f.varint, ""); scope != "" {
+				component["scope"] = scope
+			}
+		case 12:
+			hash, err := decodeProtoHash(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			hashes = append(hashes, hash)
+		case 13:
+			license, err := decodeProtoLicenseChoice(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			licenses = append(licenses, license)
+		case 14:
+			component["copyright"] = string(f.bytes)
+		case 15:
+			component["cpe"] = string(f.bytes)
+		case 16:
+			component["purl"] = string(f.bytes)
+		case 20:
+			ref, err := decodeProtoExternalReference(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			externalRefs = append(externalRefs, ref)
+		case 21:
+			property, err := decodeProtoProperty(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			properties = append(properties, property)
+		case 22:
+			child, err := decodeProtoComponent(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+	}
+
+	if hashes != nil {
+		component["hashes"] = hashes
+	}
+	if licenses != nil {
+		component["licenses"] = licenses
+	}
+	if externalRefs != nil {
+		component["externalReferences"] = externalRefs
+	}
+	if properties != nil {
+		component["properties"] = properties
+	}
+	if children != nil {
+		component["components"] = children
+	}
+	return component, nil
+}
+
+func decodeProtoOrganizationalEntity(b []byte) (map[string]any, error) {
+	fields, err := parseProtoMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing organizational entity: %w", err)
+	}
+
+	entity := map[string]any{}
+	var urls []any
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			entity["name"] = string(f.bytes)
+		case 2:
+			urls = append(urls, string(f.bytes))
+		}
+	}
+	if urls != nil {
+		entity["url"] = urls
+	}
+	return entity, nil
+}
+
+func decodeProtoHash(b []byte) (map[string]any, error) {
+	fields, err := parseProtoMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing hash: %w", err)
+	}
+
+	hash := map[string]any{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			hash["alg"] = protoEnumName(cdxHashAlgorithms, f.varint, "")
+		case 2:
+			hash["content"] = string(f.bytes)
+		}
+	}
+	return hash, nil
+}
+
+func decodeProtoLicenseChoice(b []byte) (map[string]any, error) {
+	fields, err := parseProtoMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing license choice: %w", err)
+	}
+
+	choice := map[string]any{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			licenseFields, err := parseProtoMessage(f.bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parsing license: %w", err)
+			}
+			license := map[string]any{}
+			for _, lf := range licenseFields {
+				switch lf.num {
+				case 1:
+					license["id"] = string(lf.bytes)
+				case 2:
+					license["name"] = string(lf.bytes)
+				case 4:
+					license["url"] = string(lf.bytes)
+				}
+			}
+			choice["license"] = license
+		case 2:
+			choice["expression"] = string(f.bytes)
+		}
+	}
+	return choice, nil
+}
+
+func decodeProtoExternalReference(b []byte) (map[string]any, error) {
+	fields, err := parseProtoMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing external reference: %w", err)
+	}
+
+	ref := map[string]any{"type": "other"}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			ref["type"] = protoEnumName(cdxExternalReferenceTypes, f.varint, "other")
+		case 2:
+			ref["url"] = string(f.bytes)
+		case 3:
+			ref["comment"] = string(f.bytes)
+		}
+	}
+	return ref, nil
+}
+
+func decodeProtoProperty(b []byte) (map[string]any, error) {
+	fields, err := parseProtoMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing property: %w", err)
+	}
+
+	property := map[string]any{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			property["name"] = string(f.bytes)
+		case 2:
+			property["value"] = string(f.bytes)
+		}
+	}
+	return property, nil
+}
+
+func decodeProtoDependency(b []byte) (map[string]any, error) {
+	fields, err := parseProtoMessage(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing dependency: %w", err)
+	}
+
+	dependency := map[string]any{}
+	var dependsOn []any
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			dependency["ref"] = string(f.bytes)
+		case 2:
+			// nested dependencies only carry the ref in JSON's dependsOn
+			child, err := decodeProtoDependency(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			if ref, ok := child["ref"]; ok {
+				dependsOn = append(dependsOn, ref)
+			}
+		}
+	}
+	if dependsOn != nil {
+		dependency["dependsOn"] = dependsOn
+	}
+	return dependency, nil
+}
+
+func decodeProtoTimestamp(b []byte) (string, error) {
+	fields, err := parseProtoMessage(b)
+	if err != nil {
+		return "", fmt.Errorf("parsing timestamp: %w", err)
+	}
+
+	var seconds, nanos int64
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			seconds = int64(f.varint)
+		case 2:
+			nanos = int64(int32(f.varint))
+		}
+	}
+	return time.Unix(seconds, nanos).UTC().Format(time.RFC3339), nil
+}
+
+func protoEnumName(names []string, value uint64, fallback string) string {
+	if value < uint64(len(names)) && names[value] != "" {
+		return names[value]
+	}
+	return fallback
+}
+
+// enum values as numbered in bom-1.x.proto, index 0 being the proto "NULL" value
+var (
+	cdxClassifications = []string{
+		"", "application", "framework", "library", "operating-system", "device", "file",
+		"container", "firmware", "device-driver", "platform", "machine-learning-model", "data",
+	}
+	cdxScopes         = []string{"", "required", "optional", "excluded"}
+	cdxHashAlgorithms = []string{
+		"", "MD5", "SHA-1", "SHA-256", "SHA-384", "SHA-512", "SHA3-256", "SHA3-384", "SHA3-512",
+		"BLAKE2b-256", "BLAKE2b-384", "BLAKE2b-512", "BLAKE3",
+	}
+	cdxExternalReferenceTypes = []string{
+		"other", "vcs", "issue-tracker", "website", "advisories", "bom", "mailing-list", "social",
+		"chat", "documentation", "support", "distribution", "license", "build-meta", "build-system",
+		"release-notes",
+	}
+)
@@ -15,8 +15,13 @@
 package sbom
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"strings"
 )
 
 type FormatSpec string
@@ -40,6 +45,12 @@ const (
 )
 
 func DetectSBOMSpecAndVersion(data []byte) (FormatSpec, string, error) {
+	// CycloneDX protobuf and SPDX YAML are inspected through their JSON form
+	data, _, err := ToJSON(data)
+	if err != nil {
+		return "", "", err
+	}
+
 	var raw map[string]interface{}
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return "", "", fmt.Errorf("unmarshaling SBOM: %w", err)
@@ -55,3 +66,129 @@ func DetectSBOMSpecAndVersion(data []byte) (FormatSpec, string, error) {
 
 	return FormatSpecUnknown, "", nil
 }
+
+// knownSpecVersions are the spec versions StrictDetect accepts
+var knownSpecVersions = map[FormatSpec]map[string]bool{
+	FormatSpecCycloneDX: {"1.0": true, "1.1": true, "1.2": true, "1.3": true, "1.4": true, "1.5": true, "1.6": true, "1.7": true},
+	FormatSpecSPDX:      {"SPDX-2.0": true, "SPDX-2.1": true, "SPDX-2.2": true, "SPDX-2.3": true},
+}
+
+// cycloneDXNamespace is the XML namespace of CycloneDX documents, followed by the spec version
+const cycloneDXNamespace = "http://cyclonedx.org/schema/bom/"
+
+// StrictDetect identifies the spec and version of an SBOM from the fields the specs require,
+// rather than from its overall shape: bomFormat and specVersion for CycloneDX JSON, the bom
+// element namespace for CycloneDX XML, spdxVersion and the SPDXRef-DOCUMENT identifier for SPDX.
+// The version must be a released one. It returns why the document wasn't identified otherwise.
+func StrictDetect(data []byte) (FormatSpec, string, error) {
+	var (
+		spec    FormatSpec
+		version string
+		err     error
+	)
+
+	switch DetectFormat(data) {
+	case FormatCycloneDXJSON:
+		spec = FormatSpecCycloneDX
+		var doc struct {
+			BOMFormat   string `json:"bomFormat"`
+			SpecVersion string `json:"specVersion"`
+		}
+		if err = json.Unmarshal(data, &doc); err != nil {
+			return FormatSpecUnknown, "", fmt.Errorf("invalid JSON: %w", err)
+		}
+		if doc.BOMFormat != "CycloneDX" {
+			return FormatSpecUnknown, "", fmt.Errorf("bomFormat is %q, not \"CycloneDX\"", doc.BOMFormat)
+		}
+		version = doc.SpecVersion
+
+	case FormatCycloneDXXML:
+		spec = FormatSpecCycloneDX
+		if version, err = cycloneDXXMLVersion(data); err != nil {
+			return FormatSpecUnknown, "", err
+		}
+
+	case FormatCycloneDXProto:
+		spec = FormatSpecCycloneDX
+		if version, err = CycloneDXProtobufSpecVersion(data); err != nil {
+			return FormatSpecUnknown, "", err
+		}
+
+	case FormatSPDXJSON, FormatSPDXYAML:
+		spec = FormatSpecSPDX
+		if data, _, err = ToJSON(data); err != nil {
+			return FormatSpecUnknown, "", err
+		}
+		var doc struct {
+			SPDXID      string `json:"SPDXID"`
+			SPDXVersion string `json:"spdxVersion"`
+		}
+		if err = json.Unmarshal(data, &doc); err != nil {
+			return FormatSpecUnknown, "", fmt.Errorf("invalid JSON: %w", err)
+		}
+		if doc.SPDXID != "SPDXRef-DOCUMENT" {
+			return FormatSpecUnknown, "", fmt.Errorf("SPDXID is %q, not \"SPDXRef-DOCUMENT\"", doc.SPDXID)
+		}
+		version = doc.SPDXVersion
+
+	case FormatSPDXTag:
+		spec = FormatSpecSPDX
+		if version, err = spdxTagVersion(data); err != nil {
+			return FormatSpecUnknown, "", err
+		}
+
+	default:
+		return FormatSpecUnknown, "", errors.New("not a CycloneDX or SPDX document")
+	}
+
+	if !knownSpecVersions[spec][version] {
+		return FormatSpecUnknown, "", fmt.Errorf("unknown %s version %q", spec, version)
+	}
+	return spec, version, nil
+}
+
+// cycloneDXXMLVersion returns the spec version of the namespace of the root bom element
+func cycloneDXXMLVersion(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("invalid XML: %w", err)
+		}
+		root, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root.Name.Local != "bom" || !strings.HasPrefix(root.Name.Space, cycloneDXNamespace) {
+			return "", fmt.Errorf("root element is {%s}%s, not a CycloneDX bom", root.Name.Space, root.Name.Local)
+		}
+		return strings.TrimPrefix(root.Name.Space, cycloneDXNamespace), nil
+	}
+}
+
+// spdxTagVersion returns the SPDXVersion of a tag-value document whose SPDXID is SPDXRef-DOCUMENT
+func spdxTagVersion(data []byte) (string, error) {
+	var version, id string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() && (version == "" || id == "") {
+		tag, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(tag) {
+		case "SPDXVersion":
+			if version == "" {
+				version = strings.TrimSpace(value)
+			}
+		case "SPDXID":
+			if id == "" {
+				id = strings.TrimSpace(value)
+			}
+		}
+	}
+
+	if id != "SPDXRef-DOCUMENT" {
+		return "", fmt.Errorf("SPDXID is %q, not \"SPDXRef-DOCUMENT\"", id)
+	}
+	return version, nil
+}
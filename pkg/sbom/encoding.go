@@ -0,0 +1,118 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/interlynk-io/sbomasm/v2/pkg/sbom"
+	"sigs.k8s.io/yaml"
+)
+
+// DetectFormat returns the spec and encoding of the SBOM, covering the encodings
+// understood by sbomasm plus CycloneDX protobuf.
+func DetectFormat(data []byte) SBOMFormat {
+	spec, fileFormat, err := sbom.Detect(bytes.NewReader(data))
+	if err == nil {
+		switch {
+		case spec == sbom.SBOMSpecSPDX && fileFormat == sbom.FileFormatJSON:
+			return FormatSPDXJSON
+		case spec == sbom.SBOMSpecSPDX && fileFormat == sbom.FileFormatTagValue:
+			return FormatSPDXTag
+		case spec == sbom.SBOMSpecSPDX && fileFormat == sbom.FileFormatYAML:
+			return FormatSPDXYAML
+		case spec == sbom.SBOMSpecCDX && fileFormat == sbom.FileFormatJSON:
+			return FormatCycloneDXJSON
+		case spec == sbom.SBOMSpecCDX && fileFormat == sbom.FileFormatXML:
+			return FormatCycloneDXXML
+		}
+	}
+
+	if IsCycloneDXProtobuf(data) {
+		return FormatCycloneDXProto
+	}
+
+	return FormatUnknown
+}
+
+// ToJSON returns the SBOM re-encoded as JSON when it is CycloneDX protobuf or SPDX YAML,
+// reporting whether any conversion happened. Other encodings are returned unchanged.
+func ToJSON(data []byte) ([]byte, bool, error) {
+	switch DetectFormat(data) {
+	case FormatCycloneDXProto:
+		converted, err := CycloneDXProtobufToJSON(data)
+		if err != nil {
+			return nil, false, fmt.Errorf("converting CycloneDX protobuf to JSON: %w", err)
+		}
+		return converted, true, nil
+
+	case FormatSPDXYAML:
+		converted, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, false, fmt.Errorf("converting SPDX YAML to JSON: %w", err)
+		}
+		return converted, true, nil
+	}
+
+	return data, false, nil
+}
+
+// cycloneDXSpecVersions maps the CycloneDX versions encodable as JSON to cyclonedx-go's
+var cycloneDXSpecVersions = map[string]cdx.SpecVersion{
+	"1.2": cdx.SpecVersion1_2,
+	"1.3": cdx.SpecVersion1_3,
+	"1.4": cdx.SpecVersion1_4,
+	"1.5": cdx.SpecVersion1_5,
+	"1.6": cdx.SpecVersion1_6,
+	"1.7": cdx.SpecVersion1_7,
+}
+
+// CycloneDXXMLToJSON decodes a CycloneDX XML document and re-encodes it as JSON of the
+// same spec version, taken from the document's XML namespace
+func CycloneDXXMLToJSON(data []byte) ([]byte, error) {
+	_, converted, err := cycloneDXXMLToJSON(data)
+	return converted, err
+}
+
+// cycloneDXXMLToJSON is CycloneDXXMLToJSON, also returning the spec version. Versions that
+// can't be encoded as JSON return the version with no data.
+func cycloneDXXMLToJSON(data []byte) (string, []byte, error) {
+	var bom cdx.BOM
+	if err := cdx.NewBOMDecoder(bytes.NewReader(data), cdx.BOMFileFormatXML).Decode(&bom); err != nil {
+		return "", nil, fmt.Errorf("invalid CycloneDX XML: %w", err)
+	}
+
+	if !strings.HasPrefix(bom.XMLNS, cycloneDXNamespace) {
+		return "", nil, fmt.Errorf("invalid CycloneDX XML: unexpected namespace %q", bom.XMLNS)
+	}
+	version := strings.TrimPrefix(bom.XMLNS, cycloneDXNamespace)
+
+	specVersion, ok := cycloneDXSpecVersions[version]
+	if !ok {
+		return version, nil, nil
+	}
+
+	// bomFormat is JSON only
+	bom.BOMFormat = cdx.BOMFormat
+
+	var buf bytes.Buffer
+	if err := cdx.NewBOMEncoder(&buf, cdx.BOMFileFormatJSON).EncodeVersion(&bom, specVersion); err != nil {
+		return version, nil, fmt.Errorf("re-encoding CycloneDX XML as JSON: %w", err)
+	}
+	return version, buf.Bytes(), nil
+}
@@ -0,0 +1,312 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Enrichment is the metadata --enrich-* adds to SBOMs lacking it, such as the supplier,
+// author and timestamp NTIA's minimum elements ask for
+type Enrichment struct {
+	Supplier           string   // supplier of the SBOM and the component it describes
+	Authors            []string // authors of the SBOM
+	Tool               string   // tool recorded among those that produced the SBOM, empty records none
+	ToolVersion        string
+	NormalizeTimestamp bool // rewrite the creation time as UTC RFC 3339, setting it when missing
+	Override           bool // replace the supplier and authors SBOMs have, not only fill in missing ones
+}
+
+// timestampLayouts are the creation times normalization understands
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02",
+}
+
+// Enrich applies the enrichment to a CycloneDX or SPDX JSON SBOM and returns it along with
+// the fields it set, e.g. "metadata.supplier". Other encodings are returned unchanged.
+func Enrich(data []byte, e Enrichment, now time.Time) ([]byte, []string, error) {
+	format := DetectFormat(data)
+	if format != FormatCycloneDXJSON && format != FormatSPDXJSON {
+		return data, nil, nil
+	}
+
+	var doc map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("unmarshaling %s SBOM: %w", format, err)
+	}
+
+	var changed []string
+	if format == FormatCycloneDXJSON {
+		changed = enrichCycloneDX(doc, e, now)
+	} else {
+		changed = enrichSPDX(doc, e, now)
+	}
+	if len(changed) == 0 {
+		return data, nil, nil
+	}
+
+	enriched, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshaling %s SBOM: %w", format, err)
+	}
+	return enriched, changed, nil
+}
+
+func enrichCycloneDX(doc map[string]any, e Enrichment, now time.Time) []string {
+	var changed []string
+	metadata := object(doc, "metadata")
+
+	if e.Supplier != "" {
+		if setSupplier(metadata, e.Supplier, e.Override) {
+			changed = append(changed, "metadata.supplier")
+		}
+		if component, ok := metadata["component"].(map[string]any); ok && setSupplier(component, e.Supplier, e.Override) {
+			changed = append(changed, "metadata.component.supplier")
+		}
+	}
+
+	if authors, _ := metadata["authors"].([]any); len(e.Authors) > 0 && (len(authors) == 0 || e.Override) {
+		var entries []any
+		for _, author := range e.Authors {
+			entries = append(entries, map[string]any{"name": author})
+		}
+		metadata["authors"] = entries
+		changed = append(changed, "metadata.authors")
+	}
+
+	if e.Tool != "" && addCycloneDXTool(doc, metadata, e.Tool, e.ToolVersion) {
+		changed = append(changed, "metadata.tools")
+	}
+
+	if e.NormalizeTimestamp && normalizeTimestamp(metadata, "timestamp", now) {
+		changed = append(changed, "metadata.timestamp")
+	}
+	return changed
+}
+
+// setSupplier sets the supplier name of a CycloneDX metadata or component object
+func setSupplier(obj map[string]any, name string, override bool) bool {
+	supplier, _ := obj["supplier"].(map[string]any)
+	if current, _ := supplier["name"].(string); current == name || (current != "" && !override) {
+		return false
+	}
+	obj["supplier"] = map[string]any{"name": name}
+	return true
+}
+
+// addCycloneDXTool records the tool in metadata.tools, in the legacy list of CycloneDX 1.4
+// and earlier or the components of the tools object of 1.5 and later
+func addCycloneDXTool(doc, metadata map[string]any, name, version string) bool {
+	tool := map[string]any{"name": name}
+	if version != "" {
+		tool["version"] = version
+	}
+
+	hasTool := func(tools []any) bool {
+		return slices.ContainsFunc(tools, func(t any) bool {
+			entry, _ := t.(map[string]any)
+			return entry["name"] == name
+		})
+	}
+
+	switch tools := metadata["tools"].(type) {
+	case []any:
+		if hasTool(tools) {
+			return false
+		}
+		metadata["tools"] = append(tools, tool)
+	case map[string]any:
+		components, _ := tools["components"].([]any)
+		if hasTool(components) {
+			return false
+		}
+		tool["type"] = "application"
+		tools["components"] = append(components, tool)
+	default:
+		if specVersion, _ := doc["specVersion"].(string); specVersion == "1.5" || specVersion == "1.6" {
+			tool["type"] = "application"
+			metadata["tools"] = map[string]any{"components": []any{tool}}
+		} else {
+			metadata["tools"] = []any{tool}
+		}
+	}
+	return true
+}
+
+func enrichSPDX(doc map[string]any, e Enrichment, now time.Time) []string {
+	var changed []string
+	creationInfo := object(doc, "creationInfo")
+
+	if e.Supplier != "" {
+		supplier := spdxParty(e.Supplier, "Organization")
+		for _, pkg := range describedPackages(doc) {
+			current, _ := pkg["supplier"].(string)
+			if current == supplier || (current != "" && current != "NOASSERTION" && !e.Override) {
+				continue
+			}
+			pkg["supplier"] = supplier
+			id, _ := pkg["SPDXID"].(string)
+			changed = append(changed, fmt.Sprintf("packages[%s].supplier", id))
+		}
+	}
+
+	creators, _ := creationInfo["creators"].([]any)
+	if len(e.Authors) > 0 {
+		hasAuthor := slices.ContainsFunc(creators, func(c any) bool {
+			s, _ := c.(string)
+			return strings.HasPrefix(s, "Person:") || strings.HasPrefix(s, "Organization:")
+		})
+		if !hasAuthor || e.Override {
+			// tools stay, the authors replace the people and organizations
+			kept := slices.DeleteFunc(slices.Clone(creators), func(c any) bool {
+				s, _ := c.(string)
+				return !strings.HasPrefix(s, "Tool:")
+			})
+			for _, author := range e.Authors {
+				kept = append(kept, spdxParty(author, "Person"))
+			}
+			creators = kept
+			changed = append(changed, "creationInfo.creators")
+		}
+	}
+
+	if e.Tool != "" {
+		tool := "Tool: " + e.Tool
+		if e.ToolVersion != "" {
+			tool += "-" + e.ToolVersion
+		}
+		if !slices.ContainsFunc(creators, func(c any) bool {
+			s, _ := c.(string)
+			return isSPDXToolCreator(s, e.Tool)
+		}) {
+			creators = append(creators, tool)
+			changed = append(changed, "creationInfo.creators")
+		}
+	}
+	if len(creators) > 0 {
+		creationInfo["creators"] = creators
+	}
+
+	if e.NormalizeTimestamp && normalizeTimestamp(creationInfo, "created", now) {
+		changed = append(changed, "creationInfo.created")
+	}
+	return slices.Compact(changed)
+}
+
+// spdxParty returns the name as an SPDX creator or supplier, e.g. "Organization: Acme",
+// isSPDXToolCreator reports whether the creator is "Tool: <name>" or "Tool: <name>-<version>",
+// the version starting with a digit, optionally after a "v". "Tool: sbommv-demo" isn't sbommv.
+func isSPDXToolCreator(creator, name string) bool {
+	rest, ok := strings.CutPrefix(creator, "Tool: "+name)
+	if !ok {
+		return false
+	}
+	if rest == "" {
+		return true
+	}
+	version, ok := strings.CutPrefix(rest, "-")
+	if !ok {
+		return false
+	}
+	version = strings.TrimPrefix(version, "v")
+	return version != "" && version[0] >= '0' && version[0] <= '9'
+}
+
+// unless it already is one
+func spdxParty(name, kind string) string {
+	for _, prefix := range []string{"Person:", "Organization:", "Tool:", "NOASSERTION"} {
+		if strings.HasPrefix(name, prefix) {
+			return name
+		}
+	}
+	return kind + ": " + name
+}
+
+// describedPackages returns the packages the SPDX document describes, per documentDescribes
+// or its DESCRIBES relationships
+func describedPackages(doc map[string]any) []map[string]any {
+	described := map[string]bool{}
+	if ids, ok := doc["documentDescribes"].([]any); ok {
+		for _, id := range ids {
+			if s, ok := id.(string); ok {
+				described[s] = true
+			}
+		}
+	}
+	if relationships, ok := doc["relationships"].([]any); ok {
+		for _, r := range relationships {
+			rel, _ := r.(map[string]any)
+			if rel["spdxElementId"] == "SPDXRef-DOCUMENT" && rel["relationshipType"] == "DESCRIBES" {
+				if s, ok := rel["relatedSpdxElement"].(string); ok {
+					described[s] = true
+				}
+			}
+		}
+	}
+
+	var packages []map[string]any
+	if list, ok := doc["packages"].([]any); ok {
+		for _, p := range list {
+			pkg, _ := p.(map[string]any)
+			if id, _ := pkg["SPDXID"].(string); described[id] {
+				packages = append(packages, pkg)
+			}
+		}
+	}
+	return packages
+}
+
+// normalizeTimestamp rewrites the time of the key as UTC RFC 3339, or sets it to now when
+// missing. Times it can't parse are left alone.
+func normalizeTimestamp(obj map[string]any, key string, now time.Time) bool {
+	current, _ := obj[key].(string)
+	if current == "" {
+		obj[key] = now.UTC().Format(time.RFC3339)
+		return true
+	}
+
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, current); err == nil {
+			normalized := t.UTC().Format(time.RFC3339)
+			if normalized == current {
+				return false
+			}
+			obj[key] = normalized
+			return true
+		}
+	}
+	return false
+}
+
+// object returns the JSON object under the key, adding an empty one when missing
+func object(doc map[string]any, key string) map[string]any {
+	obj, ok := doc[key].(map[string]any)
+	if !ok {
+		obj = map[string]any{}
+		doc[key] = obj
+	}
+	return obj
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichSPDXTool(t *testing.T) {
+	tests := []struct {
+		name     string
+		creators []string
+		want     []string
+	}{
+		{
+			name:     "no tool",
+			creators: []string{"Organization: Interlynk"},
+			want:     []string{"Organization: Interlynk", "Tool: sbommv-1.2.0"},
+		},
+		{
+			name:     "lookalike tool name",
+			creators: []string{"Tool: sbommv-demo"},
+			want:     []string{"Tool: sbommv-demo", "Tool: sbommv-1.2.0"},
+		},
+		{
+			name:     "longer tool name",
+			creators: []string{"Tool: sbommvx-1.0.0"},
+			want:     []string{"Tool: sbommvx-1.0.0", "Tool: sbommv-1.2.0"},
+		},
+		{
+			name:     "other version",
+			creators: []string{"Tool: sbommv-1.1.0"},
+			want:     []string{"Tool: sbommv-1.1.0"},
+		},
+		{
+			name:     "v-prefixed version",
+			creators: []string{"Tool: sbommv-v1.1.0"},
+			want:     []string{"Tool: sbommv-v1.1.0"},
+		},
+		{
+			name:     "without version",
+			creators: []string{"Tool: sbommv"},
+			want:     []string{"Tool: sbommv"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := json.Marshal(map[string]any{
+				"spdxVersion":  "SPDX-2.3",
+				"SPDXID":       "SPDXRef-DOCUMENT",
+				"creationInfo": map[string]any{"created": "2025-01-01T00:00:00Z", "creators": tt.creators},
+			})
+			require.NoError(t, err)
+
+			enriched, _, err := Enrich(doc, Enrichment{Tool: "sbommv", ToolVersion: "1.2.0"}, time.Now())
+			require.NoError(t, err)
+
+			var got struct {
+				CreationInfo struct {
+					Creators []string `json:"creators"`
+				} `json:"creationInfo"`
+			}
+			require.NoError(t, json.Unmarshal(enriched, &got))
+			assert.Equal(t, tt.want, got.CreationInfo.Creators)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultExtensions are the file extensions of each format, as downstream scanners expect them
+var defaultExtensions = map[SBOMFormat]string{
+	FormatCycloneDXJSON:  ".cdx.json",
+	FormatCycloneDXXML:   ".cdx.xml",
+	FormatCycloneDXProto: ".cdx.pb",
+	FormatSPDXJSON:       ".spdx.json",
+	FormatSPDXYAML:       ".spdx.yaml",
+	FormatSPDXTag:        ".spdx",
+}
+
+// contentTypes are the media types of each format
+var contentTypes = map[SBOMFormat]string{
+	FormatCycloneDXJSON:  "application/vnd.cyclonedx+json",
+	FormatCycloneDXXML:   "application/vnd.cyclonedx+xml",
+	FormatCycloneDXProto: "application/x.vnd.cyclonedx+protobuf",
+	FormatSPDXJSON:       "application/spdx+json",
+	FormatSPDXYAML:       "application/yaml",
+	FormatSPDXTag:        "text/spdx",
+}
+
+// ExtensionMap names SBOM files after their detected format rather than their source name,
+// e.g. "sbom.json" holding CycloneDX JSON becomes "sbom.cdx.json".
+type ExtensionMap struct {
+	extensions map[SBOMFormat]string
+}
+
+// ParseExtensionMap returns the default extensions with the overrides applied. Overrides
+// are "format=extension" pairs, e.g. "cyclonedx-json=.json" or "spdx=.spdx.txt".
+func ParseExtensionMap(overrides []string) (*ExtensionMap, error) {
+	m := &ExtensionMap{extensions: make(map[SBOMFormat]string, len(defaultExtensions))}
+	for format, ext := range defaultExtensions {
+		m.extensions[format] = ext
+	}
+
+	for _, override := range overrides {
+		name, ext, ok := strings.Cut(strings.TrimSpace(override), "=")
+		ext = strings.TrimSpace(ext)
+		if !ok || ext == "" {
+			return nil, fmt.Errorf("invalid extension mapping %q (must be format=extension, e.g. cyclonedx-json=.json)", override)
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+
+		formats, err := parseFormatList([]string{name})
+		if err != nil {
+			return nil, err
+		}
+		for format := range formats {
+			m.extensions[format] = ext
+		}
+	}
+	return m, nil
+}
+
+// Rename returns the name of a file holding data, with its SBOM extensions replaced by the
+// extension of the detected format. Directories in the name are kept, and names of content
+// in an unknown format are returned unchanged.
+func (m *ExtensionMap) Rename(name string, data []byte) string {
+	ext, ok := m.extensions[DetectFormat(data)]
+	if !ok {
+		return name
+	}
+	return sbomStem(name) + ext
+}
+
+// ContentType returns the media type of the SBOM content, "application/octet-stream" when
+// its format is unknown
+func ContentType(data []byte) string {
+	if contentType, ok := contentTypes[DetectFormat(data)]; ok {
+		return contentType
+	}
+	return "application/octet-stream"
+}
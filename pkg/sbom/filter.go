@@ -0,0 +1,302 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// formatAliases maps the values accepted by --include-formats/--exclude-formats to formats.
+// A bare spec name selects all of its encodings.
+var formatAliases = map[string][]SBOMFormat{
+	"cyclonedx-json":     {FormatCycloneDXJSON},
+	"cdx-json":           {FormatCycloneDXJSON},
+	"cyclonedx-xml":      {FormatCycloneDXXML},
+	"cdx-xml":            {FormatCycloneDXXML},
+	"cyclonedx-protobuf": {FormatCycloneDXProto},
+	"cdx-protobuf":       {FormatCycloneDXProto},
+	"spdx-json":          {FormatSPDXJSON},
+	"spdx-yaml":          {FormatSPDXYAML},
+	"spdx-tag":           {FormatSPDXTag},
+	"spdx-tv":            {FormatSPDXTag},
+	"cyclonedx":          {FormatCycloneDXJSON, FormatCycloneDXXML, FormatCycloneDXProto},
+	"cdx":                {FormatCycloneDXJSON, FormatCycloneDXXML, FormatCycloneDXProto},
+	"spdx":               {FormatSPDXJSON, FormatSPDXYAML, FormatSPDXTag},
+}
+
+// FormatFilter selects SBOMs by format. A nil *FormatFilter allows everything.
+type FormatFilter struct {
+	include map[SBOMFormat]bool
+	exclude map[SBOMFormat]bool
+}
+
+// ParseFormatFilter builds a filter from include and exclude format lists, e.g. "cyclonedx-json"
+// or "spdx". It returns nil when both lists are empty.
+func ParseFormatFilter(include, exclude []string) (*FormatFilter, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+
+	inc, err := parseFormatList(include)
+	if err != nil {
+		return nil, err
+	}
+	exc, err := parseFormatList(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FormatFilter{include: inc, exclude: exc}, nil
+}
+
+func parseFormatList(values []string) (map[SBOMFormat]bool, error) {
+	formats := map[SBOMFormat]bool{}
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+
+		aliased, ok := formatAliases[value]
+		if !ok {
+			return nil, fmt.Errorf("unsupported format %q (must be one of: cyclonedx-json, cyclonedx-xml, cyclonedx-protobuf, spdx-json, spdx-yaml, spdx-tag, cyclonedx, spdx)", value)
+		}
+		for _, f := range aliased {
+			formats[f] = true
+		}
+	}
+	return formats, nil
+}
+
+// Allows reports whether SBOMs of the given format pass the filter.
+// FormatUnknown is allowed only when no include list is set.
+func (f *FormatFilter) Allows(format SBOMFormat) bool {
+	if f == nil {
+		return true
+	}
+	if f.exclude[format] {
+		return false
+	}
+	if len(f.include) > 0 {
+		return f.include[format]
+	}
+	return true
+}
+
+// AllowsName reports whether a file or asset of this name may pass the filter, so sources
+// can skip downloading it. Names that don't reveal the format are allowed; their content
+// is checked after download.
+func (f *FormatFilter) AllowsName(name string) bool {
+	format := FormatFromName(name)
+	if format == FormatUnknown {
+		return true
+	}
+	return f.Allows(format)
+}
+
+// AllowsContent reports whether the SBOM content passes the filter
+func (f *FormatFilter) AllowsContent(data []byte) bool {
+	if f == nil {
+		return true
+	}
+	return f.Allows(DetectFormat(data))
+}
+
+// specAliases maps the spec prefixes accepted by --include-spec-versions/--exclude-spec-versions
+var specAliases = map[string]FormatSpec{
+	"cyclonedx": FormatSpecCycloneDX,
+	"cdx":       FormatSpecCycloneDX,
+	"spdx":      FormatSpecSPDX,
+}
+
+// specVersion is a spec version selected by a SpecVersionFilter, spec is empty when the
+// version alone was given
+type specVersion struct {
+	spec    FormatSpec
+	version string
+}
+
+// SpecVersionFilter selects SBOMs by spec version. Unlike FormatFilter it can only be
+// applied to SBOM content. A nil *SpecVersionFilter allows everything.
+type SpecVersionFilter struct {
+	include []specVersion
+	exclude []specVersion
+}
+
+// ParseSpecVersionFilter builds a filter from include and exclude spec version lists, e.g.
+// "1.5", "cyclonedx-1.6" or "spdx-2.3". A bare version selects it in any spec. It returns
+// nil when both lists are empty.
+func ParseSpecVersionFilter(include, exclude []string) (*SpecVersionFilter, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+
+	inc, err := parseSpecVersionList(include)
+	if err != nil {
+		return nil, err
+	}
+	exc, err := parseSpecVersionList(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpecVersionFilter{include: inc, exclude: exc}, nil
+}
+
+func parseSpecVersionList(values []string) ([]specVersion, error) {
+	var versions []specVersion
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+
+		selected := specVersion{version: value}
+		if name, version, ok := strings.Cut(value, "-"); ok {
+			spec, known := specAliases[name]
+			if !known {
+				return nil, fmt.Errorf("unsupported spec %q in %q (must be one of: cyclonedx, spdx)", name, value)
+			}
+			selected = specVersion{spec: spec, version: version}
+		}
+
+		if !knownSpecVersion(selected) {
+			return nil, fmt.Errorf("unsupported spec version %q (e.g. 1.5, cyclonedx-1.6, spdx-2.3)", value)
+		}
+		versions = append(versions, selected)
+	}
+	return versions, nil
+}
+
+// knownSpecVersion reports whether a released version of the spec, or of any spec when
+// none is set, matches the selected one
+func knownSpecVersion(selected specVersion) bool {
+	for spec, versions := range knownSpecVersions {
+		for version := range versions {
+			if selected.matches(spec, version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matches reports whether the detected spec and version, e.g. "SPDX-2.3", are the selected ones
+func (v specVersion) matches(spec FormatSpec, version string) bool {
+	if v.spec != "" && v.spec != spec {
+		return false
+	}
+	return v.version == strings.TrimPrefix(strings.ToLower(version), "spdx-")
+}
+
+// AllowsContent reports whether the SBOM content passes the filter. SBOMs whose spec
+// version can't be detected are allowed only when no include list is set.
+func (f *SpecVersionFilter) AllowsContent(data []byte) bool {
+	if f == nil {
+		return true
+	}
+
+	spec, version := DetectSpecVersion(data)
+	if spec == FormatSpecUnknown {
+		return len(f.include) == 0
+	}
+
+	for _, excluded := range f.exclude {
+		if excluded.matches(spec, version) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, included := range f.include {
+		if included.matches(spec, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectSpecVersion returns the spec and version of an SBOM in any supported encoding, or
+// FormatSpecUnknown when neither can be told
+func DetectSpecVersion(data []byte) (FormatSpec, string) {
+	if spec, version, err := StrictDetect(data); err == nil {
+		return spec, version
+	}
+	// fall back to the loose detection for unreleased versions or incomplete documents
+	spec, version, err := DetectSBOMSpecAndVersion(data)
+	if err != nil || version == "" {
+		return FormatSpecUnknown, ""
+	}
+	return spec, version
+}
+
+// FormatFromName guesses the SBOM format from common file naming conventions such as
+// "app.cdx.json" or "app.spdx". It returns FormatUnknown when the name is ambiguous.
+func FormatFromName(name string) SBOMFormat {
+	name = strings.ToLower(name)
+
+	isCDX := strings.Contains(name, "cdx") || strings.Contains(name, "cyclonedx")
+	isSPDX := strings.Contains(name, "spdx")
+	if isCDX == isSPDX {
+		// both or neither spec named, e.g. "sbom.json"
+		return FormatUnknown
+	}
+
+	switch {
+	case isSPDX && strings.HasSuffix(name, ".json"):
+		return FormatSPDXJSON
+	case isSPDX && (strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")):
+		return FormatSPDXYAML
+	case isSPDX && (strings.HasSuffix(name, ".spdx") || strings.HasSuffix(name, ".txt") || strings.HasSuffix(name, ".tv")):
+		return FormatSPDXTag
+	case isCDX && strings.HasSuffix(name, ".json"):
+		return FormatCycloneDXJSON
+	case isCDX && strings.HasSuffix(name, ".xml"):
+		return FormatCycloneDXXML
+	case isCDX && (strings.HasSuffix(name, ".pb") || strings.HasSuffix(name, ".bin")):
+		return FormatCycloneDXProto
+	}
+	return FormatUnknown
+}
+
+// sbomExtensions are the extensions and spec markers stripped from names by ConvertedName
+var sbomExtensions = []string{".json", ".xml", ".yaml", ".yml", ".spdx", ".txt", ".tv", ".pb", ".bin", ".cdx", ".cyclonedx"}
+
+// ConvertedName renames an SBOM file after the spec it was converted to, e.g. "app.cdx.json"
+// to "app.spdx.json" for SPDX. Directories in the name are kept.
+func ConvertedName(name string, spec FormatSpec) string {
+	stem := sbomStem(name)
+	if spec == FormatSpecSPDX {
+		return stem + ".spdx.json"
+	}
+	return stem + ".cdx.json"
+}
+
+// sbomStem strips the SBOM extensions and spec markers off a file name, e.g. "app.cdx.json" to "app"
+func sbomStem(name string) string {
+	stem := name
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, ext := range sbomExtensions {
+			if len(stem) > len(ext) && strings.HasSuffix(strings.ToLower(stem), ext) {
+				stem = stem[:len(stem)-len(ext)]
+				trimmed = true
+			}
+		}
+	}
+	return stem
+}
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/interlynk-io/sbomasm/v2/pkg/sbom"
+	"sigs.k8s.io/yaml"
 )
 
 // Format-specific structs for basic parsing
@@ -53,6 +54,11 @@ func (p *SBOMProcessor) detectAndParse(doc *SBOMDocument) error {
 	// Use sbomasms Detect function
 	specFormat, fileFormat, err := sbom.Detect(sbomReader)
 	if err != nil {
+		// sbomasm doesn't know about the CycloneDX protobuf encoding
+		if IsCycloneDXProtobuf(doc.Content) {
+			doc.Format = FormatCycloneDXProto
+			return p.parseSBOMContent(doc)
+		}
 		return fmt.Errorf("failed to detect SBOM format: %w", err)
 	}
 
@@ -97,9 +103,14 @@ func (p *SBOMProcessor) parseSBOMContent(doc *SBOMDocument) error {
 		if err := json.Unmarshal(doc.Content, &cdx); err == nil {
 			doc.SpecVersion = cdx.SpecVersion
 		}
+	case FormatCycloneDXProto:
+		if version, err := CycloneDXProtobufSpecVersion(doc.Content); err == nil {
+			doc.SpecVersion = version
+		}
+
 	case FormatSPDXJSON, FormatSPDXYAML:
 		var spdx spdxJSON
-		if err := json.Unmarshal(doc.Content, &spdx); err == nil {
+		if err := yaml.Unmarshal(doc.Content, &spdx); err == nil {
 			doc.SpecVersion = spdx.SpecVersion
 		}
 
@@ -0,0 +1,188 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+)
+
+// MergeCycloneDX merges CycloneDX JSON documents into one document describing an application
+// of the given name and version, encoded as CycloneDX JSON of specVersion. The components of
+// all documents, including the component each describes, are flattened into the merged
+// document and deduplicated by purl, or by group, name and version when they have none.
+// Dependencies are merged and point at the deduplicated components.
+func MergeCycloneDX(name, version string, docs [][]byte, specVersion string) ([]byte, error) {
+	encodeVersion, ok := cycloneDXSpecVersions[specVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported CycloneDX version %q", specVersion)
+	}
+
+	m := &merger{
+		refs:      map[string]bool{},
+		byKey:     map[string]string{},
+		dependsOn: map[string]map[string]bool{},
+	}
+
+	root := cdx.Component{
+		BOMRef:  "sbommv-merged-" + name,
+		Type:    cdx.ComponentTypeApplication,
+		Name:    name,
+		Version: version,
+	}
+	m.refs[root.BOMRef] = true
+
+	for i, data := range docs {
+		var bom cdx.BOM
+		if err := cdx.NewBOMDecoder(bytes.NewReader(data), cdx.BOMFileFormatJSON).Decode(&bom); err != nil {
+			return nil, fmt.Errorf("decoding CycloneDX document %d: %w", i+1, err)
+		}
+		m.add(i, &bom, root.BOMRef)
+	}
+
+	merged := cdx.NewBOM()
+	merged.SerialNumber = "urn:uuid:" + uuid.NewString()
+	merged.Metadata = &cdx.Metadata{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Tools: &cdx.ToolsChoice{Components: &[]cdx.Component{
+			{Type: cdx.ComponentTypeApplication, Name: "sbommv"},
+		}},
+		Component: &root,
+	}
+	merged.Components = &m.components
+	merged.Dependencies = m.dependencies()
+
+	var buf bytes.Buffer
+	if err := cdx.NewBOMEncoder(&buf, cdx.BOMFileFormatJSON).SetPretty(true).EncodeVersion(merged, encodeVersion); err != nil {
+		return nil, fmt.Errorf("encoding merged CycloneDX document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// merger accumulates the components and dependencies of the documents being merged
+type merger struct {
+	components []cdx.Component
+	refs       map[string]bool            // bom-refs used in the merged document
+	byKey      map[string]string          // dedup key to the bom-ref of the kept component
+	dependsOn  map[string]map[string]bool // bom-ref to the bom-refs it depends on
+}
+
+// add merges the components and dependencies of the i-th document. The component the
+// document describes becomes a dependency of the merged root.
+func (m *merger) add(i int, bom *cdx.BOM, rootRef string) {
+	// bom-refs of this document to their bom-ref in the merged document
+	renamed := map[string]string{}
+
+	var described string
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		described = m.addComponent(i, *bom.Metadata.Component, renamed)
+		m.depend(rootRef, described)
+	}
+	if bom.Components != nil {
+		for _, c := range *bom.Components {
+			m.addComponent(i, c, renamed)
+		}
+	}
+
+	if bom.Dependencies == nil {
+		return
+	}
+	for _, dep := range *bom.Dependencies {
+		ref, ok := renamed[dep.Ref]
+		if !ok || dep.Dependencies == nil {
+			continue
+		}
+		for _, target := range *dep.Dependencies {
+			if targetRef, ok := renamed[target]; ok && targetRef != ref {
+				m.depend(ref, targetRef)
+			}
+		}
+	}
+}
+
+// addComponent adds a component and its nested components, flattened, unless an identical
+// component was already added. It returns the component's bom-ref in the merged document.
+func (m *merger) addComponent(i int, c cdx.Component, renamed map[string]string) string {
+	nested := c.Components
+	c.Components = nil
+	localRef := c.BOMRef
+
+	key := componentKey(c)
+	ref, seen := m.byKey[key]
+	if !seen {
+		ref = c.BOMRef
+		if ref == "" {
+			ref = key
+		}
+		// refs local to a document, such as "1", may collide across documents
+		if m.refs[ref] {
+			ref = fmt.Sprintf("%d-%s", i+1, ref)
+		}
+		c.BOMRef = ref
+		m.refs[ref] = true
+		m.byKey[key] = ref
+		m.components = append(m.components, c)
+	}
+	if localRef != "" {
+		renamed[localRef] = ref
+	}
+
+	if nested != nil {
+		for _, child := range *nested {
+			m.addComponent(i, child, renamed)
+		}
+	}
+	return ref
+}
+
+func (m *merger) depend(ref, target string) {
+	if m.dependsOn[ref] == nil {
+		m.dependsOn[ref] = map[string]bool{}
+	}
+	m.dependsOn[ref][target] = true
+}
+
+// dependencies returns the merged dependency graph, sorted for stable output
+func (m *merger) dependencies() *[]cdx.Dependency {
+	refs := make([]string, 0, len(m.dependsOn))
+	for ref := range m.dependsOn {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	deps := make([]cdx.Dependency, 0, len(refs))
+	for _, ref := range refs {
+		targets := make([]string, 0, len(m.dependsOn[ref]))
+		for target := range m.dependsOn[ref] {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+		deps = append(deps, cdx.Dependency{Ref: ref, Dependencies: &targets})
+	}
+	return &deps
+}
+
+// componentKey identifies a component across documents: its purl, or its group, name and version
+func componentKey(c cdx.Component) string {
+	if c.PackageURL != "" {
+		return c.PackageURL
+	}
+	return fmt.Sprintf("%s/%s@%s", c.Group, c.Name, c.Version)
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mergeAPIFixture and mergeWorkerFixture are SBOMs of two services of a release, sharing a
+// library with a purl and one without. Both use the local bom-ref "1", for different components.
+const mergeAPIFixture = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"component": {"bom-ref": "api", "type": "application", "name": "api", "version": "1.0.0", "purl": "pkg:oci/api@1.0.0"}},
+  "components": [
+    {"bom-ref": "zap", "type": "library", "name": "zap", "version": "1.27.0", "purl": "pkg:golang/go.uber.org/zap@1.27.0"},
+    {"bom-ref": "1", "type": "library", "name": "common", "version": "1.0.0"}
+  ],
+  "dependencies": [{"ref": "api", "dependsOn": ["zap", "1"]}]
+}`
+
+const mergeWorkerFixture = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "metadata": {"component": {"bom-ref": "worker", "type": "application", "name": "worker", "version": "1.0.0", "purl": "pkg:oci/worker@1.0.0"}},
+  "components": [
+    {"bom-ref": "zap-ref", "type": "library", "name": "zap", "version": "1.27.0", "purl": "pkg:golang/go.uber.org/zap@1.27.0"},
+    {"bom-ref": "common", "type": "library", "name": "common", "version": "1.0.0"},
+    {"bom-ref": "1", "type": "library", "name": "queue", "version": "2.0.0",
+     "components": [{"bom-ref": "queue-codec", "type": "library", "name": "codec", "version": "2.0.0"}]}
+  ],
+  "dependencies": [
+    {"ref": "worker", "dependsOn": ["zap-ref", "common", "1"]},
+    {"ref": "1", "dependsOn": ["queue-codec"]}
+  ]
+}`
+
+// decodeCycloneDX decodes a CycloneDX JSON document
+func decodeCycloneDX(t *testing.T, data []byte) *cdx.BOM {
+	t.Helper()
+	var bom cdx.BOM
+	require.NoError(t, cdx.NewBOMDecoder(bytes.NewReader(data), cdx.BOMFileFormatJSON).Decode(&bom))
+	return &bom
+}
+
+// dependencyGraph returns the dependencies of a CycloneDX document by bom-ref
+func dependencyGraph(bom *cdx.BOM) map[string][]string {
+	graph := map[string][]string{}
+	if bom.Dependencies == nil {
+		return graph
+	}
+	for _, dep := range *bom.Dependencies {
+		graph[dep.Ref] = []string{}
+		if dep.Dependencies != nil {
+			graph[dep.Ref] = *dep.Dependencies
+		}
+	}
+	return graph
+}
+
+func TestMergeCycloneDX(t *testing.T) {
+	data, err := MergeCycloneDX("interlynk-io/monorepo", "v1.0.0", [][]byte{[]byte(mergeAPIFixture), []byte(mergeWorkerFixture)}, "1.6")
+	require.NoError(t, err)
+	assert.True(t, Validate(data).Valid(), "merged SBOM is invalid: %v", Validate(data).Errors)
+
+	bom := decodeCycloneDX(t, data)
+	assert.Equal(t, cdx.SpecVersion1_6, bom.SpecVersion)
+	require.NotNil(t, bom.Metadata.Component)
+	root := bom.Metadata.Component
+	assert.Equal(t, "interlynk-io/monorepo", root.Name)
+	assert.Equal(t, "v1.0.0", root.Version)
+
+	// components of both documents are flattened and deduplicated, local bom-refs colliding
+	// across documents are renamed
+	refs := map[string]string{}
+	for _, c := range *bom.Components {
+		refs[c.Name] = c.BOMRef
+		assert.Nil(t, c.Components, "component %s keeps nested components", c.Name)
+	}
+	assert.Equal(t, map[string]string{
+		"api":    "api",
+		"zap":    "zap",
+		"common": "1",
+		"worker": "worker",
+		"queue":  "2-1",
+		"codec":  "queue-codec",
+	}, refs)
+
+	assert.Equal(t, map[string][]string{
+		root.BOMRef: {"api", "worker"},
+		"api":       {"1", "zap"},
+		"worker":    {"1", "2-1", "zap"},
+		"2-1":       {"queue-codec"},
+	}, dependencyGraph(bom))
+}
+
+func TestMergeCycloneDXErrors(t *testing.T) {
+	_, err := MergeCycloneDX("app", "v1", [][]byte{[]byte(mergeAPIFixture)}, "9.9")
+	assert.ErrorContains(t, err, `unsupported CycloneDX version "9.9"`)
+
+	_, err = MergeCycloneDX("app", "v1", [][]byte{[]byte(mergeAPIFixture), []byte("not json")}, "1.5")
+	assert.ErrorContains(t, err, "decoding CycloneDX document 2")
+}
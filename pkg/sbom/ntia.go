@@ -0,0 +1,272 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrNTIAUnsupported is returned for SBOMs whose encoding can't be scored, e.g. SPDX tag-value
+var ErrNTIAUnsupported = errors.New("NTIA scoring not supported for this encoding")
+
+// NTIA minimum elements, as scored by ScoreNTIA
+const (
+	NTIASupplier     = "supplier name"
+	NTIAName         = "component name"
+	NTIAVersion      = "component version"
+	NTIAIdentifiers  = "unique identifiers"
+	NTIADependencies = "dependency relationships"
+	NTIAAuthor       = "author of SBOM data"
+	NTIATimestamp    = "timestamp"
+)
+
+// NTIAElement is how well an SBOM covers one minimum element: 1 when the document has it, or
+// the share of components that have it for per-component elements
+type NTIAElement struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+// NTIAResult is an SBOM scored against the NTIA minimum elements
+type NTIAResult struct {
+	Score      float64       `json:"score"` // 0 to 10, the mean of the element scores times 10
+	Components int           `json:"components"`
+	Elements   []NTIAElement `json:"elements"`
+}
+
+// Missing describes the elements the SBOM doesn't fully cover, e.g.
+// "supplier name (3 of 5 components)"
+func (r NTIAResult) Missing() []string {
+	var missing []string
+	for _, e := range r.Elements {
+		switch {
+		case e.Score >= 1:
+		case isComponentElement(e.Name) && r.Components > 0:
+			lacking := r.Components - int(math.Round(e.Score*float64(r.Components)))
+			missing = append(missing, fmt.Sprintf("%s (%d of %d components)", e.Name, lacking, r.Components))
+		default:
+			missing = append(missing, e.Name)
+		}
+	}
+	return missing
+}
+
+func isComponentElement(name string) bool {
+	switch name {
+	case NTIASupplier, NTIAName, NTIAVersion, NTIAIdentifiers:
+		return true
+	}
+	return false
+}
+
+// ScoreNTIA scores the SBOM against the NTIA minimum elements: supplier, name, version and
+// unique identifiers of each component, and the dependencies, author and timestamp of the
+// document. An SBOM without components scores 0 on the per-component elements.
+func ScoreNTIA(data []byte) (NTIAResult, error) {
+	format := DetectFormat(data)
+	switch format {
+	case FormatCycloneDXXML:
+		converted, err := CycloneDXXMLToJSON(data)
+		if err != nil {
+			return NTIAResult{}, err
+		}
+		data = converted
+	case FormatCycloneDXProto, FormatSPDXYAML:
+		converted, _, err := ToJSON(data)
+		if err != nil {
+			return NTIAResult{}, err
+		}
+		data = converted
+	case FormatCycloneDXJSON, FormatSPDXJSON:
+	default:
+		return NTIAResult{}, ErrNTIAUnsupported
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return NTIAResult{}, fmt.Errorf("unmarshaling SBOM: %w", err)
+	}
+
+	var result NTIAResult
+	if format == FormatSPDXJSON || format == FormatSPDXYAML {
+		result = scoreSPDX(doc)
+	} else {
+		result = scoreCycloneDX(doc)
+	}
+
+	var total float64
+	for _, e := range result.Elements {
+		total += e.Score
+	}
+	result.Score = math.Round(total/float64(len(result.Elements))*100) / 10
+	return result, nil
+}
+
+// componentScores counts the components having each per-component element
+type componentScores struct {
+	components, supplier, name, version, identifiers int
+}
+
+func (c *componentScores) add(supplier, name, version, identifiers bool) {
+	c.components++
+	for _, counted := range []struct {
+		has   bool
+		count *int
+	}{{supplier, &c.supplier}, {name, &c.name}, {version, &c.version}, {identifiers, &c.identifiers}} {
+		if counted.has {
+			*counted.count++
+		}
+	}
+}
+
+func (c componentScores) result(dependencies, author, timestamp bool) NTIAResult {
+	share := func(n int) float64 {
+		if c.components == 0 {
+			return 0
+		}
+		return float64(n) / float64(c.components)
+	}
+	present := func(has bool) float64 {
+		if has {
+			return 1
+		}
+		return 0
+	}
+	return NTIAResult{
+		Components: c.components,
+		Elements: []NTIAElement{
+			{Name: NTIASupplier, Score: share(c.supplier)},
+			{Name: NTIAName, Score: share(c.name)},
+			{Name: NTIAVersion, Score: share(c.version)},
+			{Name: NTIAIdentifiers, Score: share(c.identifiers)},
+			{Name: NTIADependencies, Score: present(dependencies)},
+			{Name: NTIAAuthor, Score: present(author)},
+			{Name: NTIATimestamp, Score: present(timestamp)},
+		},
+	}
+}
+
+func scoreCycloneDX(doc map[string]any) NTIAResult {
+	metadata, _ := doc["metadata"].(map[string]any)
+
+	var scores componentScores
+	var walk func(components []any)
+	walk = func(components []any) {
+		for _, c := range components {
+			component, _ := c.(map[string]any)
+			if component == nil {
+				continue
+			}
+			scores.add(
+				hasName(component["supplier"]) || hasString(component, "publisher") || hasName(component["manufacturer"]),
+				hasString(component, "name"),
+				hasString(component, "version"),
+				hasString(component, "purl") || hasString(component, "cpe") || component["swid"] != nil,
+			)
+			nested, _ := component["components"].([]any)
+			walk(nested)
+		}
+	}
+	components, _ := doc["components"].([]any)
+	walk(components)
+
+	dependencies := false
+	deps, _ := doc["dependencies"].([]any)
+	for _, d := range deps {
+		dep, _ := d.(map[string]any)
+		if dependsOn, _ := dep["dependsOn"].([]any); len(dependsOn) > 0 {
+			dependencies = true
+			break
+		}
+	}
+
+	authors, _ := metadata["authors"].([]any)
+	author := len(authors) > 0 || hasName(metadata["manufacture"]) || hasName(metadata["manufacturer"]) || hasName(metadata["supplier"])
+
+	return scores.result(dependencies, author, hasString(metadata, "timestamp"))
+}
+
+func scoreSPDX(doc map[string]any) NTIAResult {
+	var scores componentScores
+	packages, _ := doc["packages"].([]any)
+	for _, p := range packages {
+		pkg, _ := p.(map[string]any)
+		if pkg == nil {
+			continue
+		}
+
+		identifiers := false
+		refs, _ := pkg["externalRefs"].([]any)
+		for _, r := range refs {
+			ref, _ := r.(map[string]any)
+			category, _ := ref["referenceCategory"].(string)
+			switch strings.ReplaceAll(category, "_", "-") {
+			case "PACKAGE-MANAGER", "SECURITY", "PERSISTENT-ID":
+				identifiers = true
+			}
+		}
+
+		scores.add(
+			assertedString(pkg, "supplier") || assertedString(pkg, "originator"),
+			hasString(pkg, "name"),
+			hasString(pkg, "versionInfo"),
+			identifiers,
+		)
+	}
+
+	dependencies := false
+	relationships, _ := doc["relationships"].([]any)
+	for _, r := range relationships {
+		rel, _ := r.(map[string]any)
+		switch rel["relationshipType"] {
+		case "DEPENDS_ON", "DEPENDENCY_OF", "CONTAINS", "CONTAINED_BY":
+			dependencies = true
+		}
+	}
+
+	creationInfo, _ := doc["creationInfo"].(map[string]any)
+	author := false
+	creators, _ := creationInfo["creators"].([]any)
+	for _, c := range creators {
+		s, _ := c.(string)
+		if strings.HasPrefix(s, "Person:") || strings.HasPrefix(s, "Organization:") {
+			author = true
+		}
+	}
+
+	return scores.result(dependencies, author, hasString(creationInfo, "created"))
+}
+
+// hasString reports whether the object has a non-empty string under the key
+func hasString(obj map[string]any, key string) bool {
+	s, _ := obj[key].(string)
+	return strings.TrimSpace(s) != ""
+}
+
+// assertedString is hasString, not counting SPDX's NOASSERTION
+func assertedString(obj map[string]any, key string) bool {
+	s, _ := obj[key].(string)
+	return hasString(obj, key) && s != "NOASSERTION"
+}
+
+// hasName reports whether the value is an object with a non-empty name, e.g. a CycloneDX supplier
+func hasName(v any) bool {
+	obj, _ := v.(map[string]any)
+	return hasString(obj, "name")
+}
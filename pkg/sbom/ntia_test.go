@@ -0,0 +1,109 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ntiaCycloneDXComplete has every NTIA minimum element, for both of its components
+const ntiaCycloneDXComplete = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {
+    "timestamp": "2025-01-15T10:00:00Z",
+    "authors": [{"name": "Interlynk"}]
+  },
+  "components": [
+    {"bom-ref": "a", "name": "sbomqs", "version": "1.0.0", "supplier": {"name": "Interlynk"}, "purl": "pkg:golang/github.com/interlynk-io/sbomqs@1.0.0"},
+    {"bom-ref": "b", "name": "cobra", "version": "1.8.1", "publisher": "spf13", "cpe": "cpe:2.3:a:spf13:cobra:1.8.1:*:*:*:*:*:*:*"}
+  ],
+  "dependencies": [{"ref": "a", "dependsOn": ["b"]}]
+}`
+
+// ntiaCycloneDXPartial has four components, one of them nested, lacking some elements
+const ntiaCycloneDXPartial = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"timestamp": "2025-01-15T10:00:00Z"},
+  "components": [
+    {"name": "app", "version": "2.0.0", "supplier": {"name": "Acme"}, "purl": "pkg:npm/app@2.0.0",
+     "components": [{"name": "plugin", "version": "0.1.0"}]},
+    {"name": "left-pad", "version": "1.3.0", "supplier": {"name": ""}, "purl": "pkg:npm/left-pad@1.3.0"},
+    {"name": "lodash"}
+  ],
+  "dependencies": [{"ref": "app", "dependsOn": []}]
+}`
+
+// ntiaSPDX has two packages, one without supplier, version or identifiers
+const ntiaSPDX = `{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "creationInfo": {"created": "2025-01-15T10:00:00Z", "creators": ["Tool: sbomqs-1.0.0"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-app", "name": "app", "versionInfo": "2.0.0", "supplier": "Organization: Acme",
+     "externalRefs": [{"referenceCategory": "PACKAGE_MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/app@2.0.0"}]},
+    {"SPDXID": "SPDXRef-lodash", "name": "lodash", "supplier": "NOASSERTION",
+     "externalRefs": [{"referenceCategory": "OTHER", "referenceType": "website", "referenceLocator": "https://lodash.com"}]}
+  ],
+  "relationships": [{"spdxElementId": "SPDXRef-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-lodash"}]
+}`
+
+func TestScoreNTIA(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		score      float64
+		components int
+		missing    []string
+	}{
+		{"complete CycloneDX", ntiaCycloneDXComplete, 10, 2, nil},
+		{"partial CycloneDX", ntiaCycloneDXPartial, 5, 4, []string{
+			"supplier name (3 of 4 components)",
+			"component version (1 of 4 components)",
+			"unique identifiers (2 of 4 components)",
+			"dependency relationships",
+			"author of SBOM data",
+		}},
+		{"SPDX", ntiaSPDX, 6.4, 2, []string{
+			"supplier name (1 of 2 components)",
+			"component version (1 of 2 components)",
+			"unique identifiers (1 of 2 components)",
+			"author of SBOM data",
+		}},
+		{"CycloneDX without components", `{"bomFormat": "CycloneDX", "specVersion": "1.5"}`, 0, 0, []string{
+			"supplier name", "component name", "component version", "unique identifiers",
+			"dependency relationships", "author of SBOM data", "timestamp",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ScoreNTIA([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.score, result.Score)
+			assert.Equal(t, tt.components, result.Components)
+			assert.Equal(t, tt.missing, result.Missing())
+		})
+	}
+}
+
+func TestScoreNTIAUnsupported(t *testing.T) {
+	_, err := ScoreNTIA([]byte("SPDXVersion: SPDX-2.3\nDataLicense: CC0-1.0\nSPDXID: SPDXRef-DOCUMENT\n"))
+	assert.ErrorIs(t, err, ErrNTIAUnsupported)
+}
@@ -23,12 +23,13 @@ import (
 type SBOMFormat string
 
 const (
-	FormatCycloneDXJSON SBOMFormat = "CycloneDX-JSON"
-	FormatCycloneDXXML  SBOMFormat = "CycloneDX-XML"
-	FormatSPDXJSON      SBOMFormat = "SPDX-JSON"
-	FormatSPDXYAML      SBOMFormat = "SPDX-YAML"
-	FormatSPDXTag       SBOMFormat = "SPDX-Tag"
-	FormatUnknown       SBOMFormat = "Unknown"
+	FormatCycloneDXJSON  SBOMFormat = "CycloneDX-JSON"
+	FormatCycloneDXXML   SBOMFormat = "CycloneDX-XML"
+	FormatCycloneDXProto SBOMFormat = "CycloneDX-Protobuf"
+	FormatSPDXJSON       SBOMFormat = "SPDX-JSON"
+	FormatSPDXYAML       SBOMFormat = "SPDX-YAML"
+	FormatSPDXTag        SBOMFormat = "SPDX-Tag"
+	FormatUnknown        SBOMFormat = "Unknown"
 )
 
 // SBOMDocument represents a processed SBOM file
@@ -0,0 +1,74 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// AddCycloneDXProperty sets a name/value pair in metadata.properties of a CycloneDX JSON SBOM,
+// replacing any existing property with the same name. Content that isn't CycloneDX JSON is
+// returned unchanged.
+func AddCycloneDXProperty(data []byte, name, value string) ([]byte, error) {
+	spec, _, err := DetectSBOMSpecAndVersion(data)
+	if err != nil || spec != FormatSpecCycloneDX {
+		return data, nil
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling CycloneDX SBOM: %w", err)
+	}
+
+	metadata := map[string]json.RawMessage{}
+	if raw, ok := doc["metadata"]; ok {
+		if err := json.Unmarshal(raw, &metadata); err != nil {
+			return nil, fmt.Errorf("unmarshaling CycloneDX metadata: %w", err)
+		}
+	}
+
+	var properties []cdxProperty
+	if raw, ok := metadata["properties"]; ok {
+		if err := json.Unmarshal(raw, &properties); err != nil {
+			return nil, fmt.Errorf("unmarshaling CycloneDX metadata properties: %w", err)
+		}
+	}
+
+	replaced := false
+	for i := range properties {
+		if properties[i].Name == name {
+			properties[i].Value = value
+			replaced = true
+		}
+	}
+	if !replaced {
+		properties = append(properties, cdxProperty{Name: name, Value: value})
+	}
+
+	if metadata["properties"], err = json.Marshal(properties); err != nil {
+		return nil, fmt.Errorf("marshaling CycloneDX metadata properties: %w", err)
+	}
+	if doc["metadata"], err = json.Marshal(metadata); err != nil {
+		return nil, fmt.Errorf("marshaling CycloneDX metadata: %w", err)
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"fmt"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+)
+
+// SplitPart is the document of one top-level component split out of a CycloneDX document
+type SplitPart struct {
+	Name    string // name of the component the part describes
+	Version string // version of the component, empty when it has none
+	Data    []byte // CycloneDX JSON, in the spec version of the split document
+}
+
+// SplitCycloneDX splits a CycloneDX JSON document into one document per top-level component:
+// the components the described component directly depends on or, when the dependency graph
+// doesn't say, the components at the top of the component tree that group nested ones. Each
+// part describes its component, keeping the rest of the document's metadata, and holds the
+// components nested in it and those it transitively depends on, so components shared by
+// several are in each of their parts. Services, compositions and vulnerabilities aren't
+// carried over. It returns no parts for documents with fewer than two top-level components.
+func SplitCycloneDX(data []byte) ([]SplitPart, error) {
+	var bom cdx.BOM
+	if err := cdx.NewBOMDecoder(bytes.NewReader(data), cdx.BOMFileFormatJSON).Decode(&bom); err != nil {
+		return nil, fmt.Errorf("decoding CycloneDX document: %w", err)
+	}
+
+	s := &splitter{
+		byRef:     map[string]cdx.Component{},
+		nested:    map[string][]cdx.Component{},
+		dependsOn: map[string][]string{},
+	}
+	if bom.Components != nil {
+		for _, c := range *bom.Components {
+			s.index(c)
+		}
+	}
+	if bom.Dependencies != nil {
+		for _, dep := range *bom.Dependencies {
+			if dep.Dependencies != nil {
+				s.dependsOn[dep.Ref] = append(s.dependsOn[dep.Ref], *dep.Dependencies...)
+			}
+		}
+	}
+
+	units := s.units(&bom)
+	if len(units) < 2 {
+		return nil, nil
+	}
+
+	parts := make([]SplitPart, 0, len(units))
+	for _, unit := range units {
+		part, err := s.part(&bom, unit)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// splitter indexes the components and dependency graph of the document being split
+type splitter struct {
+	byRef     map[string]cdx.Component   // components by bom-ref, without their nested components
+	nested    map[string][]cdx.Component // bom-ref to the components nested in it, flattened
+	dependsOn map[string][]string        // bom-ref to the bom-refs it depends on
+}
+
+// index indexes a component and its nested components, returning them flattened
+func (s *splitter) index(c cdx.Component) []cdx.Component {
+	children := c.Components
+	c.Components = nil
+
+	var descendants []cdx.Component
+	if children != nil {
+		for _, child := range *children {
+			descendants = append(descendants, s.index(child)...)
+		}
+	}
+	if c.BOMRef != "" {
+		s.byRef[c.BOMRef] = c
+		s.nested[c.BOMRef] = descendants
+	}
+	return append([]cdx.Component{c}, descendants...)
+}
+
+// units returns the top-level components the document is split into
+func (s *splitter) units(bom *cdx.BOM) []cdx.Component {
+	var units []cdx.Component
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		for _, ref := range s.dependsOn[bom.Metadata.Component.BOMRef] {
+			if c, ok := s.byRef[ref]; ok {
+				units = append(units, c)
+			}
+		}
+	}
+	if len(units) > 0 || bom.Components == nil {
+		return units
+	}
+
+	for _, c := range *bom.Components {
+		if c.BOMRef != "" && c.Components != nil && len(*c.Components) > 0 {
+			units = append(units, s.byRef[c.BOMRef])
+		}
+	}
+	return units
+}
+
+// part builds the document of a top-level component
+func (s *splitter) part(bom *cdx.BOM, unit cdx.Component) (SplitPart, error) {
+	refs := map[string]bool{unit.BOMRef: true}
+	var components []cdx.Component
+	add := func(c cdx.Component) {
+		if c.BOMRef != "" {
+			if refs[c.BOMRef] {
+				return
+			}
+			refs[c.BOMRef] = true
+		}
+		components = append(components, c)
+	}
+
+	for _, c := range s.nested[unit.BOMRef] {
+		add(c)
+	}
+	// components the unit transitively depends on, in breadth-first order
+	queue := []string{unit.BOMRef}
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		for _, target := range s.dependsOn[ref] {
+			c, ok := s.byRef[target]
+			if !ok || refs[target] {
+				continue
+			}
+			add(c)
+			queue = append(queue, target)
+		}
+	}
+
+	var deps []cdx.Dependency
+	if bom.Dependencies != nil {
+		for _, dep := range *bom.Dependencies {
+			if !refs[dep.Ref] {
+				continue
+			}
+			targets := []string{}
+			if dep.Dependencies != nil {
+				for _, target := range *dep.Dependencies {
+					if refs[target] {
+						targets = append(targets, target)
+					}
+				}
+			}
+			deps = append(deps, cdx.Dependency{Ref: dep.Ref, Dependencies: &targets})
+		}
+	}
+
+	metadata := cdx.Metadata{}
+	if bom.Metadata != nil {
+		metadata = *bom.Metadata
+	}
+	metadata.Component = &unit
+
+	doc := cdx.NewBOM()
+	doc.SerialNumber = "urn:uuid:" + uuid.NewString()
+	doc.Metadata = &metadata
+	doc.Components = &components
+	doc.Dependencies = &deps
+
+	specVersion := bom.SpecVersion
+	if specVersion == 0 {
+		specVersion = cdx.SpecVersion1_5
+	}
+	var buf bytes.Buffer
+	if err := cdx.NewBOMEncoder(&buf, cdx.BOMFileFormatJSON).SetPretty(true).EncodeVersion(doc, specVersion); err != nil {
+		return SplitPart{}, fmt.Errorf("encoding CycloneDX document of %s: %w", unit.Name, err)
+	}
+	return SplitPart{Name: unit.Name, Version: unit.Version, Data: buf.Bytes()}, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// splitMonorepoFixture is a monorepo SBOM whose described component depends on two services
+// sharing a library. The api service has a nested component.
+const splitMonorepoFixture = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "metadata": {
+    "timestamp": "2025-01-15T10:00:00Z",
+    "authors": [{"name": "Interlynk"}],
+    "component": {"bom-ref": "monorepo", "type": "application", "name": "monorepo", "version": "1.0.0"}
+  },
+  "components": [
+    {"bom-ref": "api", "type": "application", "name": "api", "version": "1.0.0",
+     "components": [{"bom-ref": "api-handlers", "type": "library", "name": "handlers", "version": "1.0.0"}]},
+    {"bom-ref": "worker", "type": "application", "name": "svc/worker", "version": "1.0.0"},
+    {"bom-ref": "zap", "type": "library", "name": "zap", "version": "1.27.0"},
+    {"bom-ref": "common", "type": "library", "name": "common", "version": "1.0.0"},
+    {"bom-ref": "unused", "type": "library", "name": "unused", "version": "1.0.0"}
+  ],
+  "dependencies": [
+    {"ref": "monorepo", "dependsOn": ["api", "worker"]},
+    {"ref": "api", "dependsOn": ["zap", "common"]},
+    {"ref": "worker", "dependsOn": ["common"]},
+    {"ref": "common", "dependsOn": []}
+  ]
+}`
+
+// componentRefs returns the bom-refs of the components of a CycloneDX document, in order
+func componentRefs(bom *cdx.BOM) []string {
+	var refs []string
+	if bom.Components != nil {
+		for _, c := range *bom.Components {
+			refs = append(refs, c.BOMRef)
+		}
+	}
+	return refs
+}
+
+func TestSplitCycloneDX(t *testing.T) {
+	parts, err := SplitCycloneDX([]byte(splitMonorepoFixture))
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+
+	api, worker := parts[0], parts[1]
+	assert.Equal(t, "api", api.Name)
+	assert.Equal(t, "1.0.0", api.Version)
+	assert.Equal(t, "svc/worker", worker.Name)
+
+	for _, part := range parts {
+		result := Validate(part.Data)
+		assert.True(t, result.Valid(), "part %s is invalid: %v", part.Name, result.Errors)
+	}
+
+	// each part describes its component, keeping the document's metadata and spec version, and
+	// holds its nested components then those it transitively depends on
+	bom := decodeCycloneDX(t, api.Data)
+	assert.Equal(t, cdx.SpecVersion1_6, bom.SpecVersion)
+	assert.Equal(t, "api", bom.Metadata.Component.BOMRef)
+	assert.Equal(t, "2025-01-15T10:00:00Z", bom.Metadata.Timestamp)
+	require.NotNil(t, bom.Metadata.Authors)
+	assert.Equal(t, "Interlynk", (*bom.Metadata.Authors)[0].Name)
+	assert.Equal(t, []string{"api-handlers", "zap", "common"}, componentRefs(bom))
+	assert.Equal(t, map[string][]string{"api": {"zap", "common"}, "common": {}}, dependencyGraph(bom))
+
+	// components shared by several parts are in each of them
+	bom = decodeCycloneDX(t, worker.Data)
+	assert.Equal(t, []string{"common"}, componentRefs(bom))
+	assert.Equal(t, map[string][]string{"worker": {"common"}, "common": {}}, dependencyGraph(bom))
+}
+
+func TestSplitCycloneDXByComponentTree(t *testing.T) {
+	// without a dependency graph, top-level components grouping nested ones are split out
+	parts, err := SplitCycloneDX([]byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {"bom-ref": "web", "name": "web", "components": [{"bom-ref": "react", "name": "react", "version": "18.0.0"}]},
+    {"bom-ref": "cli", "name": "cli", "components": [{"bom-ref": "cobra", "name": "cobra", "version": "1.8.1"}]},
+    {"bom-ref": "lone", "name": "lone"}
+  ]
+}`))
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Equal(t, "web", parts[0].Name)
+	assert.Equal(t, []string{"react"}, componentRefs(decodeCycloneDX(t, parts[0].Data)))
+	assert.Equal(t, "cli", parts[1].Name)
+	assert.Equal(t, []string{"cobra"}, componentRefs(decodeCycloneDX(t, parts[1].Data)))
+}
+
+func TestSplitCycloneDXSingleComponent(t *testing.T) {
+	parts, err := SplitCycloneDX([]byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"component": {"bom-ref": "app", "name": "app"}},
+  "components": [{"bom-ref": "zap", "name": "zap"}, {"bom-ref": "cobra", "name": "cobra"}],
+  "dependencies": [{"ref": "app", "dependsOn": ["zap"]}]
+}`))
+	require.NoError(t, err)
+	assert.Empty(t, parts)
+}
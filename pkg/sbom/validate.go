@@ -0,0 +1,187 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sbom
+
+import (
+	"embed"
+	"fmt"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// schemas holds the CycloneDX JSON schemas, with the SPDX license list and JSF schemas they
+// reference, and the SPDX JSON schemas documents are validated against
+//
+//go:embed schemas/*.json
+var schemas embed.FS
+
+// ValidationStatus is the outcome of validating an SBOM
+type ValidationStatus string
+
+const (
+	ValidationPass        ValidationStatus = "pass"
+	ValidationFail        ValidationStatus = "fail"
+	ValidationUnsupported ValidationStatus = "unsupported"
+)
+
+// ValidationResult is the result of validating an SBOM against the schema of its spec version
+type ValidationResult struct {
+	Format  SBOMFormat       `json:"format"`
+	Spec    FormatSpec       `json:"spec"`
+	Version string           `json:"version,omitempty"`
+	Status  ValidationStatus `json:"status"`
+	Errors  []string         `json:"errors,omitempty"`
+}
+
+// Valid reports whether the SBOM passed validation
+func (r ValidationResult) Valid() bool {
+	return r.Status == ValidationPass
+}
+
+// validationSchemas maps spec and version to the schema they are validated against
+var validationSchemas = map[FormatSpec]map[string]string{
+	FormatSpecCycloneDX: {
+		"1.4": "schemas/bom-1.4.schema.json",
+		"1.5": "schemas/bom-1.5.schema.json",
+		"1.6": "schemas/bom-1.6.schema.json",
+	},
+	FormatSpecSPDX: {
+		string(FormatSpecVersionSPDXV2_2): "schemas/spdx-2.2.schema.json",
+		string(FormatSpecVersionSPDXV2_3): "schemas/spdx-2.3.schema.json",
+	},
+}
+
+// cdxReferencedSchemas are the schemas the CycloneDX schemas $ref by their $id
+var cdxReferencedSchemas = []string{"schemas/spdx.schema.json", "schemas/jsf-0.82.schema.json"}
+
+var (
+	compiledMu      sync.Mutex
+	compiledSchemas = map[string]*gojsonschema.Schema{}
+)
+
+// maxValidationErrors caps the errors reported per SBOM
+const maxValidationErrors = 20
+
+// Validate checks the SBOM against the schema of its spec version: SPDX 2.2/2.3 JSON (and YAML)
+// and CycloneDX 1.4/1.5/1.6 JSON, XML and protobuf. CycloneDX XML and protobuf are decoded and
+// checked against the JSON schema. Other formats and versions are reported as unsupported.
+func Validate(data []byte) ValidationResult {
+	result := ValidationResult{Format: DetectFormat(data), Spec: FormatSpecUnknown}
+
+	var (
+		doc []byte
+		err error
+	)
+	switch result.Format {
+	case FormatCycloneDXXML:
+		result.Spec = FormatSpecCycloneDX
+		result.Version, doc, err = cycloneDXXMLToJSON(data)
+		if err != nil {
+			return result.fail(err.Error())
+		}
+
+	case FormatCycloneDXJSON, FormatCycloneDXProto, FormatSPDXJSON, FormatSPDXYAML:
+		var spec FormatSpec
+		spec, result.Version, err = DetectSBOMSpecAndVersion(data)
+		if err != nil {
+			return result.fail(err.Error())
+		}
+		result.Spec = spec
+		if doc, _, err = ToJSON(data); err != nil {
+			return result.fail(err.Error())
+		}
+
+	case FormatSPDXTag:
+		result.Spec = FormatSpecSPDX
+		result.Status = ValidationUnsupported
+		result.Errors = []string{"schema validation of SPDX tag-value documents is not supported"}
+		return result
+
+	default:
+		return result.fail("not a CycloneDX or SPDX document")
+	}
+
+	path, ok := validationSchemas[result.Spec][result.Version]
+	if !ok {
+		result.Status = ValidationUnsupported
+		result.Errors = []string{fmt.Sprintf("no schema for %s %s", result.Spec, result.Version)}
+		return result
+	}
+
+	schema, err := compiledSchema(path, result.Spec)
+	if err != nil {
+		return result.fail(err.Error())
+	}
+
+	validation, err := schema.Validate(gojsonschema.NewBytesLoader(doc))
+	if err != nil {
+		return result.fail(fmt.Sprintf("invalid JSON: %v", err))
+	}
+	if validation.Valid() {
+		result.Status = ValidationPass
+		return result
+	}
+
+	result.Status = ValidationFail
+	for _, e := range validation.Errors() {
+		if len(result.Errors) == maxValidationErrors {
+			result.Errors = append(result.Errors, fmt.Sprintf("... and %d more", len(validation.Errors())-maxValidationErrors))
+			break
+		}
+		result.Errors = append(result.Errors, e.String())
+	}
+	return result
+}
+
+func (r ValidationResult) fail(msg string) ValidationResult {
+	r.Status = ValidationFail
+	r.Errors = append(r.Errors, msg)
+	return r
+}
+
+// compiledSchema loads and compiles an embedded schema once
+func compiledSchema(path string, spec FormatSpec) (*gojsonschema.Schema, error) {
+	compiledMu.Lock()
+	defer compiledMu.Unlock()
+
+	if schema, ok := compiledSchemas[path]; ok {
+		return schema, nil
+	}
+
+	loader := gojsonschema.NewSchemaLoader()
+	if spec == FormatSpecCycloneDX {
+		for _, ref := range cdxReferencedSchemas {
+			data, err := schemas.ReadFile(ref)
+			if err != nil {
+				return nil, fmt.Errorf("reading schema %s: %w", ref, err)
+			}
+			if err := loader.AddSchemas(gojsonschema.NewBytesLoader(data)); err != nil {
+				return nil, fmt.Errorf("loading schema %s: %w", ref, err)
+			}
+		}
+	}
+
+	data, err := schemas.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema %s: %w", path, err)
+	}
+	schema, err := loader.Compile(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return nil, fmt.Errorf("compiling schema %s: %w", path, err)
+	}
+
+	compiledSchemas[path] = schema
+	return schema, nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression with the standard five fields:
+// minute, hour, day of month, month and day of week.
+type Schedule struct {
+	expr   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDom bool // day of month is "*"
+	anyDow bool // day of week is "*"
+
+	// fixed is set when neither minute nor hour starts with "*": the schedule runs at set
+	// times of the day, which cron runs once when clocks are turned back, and as soon as
+	// they are turned forward past them
+	fixed bool
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// macros are the predefined schedules accepted in place of the five fields
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a cron expression such as "0 2 * * *", "*/15 * * * mon-fri" or "@daily".
+// Fields accept "*", values, names (jan, mon), ranges ("1-5"), steps ("*/10", "0-30/5") and lists.
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := macros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+
+	s := &Schedule{
+		expr:   expr,
+		anyDom: fields[2] == "*",
+		anyDow: fields[4] == "*",
+		fixed:  !strings.HasPrefix(fields[0], "*") && !strings.HasPrefix(fields[1], "*"),
+	}
+
+	var err error
+	parsers := []struct {
+		value string
+		f     field
+		dst   *uint64
+	}{
+		{fields[0], minuteField, &s.minute},
+		{fields[1], hourField, &s.hour},
+		{fields[2], domField, &s.dom},
+		{fields[3], monthField, &s.month},
+		{fields[4], dowField, &s.dow},
+	}
+	for _, p := range parsers {
+		if *p.dst, err = parseField(p.value, p.f); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+	}
+
+	// 7 is an alias of sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	return s, nil
+}
+
+func parseField(value string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field: %q", f.name, part)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range in %s field: %q", f.name, rangePart)
+			}
+		default:
+			v, err := f.value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if step == 1 {
+				hi = v
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value in %s field: %q (must be %d-%d)", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// String returns the expression the schedule was parsed from
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first time after t matching the schedule, in t's location.
+// It returns the zero time when nothing matches within five years (e.g. "0 0 30 2 *").
+// Like cron, schedules at set times of the day run once in an hour repeated by clocks
+// turned back, and run as clocks turn forward past them; other schedules run as usual.
+func (s *Schedule) Next(t time.Time) time.Time {
+	from := wallClock(t)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			// the next hour as clocks show it, the first one when they are turned back
+			next := t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			if s.fixed && s.skipped(t.Hour()+1, next) {
+				return next
+			}
+			t = next
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		if s.fixed && !wallClock(t).After(from) {
+			// the hour is repeated, the schedule already ran at this time of the day
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// skipped reports whether clocks turned forward at next skipped an hour of the schedule,
+// from hour up to the hour of next
+func (s *Schedule) skipped(hour int, next time.Time) bool {
+	if next.Hour() <= hour {
+		return false
+	}
+	for h := hour; h < next.Hour(); h++ {
+		if s.hour&(1<<uint(h)) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// wallClock returns the date and time of day t shows, to compare times across a change of
+// the UTC offset
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+// dayMatches applies cron's rule that a restricted day of month and day of week match
+// when either of them does
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case s.anyDom && s.anyDow:
+		return true
+	case s.anyDom:
+		return dowMatch
+	case s.anyDow:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
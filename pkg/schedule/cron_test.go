@@ -0,0 +1,137 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"* * * foo *",
+		"@every 5m",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := Parse(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	// a Wednesday
+	from := time.Date(2025, time.January, 15, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want []string // the next runs after from, in order
+	}{
+		{"* * * * *", []string{"2025-01-15 10:08", "2025-01-15 10:09"}},
+		{"0 2 * * *", []string{"2025-01-16 02:00", "2025-01-17 02:00"}},
+		{"@hourly", []string{"2025-01-15 11:00", "2025-01-15 12:00"}},
+		{"@weekly", []string{"2025-01-19 00:00", "2025-01-26 00:00"}},
+		{"@yearly", []string{"2026-01-01 00:00", "2027-01-01 00:00"}},
+		{"*/15 * * * *", []string{"2025-01-15 10:15", "2025-01-15 10:30", "2025-01-15 10:45", "2025-01-15 11:00"}},
+		{"10-20/5 10 * * *", []string{"2025-01-15 10:10", "2025-01-15 10:15", "2025-01-15 10:20", "2025-01-16 10:10"}},
+		{"5/20 10 * * *", []string{"2025-01-15 10:25", "2025-01-15 10:45", "2025-01-16 10:05"}},
+		{"0 9-11 * * *", []string{"2025-01-15 11:00", "2025-01-16 09:00", "2025-01-16 10:00"}},
+		{"0,30 22 * * *", []string{"2025-01-15 22:00", "2025-01-15 22:30", "2025-01-16 22:00"}},
+		{"0 9 * * mon-fri", []string{"2025-01-16 09:00", "2025-01-17 09:00", "2025-01-20 09:00"}},
+		{"0 0 * * 7", []string{"2025-01-19 00:00", "2025-01-26 00:00"}},
+		{"0 0 1 feb,MAR *", []string{"2025-02-01 00:00", "2025-03-01 00:00", "2026-02-01 00:00"}},
+		// a restricted day of month and day of week match when either does
+		{"0 0 20 * sat", []string{"2025-01-18 00:00", "2025-01-20 00:00", "2025-01-25 00:00"}},
+		{"0 0 29 2 *", []string{"2028-02-29 00:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, runs(s, from, len(tt.want), "2006-01-02 15:04"))
+		})
+	}
+}
+
+func TestNextNeverMatches(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
+
+func TestNextDaylightSaving(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// clocks are turned forward from 02:00 to 03:00 on 2025-03-30, and back from 03:00 to
+	// 02:00 on 2025-10-26
+	spring := time.Date(2025, time.March, 29, 12, 0, 0, 0, berlin)
+	autumn := time.Date(2025, time.October, 25, 12, 0, 0, 0, berlin)
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want []string
+	}{
+		{"set time in the skipped hour runs as clocks turn forward", "30 2 * * *", spring,
+			[]string{"2025-03-30 03:00 CEST", "2025-03-31 02:30 CEST"}},
+		{"set time after the skipped hour", "30 3 * * *", spring,
+			[]string{"2025-03-30 03:30 CEST", "2025-03-31 03:30 CEST"}},
+		{"every 30 minutes skips the skipped hour", "*/30 * * * *", time.Date(2025, time.March, 30, 1, 0, 0, 0, berlin),
+			[]string{"2025-03-30 01:30 CET", "2025-03-30 03:00 CEST", "2025-03-30 03:30 CEST"}},
+		{"set time in the repeated hour runs once", "30 2 * * *", autumn,
+			[]string{"2025-10-26 02:30 CEST", "2025-10-27 02:30 CET"}},
+		{"hourly runs in both repeated hours", "0 * * * *", time.Date(2025, time.October, 26, 1, 30, 0, 0, berlin),
+			[]string{"2025-10-26 02:00 CEST", "2025-10-26 02:00 CET", "2025-10-26 03:00 CET"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, runs(s, tt.from, len(tt.want), "2006-01-02 15:04 MST"))
+		})
+	}
+}
+
+// runs returns the next n runs of the schedule after from, formatted with layout
+func runs(s *Schedule, from time.Time, n int, layout string) []string {
+	var got []string
+	for t := from; len(got) < n; {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+		got = append(got, t.Format(layout))
+	}
+	return got
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sign
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Environment variables identity tokens are read from: a token issued for sigstore, or the
+// request URL and bearer of GitHub Actions jobs with the id-token: write permission
+const (
+	identityTokenEnv      = "SIGSTORE_ID_TOKEN"
+	actionsTokenURLEnv    = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	actionsTokenBearerEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+// requestTimeout bounds the requests for identity tokens and certificates
+const requestTimeout = 30 * time.Second
+
+// tokenSource returns the OIDC token certificates are requested with
+type tokenSource func(ctx context.Context) (string, error)
+
+// newTokenSource returns the token, SIGSTORE_ID_TOKEN or, in GitHub Actions, a token the
+// job requests for each certificate, as GitHub's tokens expire within minutes
+func newTokenSource(token string) (tokenSource, error) {
+	if token == "" {
+		token = os.Getenv(identityTokenEnv)
+	}
+	if token != "" {
+		return func(context.Context) (string, error) { return token, nil }, nil
+	}
+
+	requestURL, bearer := os.Getenv(actionsTokenURLEnv), os.Getenv(actionsTokenBearerEnv)
+	if requestURL != "" && bearer != "" {
+		return func(ctx context.Context) (string, error) {
+			return actionsToken(ctx, requestURL, bearer)
+		}, nil
+	}
+	return nil, fmt.Errorf("keyless signing requires an identity token: pass one, set %s, or run in GitHub Actions with the id-token: write permission", identityTokenEnv)
+}
+
+// actionsToken requests an identity token for sigstore from GitHub Actions
+func actionsToken(ctx context.Context, requestURL, bearer string) (string, error) {
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing %s: %w", actionsTokenURLEnv, err)
+	}
+	query := u.Query()
+	query.Set("audience", "sigstore")
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+bearer)
+
+	var response struct {
+		Value string `json:"value"`
+	}
+	if err := doJSON(req, &response); err != nil {
+		return "", fmt.Errorf("requesting GitHub Actions identity token: %w", err)
+	}
+	return response.Value, nil
+}
+
+// signingCertRequest is the body of Fulcio's v2 signingCert request
+type signingCertRequest struct {
+	Credentials struct {
+		OIDCIdentityToken string `json:"oidcIdentityToken"`
+	} `json:"credentials"`
+	PublicKeyRequest struct {
+		PublicKey struct {
+			Algorithm string `json:"algorithm"`
+			Content   string `json:"content"`
+		} `json:"publicKey"`
+		ProofOfPossession string `json:"proofOfPossession"`
+	} `json:"publicKeyRequest"`
+}
+
+// certificateChain is the chain of a signingCert response, leaf first
+type certificateChain struct {
+	Chain struct {
+		Certificates []string `json:"certificates"`
+	} `json:"chain"`
+}
+
+// request asks Fulcio for a certificate of the key, issued to the identity of the token
+func (c *certificateIssuer) request(ctx context.Context) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := tokenSubject(token)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&c.key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256([]byte(subject))
+	proof, err := c.key.Sign(rand.Reader, digest[:], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var body signingCertRequest
+	body.Credentials.OIDCIdentityToken = token
+	body.PublicKeyRequest.PublicKey.Algorithm = "ECDSA"
+	body.PublicKeyRequest.PublicKey.Content = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	body.PublicKeyRequest.ProofOfPossession = base64.StdEncoding.EncodeToString(proof)
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.fulcioURL+"/api/v2/signingCert", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "sbommv/1.0")
+
+	var response struct {
+		Embedded *certificateChain `json:"signedCertificateEmbeddedSct"`
+		Detached *certificateChain `json:"signedCertificateDetachedSct"`
+	}
+	if err := doJSON(req, &response); err != nil {
+		return nil, err
+	}
+
+	chain := response.Embedded
+	if chain == nil {
+		chain = response.Detached
+	}
+	if chain == nil || len(chain.Chain.Certificates) == 0 {
+		return nil, errors.New("no certificate in Fulcio response")
+	}
+
+	var pems []string
+	for _, cert := range chain.Chain.Certificates {
+		pems = append(pems, strings.TrimSpace(cert)+"\n")
+	}
+	return []byte(strings.Join(pems, "")), nil
+}
+
+// doJSON sends the request and decodes the JSON response into v
+func doJSON(req *http.Request, v any) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status: %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sign signs SBOMs the way cosign sign-blob does, for destinations to store the
+// signature (.sig) and, for keyless signatures, the signing certificate (.pem) next to them
+package sign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/verify"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// contextKey is the TransferMetadata key under which the engine stores the signer
+const contextKey = "signer"
+
+// passwordEnv is the environment variable the password of encrypted cosign keys is read from
+const passwordEnv = "COSIGN_PASSWORD"
+
+// Options configure how SBOMs are signed: with a private key, or keyless with a short-lived
+// certificate Fulcio issues to the identity of an OIDC token
+type Options struct {
+	KeyPath       string // PEM private key, e.g. the cosign.key of cosign generate-key-pair
+	Keyless       bool
+	FulcioURL     string // e.g. https://fulcio.sigstore.dev
+	IdentityToken string // OIDC token, empty reads SIGSTORE_ID_TOKEN or asks GitHub Actions for one
+}
+
+// Signer signs SBOMs. It is safe for concurrent use.
+type Signer struct {
+	key     crypto.Signer
+	keyless *certificateIssuer // nil when signing with a private key
+}
+
+// New returns a signer for the options. Keyless signers request their certificate when
+// they sign the first SBOM.
+func New(opts Options) (*Signer, error) {
+	switch {
+	case opts.KeyPath != "" && opts.Keyless:
+		return nil, errors.New("a private key and keyless signing can't be combined")
+	case opts.KeyPath != "":
+		key, err := loadPrivateKey(opts.KeyPath)
+		if err != nil {
+			return nil, err
+		}
+		return &Signer{key: key}, nil
+	case opts.Keyless:
+		if opts.FulcioURL == "" {
+			return nil, errors.New("keyless signing requires a Fulcio URL")
+		}
+		token, err := newTokenSource(opts.IdentityToken)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generating signing key: %w", err)
+		}
+		return &Signer{key: key, keyless: newCertificateIssuer(opts.FulcioURL, token, key)}, nil
+	default:
+		return nil, errors.New("a private key or keyless signing is required")
+	}
+}
+
+// Attach stores the signer in the transfer context for the processing stages to pick up
+func Attach(ctx *tcontext.TransferMetadata, s *Signer) {
+	ctx.WithValue(contextKey, s)
+}
+
+// FromContext returns the signer of the transfer, nil when SBOMs aren't signed
+func FromContext(ctx tcontext.TransferMetadata) *Signer {
+	s, _ := ctx.Value(contextKey).(*Signer)
+	return s
+}
+
+// Sign signs the SBOM data and returns its signature files, keyed by suffix: the base64
+// signature and, for keyless signatures, the PEM certificate chain
+func (s *Signer) Sign(ctx context.Context, data []byte) (map[string][]byte, error) {
+	signatures := make(map[string][]byte, 2)
+	if s.keyless != nil {
+		chain, err := s.keyless.certificate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		signatures[verify.SuffixCertificate] = chain
+	}
+
+	signature, err := signMessage(s.key, data)
+	if err != nil {
+		return nil, fmt.Errorf("signing SBOM: %w", err)
+	}
+	signatures[verify.SuffixSignature] = []byte(base64.StdEncoding.EncodeToString(signature))
+	return signatures, nil
+}
+
+// signMessage signs like cosign: ECDSA over the digest of the message matching the curve,
+// RSA PKCS #1 v1.5 over its SHA-256 digest, or Ed25519 over the message itself
+func signMessage(key crypto.Signer, message []byte) ([]byte, error) {
+	switch k := key.Public().(type) {
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P384():
+			digest := sha512.Sum384(message)
+			return key.Sign(rand.Reader, digest[:], crypto.SHA384)
+		case elliptic.P521():
+			digest := sha512.Sum512(message)
+			return key.Sign(rand.Reader, digest[:], crypto.SHA512)
+		}
+	case ed25519.PublicKey:
+		return key.Sign(rand.Reader, message, crypto.Hash(0))
+	case *rsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", k)
+	}
+	digest := sha256.Sum256(message)
+	return key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// loadPrivateKey reads a PEM private key: PKCS #8, SEC 1 or PKCS #1, or a cosign key
+// encrypted with the password of COSIGN_PASSWORD
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM private key in %s", path)
+	}
+
+	var key any
+	switch block.Type {
+	case "ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED COSIGN PRIVATE KEY":
+		der, err := decryptKey(block.Bytes, []byte(os.Getenv(passwordEnv)))
+		if err != nil {
+			return nil, fmt.Errorf("decrypting private key %s: %w", path, err)
+		}
+		key, err = x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("parsing private key %s: %w", path, err)
+		}
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key %s: %w", path, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T in %s", key, path)
+	}
+	return signer, nil
+}
+
+// encryptedKey is the encrypted key format of cosign generate-key-pair
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// decryptKey returns the PKCS #8 key of an encrypted cosign key
+func decryptKey(data, password []byte) ([]byte, error) {
+	var enc encryptedKey
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, err
+	}
+	if enc.KDF.Name != "scrypt" || enc.Cipher.Name != "nacl/secretbox" {
+		return nil, fmt.Errorf("unsupported key encryption %s/%s", enc.KDF.Name, enc.Cipher.Name)
+	}
+	if len(enc.Cipher.Nonce) != 24 {
+		return nil, errors.New("invalid nonce")
+	}
+
+	secret, err := scrypt.Key(password, enc.KDF.Salt, enc.KDF.Params.N, enc.KDF.Params.R, enc.KDF.Params.P, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [24]byte
+	var key [32]byte
+	copy(nonce[:], enc.Cipher.Nonce)
+	copy(key[:], secret)
+
+	der, ok := secretbox.Open(nil, enc.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, fmt.Errorf("wrong password, set it in %s", passwordEnv)
+	}
+	return der, nil
+}
+
+// tokenSubject returns the subject of an OIDC token, which Fulcio requires to be signed as
+// proof of possession of the key
+func tokenSubject(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed identity token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed identity token: %w", err)
+	}
+
+	var claims struct {
+		Subject string `json:"sub"`
+		Email   string `json:"email"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("malformed identity token: %w", err)
+	}
+	if claims.Subject == "" {
+		return "", errors.New("identity token has no subject")
+	}
+	return claims.Subject, nil
+}
+
+// certificateIssuer requests the certificate of a keyless signer from Fulcio, and a new one
+// when the previous expires
+type certificateIssuer struct {
+	fulcioURL string
+	token     tokenSource
+	key       *ecdsa.PrivateKey
+
+	mu       sync.Mutex
+	chain    []byte
+	notAfter time.Time
+}
+
+func newCertificateIssuer(fulcioURL string, token tokenSource, key *ecdsa.PrivateKey) *certificateIssuer {
+	return &certificateIssuer{fulcioURL: strings.TrimSuffix(fulcioURL, "/"), token: token, key: key}
+}
+
+// certificate returns the PEM certificate chain of the signing key, valid for another minute at least
+func (c *certificateIssuer) certificate(ctx context.Context) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.chain != nil && time.Now().Add(time.Minute).Before(c.notAfter) {
+		return c.chain, nil
+	}
+
+	chain, err := c.request(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("requesting signing certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(chain)
+	if block == nil {
+		return nil, errors.New("no certificate in Fulcio response")
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing certificate: %w", err)
+	}
+
+	c.chain, c.notAfter = chain, leaf.NotAfter
+	return chain, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type AzureBlobAdapter struct {
+	Config         *AzureBlobConfig
+	Role           types.AdapterRole // "input" or "output" adapter type
+	ProcessingMode types.ProcessingMode
+	Fetcher        SBOMFetcher
+}
+
+// AddCommandParams adds Azure Blob Storage-specific CLI flags
+func (a *AzureBlobAdapter) AddCommandParams(cmd *cobra.Command) {
+	cmd.Flags().String("in-azblob-account-name", "", "Storage account name (or AZURE_STORAGE_ACCOUNT)")
+	cmd.Flags().String("in-azblob-container", "", "Blob container name")
+	cmd.Flags().String("in-azblob-prefix", "", "Blob name prefix")
+	cmd.Flags().String("in-azblob-endpoint-url", "", "Custom blob service URL, e.g. Azurite or a sovereign cloud (default: https://<account>.blob.core.windows.net)")
+	cmd.Flags().String("in-azblob-sas-token", "", "Shared access signature of the account or container (or AZURE_STORAGE_SAS_TOKEN)")
+	cmd.Flags().String("in-azblob-account-key", "", "Storage account key (or AZURE_STORAGE_KEY)")
+	cmd.Flags().String("in-azblob-connection-string", "", "Storage account connection string (or AZURE_STORAGE_CONNECTION_STRING)")
+	cmd.Flags().String("in-azblob-namespace-template", "", "Regex with capture groups deriving namespace and version from the blob name relative to the prefix, e.g. '^(?P<namespace>[^/]+)/(?P<version>[^/]+)/'")
+}
+
+// ParseAndValidateParams validates the Azure Blob Storage adapter params
+func (a *AzureBlobAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	var (
+		accountNameFlag, containerFlag, prefixFlag, endpointURLFlag, sasTokenFlag, accountKeyFlag, connectionStringFlag, namespaceTemplateFlag string
+		missingFlags                                                                                                                           []string
+		invalidFlags                                                                                                                           []string
+	)
+
+	accountNameFlag = "in-azblob-account-name"
+	containerFlag = "in-azblob-container"
+	prefixFlag = "in-azblob-prefix"
+	endpointURLFlag = "in-azblob-endpoint-url"
+	sasTokenFlag = "in-azblob-sas-token"
+	accountKeyFlag = "in-azblob-account-key"
+	connectionStringFlag = "in-azblob-connection-string"
+	namespaceTemplateFlag = "in-azblob-namespace-template"
+
+	var fetcher SBOMFetcher
+	if a.ProcessingMode == types.FetchSequential {
+		fetcher = &AzureBlobSequentialFetcher{}
+	} else if a.ProcessingMode == types.FetchParallel {
+		fetcher = &AzureBlobParallelFetcher{}
+	} else {
+		return fmt.Errorf("unsupported processing mode: %s", a.ProcessingMode)
+	}
+
+	// validate flags for Azure Blob Storage adapter, all flags should start with "in-azblob-"
+	err := utils.FlagValidation(cmd, types.AzureBlobAdapterType, types.InputAdapterFlagPrefix)
+	if err != nil {
+		return fmt.Errorf("azblob flag validation failed: %w", err)
+	}
+
+	containerName, _ := cmd.Flags().GetString(containerFlag)
+	if containerName == "" {
+		missingFlags = append(missingFlags, containerFlag)
+	}
+
+	// if prefix is empty that means all blobs of the container
+	prefix, _ := cmd.Flags().GetString(prefixFlag)
+
+	// extract custom blob service endpoint
+	endpointURL, _ := cmd.Flags().GetString(endpointURLFlag)
+	if endpointURL != "" {
+		if u, err := url.Parse(endpointURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be an http or https URL)", endpointURLFlag, endpointURL))
+		}
+	}
+
+	accountName, _ := cmd.Flags().GetString(accountNameFlag)
+	if accountName == "" {
+		accountName = viper.GetString("AZURE_STORAGE_ACCOUNT")
+	}
+
+	// credentials from the flags, or from the environment when no credential flag is set,
+	// and the default Azure credential chain (e.g. managed identity) when neither has any
+	sasToken, _ := cmd.Flags().GetString(sasTokenFlag)
+	accountKey, _ := cmd.Flags().GetString(accountKeyFlag)
+	connectionString, _ := cmd.Flags().GetString(connectionStringFlag)
+	if sasToken == "" && accountKey == "" && connectionString == "" {
+		sasToken = viper.GetString("AZURE_STORAGE_SAS_TOKEN")
+		accountKey = viper.GetString("AZURE_STORAGE_KEY")
+		connectionString = viper.GetString("AZURE_STORAGE_CONNECTION_STRING")
+	}
+
+	credentials := 0
+	for _, c := range []string{sasToken, accountKey, connectionString} {
+		if c != "" {
+			credentials++
+		}
+	}
+	if credentials > 1 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("only one of --%s, --%s and --%s can be used", sasTokenFlag, accountKeyFlag, connectionStringFlag))
+	}
+	if connectionString == "" && accountName == "" && (endpointURL == "" || accountKey != "") {
+		missingFlags = append(missingFlags, accountNameFlag)
+	}
+	if credentials == 0 {
+		logger.LogDebug(cmd.Context(), "Azure Blob Storage credentials not provided, using the default Azure credential chain")
+	}
+
+	// extract namespace template
+	var namespaceTemplate *source.NamespaceTemplate
+	if template, _ := cmd.Flags().GetString(namespaceTemplateFlag); template != "" {
+		namespaceTemplate, err = source.ParseNamespaceTemplate(template)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s: %v", namespaceTemplateFlag, err))
+		}
+	}
+
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing flags: %s", strings.Join(missingFlags, ", "))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid input adapter flag usage:\n %s\n\nUse 'sbommv transfer --help' for correct usage.", strings.Join(invalidFlags, "\n "))
+	}
+
+	cfg := NewAzureBlobConfig()
+	cfg.ProcessingMode = a.ProcessingMode
+	cfg.AccountName = accountName
+	cfg.EndpointURL = endpointURL
+	cfg.ContainerName = containerName
+	cfg.Prefix = prefix
+	cfg.SASToken = sasToken
+	cfg.AccountKey = accountKey
+	cfg.ConnectionString = connectionString
+	cfg.NamespaceTemplate = namespaceTemplate
+
+	a.Config = cfg
+	a.Fetcher = fetcher
+
+	return nil
+}
+
+func (a *AzureBlobAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Initializing SBOM fetching", "mode", a.ProcessingMode)
+	return a.Fetcher.Fetch(ctx, a.Config)
+}
+
+func (a *AzureBlobAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	return fmt.Errorf("Azure Blob Storage adapter does not support SBOM uploading when it is in input adapter role")
+}
+
+func (a *AzureBlobAdapter) DryRun(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	reporter := NewAzureBlobReporter(false, "", a.Config.ContainerName, a.Config.Prefix)
+	return reporter.DryRun(ctx, iterator)
+}
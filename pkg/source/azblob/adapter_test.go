@@ -0,0 +1,90 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseFlags returns the adapter configured from the given flags, and the validation error
+func parseFlags(t *testing.T, args ...string) (*AzureBlobAdapter, error) {
+	t.Helper()
+	adapter := &AzureBlobAdapter{Role: types.InputAdapterRole, ProcessingMode: types.FetchSequential}
+	cmd := &cobra.Command{Use: "transfer"}
+	cmd.Flags().String("input-adapter", "azblob", "")
+	cmd.Flags().String("in-s3-bucket-name", "", "flag of another input adapter")
+	adapter.AddCommandParams(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetContext(context.Background())
+	return adapter, adapter.ParseAndValidateParams(cmd)
+}
+
+func TestParseAndValidateParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		invalid string
+	}{
+		{"no container", []string{"--in-azblob-account-name=acct"}, "missing flags: in-azblob-container"},
+		{"no account", []string{"--in-azblob-container=sboms", "--in-azblob-sas-token=sig=x"}, "missing flags: in-azblob-account-name"},
+		{"account key with endpoint but no account", []string{"--in-azblob-container=sboms", "--in-azblob-endpoint-url=http://127.0.0.1:10000/devstoreaccount1", "--in-azblob-account-key=a2V5"}, "missing flags: in-azblob-account-name"},
+		{"endpoint without scheme", []string{"--in-azblob-account-name=acct", "--in-azblob-container=sboms", "--in-azblob-endpoint-url=127.0.0.1:10000"}, "(must be an http or https URL)"},
+		{"two credentials", []string{"--in-azblob-account-name=acct", "--in-azblob-container=sboms", "--in-azblob-sas-token=sig=x", "--in-azblob-account-key=a2V5"}, "only one of --in-azblob-sas-token, --in-azblob-account-key and --in-azblob-connection-string can be used"},
+		{"invalid namespace template", []string{"--in-azblob-account-name=acct", "--in-azblob-container=sboms", "--in-azblob-namespace-template=(unclosed"}, "--in-azblob-namespace-template:"},
+		{"flag of another adapter", []string{"--in-azblob-account-name=acct", "--in-azblob-container=sboms", "--in-s3-bucket-name=bucket"}, "flag --in-s3-bucket-name is invalid"},
+		{"connection string without account", []string{"--in-azblob-container=sboms", "--in-azblob-connection-string=UseDevelopmentStorage=true"}, ""},
+		{"sas token on custom endpoint", []string{"--in-azblob-container=sboms", "--in-azblob-endpoint-url=http://127.0.0.1:10000/devstoreaccount1", "--in-azblob-sas-token=sig=x", "--in-azblob-prefix=prod"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := parseFlags(t, tt.args...)
+			if tt.invalid != "" {
+				assert.ErrorContains(t, err, tt.invalid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "sboms", adapter.Config.ContainerName)
+			assert.IsType(t, &AzureBlobSequentialFetcher{}, adapter.Fetcher)
+		})
+	}
+}
+
+func TestParseAndValidateParamsCredentialsFromEnv(t *testing.T) {
+	viper.Set("AZURE_STORAGE_ACCOUNT", "envacct")
+	viper.Set("AZURE_STORAGE_KEY", "ZW52LWtleQ==")
+	t.Cleanup(func() {
+		viper.Set("AZURE_STORAGE_ACCOUNT", "")
+		viper.Set("AZURE_STORAGE_KEY", "")
+	})
+
+	adapter, err := parseFlags(t, "--in-azblob-container=sboms")
+	require.NoError(t, err)
+	assert.Equal(t, "envacct", adapter.Config.AccountName)
+	assert.Equal(t, "ZW52LWtleQ==", adapter.Config.AccountKey)
+	assert.Equal(t, "https://envacct.blob.core.windows.net/", adapter.Config.ServiceURL())
+
+	// a credential flag replaces the environment's credentials, which would otherwise conflict
+	adapter, err = parseFlags(t, "--in-azblob-container=sboms", "--in-azblob-sas-token=sig=x")
+	require.NoError(t, err)
+	assert.Equal(t, "sig=x", adapter.Config.SASToken)
+	assert.Empty(t, adapter.Config.AccountKey)
+}
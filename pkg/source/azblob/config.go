@@ -0,0 +1,84 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+type AzureBlobConfig struct {
+	AccountName      string
+	EndpointURL      string // custom blob service URL, e.g. Azurite, empty uses https://<account>.blob.core.windows.net
+	ContainerName    string
+	Prefix           string
+	SASToken         string // shared access signature of the account or container
+	AccountKey       string // storage account key
+	ConnectionString string // storage account connection string, carries the endpoint and credentials
+	ProcessingMode   types.ProcessingMode
+
+	// NamespaceTemplate derives namespace and version from blob names, nil keeps container-prefix
+	NamespaceTemplate *source.NamespaceTemplate
+}
+
+func NewAzureBlobConfig() *AzureBlobConfig {
+	return &AzureBlobConfig{
+		ProcessingMode: types.FetchSequential, // Default
+	}
+}
+
+// ServiceURL returns the URL of the account's blob service
+func (c *AzureBlobConfig) ServiceURL() string {
+	if c.EndpointURL != "" {
+		return strings.TrimSuffix(c.EndpointURL, "/") + "/"
+	}
+	return fmt.Sprintf("https://%s.blob.core.windows.net/", c.AccountName)
+}
+
+// GetAzureClient creates a blob service client, authenticated with the connection string,
+// account key or SAS token when given, and with the default Azure credential chain otherwise
+// (environment, workload identity, managed identity, Azure CLI)
+func (c *AzureBlobConfig) GetAzureClient(ctx tcontext.TransferMetadata) (*azblob.Client, error) {
+	logger.LogDebug(ctx.Context, "Initializing Azure Blob Storage client", "account", c.AccountName, "container", c.ContainerName, "prefix", c.Prefix, "endpoint", c.EndpointURL)
+
+	switch {
+	case c.ConnectionString != "":
+		return azblob.NewClientFromConnectionString(c.ConnectionString, nil)
+
+	case c.AccountKey != "":
+		cred, err := azblob.NewSharedKeyCredential(c.AccountName, c.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account key: %w", err)
+		}
+		return azblob.NewClientWithSharedKeyCredential(c.ServiceURL(), cred, nil)
+
+	case c.SASToken != "":
+		return azblob.NewClientWithNoCredential(c.ServiceURL()+"?"+strings.TrimPrefix(c.SASToken, "?"), nil)
+
+	default:
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Azure credentials: %w", err)
+		}
+		return azblob.NewClient(c.ServiceURL(), cred, nil)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// Estimate lists the blobs under the prefix a transfer would pick up, judging them by name
+// and using the sizes from the listing, without downloading any of them
+func (a *AzureBlobAdapter) Estimate(ctx tcontext.TransferMetadata) ([]types.SBOMCandidate, error) {
+	client, err := a.Config.GetAzureClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure Blob Storage client: %w", err)
+	}
+
+	var candidates []types.SBOMCandidate
+	pager := client.NewListBlobsFlatPager(a.Config.ContainerName, &azblob.ListBlobsFlatOptions{Prefix: to.Ptr(a.Config.Prefix)})
+	for pager.More() {
+		page, err := pager.NextPage(ctx.Context)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blobs: %w", err)
+		}
+
+		for _, blob := range page.Segment.BlobItems {
+			if blob.Name == nil {
+				continue
+			}
+			name := *blob.Name
+			if source.IsSidecar(name) || !source.DetectSBOMsFile(path.Base(name)) || !source.AllowsFormatName(ctx, name) {
+				continue
+			}
+
+			var size int64
+			if blob.Properties != nil && blob.Properties.ContentLength != nil {
+				size = *blob.Properties.ContentLength
+			}
+			candidates = append(candidates, types.SBOMCandidate{
+				Name:      name,
+				Namespace: a.Config.ContainerName + "-" + a.Config.Prefix,
+				Size:      size,
+			})
+		}
+	}
+
+	return candidates, nil
+}
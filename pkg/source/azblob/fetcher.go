@@ -0,0 +1,292 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+type SBOMFetcher interface {
+	Fetch(ctx tcontext.TransferMetadata, config *AzureBlobConfig) (iterator.SBOMIterator, error)
+}
+
+type (
+	AzureBlobSequentialFetcher struct{}
+	AzureBlobParallelFetcher   struct{}
+)
+
+// maxParallelDownloads is the number of workers downloading blobs with --processing-mode=parallel
+const maxParallelDownloads = 5
+
+// Fetch lists the blobs under the prefix and downloads them with a pool of workers
+func (f *AzureBlobParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *AzureBlobConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Concurrently...")
+
+	client, prefix, err := openContainer(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs, names, err := listBlobs(ctx, client, config.ContainerName, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var sboms []*iterator.SBOM
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	nameChan := make(chan string)
+
+	for i := 0; i < maxParallelDownloads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range nameChan {
+				// drain the remaining names once cancelled
+				if ctx.Err() != nil {
+					continue
+				}
+
+				sbom, err := fetchBlob(ctx, client, config, prefix, name, names)
+				if err != nil {
+					logger.LogError(ctx.Context, err, "Skipping blob", "name", name)
+					continue
+				}
+				if sbom == nil {
+					continue
+				}
+
+				mu.Lock()
+				sboms = append(sboms, sbom)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, blob := range blobs {
+		if ctx.Err() != nil {
+			break
+		}
+		name := *blob.Name
+		if source.IsSidecar(name) || !source.AllowsFormatName(ctx, name) {
+			continue
+		}
+		nameChan <- name
+	}
+	close(nameChan)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, source.FetchInterrupted(ctx, len(sboms))
+	}
+	if len(sboms) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in container %s/%s", config.ContainerName, config.Prefix))
+	}
+
+	return NewAzureBlobIterator(sboms), nil
+}
+
+// Fetch lists the blobs under the prefix and downloads them one by one
+func (f *AzureBlobSequentialFetcher) Fetch(ctx tcontext.TransferMetadata, config *AzureBlobConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Sequentially")
+
+	client, prefix, err := openContainer(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.LogDebug(ctx.Context, "Fetching SBOMs from Azure Blob Storage container", "container", config.ContainerName, "prefix", config.Prefix)
+
+	blobs, names, err := listBlobs(ctx, client, config.ContainerName, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var sbomList []*iterator.SBOM
+	for _, blob := range blobs {
+		if ctx.Err() != nil {
+			return nil, source.FetchInterrupted(ctx, len(sbomList))
+		}
+		name := *blob.Name
+		if source.IsSidecar(name) || !source.AllowsFormatName(ctx, name) {
+			continue
+		}
+
+		sbom, err := fetchBlob(ctx, client, config, prefix, name, names)
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Skipping blob", "name", name)
+			continue
+		}
+		if sbom != nil {
+			sbomList = append(sbomList, sbom)
+		}
+	}
+
+	if len(sbomList) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in container %s/%s", config.ContainerName, config.Prefix))
+	}
+	return NewAzureBlobIterator(sbomList), nil
+}
+
+// openContainer creates the client and checks the container is accessible. It returns the
+// prefix to list, ending with "/" when set.
+func openContainer(ctx tcontext.TransferMetadata, config *AzureBlobConfig) (*azblob.Client, string, error) {
+	client, err := config.GetAzureClient(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create Azure Blob Storage client: %w", err)
+	}
+
+	// add "/" to prefix if not present in the end
+	prefix := config.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix = prefix + "/"
+	}
+
+	// a container-scoped SAS can't read the container properties, so probe with a one-blob listing
+	pager := client.NewListBlobsFlatPager(config.ContainerName, &azblob.ListBlobsFlatOptions{MaxResults: to.Ptr(int32(1))})
+	if _, err := pager.NextPage(ctx.Context); err != nil {
+		if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+			return nil, "", fmt.Errorf("container %q does not exist", config.ContainerName)
+		}
+		return nil, "", fmt.Errorf("failed to access container %q: %w", config.ContainerName, err)
+	}
+
+	return client, prefix, nil
+}
+
+// listBlobs lists every blob under prefix, following the listing pages (at most 5000 blobs
+// per page), and returns them with the set of listed names
+func listBlobs(ctx tcontext.TransferMetadata, client *azblob.Client, containerName, prefix string) ([]*container.BlobItem, map[string]bool, error) {
+	var blobs []*container.BlobItem
+	names := map[string]bool{}
+
+	pager := client.NewListBlobsFlatPager(containerName, &azblob.ListBlobsFlatOptions{Prefix: to.Ptr(prefix)})
+	for page := 1; pager.More(); page++ {
+		resp, err := pager.NextPage(ctx.Context)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list blobs: %w", err)
+		}
+
+		for _, blob := range resp.Segment.BlobItems {
+			if blob.Name == nil {
+				continue
+			}
+			blobs = append(blobs, blob)
+			names[*blob.Name] = true
+		}
+		logger.LogDebug(ctx.Context, "Listed blobs page", "container", containerName, "prefix", prefix, "page", page, "blobs", len(resp.Segment.BlobItems), "total_so_far", len(blobs))
+	}
+
+	return blobs, names, nil
+}
+
+// fetchBlob downloads the blob and builds its SBOM. It returns nil without an error when
+// the blob isn't an SBOM.
+func fetchBlob(ctx tcontext.TransferMetadata, client *azblob.Client, config *AzureBlobConfig, prefix, name string, names map[string]bool) (*iterator.SBOM, error) {
+	content, err := downloadBlob(ctx, client, config.ContainerName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// check whether it's a SBOM content or not
+	if !source.IsSBOM(ctx, name, content) {
+		logger.LogDebug(ctx.Context, "Skipping invalid SBOM", "name", name, "content_sample", string(content[:min(100, len(content))]))
+		return nil, nil
+	}
+
+	annotations, err := fetchSidecar(ctx, client, config.ContainerName, name, names)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata file for %s: %w", name, err)
+	}
+
+	logger.LogDebug(ctx.Context, "Fetched SBOM", "name", name, "size", len(content))
+	return newBlobSBOM(ctx, config, strings.TrimPrefix(name, prefix), content, annotations), nil
+}
+
+// fetchSidecar downloads and parses the metadata file of the blob. It returns nil when the
+// listing has no metadata file for it.
+func fetchSidecar(ctx tcontext.TransferMetadata, client *azblob.Client, containerName, name string, names map[string]bool) (*iterator.Annotations, error) {
+	for _, sidecar := range source.SidecarNames(name) {
+		if !names[sidecar] {
+			continue
+		}
+
+		data, err := downloadBlob(ctx, client, containerName, sidecar)
+		if err != nil {
+			return nil, err
+		}
+
+		annotations, err := source.ParseSidecar(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", sidecar, err)
+		}
+		return annotations, nil
+	}
+	return nil, nil
+}
+
+func downloadBlob(ctx tcontext.TransferMetadata, client *azblob.Client, containerName, name string) ([]byte, error) {
+	resp, err := client.DownloadStream(ctx.Context, containerName, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return content, nil
+}
+
+// newBlobSBOM builds the SBOM for a blob, named relative to the prefix. The namespace is
+// container and prefix, unless a namespace template derives namespace and version from the name.
+func newBlobSBOM(ctx tcontext.TransferMetadata, config *AzureBlobConfig, relName string, content []byte, annotations *iterator.Annotations) *iterator.SBOM {
+	sbom := &iterator.SBOM{
+		Path:        relName,
+		Data:        content,
+		Namespace:   config.ContainerName + "-" + config.Prefix,
+		Annotations: annotations,
+	}
+
+	if config.NamespaceTemplate == nil {
+		return sbom
+	}
+
+	namespace, version, ok := config.NamespaceTemplate.Apply(strings.TrimPrefix(relName, "/"))
+	if !ok {
+		logger.LogDebug(ctx.Context, "Blob name doesn't match namespace template, keeping default namespace", "name", relName)
+		return sbom
+	}
+
+	sbom.Namespace = namespace
+	sbom.Version = version
+	sbom.ExplicitNamespace = true
+	logger.LogDebug(ctx.Context, "Namespace derived from template", "name", relName, "namespace", namespace, "version", version)
+	return sbom
+}
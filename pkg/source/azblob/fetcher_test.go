@@ -0,0 +1,185 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const appSBOM = `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"metadata":{"component":{"type":"application","name":"app","version":"1.0.0"}}}`
+
+// blobService serves container "sboms" of account "acct" the way Azurite does, listing two
+// blobs per page. Requests must carry the container's SAS signature.
+func blobService(t *testing.T, blobs map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fail := func(status int, code string) {
+			w.Header().Set("x-ms-error-code", code)
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><Error><Code>%s</Code></Error>`, code)
+		}
+		if r.URL.Query().Get("sig") != "secret" {
+			fail(http.StatusForbidden, "AuthenticationFailed")
+			return
+		}
+
+		containerName, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/acct/"), "/")
+		if containerName != "sboms" {
+			fail(http.StatusNotFound, "ContainerNotFound")
+			return
+		}
+
+		if name == "" && r.URL.Query().Get("comp") == "list" {
+			prefix := r.URL.Query().Get("prefix")
+			var names []string
+			for n := range blobs {
+				if strings.HasPrefix(n, prefix) {
+					names = append(names, n)
+				}
+			}
+			sort.Strings(names)
+
+			start, _ := strconv.Atoi(r.URL.Query().Get("marker"))
+			size := 2
+			if n, _ := strconv.Atoi(r.URL.Query().Get("maxresults")); n > 0 {
+				size = n
+			}
+			end := min(start+size, len(names))
+
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults ServiceEndpoint="%s/acct/" ContainerName="sboms"><Prefix>%s</Prefix><Blobs>`, "http://"+r.Host, prefix)
+			for _, n := range names[start:end] {
+				fmt.Fprint(w, "<Blob><Name>")
+				xml.EscapeText(w, []byte(n))
+				fmt.Fprintf(w, "</Name><Properties><Content-Length>%d</Content-Length><BlobType>BlockBlob</BlobType></Properties></Blob>", len(blobs[n]))
+			}
+			fmt.Fprint(w, "</Blobs><NextMarker>")
+			if end < len(names) {
+				fmt.Fprint(w, end)
+			}
+			fmt.Fprint(w, "</NextMarker></EnumerationResults>")
+			return
+		}
+
+		data, ok := blobs[name]
+		if !ok {
+			fail(http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("x-ms-blob-type", "BlockBlob")
+		io.WriteString(w, data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func drain(t *testing.T, ctx tcontext.TransferMetadata, it iterator.SBOMIterator) []*iterator.SBOM {
+	t.Helper()
+	var sboms []*iterator.SBOM
+	for {
+		sbom, err := it.Next(ctx)
+		if err == io.EOF {
+			return sboms
+		}
+		require.NoError(t, err)
+		sboms = append(sboms, sbom)
+	}
+}
+
+func TestFetchBlobs(t *testing.T) {
+	blobs := map[string]string{
+		"prod/app/1.0/bom.json":           appSBOM,
+		"prod/app/1.0/bom.json.meta.yaml": "project_name: billing\nenvironment: production\n",
+		"prod/api/2.0/bom.json":           strings.Replace(appSBOM, `"app"`, `"api"`, 1),
+		"prod/notes.json":                 `{"not":"an sbom"}`,
+		"staging/app/bom.json":            appSBOM,
+	}
+
+	for _, fetcher := range []SBOMFetcher{&AzureBlobSequentialFetcher{}, &AzureBlobParallelFetcher{}} {
+		t.Run(fmt.Sprintf("%T", fetcher), func(t *testing.T) {
+			ctx := *tcontext.NewTransferMetadata(context.Background())
+			server := blobService(t, blobs)
+
+			template, err := source.ParseNamespaceTemplate(`^(?P<namespace>[^/]+)/(?P<version>[^/]+)/`)
+			require.NoError(t, err)
+			config := NewAzureBlobConfig()
+			config.EndpointURL = server.URL + "/acct"
+			config.ContainerName = "sboms"
+			config.Prefix = "prod"
+			config.SASToken = "?sv=2023-11-03&sig=secret"
+			config.NamespaceTemplate = template
+
+			it, err := fetcher.Fetch(ctx, config)
+			require.NoError(t, err)
+			sboms := drain(t, ctx, it)
+			sort.Slice(sboms, func(i, j int) bool { return sboms[i].Path < sboms[j].Path })
+
+			require.Len(t, sboms, 2, "the SBOMs under the prefix, not the sidecar nor the non-SBOM JSON")
+			assert.Equal(t, "api/2.0/bom.json", sboms[0].Path)
+			assert.Equal(t, "api", sboms[0].Namespace)
+			assert.Equal(t, "2.0", sboms[0].Version)
+			assert.Nil(t, sboms[0].Annotations)
+
+			assert.Equal(t, "app/1.0/bom.json", sboms[1].Path)
+			assert.Equal(t, "app", sboms[1].Namespace)
+			assert.True(t, sboms[1].ExplicitNamespace)
+			assert.JSONEq(t, appSBOM, string(sboms[1].Data))
+			assert.Equal(t, &iterator.Annotations{ProjectName: "billing", Environment: "production"}, sboms[1].Annotations)
+		})
+	}
+}
+
+func TestFetchBlobsErrors(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	server := blobService(t, map[string]string{"prod/notes.json": `{"not":"an sbom"}`})
+
+	tests := []struct {
+		name      string
+		container string
+		sasToken  string
+		err       string
+	}{
+		{"missing container", "other", "sig=secret", `container "other" does not exist`},
+		{"wrong signature", "sboms", "sig=wrong", `failed to access container "sboms"`},
+		{"no SBOMs", "sboms", "sig=secret", "no SBOMs found in container sboms/prod"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewAzureBlobConfig()
+			config.EndpointURL = server.URL + "/acct/"
+			config.ContainerName = tt.container
+			config.Prefix = "prod"
+			config.SASToken = tt.sasToken
+
+			_, err := (&AzureBlobSequentialFetcher{}).Fetch(ctx, config)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"fmt"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// FetchInterrupted is the error a fetcher returns when the transfer is cancelled part way.
+// It logs how many SBOMs were fetched before the fetch stopped.
+func FetchInterrupted(ctx tcontext.TransferMetadata, fetched int) error {
+	logger.LogInfo(ctx.Context, "Fetch interrupted", "fetched", fetched)
+	return fmt.Errorf("fetch interrupted after %d SBOMs: %w", fetched, ctx.Err())
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package source
+
+import (
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// detectionKey is the TransferMetadata key under which the engine stores the --detection mode
+const detectionKey = "detection"
+
+// detection is the --detection mode and the function files it rejects are reported to
+type detection struct {
+	mode     types.DetectionMode
+	onReject func(ctx tcontext.TransferMetadata, name, reason string)
+}
+
+// AttachDetection stores the --detection mode in the transfer context, with a function called
+// for every file strict detection rejects
+func AttachDetection(ctx *tcontext.TransferMetadata, mode types.DetectionMode, onReject func(ctx tcontext.TransferMetadata, name, reason string)) {
+	if mode != "" {
+		ctx.WithValue(detectionKey, detection{mode: mode, onReject: onReject})
+	}
+}
+
+// DetectionFromContext returns the --detection mode of the transfer, lenient by default
+func DetectionFromContext(ctx tcontext.TransferMetadata) types.DetectionMode {
+	if d, ok := ctx.Value(detectionKey).(detection); ok {
+		return d.mode
+	}
+	return types.DetectionLenient
+}
+
+// IsSBOM reports whether the content of the file, object or asset name is an SBOM. With
+// --detection=strict the spec must also be positively identified (see sbom.StrictDetect);
+// files rejected only by strict detection are logged and reported.
+func IsSBOM(ctx tcontext.TransferMetadata, name string, content []byte) bool {
+	if !IsSBOMFile(content) {
+		return false
+	}
+
+	d, _ := ctx.Value(detectionKey).(detection)
+	if d.mode != types.DetectionStrict {
+		return true
+	}
+
+	if _, _, err := sbomd.StrictDetect(content); err != nil {
+		logger.LogDebug(ctx.Context, "File rejected by strict detection", "name", name, "reason", err)
+		if d.onReject != nil {
+			d.onReject(ctx, name, err.Error())
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecr
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// reportFormats maps --in-ecr-format to the Inspector report formats
+var reportFormats = map[string]string{
+	"cyclonedx": "CYCLONEDX_1_4",
+	"spdx":      "SPDX_2_3",
+}
+
+// ECRAdapter collects the SBOMs Amazon Inspector exports for ECR container images
+type ECRAdapter struct {
+	Config         *ECRConfig
+	Role           types.AdapterRole // "input" or "output" adapter type
+	ProcessingMode types.ProcessingMode
+	Fetcher        SBOMFetcher
+}
+
+// AddCommandParams adds ECR-specific CLI flags
+func (e *ECRAdapter) AddCommandParams(cmd *cobra.Command) {
+	cmd.Flags().String("in-ecr-region", "", "AWS region of the ECR registry")
+	cmd.Flags().StringSlice("in-ecr-repositories", nil, "ECR repositories to export SBOMs of, a trailing '*' matches by prefix (default: all repositories)")
+	cmd.Flags().StringSlice("in-ecr-image-tags", nil, "Only export SBOMs of images with these tags (default: all images)")
+	cmd.Flags().String("in-ecr-format", "cyclonedx", "Format Inspector exports the SBOMs in (cyclonedx, spdx)")
+	cmd.Flags().String("in-ecr-export-bucket", "", "S3 bucket Inspector writes the SBOM export to")
+	cmd.Flags().String("in-ecr-export-prefix", "sbommv", "Key prefix of the SBOM export, each run exports below <prefix>/<run id>")
+	cmd.Flags().String("in-ecr-kms-key-arn", "", "KMS key Inspector encrypts the SBOM export with")
+	cmd.Flags().String("in-ecr-report-id", "", "Collect an existing Inspector SBOM export instead of starting a new one")
+	cmd.Flags().Duration("in-ecr-export-timeout", 30*time.Minute, "How long to wait for the SBOM export to finish")
+	cmd.Flags().String("in-ecr-access-key", "", "AWS access key")
+	cmd.Flags().String("in-ecr-secret-key", "", "AWS secret key")
+}
+
+// ParseAndValidateParams validates the ECR adapter params
+func (e *ECRAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	var (
+		regionFlag, repositoriesFlag, imageTagsFlag, formatFlag, bucketFlag, prefixFlag string
+		kmsKeyFlag, reportIDFlag, timeoutFlag, accessKeyFlag, secretKeyFlag             string
+		missingFlags                                                                    []string
+		invalidFlags                                                                    []string
+	)
+
+	regionFlag = "in-ecr-region"
+	repositoriesFlag = "in-ecr-repositories"
+	imageTagsFlag = "in-ecr-image-tags"
+	formatFlag = "in-ecr-format"
+	bucketFlag = "in-ecr-export-bucket"
+	prefixFlag = "in-ecr-export-prefix"
+	kmsKeyFlag = "in-ecr-kms-key-arn"
+	reportIDFlag = "in-ecr-report-id"
+	timeoutFlag = "in-ecr-export-timeout"
+	accessKeyFlag = "in-ecr-access-key"
+	secretKeyFlag = "in-ecr-secret-key"
+
+	var fetcher SBOMFetcher
+	if e.ProcessingMode == types.FetchSequential {
+		fetcher = &ECRSequentialFetcher{}
+	} else if e.ProcessingMode == types.FetchParallel {
+		fetcher = &ECRParallelFetcher{}
+	} else {
+		return fmt.Errorf("unsupported processing mode: %s", e.ProcessingMode)
+	}
+
+	// validate flags for ECR adapter, all flags should start with "in-ecr-"
+	err := utils.FlagValidation(cmd, types.ECRAdapterType, types.InputAdapterFlagPrefix)
+	if err != nil {
+		return fmt.Errorf("ecr flag validation failed: %w", err)
+	}
+
+	region, _ := cmd.Flags().GetString(regionFlag)
+	if region == "" {
+		// set default as us-east-1
+		region = "us-east-1"
+	}
+
+	repositories, _ := cmd.Flags().GetStringSlice(repositoriesFlag)
+	imageTags, _ := cmd.Flags().GetStringSlice(imageTagsFlag)
+
+	format, _ := cmd.Flags().GetString(formatFlag)
+	reportFormat, ok := reportFormats[strings.ToLower(format)]
+	if !ok {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be cyclonedx or spdx)", formatFlag, format))
+	}
+
+	bucket, _ := cmd.Flags().GetString(bucketFlag)
+	prefix, _ := cmd.Flags().GetString(prefixFlag)
+	kmsKeyARN, _ := cmd.Flags().GetString(kmsKeyFlag)
+
+	// an existing export already has its destination, a new one needs bucket and key
+	reportID, _ := cmd.Flags().GetString(reportIDFlag)
+	if reportID == "" {
+		if bucket == "" {
+			missingFlags = append(missingFlags, bucketFlag)
+		}
+		if kmsKeyARN == "" {
+			missingFlags = append(missingFlags, kmsKeyFlag)
+		}
+	} else if len(repositories) > 0 || len(imageTags) > 0 {
+		logger.LogInfo(cmd.Context(), "Collecting existing SBOM export, repository and tag filters are ignored", "report_id", reportID)
+	}
+
+	timeout, _ := cmd.Flags().GetDuration(timeoutFlag)
+	if timeout <= 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be greater than 0)", timeoutFlag, timeout))
+	}
+
+	accessKey, _ := cmd.Flags().GetString(accessKeyFlag)
+	secretKey, _ := cmd.Flags().GetString(secretKeyFlag)
+
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing flags: %s", strings.Join(missingFlags, ", "))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid input adapter flag usage:\n %s\n\nUse 'sbommv transfer --help' for correct usage.", strings.Join(invalidFlags, "\n "))
+	}
+
+	cfg := NewECRConfig()
+	cfg.Region = region
+	cfg.Repositories = repositories
+	cfg.ImageTags = imageTags
+	cfg.Format = reportFormat
+	cfg.Bucket = bucket
+	cfg.Prefix = prefix
+	cfg.KMSKeyARN = kmsKeyARN
+	cfg.ReportID = reportID
+	cfg.Timeout = timeout
+	cfg.AccessKey = accessKey
+	cfg.SecretKey = secretKey
+	cfg.ProcessingMode = e.ProcessingMode
+
+	e.Config = cfg
+	e.Fetcher = fetcher
+
+	return nil
+}
+
+func (e *ECRAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Initializing SBOM fetching", "mode", e.ProcessingMode)
+	return e.Fetcher.Fetch(ctx, e.Config)
+}
+
+func (e *ECRAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	return fmt.Errorf("ECR adapter does not support SBOM uploading")
+}
+
+func (e *ECRAdapter) DryRun(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	reporter := NewECRReporter(false, "", e.Config.Region)
+	return reporter.DryRun(ctx, iterator)
+}
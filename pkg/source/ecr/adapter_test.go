@@ -0,0 +1,82 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseFlags returns the adapter configured from the given flags, and the validation error
+func parseFlags(t *testing.T, mode types.ProcessingMode, args ...string) (*ECRAdapter, error) {
+	t.Helper()
+	adapter := &ECRAdapter{Role: types.InputAdapterRole, ProcessingMode: mode}
+	cmd := &cobra.Command{Use: "transfer"}
+	cmd.Flags().String("input-adapter", "ecr", "")
+	cmd.Flags().String("in-s3-bucket-name", "", "flag of another input adapter")
+	adapter.AddCommandParams(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetContext(context.Background())
+	return adapter, adapter.ParseAndValidateParams(cmd)
+}
+
+func TestParseAndValidateParams(t *testing.T) {
+	newExport := []string{"--in-ecr-export-bucket=sbom-exports", "--in-ecr-kms-key-arn=arn:aws:kms:eu-west-1:123456789012:key/abcd"}
+
+	tests := []struct {
+		name    string
+		args    []string
+		invalid string
+	}{
+		{"new export without bucket and key", nil, "missing flags: in-ecr-export-bucket, in-ecr-kms-key-arn"},
+		{"new export without key", []string{"--in-ecr-export-bucket=sbom-exports"}, "missing flags: in-ecr-kms-key-arn"},
+		{"unknown format", append([]string{"--in-ecr-format=swid"}, newExport...), "--in-ecr-format=swid (must be cyclonedx or spdx)"},
+		{"zero timeout", append([]string{"--in-ecr-export-timeout=0s"}, newExport...), "--in-ecr-export-timeout=0s (must be greater than 0)"},
+		{"flag of another adapter", append([]string{"--in-s3-bucket-name=sboms"}, newExport...), "flag --in-s3-bucket-name is invalid"},
+		{"new export", newExport, ""},
+		{"existing export", []string{"--in-ecr-report-id=0f3b8c1e-6d2a-4c8e-9a51-2b7f2d4e9c10"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFlags(t, types.FetchSequential, tt.args...)
+			if tt.invalid != "" {
+				assert.ErrorContains(t, err, tt.invalid)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	_, err := parseFlags(t, "stream", newExport...)
+	assert.ErrorContains(t, err, "unsupported processing mode")
+}
+
+func TestParseAndValidateParamsDefaults(t *testing.T) {
+	adapter, err := parseFlags(t, types.FetchParallel, "--in-ecr-export-bucket=sbom-exports", "--in-ecr-kms-key-arn=arn:aws:kms:us-east-1:123456789012:key/abcd", "--in-ecr-format=SPDX", "--in-ecr-repositories=team-*,api")
+	require.NoError(t, err)
+
+	assert.Equal(t, "us-east-1", adapter.Config.Region)
+	assert.Equal(t, "SPDX_2_3", adapter.Config.Format)
+	assert.Equal(t, "sbommv", adapter.Config.Prefix)
+	assert.Equal(t, 30*time.Minute, adapter.Config.Timeout)
+	assert.Equal(t, []string{"team-*", "api"}, adapter.Config.Repositories)
+	assert.IsType(t, &ECRParallelFetcher{}, adapter.Fetcher)
+}
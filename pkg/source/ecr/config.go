@@ -0,0 +1,71 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecr
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+type ECRConfig struct {
+	Region       string
+	Repositories []string // repository names, a trailing "*" matches by prefix; empty means all
+	ImageTags    []string // image tags, empty means all
+	Format       string   // Inspector report format, CYCLONEDX_1_4 or SPDX_2_3
+
+	// S3 location Inspector writes the export to
+	Bucket    string
+	Prefix    string
+	KMSKeyARN string
+
+	// ReportID collects an existing export instead of triggering a new one
+	ReportID string
+	Timeout  time.Duration // how long to wait for the export to finish
+
+	AccessKey      string
+	SecretKey      string
+	ProcessingMode types.ProcessingMode
+}
+
+func NewECRConfig() *ECRConfig {
+	return &ECRConfig{
+		ProcessingMode: types.FetchSequential,
+	}
+}
+
+// awsConfig loads the AWS config for the Inspector and S3 clients, with static credentials
+// when given and the default credential chain otherwise
+func (c *ECRConfig) awsConfig(ctx tcontext.TransferMetadata) (aws.Config, error) {
+	opts := []func(*config.LoadOptions) error{config.WithRegion(c.Region)}
+	if c.AccessKey != "" && c.SecretKey != "" {
+		creds := aws.Credentials{
+			AccessKeyID:     c.AccessKey,
+			SecretAccessKey: c.SecretKey,
+		}
+		opts = append(opts, config.WithCredentialsProvider(aws.NewCredentialsCache(credentials.StaticCredentialsProvider{Value: creds})))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx.Context, opts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,282 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecr
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// exportPollInterval is how often the status of a running export is checked
+const exportPollInterval = 10 * time.Second
+
+type SBOMFetcher interface {
+	Fetch(ctx tcontext.TransferMetadata, config *ECRConfig) (iterator.SBOMIterator, error)
+}
+
+type (
+	ECRSequentialFetcher struct{}
+	ECRParallelFetcher   struct{}
+)
+
+// exportedObjects is the S3 location of a finished export and the objects in it
+type exportedObjects struct {
+	client *s3.Client
+	bucket string
+	prefix string
+	keys   []string
+}
+
+// Fetch downloads the exported SBOMs one by one
+func (f *ECRSequentialFetcher) Fetch(ctx tcontext.TransferMetadata, config *ECRConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Sequentially")
+
+	export, err := runExport(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var sbomList []*iterator.SBOM
+	for _, key := range export.keys {
+		if ctx.Err() != nil {
+			return nil, source.FetchInterrupted(ctx, len(sbomList))
+		}
+
+		sbom, err := export.fetchSBOM(ctx, key)
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Failed to fetch exported SBOM", "key", key, "error", err)
+			continue
+		}
+		if sbom != nil {
+			sbomList = append(sbomList, sbom)
+		}
+	}
+
+	if len(sbomList) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in export s3://%s/%s", export.bucket, export.prefix))
+	}
+	return NewECRIterator(sbomList), nil
+}
+
+// Fetch downloads the exported SBOMs with a few concurrent downloads
+func (f *ECRParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *ECRConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Concurrently")
+
+	export, err := runExport(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var sbomList []*iterator.SBOM
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	const maxConcurrency = 3
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	for _, key := range export.keys {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			sbom, err := export.fetchSBOM(ctx, key)
+			if err != nil {
+				logger.LogDebug(ctx.Context, "Failed to fetch exported SBOM", "key", key, "error", err)
+				return
+			}
+			if sbom == nil {
+				return
+			}
+
+			mu.Lock()
+			sbomList = append(sbomList, sbom)
+			mu.Unlock()
+		}(key)
+	}
+
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, source.FetchInterrupted(ctx, len(sbomList))
+	}
+	if len(sbomList) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in export s3://%s/%s", export.bucket, export.prefix))
+	}
+	return NewECRIterator(sbomList), nil
+}
+
+// runExport triggers an Inspector SBOM export, or picks up the one given by --in-ecr-report-id,
+// waits for it to finish and lists the objects it wrote
+func runExport(ctx tcontext.TransferMetadata, config *ECRConfig) (*exportedObjects, error) {
+	awsCfg, err := config.awsConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inspector := newInspectorClient(awsCfg)
+
+	reportID := config.ReportID
+	if reportID == "" {
+		reportID, err = inspector.CreateSbomExport(ctx, exportInput(ctx, config))
+		if err != nil {
+			return nil, err
+		}
+		logger.LogInfo(ctx.Context, "Started Inspector SBOM export", "report_id", reportID, "repositories", config.Repositories, "format", config.Format)
+	}
+
+	export, err := waitForExport(ctx, inspector, reportID, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.NewFromConfig(awsCfg)
+	objects := &exportedObjects{
+		client: client,
+		bucket: export.S3Destination.BucketName,
+		prefix: export.S3Destination.KeyPrefix,
+	}
+
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(objects.bucket),
+		Prefix: aws.String(objects.prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx.Context)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list export objects in s3://%s/%s: %w", objects.bucket, objects.prefix, err)
+		}
+		for _, obj := range page.Contents {
+			if source.AllowsFormatName(ctx, *obj.Key) {
+				objects.keys = append(objects.keys, *obj.Key)
+			}
+		}
+	}
+
+	logger.LogInfo(ctx.Context, "Inspector SBOM export ready", "report_id", reportID, "bucket", objects.bucket, "prefix", objects.prefix, "objects", len(objects.keys))
+	return objects, nil
+}
+
+// exportInput builds the export request. Each run exports under its own key prefix,
+// so only the SBOMs of this export are collected.
+func exportInput(ctx tcontext.TransferMetadata, config *ECRConfig) createSbomExportInput {
+	criteria := resourceFilterCriteria{
+		ResourceType: []stringFilter{{Comparison: "EQUALS", Value: "AWS_ECR_CONTAINER_IMAGE"}},
+	}
+	for _, repo := range config.Repositories {
+		if name, ok := strings.CutSuffix(repo, "*"); ok {
+			criteria.ECRRepositoryName = append(criteria.ECRRepositoryName, stringFilter{Comparison: "PREFIX", Value: name})
+			continue
+		}
+		criteria.ECRRepositoryName = append(criteria.ECRRepositoryName, stringFilter{Comparison: "EQUALS", Value: repo})
+	}
+	for _, tag := range config.ImageTags {
+		criteria.ECRImageTags = append(criteria.ECRImageTags, stringFilter{Comparison: "EQUALS", Value: tag})
+	}
+
+	runID := ctx.RunID()
+	if runID == "" {
+		runID = time.Now().UTC().Format("20060102T150405Z")
+	}
+
+	return createSbomExportInput{
+		ReportFormat:           config.Format,
+		ResourceFilterCriteria: criteria,
+		S3Destination: s3Destination{
+			BucketName: config.Bucket,
+			KeyPrefix:  path.Join(config.Prefix, runID),
+			KMSKeyARN:  config.KMSKeyARN,
+		},
+	}
+}
+
+// waitForExport polls the export until it succeeded, failed or the timeout passed
+func waitForExport(ctx tcontext.TransferMetadata, inspector *inspectorClient, reportID string, timeout time.Duration) (*sbomExport, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		export, err := inspector.GetSbomExport(ctx, reportID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch export.Status {
+		case exportSucceeded:
+			return export, nil
+		case exportFailed, exportCancelled:
+			return nil, fmt.Errorf("inspector SBOM export %s %s: %s %s", reportID, strings.ToLower(export.Status), export.ErrorCode, export.ErrorMessage)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("inspector SBOM export %s still %s after %s", reportID, export.Status, timeout)
+		}
+		logger.LogDebug(ctx.Context, "Waiting for Inspector SBOM export", "report_id", reportID, "status", export.Status)
+
+		select {
+		case <-ctx.Done():
+			return nil, source.FetchInterrupted(ctx, 0)
+		case <-time.After(exportPollInterval):
+		}
+	}
+}
+
+// fetchSBOM downloads an exported object. It returns nil for objects that aren't SBOMs.
+func (e *exportedObjects) fetchSBOM(ctx tcontext.TransferMetadata, key string) (*iterator.SBOM, error) {
+	resp, err := e.client.GetObject(ctx.Context, &s3.GetObjectInput{
+		Bucket: aws.String(e.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if !source.IsSBOM(ctx, key, content) {
+		logger.LogDebug(ctx.Context, "Skipping invalid SBOM", "key", key)
+		return nil, nil
+	}
+
+	// the namespace is the directory the export wrote the SBOM to, relative to the prefix
+	relKey := strings.TrimPrefix(strings.TrimPrefix(key, e.prefix), "/")
+	namespace := path.Dir(relKey)
+	if namespace == "." {
+		namespace = e.bucket + "-" + e.prefix
+	}
+
+	logger.LogDebug(ctx.Context, "Fetched SBOM", "key", key, "size", len(content))
+	return &iterator.SBOM{
+		Path:      strings.ReplaceAll(relKey, "/", "_"),
+		Data:      content,
+		Namespace: namespace,
+	}, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecr
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const apiSBOM = `{"bomFormat":"CycloneDX","specVersion":"1.4","version":1,"metadata":{"component":{"type":"container","name":"team-api","version":"1.2.0"}}}`
+
+// awsServer plays Inspector and the S3 bucket it exports to. The export succeeds at once,
+// writing the objects to bucket sbom-exports below the key prefix of the request.
+type awsServer struct {
+	*httptest.Server
+	objects map[string]string // key relative to the export prefix -> content
+	status  string
+
+	mu     sync.Mutex
+	export createSbomExportInput
+}
+
+func newAWSServer(t *testing.T, objects map[string]string) *awsServer {
+	s := &awsServer{objects: objects, status: exportSucceeded}
+	s.Server = httptest.NewServer(s)
+	t.Cleanup(s.Close)
+
+	// only the endpoint and the static credentials of the test are used
+	t.Setenv("AWS_ENDPOINT_URL", s.URL)
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/nonexistent")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	return s
+}
+
+func (s *awsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIATEST/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/reporting/sbom/create":
+		json.NewDecoder(r.Body).Decode(&s.export)
+		fmt.Fprint(w, `{"reportId":"report-1"}`)
+
+	case r.Method == http.MethodPost && r.URL.Path == "/reporting/sbom/get":
+		var in struct {
+			ReportID string `json:"reportId"`
+		}
+		json.NewDecoder(r.Body).Decode(&in)
+		json.NewEncoder(w).Encode(sbomExport{
+			ReportID: in.ReportID, Status: s.status, ErrorCode: "ACCESS_DENIED", ErrorMessage: "bucket policy",
+			S3Destination: s3Destination{BucketName: "sbom-exports", KeyPrefix: s.exportPrefix()},
+		})
+
+	case r.URL.Path == "/sbom-exports" && r.URL.Query().Get("list-type") == "2":
+		type object struct {
+			Key  string
+			Size int
+		}
+		list := struct {
+			XMLName     xml.Name `xml:"ListBucketResult"`
+			Name        string
+			Prefix      string
+			IsTruncated bool
+			Contents    []object
+		}{Name: "sbom-exports", Prefix: r.URL.Query().Get("prefix")}
+		for key, content := range s.objects {
+			list.Contents = append(list.Contents, object{Key: s.exportPrefix() + "/" + key, Size: len(content)})
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		xml.NewEncoder(w).Encode(list)
+
+	case strings.HasPrefix(r.URL.Path, "/sbom-exports/"+s.exportPrefix()+"/"):
+		content, ok := s.objects[strings.TrimPrefix(r.URL.Path, "/sbom-exports/"+s.exportPrefix()+"/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, content)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// exportPrefix is where the export was written, the prefix of an existing export otherwise
+func (s *awsServer) exportPrefix() string {
+	if s.export.S3Destination.KeyPrefix != "" {
+		return s.export.S3Destination.KeyPrefix
+	}
+	return "sbommv/existing"
+}
+
+func testConfig() *ECRConfig {
+	config := NewECRConfig()
+	config.Region = "eu-west-1"
+	config.Format = "CYCLONEDX_1_4"
+	config.Bucket = "sbom-exports"
+	config.Prefix = "sbommv"
+	config.KMSKeyARN = "arn:aws:kms:eu-west-1:123456789012:key/abcd"
+	config.Timeout = time.Minute
+	config.AccessKey, config.SecretKey = "AKIATEST", "secret"
+	return config
+}
+
+func drain(t *testing.T, ctx tcontext.TransferMetadata, it iterator.SBOMIterator) []*iterator.SBOM {
+	t.Helper()
+	var sboms []*iterator.SBOM
+	for {
+		sbom, err := it.Next(ctx)
+		if err == io.EOF {
+			sort.Slice(sboms, func(i, j int) bool { return sboms[i].Path < sboms[j].Path })
+			return sboms
+		}
+		require.NoError(t, err)
+		sboms = append(sboms, sbom)
+	}
+}
+
+func TestFetchExport(t *testing.T) {
+	for _, fetcher := range []SBOMFetcher{&ECRSequentialFetcher{}, &ECRParallelFetcher{}} {
+		t.Run(fmt.Sprintf("%T", fetcher), func(t *testing.T) {
+			server := newAWSServer(t, map[string]string{
+				"team-api/sha256_1111.json":    apiSBOM,
+				"team-worker/sha256_2222.json": strings.ReplaceAll(apiSBOM, "team-api", "team-worker"),
+				"team-api/export-errors.txt":   "image sha256:3333 has no packages",
+			})
+			ctx := tcontext.NewTransferMetadata(context.Background())
+			ctx.SetRunID("run-1")
+
+			config := testConfig()
+			config.Repositories = []string{"team-*", "payments"}
+			config.ImageTags = []string{"latest"}
+
+			it, err := fetcher.Fetch(*ctx, config)
+			require.NoError(t, err)
+			sboms := drain(t, *ctx, it)
+
+			require.Len(t, sboms, 2)
+			assert.Equal(t, "team-api_sha256_1111.json", sboms[0].Path)
+			assert.Equal(t, "team-api", sboms[0].Namespace)
+			assert.JSONEq(t, apiSBOM, string(sboms[0].Data))
+			assert.Equal(t, "team-worker", sboms[1].Namespace)
+
+			// the export request of the run
+			assert.Equal(t, "CYCLONEDX_1_4", server.export.ReportFormat)
+			assert.Equal(t, s3Destination{BucketName: "sbom-exports", KeyPrefix: "sbommv/run-1", KMSKeyARN: config.KMSKeyARN}, server.export.S3Destination)
+			assert.Equal(t, []stringFilter{{Comparison: "PREFIX", Value: "team-"}, {Comparison: "EQUALS", Value: "payments"}}, server.export.ResourceFilterCriteria.ECRRepositoryName)
+			assert.Equal(t, []stringFilter{{Comparison: "EQUALS", Value: "latest"}}, server.export.ResourceFilterCriteria.ECRImageTags)
+		})
+	}
+}
+
+func TestFetchExistingExport(t *testing.T) {
+	server := newAWSServer(t, map[string]string{"team-api/sha256_1111.json": apiSBOM})
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	config := testConfig()
+	config.ReportID = "report-0"
+	it, err := (&ECRSequentialFetcher{}).Fetch(ctx, config)
+	require.NoError(t, err)
+	assert.Len(t, drain(t, ctx, it), 1)
+	assert.Empty(t, server.export.ReportFormat, "no export is started")
+}
+
+func TestFetchFailedExport(t *testing.T) {
+	server := newAWSServer(t, nil)
+	server.status = exportFailed
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	_, err := (&ECRSequentialFetcher{}).Fetch(ctx, testConfig())
+	assert.ErrorContains(t, err, "inspector SBOM export report-1 failed: ACCESS_DENIED bucket policy")
+}
+
+func TestFetchEmptyExport(t *testing.T) {
+	newAWSServer(t, map[string]string{"team-api/export-errors.txt": "no packages"})
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	_, err := (&ECRParallelFetcher{}).Fetch(ctx, testConfig())
+	assert.ErrorContains(t, err, "no SBOMs found in export s3://sbom-exports/sbommv/")
+}
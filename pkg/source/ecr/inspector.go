@@ -0,0 +1,159 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// Inspector SBOM export statuses
+const (
+	exportInProgress = "IN_PROGRESS"
+	exportSucceeded  = "SUCCEEDED"
+	exportFailed     = "FAILED"
+	exportCancelled  = "CANCELLED"
+)
+
+// inspectorClient calls the two Amazon Inspector APIs the adapter needs, CreateSbomExport
+// and GetSbomExport, as signed REST requests
+type inspectorClient struct {
+	endpoint   string
+	region     string
+	creds      aws.CredentialsProvider
+	httpClient *http.Client
+}
+
+// newInspectorClient calls the Inspector endpoint of the region, or the endpoint set with
+// AWS_ENDPOINT_URL, which the S3 client of the export honors too
+func newInspectorClient(cfg aws.Config) *inspectorClient {
+	endpoint := fmt.Sprintf("https://inspector2.%s.amazonaws.com", cfg.Region)
+	if cfg.BaseEndpoint != nil {
+		endpoint = strings.TrimSuffix(*cfg.BaseEndpoint, "/")
+	}
+	return &inspectorClient{
+		endpoint:   endpoint,
+		region:     cfg.Region,
+		creds:      cfg.Credentials,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+type stringFilter struct {
+	Comparison string `json:"comparison"` // EQUALS, PREFIX or NOT_EQUALS
+	Value      string `json:"value"`
+}
+
+type resourceFilterCriteria struct {
+	ResourceType      []stringFilter `json:"resourceType,omitempty"`
+	ECRRepositoryName []stringFilter `json:"ecrRepositoryName,omitempty"`
+	ECRImageTags      []stringFilter `json:"ecrImageTags,omitempty"`
+}
+
+type s3Destination struct {
+	BucketName string `json:"bucketName"`
+	KeyPrefix  string `json:"keyPrefix,omitempty"`
+	KMSKeyARN  string `json:"kmsKeyArn,omitempty"`
+}
+
+type createSbomExportInput struct {
+	ReportFormat           string                 `json:"reportFormat"`
+	ResourceFilterCriteria resourceFilterCriteria `json:"resourceFilterCriteria"`
+	S3Destination          s3Destination          `json:"s3Destination"`
+}
+
+// sbomExport is the GetSbomExport response
+type sbomExport struct {
+	ReportID      string        `json:"reportId"`
+	Status        string        `json:"status"`
+	Format        string        `json:"format"`
+	ErrorCode     string        `json:"errorCode"`
+	ErrorMessage  string        `json:"errorMessage"`
+	S3Destination s3Destination `json:"s3Destination"`
+}
+
+// CreateSbomExport starts an export of the SBOMs of the resources matching the criteria
+// and returns its report ID
+func (c *inspectorClient) CreateSbomExport(ctx tcontext.TransferMetadata, input createSbomExportInput) (string, error) {
+	var out struct {
+		ReportID string `json:"reportId"`
+	}
+	if err := c.call(ctx, "/reporting/sbom/create", input, &out); err != nil {
+		return "", fmt.Errorf("creating SBOM export: %w", err)
+	}
+	return out.ReportID, nil
+}
+
+// GetSbomExport returns the status and destination of an SBOM export
+func (c *inspectorClient) GetSbomExport(ctx tcontext.TransferMetadata, reportID string) (*sbomExport, error) {
+	var out sbomExport
+	if err := c.call(ctx, "/reporting/sbom/get", map[string]string{"reportId": reportID}, &out); err != nil {
+		return nil, fmt.Errorf("getting SBOM export %s: %w", reportID, err)
+	}
+	return &out, nil
+}
+
+func (c *inspectorClient) call(ctx tcontext.TransferMetadata, path string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx.Context, http.MethodPost, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	creds, err := c.creds.Retrieve(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("retrieving AWS credentials: %w", err)
+	}
+	payloadHash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx.Context, creds, req, hex.EncodeToString(payloadHash[:]), "inspector2", c.region, time.Now()); err != nil {
+		return fmt.Errorf("signing request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &apiErr)
+		errType, _, _ := strings.Cut(resp.Header.Get("X-Amzn-Errortype"), ":")
+		return fmt.Errorf("inspector returned %s: %s %s", resp.Status, errType, apiErr.Message)
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecr
+
+import (
+	"io"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// ECRIterator implements SBOMIterator
+type ECRIterator struct {
+	sboms []*iterator.SBOM
+	index int
+}
+
+// NewECRIterator creates an ECR iterator
+func NewECRIterator(sboms []*iterator.SBOM) *ECRIterator {
+	return &ECRIterator{
+		sboms: sboms,
+		index: 0,
+	}
+}
+
+// Next yields the next SBOM
+func (it *ECRIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
+	if it.index >= len(it.sboms) {
+		return nil, io.EOF
+	}
+	sbom := it.sboms[it.index]
+	it.index++
+	return sbom, nil
+}
+
+// Total returns the number of SBOMs the iterator yields
+func (it *ECRIterator) Total() int {
+	return len(it.sboms)
+}
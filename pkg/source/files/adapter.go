@@ -0,0 +1,170 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package files
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// StdinPath names stdin among the files of the adapter
+const StdinPath = "-"
+
+// FilesAdapter reads the SBOMs given to `sbommv upload`: the files named on the command
+// line, or a single SBOM piped to stdin. It has no flags and isn't in the adapter catalog.
+type FilesAdapter struct {
+	Role  types.AdapterRole // only "input" is supported
+	Paths []string          // files read in order, StdinPath reads Stdin in its place
+	Stdin io.Reader
+}
+
+// NewFilesAdapter returns the input adapter reading paths, or stdin when there are none
+func NewFilesAdapter(paths []string, stdin io.Reader) *FilesAdapter {
+	if len(paths) == 0 {
+		paths = []string{StdinPath}
+	}
+	return &FilesAdapter{Role: types.InputAdapterRole, Paths: paths, Stdin: stdin}
+}
+
+// AddCommandParams adds no flags, the files are the command's arguments
+func (f *FilesAdapter) AddCommandParams(cmd *cobra.Command) {}
+
+// ParseAndValidateParams checks the files exist before anything is uploaded
+func (f *FilesAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	if f.Role != types.InputAdapterRole {
+		return fmt.Errorf("The Files adapter doesn't support output adapter functionalities.")
+	}
+
+	var stdin int
+	var missing, invalid []string
+	for _, path := range f.Paths {
+		if path == StdinPath {
+			stdin++
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			missing = append(missing, path)
+		} else if info.IsDir() {
+			invalid = append(invalid, fmt.Sprintf("%s is a directory, use --input-adapter=folder with 'sbommv transfer' for folders", path))
+		}
+	}
+	if stdin > 1 {
+		invalid = append(invalid, fmt.Sprintf("%q is given %d times, stdin holds a single SBOM", StdinPath, stdin))
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid files:\n %s", strings.Join(invalid, "\n "))
+	}
+	if len(missing) > 0 {
+		return errdefs.NotFound(fmt.Errorf("files not found: %v", missing))
+	}
+	return nil
+}
+
+// FetchSBOMs returns an iterator reading the files one by one as they are uploaded
+func (f *FilesAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Reading SBOMs from files", "files", f.Paths)
+	return &filesIterator{paths: f.Paths, stdin: f.Stdin}, nil
+}
+
+// UploadSBOMs is not supported, the Files adapter is an input only
+func (f *FilesAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
+	return fmt.Errorf("Files adapter does not support SBOM uploading")
+}
+
+// DryRun lists the SBOMs read from the files
+func (f *FilesAdapter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Dry-run mode: Displaying SBOMs read from files")
+	fmt.Println("\n📦 Details of all SBOMs read by the Files Input Adapter")
+
+	count := 0
+	for {
+		doc, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
+			return err
+		}
+
+		spec, version := sbom.DetectSpecVersion(doc.Data)
+		count++
+		fmt.Printf(" - 📄 File: %s | Format: %s | SpecVersion: %s\n", displayName(doc), spec, version)
+	}
+	fmt.Printf("📊 Total SBOMs: %d\n", count)
+	return nil
+}
+
+// filesIterator reads the SBOM of each file when it is asked for
+type filesIterator struct {
+	paths []string
+	stdin io.Reader
+	index int
+}
+
+func (it *filesIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if it.index >= len(it.paths) {
+		return nil, io.EOF
+	}
+	path := it.paths[it.index]
+	it.index++
+
+	doc := &iterator.SBOM{Namespace: filepath.Dir(path), Path: filepath.Base(path)}
+	var err error
+	if path == StdinPath {
+		// stdin has no name, destinations name the SBOM after its content
+		doc.Namespace, doc.Path = "stdin", ""
+		doc.Data, err = io.ReadAll(it.stdin)
+	} else {
+		doc.Data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", displayName(doc), err)
+	}
+
+	if !source.IsSBOM(ctx, displayName(doc), doc.Data) {
+		return nil, fmt.Errorf("%s is not an SBOM", displayName(doc))
+	}
+	return doc, nil
+}
+
+// Total returns the number of SBOMs the iterator yields
+func (it *filesIterator) Total() int {
+	return len(it.paths)
+}
+
+func displayName(doc *iterator.SBOM) string {
+	if doc.Path == "" {
+		return "stdin"
+	}
+	return filepath.Join(doc.Namespace, doc.Path)
+}
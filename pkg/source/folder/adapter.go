@@ -20,6 +20,7 @@ import (
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 	"github.com/interlynk-io/sbommv/pkg/types"
 	"github.com/interlynk-io/sbommv/pkg/utils"
@@ -37,20 +38,34 @@ type FolderAdapter struct {
 func (f *FolderAdapter) AddCommandParams(cmd *cobra.Command) {
 	cmd.Flags().String("in-folder-path", "", "Folder path")
 	cmd.Flags().Bool("in-folder-recursive", false, "Folder recurssive (default: false)")
+	cmd.Flags().Int("in-folder-max-depth", 0, "With --in-folder-recursive, read subdirectories at most this many levels below the folder (0: no limit)")
+	cmd.Flags().Bool("in-folder-follow-symlinks", false, "Read symlinked directories as if they were in the folder, e.g. SBOM archives linked into a staging directory; symlinked files are always read")
+	cmd.Flags().Duration("in-folder-settle-period", defaultSettlePeriod, "In daemon mode, how long a new or changed file must see no writes before it is read, so half-written SBOMs aren't (0: read right away)")
+	cmd.Flags().Bool("in-folder-no-cache", false, "In daemon mode, transfer the SBOMs already in the folder on every start instead of skipping those cached in .sbommv as transferred")
+	cmd.Flags().StringSlice("in-folder-include-pattern", nil, "Transfer only files matching these patterns: globs on the file name, or on the relative path when containing '/', or 'regex:<expr>', e.g. '*.cdx.json'")
+	cmd.Flags().StringSlice("in-folder-exclude-pattern", nil, "Skip files matching these patterns, with the same syntax as --in-folder-include-pattern, e.g. '*.spdx.json'")
+	cmd.Flags().String("in-folder-namespace-template", "", "Regex with capture groups deriving namespace and version from the file path relative to the folder, e.g. '^(?P<namespace>[^/]+)/(?P<version>[^/]+)/'")
 }
 
 // ParseAndValidateParams validates the Folder adapter params
 func (f *FolderAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	var (
-		pathFlag, recursiveFlag string
-		missingFlags            []string
-		invalidFlags            []string
+		pathFlag, recursiveFlag, maxDepthFlag, followSymlinksFlag, settlePeriodFlag, noCacheFlag, namespaceTemplateFlag, includeFlag, excludeFlag string
+		missingFlags                                                                                                                              []string
+		invalidFlags                                                                                                                              []string
 	)
 
 	switch f.Role {
 	case types.InputAdapterRole:
 		pathFlag = "in-folder-path"
 		recursiveFlag = "in-folder-recursive"
+		maxDepthFlag = "in-folder-max-depth"
+		followSymlinksFlag = "in-folder-follow-symlinks"
+		settlePeriodFlag = "in-folder-settle-period"
+		noCacheFlag = "in-folder-no-cache"
+		namespaceTemplateFlag = "in-folder-namespace-template"
+		includeFlag = "in-folder-include-pattern"
+		excludeFlag = "in-folder-exclude-pattern"
 
 	case types.OutputAdapterRole:
 		return fmt.Errorf("The Folder adapter doesn't support output adapter functionalities.")
@@ -75,6 +90,41 @@ func (f *FolderAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	// Extract Folder Path
 	folderRecurse, _ := cmd.Flags().GetBool(recursiveFlag)
 
+	// Extract traversal depth and symlink following
+	maxDepth, _ := cmd.Flags().GetInt(maxDepthFlag)
+	if maxDepth < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%d (must be 0 or more)", maxDepthFlag, maxDepth))
+	} else if maxDepth > 0 && !folderRecurse {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s requires --%s", maxDepthFlag, recursiveFlag))
+	}
+	followSymlinks, _ := cmd.Flags().GetBool(followSymlinksFlag)
+
+	// Extract how long watched files settle before they are read
+	settlePeriod, _ := cmd.Flags().GetDuration(settlePeriodFlag)
+	if settlePeriod < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be 0 or more)", settlePeriodFlag, settlePeriod))
+	}
+
+	// Extract whether the watcher remembers transferred files across restarts
+	noCache, _ := cmd.Flags().GetBool(noCacheFlag)
+
+	// Extract namespace template
+	var namespaceTemplate *source.NamespaceTemplate
+	if template, _ := cmd.Flags().GetString(namespaceTemplateFlag); template != "" {
+		namespaceTemplate, err = source.ParseNamespaceTemplate(template)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s: %v", namespaceTemplateFlag, err))
+		}
+	}
+
+	// Extract include and exclude patterns
+	include, _ := cmd.Flags().GetStringSlice(includeFlag)
+	exclude, _ := cmd.Flags().GetStringSlice(excludeFlag)
+	filePatterns, err := source.ParseFilePatterns(include, exclude)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s/--%s: %v", includeFlag, excludeFlag, err))
+	}
+
 	// Validate required flags
 	if len(missingFlags) > 0 {
 		return fmt.Errorf("missing input adapter required flags: %v\n\nUse 'sbommv transfer --help' for usage details.", missingFlags)
@@ -97,10 +147,16 @@ func (f *FolderAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	}
 
 	cfg := FolderConfig{
-		FolderPath:     folderPath,
-		Recursive:      folderRecurse,
-		Daemon:         daemon,
-		ProcessingMode: f.Config.ProcessingMode,
+		FolderPath:        folderPath,
+		Recursive:         folderRecurse,
+		MaxDepth:          maxDepth,
+		FollowSymlinks:    followSymlinks,
+		Daemon:            daemon,
+		SettlePeriod:      settlePeriod,
+		NoCache:           noCache,
+		ProcessingMode:    f.Config.ProcessingMode,
+		NamespaceTemplate: namespaceTemplate,
+		FilePatterns:      filePatterns,
 	}
 
 	f.Config = &cfg
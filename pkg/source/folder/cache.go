@@ -0,0 +1,109 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package folder
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/interlynk-io/sbommv/pkg/cache"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// CacheName is the cache store of the folder watcher for an output adapter
+func CacheName(outputAdapter string) string {
+	return fmt.Sprintf("cache_%s_folder", outputAdapter)
+}
+
+// Cache records the files the watcher has sent, with the hash of their content, so restarts
+// don't transfer them again and files are transferred again only when they change. Files
+// are keyed by their path relative to the watched folder, in a namespace per folder.
+type Cache struct {
+	store     cache.Store
+	namespace string
+	folder    string
+}
+
+// OpenCache opens, or creates, the cache of the output adapter and returns the files of the
+// folder it records, by full path, with their content hash
+func OpenCache(ctx tcontext.TransferMetadata, outputAdapter, folder string) (*Cache, map[string]string, error) {
+	store, err := cache.Open(ctx, CacheName(outputAdapter))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	folder = filepath.Clean(folder)
+	c := &Cache{store: store, namespace: "folder/" + filepath.ToSlash(folder), folder: folder}
+
+	entries, err := store.Entries(ctx.Context, c.namespace)
+	if err != nil {
+		store.Close()
+		return nil, nil, err
+	}
+
+	files := make(map[string]string, len(entries))
+	for relPath, hash := range entries {
+		files[filepath.Join(folder, filepath.FromSlash(relPath))] = string(hash)
+	}
+
+	logger.LogDebug(ctx.Context, "Loaded folder cache", "store", CacheName(outputAdapter), "folder", folder, "files", len(files))
+	return c, files, nil
+}
+
+// MarkProcessed records the file as sent with this content hash. A nil cache records nothing.
+func (c *Cache) MarkProcessed(ctx tcontext.TransferMetadata, fullPath, hash string) error {
+	if c == nil {
+		return nil
+	}
+
+	if err := c.store.Put(ctx.Context, c.namespace, c.relPath(fullPath), []byte(hash)); err != nil {
+		return fmt.Errorf("failed to save file %s to cache: %w", fullPath, err)
+	}
+	return nil
+}
+
+// Forget drops the file at fullPath and, when it was a directory, every file below it.
+// A nil cache records nothing.
+func (c *Cache) Forget(ctx tcontext.TransferMetadata, fullPath string) error {
+	if c == nil {
+		return nil
+	}
+
+	relPath := c.relPath(fullPath)
+	if err := c.store.DeletePrefix(ctx.Context, c.namespace, relPath+"/"); err != nil {
+		return fmt.Errorf("failed to remove %s from cache: %w", fullPath, err)
+	}
+	if err := c.store.Delete(ctx.Context, c.namespace, relPath); err != nil {
+		return fmt.Errorf("failed to remove %s from cache: %w", fullPath, err)
+	}
+	return nil
+}
+
+// Close closes the cache store. A nil cache has nothing to close.
+func (c *Cache) Close() error {
+	if c == nil {
+		return nil
+	}
+	return c.store.Close()
+}
+
+func (c *Cache) relPath(fullPath string) string {
+	relPath, err := filepath.Rel(c.folder, fullPath)
+	if err != nil {
+		relPath = fullPath
+	}
+	return filepath.ToSlash(relPath)
+}
@@ -15,17 +15,47 @@
 
 package folder
 
-import "github.com/interlynk-io/sbommv/pkg/types"
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
 
 type FolderConfig struct {
-	FolderPath     string
-	Recursive      bool
-	ProcessingMode types.ProcessingMode
-	Daemon         bool
+	FolderPath        string
+	Recursive         bool
+	MaxDepth          int  // subdirectory levels read when recursive, 0 reads all
+	FollowSymlinks    bool // descend into symlinked directories
+	ProcessingMode    types.ProcessingMode
+	Daemon            bool
+	SettlePeriod      time.Duration // in daemon mode, how long files see no writes before they are read
+	NoCache           bool          // in daemon mode, don't remember the files transferred across restarts
+	NamespaceTemplate *source.NamespaceTemplate
+	FilePatterns      *source.FilePatterns // include and exclude patterns, nil selects every file
 }
 
+// defaultSettlePeriod is long enough for tools to finish writing or renaming an SBOM
+const defaultSettlePeriod = 2 * time.Second
+
 func NewFolderConfig() *FolderConfig {
 	return &FolderConfig{
 		ProcessingMode: types.FetchSequential, // Default
+		SettlePeriod:   defaultSettlePeriod,
+	}
+}
+
+// selected reports whether the file at fullPath matches the include and exclude patterns,
+// which apply to its path relative to the folder
+func (c *FolderConfig) selected(fullPath string) bool {
+	if c.FilePatterns == nil {
+		return true
+	}
+
+	relPath, err := filepath.Rel(c.FolderPath, fullPath)
+	if err != nil {
+		relPath = fullPath
 	}
+	return c.FilePatterns.Allows(filepath.ToSlash(relPath))
 }
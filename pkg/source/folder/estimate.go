@@ -0,0 +1,45 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package folder
+
+import (
+	"os"
+
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// Estimate lists the files a transfer would pick up, judging them by name instead of reading them
+func (f *FolderAdapter) Estimate(ctx tcontext.TransferMetadata) ([]types.SBOMCandidate, error) {
+	var candidates []types.SBOMCandidate
+
+	err := walkFolder(ctx, f.Config, nil, func(path string, info os.FileInfo) {
+		if source.IsSidecar(info.Name()) || source.IsSignature(info.Name()) || !source.DetectSBOMsFile(info.Name()) || !source.AllowsFormatName(ctx, info.Name()) || !f.Config.selected(path) {
+			return
+		}
+
+		candidates = append(candidates, types.SBOMCandidate{
+			Name:      getFilePath(f.Config.FolderPath, path),
+			Namespace: f.Config.FolderPath,
+			Size:      info.Size(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/source"
@@ -37,50 +38,43 @@ type SequentialFetcher struct{}
 
 // SequentialFetcher Fetch() scans the folder for SBOMs one-by-one
 // 1. Walks through the folder file-by-file
-// 2. Detects valid SBOMs using source.IsSBOMFile().
+// 2. Detects valid SBOMs using source.IsSBOM().
 // 3. Reads the content & adds it to the iterator along with path.
 func (f *SequentialFetcher) Fetch(ctx tcontext.TransferMetadata, config *FolderConfig) (iterator.SBOMIterator, error) {
 	logger.LogDebug(ctx.Context, "Fetching SBOMs Sequentially")
 	var sbomList []*iterator.SBOM
-	err := filepath.Walk(config.FolderPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			logger.LogInfo(ctx.Context, "error", "path", path, "error", err)
-			return nil
-		}
-
-		if info.IsDir() {
-			// Skip subdirectories if not recursive
-			if !config.Recursive && path != config.FolderPath {
-				return filepath.SkipDir
-			}
-			return nil
+	err := walkFolder(ctx, config, nil, func(path string, info os.FileInfo) {
+		if source.IsSidecar(info.Name()) || source.IsSignature(info.Name()) || !source.AllowsFormatName(ctx, info.Name()) || !config.selected(path) {
+			return
 		}
 
 		content, err := os.ReadFile(path)
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Failed to read SBOM", "path", path)
-			return nil
+			return
 		}
 
-		if source.IsSBOMFile(content) {
+		if source.IsSBOM(ctx, path, content) {
 			logger.LogDebug(ctx.Context, "Locally SBOM located folder", "path", config.FolderPath)
 
-			fileName := getFilePath(config.FolderPath, path)
-			sbomList = append(sbomList, &iterator.SBOM{
-				Data:      content,
-				Path:      fileName,
-				Namespace: config.FolderPath,
-			})
+			sbom, err := newFolderSBOM(ctx, config, path, content)
+			if err != nil {
+				logger.LogError(ctx.Context, err, "Skipping SBOM with invalid metadata file", "path", path)
+				return
+			}
+			sbomList = append(sbomList, sbom)
 		} else {
 			logger.LogDebug(ctx.Context, "Skipping non-SBOM file", "path", getFilePath(config.FolderPath, path))
 		}
-		return nil
 	})
+	if ctx.Err() != nil {
+		return nil, source.FetchInterrupted(ctx, len(sbomList))
+	}
 	if err != nil {
 		return nil, err
 	}
 	if len(sbomList) == 0 {
-		return nil, fmt.Errorf("No SBOM found in the folder")
+		return nil, errdefs.NotFound(fmt.Errorf("No SBOM found in the folder"))
 	}
 	return NewFolderIterator(sbomList), nil
 }
@@ -103,14 +97,13 @@ func (f *ParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *FolderCon
 		go func() {
 			defer wg.Done()
 			for path := range filePaths {
-
-				// skip directories.
-				info, err := os.Stat(path)
-				if err != nil {
-					logger.LogError(ctx.Context, err, "Failed to stat file", "path", path)
+				// drain the remaining paths once cancelled
+				if ctx.Err() != nil {
 					continue
 				}
-				if info.IsDir() {
+
+				name := filepath.Base(path)
+				if source.IsSidecar(name) || source.IsSignature(name) || !source.AllowsFormatName(ctx, name) || !config.selected(path) {
 					continue
 				}
 
@@ -120,54 +113,93 @@ func (f *ParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *FolderCon
 					continue
 				}
 
-				if !source.IsSBOMFile(content) {
+				if !source.IsSBOM(ctx, path, content) {
 					continue
 				}
 
 				logger.LogDebug(ctx.Context, "Locally SBOM located folder", "path", config.FolderPath)
 
-				//  get a relative file path.
-				fileName := getFilePath(config.FolderPath, path)
+				sbom, err := newFolderSBOM(ctx, config, path, content)
+				if err != nil {
+					logger.LogError(ctx.Context, err, "Skipping SBOM with invalid metadata file", "path", path)
+					continue
+				}
 
 				mu.Lock()
-				sbomList = append(sbomList, &iterator.SBOM{
-					Data:      content,
-					Path:      fileName,
-					Namespace: config.FolderPath,
-				})
+				sbomList = append(sbomList, sbom)
 				mu.Unlock()
 			}
 		}()
 	}
 
 	// walk the folder and send each file path into the channel.
-	err := filepath.Walk(config.FolderPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			logger.LogInfo(ctx.Context, "error", "path", path, "error", err)
-			return nil
-		}
-
-		// if not recursive and the current path is a subdirectory, skip it.
-		if info.IsDir() && !config.Recursive && path != config.FolderPath {
-			return filepath.SkipDir
-		}
-
+	err := walkFolder(ctx, config, nil, func(path string, info os.FileInfo) {
 		filePaths <- path
-		return nil
 	})
 	close(filePaths)
 	wg.Wait()
 
+	if ctx.Err() != nil {
+		return nil, source.FetchInterrupted(ctx, len(sbomList))
+	}
 	if err != nil {
 		return nil, err
 	}
 	if len(sbomList) == 0 {
-		return nil, fmt.Errorf("No SBOM found in the folder")
+		return nil, errdefs.NotFound(fmt.Errorf("No SBOM found in the folder"))
 	}
 	return NewFolderIterator(sbomList), nil
 }
 
 // getFilePath returns file path
+// newFolderSBOM builds the SBOM for a file found under the folder. The namespace is the
+// folder path, unless a namespace template derives namespace and version from the file's
+// path relative to the folder. The overrides of a sidecar metadata file are attached as
+// annotations, and with --verify-signatures the signature files next to it; an unreadable
+// metadata or signature file is an error.
+func newFolderSBOM(ctx tcontext.TransferMetadata, config *FolderConfig, fullPath string, content []byte) (*iterator.SBOM, error) {
+	annotations, err := source.LoadSidecar(fullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var signatures map[string][]byte
+	if source.FetchesSignatures(ctx) {
+		if signatures, err = source.LoadSignatures(fullPath); err != nil {
+			return nil, err
+		}
+	}
+
+	sbom := &iterator.SBOM{
+		Data:        content,
+		Path:        getFilePath(config.FolderPath, fullPath),
+		Namespace:   config.FolderPath,
+		Annotations: annotations,
+		Signatures:  signatures,
+	}
+
+	if config.NamespaceTemplate == nil {
+		return sbom, nil
+	}
+
+	relPath, err := filepath.Rel(config.FolderPath, fullPath)
+	if err != nil {
+		relPath = fullPath
+	}
+
+	namespace, version, ok := config.NamespaceTemplate.Apply(filepath.ToSlash(relPath))
+	if !ok {
+		logger.LogDebug(ctx.Context, "Path doesn't match namespace template, keeping default namespace", "path", relPath)
+		return sbom, nil
+	}
+
+	sbom.Namespace = namespace
+	sbom.Version = version
+	sbom.ExplicitNamespace = true
+	logger.LogDebug(ctx.Context, "Namespace derived from template", "path", relPath, "namespace", namespace, "version", version)
+	return sbom, nil
+}
+
 func getFilePath(basePath, fullPath string) string {
 	relPath, err := filepath.Rel(basePath, fullPath)
 	if err != nil {
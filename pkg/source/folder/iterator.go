@@ -47,6 +47,11 @@ func (it *FolderIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, e
 	return sbom, nil
 }
 
+// Total returns the number of SBOMs the iterator yields
+func (it *FolderIterator) Total() int {
+	return len(it.sboms)
+}
+
 // watchiterator collects sbom on the real time via channel
 type WatcherIterator struct {
 	sbomChan chan *iterator.SBOM
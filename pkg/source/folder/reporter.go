@@ -50,6 +50,9 @@ func (r *FolderReporter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBO
 		if err == io.EOF {
 			break
 		}
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
 			return err
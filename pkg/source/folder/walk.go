@@ -0,0 +1,106 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package folder
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// walkFolder calls visitDir for every directory read, the folder first, and visitFile for
+// every file in them, in lexical order; either may be nil. Subdirectories are read when config.descends them.
+// Symlinked directories are followed with FollowSymlinks, each directory read once so links
+// pointing back up the tree don't loop; symlinked files are read either way. Paths are
+// those through the folder, not the link targets, and info is that of the target.
+// Unreadable entries are logged and skipped; the walk stops when ctx is cancelled.
+func walkFolder(ctx tcontext.TransferMetadata, config *FolderConfig, visitDir func(path string), visitFile func(path string, info os.FileInfo)) error {
+	visited := make(map[string]bool)
+	return walkDir(ctx, config, config.FolderPath, visited, visitDir, visitFile)
+}
+
+func walkDir(ctx tcontext.TransferMetadata, config *FolderConfig, dir string, visited map[string]bool, visitDir func(string), visitFile func(string, os.FileInfo)) error {
+	if realDir, err := filepath.EvalSymlinks(dir); err == nil {
+		if visited[realDir] {
+			logger.LogDebug(ctx.Context, "Skipping directory already read through another path", "path", dir, "target", realDir)
+			return nil
+		}
+		visited[realDir] = true
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		logger.LogInfo(ctx.Context, "error", "path", dir, "error", err)
+		return nil
+	}
+	if visitDir != nil {
+		visitDir(dir)
+	}
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		symlink := entry.Type()&os.ModeSymlink != 0
+
+		// the target's info for symlinks, the entry's otherwise
+		info, err := os.Stat(path)
+		if err != nil {
+			logger.LogInfo(ctx.Context, "error", "path", path, "error", err)
+			continue
+		}
+
+		if !info.IsDir() {
+			if info.Mode().IsRegular() && visitFile != nil {
+				visitFile(path, info)
+			}
+			continue
+		}
+
+		if symlink && !config.FollowSymlinks {
+			logger.LogDebug(ctx.Context, "Skipping symlinked directory, not following symlinks", "path", path)
+			continue
+		}
+		if !config.descends(path) {
+			continue
+		}
+		if err := walkDir(ctx, config, path, visited, visitDir, visitFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// descends reports whether the subdirectory at path is read: the folder is read
+// recursively and the subdirectory is at most MaxDepth levels below it, when set
+func (c *FolderConfig) descends(path string) bool {
+	if !c.Recursive {
+		return false
+	}
+	if c.MaxDepth <= 0 {
+		return true
+	}
+
+	relPath, err := filepath.Rel(c.FolderPath, path)
+	if err != nil || relPath == "." {
+		return true
+	}
+	return len(strings.Split(filepath.ToSlash(relPath), "/")) <= c.MaxDepth
+}
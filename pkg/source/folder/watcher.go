@@ -19,12 +19,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/status"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 )
 
@@ -45,38 +48,71 @@ func (f *WatcherFetcher) Fetch(ctx tcontext.TransferMetadata, config *FolderConf
 		return nil, fmt.Errorf("failed to create watcher: %w", err)
 	}
 
-	sbomChan := make(chan *iterator.SBOM, 10)
-
-	// add to watch more sub-directories if recurssive is true
-	err = filepath.Walk(config.FolderPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			logger.LogError(ctx.Context, err, "Error accessing path", "path", path)
-			return nil
+	// content hash of every SBOM already sent, keyed by file path, so repeated write events
+	// for unchanged content are deduplicated while modified content is re-processed; the
+	// cache keeps them across restarts
+	var cache *Cache
+	processed := make(map[string]string)
+	if !config.NoCache {
+		outputAdapter := ctx.DestinationAdapter()
+		if cache, processed, err = OpenCache(ctx, outputAdapter, config.FolderPath); err != nil {
+			watcher.Close()
+			return nil, fmt.Errorf("failed to initialize cache: %w", err)
 		}
-		if info.IsDir() {
-			if !config.Recursive && path != config.FolderPath {
-				return filepath.SkipDir
-			}
+	}
 
-			// add it to the watcher
-			if err := watcher.Add(path); err != nil {
-				logger.LogError(ctx.Context, err, "Failed to watch directory", "path", path)
-			} else {
-				logger.LogDebug(ctx.Context, "Watching directory", "path", path)
-			}
+	sbomChan := make(chan *iterator.SBOM, 10)
 
-			return nil
+	// add to watch more sub-directories if recurssive is true, and list the files already
+	// there, which are transferred first unless they were before
+	var existing []string
+	err = walkFolder(ctx, config, func(path string) {
+		// add it to the watcher
+		if err := watcher.Add(path); err != nil {
+			logger.LogError(ctx.Context, err, "Failed to watch directory", "path", path)
+		} else {
+			logger.LogDebug(ctx.Context, "Watching directory", "path", path)
+		}
+	}, func(path string, info os.FileInfo) {
+		if !source.IsSidecar(info.Name()) && !source.IsSignature(info.Name()) {
+			existing = append(existing, path)
 		}
-		return nil
 	})
 	if err != nil {
 		watcher.Close()
+		cache.Close()
 		return nil, fmt.Errorf("failed to walk directory: %w", err)
 	}
 
+	status.Watch(ctx, config.FolderPath)
+	status.TrackPending(ctx, func() int { return len(sbomChan) })
+
+	w := &folderWatch{
+		config:    config,
+		watcher:   watcher,
+		processor: processor,
+		sbomChan:  sbomChan,
+		cache:     cache,
+		processed: processed,
+		movedAway: make(map[string]time.Time),
+		pending:   make(map[string]*time.Timer),
+		settled:   make(chan string),
+	}
+
 	// Start listening for events.
 	go func() {
 		defer watcher.Close()
+		defer cache.Close()
+		defer w.stopPending()
+
+		for _, path := range existing {
+			if ctx.Err() != nil {
+				break
+			}
+			w.process(ctx, path)
+		}
+		logger.LogDebug(ctx.Context, "Files already in watched folder processed", "files", len(existing))
+
 		for {
 			select {
 			case event, ok := <-watcher.Events:
@@ -84,84 +120,11 @@ func (f *WatcherFetcher) Fetch(ctx tcontext.TransferMetadata, config *FolderConf
 					close(sbomChan)
 					return
 				}
+				w.handle(ctx, event)
 
-				logger.LogDebug(ctx.Context, "Event Triggered", "name", event)
-
-				// handle removal or renaming events explicitly.
-				if event.Has(fsnotify.Rename) || event.Has(fsnotify.Remove) {
-					// just log and continue.
-					logger.LogDebug(ctx.Context, "Resource removed from watched folder", "path", event.Name)
-					continue
-				}
-
-				info, err := os.Stat(event.Name)
-				if err != nil {
-					logger.LogDebug(ctx.Context, "err", "Failed to stat path", "path", event.Name)
-					continue
-				}
-				// || event.Has(fsnotify.Create)
-				if event.Has(fsnotify.Write) {
-
-					var allFiles []string
-					if info.IsDir() {
-						logger.LogDebug(ctx.Context, "New directory created", "path", event.Name)
-
-						// if recurssive is true, add subdirectory to the watcher created during real-time monitoring.
-						if config.Recursive {
-							if err := watcher.Add(event.Name); err != nil {
-								logger.LogError(ctx.Context, err, "Failed to watch new directory", "path", event.Name)
-							} else {
-								logger.LogInfo(ctx.Context, "monitoring", "path", event.Name)
-							}
-						}
-
-						dirEntries, err := os.ReadDir(event.Name)
-						if err != nil {
-							logger.LogDebug(ctx.Context, "err", "Failed to read directory", "path", event.Name)
-							continue
-						}
-
-						if len(dirEntries) == 0 {
-							logger.LogDebug(ctx.Context, "No files in new directory, skipping", "path", event.Name)
-							continue
-						}
-
-						for _, entry := range dirEntries {
-							if !entry.IsDir() {
-								logger.LogDebug(ctx.Context, "Found file in new directory", "path", entry.Name())
-								allFiles = append(allFiles, filepath.Join(event.Name, entry.Name()))
-							}
-						}
-					} else {
-						// add file directly to allFiles.
-						allFiles = append(allFiles, event.Name)
-					}
-
-					for _, filePath := range allFiles {
-						content, err := os.ReadFile(filePath)
-						if err != nil {
-							logger.LogDebug(ctx.Context, "err", "Failed to read SBOM", "path", filePath)
-							continue
-						}
-
-						if source.IsSBOMFile(content) {
-							logger.LogDebug(ctx.Context, "Locally SBOM located folder", "path", config.FolderPath)
-
-							fileName := getFilePath(config.FolderPath, filePath)
-							processor.Update(content, "", fileName)
-
-							sbomChan <- &iterator.SBOM{
-								Data:      content,
-								Path:      fileName,
-								Namespace: config.FolderPath,
-							}
-
-						} else {
-							logger.LogInfo(ctx.Context, "not-found", "path", filePath)
-						}
-
-					}
-				}
+			case path := <-w.settled:
+				delete(w.pending, path)
+				w.process(ctx, path)
 
 			case err, ok := <-watcher.Errors:
 				if !ok {
@@ -169,6 +132,7 @@ func (f *WatcherFetcher) Fetch(ctx tcontext.TransferMetadata, config *FolderConf
 					return
 				}
 				logger.LogError(ctx.Context, err, "Watcher error")
+				status.Polled(ctx, config.FolderPath, err)
 
 			case <-ctx.Done():
 				close(sbomChan)
@@ -179,3 +143,234 @@ func (f *WatcherFetcher) Fetch(ctx tcontext.TransferMetadata, config *FolderConf
 
 	return &WatcherIterator{sbomChan: sbomChan}, nil
 }
+
+// moveWindow is how long after a file is renamed away an SBOM of the same content showing
+// up under another name, once settled, counts as the file being moved rather than a new one
+const moveWindow = 5 * time.Second
+
+// folderWatch is the state of a folder watcher, only used by its event loop
+type folderWatch struct {
+	config    *FolderConfig
+	watcher   *fsnotify.Watcher
+	processor *sbom.SBOMProcessor
+	sbomChan  chan *iterator.SBOM
+
+	// content hash of every SBOM already sent, keyed by file path, and the cache persisting
+	// them, nil with NoCache
+	processed map[string]string
+	cache     *Cache
+
+	// content hashes of SBOMs renamed away, with the time they were, to recognize moves
+	movedAway map[string]time.Time
+
+	// files written to recently, read once they see no event for the settle period
+	pending map[string]*time.Timer
+	settled chan string
+}
+
+// handle handles a watcher event: files created or written to are read once they settle,
+// new directories are watched, and files removed or renamed away are forgotten
+func (w *folderWatch) handle(ctx tcontext.TransferMetadata, event fsnotify.Event) {
+	logger.LogDebug(ctx.Context, "Event Triggered", "name", event)
+	event.Name = filepath.Clean(event.Name)
+
+	// handle removal or renaming events explicitly: forget the path (and anything below it,
+	// for directories) so a file re-added or renamed back under this name is transferred again.
+	// A file half-written when it is renamed, e.g. to its final name, is read under the new name.
+	if event.Has(fsnotify.Rename) || event.Has(fsnotify.Remove) {
+		w.cancelPending(event.Name)
+		if event.Has(fsnotify.Rename) {
+			w.rememberMoved(event.Name)
+		}
+		invalidated := invalidateProcessed(w.processed, event.Name)
+		if err := w.cache.Forget(ctx, event.Name); err != nil {
+			logger.LogError(ctx.Context, err, "Failed to update cache", "path", event.Name)
+		}
+		logger.LogDebug(ctx.Context, "Resource removed from watched folder", "path", event.Name, "invalidated", invalidated)
+		return
+	}
+
+	// files renamed or moved into the folder only fire a create event
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+		return
+	}
+
+	info, err := os.Stat(event.Name)
+	if err != nil {
+		logger.LogDebug(ctx.Context, "err", "Failed to stat path", "path", event.Name)
+		return
+	}
+	status.Polled(ctx, w.config.FolderPath, nil)
+
+	if !info.IsDir() {
+		w.schedule(ctx, event.Name)
+		return
+	}
+
+	logger.LogDebug(ctx.Context, "New directory created", "path", event.Name)
+
+	// if recurssive is true, add subdirectory to the watcher created during real-time monitoring,
+	// unless it is too deep or a symlink not to follow.
+	if w.config.descends(event.Name) && (w.config.FollowSymlinks || !isSymlink(event.Name)) {
+		if err := w.watcher.Add(event.Name); err != nil {
+			logger.LogError(ctx.Context, err, "Failed to watch new directory", "path", event.Name)
+		} else {
+			logger.LogInfo(ctx.Context, "monitoring", "path", event.Name)
+		}
+	}
+
+	dirEntries, err := os.ReadDir(event.Name)
+	if err != nil {
+		logger.LogDebug(ctx.Context, "err", "Failed to read directory", "path", event.Name)
+		return
+	}
+
+	if len(dirEntries) == 0 {
+		logger.LogDebug(ctx.Context, "No files in new directory, skipping", "path", event.Name)
+		return
+	}
+
+	for _, entry := range dirEntries {
+		// metadata and signature files are read along with their SBOMs
+		if !entry.IsDir() && !source.IsSidecar(entry.Name()) && !source.IsSignature(entry.Name()) {
+			logger.LogDebug(ctx.Context, "Found file in new directory", "path", entry.Name())
+			w.schedule(ctx, filepath.Join(event.Name, entry.Name()))
+		}
+	}
+}
+
+// schedule reads the file once it has seen no event for the settle period, restarting the
+// period of a file already waiting. Without a settle period the file is read right away.
+func (w *folderWatch) schedule(ctx tcontext.TransferMetadata, path string) {
+	if w.config.SettlePeriod <= 0 {
+		w.process(ctx, path)
+		return
+	}
+
+	if timer, ok := w.pending[path]; ok {
+		timer.Reset(w.config.SettlePeriod)
+		return
+	}
+	logger.LogDebug(ctx.Context, "Waiting for file to settle", "path", path, "period", w.config.SettlePeriod)
+	w.pending[path] = time.AfterFunc(w.config.SettlePeriod, func() {
+		select {
+		case w.settled <- path:
+		case <-ctx.Done():
+		}
+	})
+}
+
+// cancelPending stops waiting for path and, when path was a directory, for the files below it
+func (w *folderWatch) cancelPending(path string) {
+	prefix := path + string(filepath.Separator)
+	for p, timer := range w.pending {
+		if p == path || strings.HasPrefix(p, prefix) {
+			timer.Stop()
+			delete(w.pending, p)
+		}
+	}
+}
+
+func (w *folderWatch) stopPending() {
+	for _, timer := range w.pending {
+		timer.Stop()
+	}
+}
+
+// rememberMoved records the content of the SBOMs sent for path, or below it, as renamed away
+func (w *folderWatch) rememberMoved(path string) {
+	now := time.Now()
+	for hash, at := range w.movedAway {
+		if now.Sub(at) > w.config.SettlePeriod+moveWindow {
+			delete(w.movedAway, hash)
+		}
+	}
+
+	prefix := path + string(filepath.Separator)
+	for p, hash := range w.processed {
+		if p == path || strings.HasPrefix(p, prefix) {
+			w.movedAway[hash] = now
+		}
+	}
+}
+
+// process reads a settled file and sends it when it is an SBOM not sent yet
+func (w *folderWatch) process(ctx tcontext.TransferMetadata, filePath string) {
+	// a changed metadata file re-sends its SBOM with the new annotations
+	if source.IsSidecar(filePath) {
+		filePath = source.SidecarSBOMName(filePath)
+		delete(w.processed, filePath)
+	}
+
+	// so does a signature file, often written after its SBOM
+	if sbomName, _, ok := source.SignatureOf(filePath); ok {
+		if !source.FetchesSignatures(ctx) {
+			return
+		}
+		filePath = sbomName
+		delete(w.processed, filePath)
+	}
+
+	if !source.AllowsFormatName(ctx, filepath.Base(filePath)) || !w.config.selected(filePath) {
+		return
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		logger.LogDebug(ctx.Context, "err", "Failed to read SBOM", "path", filePath)
+		return
+	}
+
+	if !source.IsSBOM(ctx, filePath, content) {
+		logger.LogInfo(ctx.Context, "not-found", "path", filePath)
+		return
+	}
+	logger.LogDebug(ctx.Context, "Locally SBOM located folder", "path", w.config.FolderPath)
+
+	doc, err := newFolderSBOM(ctx, w.config, filePath, content)
+	if err != nil {
+		logger.LogError(ctx.Context, err, "Skipping SBOM with invalid metadata file", "path", filePath)
+		return
+	}
+
+	hash := sbom.ComputeContentHash(content)
+	if w.processed[filePath] == hash {
+		logger.LogDebug(ctx.Context, "SBOM content unchanged, skipping", "path", filePath)
+		return
+	}
+	w.processed[filePath] = hash
+	if err := w.cache.MarkProcessed(ctx, filePath, hash); err != nil {
+		logger.LogError(ctx.Context, err, "Failed to update cache", "path", filePath)
+	}
+
+	if at, ok := w.movedAway[hash]; ok && time.Since(at) <= w.config.SettlePeriod+moveWindow {
+		delete(w.movedAway, hash)
+		logger.LogInfo(ctx.Context, "SBOM moved within watched folder, already transferred", "path", filePath)
+		return
+	}
+
+	fileName := getFilePath(w.config.FolderPath, filePath)
+	w.processor.Update(content, "", fileName)
+
+	w.sbomChan <- doc
+}
+
+// invalidateProcessed drops the cached entry for path and, when path was a directory,
+// every entry below it. It returns the number of entries removed.
+func invalidateProcessed(processed map[string]string, path string) int {
+	removed := 0
+	prefix := path + string(filepath.Separator)
+	for p := range processed {
+		if p == path || strings.HasPrefix(p, prefix) {
+			delete(processed, p)
+			removed++
+		}
+	}
+	return removed
+}
+
+// isSymlink reports whether path is a symbolic link
+func isSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	return err == nil && info.Mode()&os.ModeSymlink != 0
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// formatFilterKey is the TransferMetadata key under which the engine stores the format filter
+const formatFilterKey = "format_filter"
+
+// AttachFormatFilter stores the --include-formats/--exclude-formats filter in the transfer context
+func AttachFormatFilter(ctx *tcontext.TransferMetadata, f *sbomd.FormatFilter) {
+	if f != nil {
+		ctx.WithValue(formatFilterKey, f)
+	}
+}
+
+// FormatFilterFromContext returns the format filter of the transfer, or nil when none is set
+func FormatFilterFromContext(ctx tcontext.TransferMetadata) *sbomd.FormatFilter {
+	f, _ := ctx.Value(formatFilterKey).(*sbomd.FormatFilter)
+	return f
+}
+
+// AllowsFormatName reports whether a file, object or asset should be downloaded, judging its
+// format from the name alone. Sources call it before downloading to save bandwidth.
+func AllowsFormatName(ctx tcontext.TransferMetadata, name string) bool {
+	if FormatFilterFromContext(ctx).AllowsName(name) {
+		return true
+	}
+	logger.LogDebug(ctx.Context, "Skipping SBOM excluded by format filter", "name", name, "format", sbomd.FormatFromName(name))
+	return false
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type GCSAdapter struct {
+	Config         *GCSConfig
+	Role           types.AdapterRole // "input" or "output" adapter type
+	ProcessingMode types.ProcessingMode
+	Fetcher        SBOMFetcher
+}
+
+// AddCommandParams adds Google Cloud Storage-specific CLI flags
+func (a *GCSAdapter) AddCommandParams(cmd *cobra.Command) {
+	cmd.Flags().String("in-gcs-bucket", "", "Bucket name")
+	cmd.Flags().String("in-gcs-prefix", "", "Object name prefix")
+	cmd.Flags().String("in-gcs-credentials-file", "", "Service account key file (default: Application Default Credentials)")
+	cmd.Flags().String("in-gcs-endpoint-url", "", "Custom JSON API URL, e.g. fake-gcs-server (default: https://storage.googleapis.com)")
+	cmd.Flags().String("in-gcs-namespace-template", "", "Regex with capture groups deriving namespace and version from the object name relative to the prefix, e.g. '^(?P<namespace>[^/]+)/(?P<version>[^/]+)/'")
+}
+
+// ParseAndValidateParams validates the Google Cloud Storage adapter params
+func (a *GCSAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	var (
+		bucketFlag, prefixFlag, credentialsFileFlag, endpointURLFlag, namespaceTemplateFlag string
+		missingFlags                                                                        []string
+		invalidFlags                                                                        []string
+	)
+
+	bucketFlag = "in-gcs-bucket"
+	prefixFlag = "in-gcs-prefix"
+	credentialsFileFlag = "in-gcs-credentials-file"
+	endpointURLFlag = "in-gcs-endpoint-url"
+	namespaceTemplateFlag = "in-gcs-namespace-template"
+
+	var fetcher SBOMFetcher
+	if a.ProcessingMode == types.FetchSequential {
+		fetcher = &GCSSequentialFetcher{}
+	} else if a.ProcessingMode == types.FetchParallel {
+		fetcher = &GCSParallelFetcher{}
+	} else {
+		return fmt.Errorf("unsupported processing mode: %s", a.ProcessingMode)
+	}
+
+	// validate flags for Google Cloud Storage adapter, all flags should start with "in-gcs-"
+	err := utils.FlagValidation(cmd, types.GCSAdapterType, types.InputAdapterFlagPrefix)
+	if err != nil {
+		return fmt.Errorf("gcs flag validation failed: %w", err)
+	}
+
+	bucketName, _ := cmd.Flags().GetString(bucketFlag)
+	if bucketName == "" {
+		missingFlags = append(missingFlags, bucketFlag)
+	}
+
+	// if prefix is empty that means all objects of the bucket
+	prefix, _ := cmd.Flags().GetString(prefixFlag)
+
+	// extract custom JSON API endpoint
+	endpointURL, _ := cmd.Flags().GetString(endpointURLFlag)
+	if endpointURL != "" {
+		if u, err := url.Parse(endpointURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be an http or https URL)", endpointURLFlag, endpointURL))
+		}
+	}
+
+	// without a key file, Application Default Credentials are used
+	credentialsFile, _ := cmd.Flags().GetString(credentialsFileFlag)
+	if credentialsFile != "" {
+		if _, err := os.Stat(credentialsFile); err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (file does not exist)", credentialsFileFlag, credentialsFile))
+		}
+	} else if endpointURL == "" {
+		logger.LogDebug(cmd.Context(), "Google Cloud Storage credentials file not provided, using Application Default Credentials")
+	}
+
+	// extract namespace template
+	var namespaceTemplate *source.NamespaceTemplate
+	if template, _ := cmd.Flags().GetString(namespaceTemplateFlag); template != "" {
+		namespaceTemplate, err = source.ParseNamespaceTemplate(template)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s: %v", namespaceTemplateFlag, err))
+		}
+	}
+
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing flags: %s", strings.Join(missingFlags, ", "))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid input adapter flag usage:\n %s\n\nUse 'sbommv transfer --help' for correct usage.", strings.Join(invalidFlags, "\n "))
+	}
+
+	cfg := NewGCSConfig()
+	cfg.ProcessingMode = a.ProcessingMode
+	cfg.BucketName = bucketName
+	cfg.Prefix = prefix
+	cfg.CredentialsFile = credentialsFile
+	cfg.EndpointURL = endpointURL
+	cfg.NamespaceTemplate = namespaceTemplate
+
+	a.Config = cfg
+	a.Fetcher = fetcher
+
+	return nil
+}
+
+func (a *GCSAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Initializing SBOM fetching", "mode", a.ProcessingMode)
+	return a.Fetcher.Fetch(ctx, a.Config)
+}
+
+func (a *GCSAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	return fmt.Errorf("Google Cloud Storage adapter does not support SBOM uploading when it is in input adapter role")
+}
+
+func (a *GCSAdapter) DryRun(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	reporter := NewGCSReporter(false, "", a.Config.BucketName, a.Config.Prefix)
+	return reporter.DryRun(ctx, iterator)
+}
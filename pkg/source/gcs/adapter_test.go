@@ -0,0 +1,73 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseFlags returns the adapter configured from the given flags, and the validation error
+func parseFlags(t *testing.T, args ...string) (*GCSAdapter, error) {
+	t.Helper()
+	adapter := &GCSAdapter{Role: types.InputAdapterRole, ProcessingMode: types.FetchParallel}
+	cmd := &cobra.Command{Use: "transfer"}
+	cmd.Flags().String("input-adapter", "gcs", "")
+	cmd.Flags().String("in-azblob-container", "", "flag of another input adapter")
+	adapter.AddCommandParams(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetContext(context.Background())
+	return adapter, adapter.ParseAndValidateParams(cmd)
+}
+
+func TestParseAndValidateParams(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(keyFile, []byte("{}"), 0o600))
+
+	tests := []struct {
+		name    string
+		args    []string
+		invalid string
+	}{
+		{"no bucket", []string{"--in-gcs-prefix=prod"}, "missing flags: in-gcs-bucket"},
+		{"missing credentials file", []string{"--in-gcs-bucket=sboms", "--in-gcs-credentials-file=" + filepath.Join(t.TempDir(), "absent.json")}, "absent.json (file does not exist)"},
+		{"endpoint without scheme", []string{"--in-gcs-bucket=sboms", "--in-gcs-endpoint-url=localhost:4443"}, "(must be an http or https URL)"},
+		{"invalid namespace template", []string{"--in-gcs-bucket=sboms", "--in-gcs-namespace-template=(unclosed"}, "--in-gcs-namespace-template:"},
+		{"flag of another adapter", []string{"--in-gcs-bucket=sboms", "--in-azblob-container=sboms"}, "flag --in-azblob-container is invalid"},
+		{"credentials file and endpoint", []string{"--in-gcs-bucket=sboms", "--in-gcs-prefix=prod", "--in-gcs-credentials-file=" + keyFile, "--in-gcs-endpoint-url=http://localhost:4443/"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := parseFlags(t, tt.args...)
+			if tt.invalid != "" {
+				assert.ErrorContains(t, err, tt.invalid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "sboms", adapter.Config.BucketName)
+			assert.Equal(t, "prod", adapter.Config.Prefix)
+			assert.Equal(t, keyFile, adapter.Config.CredentialsFile)
+			assert.Equal(t, "http://localhost:4443", adapter.Config.APIURL())
+			assert.IsType(t, &GCSParallelFetcher{}, adapter.Fetcher)
+		})
+	}
+}
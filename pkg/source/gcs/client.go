@@ -0,0 +1,124 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Client is a minimal client of the Cloud Storage JSON API, covering what the input adapter needs
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// Object is an object of a bucket listing
+type Object struct {
+	Name string `json:"name"`
+	Size string `json:"size"` // the JSON API encodes 64-bit integers as strings
+}
+
+// SizeBytes returns the object size, 0 when the listing has none
+func (o Object) SizeBytes() int64 {
+	size, _ := strconv.ParseInt(o.Size, 10, 64)
+	return size
+}
+
+// StatusError is a failed JSON API request, with the message of the error response
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("status: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("status: %d: %s", e.StatusCode, e.Message)
+}
+
+// ListObjects lists a page of the objects under prefix, starting at pageToken. It returns the
+// token of the next page, empty on the last page.
+func (c *Client) ListObjects(ctx context.Context, bucket, prefix, pageToken string) ([]Object, string, error) {
+	query := url.Values{}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	if pageToken != "" {
+		query.Set("pageToken", pageToken)
+	}
+	query.Set("fields", "items(name,size),nextPageToken")
+
+	resp, err := c.get(ctx, fmt.Sprintf("%s/storage/v1/b/%s/o?%s", c.baseURL, url.PathEscape(bucket), query.Encode()))
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	var page struct {
+		Items         []Object `json:"items"`
+		NextPageToken string   `json:"nextPageToken"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, "", fmt.Errorf("failed to decode object listing: %w", err)
+	}
+	return page.Items, page.NextPageToken, nil
+}
+
+// Download returns the content of an object
+func (c *Client) Download(ctx context.Context, bucket, name string) ([]byte, error) {
+	resp, err := c.get(ctx, fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media", c.baseURL, url.PathEscape(bucket), url.PathEscape(name)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// get sends a GET request, returning a *StatusError for a non-2xx response
+func (c *Client) get(ctx context.Context, reqURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, newStatusError(resp)
+	}
+	return resp, nil
+}
+
+// newStatusError reads the message of a JSON API error response
+func newStatusError(resp *http.Response) *StatusError {
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	_ = json.Unmarshal(data, &body)
+	return &StatusError{StatusCode: resp.StatusCode, Message: body.Error.Message}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// readOnlyScope is the OAuth scope of the input adapter, which only lists and downloads objects
+const readOnlyScope = "https://www.googleapis.com/auth/devstorage.read_only"
+
+type GCSConfig struct {
+	BucketName      string
+	Prefix          string
+	CredentialsFile string // service account key file, empty uses Application Default Credentials
+	EndpointURL     string // custom JSON API URL, e.g. fake-gcs-server, empty uses https://storage.googleapis.com
+	ProcessingMode  types.ProcessingMode
+
+	// NamespaceTemplate derives namespace and version from object names, nil keeps bucket-prefix
+	NamespaceTemplate *source.NamespaceTemplate
+}
+
+func NewGCSConfig() *GCSConfig {
+	return &GCSConfig{
+		ProcessingMode: types.FetchSequential, // Default
+	}
+}
+
+// APIURL returns the base URL of the JSON API
+func (c *GCSConfig) APIURL() string {
+	if c.EndpointURL != "" {
+		return strings.TrimSuffix(c.EndpointURL, "/")
+	}
+	return "https://storage.googleapis.com"
+}
+
+// GetGCSClient creates the HTTP client for the JSON API, authenticated with the service account
+// key file when given and with Application Default Credentials otherwise (GOOGLE_APPLICATION_CREDENTIALS,
+// gcloud user credentials, the metadata server on GCP). A custom endpoint without a key file
+// is used unauthenticated, as emulators expect.
+func (c *GCSConfig) GetGCSClient(ctx tcontext.TransferMetadata) (*Client, error) {
+	logger.LogDebug(ctx.Context, "Initializing Google Cloud Storage client", "bucket", c.BucketName, "prefix", c.Prefix, "endpoint", c.EndpointURL)
+
+	var httpClient *http.Client
+	switch {
+	case c.CredentialsFile != "":
+		data, err := os.ReadFile(c.CredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		creds, err := google.CredentialsFromJSONWithType(ctx.Context, data, google.ServiceAccount, readOnlyScope)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service account credentials: %w", err)
+		}
+		httpClient = oauth2.NewClient(ctx.Context, creds.TokenSource)
+
+	case c.EndpointURL != "":
+		httpClient = &http.Client{}
+
+	default:
+		client, err := google.DefaultClient(ctx.Context, readOnlyScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Google Cloud credentials: %w", err)
+		}
+		httpClient = client
+	}
+
+	return &Client{httpClient: httpClient, baseURL: c.APIURL()}, nil
+}
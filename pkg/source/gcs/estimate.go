@@ -0,0 +1,51 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"path"
+
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// Estimate lists the objects under the prefix a transfer would pick up, judging them by name
+// and using the sizes from the listing, without downloading any of them
+func (a *GCSAdapter) Estimate(ctx tcontext.TransferMetadata) ([]types.SBOMCandidate, error) {
+	client, prefix, err := openBucket(ctx, a.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, _, err := listObjects(ctx, client, a.Config.BucketName, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []types.SBOMCandidate
+	for _, object := range objects {
+		if skipObject(ctx, object.Name) || !source.DetectSBOMsFile(path.Base(object.Name)) {
+			continue
+		}
+		candidates = append(candidates, types.SBOMCandidate{
+			Name:      object.Name,
+			Namespace: a.Config.BucketName + "-" + a.Config.Prefix,
+			Size:      object.SizeBytes(),
+		})
+	}
+
+	return candidates, nil
+}
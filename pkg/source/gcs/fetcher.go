@@ -0,0 +1,284 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+type SBOMFetcher interface {
+	Fetch(ctx tcontext.TransferMetadata, config *GCSConfig) (iterator.SBOMIterator, error)
+}
+
+type (
+	GCSSequentialFetcher struct{}
+	GCSParallelFetcher   struct{}
+)
+
+// maxParallelDownloads is the number of workers downloading objects with --processing-mode=parallel
+const maxParallelDownloads = 5
+
+// Fetch lists the objects under the prefix and downloads them with a pool of workers
+func (f *GCSParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *GCSConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Concurrently...")
+
+	client, prefix, err := openBucket(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, names, err := listObjects(ctx, client, config.BucketName, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var sboms []*iterator.SBOM
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	nameChan := make(chan string)
+
+	for i := 0; i < maxParallelDownloads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range nameChan {
+				// drain the remaining names once cancelled
+				if ctx.Err() != nil {
+					continue
+				}
+
+				sbom, err := fetchObject(ctx, client, config, prefix, name, names)
+				if err != nil {
+					logger.LogError(ctx.Context, err, "Skipping object", "name", name)
+					continue
+				}
+				if sbom == nil {
+					continue
+				}
+
+				mu.Lock()
+				sboms = append(sboms, sbom)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, object := range objects {
+		if ctx.Err() != nil {
+			break
+		}
+		if skipObject(ctx, object.Name) {
+			continue
+		}
+		nameChan <- object.Name
+	}
+	close(nameChan)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, source.FetchInterrupted(ctx, len(sboms))
+	}
+	if len(sboms) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in bucket %s/%s", config.BucketName, config.Prefix))
+	}
+
+	return NewGCSIterator(sboms), nil
+}
+
+// Fetch lists the objects under the prefix and downloads them one by one
+func (f *GCSSequentialFetcher) Fetch(ctx tcontext.TransferMetadata, config *GCSConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Sequentially")
+
+	client, prefix, err := openBucket(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.LogDebug(ctx.Context, "Fetching SBOMs from Google Cloud Storage bucket", "bucket", config.BucketName, "prefix", config.Prefix)
+
+	objects, names, err := listObjects(ctx, client, config.BucketName, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var sbomList []*iterator.SBOM
+	for _, object := range objects {
+		if ctx.Err() != nil {
+			return nil, source.FetchInterrupted(ctx, len(sbomList))
+		}
+		if skipObject(ctx, object.Name) {
+			continue
+		}
+
+		sbom, err := fetchObject(ctx, client, config, prefix, object.Name, names)
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Skipping object", "name", object.Name)
+			continue
+		}
+		if sbom != nil {
+			sbomList = append(sbomList, sbom)
+		}
+	}
+
+	if len(sbomList) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in bucket %s/%s", config.BucketName, config.Prefix))
+	}
+	return NewGCSIterator(sbomList), nil
+}
+
+// openBucket creates the client. It returns the prefix to list, ending with "/" when set.
+func openBucket(ctx tcontext.TransferMetadata, config *GCSConfig) (*Client, string, error) {
+	client, err := config.GetGCSClient(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create Google Cloud Storage client: %w", err)
+	}
+
+	// add "/" to prefix if not present in the end
+	prefix := config.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix = prefix + "/"
+	}
+
+	return client, prefix, nil
+}
+
+// skipObject reports whether the object is left out before downloading: "directory" placeholder
+// objects created by the console, metadata files and names of excluded formats
+func skipObject(ctx tcontext.TransferMetadata, name string) bool {
+	return strings.HasSuffix(name, "/") || source.IsSidecar(name) || !source.AllowsFormatName(ctx, name)
+}
+
+// listObjects lists every object under prefix, following the listing pages (at most 1000
+// objects per page), and returns them with the set of listed names. The bucket is checked
+// by the listing itself, as a read-only service account may not be allowed to get the bucket.
+func listObjects(ctx tcontext.TransferMetadata, client *Client, bucket, prefix string) ([]Object, map[string]bool, error) {
+	var objects []Object
+	names := map[string]bool{}
+
+	pageToken := ""
+	for page := 1; ; page++ {
+		items, next, err := client.ListObjects(ctx.Context, bucket, prefix, pageToken)
+		if err != nil {
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+				return nil, nil, fmt.Errorf("bucket %q does not exist", bucket)
+			}
+			return nil, nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, object := range items {
+			objects = append(objects, object)
+			names[object.Name] = true
+		}
+		logger.LogDebug(ctx.Context, "Listed objects page", "bucket", bucket, "prefix", prefix, "page", page, "objects", len(items), "total_so_far", len(objects))
+
+		if next == "" {
+			break
+		}
+		pageToken = next
+	}
+
+	return objects, names, nil
+}
+
+// fetchObject downloads the object and builds its SBOM. It returns nil without an error when
+// the object isn't an SBOM.
+func fetchObject(ctx tcontext.TransferMetadata, client *Client, config *GCSConfig, prefix, name string, names map[string]bool) (*iterator.SBOM, error) {
+	content, err := downloadObject(ctx, client, config.BucketName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// check whether it's a SBOM content or not
+	if !source.IsSBOM(ctx, name, content) {
+		logger.LogDebug(ctx.Context, "Skipping invalid SBOM", "name", name, "content_sample", string(content[:min(100, len(content))]))
+		return nil, nil
+	}
+
+	annotations, err := fetchSidecar(ctx, client, config.BucketName, name, names)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata file for %s: %w", name, err)
+	}
+
+	logger.LogDebug(ctx.Context, "Fetched SBOM", "name", name, "size", len(content))
+	return newObjectSBOM(ctx, config, strings.TrimPrefix(name, prefix), content, annotations), nil
+}
+
+// fetchSidecar downloads and parses the metadata file of the object. It returns nil when the
+// listing has no metadata file for it.
+func fetchSidecar(ctx tcontext.TransferMetadata, client *Client, bucket, name string, names map[string]bool) (*iterator.Annotations, error) {
+	for _, sidecar := range source.SidecarNames(name) {
+		if !names[sidecar] {
+			continue
+		}
+
+		data, err := downloadObject(ctx, client, bucket, sidecar)
+		if err != nil {
+			return nil, err
+		}
+
+		annotations, err := source.ParseSidecar(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", sidecar, err)
+		}
+		return annotations, nil
+	}
+	return nil, nil
+}
+
+func downloadObject(ctx tcontext.TransferMetadata, client *Client, bucket, name string) ([]byte, error) {
+	content, err := client.Download(ctx.Context, bucket, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	return content, nil
+}
+
+// newObjectSBOM builds the SBOM for an object, named relative to the prefix. The namespace is
+// bucket and prefix, unless a namespace template derives namespace and version from the name.
+func newObjectSBOM(ctx tcontext.TransferMetadata, config *GCSConfig, relName string, content []byte, annotations *iterator.Annotations) *iterator.SBOM {
+	sbom := &iterator.SBOM{
+		Path:        relName,
+		Data:        content,
+		Namespace:   config.BucketName + "-" + config.Prefix,
+		Annotations: annotations,
+	}
+
+	if config.NamespaceTemplate == nil {
+		return sbom
+	}
+
+	namespace, version, ok := config.NamespaceTemplate.Apply(strings.TrimPrefix(relName, "/"))
+	if !ok {
+		logger.LogDebug(ctx.Context, "Object name doesn't match namespace template, keeping default namespace", "name", relName)
+		return sbom
+	}
+
+	sbom.Namespace = namespace
+	sbom.Version = version
+	sbom.ExplicitNamespace = true
+	logger.LogDebug(ctx.Context, "Namespace derived from template", "name", relName, "namespace", namespace, "version", version)
+	return sbom
+}
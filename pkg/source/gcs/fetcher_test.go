@@ -0,0 +1,221 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const appSBOM = `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"metadata":{"component":{"type":"application","name":"app","version":"1.0.0"}}}`
+
+// gcsServer serves bucket "sboms" through the JSON API, listing two objects per page, and
+// the OAuth token endpoint of a service account. API requests must carry the access token,
+// which is only granted for the read-only scope.
+func gcsServer(t *testing.T, objects map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fail := func(status int, message string) {
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"error":{"code":%d,"message":%q}}`, status, message)
+		}
+
+		if r.URL.Path == "/token" {
+			_, claims, _ := strings.Cut(r.FormValue("assertion"), ".")
+			claims, _, _ = strings.Cut(claims, ".")
+			data, _ := base64.RawURLEncoding.DecodeString(claims)
+			var jwt struct {
+				Scope string `json:"scope"`
+			}
+			if json.Unmarshal(data, &jwt); jwt.Scope != readOnlyScope {
+				fail(http.StatusBadRequest, "invalid scope "+jwt.Scope)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"ya29.test","token_type":"Bearer","expires_in":3600}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer ya29.test" {
+			fail(http.StatusUnauthorized, "Anonymous caller does not have storage.objects.list access")
+			return
+		}
+
+		path, ok := strings.CutPrefix(r.URL.Path, "/storage/v1/b/sboms/o")
+		if !ok {
+			fail(http.StatusNotFound, "The specified bucket does not exist.")
+			return
+		}
+		if name, ok := strings.CutPrefix(path, "/"); ok {
+			data, ok := objects[name]
+			if !ok || r.URL.Query().Get("alt") != "media" {
+				fail(http.StatusNotFound, "No such object: sboms/"+name)
+				return
+			}
+			io.WriteString(w, data)
+			return
+		}
+
+		prefix := r.URL.Query().Get("prefix")
+		var names []string
+		for name := range objects {
+			if strings.HasPrefix(name, prefix) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		start, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+		end := min(start+2, len(names))
+		page := map[string]any{}
+		var items []Object
+		for _, name := range names[start:end] {
+			items = append(items, Object{Name: name, Size: strconv.Itoa(len(objects[name]))})
+		}
+		page["items"] = items
+		if end < len(names) {
+			page["nextPageToken"] = strconv.Itoa(end)
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// serviceAccountKey writes a key file of a service account whose tokens are issued by server
+func serviceAccountKey(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "sbommv",
+		"private_key_id": "1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   "reader@sbommv.iam.gserviceaccount.com",
+		"token_uri":      server.URL + "/token",
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func drain(t *testing.T, ctx tcontext.TransferMetadata, it iterator.SBOMIterator) []*iterator.SBOM {
+	t.Helper()
+	var sboms []*iterator.SBOM
+	for {
+		sbom, err := it.Next(ctx)
+		if err == io.EOF {
+			return sboms
+		}
+		require.NoError(t, err)
+		sboms = append(sboms, sbom)
+	}
+}
+
+func TestFetchObjects(t *testing.T) {
+	objects := map[string]string{
+		"prod/app/1.0/bom.json":           appSBOM,
+		"prod/app/1.0/bom.json.meta.yaml": "project_name: billing\ntags: [team-a]\n",
+		"prod/api/2.0/bom.json":           strings.Replace(appSBOM, `"app"`, `"api"`, 1),
+		"prod/notes.json":                 `{"not":"an sbom"}`,
+		"staging/app/bom.json":            appSBOM,
+	}
+
+	for _, fetcher := range []SBOMFetcher{&GCSSequentialFetcher{}, &GCSParallelFetcher{}} {
+		t.Run(fmt.Sprintf("%T", fetcher), func(t *testing.T) {
+			ctx := *tcontext.NewTransferMetadata(context.Background())
+			server := gcsServer(t, objects)
+
+			template, err := source.ParseNamespaceTemplate(`^(?P<namespace>[^/]+)/(?P<version>[^/]+)/`)
+			require.NoError(t, err)
+			config := NewGCSConfig()
+			config.EndpointURL = server.URL + "/"
+			config.CredentialsFile = serviceAccountKey(t, server)
+			config.BucketName = "sboms"
+			config.Prefix = "prod"
+			config.NamespaceTemplate = template
+
+			it, err := fetcher.Fetch(ctx, config)
+			require.NoError(t, err)
+			sboms := drain(t, ctx, it)
+			sort.Slice(sboms, func(i, j int) bool { return sboms[i].Path < sboms[j].Path })
+
+			require.Len(t, sboms, 2, "the SBOMs under the prefix, not the sidecar nor the non-SBOM JSON")
+			assert.Equal(t, "api/2.0/bom.json", sboms[0].Path)
+			assert.Equal(t, "api", sboms[0].Namespace)
+			assert.Equal(t, "2.0", sboms[0].Version)
+			assert.Nil(t, sboms[0].Annotations)
+
+			assert.Equal(t, "app/1.0/bom.json", sboms[1].Path)
+			assert.Equal(t, "app", sboms[1].Namespace)
+			assert.True(t, sboms[1].ExplicitNamespace)
+			assert.JSONEq(t, appSBOM, string(sboms[1].Data))
+			assert.Equal(t, &iterator.Annotations{ProjectName: "billing", Tags: []string{"team-a"}}, sboms[1].Annotations)
+		})
+	}
+}
+
+func TestFetchObjectsErrors(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	server := gcsServer(t, map[string]string{"prod/notes.json": `{"not":"an sbom"}`})
+	keyFile := serviceAccountKey(t, server)
+
+	tests := []struct {
+		name            string
+		bucket          string
+		credentialsFile string
+		err             string
+	}{
+		{"missing bucket", "other", keyFile, `bucket "other" does not exist`},
+		{"anonymous", "sboms", "", "status: 401: Anonymous caller does not have storage.objects.list access"},
+		{"no SBOMs", "sboms", keyFile, "no SBOMs found in bucket sboms/prod"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewGCSConfig()
+			config.EndpointURL = server.URL
+			config.CredentialsFile = tt.credentialsFile
+			config.BucketName = tt.bucket
+			config.Prefix = "prod"
+
+			_, err := (&GCSSequentialFetcher{}).Fetch(ctx, config)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
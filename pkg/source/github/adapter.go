@@ -16,9 +16,7 @@ package github
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
-	"time"
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
@@ -47,32 +45,41 @@ const (
 
 	// MethodGenerate clones the repo and generates SBOMs using external Tools
 	MethodTool GitHubMethod = "tool"
+
+	// MethodAuto tries release assets first, then the dependency graph API, and finally generates SBOMs via tools
+	MethodAuto GitHubMethod = "auto"
 )
 
 // AddCommandParams adds GitHub-specific CLI flags
 func (g *GitHubAdapter) AddCommandParams(cmd *cobra.Command) {
-	cmd.Flags().String("in-github-url", "", "GitHub organization or repository URL")
-	cmd.Flags().String("in-github-method", "api", "GitHub method: release, api, or tool")
+	cmd.Flags().StringSlice("in-github-url", nil, "GitHub organization or repository URL, several organization URLs may be given comma-separated or by repeating the flag")
+	cmd.Flags().String("in-github-method", "api", "GitHub method: release, api, tool, or auto (release, then api, then tool per repo)")
 	cmd.Flags().String("in-github-branch", "", "Github repository branch")
+	cmd.Flags().String("in-github-tool", "syft", "SBOM generating tool of the tool and auto methods: syft, trivy or cdxgen (trivy and cdxgen must be installed)")
+	cmd.Flags().String("in-github-tool-version", "", "Version of the SBOM generating tool, e.g. 1.20.0: Syft is installed at that version, trivy and cdxgen must be installed at it")
 	cmd.Flags().String("in-github-version", "", "github repo version")
 	cmd.Flags().String("in-github-token", "", "GitHub token (required for more than 5000/hour rate limit)")
+	cmd.Flags().Float64("in-github-max-rps", 0, "Send at most this many requests per second to the GitHub API, e.g. 0.5 for one every 2s (0: no cap). Requests wait for the rate limit to reset whatever the cap")
+	cmd.Flags().String("in-github-api-url", "", "GitHub REST API URL, e.g. https://github.example.com/api/v3 for GitHub Enterprise Server (default: derived from --in-github-url)")
 	cmd.Flags().String("in-github-poll-interval", "24hr", "Polling interval to check GitHub Releases (default: 24hr; supports formats like '60s', '10m', '10hr', or plain seconds)")
 	cmd.Flags().String("in-github-asset-wait-delay", "180s", "Delay before fetching assets for a new release (default: 180s; supports formats like '60s', '10m', '10hr', or plain seconds)")
+	cmd.Flags().Bool("in-github-no-gen-cache", false, "Always regenerate SBOMs for the tool method instead of reusing ones cached in ~/.sbommv/gen-cache by commit SHA")
 
 	// Updated to StringSlice to support multiple values (comma-separated)
-	cmd.Flags().StringSlice("in-github-include-repos", nil, "Include only these repositories e.g sbomqs,sbomasm")
-	cmd.Flags().StringSlice("in-github-exclude-repos", nil, "Exclude these repositories e.g sbomqs,sbomasm")
-
-	// (Optional) If you plan to fetch **all versions** of a repo
-	// cmd.Flags().Bool("in-github-all-versions", false, "Fetch SBOMs from all versions")
+	cmd.Flags().StringSlice("in-github-include-repos", nil, "Include only these repositories e.g sbomqs,sbomasm, or org/repo to filter a single organization")
+	cmd.Flags().StringSlice("in-github-exclude-repos", nil, "Exclude these repositories e.g sbomqs,sbomasm, or org/repo to filter a single organization")
+	cmd.Flags().Int("in-github-max-repos", 0, "Transfer SBOMs of at most this many repositories of an organization, after filtering (0: no cap)")
+	cmd.Flags().String("in-github-since", "", "Fetch the SBOMs of every release published on or after this date (YYYY-MM-DD) with the release method")
+	cmd.Flags().String("in-github-version-range", "", "Fetch the SBOMs of every release whose tag is in this semver range, e.g. '>=v2.0.0 <v3.0.0', with the release method")
+	cmd.Flags().Bool("in-github-all-versions", false, "Fetch the SBOMs of every release instead of the latest one, each uploaded as the project version of its release (same as --in-github-version='*')")
 }
 
 // ParseAndValidateParams validates the GitHub adapter params
 func (g *GitHubAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	var (
 		urlFlag, methodFlag, includeFlag, excludeFlag,
-		githubBranchFlag, githubVersionFlag,
-		githubToken, githubPoll, assetWaitDelay string
+		githubBranchFlag, githubVersionFlag, toolFlag, toolVersionFlag,
+		githubToken, apiURLFlag, maxRPSFlag, githubPoll, assetWaitDelay, noGenCacheFlag, maxReposFlag, allVersionsFlag, sinceFlag, versionRangeFlag string
 		missingFlags []string
 		invalidFlags []string
 	)
@@ -85,9 +92,18 @@ func (g *GitHubAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 		excludeFlag = "in-github-exclude-repos"
 		githubBranchFlag = "in-github-branch"
 		githubVersionFlag = "in-github-version"
+		toolFlag = "in-github-tool"
+		toolVersionFlag = "in-github-tool-version"
 		githubToken = "in-github-token"
+		apiURLFlag = "in-github-api-url"
+		maxRPSFlag = "in-github-max-rps"
 		githubPoll = "in-github-poll-interval"
 		assetWaitDelay = "in-github-asset-wait-delay"
+		noGenCacheFlag = "in-github-no-gen-cache"
+		maxReposFlag = "in-github-max-repos"
+		allVersionsFlag = "in-github-all-versions"
+		sinceFlag = "in-github-since"
+		versionRangeFlag = "in-github-version-range"
 
 	case types.OutputAdapterRole:
 		return fmt.Errorf("The GitHub adapter doesn't support output adapter functionalities.")
@@ -101,22 +117,87 @@ func (g *GitHubAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 		return fmt.Errorf("github flag validation failed: %w", err)
 	}
 
-	// Extract GitHub URL
-	githubURL, _ := cmd.Flags().GetString(urlFlag)
-	if githubURL == "" {
+	// Extract GitHub URLs
+	githubURLs, _ := cmd.Flags().GetStringSlice(urlFlag)
+	if len(githubURLs) == 0 {
 		missingFlags = append(missingFlags, "--"+urlFlag)
+		githubURLs = []string{""}
 	}
 
 	includeRepos, _ := cmd.Flags().GetStringSlice(includeFlag)
 	excludeRepos, _ := cmd.Flags().GetStringSlice(excludeFlag)
 
 	// Validate GitHub URL to determine if it's an org or repo
-	owner, repo, err := utils.ParseGithubURL(githubURL)
+	owner, repo, err := utils.ParseGithubURL(githubURLs[0])
 	if err != nil {
 		return fmt.Errorf("invalid GitHub URL format: %w", err)
 	}
+	githubURL := githubURLs[0]
+
+	// several URLs make a multi-organization transfer, each of them must be an organization
+	var owners []string
+	if len(githubURLs) > 1 {
+		seen := map[string]bool{}
+		for _, u := range githubURLs {
+			orgOwner, orgRepo, err := utils.ParseGithubURL(u)
+			if err != nil {
+				return fmt.Errorf("invalid GitHub URL format: %w", err)
+			}
+			if orgRepo != "" {
+				invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (only organization URLs, i.e. https://github.com/<organization>, can be combined)", urlFlag, u))
+				continue
+			}
+			if !seen[strings.ToLower(orgOwner)] {
+				seen[strings.ToLower(orgOwner)] = true
+				owners = append(owners, orgOwner)
+			}
+		}
+		if g.Config.Daemon {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s accepts a single URL in daemon mode", urlFlag))
+		}
+	}
+
+	// org/repo filters must name one of the organizations of the transfer
+	transferOwners := owners
+	if len(transferOwners) == 0 {
+		transferOwners = []string{owner}
+	}
+	for _, filter := range []struct {
+		flag  string
+		repos []string
+	}{{includeFlag, includeRepos}, {excludeFlag, excludeRepos}} {
+		for _, r := range filter.repos {
+			org, _, qualified := strings.Cut(strings.TrimSpace(r), "/")
+			if qualified && !containsFold(transferOwners, org) {
+				invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (organization %s is not one of --%s)", filter.flag, r, org, urlFlag))
+			}
+		}
+	}
 
 	version, _ := cmd.Flags().GetString(githubVersionFlag)
+
+	// all versions are fetched with the "*" version
+	allVersions, _ := cmd.Flags().GetBool(allVersionsFlag)
+	if allVersions {
+		if version != "" && version != "*" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s cannot be used with --%s=%s", allVersionsFlag, githubVersionFlag, version))
+		}
+		version = "*"
+	}
+
+	// a date or version range selects among all releases
+	since, _ := cmd.Flags().GetString(sinceFlag)
+	versionRange, _ := cmd.Flags().GetString(versionRangeFlag)
+	releaseFilter, err := ParseReleaseFilter(since, versionRange)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s/--%s: %v", sinceFlag, versionRangeFlag, err))
+	}
+	if since != "" || versionRange != "" {
+		if version != "" && version != "*" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s and --%s cannot be used with --%s=%s", sinceFlag, versionRangeFlag, githubVersionFlag, version))
+		}
+		version = "*"
+	}
 	if version == "" {
 		version = "latest"
 	}
@@ -130,18 +211,66 @@ func (g *GitHubAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 		}
 	}
 
-	validMethods := map[string]bool{"release": true, "api": true, "tool": true}
+	validMethods := map[string]bool{"release": true, "api": true, "tool": true, "auto": true}
 
 	// Extract GitHub method
 	method, _ := cmd.Flags().GetString(methodFlag)
 	if !validMethods[method] {
-		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: release, api, tool)", methodFlag, method))
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (must be one of: release, api, tool, auto)", methodFlag, method))
 	}
 
 	// Extract branch (only valid for "tool" method)
 	branch, _ := cmd.Flags().GetString(githubBranchFlag)
-	if branch != "" && method != "tool" {
-		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s is only supported for --in-github-method=tool, whereas it's not supported for --in-github-method=api and --in-github-method=release", githubBranchFlag))
+	if branch != "" && method != "tool" && method != "auto" {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s is only supported for --in-github-method=tool or auto, whereas it's not supported for --in-github-method=api and --in-github-method=release", githubBranchFlag))
+	}
+
+	// only release SBOMs have versions, the daemon already transfers each new release
+	if version == "*" {
+		if method != "release" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s, --%s and --%s are only supported for --in-github-method=release", allVersionsFlag, sinceFlag, versionRangeFlag))
+		}
+		if g.Config.Daemon {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s, --%s and --%s are not supported in daemon mode", allVersionsFlag, sinceFlag, versionRangeFlag))
+		}
+	}
+
+	// the generating tool only applies to methods that may run it
+	toolName, _ := cmd.Flags().GetString(toolFlag)
+	toolVersion, _ := cmd.Flags().GetString(toolVersionFlag)
+	generator, err := NewGenerator(toolName, toolVersion)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (%v)", toolFlag, toolName, err))
+	}
+	if (cmd.Flags().Changed(toolFlag) || toolVersion != "") && method != "tool" && method != "auto" {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s and --%s are only supported for --in-github-method=tool or auto", toolFlag, toolVersionFlag))
+	}
+
+	// generated SBOM cache only applies to methods that may run the tool
+	noGenCache, _ := cmd.Flags().GetBool(noGenCacheFlag)
+	if noGenCache && method != "tool" && method != "auto" {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s is only supported for --in-github-method=tool or auto", noGenCacheFlag))
+	}
+
+	// the cap only applies to organization transfers
+	maxRepos, _ := cmd.Flags().GetInt(maxReposFlag)
+	if maxRepos < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%d (must be 0 or greater)", maxReposFlag, maxRepos))
+	} else if maxRepos > 0 && repo != "" {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s can only be used with an organization URL(i.e. https://github.com/<organization>)", maxReposFlag))
+	}
+
+	// the REST API of github.com, or of the GitHub Enterprise Server hosting the URL
+	apiURL, _ := cmd.Flags().GetString(apiURLFlag)
+	if apiURL == "" {
+		apiURL = APIURLFor(githubURL)
+	} else if !utils.IsValidURL(apiURL) {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be an http or https URL)", apiURLFlag, apiURL))
+	}
+
+	maxRPS, _ := cmd.Flags().GetFloat64(maxRPSFlag)
+	if maxRPS < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%g (must be 0 or greater)", maxRPSFlag, maxRPS))
 	}
 
 	// Validate include & exclude repos cannot be used together
@@ -171,22 +300,30 @@ func (g *GitHubAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 		fetcher = &ParallelFetcher{}
 	}
 
+	if len(owners) > 1 {
+		fetcher = &MultiOrgFetcher{inner: fetcher}
+	}
+
 	cfg := NewGithubConfig()
 	cfg.SetIncludeRepos(includeRepos)
 	cfg.SetExcludeRepos(excludeRepos)
+	if len(owners) <= 1 {
+		// a single organization takes org/repo filters as plain repository names
+		cfg.SetIncludeRepos(reposOfOwner(includeRepos, owner))
+		cfg.SetExcludeRepos(reposOfOwner(excludeRepos, owner))
+	}
 
 	// Validate that both include & exclude are not used together
 	if len(cfg.IncludeRepos) > 0 && len(cfg.ExcludeRepos) > 0 {
 		return fmt.Errorf("cannot use both --in-github-include-repos and --in-github-exclude-repos together")
 	}
 
+	// the auto method resolves the tool's binary lazily, only for repos that need the tool fallback
+	cfg.Generator = generator
 	if GitHubMethod(method) == MethodTool {
-		binaryPath, err := utils.GetBinaryPath()
-		if err != nil {
-			return fmt.Errorf("failed to get Syft binary: %w", err)
+		if _, err := generator.Binary(); err != nil {
+			return err
 		}
-
-		cfg.BinaryPath = binaryPath
 	}
 
 	token := viper.GetString("GITHUB_TOKEN")
@@ -199,22 +336,23 @@ func (g *GitHubAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 		fmt.Println("Github API method calculates SBOM for a complete repo not for any particular version: ", version)
 	}
 
+	cfg.APIURL = strings.TrimSuffix(apiURL, "/")
 	if version == "" {
 		version = "latest"
 		cfg.URL = githubURL
 	} else {
-		cfg.URL = fmt.Sprintf("https://github.com/%s/%s", owner, repo)
+		cfg.URL = fmt.Sprintf("%s/%s/%s", webURL(cfg.APIURL), owner, repo)
 	}
 
 	if g.Config.Daemon {
 		pollStr, _ := cmd.Flags().GetString(githubPoll)
-		pollSeconds, err := parseDuration(pollStr)
+		pollSeconds, err := utils.ParseDuration(pollStr)
 		if err != nil {
 			return fmt.Errorf("invalid --in-github-poll-interval: %w", err)
 		}
 
 		assetDelayStr, _ := cmd.Flags().GetString(assetWaitDelay)
-		assetDelaySeconds, err := parseDuration(assetDelayStr)
+		assetDelaySeconds, err := utils.ParseDuration(assetDelayStr)
 		if err != nil {
 			return fmt.Errorf("invalid --in-github-asset-wait-delay: %w", err)
 		}
@@ -224,12 +362,18 @@ func (g *GitHubAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	}
 
 	cfg.Owner = owner
+	cfg.Owners = owners
 	cfg.Repo = repo
 	cfg.Branch = branch
 
 	cfg.Version = version
+	cfg.Releases = releaseFilter
 	cfg.Method = method
 	cfg.Token = token
+	cfg.NoGenCache = noGenCache
+	cfg.MaxRepos = maxRepos
+	cfg.MaxRPS = maxRPS
+	cfg.limits = newRateLimiter(maxRPS)
 
 	// Initialize GitHub client
 	cfg.client = NewClient(cfg)
@@ -239,6 +383,16 @@ func (g *GitHubAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	return nil
 }
 
+// containsFold reports whether names holds name, ignoring case as GitHub does
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // FetchSBOMs initializes the GitHub SBOM iterator using the unified method
 func (g *GitHubAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
 	logger.LogDebug(ctx.Context, "Intializing SBOM fetching process", "fetching strategy", g.Config.ProcessingMode)
@@ -260,38 +414,3 @@ func (g *GitHubAdapter) DryRun(ctx tcontext.TransferMetadata, iterator iterator.
 	reporter := NewGithubReporter(false, "")
 	return reporter.DryRun(ctx, iterator)
 }
-
-// parseDuration parses a duration string (e.g., "10s", "10m", "10hr") into seconds.
-func parseDuration(durationStr string) (int64, error) {
-	// Normalize the input
-	durationStr = strings.TrimSpace(durationStr)
-	durationStr = strings.ToLower(durationStr)
-	durationStr = strings.ReplaceAll(durationStr, " ", "")
-
-	// Parse the duration
-	var duration time.Duration
-	var err error
-
-	switch {
-	case strings.HasSuffix(durationStr, "s"): // Seconds: xs (e.g., "60s")
-		duration, err = time.ParseDuration(durationStr)
-	case strings.HasSuffix(durationStr, "m"): // Minutes: xm (e.g., "10m")
-		duration, err = time.ParseDuration(durationStr)
-	case strings.HasSuffix(durationStr, "hr"): // Hours: xhr (e.g., "10hr")
-		// Normalize "hr" to "h" for time.ParseDuration
-		durationStr = strings.TrimSuffix(durationStr, "hr") + "h"
-		duration, err = time.ParseDuration(durationStr)
-	default: // Backward compatibility: plain seconds (e.g., "60")
-		seconds, err := strconv.Atoi(durationStr)
-		if err != nil {
-			return 0, fmt.Errorf("must be in format like '60s', '10m', '10hr', or plain seconds (e.g., '60'): %w", err)
-		}
-		duration = time.Duration(seconds) * time.Second
-	}
-
-	if err != nil {
-		return 0, fmt.Errorf("must be in format like '60s', '10m', '10hr', or plain seconds (e.g., '60'): %w", err)
-	}
-
-	return int64(duration.Seconds()), nil
-}
@@ -15,28 +15,21 @@
 package github
 
 import (
-	"context"
-	"database/sql"
+	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 	"sync"
-	"time"
 
+	"github.com/interlynk-io/sbommv/pkg/cache"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
-	_ "modernc.org/sqlite"
 )
 
-const (
-	CACHE_PATH = ".sbommv/cache.db"
-)
-
-// Cache holds in-memory cache data (JSON-like maps) to reduce SQLite queries, synced to cache.db.
+// Cache holds in-memory cache data (JSON-like maps) to reduce store queries, synced to the
+// cache store of the output adapter and method.
 type Cache struct {
-	Data map[string]AdapterCache
-	db   *sql.DB
+	Data  map[string]AdapterCache
+	store cache.Store
 	sync.RWMutex
 }
 
@@ -62,73 +55,51 @@ func NewCache() *Cache {
 	}
 }
 
-// CachePath generates a daemon-specific cache file path
-func CachePath(outputAdapter, method string) string {
-	return filepath.Join(".sbommv", fmt.Sprintf("cache_%s_%s.db", outputAdapter, method))
+// CacheName generates a daemon-specific cache store name
+func CacheName(outputAdapter, method string) string {
+	return fmt.Sprintf("cache_%s_%s", outputAdapter, method)
 }
 
-const createReposAndSBOMsTable string = `
-	CREATE TABLE IF NOT EXISTS repos (
-		output_adapter TEXT,
-		input_adapter TEXT,
-		method TEXT,
-		repo TEXT,
-		published_at TEXT,
-		release_id TEXT,
-		PRIMARY KEY (output_adapter, input_adapter, method, repo)
-	);
-
-	CREATE TABLE IF NOT EXISTS sboms (
-		output_adapter TEXT,
-		input_adapter TEXT,
-		method TEXT,
-		owner TEXT,
-		repo TEXT,
-		tag_name TEXT,
-		filename TEXT,
-		processed BOOLEAN,
-		PRIMARY KEY (output_adapter, input_adapter, method, repo, tag_name, filename)
-	);
-`
-
-// InitCache initializes SQLite database with repos and sboms tables.
-func (c *Cache) InitCache(ctx tcontext.TransferMetadata, outputAdapter, method string) error {
-	path := CachePath(outputAdapter, method)
+// cacheMethods are the methods SBOMs are cached under, auto caching under those it falls back to
+var cacheMethods = []string{string(MethodAPI), string(MethodReleases), string(MethodTool), string(MethodAuto)}
 
-	logger.LogDebug(ctx.Context, "Initializing SQLite cache", "path", path)
+// namespaces of the store: the last release of each repo and the processed SBOMs, per
+// output adapter, input adapter and method, and the responses of the REST API
+func reposNamespace(outputAdapter, inputAdapter, method string) string {
+	return fmt.Sprintf("github/%s/%s/%s/repos", outputAdapter, inputAdapter, method)
+}
 
-	// Create cache directory
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		logger.LogError(ctx.Context, err, "Failed to create cache directory")
-		return fmt.Errorf("failed to create cache directory: %w", err)
-	}
+func sbomsNamespace(outputAdapter, inputAdapter, method string) string {
+	return fmt.Sprintf("github/%s/%s/%s/sboms", outputAdapter, inputAdapter, method)
+}
 
-	// Open embedded SQLite database with timeout
-	dbCtx, cancel := context.WithTimeout(ctx.Context, 5*time.Second)
-	defer cancel()
+const responsesNamespace = "github/http_responses"
 
-	db, err := sql.Open("sqlite", path)
+// InitCache opens the cache store of the output adapter and method, importing the repos and
+// SBOMs of the SQLite tables earlier versions kept them in.
+func (c *Cache) InitCache(ctx tcontext.TransferMetadata, outputAdapter, method string) error {
+	store, err := cache.Open(ctx, CacheName(outputAdapter, method))
 	if err != nil {
-		logger.LogError(ctx.Context, err, "Failed to open SQLite database")
-		return fmt.Errorf("failed to open SQLite database: %w", err)
+		logger.LogError(ctx.Context, err, "Failed to open cache")
+		return fmt.Errorf("failed to open cache: %w", err)
 	}
-
-	c.db = db
-	logger.LogDebug(ctx.Context, "Cache database opened", "path", path)
-
-	// // Enable WAL mode for concurrent reads/writes
-	// if _, err = db.ExecContext(dbCtx, "PRAGMA journal_mode=WAL;"); err != nil {
-	// 	logger.LogError(ctx.Context, err, "Failed to enable WAL mode")
-	// 	return fmt.Errorf("failed to enable WAL mode: %w", err)
-	// }
-
-	// Create tables
-	if _, err = db.ExecContext(dbCtx, createReposAndSBOMsTable); err != nil {
-		logger.LogError(ctx.Context, err, "Failed to create tables")
-		return fmt.Errorf("failed to create tables: %w", err)
+	c.store = store
+
+	for _, m := range cacheMethods {
+		err := cache.ImportLegacy(ctx, store, reposNamespace(outputAdapter, "github", m), `
+			SELECT repo, json_object('published_at', published_at, 'release_id', release_id)
+			FROM repos WHERE output_adapter = ? AND input_adapter = ? AND method = ?`, outputAdapter, "github", m)
+		if err == nil {
+			err = cache.ImportLegacy(ctx, store, sbomsNamespace(outputAdapter, "github", m), `
+				SELECT owner || ':' || repo || ':' || tag_name || ':' || filename, 'true'
+				FROM sboms WHERE output_adapter = ? AND input_adapter = ? AND method = ? AND processed`, outputAdapter, "github", m)
+		}
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Failed to import earlier GitHub cache", "method", m)
+		}
 	}
 
-	logger.LogDebug(ctx.Context, "Successfully initialized SQLite cache", "path", path)
+	logger.LogDebug(ctx.Context, "Successfully initialized cache", "store", CacheName(outputAdapter, method))
 	return nil
 }
 
@@ -148,13 +119,11 @@ func (c *Cache) ensureCachePathFor(outputAdapter, inputAdapter, method string) {
 	}
 }
 
-// LoadCache populates in-memory cache (cache-aside pattern) from SQLite to reduce query frequency.
+// LoadCache populates in-memory cache (cache-aside pattern) from the store to reduce query frequency.
 func (c *Cache) LoadCache(ctx tcontext.TransferMetadata, adapter, method string) error {
-	path := CachePath(adapter, method)
-
-	logger.LogDebug(ctx.Context, "Loading cache from SQLite database", "path", path)
+	logger.LogDebug(ctx.Context, "Loading cache from store", "store", CacheName(adapter, method))
 
-	if c.db == nil {
+	if c.store == nil {
 		if err := c.InitCache(ctx, adapter, method); err != nil {
 			return err
 		}
@@ -164,124 +133,70 @@ func (c *Cache) LoadCache(ctx tcontext.TransferMetadata, adapter, method string)
 	defer c.Unlock()
 	c.Data = make(map[string]AdapterCache)
 
-	rows, err := c.db.Query(`
-		SELECT output_adapter, input_adapter, method, repo, published_at, release_id
-		FROM repos
-	`)
-	if err != nil {
-		logger.LogError(ctx.Context, err, "Failed to query repos")
-		return fmt.Errorf("failed to query repos: %w", err)
-	}
-
-	defer rows.Close()
-
-	for rows.Next() {
-		var outputAdapter, inputAdapter, method, repo, publishedAt, releaseID string
-		if err := rows.Scan(&outputAdapter, &inputAdapter, &method, &repo, &publishedAt, &releaseID); err != nil {
-			return fmt.Errorf("failed to scan row: %w", err)
+	for _, m := range cacheMethods {
+		repos, err := c.store.Entries(ctx.Context, reposNamespace(adapter, "github", m))
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Failed to query repos")
+			return fmt.Errorf("failed to query repos: %w", err)
 		}
-		c.ensureCachePathFor(outputAdapter, inputAdapter, method)
-
-		c.Data[outputAdapter][inputAdapter][method].Repos[repo] = RepoState{
-			PublishedAt: publishedAt,
-			ReleaseID:   releaseID,
+		sboms, err := c.store.Entries(ctx.Context, sbomsNamespace(adapter, "github", m))
+		if err != nil {
+			return fmt.Errorf("failed to query sboms: %w", err)
 		}
-	}
-
-	sbomsRows, err := c.db.Query(`
-		SELECT output_adapter, input_adapter, method, owner, repo, tag_name, filename, processed
-		FROM sboms
-	`)
-	if err != nil {
-		return fmt.Errorf("failed to query sboms: %w", err)
-	}
-
-	defer sbomsRows.Close()
-
-	for sbomsRows.Next() {
-		var outputAdapter, inputAdapter, method, owner, repo, tagName, filename string
-		var processed bool
-		if err := sbomsRows.Scan(&outputAdapter, &inputAdapter, &method, &owner, &repo, &tagName, &filename, &processed); err != nil {
-			return fmt.Errorf("failed to scan sboms row: %w", err)
+		if len(repos) == 0 && len(sboms) == 0 {
+			continue
 		}
 
-		c.ensureCachePathFor(outputAdapter, inputAdapter, method)
-		sbomKey := fmt.Sprintf("%s:%s:%s:%s", owner, repo, tagName, filename)
-		c.Data[outputAdapter][inputAdapter][method].SBOMs[sbomKey] = processed
+		c.ensureCachePathFor(adapter, "github", m)
+		for repo, value := range repos {
+			var state RepoState
+			if err := json.Unmarshal(value, &state); err != nil {
+				return fmt.Errorf("failed to decode cached repo %s: %w", repo, err)
+			}
+			c.Data[adapter]["github"][m].Repos[repo] = state
+		}
+		for sbomKey := range sboms {
+			c.Data[adapter]["github"][m].SBOMs[sbomKey] = true
+		}
 	}
 
-	logger.LogDebug(ctx.Context, "Successfully loaded cache from SQLite", "path", path)
+	logger.LogDebug(ctx.Context, "Successfully loaded cache from store", "store", CacheName(adapter, method))
 	return nil
 }
 
-// SaveCache updates SQLite with in-memory cache changes (write-through caching).
+// SaveCache updates the store with in-memory cache changes (write-through caching).
 func (c *Cache) SaveCache(ctx tcontext.TransferMetadata, adapter, method string) error {
-	path := CachePath(adapter, method)
-
-	if c.db == nil {
-		return fmt.Errorf("SQLite database not initialized")
+	if c.store == nil {
+		return fmt.Errorf("cache store not initialized")
 	}
-	logger.LogDebug(ctx.Context, "Saving cache to SQLite database", "path", CACHE_PATH)
-
-	// Retry on "database is locked" errors
-	for retries := 3; retries > 0; retries-- {
-		tx, err := c.db.Begin()
-		if err != nil {
-			return fmt.Errorf("failed to start transaction: %w", err)
-		}
-
-		// Defer rollback in case of failure
-		rollback := true
-		defer func() {
-			if rollback {
-				tx.Rollback()
-			}
-		}()
-
-		for outputAdapter, adapterCache := range c.Data {
-			for inputAdapter, daemonCache := range adapterCache {
-				for method, methodCache := range daemonCache {
-					for repo, state := range methodCache.Repos {
-						_, err := tx.Exec(`
-							INSERT OR REPLACE INTO repos (output_adapter, input_adapter, method, repo, published_at, release_id)
-							VALUES (?, ?, ?, ?, ?, ?)`, outputAdapter, inputAdapter, method, repo, state.PublishedAt, state.ReleaseID)
-						if err != nil {
-							return fmt.Errorf("failed to save repos: %w", err)
-						}
+	logger.LogDebug(ctx.Context, "Saving cache to store", "store", CacheName(adapter, method))
+
+	for outputAdapter, adapterCache := range c.Data {
+		for inputAdapter, daemonCache := range adapterCache {
+			for method, methodCache := range daemonCache {
+				for repo, state := range methodCache.Repos {
+					value, err := json.Marshal(state)
+					if err != nil {
+						return fmt.Errorf("failed to save repos: %w", err)
 					}
-
-					for sbomKey, processed := range methodCache.SBOMs {
-						parts := strings.SplitN(sbomKey, ":", 4)
-						if len(parts) != 4 {
-							continue // Skip invalid keys
-						}
-
-						owner, repo, tagName, filename := parts[0], parts[1], parts[2], parts[3]
-						_, err := tx.Exec(`
-							INSERT OR REPLACE INTO sboms (output_adapter, input_adapter, method, owner, repo, tag_name, filename, processed)
-							VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, outputAdapter, inputAdapter, method, owner, repo, tagName, filename, processed)
-						if err != nil {
-							return fmt.Errorf("failed to save sboms: %w", err)
-						}
+					if err := c.store.Put(ctx.Context, reposNamespace(outputAdapter, inputAdapter, method), repo, value); err != nil {
+						return fmt.Errorf("failed to save repos: %w", err)
 					}
 				}
-			}
-		}
 
-		if err := tx.Commit(); err != nil {
-			if strings.Contains(err.Error(), "database is locked") && retries > 1 {
-				logger.LogDebug(ctx.Context, "Database locked, retrying", "retries_left", retries-1)
-				time.Sleep(100 * time.Millisecond)
-				continue
+				for sbomKey, processed := range methodCache.SBOMs {
+					if !processed {
+						continue
+					}
+					if err := c.store.Put(ctx.Context, sbomsNamespace(outputAdapter, inputAdapter, method), sbomKey, []byte("true")); err != nil {
+						return fmt.Errorf("failed to save sboms: %w", err)
+					}
+				}
 			}
-			return fmt.Errorf("failed to commit transaction: %w", err)
 		}
-		rollback = false
-		break
-
 	}
 
-	logger.LogDebug(ctx.Context, "Successfully saved cache to SQLite", "path", path)
+	logger.LogDebug(ctx.Context, "Successfully saved cache to store", "store", CacheName(adapter, method))
 	return nil
 }
 
@@ -299,7 +214,7 @@ func (c *Cache) EnsureCachePath(ctx tcontext.TransferMetadata, outputAdapter, in
 	}
 
 	// intialize all methods
-	for _, method := range []string{string(MethodAPI), string(MethodReleases), string(MethodTool)} {
+	for _, method := range cacheMethods {
 		if _, exists := c.Data[outputAdapter][inputAdapter][method]; !exists {
 			c.Data[outputAdapter][inputAdapter][method] = MethodCache{
 				Repos: make(map[string]RepoState),
@@ -307,34 +222,18 @@ func (c *Cache) EnsureCachePath(ctx tcontext.TransferMetadata, outputAdapter, in
 			}
 		}
 	}
-	logger.LogDebug(ctx.Context, "Initialized cache paths", "output_adapter", outputAdapter, "input_adapter", inputAdapter, "methods", []string{"release", "api", "tool"})
+	logger.LogDebug(ctx.Context, "Initialized cache paths", "output_adapter", outputAdapter, "input_adapter", inputAdapter, "methods", []string{"release", "api", "tool", "auto"})
 }
 
 // IsSBOMProcessed checks if an SBOM is processed in the cache or not
 func (c *Cache) IsSBOMProcessed(ctx tcontext.TransferMetadata, outputAdapter, inputAdapter, method, sbomCacheKey, repo string) bool {
 	logger.LogDebug(ctx.Context, "Checking if SBOM is processed", "cache_key", sbomCacheKey, "method", method)
 
-	if c.db == nil {
-		return false
-	}
-
-	parts := strings.SplitN(sbomCacheKey, ":", 4)
-	if len(parts) != 4 {
-		return false
-	}
-
-	owner, repo, tagName, filename := parts[0], parts[1], parts[2], parts[3]
-
-	var processed bool
-	err := c.db.QueryRow(`
-		SELECT processed FROM sboms
-		WHERE output_adapter = ? AND input_adapter = ? AND method = ? AND owner = ? AND repo = ? AND tag_name = ? AND filename = ?`,
-		outputAdapter, inputAdapter, method, owner, repo, tagName, filename).Scan(&processed)
-
-	if err == sql.ErrNoRows {
+	if c.store == nil || len(strings.SplitN(sbomCacheKey, ":", 4)) != 4 {
 		return false
 	}
 
+	_, processed, err := c.store.Get(ctx.Context, sbomsNamespace(outputAdapter, inputAdapter, method), sbomCacheKey)
 	if err != nil {
 		logger.LogError(ctx.Context, err, "Failed to check SBOM processed")
 		return false
@@ -349,49 +248,16 @@ func (c *Cache) IsSBOMProcessed(ctx tcontext.TransferMetadata, outputAdapter, in
 
 // MarkSBOMProcessed marks an SBOM as processed in the cache (write-through).
 func (c *Cache) MarkSBOMProcessed(ctx tcontext.TransferMetadata, outputAdapter, inputAdapter, method, sbomCacheKey, repo string) error {
-	if c.db == nil {
-		return fmt.Errorf("SQLite database not initialized")
+	if c.store == nil {
+		return fmt.Errorf("cache store not initialized")
 	}
 
-	parts := strings.SplitN(sbomCacheKey, ":", 4)
-	if len(parts) != 4 {
+	if len(strings.SplitN(sbomCacheKey, ":", 4)) != 4 {
 		return fmt.Errorf("invalid sbom cache key: %s", sbomCacheKey)
 	}
 
-	owner, repo, tagName, filename := parts[0], parts[1], parts[2], parts[3]
-
-	// Retry on "database is locked" errors
-	for retries := 3; retries > 0; retries-- {
-		tx, err := c.db.Begin()
-		if err != nil {
-			return fmt.Errorf("failed to start transaction: %w", err)
-		}
-
-		rollback := true
-		defer func() {
-			if rollback {
-				tx.Rollback()
-			}
-		}()
-
-		_, err = tx.Exec(`
-			INSERT OR REPLACE INTO sboms (output_adapter, input_adapter, method, owner, repo, tag_name, filename, processed)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-			outputAdapter, inputAdapter, method, owner, repo, tagName, filename, true)
-		if err != nil {
-			return fmt.Errorf("failed to mark SBOM processed: %w", err)
-		}
-
-		if err := tx.Commit(); err != nil {
-			if strings.Contains(err.Error(), "database is locked") && retries > 1 {
-				logger.LogDebug(ctx.Context, "Database locked, retrying", "retries_left", retries-1)
-				time.Sleep(100 * time.Millisecond)
-				continue
-			}
-			return fmt.Errorf("failed to commit transaction: %w", err)
-		}
-		rollback = false
-		break
+	if err := c.store.Put(ctx.Context, sbomsNamespace(outputAdapter, inputAdapter, method), sbomCacheKey, []byte("true")); err != nil {
+		return fmt.Errorf("failed to mark SBOM processed: %w", err)
 	}
 
 	logger.LogDebug(ctx.Context, "Marked SBOM as processed", "cache_key", sbomCacheKey, "method", method)
@@ -400,28 +266,65 @@ func (c *Cache) MarkSBOMProcessed(ctx tcontext.TransferMetadata, outputAdapter,
 
 // PruneSBOMs clears SBOMs for a specific adapter, input adapter, method, and repo.
 func (c *Cache) PruneSBOMs(ctx tcontext.TransferMetadata, outputAdapter, inputAdapter, method, repo string) error {
-	if c.db == nil {
-		return fmt.Errorf("SQLite database not initialized")
+	if c.store == nil {
+		return fmt.Errorf("cache store not initialized")
 	}
 
-	tx, err := c.db.Begin()
+	namespace := sbomsNamespace(outputAdapter, inputAdapter, method)
+	sboms, err := c.store.Entries(ctx.Context, namespace)
 	if err != nil {
-		return fmt.Errorf("failed to start transaction: %w", err)
+		return fmt.Errorf("failed to prune SBOMs: %w", err)
+	}
+	for sbomKey := range sboms {
+		// owner:repo:tag_name:filename
+		if parts := strings.SplitN(sbomKey, ":", 4); len(parts) == 4 && parts[1] == repo {
+			if err := c.store.Delete(ctx.Context, namespace, sbomKey); err != nil {
+				return fmt.Errorf("failed to prune SBOMs: %w", err)
+			}
+		}
+	}
+
+	logger.LogDebug(ctx.Context, "Cleared old SBOMs", "output_adapter", outputAdapter, "method", method, "repo", repo)
+	return nil
+}
+
+// CachedResponse is the last response of a GitHub REST endpoint, with its validators
+type CachedResponse struct {
+	ETag         string
+	LastModified string
+	Body         []byte
+}
+
+// LookupResponse returns the cached response of the endpoint url, if any
+func (c *Cache) LookupResponse(ctx tcontext.TransferMetadata, url string) (CachedResponse, bool) {
+	var cached CachedResponse
+	if c.store == nil {
+		return cached, false
 	}
-	defer tx.Rollback()
 
-	_, err = tx.Exec(`
-		DELETE FROM sboms
-		WHERE output_adapter = ? AND input_adapter = ? AND method = ? AND repo = ?`,
-		outputAdapter, inputAdapter, method, repo)
+	value, ok, err := c.store.Get(ctx.Context, responsesNamespace, url)
+	if err == nil && ok {
+		err = json.Unmarshal(value, &cached)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to prune SBOMs: %w", err)
+		logger.LogDebug(ctx.Context, "Failed to look up cached response", "url", url, "error", err)
+		return cached, false
 	}
+	return cached, ok
+}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+// StoreResponse caches the response of the endpoint url (write-through)
+func (c *Cache) StoreResponse(ctx tcontext.TransferMetadata, url string, cached CachedResponse) error {
+	if c.store == nil {
+		return fmt.Errorf("cache store not initialized")
 	}
 
-	logger.LogDebug(ctx.Context, "Cleared old SBOMs", "output_adapter", outputAdapter, "method", method, "repo", repo)
+	value, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to cache response: %w", err)
+	}
+	if err := c.store.Put(ctx.Context, responsesNamespace, url, value); err != nil {
+		return fmt.Errorf("failed to cache response: %w", err)
+	}
 	return nil
 }
@@ -21,8 +21,10 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	githublib "github.com/google/go-github/v62/github"
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/source"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
@@ -43,22 +45,28 @@ type GitHubSBOMResponse struct {
 // Asset represents a GitHub release asset (e.g., SBOM files)
 type Asset struct {
 	Name        string `json:"name"`
+	APIURL      string `json:"url"`
 	DownloadURL string `json:"browser_download_url"`
 	Size        int    `json:"size"`
 }
 
 // Release represents a GitHub release containing assets
 type Release struct {
-	TagName string  `json:"tag_name"`
-	Assets  []Asset `json:"assets"`
+	TagName     string    `json:"tag_name"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []Asset   `json:"assets"`
 }
 
 // SBOMAsset represents an SBOM file found in a GitHub release
 type SBOMAsset struct {
 	Release     string
 	Name        string
+	APIURL      string // asset API URL, which also serves private repositories' assets to a token
 	DownloadURL string
 	Size        int
+
+	// Signatures are the signature assets of the SBOM in the release, keyed by suffix
+	Signatures map[string]SBOMAsset
 }
 
 // VersionedSBOMs maps versions to their respective SBOMs in that version
@@ -66,8 +74,9 @@ type SBOMAsset struct {
 type VersionedSBOMs map[string][]SBOMData
 
 type SBOMData struct {
-	Content  []byte
-	Filename string
+	Content    []byte
+	Filename   string
+	Signatures map[string][]byte // signature files downloaded with --verify-signatures, keyed by suffix
 }
 
 // Client interacts with the GitHub API
@@ -79,23 +88,34 @@ type Client struct {
 	Owner        string
 	Repo         string
 	Version      string
+	Releases     *ReleaseFilter // narrows the releases of the "*" version
 	Method       string
 	Branch       string
 	Token        string
+
+	// IncludeRepos, ExcludeRepos and MaxRepos stop the listing of an organization's repositories
+	// once MaxRepos of them pass the filters, 0 lists them all
+	IncludeRepos []string
+	ExcludeRepos []string
+	MaxRepos     int
 }
 
 // NewClient initializes a GitHub client
 func NewClient(g *GithubConfig) *Client {
 	return &Client{
-		httpClient: &http.Client{},
-		BaseURL:    "https://api.github.com",
-		RepoURL:    g.URL,
-		Version:    g.Version,
-		Method:     g.Method,
-		Owner:      g.Owner,
-		Repo:       g.Repo,
-		Branch:     g.Branch,
-		Token:      g.Token,
+		httpClient:   &http.Client{Transport: g.limits.transport(nil)},
+		BaseURL:      g.apiURL(),
+		RepoURL:      g.URL,
+		Version:      g.Version,
+		Releases:     g.Releases,
+		Method:       g.Method,
+		Owner:        g.Owner,
+		Repo:         g.Repo,
+		Branch:       g.Branch,
+		Token:        g.Token,
+		IncludeRepos: g.IncludeRepos,
+		ExcludeRepos: g.ExcludeRepos,
+		MaxRepos:     g.MaxRepos,
 	}
 }
 
@@ -105,7 +125,13 @@ func NewClient(g *GithubConfig) *Client {
 func (c *Client) FindSBOMs(ctx tcontext.TransferMetadata) ([]SBOMAsset, error) {
 	logger.LogDebug(ctx.Context, "Fetching SBOMs from GitHub releases", "repo_url", c.RepoURL, "owner", c.Owner, "repo", c.Repo)
 
-	releases, err := c.GetReleases(ctx, c.Owner, c.Repo)
+	var releases []Release
+	var err error
+	if c.Version == "*" {
+		releases, err = c.GetAllReleases(ctx, c.Owner, c.Repo)
+	} else {
+		releases, err = c.GetReleases(ctx, c.Owner, c.Repo)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving releases: %w", err)
 	}
@@ -117,12 +143,15 @@ func (c *Client) FindSBOMs(ctx tcontext.TransferMetadata) ([]SBOMAsset, error) {
 	// Select target releases (single version or all versions)
 	targetReleases := c.filterReleases(releases, c.Version)
 	if len(targetReleases) == 0 {
+		if c.Version == "*" {
+			return nil, fmt.Errorf("no matching release found among %s", c.Releases)
+		}
 		return nil, fmt.Errorf("no matching release found for version: %s", c.Version)
 	}
 	logger.LogDebug(ctx.Context, "Total Releases from SBOM is fetched", "value", len(targetReleases))
 
 	// Extract SBOM assets from target release
-	sboms := c.extractSBOMs(targetReleases)
+	sboms := c.extractSBOMs(ctx, targetReleases)
 
 	if len(sboms) == 0 {
 		logger.LogInfo(ctx.Context, "error", "sboms", 0, "repo", c.Repo, "owner", c.Owner)
@@ -136,8 +165,14 @@ func (c *Client) FindSBOMs(ctx tcontext.TransferMetadata) ([]SBOMAsset, error) {
 // filterReleases filters releases based on version input
 func (c *Client) filterReleases(releases []Release, version string) []Release {
 	if version == "*" {
-		// Return all refilterReleasesleases
-		return releases
+		// Return all releases, or those the release filter selects
+		var selected []Release
+		for _, release := range releases {
+			if c.Releases.Allows(release) {
+				selected = append(selected, release)
+			}
+		}
+		return selected
 	}
 	if version == "latest" {
 		// Return latest release
@@ -154,16 +189,22 @@ func (c *Client) filterReleases(releases []Release, version string) []Release {
 }
 
 // extractSBOMs extracts SBOM assets from releases
-func (c *Client) extractSBOMs(releases []Release) []SBOMAsset {
+func (c *Client) extractSBOMs(ctx tcontext.TransferMetadata, releases []Release) []SBOMAsset {
 	var sboms []SBOMAsset
 	for _, release := range releases {
+		signatures := signatureAssets(release)
 		for _, asset := range release.Assets {
-			if source.DetectSBOMsFile(asset.Name) {
+			if source.IsSignature(asset.Name) {
+				continue
+			}
+			if source.DetectSBOMsFile(asset.Name) && source.AllowsFormatName(ctx, asset.Name) {
 				sboms = append(sboms, SBOMAsset{
 					Release:     release.TagName,
 					Name:        asset.Name,
+					APIURL:      asset.APIURL,
 					DownloadURL: asset.DownloadURL,
 					Size:        asset.Size,
+					Signatures:  signatures[asset.Name],
 				})
 			}
 		}
@@ -171,21 +212,69 @@ func (c *Client) extractSBOMs(releases []Release) []SBOMAsset {
 	return sboms
 }
 
-// GetReleases fetches all releases for a repository
+// signatureAssets returns the signature assets of a release by the name of the SBOM they sign
+func signatureAssets(release Release) map[string]map[string]SBOMAsset {
+	signatures := make(map[string]map[string]SBOMAsset)
+	for _, asset := range release.Assets {
+		sbomName, suffix, ok := source.SignatureOf(asset.Name)
+		if !ok {
+			continue
+		}
+		if signatures[sbomName] == nil {
+			signatures[sbomName] = make(map[string]SBOMAsset)
+		}
+		signatures[sbomName][suffix] = SBOMAsset{
+			Release:     release.TagName,
+			Name:        asset.Name,
+			APIURL:      asset.APIURL,
+			DownloadURL: asset.DownloadURL,
+			Size:        asset.Size,
+		}
+	}
+	return signatures
+}
+
+// GetReleases fetches the most recent releases of a repository, as listed on the first page
 func (c *Client) GetReleases(ctx tcontext.TransferMetadata, owner, repo string) ([]Release, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/releases", c.BaseURL, owner, repo)
 	logger.LogDebug(ctx.Context, "Constructed GitHub Releases", "url", url)
 
-	req, err := http.NewRequestWithContext(ctx.Context, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+	releases, _, err := c.getReleasesPage(ctx, url, owner, repo)
+	return releases, err
+}
+
+// GetAllReleases fetches every release of a repository, newest first, following the
+// pagination of the releases API
+func (c *Client) GetAllReleases(ctx tcontext.TransferMetadata, owner, repo string) ([]Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=100", c.BaseURL, owner, repo)
+
+	var releases []Release
+	for page := 1; url != ""; page++ {
+		logger.LogDebug(ctx.Context, "Fetching releases page", "repo", repo, "page", page)
+
+		pageReleases, next, err := c.getReleasesPage(ctx, url, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, pageReleases...)
+		url = next
 	}
 
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	logger.LogDebug(ctx.Context, "Fetched all releases", "repo", repo, "total", len(releases))
+	return releases, nil
+}
+
+// getReleasesPage fetches one page of releases, returning the URL of the next page, or ""
+// for the last one
+func (c *Client) getReleasesPage(ctx tcontext.TransferMetadata, url, owner, repo string) ([]Release, string, error) {
+	req, err := c.newRequest(ctx, url, "application/vnd.github.v3+json")
+	if err != nil {
+		return nil, "", fmt.Errorf("creating request: %w", err)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+		return nil, "", fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 	// logger.LogDebug(ctx, "Response ", "body", resp.Body)
@@ -193,7 +282,7 @@ func (c *Client) GetReleases(ctx tcontext.TransferMetadata, owner, repo string)
 	// Read response body for error reporting
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response body failed: %w", err)
+		return nil, "", fmt.Errorf("reading response body failed: %w", err)
 	}
 
 	// Handle different status codes with specific error messages
@@ -202,21 +291,21 @@ func (c *Client) GetReleases(ctx tcontext.TransferMetadata, owner, repo string)
 	case http.StatusOK:
 		var releases []Release
 		if err := json.Unmarshal(body, &releases); err != nil {
-			return nil, fmt.Errorf("parsing response: %w", err)
+			return nil, "", fmt.Errorf("parsing response: %w", err)
 		}
-		return releases, nil
+		return releases, parseLinkHeader(resp.Header.Get("Link"))["next"], nil
 
 	case http.StatusNotFound:
-		return nil, fmt.Errorf("repository %s/%s not found or no releases available", owner, repo)
+		return nil, "", errdefs.NotFound(fmt.Errorf("repository %s/%s not found or no releases available", owner, repo))
 
 	case http.StatusUnauthorized:
-		return nil, fmt.Errorf("authentication required or invalid token for %s/%s", owner, repo)
+		return nil, "", errdefs.Auth(fmt.Errorf("authentication required or invalid token for %s/%s", owner, repo))
 
 	case http.StatusForbidden:
 		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
-			return nil, fmt.Errorf("GitHub API rate limit exceeded")
+			return nil, "", errdefs.RateLimit(fmt.Errorf("GitHub API rate limit exceeded"))
 		}
-		return nil, fmt.Errorf("access forbidden to %s/%s", owner, repo)
+		return nil, "", errdefs.Auth(fmt.Errorf("access forbidden to %s/%s", owner, repo))
 
 	default:
 		// Try to parse GitHub error message
@@ -224,15 +313,21 @@ func (c *Client) GetReleases(ctx tcontext.TransferMetadata, owner, repo string)
 			Message string `json:"message"`
 		}
 		if err := json.Unmarshal(body, &ghErr); err == nil && ghErr.Message != "" {
-			return nil, fmt.Errorf("GitHub API error: %s", ghErr.Message)
+			return nil, "", errdefs.FromResponse(resp, fmt.Errorf("GitHub API error: %s", ghErr.Message))
 		}
-		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		return nil, "", errdefs.FromResponse(resp, fmt.Errorf("GitHub API returned status %d", resp.StatusCode))
 	}
 }
 
-// DownloadAsset downloads a release asset from download url of SBOM
-func (c *Client) DownloadAsset(ctx tcontext.TransferMetadata, downloadURL string) (io.ReadCloser, error) {
-	req, err := http.NewRequestWithContext(ctx.Context, "GET", downloadURL, nil)
+// DownloadAsset downloads a release asset of SBOM. With a token it's fetched through the
+// asset API, as the browser download URL of a private repository doesn't accept tokens.
+func (c *Client) DownloadAsset(ctx tcontext.TransferMetadata, asset SBOMAsset) (io.ReadCloser, error) {
+	downloadURL, accept := asset.DownloadURL, ""
+	if c.Token != "" && asset.APIURL != "" {
+		downloadURL, accept = asset.APIURL, "application/octet-stream"
+	}
+
+	req, err := c.newRequest(ctx, downloadURL, accept)
 	if err != nil {
 		return nil, fmt.Errorf("creating request failed: %w", err)
 	}
@@ -244,7 +339,7 @@ func (c *Client) DownloadAsset(ctx tcontext.TransferMetadata, downloadURL string
 
 	if resp.StatusCode != http.StatusOK {
 		resp.Body.Close()
-		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		return nil, errdefs.FromResponse(resp, fmt.Errorf("GitHub API returned status %d", resp.StatusCode))
 	}
 
 	return resp.Body, nil
@@ -259,7 +354,7 @@ func (c *Client) FetchSBOMFromReleases(ctx tcontext.TransferMetadata) (Versioned
 		return nil, fmt.Errorf("finding SBOMs: %w", err)
 	}
 	if len(sboms) == 0 {
-		return nil, fmt.Errorf("no SBOMs found in repository")
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in repository"))
 	}
 
 	logger.LogDebug(ctx.Context, "Total SBOMs found in the repository release page", "version", c.Version, "total sboms", len(sboms))
@@ -311,13 +406,24 @@ func (c *Client) downloadSBOMs(ctx tcontext.TransferMetadata, sboms []SBOMAsset)
 			}
 
 			// now check the spec and format of a downloaded SBOM files from github
-			if source.IsSBOMFile(sbomData) {
+			if source.IsSBOM(ctx, sbom.Name, sbomData) {
 				totalSBOMsWithCorrectFormatAndSpec++
 				versionedSBOM := SBOMData{
 					Content:  sbomData,
 					Filename: sbom.Name,
 				}
 
+				// signatures are only downloaded to be verified
+				if source.FetchesSignatures(ctx) {
+					versionedSBOM.Signatures, err = c.downloadSignatures(ctx, sbom)
+					if err != nil {
+						mu.Lock()
+						errors = append(errors, fmt.Errorf("downloading signatures of %s: %w", sbom.Name, err))
+						mu.Unlock()
+						return
+					}
+				}
+
 				mu.Lock()
 				versionedSBOMs[sbom.Release] = append(versionedSBOMs[sbom.Release], versionedSBOM)
 				mu.Unlock()
@@ -340,7 +446,7 @@ func (c *Client) downloadSBOMs(ctx tcontext.TransferMetadata, sboms []SBOMAsset)
 
 // downloadSingleSBOM downloads a single SBOM and stores it in memory
 func (c *Client) downloadSingleSBOM(ctx tcontext.TransferMetadata, sbom SBOMAsset) ([]byte, error) {
-	reader, err := c.DownloadAsset(ctx, sbom.DownloadURL)
+	reader, err := c.DownloadAsset(ctx, sbom)
 	if err != nil {
 		return nil, fmt.Errorf("downloading asset: %w", err)
 	}
@@ -356,12 +462,27 @@ func (c *Client) downloadSingleSBOM(ctx tcontext.TransferMetadata, sbom SBOMAsse
 	return sbomData, nil
 }
 
-func (c *Client) FetchSBOMFromAPI(ctx tcontext.TransferMetadata) ([]byte, error) {
-	owner, repo, err := source.ParseGitHubURL(c.RepoURL)
-	if err != nil {
-		return nil, fmt.Errorf("parsing GitHub URL: %w", err)
+// downloadSignatures downloads the signature assets of an SBOM, keyed by suffix
+func (c *Client) downloadSignatures(ctx tcontext.TransferMetadata, sbom SBOMAsset) (map[string][]byte, error) {
+	if len(sbom.Signatures) == 0 {
+		return nil, nil
 	}
 
+	signatures := make(map[string][]byte, len(sbom.Signatures))
+	for suffix, asset := range sbom.Signatures {
+		data, err := c.downloadSingleSBOM(ctx, asset)
+		if err != nil {
+			return nil, err
+		}
+		signatures[suffix] = data
+	}
+	return signatures, nil
+}
+
+func (c *Client) FetchSBOMFromAPI(ctx tcontext.TransferMetadata) ([]byte, error) {
+	// GitHub Enterprise repository URLs have no github.com prefix to parse owner and repo from
+	owner, repo := c.Owner, c.Repo
+
 	logger.LogDebug(ctx.Context, "Fetching SBOM Details", "repository", repo, "owner", owner, "repo_url", c.RepoURL)
 
 	// Construct the API URL for the SBOM export
@@ -369,19 +490,11 @@ func (c *Client) FetchSBOMFromAPI(ctx tcontext.TransferMetadata) ([]byte, error)
 	logger.LogDebug(ctx.Context, "Fetching SBOM via GitHub API", "url", url)
 
 	// Create request
-	req, err := http.NewRequestWithContext(ctx.Context, "GET", url, nil)
+	req, err := c.newRequest(ctx, url, "application/vnd.github.v3+json")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Add authentication only if a token is provided
-	if c.Token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
-	}
-
-	// Set required headers
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-
 	// Perform the request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -392,7 +505,7 @@ func (c *Client) FetchSBOMFromAPI(ctx tcontext.TransferMetadata) ([]byte, error)
 	// Handle non-200 responses
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, errdefs.FromResponse(resp, fmt.Errorf("GitHub API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	// Read response body
@@ -418,55 +531,83 @@ func (c *Client) FetchSBOMFromAPI(ctx tcontext.TransferMetadata) ([]byte, error)
 	return response.SBOM, nil
 }
 
+// newRequest creates a GET request to GitHub, authenticated with the token when one is set
+func (c *Client) newRequest(ctx tcontext.TransferMetadata, url, accept string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx.Context, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	return req, nil
+}
+
 func (c *Client) updateRepo(repo string) {
 	c.Repo = repo
-	c.RepoURL = fmt.Sprintf("https://github.com/%s/%s", c.Owner, repo)
+	c.RepoURL = fmt.Sprintf("%s/%s/%s", webURL(c.BaseURL), c.Owner, repo)
 }
 
 // GetAllRepositories fetches all repositories for the organization specified in c.Owner.
-// It also handles pagination to ensure all repositories are retrieved.
+// It follows the pagination, stopping after the page holding the MaxRepos-th repository
+// that passes the include/exclude filters when MaxRepos is set.
 func (c *Client) GetAllRepositories(ctx tcontext.TransferMetadata) ([]string, error) {
 	if c.Repo != "" {
 		return []string{c.Repo}, nil
 	}
 	logger.LogDebug(ctx.Context, "Fetching all repositories for an organization", "name", c.Owner)
 
-	baseURL := fmt.Sprintf("https://api.github.com/orgs/%s/repos", c.Owner)
+	baseURL := fmt.Sprintf("%s/orgs/%s/repos", c.BaseURL, c.Owner)
 	apiURL := baseURL + "?per_page=100"
 
-	var allRepos []map[string]interface{}
+	var repoNames []string
+	kept := 0
 	page := 1
 	for {
 		logger.LogDebug(ctx.Context, "Fetching repository page", "org", c.Owner, "page", page)
 
-		req, err := http.NewRequestWithContext(ctx.Context, "GET", apiURL, nil)
+		req, err := c.newRequest(ctx, apiURL, "application/vnd.github.v3+json")
 		if err != nil {
 			return nil, fmt.Errorf("creating request for page %d: %w", page, err)
 		}
 
-		if c.Token != "" {
-			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
-		}
-		req.Header.Set("Accept", "application/vnd.github.v3+json")
-
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			return nil, fmt.Errorf("fetching repositories for page %d: %w", page, err)
 		}
-		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusOK {
 			body, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("GitHub API returned status %d for page %d: %s", resp.StatusCode, page, string(body))
+			resp.Body.Close()
+			return nil, errdefs.FromResponse(resp, fmt.Errorf("GitHub API returned status %d for page %d: %s", resp.StatusCode, page, string(body)))
 		}
 
 		var repos []map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&repos); err != nil {
+		err = json.NewDecoder(resp.Body).Decode(&repos)
+		resp.Body.Close()
+		if err != nil {
 			return nil, fmt.Errorf("decoding response for page %d: %w", page, err)
 		}
 
-		logger.LogDebug(ctx.Context, "Fetched repository page", "org", c.Owner, "page", page, "repos_fetched", len(repos), "total_so_far", len(allRepos)+len(repos))
-		allRepos = append(allRepos, repos...)
+		var pageNames []string
+		for _, r := range repos {
+			if name, ok := r["name"].(string); ok {
+				pageNames = append(pageNames, name)
+			}
+		}
+		repoNames = append(repoNames, pageNames...)
+		logger.LogDebug(ctx.Context, "Fetched repository page", "org", c.Owner, "page", page, "repos_fetched", len(repos), "total_so_far", len(repoNames))
+
+		if c.MaxRepos > 0 {
+			kept += len(c.applyRepoFilters(ctx, pageNames, c.IncludeRepos, c.ExcludeRepos))
+			if kept >= c.MaxRepos {
+				logger.LogDebug(ctx.Context, "Stopped listing repositories at --in-github-max-repos", "org", c.Owner, "page", page, "max_repos", c.MaxRepos)
+				break
+			}
+		}
 
 		// Check for pagination via Link header
 		linkHeader := resp.Header.Get("Link")
@@ -485,14 +626,7 @@ func (c *Client) GetAllRepositories(ctx tcontext.TransferMetadata) ([]string, er
 		}
 	}
 
-	logger.LogInfo(ctx.Context, "Completed fetching repositories", "org", c.Owner, "total_repos", len(allRepos))
-
-	var repoNames []string
-	for _, r := range allRepos {
-		if name, ok := r["name"].(string); ok {
-			repoNames = append(repoNames, name)
-		}
-	}
+	logger.LogInfo(ctx.Context, "Completed fetching repositories", "org", c.Owner, "total_repos", len(repoNames))
 
 	if len(repoNames) == 0 {
 		return nil, fmt.Errorf("no repositories found for organization %s", c.Owner)
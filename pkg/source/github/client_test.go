@@ -0,0 +1,328 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllReleasesFollowsPagination(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+
+	server := &releaseServer{pageSize: 100}
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 250; i++ {
+		server.publish(int64(i), fmt.Sprintf("v%d", i), base.Add(time.Duration(i)*time.Hour))
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	client := &Client{httpClient: &http.Client{}, BaseURL: httpServer.URL, Version: "*"}
+	releases, err := client.GetAllReleases(*ctx, "o", "r")
+	require.NoError(t, err)
+	require.Len(t, releases, 250)
+	assert.Equal(t, "v250", releases[0].TagName)
+	assert.Equal(t, "v1", releases[249].TagName)
+
+	assert.Len(t, client.filterReleases(releases, "*"), 250)
+	assert.Equal(t, []Release{releases[0]}, client.filterReleases(releases, "latest"))
+}
+
+func TestMaxReposStopsListingRepositories(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+
+	// 350 repositories, listed reposPerPage at a time
+	var mu sync.Mutex
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		pages++
+		mu.Unlock()
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		var repos []map[string]string
+		for i := (page-1)*reposPerPage + 1; i <= min(page*reposPerPage, 350); i++ {
+			repos = append(repos, map[string]string{"name": fmt.Sprintf("repo-%d", i)})
+		}
+		if page*reposPerPage < 350 {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/orgs/o/repos?per_page=100&page=%d>; rel="next"`, r.Host, page+1))
+		}
+		json.NewEncoder(w).Encode(repos)
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name     string
+		config   GithubConfig
+		pages    int
+		repos    int
+		resolved int
+		first    string
+	}{
+		{"no cap", GithubConfig{}, 4, 350, 350, "repo-1"},
+		{"cap within the first page", GithubConfig{MaxRepos: 100}, 1, 100, 100, "repo-1"},
+		{"cap within the second page", GithubConfig{MaxRepos: 150}, 2, 200, 150, "repo-1"},
+		{"excluded repository", GithubConfig{MaxRepos: 100, ExcludeRepos: []string{"repo-1"}}, 2, 200, 100, "repo-2"},
+		{"included repositories", GithubConfig{MaxRepos: 2, IncludeRepos: []string{"repo-5", "repo-340"}}, 4, 350, 2, "repo-5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.APIURL = server.URL
+			config.Owner = "o"
+			config.limits = newRateLimiter(0)
+			config.client = NewClient(&config)
+
+			pages = 0
+			repos, err := config.client.GetAllRepositories(*ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.pages, pages)
+			assert.Len(t, repos, tt.repos)
+
+			resolved, err := resolveRepos(*ctx, &config)
+			require.NoError(t, err)
+			assert.Len(t, resolved, tt.resolved)
+			assert.Equal(t, tt.first, resolved[0])
+		})
+	}
+}
+
+func TestReleaseFilter(t *testing.T) {
+	published := func(tag, date string) Release {
+		at, _ := time.Parse("2006-01-02", date)
+		return Release{TagName: tag, PublishedAt: at}
+	}
+	releases := []Release{
+		published("v3.0.0", "2024-06-01"),
+		published("v2.1.0", "2024-03-01"),
+		published("nightly", "2024-02-15"),
+		published("v2.0.0", "2023-12-01"),
+		published("v1.9.0", "2023-06-01"),
+	}
+
+	tests := []struct {
+		name         string
+		since        string
+		versionRange string
+		want         []string
+	}{
+		{name: "none", want: []string{"v3.0.0", "v2.1.0", "nightly", "v2.0.0", "v1.9.0"}},
+		{name: "since", since: "2024-01-01", want: []string{"v3.0.0", "v2.1.0", "nightly"}},
+		{name: "range", versionRange: ">=v2.0.0 <v3.0.0", want: []string{"v2.1.0", "v2.0.0"}},
+		{name: "range without prefix", versionRange: ">=2.0.0 <3.0.0", want: []string{"v2.1.0", "v2.0.0"}},
+		{name: "since and range", since: "2024-01-01", versionRange: ">=v2.0.0 <v3.0.0", want: []string{"v2.1.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseReleaseFilter(tt.since, tt.versionRange)
+			require.NoError(t, err)
+
+			client := &Client{Releases: filter}
+			var tags []string
+			for _, release := range client.filterReleases(releases, "*") {
+				tags = append(tags, release.TagName)
+			}
+			assert.Equal(t, tt.want, tags)
+		})
+	}
+
+	_, err := ParseReleaseFilter("01/02/2024", "")
+	assert.Error(t, err)
+	_, err = ParseReleaseFilter("", ">=banana")
+	assert.Error(t, err)
+}
+
+func TestPrivateReleaseAssetsUseToken(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+
+	// a private repository answers only authenticated API requests, and its browser
+	// download URLs don't accept tokens
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || strings.HasPrefix(r.URL.Path, "/download/") {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/o/r/releases":
+			fmt.Fprintf(w, `[{"tag_name":"v1.0.0","assets":[{"name":"r.cdx.json","url":"%[1]s/repos/o/r/releases/assets/7","browser_download_url":"%[1]s/download/r.cdx.json"}]}]`, server.URL)
+		case "/repos/o/r/releases/assets/7":
+			assert.Equal(t, "application/octet-stream", r.Header.Get("Accept"))
+			fmt.Fprint(w, `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := &Client{httpClient: &http.Client{}, BaseURL: server.URL, Owner: "o", Repo: "r", Version: "latest", Token: "secret"}
+	sboms, err := client.FindSBOMs(*ctx)
+	require.NoError(t, err)
+	require.Len(t, sboms, 1)
+
+	data, err := client.downloadSingleSBOM(*ctx, sboms[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "CycloneDX")
+
+	client.Token = ""
+	_, err = client.GetReleases(*ctx, "o", "r")
+	assert.Error(t, err)
+}
+
+func TestReleaseSignaturesAttachedToSBOMs(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+
+	release := Release{TagName: "v1.0.0", Assets: []Asset{
+		{Name: "app.cdx.json", APIURL: "https://api/1"},
+		{Name: "app.cdx.json.sig", APIURL: "https://api/2"},
+		{Name: "app.cdx.json.pem", APIURL: "https://api/3"},
+		{Name: "lib.spdx.json", APIURL: "https://api/4"},
+		{Name: "lib.spdx.json.intoto.jsonl", APIURL: "https://api/5"},
+		{Name: "unrelated.sig", APIURL: "https://api/6"},
+	}}
+
+	client := &Client{}
+	sboms := client.extractSBOMs(*ctx, []Release{release})
+	require.Len(t, sboms, 2)
+
+	assert.Equal(t, "app.cdx.json", sboms[0].Name)
+	require.Len(t, sboms[0].Signatures, 2)
+	assert.Equal(t, "https://api/2", sboms[0].Signatures[".sig"].APIURL)
+	assert.Equal(t, "https://api/3", sboms[0].Signatures[".pem"].APIURL)
+
+	assert.Equal(t, "lib.spdx.json", sboms[1].Name)
+	require.Len(t, sboms[1].Signatures, 1)
+	assert.Equal(t, "https://api/5", sboms[1].Signatures[".intoto.jsonl"].APIURL)
+}
+
+func TestEnterpriseURLs(t *testing.T) {
+	assert.Equal(t, "https://api.github.com", APIURLFor("https://github.com/interlynk-io/sbomqs"))
+	assert.Equal(t, "https://ghe.example.com/api/v3", APIURLFor("https://ghe.example.com/platform/api"))
+
+	assert.Equal(t, "https://github.com", webURL("https://api.github.com"))
+	assert.Equal(t, "https://ghe.example.com", webURL("https://ghe.example.com/api/v3"))
+
+	client := NewClient(&GithubConfig{APIURL: "https://ghe.example.com/api/v3/", Owner: "platform"})
+	assert.Equal(t, "https://ghe.example.com/api/v3", client.BaseURL)
+	client.updateRepo("api")
+	assert.Equal(t, "https://ghe.example.com/platform/api", client.RepoURL)
+}
+
+func TestRateLimitWaitsForReset(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+
+	// the first listing exhausts the rate limit, the second one must wait for its reset
+	var calls int
+	var reset time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			reset = time.Now().Add(time.Second).Truncate(time.Second).Add(time.Second)
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+			fmt.Fprint(w, `[{"tag_name":"v1"}]`)
+		default:
+			assert.False(t, time.Now().Before(reset), "request sent before the rate limit reset")
+			w.Header().Set("X-RateLimit-Remaining", "4999")
+			fmt.Fprint(w, `[{"tag_name":"v1"}]`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(&GithubConfig{APIURL: server.URL, limits: newRateLimiter(0)})
+	_, err := client.GetReleases(*ctx, "o", "r")
+	require.NoError(t, err)
+	_, err = client.GetReleases(*ctx, "o", "r")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRateLimitRetriesRejectedRequest(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"You have exceeded a secondary rate limit"}`)
+			return
+		}
+		fmt.Fprint(w, `[{"tag_name":"v1"}]`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(&GithubConfig{APIURL: server.URL, limits: newRateLimiter(0)})
+	start := time.Now()
+	releases, err := client.GetReleases(*ctx, "o", "r")
+	require.NoError(t, err)
+	assert.Len(t, releases, 1)
+	assert.Equal(t, 2, calls)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	// a 403 with calls left is a permission error, not retried
+	calls = 10
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-RateLimit-Remaining", "4000")
+		w.WriteHeader(http.StatusForbidden)
+	})
+	_, err = client.GetReleases(*ctx, "o", "r")
+	assert.ErrorContains(t, err, "access forbidden")
+	assert.Equal(t, 11, calls)
+}
+
+func TestExhaustedRateLimitIsRateLimitError(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+
+	// without a reset time the rejected requests are retried at once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"API rate limit exceeded"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(&GithubConfig{APIURL: server.URL, Owner: "o", Repo: "r", limits: newRateLimiter(0)})
+	_, err := client.GetReleases(*ctx, "o", "r")
+	assert.Equal(t, errdefs.KindRateLimit, errdefs.KindOf(err))
+	_, err = client.FetchSBOMFromAPI(*ctx)
+	assert.Equal(t, errdefs.KindRateLimit, errdefs.KindOf(err))
+
+	// a 403 with calls left is a permission error
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4000")
+		w.WriteHeader(http.StatusForbidden)
+	})
+	_, err = client.FetchSBOMFromAPI(*ctx)
+	assert.Equal(t, errdefs.KindAuth, errdefs.KindOf(err))
+}
@@ -18,6 +18,7 @@ package github
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	githublib "github.com/google/go-github/v62/github"
@@ -27,28 +28,39 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// defaultAPIURL is the REST API of github.com
+const defaultAPIURL = "https://api.github.com"
+
 type GithubConfig struct {
 	URL            string
+	APIURL         string // REST API base URL, that of a GitHub Enterprise Server is https://<host>/api/v3
 	Repo           string
 	Owner          string
+	Owners         []string // organizations of a multi-organization transfer, empty for a single --in-github-url
 	Version        string
+	Releases       *ReleaseFilter // selects releases by date and version range with the "*" version
 	Branch         string
 	Method         string
-	BinaryPath     string
+	Generator      *Generator // SBOM generating tool of the tool and auto methods
 	client         *Client
 	Token          string
 	IncludeRepos   []string
 	ExcludeRepos   []string
+	MaxRepos       int // caps the repositories of an organization transfer, 0 means no cap
 	ProcessingMode types.ProcessingMode
 	Daemon         bool
 	Poll           int64
 	AssetWaitDelay int64
+	NoGenCache     bool
+	MaxRPS         float64      // caps the requests per second to the GitHub API, 0 means no cap
+	limits         *rateLimiter // shared by every client of the transfer
 }
 
 func NewGithubConfig() *GithubConfig {
 	return &GithubConfig{
+		APIURL:         defaultAPIURL,
 		Method:         "",
-		BinaryPath:     "",
+		Generator:      &Generator{Tool: Tools["syft"]},
 		client:         nil,
 		Token:          "",
 		IncludeRepos:   []string{},
@@ -57,6 +69,7 @@ func NewGithubConfig() *GithubConfig {
 		Daemon:         false,
 		Poll:           60,
 		AssetWaitDelay: 180,
+		limits:         newRateLimiter(0),
 	}
 }
 
@@ -111,19 +124,55 @@ func (c *GithubConfig) SetProcessingMode(mode types.ProcessingMode) {
 	c.ProcessingMode = mode
 }
 
-// GetGitHubClient initializes and returns a GitHub API client.
-func (c *GithubConfig) GetGitHubClient(ctx tcontext.TransferMetadata) (*githublib.Client, error) {
-	logger.LogDebug(ctx.Context, "Initializing GitHub client", "has_token", c.Token != "")
+// apiURL returns the REST API base URL of the config, without a trailing slash
+func (c *GithubConfig) apiURL() string {
+	if c.APIURL == "" {
+		return defaultAPIURL
+	}
+	return strings.TrimSuffix(c.APIURL, "/")
+}
+
+// APIURLFor returns the REST API base URL serving a GitHub URL: api.github.com for
+// github.com, and https://<host>/api/v3 for a GitHub Enterprise Server
+func APIURLFor(githubURL string) string {
+	u, err := url.Parse(githubURL)
+	if err != nil || u.Host == "" || strings.EqualFold(u.Hostname(), "github.com") || strings.EqualFold(u.Hostname(), "www.github.com") {
+		return defaultAPIURL
+	}
+	return fmt.Sprintf("%s://%s/api/v3", u.Scheme, u.Host)
+}
+
+// webURL returns the base URL of the repositories served by the REST API at apiURL, used to
+// build repository URLs and clone them
+func webURL(apiURL string) string {
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Host == "" || strings.EqualFold(u.Hostname(), "api.github.com") {
+		return "https://github.com"
+	}
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+}
+
+// GetGitHubClient initializes and returns a GitHub API client. With a cache, GET requests
+// to the REST API are made conditional on the ETag of the previous response, so unchanged
+// payloads come back as 304s that don't consume the rate limit.
+func (c *GithubConfig) GetGitHubClient(ctx tcontext.TransferMetadata, cache *Cache) (*githublib.Client, error) {
+	logger.LogDebug(ctx.Context, "Initializing GitHub client", "has_token", c.Token != "", "conditional_requests", cache != nil)
 
-	// create HTTP client
+	// create HTTP client, paced by the rate limiter of the transfer
 	var tc *http.Client
 	if c.Token != "" {
 		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.Token})
-		tc := oauth2.NewClient(ctx.Context, ts)
-		client := githublib.NewClient(tc)
+		tc := &http.Client{Transport: &oauth2.Transport{Source: ts, Base: c.limits.transport(nil)}}
+		if cache != nil {
+			tc.Transport = newConditionalTransport(ctx, tc.Transport, cache, c.apiURL())
+		}
+		client, err := c.newGitHubClient(tc)
+		if err != nil {
+			return nil, err
+		}
 
 		// Verify token by making a simple API call
-		_, _, err := client.Users.Get(ctx.Context, "")
+		_, _, err = client.Users.Get(ctx.Context, "")
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Failed to validate GitHub token")
 			return nil, fmt.Errorf("invalid GitHub token: %w", err)
@@ -132,13 +181,34 @@ func (c *GithubConfig) GetGitHubClient(ctx tcontext.TransferMetadata) (*githubli
 	}
 
 	// unauthenticated client
-	tc = &http.Client{}
-	client := githublib.NewClient(tc)
+	tc = &http.Client{Transport: c.limits.transport(nil)}
+	if cache != nil {
+		tc.Transport = newConditionalTransport(ctx, tc.Transport, cache, c.apiURL())
+	}
+	client, err := c.newGitHubClient(tc)
+	if err != nil {
+		return nil, err
+	}
 	logger.LogDebug(ctx.Context, "Using unauthenticated GitHub client; rate limit is 60 requests/hour. Provide a token for 5000 requests/hour.")
 
 	return client, nil
 }
 
+// newGitHubClient returns a go-github client sending its requests to the REST API of the config
+func (c *GithubConfig) newGitHubClient(tc *http.Client) (*githublib.Client, error) {
+	client := githublib.NewClient(tc)
+	if c.apiURL() == defaultAPIURL {
+		return client, nil
+	}
+
+	baseURL, err := url.Parse(c.apiURL() + "/")
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub API URL %s: %w", c.APIURL, err)
+	}
+	client.BaseURL = baseURL
+	return client, nil
+}
+
 // applyRepoFilters filters repositories based on inclusion/exclusion flags
 func (g *GithubConfig) applyRepoFilters(ctx tcontext.TransferMetadata, repos []string) []string {
 	logger.LogDebug(ctx.Context, "applying repository filters by", "including", g.IncludeRepos, "excluding", g.ExcludeRepos)
@@ -186,3 +256,44 @@ func (g *GithubConfig) applyRepoFilters(ctx tcontext.TransferMetadata, repos []s
 	logger.LogDebug(ctx.Context, "filtered repositories", "filtered", filteredRepos)
 	return filteredRepos
 }
+
+// forOwner returns a copy of the config fetching from the organization owner alone, with
+// the include/exclude filters that apply to it
+func (c *GithubConfig) forOwner(owner string) *GithubConfig {
+	orgCfg := *c
+	orgCfg.Owners = nil
+	orgCfg.Owner = owner
+	orgCfg.Repo = ""
+	orgCfg.URL = webURL(c.apiURL()) + "/" + owner
+	orgCfg.IncludeRepos = reposOfOwner(c.IncludeRepos, owner)
+	orgCfg.ExcludeRepos = reposOfOwner(c.ExcludeRepos, owner)
+	orgCfg.client = NewClient(&orgCfg)
+	return &orgCfg
+}
+
+// reposOfOwner returns the repository names of a filter list that apply to the organization
+// owner: unqualified names apply to every organization, "org/repo" names only to their own
+func reposOfOwner(repos []string, owner string) []string {
+	var names []string
+	for _, repo := range repos {
+		repo = strings.TrimSpace(repo)
+		org, name, qualified := strings.Cut(repo, "/")
+		if !qualified {
+			names = append(names, repo)
+			continue
+		}
+		if strings.EqualFold(org, owner) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// capRepos keeps the first MaxRepos repositories, in the order GitHub lists them
+func (g *GithubConfig) capRepos(ctx tcontext.TransferMetadata, repos []string) []string {
+	if g.MaxRepos <= 0 || len(repos) <= g.MaxRepos {
+		return repos
+	}
+	logger.LogInfo(ctx.Context, "Repositories capped by --in-github-max-repos", "org", g.Owner, "repos", len(repos), "max_repos", g.MaxRepos)
+	return repos[:g.MaxRepos]
+}
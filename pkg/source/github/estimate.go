@@ -0,0 +1,94 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// Estimate lists the SBOMs a transfer would fetch per repository. With the release method
+// these are the matching release assets and their sizes; the api and tool methods produce one
+// SBOM per repository whose size isn't known before it's generated.
+func (g *GitHubAdapter) Estimate(ctx tcontext.TransferMetadata) ([]types.SBOMCandidate, error) {
+	if len(g.Config.Owners) <= 1 {
+		return estimateRepos(ctx, g.Config)
+	}
+
+	var candidates []types.SBOMCandidate
+	for _, owner := range g.Config.Owners {
+		orgCandidates, err := estimateRepos(ctx, g.Config.forOwner(owner))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			logger.LogInfo(ctx.Context, "Failed to list SBOMs of organization", "org", owner, "error", err)
+			continue
+		}
+		candidates = append(candidates, orgCandidates...)
+	}
+	return candidates, nil
+}
+
+// estimateRepos lists the SBOMs of the repositories of a single URL
+func estimateRepos(ctx tcontext.TransferMetadata, config *GithubConfig) ([]types.SBOMCandidate, error) {
+	repos, err := resolveRepos(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []types.SBOMCandidate
+	for _, repo := range repos {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		config.client.updateRepo(repo)
+		namespace := fmt.Sprintf("%s/%s", config.Owner, repo)
+
+		method := GitHubMethod(config.Method)
+		if method == MethodReleases || method == MethodAuto {
+			assets, err := config.client.FindSBOMs(ctx)
+			if err != nil {
+				logger.LogDebug(ctx.Context, "Failed to list release SBOMs", "repo", repo, "error", err)
+			}
+			for _, asset := range assets {
+				candidates = append(candidates, types.SBOMCandidate{
+					Name:      asset.Release + "/" + asset.Name,
+					Namespace: namespace,
+					Size:      int64(asset.Size),
+				})
+			}
+			if method == MethodReleases || len(assets) > 0 {
+				continue
+			}
+		}
+
+		// the api and tool methods, and auto without release SBOMs, produce one SBOM per repository
+		name := "dependency-graph-sbom.json"
+		if method == MethodTool {
+			name = config.Generator.Filename()
+		}
+		candidates = append(candidates, types.SBOMCandidate{
+			Name:      name,
+			Namespace: namespace,
+		})
+	}
+
+	return candidates, nil
+}
@@ -17,11 +17,13 @@ package github
 
 import (
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 	"golang.org/x/time/rate"
 )
@@ -30,12 +32,27 @@ type SBOMFetcher interface {
 	Fetch(ctx tcontext.TransferMetadata, config *GithubConfig) (iterator.SBOMIterator, error)
 }
 
+// SequentialFetcher fetches the repositories one after the other, as the SBOMs are consumed
 type SequentialFetcher struct{}
 
 func (f *SequentialFetcher) Fetch(ctx tcontext.TransferMetadata, config *GithubConfig) (iterator.SBOMIterator, error) {
-	// Implement the logic to fetch SBOMs sequentially
 	logger.LogDebug(ctx.Context, "Fetching SBOMs Sequentially")
 
+	filterdRepos, err := resolveRepos(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.LogDebug(ctx.Context, "Total repos from which SBOMs will be fetched", "count", len(filterdRepos), "repos", filterdRepos)
+	logger.LogDebug(ctx.Context, "Processing Mode", "strategy", config.ProcessingMode)
+
+	// repositories are fetched lazily by the iterator, one at a time
+	return NewGitHubIterator(ctx, config, filterdRepos), nil
+}
+
+// resolveRepos returns the repository of --in-github-url, or all repositories of the
+// organization left after applying the include/exclude filters
+func resolveRepos(ctx tcontext.TransferMetadata, config *GithubConfig) ([]string, error) {
 	var filterdRepos []string
 
 	if config.Repo == "" && config.Owner != "" {
@@ -53,6 +70,7 @@ func (f *SequentialFetcher) Fetch(ctx tcontext.TransferMetadata, config *GithubC
 		if len(filterdRepos) == 0 {
 			return nil, fmt.Errorf("no repositories found post filtering")
 		}
+		filterdRepos = config.capRepos(ctx, filterdRepos)
 	}
 
 	if config.Repo != "" {
@@ -63,70 +81,11 @@ func (f *SequentialFetcher) Fetch(ctx tcontext.TransferMetadata, config *GithubC
 		return nil, fmt.Errorf("no repositories found")
 	}
 
-	logger.LogDebug(ctx.Context, "Total repos from which SBOMs will be fetched", "count", len(filterdRepos), "repos", filterdRepos)
-	logger.LogDebug(ctx.Context, "Processing Mode", "strategy", config.ProcessingMode)
-
-	var sbomList []*iterator.SBOM
-	giter := &GitHubIterator{client: config.client, binaryPath: config.BinaryPath}
-
-	// Iterate over repositories one by one (sequential processing)
-	for _, repo := range filterdRepos {
-		giter.client.updateRepo(repo)
-
-		logger.LogDebug(ctx.Context, "Repository", "value", repo)
-
-		switch GitHubMethod(config.Method) {
-
-		case MethodAPI:
-
-			releaseSBOM, err := giter.fetchSBOMFromAPI(ctx)
-			if err != nil {
-				logger.LogDebug(ctx.Context, "Failed to fetch SBOMs from API Method for", "repo", repo, "error", err)
-				continue
-			}
-			if len(releaseSBOM) > 0 {
-				sbomList = append(sbomList, releaseSBOM...)
-			}
-
-		case MethodReleases:
-
-			releaseSBOMs, err := giter.fetchSBOMFromReleases(ctx)
-			if err != nil {
-				logger.LogDebug(ctx.Context, "Failed to fetch SBOMs from Release Method for", "repo", repo, "error", err)
-				continue
-			}
-			if len(releaseSBOMs) > 0 {
-				sbomList = append(sbomList, releaseSBOMs...)
-			}
-
-		case MethodTool:
-
-			releaseSBOM, err := giter.fetchSBOMFromTool(ctx)
-			if err != nil {
-				logger.LogDebug(ctx.Context, "Failed to generate SBOMs via Tool Method for", "repo", repo, "error", err)
-				continue
-			}
-
-			if len(releaseSBOM) > 0 {
-				sbomList = append(sbomList, releaseSBOM...)
-			}
-
-		default:
-			return nil, fmt.Errorf("unsupported GitHub method: %s", config.Method)
-		}
-
-	}
-
-	if len(sbomList) == 0 {
-		return nil, fmt.Errorf("no SBOMs found for any repository")
-	}
-	logger.LogDebug(ctx.Context, "Total SBOMs fetched from all repos", "count", len(sbomList))
-
-	return &GitHubIterator{
-		sboms: sbomList,
-	}, nil
+	return filterdRepos, nil
 }
 
+// ParallelFetcher fetches the repositories with a pool of workers, streaming their SBOMs
+// through a bounded channel so workers wait for the SBOMs to be consumed
 type ParallelFetcher struct{}
 
 func (f *ParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *GithubConfig) (iterator.SBOMIterator, error) {
@@ -143,6 +102,7 @@ func (f *ParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *GithubCon
 	if len(repos) == 0 {
 		return nil, fmt.Errorf("no repositories left after applying filters")
 	}
+	repos = config.capRepos(ctx, repos)
 
 	logger.LogDebug(ctx.Context, "Total repos from which SBOMs will be fetched", "count", len(repos), "repos", repos)
 	logger.LogDebug(ctx.Context, "Processing Mode", "strategy", config.ProcessingMode)
@@ -150,8 +110,15 @@ func (f *ParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *GithubCon
 	const maxWorkers = 5
 	const requestsPerSecond = 5
 
+	// Distribute repositories to workers
 	repoChan := make(chan string, len(repos))
-	sbomsChan := make(chan []*iterator.SBOM, len(repos))
+	for _, repo := range repos {
+		repoChan <- repo
+	}
+	close(repoChan)
+
+	// at most one SBOM per worker waits to be consumed
+	sbomChan := make(chan *iterator.SBOM, maxWorkers)
 
 	var wg sync.WaitGroup
 
@@ -163,74 +130,165 @@ func (f *ParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *GithubCon
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+
+			// each worker points its own copy of the client at the repository it fetches
+			client := *config.client
+			worker := &GitHubIterator{
+				client:    &client,
+				method:    GitHubMethod(config.Method),
+				generator: config.Generator,
+				genCache:  NewGenCache(config.NoGenCache, config.Generator),
+			}
+
 			for repo := range repoChan {
+				// drain the remaining repos once cancelled
+				if ctx.Err() != nil {
+					continue
+				}
+
 				// Apply rate limiting
 				if err := limiter.Wait(ctx.Context); err != nil {
 					logger.LogDebug(ctx.Context, "Rate limiter error", "repo", repo, "error", err)
 					continue
 				}
 
-				config.client.updateRepo(repo)
-				iter := NewGitHubIterator(ctx, config, repo)
-
-				var repoSboms []*iterator.SBOM
-				var err error
+				repoSboms, err := worker.fetchRepo(ctx, repo)
+				if err != nil || len(repoSboms) == 0 {
+					logger.LogInfo(ctx.Context, "Skipping SBOMs due to fetch error or no SBOMs found", "repo", repo, "error", err)
+					continue
+				}
+				logger.LogDebug(ctx.Context, "Fetched SBOMs", "repo", repo, "method", config.Method, "count", len(repoSboms))
 
-				switch GitHubMethod(config.Method) {
-				case MethodAPI:
-					repoSboms, err = iter.fetchSBOMFromAPI(ctx)
-					if err == nil {
-						logger.LogDebug(ctx.Context, "Total SBOM fetched from API method", "count", len(repoSboms), "repo", repo, "error", err)
+				for _, sbom := range repoSboms {
+					select {
+					case sbomChan <- sbom:
+					case <-ctx.Done():
 					}
+				}
+			}
+		}()
+	}
 
-				case MethodReleases:
-					repoSboms, err = iter.fetchSBOMFromReleases(ctx)
-					if err == nil {
-						logger.LogDebug(ctx.Context, "Total SBOM fetched from release method", "count", len(repoSboms), "repo", repo, "error", err)
-					}
+	// close the results channel once all workers completed
+	go func() {
+		wg.Wait()
+		close(sbomChan)
+	}()
 
-				case MethodTool:
-					repoSboms, err = iter.fetchSBOMFromTool(ctx)
-					if err == nil {
-						logger.LogDebug(ctx.Context, "Total SBOM fetched from tool method", "count", len(repoSboms), "repo", repo, "error", err)
-					}
+	return &ParallelIterator{sbomChan: sbomChan}, nil
+}
 
-				default:
-					logger.LogInfo(ctx.Context, "Unsupported method", "repo", repo, "method", config.Method)
-					err = fmt.Errorf("unsupported method: %s", config.Method)
-				}
+// ParallelIterator returns the SBOMs of the ParallelFetcher workers as they are fetched
+type ParallelIterator struct {
+	sbomChan <-chan *iterator.SBOM
+	fetched  int
+}
 
-				// only send SBOMs if fetch succeeded (no error)
-				if err == nil && len(repoSboms) > 0 {
-					logger.LogDebug(ctx.Context, "Fetched SBOMs", "repo", repo, "count", len(repoSboms))
-					sbomsChan <- repoSboms
-				} else {
-					logger.LogInfo(ctx.Context, "Skipping SBOMs due to fetch error or no SBOMs found", "repo", repo, "error", err)
+func (it *ParallelIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
+	select {
+	case sbom, ok := <-it.sbomChan:
+		if ok {
+			it.fetched++
+			return sbom, nil
+		}
+		if ctx.Err() != nil {
+			return nil, source.FetchInterrupted(ctx, it.fetched)
+		}
+		if it.fetched == 0 {
+			logger.LogInfo(ctx.Context, "No SBOMs found for any repository")
+		}
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, source.FetchInterrupted(ctx, it.fetched)
+	}
+}
+
+// MultiOrgFetcher fetches the SBOMs of several organizations, one after the other, with the
+// fetcher of the processing mode, and merges them into a single iterator
+type MultiOrgFetcher struct {
+	inner SBOMFetcher
+}
+
+// orgResult is what a multi-organization transfer fetched from one organization
+type orgResult struct {
+	owner string
+	repos int
+	sboms int
+	err   error
+}
+
+func (f *MultiOrgFetcher) Fetch(ctx tcontext.TransferMetadata, config *GithubConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs of multiple organizations", "orgs", config.Owners)
+
+	// organizations are fetched lazily, once the SBOMs of the previous one were consumed
+	return &MultiOrgIterator{inner: f.inner, config: config, owners: config.Owners}, nil
+}
+
+// MultiOrgIterator chains the iterators of the organizations of a MultiOrgFetcher
+type MultiOrgIterator struct {
+	inner   SBOMFetcher
+	config  *GithubConfig
+	owners  []string              // Organizations not fetched yet
+	current iterator.SBOMIterator // Iterator of the organization being fetched
+	result  orgResult
+	repos   map[string]bool
+	fetched int
+}
+
+func (it *MultiOrgIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
+	for {
+		if it.current == nil {
+			if len(it.owners) == 0 {
+				if it.fetched == 0 {
+					logger.LogInfo(ctx.Context, "No SBOMs found for any organization")
 				}
+				return nil, io.EOF
+			}
+			if ctx.Err() != nil {
+				return nil, source.FetchInterrupted(ctx, it.fetched)
 			}
-		}()
-	}
 
-	// Distribute repositories to workers
-	for _, repo := range repos {
-		repoChan <- repo
-	}
-	close(repoChan)
+			owner := it.owners[0]
+			it.owners = it.owners[1:]
+			it.result = orgResult{owner: owner}
+			it.repos = map[string]bool{}
+
+			iter, err := it.inner.Fetch(ctx, it.config.forOwner(owner))
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, source.FetchInterrupted(ctx, it.fetched)
+				}
+				logger.LogInfo(ctx.Context, "Failed to fetch SBOMs of organization", "org", owner, "error", err)
+				it.result.err = err
+				it.result.log(ctx)
+				continue
+			}
+			it.current = iter
+		}
 
-	// Wait for all workers to complete and close the results channel
-	wg.Wait()
-	close(sbomsChan)
+		sbom, err := it.current.Next(ctx)
+		if err == io.EOF {
+			it.result.repos = len(it.repos)
+			it.result.log(ctx)
+			it.current = nil
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
 
-	// Collect all SBOMs
-	var finalSbomList []*iterator.SBOM
-	for repoSboms := range sbomsChan {
-		finalSbomList = append(finalSbomList, repoSboms...)
+		it.repos[sbom.Namespace] = true
+		it.result.sboms++
+		it.fetched++
+		return sbom, nil
 	}
+}
 
-	if len(finalSbomList) == 0 {
-		return nil, fmt.Errorf("no SBOMs found for any repository")
+// log reports what was fetched from the organization
+func (r orgResult) log(ctx tcontext.TransferMetadata) {
+	if r.err != nil {
+		logger.LogInfo(ctx.Context, "Organization fetched", "org", r.owner, "repos", r.repos, "sboms", r.sboms, "error", r.err)
+		return
 	}
-	logger.LogDebug(ctx.Context, "Total SBOMs fetched from all repos", "count", len(finalSbomList))
-
-	return &GitHubIterator{sboms: finalSbomList}, nil
+	logger.LogInfo(ctx.Context, "Organization fetched", "org", r.owner, "repos", r.repos, "sboms", r.sboms)
 }
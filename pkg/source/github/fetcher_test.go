@@ -0,0 +1,131 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dependencyGraphServer serves the repositories of organization "o" and their dependency
+// graph SBOMs, recording which repositories were fetched
+type dependencyGraphServer struct {
+	mu      sync.Mutex
+	repos   []string
+	missing string // repository without a dependency graph
+	fetched []string
+}
+
+func (s *dependencyGraphServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/orgs/o/repos" {
+		var names []string
+		for _, repo := range s.repos {
+			names = append(names, fmt.Sprintf(`{"name":%q}`, repo))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(names, ","))
+		return
+	}
+
+	repo := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/o/"), "/dependency-graph/sbom")
+	s.mu.Lock()
+	s.fetched = append(s.fetched, repo)
+	s.mu.Unlock()
+
+	if repo == s.missing {
+		http.NotFound(w, r)
+		return
+	}
+	fmt.Fprintf(w, `{"sbom":{"spdxVersion":"SPDX-2.3","name":%q}}`, repo)
+}
+
+func (s *dependencyGraphServer) fetchedRepos() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.fetched...)
+}
+
+func newDependencyGraphConfig(t *testing.T, server *dependencyGraphServer) *GithubConfig {
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	config := &GithubConfig{APIURL: httpServer.URL, Owner: "o", Method: string(MethodAPI), NoGenCache: true, limits: newRateLimiter(0)}
+	config.client = NewClient(config)
+	return config
+}
+
+func TestSequentialFetcherStreamsRepos(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+
+	server := &dependencyGraphServer{repos: []string{"a", "b", "c"}, missing: "b"}
+	config := newDependencyGraphConfig(t, server)
+
+	iter, err := (&SequentialFetcher{}).Fetch(*ctx, config)
+	require.NoError(t, err)
+	assert.Empty(t, server.fetchedRepos(), "SBOMs fetched before being consumed")
+
+	sbom, err := iter.Next(*ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "o/a", sbom.Namespace)
+	assert.Equal(t, []string{"a"}, server.fetchedRepos())
+
+	// repositories without SBOMs are skipped
+	sbom, err = iter.Next(*ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "o/c", sbom.Namespace)
+	assert.Equal(t, []string{"a", "b", "c"}, server.fetchedRepos())
+
+	_, err = iter.Next(*ctx)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestParallelFetcherStreamsRepos(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+
+	server := &dependencyGraphServer{missing: "r3"}
+	for i := 0; i < 20; i++ {
+		server.repos = append(server.repos, fmt.Sprintf("r%d", i))
+	}
+	config := newDependencyGraphConfig(t, server)
+
+	iter, err := (&ParallelFetcher{}).Fetch(*ctx, config)
+	require.NoError(t, err)
+
+	namespaces := map[string]bool{}
+	for {
+		sbom, err := iter.Next(*ctx)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.False(t, namespaces[sbom.Namespace], "repository fetched twice")
+		namespaces[sbom.Namespace] = true
+	}
+
+	// each worker fetched its repositories with its own client
+	assert.Len(t, namespaces, 19)
+	assert.False(t, namespaces["o/r3"])
+	assert.True(t, namespaces["o/r19"])
+	assert.Len(t, server.fetchedRepos(), 20)
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// GenCache stores SBOMs generated by the tool method on disk, keyed by commit SHA and tool,
+// so repeated runs against an unchanged commit skip cloning and running the tool.
+// A nil *GenCache is valid and behaves as a disabled cache.
+type GenCache struct {
+	dir  string
+	tool string // suffix of the cache files of tools other than the unpinned Syft
+}
+
+// NewGenCache returns the cache of the SBOMs generator generates, rooted at
+// ~/.sbommv/gen-cache, or nil when caching is disabled or the home directory can't be resolved.
+func NewGenCache(disabled bool, generator *Generator) *GenCache {
+	if disabled {
+		return nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return nil
+	}
+
+	// unpinned Syft SBOMs keep the <sha>.json files of the Syft-only releases
+	cache := &GenCache{dir: filepath.Join(home, ".sbommv", "gen-cache")}
+	if generator != nil && (generator.Tool.Name != "syft" || generator.Version != "") {
+		cache.tool = "." + strings.ReplaceAll(generator.String(), "@", "-")
+	}
+	return cache
+}
+
+// path returns the cache file for the given repository commit
+func (c *GenCache) path(owner, repo, sha string) string {
+	return filepath.Join(c.dir, owner, repo, sha+c.tool+".json")
+}
+
+// Get returns the cached SBOM generated for owner/repo at sha, if present
+func (c *GenCache) Get(ctx tcontext.TransferMetadata, owner, repo, sha string) ([]byte, bool) {
+	if c == nil || sha == "" {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.path(owner, repo, sha))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.LogDebug(ctx.Context, "Failed to read generated SBOM cache", "repo", repo, "commit", sha, "error", err)
+		}
+		return nil, false
+	}
+
+	if len(data) == 0 {
+		return nil, false
+	}
+
+	logger.LogDebug(ctx.Context, "Generated SBOM cache hit", "owner", owner, "repo", repo, "commit", sha)
+	return data, true
+}
+
+// Put stores the SBOM generated for owner/repo at sha. Failures are logged and ignored,
+// since the cache is only an optimization.
+func (c *GenCache) Put(ctx tcontext.TransferMetadata, owner, repo, sha string, data []byte) {
+	if c == nil || sha == "" || len(data) == 0 {
+		return
+	}
+
+	target := c.path(owner, repo, sha)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		logger.LogDebug(ctx.Context, "Failed to create generated SBOM cache directory", "path", filepath.Dir(target), "error", err)
+		return
+	}
+
+	// write to a temp file first so a concurrent reader never sees a partial SBOM
+	tmp, err := os.CreateTemp(filepath.Dir(target), sha+".*.tmp")
+	if err != nil {
+		logger.LogDebug(ctx.Context, "Failed to create generated SBOM cache file", "path", target, "error", err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		logger.LogDebug(ctx.Context, "Failed to write generated SBOM cache file", "path", target, "error", err)
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		logger.LogDebug(ctx.Context, "Failed to write generated SBOM cache file", "path", target, "error", err)
+		return
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		logger.LogDebug(ctx.Context, "Failed to store generated SBOM in cache", "path", target, "error", err)
+		return
+	}
+
+	logger.LogDebug(ctx.Context, "Stored generated SBOM in cache", "owner", owner, "repo", repo, "commit", sha)
+}
+
+// resolveRemoteSHA returns the commit SHA the branch (or the default branch when empty)
+// points to, using `git ls-remote` so no clone is needed.
+func resolveRemoteSHA(ctx tcontext.TransferMetadata, repoURL, branch string) (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", fmt.Errorf("git is not installed")
+	}
+
+	ref := "HEAD"
+	if branch != "" {
+		ref = "refs/heads/" + branch
+	}
+
+	cmd := exec.CommandContext(ctx.Context, "git", "ls-remote", repoURL, ref)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git ls-remote failed: %w, stderr: %s", err, stderr.String())
+	}
+
+	fields := strings.Fields(stdout.String())
+	if len(fields) == 0 {
+		return "", fmt.Errorf("ref %s not found in %s", ref, repoURL)
+	}
+
+	return fields[0], nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// maxCachedResponseSize bounds the responses kept for conditional requests; larger ones,
+// such as dependency graph SBOMs of big repositories, are always fetched in full
+const maxCachedResponseSize = 5 << 20
+
+// conditionalTransport sends conditional requests (If-None-Match / If-Modified-Since) to the
+// GitHub REST API, using the validators of the last response stored in the cache. A 304 reply
+// doesn't count against the rate limit and is answered with the cached body as a 200, so
+// callers such as go-github see an ordinary response.
+type conditionalTransport struct {
+	ctx   tcontext.TransferMetadata
+	base  http.RoundTripper
+	cache *Cache
+	host  string // host of the REST API, the only one whose responses are cached
+
+	hits atomic.Int64
+}
+
+func newConditionalTransport(ctx tcontext.TransferMetadata, base http.RoundTripper, cache *Cache, apiURL string) *conditionalTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	host := "api.github.com"
+	if u, err := url.Parse(apiURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return &conditionalTransport{ctx: ctx, base: base, cache: cache, host: host}
+}
+
+func (t *conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.URL.Host != t.host {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	cached, ok := t.cache.LookupResponse(t.ctx, key)
+	if ok {
+		req = req.Clone(req.Context())
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		resp.Body.Close()
+		hits := t.hits.Add(1)
+		logger.LogDebug(t.ctx.Context, "GitHub response not modified, using cached copy", "url", key, "cache_hits", hits)
+
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK"
+		resp.Header.Set("Content-Type", "application/json")
+		resp.Body = io.NopCloser(bytes.NewReader(cached.Body))
+		resp.ContentLength = int64(len(cached.Body))
+		return resp, nil
+
+	case resp.StatusCode == http.StatusOK:
+		etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+		if etag == "" && lastModified == "" {
+			return resp, nil
+		}
+		if !strings.Contains(resp.Header.Get("Content-Type"), "json") || resp.ContentLength > maxCachedResponseSize {
+			return resp, nil
+		}
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedResponseSize+1))
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		if len(body) > maxCachedResponseSize {
+			// too large to cache, hand back the full body
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+			return resp, nil
+		}
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err := t.cache.StoreResponse(t.ctx, key, CachedResponse{ETag: etag, LastModified: lastModified, Body: body}); err != nil {
+			logger.LogDebug(t.ctx.Context, "Failed to cache GitHub response", "url", key, "error", err)
+		}
+	}
+	return resp, nil
+}
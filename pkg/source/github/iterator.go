@@ -22,42 +22,88 @@ import (
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 )
 
-// // GitHubIterator iterates over SBOMs fetched from GitHub (API, Release, Tool)
+// GitHubIterator streams the SBOMs of a list of repositories (API, Release, Tool). A repository
+// is only fetched once the SBOMs of the previous one were consumed, so memory holds the SBOMs
+// of a single repository however many the organization has.
 type GitHubIterator struct {
-	client     *Client
-	sboms      []*iterator.SBOM // Stores all fetched SBOMs
-	position   int              // Tracks iteration position
-	binaryPath string
+	client    *Client
+	method    GitHubMethod
+	repos     []string         // Repositories not fetched yet
+	sboms     []*iterator.SBOM // SBOMs of the current repository not returned yet
+	fetched   int              // SBOMs returned so far
+	generator *Generator       // SBOM generating tool, installed on first use
+	genCache  *GenCache        // Generated SBOMs keyed by commit SHA, nil when disabled
 }
 
-// NewGitHubIterator initializes and returns a new GitHubIterator instance
-func NewGitHubIterator(ctx tcontext.TransferMetadata, g *GithubConfig, repo string) *GitHubIterator {
-	logger.LogDebug(ctx.Context, "Initializing GitHub Iterator", "repo", g.URL, "method", g.Method, "repo", repo)
+// NewGitHubIterator initializes and returns a new GitHubIterator instance over the repositories,
+// without fetching any SBOMs
+func NewGitHubIterator(ctx tcontext.TransferMetadata, g *GithubConfig, repos []string) *GitHubIterator {
+	logger.LogDebug(ctx.Context, "Initializing GitHub Iterator", "url", g.URL, "method", g.Method, "repos", len(repos))
 
-	g.client.updateRepo(repo)
-
-	// Create and return the iterator instance without fetching SBOMs
 	return &GitHubIterator{
-		client:     g.client,
-		sboms:      []*iterator.SBOM{},
-		binaryPath: g.BinaryPath,
+		client:    g.client,
+		method:    GitHubMethod(g.Method),
+		repos:     repos,
+		generator: g.Generator,
+		genCache:  NewGenCache(g.NoGenCache, g.Generator),
 	}
 }
 
-// Next returns the next SBOM from the stored list
+// Next returns the next SBOM of the current repository, fetching the next repository with
+// SBOMs once it has none left
 func (it *GitHubIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
-	if it.position >= len(it.sboms) {
-		return nil, io.EOF // No more SBOMs left
+	for len(it.sboms) == 0 {
+		if len(it.repos) == 0 {
+			if it.fetched == 0 {
+				logger.LogInfo(ctx.Context, "No SBOMs found for any repository")
+			}
+			return nil, io.EOF // No more SBOMs left
+		}
+		if ctx.Err() != nil {
+			return nil, source.FetchInterrupted(ctx, it.fetched)
+		}
+
+		repo := it.repos[0]
+		it.repos = it.repos[1:]
+
+		sboms, err := it.fetchRepo(ctx, repo)
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Failed to fetch SBOMs", "repo", repo, "method", it.method, "error", err)
+			continue
+		}
+		logger.LogDebug(ctx.Context, "Fetched SBOMs", "repo", repo, "method", it.method, "count", len(sboms))
+		it.sboms = sboms
 	}
 
-	sbom := it.sboms[it.position]
-	it.position++ // Move to the next SBOM
+	sbom := it.sboms[0]
+	it.sboms[0] = nil // release the SBOM once returned
+	it.sboms = it.sboms[1:]
+	it.fetched++
 	return sbom, nil
 }
 
+// fetchRepo fetches the SBOMs of a repository with the method of the iterator
+func (it *GitHubIterator) fetchRepo(ctx tcontext.TransferMetadata, repo string) ([]*iterator.SBOM, error) {
+	it.client.updateRepo(repo)
+
+	switch it.method {
+	case MethodAPI:
+		return it.fetchSBOMFromAPI(ctx)
+	case MethodReleases:
+		return it.fetchSBOMFromReleases(ctx)
+	case MethodTool:
+		return it.fetchSBOMFromTool(ctx)
+	case MethodAuto:
+		return it.fetchSBOMAuto(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported GitHub method: %s", it.method)
+	}
+}
+
 type GithubWatcherIterator struct {
 	sbomChan chan *iterator.SBOM
 }
@@ -106,14 +152,22 @@ func (it *GitHubIterator) fetchSBOMFromReleases(ctx tcontext.TransferMetadata) (
 	var sbomSlice []*iterator.SBOM
 
 	for version, sbomDataList := range sbomFiles {
+		// with all versions, each release becomes its own project version at the destination
+		var annotations *iterator.Annotations
+		if it.client.Version == "*" {
+			annotations = &iterator.Annotations{ProjectVersion: version}
+		}
+
 		for _, sbomData := range sbomDataList { // sbomPath is a string (file path)
 			sbomSlice = append(sbomSlice, &iterator.SBOM{
 				Path: sbomData.Filename,
 				Data: sbomData.Content,
 
 				// namespace as owner/repo, where SBOM are present
-				Namespace: fmt.Sprintf("%s/%s", it.client.Owner, it.client.Repo),
-				Version:   version,
+				Namespace:   fmt.Sprintf("%s/%s", it.client.Owner, it.client.Repo),
+				Version:     version,
+				Annotations: annotations,
+				Signatures:  sbomData.Signatures,
 			})
 		}
 	}
@@ -126,18 +180,36 @@ func (it *GitHubIterator) fetchSBOMFromTool(ctx tcontext.TransferMetadata) ([]*i
 
 	var sbomSlice []*iterator.SBOM
 
-	// Clone the repository
-	repoDir := filepath.Join(os.TempDir(), fmt.Sprintf("%s-%s", it.client.Repo, it.client.Version))
-	defer os.RemoveAll(repoDir)
-
-	if err := CloneRepoWithGit(ctx, it.client.RepoURL, it.client.Branch, repoDir); err != nil {
-		return nil, fmt.Errorf("failed to clone the repository: %w", err)
+	// resolve the commit being scanned so a previously generated SBOM can be reused
+	var commitSHA string
+	if it.genCache != nil {
+		sha, err := resolveRemoteSHA(ctx, it.client.RepoURL, it.client.Branch)
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Failed to resolve commit SHA, skipping generated SBOM cache", "repo", it.client.Repo, "error", err)
+		}
+		commitSHA = sha
 	}
 
-	// Generate SBOM and save in memory
-	sbomBytes, err := GenerateSBOM(ctx, repoDir, it.binaryPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate SBOM: %w", err)
+	sbomBytes, cached := it.genCache.Get(ctx, it.client.Owner, it.client.Repo, commitSHA)
+	if cached {
+		logger.LogInfo(ctx.Context, "Reusing cached generated SBOM", "repo", it.client.Repo, "commit", commitSHA)
+	} else {
+		// Clone the repository
+		repoDir := filepath.Join(os.TempDir(), fmt.Sprintf("%s-%s", it.client.Repo, it.client.Version))
+		defer os.RemoveAll(repoDir)
+
+		if err := CloneRepoWithGit(ctx, it.client.RepoURL, it.client.Branch, repoDir); err != nil {
+			return nil, fmt.Errorf("failed to clone the repository: %w", err)
+		}
+
+		// Generate SBOM and save in memory
+		generated, err := it.generator.Generate(ctx, repoDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate SBOM: %w", err)
+		}
+		sbomBytes = generated
+
+		it.genCache.Put(ctx, it.client.Owner, it.client.Repo, commitSHA, sbomBytes)
 	}
 
 	// TODO(cleanup later): Write SBOM to a file for testing to see its content
@@ -147,10 +219,10 @@ func (it *GitHubIterator) fetchSBOMFromTool(ctx tcontext.TransferMetadata) ([]*i
 	// }
 
 	if len(sbomBytes) == 0 {
-		return nil, fmt.Errorf("generate SBOM with zero file data: %w", err)
+		return nil, fmt.Errorf("generate SBOM with zero file data")
 	}
 
-	filepath := "syft-generated-sbom.json"
+	filepath := it.generator.Filename()
 	sbomSlice = append(sbomSlice, &iterator.SBOM{
 		Path: filepath,
 		Data: sbomBytes,
@@ -163,3 +235,30 @@ func (it *GitHubIterator) fetchSBOMFromTool(ctx tcontext.TransferMetadata) ([]*i
 	logger.LogDebug(ctx.Context, "SBOM successfully fetched using Tool Method")
 	return sbomSlice, nil
 }
+
+// fetchSBOMAuto tries each method in turn (release, then api, then tool) and returns
+// the SBOMs from the first one that yields any, logging which method was used.
+func (it *GitHubIterator) fetchSBOMAuto(ctx tcontext.TransferMetadata) ([]*iterator.SBOM, error) {
+	releaseSBOMs, err := it.fetchSBOMFromReleases(ctx)
+	if err == nil && len(releaseSBOMs) > 0 {
+		logger.LogInfo(ctx.Context, "Auto method resolved", "repo", it.client.Repo, "method", MethodReleases, "count", len(releaseSBOMs))
+		return releaseSBOMs, nil
+	}
+	logger.LogDebug(ctx.Context, "No SBOMs from release method, falling back", "repo", it.client.Repo, "next", MethodAPI, "error", err)
+
+	apiSBOMs, err := it.fetchSBOMFromAPI(ctx)
+	if err == nil && len(apiSBOMs) > 0 {
+		logger.LogInfo(ctx.Context, "Auto method resolved", "repo", it.client.Repo, "method", MethodAPI, "count", len(apiSBOMs))
+		return apiSBOMs, nil
+	}
+	logger.LogDebug(ctx.Context, "No SBOMs from api method, falling back", "repo", it.client.Repo, "next", MethodTool, "error", err)
+
+	// the tool is installed on first use, only by repos falling back to it
+	toolSBOMs, err := it.fetchSBOMFromTool(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("all methods (release, api, tool) failed: %w", err)
+	}
+	logger.LogInfo(ctx.Context, "Auto method resolved", "repo", it.client.Repo, "method", MethodTool, "count", len(toolSBOMs))
+
+	return toolSBOMs, nil
+}
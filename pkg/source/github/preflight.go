@@ -0,0 +1,129 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// reposPerPage is the page size of repository listings, see GetAllRepositories
+const reposPerPage = 100
+
+// callsPerRepo are the REST API calls fetching the SBOMs of one repository takes. Release
+// assets are only downloaded through the API with a token, and aren't known before listing
+// the releases, so they aren't counted; the tool method clones repositories with git, which
+// doesn't count against the rate limit. The auto method is counted at its worst, listing
+// releases and then reading the dependency graph.
+var callsPerRepo = map[GitHubMethod]int{
+	MethodReleases: 1,
+	MethodAPI:      1,
+	MethodTool:     0,
+	MethodAuto:     2,
+}
+
+// Preflight estimates the REST API calls of the transfer, listing the repositories of each
+// organization, against the rate limit left to the token, or to the IP address without one.
+func (g *GitHubAdapter) Preflight(ctx tcontext.TransferMetadata) ([]types.RateLimitCheck, error) {
+	check, err := g.Config.client.RateLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := []*GithubConfig{g.Config}
+	if len(g.Config.Owners) > 1 {
+		configs = configs[:0]
+		for _, owner := range g.Config.Owners {
+			configs = append(configs, g.Config.forOwner(owner))
+		}
+	}
+
+	for _, config := range configs {
+		calls, err := estimateCalls(ctx, config)
+		if err != nil {
+			return nil, fmt.Errorf("estimating API calls of %s: %w", config.URL, err)
+		}
+		check.Calls += calls
+	}
+
+	logger.LogDebug(ctx.Context, "GitHub preflight", "calls", check.Calls, "remaining", check.Remaining, "limit", check.Limit, "reset", check.Reset)
+	return []types.RateLimitCheck{check}, nil
+}
+
+// estimateCalls returns the API calls of listing and fetching the repositories of one URL
+func estimateCalls(ctx tcontext.TransferMetadata, config *GithubConfig) (int, error) {
+	perRepo := callsPerRepo[GitHubMethod(config.Method)]
+	if config.Repo != "" {
+		return perRepo, nil
+	}
+
+	repos, err := config.client.GetAllRepositories(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get repositories: %w", err)
+	}
+	listing := max(1, (len(repos)+reposPerPage-1)/reposPerPage)
+
+	fetched := len(config.client.applyRepoFilters(ctx, repos, config.IncludeRepos, config.ExcludeRepos))
+	if config.MaxRepos > 0 {
+		fetched = min(fetched, config.MaxRepos)
+	}
+	return listing + fetched*perRepo, nil
+}
+
+// RateLimit returns the core REST API rate limit left. Reading it doesn't count against it.
+func (c *Client) RateLimit(ctx tcontext.TransferMetadata) (types.RateLimitCheck, error) {
+	req, err := c.newRequest(ctx, c.BaseURL+"/rate_limit", "application/vnd.github.v3+json")
+	if err != nil {
+		return types.RateLimitCheck{}, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return types.RateLimitCheck{}, fmt.Errorf("fetching GitHub rate limit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return types.RateLimitCheck{}, errdefs.FromResponse(resp, fmt.Errorf("fetching GitHub rate limit: GitHub API returned status %d", resp.StatusCode))
+	}
+
+	var limits struct {
+		Resources struct {
+			Core struct {
+				Limit     int   `json:"limit"`
+				Remaining int   `json:"remaining"`
+				Reset     int64 `json:"reset"`
+			} `json:"core"`
+		} `json:"resources"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&limits); err != nil {
+		return types.RateLimitCheck{}, fmt.Errorf("parsing GitHub rate limit: %w", err)
+	}
+
+	core := limits.Resources.Core
+	return types.RateLimitCheck{
+		Source:    "github",
+		Remaining: core.Remaining,
+		Limit:     core.Limit,
+		Reset:     time.Unix(core.Reset, 0),
+	}, nil
+}
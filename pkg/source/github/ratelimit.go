@@ -0,0 +1,181 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/retry"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// maxRateLimitRetries bounds the retries of a request GitHub rejected for its rate limit
+	maxRateLimitRetries = 3
+
+	// maxRateLimitWait caps a wait for the rate limit, whose window is an hour
+	maxRateLimitWait = time.Hour
+
+	// secondaryRateLimitWait is the wait GitHub asks for on a secondary rate limit reply
+	// without Retry-After
+	secondaryRateLimitWait = time.Minute
+)
+
+// rateLimiter paces the requests of a transfer to the GitHub API. It's shared by every
+// client of the transfer, so a large organization transfer waits for the rate limit to
+// reset instead of failing midway: requests are held once the last response reported no
+// calls left, and ones rejected for the rate limit are retried after the reset (or the
+// Retry-After delay). With a max RPS, requests are also spaced out client side.
+// A nil *rateLimiter doesn't pace anything.
+type rateLimiter struct {
+	limiter *rate.Limiter // nil without a max RPS
+
+	mu        sync.Mutex
+	remaining int // calls left as of the last response, -1 when unknown
+	reset     time.Time
+}
+
+// newRateLimiter returns a rate limiter allowing at most maxRPS requests per second, 0
+// meaning no cap
+func newRateLimiter(maxRPS float64) *rateLimiter {
+	l := &rateLimiter{remaining: -1}
+	if maxRPS > 0 {
+		l.limiter = rate.NewLimiter(rate.Limit(maxRPS), 1)
+	}
+	return l
+}
+
+// transport returns a round tripper sending requests through base, http.DefaultTransport
+// when nil, paced by the limiter
+func (l *rateLimiter) transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if l == nil {
+		return base
+	}
+	return &rateLimitTransport{limiter: l, base: base}
+}
+
+// wait blocks until a request may be sent
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l.limiter != nil {
+		if err := l.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	l.mu.Lock()
+	exhausted, reset := l.remaining == 0, l.reset
+	l.mu.Unlock()
+	until := time.Until(reset)
+	if !exhausted || until <= 0 {
+		return nil
+	}
+
+	logger.LogInfo(ctx, "GitHub rate limit exhausted, waiting for reset", "reset", reset.Format(time.RFC3339), "wait", until.Round(time.Second))
+	if err := sleep(ctx, min(until+time.Second, maxRateLimitWait)); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	if !l.reset.After(time.Now()) {
+		l.remaining = -1
+	}
+	l.mu.Unlock()
+	return nil
+}
+
+// observe records the rate limit a response reports and, when GitHub rejected the request
+// for it, returns how long to wait before retrying
+func (l *rateLimiter) observe(resp *http.Response) (time.Duration, bool) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	known := err == nil
+	var reset time.Time
+	if seconds, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		reset = time.Unix(seconds, 0)
+	}
+
+	if known {
+		l.mu.Lock()
+		l.remaining, l.reset = remaining, reset
+		l.mu.Unlock()
+	}
+
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	retryAfter := retry.NewHTTPError(resp).RetryAfter
+	switch {
+	case retryAfter > 0:
+		return min(retryAfter, maxRateLimitWait), true
+	case known && remaining == 0:
+		return min(time.Until(reset)+time.Second, maxRateLimitWait), true
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return secondaryRateLimitWait, true
+	}
+	// a 403 with calls left is a permission error
+	return 0, false
+}
+
+// rateLimitTransport is the round tripper of a rateLimiter
+type rateLimitTransport struct {
+	limiter *rateLimiter
+	base    http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		// only requests without a body can be sent again
+		wait, limited := t.limiter.observe(resp)
+		if !limited || attempt == maxRateLimitRetries || req.Body != nil {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		logger.LogInfo(ctx, "GitHub rate limit hit, retrying after wait", "url", req.URL.Path, "status", resp.StatusCode, "attempt", attempt+1, "wait", wait.Round(time.Second))
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleep waits for d, or until ctx is cancelled
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
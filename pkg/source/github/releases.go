@@ -0,0 +1,98 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/blang/semver/v4"
+)
+
+// sinceLayout is the date format of --in-github-since
+const sinceLayout = "2006-01-02"
+
+// rangeVersionPrefix matches the "v" prefix of versions in a range, e.g. ">=v2.0.0"
+var rangeVersionPrefix = regexp.MustCompile(`(^|[\s<>=!])v(\d)`)
+
+// ReleaseFilter selects the releases whose SBOMs are fetched by publication date and by
+// semantic version of the tag. A nil *ReleaseFilter selects every release.
+type ReleaseFilter struct {
+	since        time.Time
+	versionRange semver.Range
+	expr         string
+}
+
+// ParseReleaseFilter builds a filter from a date (YYYY-MM-DD) releases must be published on
+// or after, and a semver range their tag must be in, e.g. ">=v2.0.0 <v3.0.0". Either may be
+// empty. It returns nil when both are.
+func ParseReleaseFilter(since, versionRange string) (*ReleaseFilter, error) {
+	if since == "" && versionRange == "" {
+		return nil, nil
+	}
+
+	filter := &ReleaseFilter{expr: versionRange}
+	if since != "" {
+		date, err := time.Parse(sinceLayout, since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q (must be YYYY-MM-DD)", since)
+		}
+		filter.since = date
+	}
+
+	if versionRange != "" {
+		r, err := semver.ParseRange(rangeVersionPrefix.ReplaceAllString(versionRange, "$1$2"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %q: %w", versionRange, err)
+		}
+		filter.versionRange = r
+	}
+	return filter, nil
+}
+
+// Allows reports whether the release is selected. With a version range, releases whose tag
+// isn't a semantic version, e.g. "nightly", are left out; with a date, unpublished ones are.
+func (f *ReleaseFilter) Allows(release Release) bool {
+	if f == nil {
+		return true
+	}
+
+	if !f.since.IsZero() && (release.PublishedAt.IsZero() || release.PublishedAt.Before(f.since)) {
+		return false
+	}
+
+	if f.versionRange != nil {
+		version, err := semver.ParseTolerant(release.TagName)
+		if err != nil || !f.versionRange(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// String describes the filter for logs and errors
+func (f *ReleaseFilter) String() string {
+	switch {
+	case f == nil:
+		return "all releases"
+	case f.since.IsZero():
+		return fmt.Sprintf("releases in %q", f.expr)
+	case f.versionRange == nil:
+		return "releases since " + f.since.Format(sinceLayout)
+	default:
+		return fmt.Sprintf("releases in %q since %s", f.expr, f.since.Format(sinceLayout))
+	}
+}
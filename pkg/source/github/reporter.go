@@ -50,6 +50,9 @@ func (r *GithubReporter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBO
 		if err == io.EOF {
 			break // No more SBOMs
 		}
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
 			continue
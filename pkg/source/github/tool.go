@@ -20,54 +20,194 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/utils"
 )
 
-// SupportedTools maps tool names to their GitHub repositories
-var SupportedTools = map[string]string{
-	"syft":    "https://github.com/anchore/syft.git",
-	"spdxgen": "https://github.com/spdx/spdx-sbom-generator.git",
+// Tool is an SBOM generating tool the tool method can run on a cloned repository
+type Tool struct {
+	Name string
+
+	// Args is the argument template generating a CycloneDX JSON SBOM, where {dir} is the
+	// repository directory and {output} the file the SBOM is written to
+	Args []string
+
+	// Managed tools are installed by sbommv on first use, the others are looked up in PATH
+	Managed bool
+}
+
+// Tools are the supported SBOM generating tools, by name
+var Tools = map[string]*Tool{
+	"syft": {
+		Name:    "syft",
+		Args:    []string{"scan", "dir:{dir}", "-o", "cyclonedx-json={output}"},
+		Managed: true,
+	},
+	"trivy": {
+		Name: "trivy",
+		Args: []string{"fs", "--quiet", "--format", "cyclonedx", "--output", "{output}", "{dir}"},
+	},
+	"cdxgen": {
+		Name: "cdxgen",
+		Args: []string{"--recurse", "--output", "{output}", "{dir}"},
+	},
+}
+
+// ToolNames returns the names of the supported tools, sorted
+func ToolNames() []string {
+	names := make([]string, 0, len(Tools))
+	for name := range Tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Generator generates SBOMs with a tool, pinned to a version or not. Its binary is
+// resolved on first use, once, so the auto method only installs a tool when a repository
+// falls back to it.
+type Generator struct {
+	Tool    *Tool
+	Version string // pinned tool version, empty for any
+
+	mu         sync.Mutex
+	binaryPath string
+}
+
+// NewGenerator returns the generator of the named tool, syft when empty
+func NewGenerator(name, version string) (*Generator, error) {
+	if name == "" {
+		name = "syft"
+	}
+	tool, ok := Tools[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported SBOM generating tool %q (must be one of: %s)", name, strings.Join(ToolNames(), ", "))
+	}
+	return &Generator{Tool: tool, Version: strings.TrimPrefix(version, "v")}, nil
+}
+
+// String returns the tool name, with its version when pinned
+func (g *Generator) String() string {
+	if g.Version == "" {
+		return g.Tool.Name
+	}
+	return g.Tool.Name + "@" + g.Version
+}
+
+// Filename is the name given to the SBOMs the tool generates
+func (g *Generator) Filename() string {
+	return g.Tool.Name + "-generated-sbom.json"
+}
+
+// Binary returns the path of the tool's binary, installing a managed tool on first use.
+// An unmanaged tool must be in PATH, at the pinned version if any.
+func (g *Generator) Binary() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.binaryPath != "" {
+		return g.binaryPath, nil
+	}
+
+	var binaryPath string
+	var err error
+	if g.Tool.Managed {
+		binaryPath, err = utils.GetBinaryPath(g.Version)
+		if err != nil {
+			return "", fmt.Errorf("failed to get %s binary: %w", g, err)
+		}
+	} else {
+		binaryPath, err = exec.LookPath(g.Tool.Name)
+		if err != nil {
+			return "", fmt.Errorf("%s is not installed, install it or use --in-github-tool=syft: %w", g.Tool.Name, err)
+		}
+		if err := g.checkVersion(binaryPath); err != nil {
+			return "", err
+		}
+	}
+
+	g.binaryPath = binaryPath
+	return binaryPath, nil
 }
 
-func GenerateSBOM(ctx tcontext.TransferMetadata, repoDir, binaryPath string) ([]byte, error) {
-	logger.LogDebug(ctx.Context, "Generating SBOM using Syft", "to_repo_dir", repoDir, "syft_binaryPath", binaryPath)
+// checkVersion verifies the installed tool is the pinned version, if any
+func (g *Generator) checkVersion(binaryPath string) error {
+	if g.Version == "" {
+		return nil
+	}
+	out, err := exec.Command(binaryPath, "--version").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to read %s version: %w", g.Tool.Name, err)
+	}
+	for _, field := range strings.Fields(string(out)) {
+		if strings.TrimPrefix(field, "v") == g.Version {
+			return nil
+		}
+	}
+	return fmt.Errorf("installed %s is not version %s: %s", g.Tool.Name, g.Version, strings.TrimSpace(string(out)))
+}
 
-	// Ensure Syft binary is executable
-	if err := os.Chmod(binaryPath, 0o755); err != nil {
-		return nil, fmt.Errorf("failed to set executable permission for syft: %w", err)
+// args fills the tool's argument template
+func (g *Generator) args(repoDir, output string) []string {
+	replacer := strings.NewReplacer("{dir}", repoDir, "{output}", output)
+	args := make([]string, len(g.Tool.Args))
+	for i, arg := range g.Tool.Args {
+		args[i] = replacer.Replace(arg)
 	}
+	return args
+}
 
-	// repository directory
-	dirFlags := fmt.Sprintf("dir:%s", repoDir)
+// Generate runs the tool on the repository directory, returning the CycloneDX JSON SBOM
+func (g *Generator) Generate(ctx tcontext.TransferMetadata, repoDir string) ([]byte, error) {
+	binaryPath, err := g.Binary()
+	if err != nil {
+		return nil, err
+	}
+	logger.LogDebug(ctx.Context, "Generating SBOM", "tool", g, "to_repo_dir", repoDir, "binary_path", binaryPath)
 
-	// create SBOM in CycloneDX JSON format
-	outputFlags := "cyclonedx-json"
+	// Ensure the binary is executable
+	if g.Tool.Managed {
+		if err := os.Chmod(binaryPath, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to set executable permission for %s: %w", g.Tool.Name, err)
+		}
+	}
 
-	args := []string{"scan", dirFlags, "-o", outputFlags}
+	output, err := os.CreateTemp("", g.Tool.Name+"-sbom-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s output file: %w", g.Tool.Name, err)
+	}
+	output.Close()
+	defer os.Remove(output.Name())
 
+	args := g.args(repoDir, output.Name())
 	logger.LogDebug(ctx.Context, "Executing SBOM command", "cmd", binaryPath, "args", args)
 
-	// Run Syft
-	cmd := exec.Command(binaryPath, args...)
+	cmd := exec.CommandContext(ctx.Context, binaryPath, args...)
 	cmd.Dir = repoDir // Ensure it runs from the correct directory
 
-	var outBuffer, errBuffer bytes.Buffer
-	cmd.Stdout = &outBuffer
+	var errBuffer bytes.Buffer
+	cmd.Stdout = io.Discard
 	cmd.Stderr = &errBuffer
 
 	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("failed to run Syft: %w", err)
+		return nil, fmt.Errorf("failed to run %s: %w, stderr: %s", g.Tool.Name, err, strings.TrimSpace(errBuffer.String()))
 	}
 
-	/// store SBOM in memory
-	data := outBuffer.Bytes()
+	// store SBOM in memory
+	data, err := os.ReadFile(output.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s output: %w", g.Tool.Name, err)
+	}
 	if len(data) == 0 {
-		return nil, fmt.Errorf("Syft produced SBOM with empty content")
+		return nil, fmt.Errorf("%s produced SBOM with empty content", g.Tool.Name)
 	}
 
-	logger.LogDebug(ctx.Context, "SBOM generated successfully", "size", len(data))
+	logger.LogDebug(ctx.Context, "SBOM generated successfully", "tool", g, "size", len(data))
 	return data, nil
 }
 
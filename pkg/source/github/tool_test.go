@@ -0,0 +1,77 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTool installs a script named like the tool in PATH, printing version with --version
+// and otherwise writing an SBOM to the file following --output
+func fakeTool(t *testing.T, name, version string) {
+	t.Helper()
+	dir := t.TempDir()
+	script := `#!/bin/sh
+if [ "$1" = "--version" ]; then echo "` + version + `"; exit 0; fi
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--output" ]; then echo '{"bomFormat":"CycloneDX","specVersion":"1.5"}' > "$2"; fi
+  shift
+done
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755))
+	t.Setenv("PATH", dir)
+}
+
+func TestGeneratorRunsTool(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+	fakeTool(t, "cdxgen", "11.0.3")
+
+	generator, err := NewGenerator("cdxgen", "v11.0.3")
+	require.NoError(t, err)
+	assert.Equal(t, "cdxgen@11.0.3", generator.String())
+	assert.Equal(t, "cdxgen-generated-sbom.json", generator.Filename())
+
+	data, err := generator.Generate(*ctx, t.TempDir())
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "CycloneDX")
+
+	// the installed tool must be the pinned version
+	generator, err = NewGenerator("cdxgen", "10.0.0")
+	require.NoError(t, err)
+	_, err = generator.Binary()
+	assert.ErrorContains(t, err, "is not version 10.0.0")
+
+	_, err = NewGenerator("scancode", "")
+	assert.ErrorContains(t, err, "must be one of: cdxgen, syft, trivy")
+}
+
+func TestGenCacheKeysByTool(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	syft, _ := NewGenerator("", "")
+	pinned, _ := NewGenerator("syft", "1.20.0")
+	trivy, _ := NewGenerator("trivy", "")
+
+	assert.Equal(t, "abc.json", filepath.Base(NewGenCache(false, syft).path("o", "r", "abc")))
+	assert.Equal(t, "abc.syft-1.20.0.json", filepath.Base(NewGenCache(false, pinned).path("o", "r", "abc")))
+	assert.Equal(t, "abc.trivy.json", filepath.Base(NewGenCache(false, trivy).path("o", "r", "abc")))
+}
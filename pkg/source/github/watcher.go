@@ -23,6 +23,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/status"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 )
 
@@ -42,7 +44,7 @@ func NewWatcherFetcher() *GithubWatcherFetcher {
 func (f *GithubWatcherFetcher) Fetch(ctx tcontext.TransferMetadata, config *GithubConfig) (iterator.SBOMIterator, error) {
 	logger.LogInfo(ctx.Context, "Starting GitHub daemon watcher", "repo", config.Repo, "version", config.Version)
 
-	outputAdapter := ctx.Value("destination").(string)
+	outputAdapter := ctx.DestinationAdapter()
 	method := config.Method
 
 	// Initialize cache with SQLite and in-memory caching
@@ -58,13 +60,15 @@ func (f *GithubWatcherFetcher) Fetch(ctx tcontext.TransferMetadata, config *Gith
 	// Ensure cache paths for all methods
 	cache.EnsureCachePath(ctx, outputAdapter, "github")
 
+	genCache := NewGenCache(config.NoGenCache, config.Generator)
+
 	sbomChan := make(chan *iterator.SBOM, 10)
 	token := config.Token
 	if token == "" {
 		logger.LogDebug(ctx.Context, "No GitHub token provided")
 	}
 
-	client, err := config.GetGitHubClient(ctx)
+	client, err := config.GetGitHubClient(ctx, cache)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize GitHub client: %w", err)
 	}
@@ -88,6 +92,7 @@ func (f *GithubWatcherFetcher) Fetch(ctx tcontext.TransferMetadata, config *Gith
 		if len(finalRepoList) == 0 {
 			return nil, fmt.Errorf("no repositories found post filtering")
 		}
+		finalRepoList = config.capRepos(ctx, finalRepoList)
 	}
 
 	if config.Repo != "" {
@@ -99,6 +104,8 @@ func (f *GithubWatcherFetcher) Fetch(ctx tcontext.TransferMetadata, config *Gith
 	}
 
 	logger.LogInfo(ctx.Context, "Final list of repositories to monitor", "repos", finalRepoList)
+	status.Watch(ctx, finalRepoList...)
+	status.TrackPending(ctx, func() int { return len(sbomChan) })
 
 	// start polling loop in a goroutine
 	go func() {
@@ -117,9 +124,11 @@ func (f *GithubWatcherFetcher) Fetch(ctx tcontext.TransferMetadata, config *Gith
 				newReleaseDetected := false
 
 				for _, repo := range finalRepoList {
-					if err := pollRepository(ctx, client, token, repo, config.Owner, config.Method, config.BinaryPath, config.AssetWaitDelay, cache, sbomChan, &newReleaseDetected); err != nil {
+					err := pollRepository(ctx, client, token, repo, config.Owner, config.Method, config.Generator, config.AssetWaitDelay, cache, genCache, sbomChan, &newReleaseDetected)
+					if err != nil {
 						logger.LogError(ctx.Context, err, "Failed to poll repository", "repo", repo)
 					}
+					status.Polled(ctx, repo, err)
 				}
 			}
 		}
@@ -128,50 +137,35 @@ func (f *GithubWatcherFetcher) Fetch(ctx tcontext.TransferMetadata, config *Gith
 	return &GithubWatcherIterator{sbomChan: sbomChan}, nil
 }
 
+// maxReleasePages bounds how far back a poll walks the release list looking for the cached release
+const maxReleasePages = 10
+
 // pollRepository checks a single repository for new releases and fetches SBOMs based on the configured method.
-func pollRepository(ctx tcontext.TransferMetadata, client *githublib.Client, token, repo, owner, method, binaryPath string, assetWaitDelay int64, cache *Cache, sbomChan chan *iterator.SBOM, newReleaseDetected *bool) error {
+// Every release published since the cached one is processed, oldest first, and cached as it completes,
+// so releases landing between two polls are not skipped.
+func pollRepository(ctx tcontext.TransferMetadata, client *githublib.Client, token, repo, owner, method string, generator *Generator, assetWaitDelay int64, cache *Cache, genCache *GenCache, sbomChan chan *iterator.SBOM, newReleaseDetected *bool) error {
 	logger.LogInfo(ctx.Context, "Polling repository", "repo", repo, "time", time.Now().Format(time.RFC3339))
 
-	outputAdapter := ctx.Value("destination").(string)
+	outputAdapter := ctx.DestinationAdapter()
 
-	var releases []*githublib.RepositoryRelease
-	var resp *githublib.Response
-	var err error
+	// check cache for the last release processed for this repo
+	cache.RLock()
+	repoInfo, exists := cache.Data[outputAdapter]["github"][method].Repos[repo]
+	cache.RUnlock()
 
-	// get all releases
-	releases, resp, err = client.Repositories.ListReleases(ctx.Context, owner, repo, &githublib.ListOptions{PerPage: 1})
+	releases, err := newReleases(ctx, client, owner, repo, repoInfo, exists)
 	if err != nil {
-		if resp != nil && resp.StatusCode == 429 {
-			logger.LogDebug(ctx.Context, "Rate limit hit, retrying", "repo", repo)
-		}
 		return err
 	}
 
 	if len(releases) == 0 {
-		logger.LogDebug(ctx.Context, "No releases found for repository", "repo", repo)
-		return nil
-	}
-
-	// extract latest release
-	latestRelease := releases[0]
-
-	// extract the release ID, published date and tag name from the latest release
-	releaseID := fmt.Sprintf("%d", latestRelease.GetID())
-	publishedAt := latestRelease.GetPublishedAt().Format(time.RFC3339)
-	tagName := latestRelease.GetTagName()
-
-	// now compare these release infor with the cached one
-	// check cache whether this repo has been processed for this release
-	cache.RLock()
-	repoInfo, exists := cache.Data[outputAdapter]["github"][method].Repos[repo]
-	cache.RUnlock()
-
-	if exists && repoInfo.PublishedAt == publishedAt && repoInfo.ReleaseID == releaseID {
 		logger.LogDebug(ctx.Context, "No new release found", "repo", repo)
 		return nil
 	}
 
-	logger.LogInfo(ctx.Context, "New release detected", "repo", repo, "tag", tagName, "release_id", releaseID, "published_at", publishedAt)
+	if len(releases) > 1 {
+		logger.LogInfo(ctx.Context, "New releases detected", "repo", repo, "count", len(releases))
+	}
 
 	// *newReleaseDetected = true
 
@@ -187,6 +181,76 @@ func pollRepository(ctx tcontext.TransferMetadata, client *githublib.Client, tok
 		}
 	}
 
+	for _, release := range releases {
+		if err := processRelease(ctx, client, token, repo, owner, method, generator, release, cache, genCache, sbomChan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newReleases lists the releases of a repository published after the cached one, oldest first.
+// Without a cached release, only the latest release is returned.
+func newReleases(ctx tcontext.TransferMetadata, client *githublib.Client, owner, repo string, cached RepoState, isCached bool) ([]*githublib.RepositoryRelease, error) {
+	opts := &githublib.ListOptions{PerPage: 1}
+	var cachedAt time.Time
+	if isCached {
+		opts.PerPage = 30
+		cachedAt, _ = time.Parse(time.RFC3339, cached.PublishedAt)
+	}
+
+	var fresh []*githublib.RepositoryRelease
+
+pages:
+	for page := 0; page < maxReleasePages; page++ {
+		// releases are listed newest first
+		releases, resp, err := client.Repositories.ListReleases(ctx.Context, owner, repo, opts)
+		if err != nil {
+			// the client already waited for the rate limit to reset and retried
+			if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusForbidden) {
+				logger.LogInfo(ctx.Context, "Rate limit still exceeded after retrying", "repo", repo, "status", resp.StatusCode)
+			}
+			return nil, err
+		}
+
+		for _, release := range releases {
+			if release.GetDraft() {
+				continue
+			}
+			if !isCached {
+				// first poll of the repo, start from its latest release
+				return []*githublib.RepositoryRelease{release}, nil
+			}
+			if fmt.Sprintf("%d", release.GetID()) == cached.ReleaseID || !release.GetPublishedAt().After(cachedAt) {
+				break pages
+			}
+			fresh = append(fresh, release)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].GetPublishedAt().Before(fresh[j].GetPublishedAt().Time)
+	})
+	return fresh, nil
+}
+
+// processRelease fetches the SBOMs of a release with the configured method, then records it as
+// the last release processed for the repository
+func processRelease(ctx tcontext.TransferMetadata, client *githublib.Client, token, repo, owner, method string, generator *Generator, release *githublib.RepositoryRelease, cache *Cache, genCache *GenCache, sbomChan chan *iterator.SBOM) error {
+	outputAdapter := ctx.DestinationAdapter()
+
+	// extract the release ID, published date and tag name from the release
+	releaseID := fmt.Sprintf("%d", release.GetID())
+	publishedAt := release.GetPublishedAt().Format(time.RFC3339)
+	tagName := release.GetTagName()
+
+	logger.LogInfo(ctx.Context, "New release detected", "repo", repo, "tag", tagName, "release_id", releaseID, "published_at", publishedAt)
+
 	// after the new released is confirmed, fetch SBOMs based on the configured method
 	switch method {
 	case string(MethodAPI):
@@ -195,20 +259,25 @@ func pollRepository(ctx tcontext.TransferMetadata, client *githublib.Client, tok
 		}
 
 	case string(MethodReleases):
-		if err := fetchSBOMFromReleaseAssets(ctx, client, owner, repo, latestRelease, releaseID, publishedAt, tagName, cache, sbomChan); err != nil {
+		if _, err := fetchSBOMFromReleaseAssets(ctx, client, owner, repo, release, releaseID, publishedAt, tagName, cache, sbomChan); err != nil {
 			logger.LogError(ctx.Context, err, "Failed to fetch SBOM from release assets", "repo", repo)
 		}
 
 	case string(MethodTool):
-		if err := fetchSBOMUsingTool(ctx, client, owner, repo, latestRelease, releaseID, publishedAt, tagName, binaryPath, cache, sbomChan); err != nil {
+		if err := fetchSBOMUsingTool(ctx, client, owner, repo, release, releaseID, publishedAt, tagName, generator, cache, genCache, sbomChan); err != nil {
 			logger.LogError(ctx.Context, err, "Failed to generate SBOM with tool", "repo", repo)
 		}
 
+	case string(MethodAuto):
+		if err := fetchSBOMAuto(ctx, client, token, owner, repo, release, releaseID, publishedAt, tagName, generator, cache, genCache, sbomChan); err != nil {
+			logger.LogError(ctx.Context, err, "Failed to fetch SBOM with auto method", "repo", repo)
+		}
+
 	default:
 		return fmt.Errorf("unsupported GitHub method: %s", method)
 	}
 
-	// update cache with latest repository release info
+	// update cache with the release just processed
 	cache.Lock()
 	repoState := cache.Data[outputAdapter]["github"][method].Repos[repo]
 	repoState.PublishedAt = publishedAt
@@ -216,14 +285,6 @@ func pollRepository(ctx tcontext.TransferMetadata, client *githublib.Client, tok
 	cache.Data[outputAdapter]["github"][method].Repos[repo] = repoState
 	cache.Unlock()
 
-	// cache.Lock()
-	// cache.ensureCachePathFor(outputAdapter, "github", method) // Initialize path
-	// cache.Data[outputAdapter]["github"][method].Repos[repo] = RepoState{
-	// 	PublishedAt: publishedAt,
-	// 	ReleaseID:   releaseID,
-	// }
-	// cache.Unlock()
-
 	// Save cache immediately to persist this daemon's update
 	if err := cache.SaveCache(ctx, outputAdapter, method); err != nil {
 		logger.LogError(ctx.Context, err, "Failed to save cache after new release", "repo", repo)
@@ -233,64 +294,122 @@ func pollRepository(ctx tcontext.TransferMetadata, client *githublib.Client, tok
 	return nil
 }
 
-func processAsset(ctx tcontext.TransferMetadata, client *githublib.Client, owner, repo, releaseID, tagName string, asset *githublib.ReleaseAsset, cache *Cache, sbomChan chan *iterator.SBOM) error {
+// fetchSBOMAuto tries release assets first, then the Dependency Graph API, and finally the
+// SBOM generating tool, stopping at the first method that yields an SBOM for the release.
+func fetchSBOMAuto(ctx tcontext.TransferMetadata, client *githublib.Client, token, owner, repo string, release *githublib.RepositoryRelease, releaseID, publishedAt, tagName string, generator *Generator, cache *Cache, genCache *GenCache, sbomChan chan *iterator.SBOM) error {
+	sent, err := fetchSBOMFromReleaseAssets(ctx, client, owner, repo, release, releaseID, publishedAt, tagName, cache, sbomChan)
+	if err == nil && sent > 0 {
+		logger.LogInfo(ctx.Context, "Auto method resolved", "repo", repo, "tag", tagName, "method", MethodReleases)
+		return nil
+	}
+	logger.LogDebug(ctx.Context, "No SBOMs from release assets, falling back", "repo", repo, "next", MethodAPI, "error", err)
+
+	err = fetchSBOMFromDependencyGraph(ctx, client, token, owner, repo, releaseID, publishedAt, tagName, cache, sbomChan)
+	if err == nil {
+		logger.LogInfo(ctx.Context, "Auto method resolved", "repo", repo, "tag", tagName, "method", MethodAPI)
+		return nil
+	}
+	logger.LogDebug(ctx.Context, "No SBOM from Dependency Graph API, falling back", "repo", repo, "next", MethodTool, "error", err)
+
+	// the tool is installed on first use, only by releases falling back to it
+	if err := fetchSBOMUsingTool(ctx, client, owner, repo, release, releaseID, publishedAt, tagName, generator, cache, genCache, sbomChan); err != nil {
+		return fmt.Errorf("all methods (release, api, tool) failed: %w", err)
+	}
+	logger.LogInfo(ctx.Context, "Auto method resolved", "repo", repo, "tag", tagName, "method", MethodTool)
+
+	return nil
+}
+
+// processAsset downloads a release asset and sends it to the channel when it is a new SBOM,
+// along with its signature assets with --verify-signatures, reporting whether an SBOM was sent.
+func processAsset(ctx tcontext.TransferMetadata, client *githublib.Client, owner, repo, releaseID, tagName string, asset *githublib.ReleaseAsset, signatureAssets map[string]*githublib.ReleaseAsset, cache *Cache, sbomChan chan *iterator.SBOM) (bool, error) {
 	logger.LogDebug(ctx.Context, "Processing asset", "repo", repo, "tag", tagName, "asset", asset.GetName())
 	assetName := asset.GetName()
 
+	if source.IsSignature(assetName) {
+		return false, nil
+	}
+
 	if !source.DetectSBOMsFile(assetName) {
 		logger.LogDebug(ctx.Context, "asset is not a SBOM from it's extention", "repo", repo, "asset", assetName)
-		return nil
+		return false, nil
 	}
 
-	// download SBOMs
-	reader, _, err := client.Repositories.DownloadReleaseAsset(ctx.Context, owner, repo, asset.GetID(), http.DefaultClient)
-	if err != nil {
-		return fmt.Errorf("failed to download asset %s: %w", assetName, err)
+	if !source.AllowsFormatName(ctx, assetName) {
+		return false, nil
 	}
-	defer reader.Close()
-	logger.LogDebug(ctx.Context, "downloaded asset", "repo", repo, "tag", tagName, "asset", assetName)
 
-	content, err := io.ReadAll(reader)
+	// download SBOMs
+	content, err := downloadReleaseAsset(ctx, client, owner, repo, asset)
 	if err != nil {
-		return fmt.Errorf("failed to read asset %s: %w", assetName, err)
+		return false, err
 	}
+	logger.LogDebug(ctx.Context, "downloaded asset", "repo", repo, "tag", tagName, "asset", assetName)
 
 	// Validate SBOM
-	if !source.IsSBOMFile(content) {
+	if !source.IsSBOM(ctx, repo+"/"+assetName, content) {
 		logger.LogDebug(ctx.Context, "asset content is not a SBOM", "repo", repo, "asset", assetName)
-		return nil
+		return false, nil
 	}
 
 	logger.LogDebug(ctx.Context, "Valid SBOM found", "repo", repo, "tag", tagName, "asset", assetName)
 
-	outputAdapter := ctx.Value("destination").(string)
+	outputAdapter := ctx.DestinationAdapter()
 
 	// create unique cache key for the SBOM (owner:repo:tagName:filename)
 	sbomCacheKey := fmt.Sprintf("%s:%s:%s:%s", owner, repo, tagName, assetName)
 	processed := cache.IsSBOMProcessed(ctx, outputAdapter, "github", string(MethodReleases), sbomCacheKey, repo)
 	if processed {
 		logger.LogDebug(ctx.Context, "SBOM already processed", "repo", "sbom_key", sbomCacheKey, "method", MethodReleases)
-		return nil
+		return false, nil
+	}
+
+	var signatures map[string][]byte
+	if source.FetchesSignatures(ctx) {
+		signatures = make(map[string][]byte, len(signatureAssets))
+		for suffix, signatureAsset := range signatureAssets {
+			data, err := downloadReleaseAsset(ctx, client, owner, repo, signatureAsset)
+			if err != nil {
+				return false, err
+			}
+			signatures[suffix] = data
+		}
 	}
 
 	// pass SBOM to the channel
 	logger.LogDebug(ctx.Context, "Found new SBOM", "repo", repo, "tag", tagName, "asset", assetName)
 	sbomChan <- &iterator.SBOM{
-		Data:      content,
-		Path:      assetName,
-		Version:   tagName,
-		Namespace: fmt.Sprintf("%s-%s", owner, repo),
+		Data:       content,
+		Path:       assetName,
+		Version:    tagName,
+		Namespace:  fmt.Sprintf("%s-%s", owner, repo),
+		Signatures: signatures,
 	}
 
 	logger.LogInfo(ctx.Context, "Fetched SBOM", "repository", repo, "tag", tagName, "asset", assetName)
 
 	// update SBOM cache
 	cache.MarkSBOMProcessed(ctx, outputAdapter, "github", string(MethodReleases), sbomCacheKey, repo)
-	return nil
+	return true, nil
 }
 
-// fetchSBOMFromReleaseAssets fetches SBOMs from the release assets.
-func fetchSBOMFromReleaseAssets(ctx tcontext.TransferMetadata, client *githublib.Client, owner, repo string, release *githublib.RepositoryRelease, releaseID, publishedAt, tagName string, cache *Cache, sbomChan chan *iterator.SBOM) error {
+// downloadReleaseAsset downloads the content of a release asset
+func downloadReleaseAsset(ctx tcontext.TransferMetadata, client *githublib.Client, owner, repo string, asset *githublib.ReleaseAsset) ([]byte, error) {
+	reader, _, err := client.Repositories.DownloadReleaseAsset(ctx.Context, owner, repo, asset.GetID(), http.DefaultClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download asset %s: %w", asset.GetName(), err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read asset %s: %w", asset.GetName(), err)
+	}
+	return content, nil
+}
+
+// fetchSBOMFromReleaseAssets fetches SBOMs from the release assets and returns how many were sent.
+func fetchSBOMFromReleaseAssets(ctx tcontext.TransferMetadata, client *githublib.Client, owner, repo string, release *githublib.RepositoryRelease, releaseID, publishedAt, tagName string, cache *Cache, sbomChan chan *iterator.SBOM) (int, error) {
 	logger.LogDebug(ctx.Context, "Fetching SBOMs via GitHub repository release page", "repo", repo, "tag", tagName)
 
 	opt := &githublib.ListOptions{PerPage: 100}
@@ -301,7 +420,7 @@ func fetchSBOMFromReleaseAssets(ctx tcontext.TransferMetadata, client *githublib
 		assets, resp, err := client.Repositories.ListReleaseAssets(ctx.Context, owner, repo, release.GetID(), opt)
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Failed to fetch release assets", "repo", repo, "page", page)
-			return fmt.Errorf("failed to list release assets: %w", err)
+			return 0, fmt.Errorf("failed to list release assets: %w", err)
 		}
 		allAssets = append(allAssets, assets...)
 		logger.LogDebug(ctx.Context, "Fetched release assets", "repo", repo, "tag", tagName, "page", page, "assets_fetched", len(assets), "total_so_far", len(allAssets))
@@ -316,14 +435,31 @@ func fetchSBOMFromReleaseAssets(ctx tcontext.TransferMetadata, client *githublib
 
 	logger.LogDebug(ctx.Context, "Fetched assets", "repo", repo, "tag", tagName, "count", len(allAssets))
 
+	// signature assets are attached to the SBOMs they sign, for --verify-signatures
+	signatures := make(map[string]map[string]*githublib.ReleaseAsset)
+	for _, asset := range allAssets {
+		if sbomName, suffix, ok := source.SignatureOf(asset.GetName()); ok {
+			if signatures[sbomName] == nil {
+				signatures[sbomName] = make(map[string]*githublib.ReleaseAsset)
+			}
+			signatures[sbomName][suffix] = asset
+		}
+	}
+
 	// process each assets
+	sent := 0
 	for _, asset := range allAssets {
-		if err := processAsset(ctx, client, owner, repo, releaseID, tagName, asset, cache, sbomChan); err != nil {
+		ok, err := processAsset(ctx, client, owner, repo, releaseID, tagName, asset, signatures[asset.GetName()], cache, sbomChan)
+		if err != nil {
 			logger.LogError(ctx.Context, err, "Failed to process asset", "repo", repo, "asset", asset.GetName())
+			continue
+		}
+		if ok {
+			sent++
 		}
 	}
 
-	return nil
+	return sent, nil
 }
 
 // fetchSBOMFromDependencyGraph fetches an SBOM from the GitHub Dependency Graph API.
@@ -333,7 +469,7 @@ func fetchSBOMFromDependencyGraph(ctx tcontext.TransferMetadata, client *githubl
 	logger.LogInfo(ctx.Context, "Fetching SBOM via Dependency Graph API", "repo", repo, "tag", tagName)
 
 	sbomCacheKey := fmt.Sprintf("%s:%s:%s:dependency-graph-sbom.json", owner, repo, tagName)
-	outputAdapter := ctx.Value("destination").(string)
+	outputAdapter := ctx.DestinationAdapter()
 
 	processed := cache.IsSBOMProcessed(ctx, outputAdapter, "github", string(MethodAPI), sbomCacheKey, repo)
 	if processed {
@@ -341,9 +477,9 @@ func fetchSBOMFromDependencyGraph(ctx tcontext.TransferMetadata, client *githubl
 		return nil
 	}
 
-	baseURL := "https://api.github.com"
+	// the client's base URL is that of the REST API, with a trailing slash
 	dependencyGraphSBOMAPI := fmt.Sprintf("repos/%s/%s/dependency-graph/sbom", owner, repo)
-	url := fmt.Sprintf("%s/%s", baseURL, dependencyGraphSBOMAPI)
+	url := client.BaseURL.String() + dependencyGraphSBOMAPI
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx.Context, "GET", url, nil)
@@ -358,8 +494,8 @@ func fetchSBOMFromDependencyGraph(ctx tcontext.TransferMetadata, client *githubl
 
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	// Perform the request
-	resp, err := http.DefaultClient.Do(req)
+	// Perform the request with the client's HTTP client, paced by the transfer's rate limiter
+	resp, err := client.Client().Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to fetch SBOM: %w", err)
 	}
@@ -418,12 +554,12 @@ func fetchSBOMFromDependencyGraph(ctx tcontext.TransferMetadata, client *githubl
 	return nil
 }
 
-// fetchSBOMUsingTool generates an SBOM using the Syft tool for the repository at the release's commit.
-func fetchSBOMUsingTool(ctx tcontext.TransferMetadata, client *githublib.Client, owner, repo string, release *githublib.RepositoryRelease, releaseID, publishedAt, tagName, binaryPath string, cache *Cache, sbomChan chan *iterator.SBOM) error {
-	logger.LogInfo(ctx.Context, "Fetching SBOM via SBOM Generating Syft tool", "repo", repo, "tag", tagName)
+// fetchSBOMUsingTool generates an SBOM using the generating tool for the repository at the release's commit.
+func fetchSBOMUsingTool(ctx tcontext.TransferMetadata, client *githublib.Client, owner, repo string, release *githublib.RepositoryRelease, releaseID, publishedAt, tagName string, generator *Generator, cache *Cache, genCache *GenCache, sbomChan chan *iterator.SBOM) error {
+	logger.LogInfo(ctx.Context, "Fetching SBOM via SBOM Generating tool", "repo", repo, "tag", tagName, "tool", generator)
 
-	sbomCacheKey := fmt.Sprintf("%s:%s:%s:syft-generated-sbom.json", owner, repo, tagName)
-	outputAdapter := ctx.Value("destination").(string)
+	sbomCacheKey := fmt.Sprintf("%s:%s:%s:%s", owner, repo, tagName, generator.Filename())
+	outputAdapter := ctx.DestinationAdapter()
 
 	processed := cache.IsSBOMProcessed(ctx, outputAdapter, "github", string(MethodTool), sbomCacheKey, repo)
 	if processed {
@@ -438,22 +574,30 @@ func fetchSBOMUsingTool(ctx tcontext.TransferMetadata, client *githublib.Client,
 	}
 	commitSHA := releaseCommit.GetSHA()
 
-	// clone repository at the release commit
-	repoDir := filepath.Join(os.TempDir(), fmt.Sprintf("%s-%s-%s", owner, repo, releaseID))
-	defer os.RemoveAll(repoDir)
+	sbomData, cached := genCache.Get(ctx, owner, repo, commitSHA)
+	if cached {
+		logger.LogInfo(ctx.Context, "Reusing cached generated SBOM", "repo", repo, "tag", tagName, "commit", commitSHA)
+	} else {
+		// clone repository at the release commit
+		repoDir := filepath.Join(os.TempDir(), fmt.Sprintf("%s-%s-%s", owner, repo, releaseID))
+		defer os.RemoveAll(repoDir)
+
+		repoURL := fmt.Sprintf("%s/%s/%s.git", webURL(client.BaseURL.String()), owner, repo)
+		if err := cloneRepoWithGit(ctx, repoURL, commitSHA, repoDir); err != nil {
+			return fmt.Errorf("failed to clone repository: %w", err)
+		}
 
-	if err := cloneRepoWithGit(ctx, repo, owner, commitSHA, repoDir); err != nil {
-		return fmt.Errorf("failed to clone repository: %w", err)
-	}
+		// generate SBOM
+		sbomData, err = generator.Generate(ctx, repoDir)
+		if err != nil {
+			return fmt.Errorf("failed to generate SBOM: %w", err)
+		}
+		logger.LogInfo(ctx.Context, "Generated new SBOM", "repo", repo, "tag", tagName, "tool", generator)
 
-	// generate SBOM
-	sbomData, err := GenerateSBOM(ctx, repoDir, binaryPath)
-	if err != nil {
-		return fmt.Errorf("failed to generate SBOM: %w", err)
+		genCache.Put(ctx, owner, repo, commitSHA, sbomData)
 	}
 
-	filepath := "syft-generated-sbom.json"
-	logger.LogInfo(ctx.Context, "Generated new SBOM with Syft", "repo", repo, "tag", tagName)
+	filepath := generator.Filename()
 	sbomChan <- &iterator.SBOM{
 		Data:      sbomData,
 		Path:      filepath,
@@ -466,8 +610,8 @@ func fetchSBOMUsingTool(ctx tcontext.TransferMetadata, client *githublib.Client,
 }
 
 // cloneRepoWithGit clones a GitHub repository at the specified commit using git.
-func cloneRepoWithGit(ctx tcontext.TransferMetadata, repo, owner, commitSHA, targetDir string) error {
-	logger.LogDebug(ctx.Context, "Cloning repository", "repo", repo, "commit", commitSHA, "directory", targetDir)
+func cloneRepoWithGit(ctx tcontext.TransferMetadata, repoURL, commitSHA, targetDir string) error {
+	logger.LogDebug(ctx.Context, "Cloning repository", "repo", repoURL, "commit", commitSHA, "directory", targetDir)
 
 	// ensure git is installed
 	if _, err := exec.LookPath("git"); err != nil {
@@ -475,7 +619,6 @@ func cloneRepoWithGit(ctx tcontext.TransferMetadata, repo, owner, commitSHA, tar
 	}
 
 	// Clone repository
-	repoURL := fmt.Sprintf("https://github.com/%s/%s.git", owner, repo)
 	cmd := exec.CommandContext(ctx.Context, "git", "clone", "--depth=1", repoURL, targetDir)
 	var stderr strings.Builder
 	cmd.Stdout = io.Discard
@@ -493,6 +636,6 @@ func cloneRepoWithGit(ctx tcontext.TransferMetadata, repo, owner, commitSHA, tar
 		return fmt.Errorf("failed to checkout commit %s: %w, stderr: %s", commitSHA, err, stderr.String())
 	}
 
-	logger.LogDebug(ctx.Context, "Repository cloned successfully", "repo", repo, "commit", commitSHA)
+	logger.LogDebug(ctx.Context, "Repository cloned successfully", "repo", repoURL, "commit", commitSHA)
 	return nil
 }
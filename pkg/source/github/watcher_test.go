@@ -0,0 +1,225 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	githublib "github.com/google/go-github/v62/github"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// releaseServer mocks the GitHub releases API of repository o/r, listing releases newest first
+// pageSize at a time
+type releaseServer struct {
+	mu       sync.Mutex
+	releases []*githublib.RepositoryRelease // newest first
+	pageSize int
+}
+
+func (s *releaseServer) publish(id int64, tag string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	release := &githublib.RepositoryRelease{
+		ID:          githublib.Int64(id),
+		TagName:     githublib.String(tag),
+		PublishedAt: &githublib.Timestamp{Time: at},
+	}
+	s.releases = append([]*githublib.RepositoryRelease{release}, s.releases...)
+}
+
+func (s *releaseServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/repos/o/r/releases":
+		size := s.pageSize
+		if size == 0 {
+			size, _ = strconv.Atoi(r.URL.Query().Get("per_page"))
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		start := min((page-1)*size, len(s.releases))
+		end := min(start+size, len(s.releases))
+		if end < len(s.releases) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/releases?page=%d>; rel="next"`, "http://"+r.Host, page+1))
+		}
+		_ = json.NewEncoder(w).Encode(s.releases[start:end])
+
+	case strings.HasPrefix(r.URL.Path, "/repos/o/r/releases/assets/"):
+		id := strings.TrimPrefix(r.URL.Path, "/repos/o/r/releases/assets/")
+		w.Header().Set("Content-Type", "application/octet-stream")
+		fmt.Fprintf(w, `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"metadata":{"component":{"name":"r","version":%q}}}`, id)
+
+	case strings.HasPrefix(r.URL.Path, "/repos/o/r/releases/") && strings.HasSuffix(r.URL.Path, "/assets"):
+		id, _ := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/o/r/releases/"), "/assets"), 10, 64)
+		for _, release := range s.releases {
+			if release.GetID() == id {
+				assets := []*githublib.ReleaseAsset{{ID: githublib.Int64(id), Name: githublib.String("r-" + release.GetTagName() + ".cdx.json")}}
+				_ = json.NewEncoder(w).Encode(assets)
+				return
+			}
+		}
+		http.NotFound(w, r)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestClient(t *testing.T, handler http.Handler) *githublib.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := githublib.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return client
+}
+
+func newTestCache(t *testing.T, ctx tcontext.TransferMetadata) *Cache {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	cache := NewCache()
+	require.NoError(t, cache.InitCache(ctx, "folder", string(MethodReleases)))
+	cache.EnsureCachePath(ctx, "folder", "github")
+	return cache
+}
+
+// drain returns the versions of the SBOMs waiting in the channel, in order
+func drain(sbomChan chan *iterator.SBOM) []string {
+	var versions []string
+	for {
+		select {
+		case sbom := <-sbomChan:
+			versions = append(versions, sbom.Version)
+		default:
+			return versions
+		}
+	}
+}
+
+func TestPollRepositoryProcessesReleaseBurst(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+	ctx.SetDestinationAdapter("folder")
+	cache := newTestCache(t, *ctx)
+
+	base := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	releases := &releaseServer{}
+	releases.publish(1, "v1.0.0", base)
+	client := newTestClient(t, releases)
+
+	sbomChan := make(chan *iterator.SBOM, 10)
+	detected := false
+	poll := func() []string {
+		t.Helper()
+		require.NoError(t, pollRepository(*ctx, client, "", "r", "o", string(MethodReleases), nil, 0, cache, NewGenCache(true, nil), sbomChan, &detected))
+		return drain(sbomChan)
+	}
+
+	// the first poll starts from the latest release
+	assert.Equal(t, []string{"v1.0.0"}, poll())
+
+	// two releases land between polls, both are processed oldest first
+	releases.publish(2, "v1.1.0", base.Add(time.Hour))
+	releases.publish(3, "v1.2.0", base.Add(2*time.Hour))
+	assert.Equal(t, []string{"v1.1.0", "v1.2.0"}, poll())
+
+	state := cache.Data["folder"]["github"][string(MethodReleases)].Repos["r"]
+	assert.Equal(t, "3", state.ReleaseID)
+	assert.Equal(t, base.Add(2*time.Hour).Format(time.RFC3339), state.PublishedAt)
+
+	// nothing new
+	assert.Empty(t, poll())
+}
+
+func TestNewReleases(t *testing.T) {
+	ctx := tcontext.NewTransferMetadata(context.Background())
+	base := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tags := func(releases []*githublib.RepositoryRelease) []string {
+		var names []string
+		for _, release := range releases {
+			names = append(names, release.GetTagName())
+		}
+		return names
+	}
+
+	t.Run("burst across pages", func(t *testing.T) {
+		releases := &releaseServer{pageSize: 2}
+		for i := int64(1); i <= 6; i++ {
+			releases.publish(i, fmt.Sprintf("v%d", i), base.Add(time.Duration(i)*time.Hour))
+		}
+		client := newTestClient(t, releases)
+
+		cached := RepoState{ReleaseID: "1", PublishedAt: base.Add(time.Hour).Format(time.RFC3339)}
+		got, err := newReleases(*ctx, client, "o", "r", cached, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v2", "v3", "v4", "v5", "v6"}, tags(got))
+	})
+
+	t.Run("uncached repo starts from the latest release", func(t *testing.T) {
+		releases := &releaseServer{}
+		releases.publish(1, "v1", base)
+		releases.publish(2, "v2", base.Add(time.Hour))
+		client := newTestClient(t, releases)
+
+		got, err := newReleases(*ctx, client, "o", "r", RepoState{}, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v2"}, tags(got))
+	})
+
+	t.Run("cached release deleted", func(t *testing.T) {
+		releases := &releaseServer{}
+		releases.publish(1, "v1", base)
+		releases.publish(3, "v3", base.Add(2*time.Hour))
+		client := newTestClient(t, releases)
+
+		// release 2 was cached, then deleted from GitHub
+		cached := RepoState{ReleaseID: "2", PublishedAt: base.Add(time.Hour).Format(time.RFC3339)}
+		got, err := newReleases(*ctx, client, "o", "r", cached, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v3"}, tags(got))
+	})
+
+	t.Run("drafts are skipped", func(t *testing.T) {
+		releases := &releaseServer{}
+		releases.publish(1, "v1", base)
+		releases.publish(2, "v2", base.Add(time.Hour))
+		releases.releases[0].Draft = githublib.Bool(true)
+		client := newTestClient(t, releases)
+
+		got, err := newReleases(*ctx, client, "o", "r", RepoState{}, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v1"}, tags(got))
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package harbor
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// HarborAdapter fetches the SBOM accessories Harbor keeps next to the artifacts of its repositories
+type HarborAdapter struct {
+	Config         *HarborConfig
+	Role           types.AdapterRole // "input" or "output" adapter type
+	ProcessingMode types.ProcessingMode
+	Fetcher        SBOMFetcher
+}
+
+// AddCommandParams adds Harbor-specific CLI flags
+func (h *HarborAdapter) AddCommandParams(cmd *cobra.Command) {
+	cmd.Flags().String("in-harbor-url", "", "Harbor URL, e.g. https://harbor.example.com")
+	cmd.Flags().StringSlice("in-harbor-projects", nil, "Harbor projects to fetch SBOMs from (default: all projects visible to the account)")
+	cmd.Flags().StringSlice("in-harbor-repositories", nil, "Repositories to fetch SBOMs from, as project/repository (default: all repositories of the projects)")
+	cmd.Flags().String("in-harbor-username", "", "Harbor robot account name, e.g. robot$ci (or HARBOR_USERNAME)")
+	cmd.Flags().String("in-harbor-password", "", "Harbor robot account secret (or HARBOR_PASSWORD)")
+}
+
+// ParseAndValidateParams validates the Harbor adapter params
+func (h *HarborAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	var (
+		urlFlag, projectsFlag, repositoriesFlag, usernameFlag, passwordFlag string
+		missingFlags                                                        []string
+		invalidFlags                                                        []string
+	)
+
+	urlFlag = "in-harbor-url"
+	projectsFlag = "in-harbor-projects"
+	repositoriesFlag = "in-harbor-repositories"
+	usernameFlag = "in-harbor-username"
+	passwordFlag = "in-harbor-password"
+
+	var fetcher SBOMFetcher
+	if h.ProcessingMode == types.FetchSequential {
+		fetcher = &HarborSequentialFetcher{}
+	} else if h.ProcessingMode == types.FetchParallel {
+		fetcher = &HarborParallelFetcher{}
+	} else {
+		return fmt.Errorf("unsupported processing mode: %s", h.ProcessingMode)
+	}
+
+	// validate flags for Harbor adapter, all flags should start with "in-harbor-"
+	err := utils.FlagValidation(cmd, types.HarborAdapterType, types.InputAdapterFlagPrefix)
+	if err != nil {
+		return fmt.Errorf("harbor flag validation failed: %w", err)
+	}
+
+	harborURL, _ := cmd.Flags().GetString(urlFlag)
+	if harborURL == "" {
+		missingFlags = append(missingFlags, urlFlag)
+	} else if u, err := url.Parse(harborURL); err != nil || u.Scheme == "" || u.Host == "" {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be a URL such as https://harbor.example.com)", urlFlag, harborURL))
+	}
+
+	projects, _ := cmd.Flags().GetStringSlice(projectsFlag)
+	repositories, _ := cmd.Flags().GetStringSlice(repositoriesFlag)
+	for _, repo := range repositories {
+		if !strings.Contains(repo, "/") {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be project/repository)", repositoriesFlag, repo))
+		}
+	}
+
+	// robot account credentials, environment first like the other adapters' tokens
+	username := viper.GetString("HARBOR_USERNAME")
+	if username == "" {
+		username, _ = cmd.Flags().GetString(usernameFlag)
+	}
+	password := viper.GetString("HARBOR_PASSWORD")
+	if password == "" {
+		password, _ = cmd.Flags().GetString(passwordFlag)
+	}
+	if username == "" {
+		logger.LogDebug(cmd.Context(), "Harbor credentials not provided, only public projects are accessible")
+	}
+
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing flags: %s", strings.Join(missingFlags, ", "))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid input adapter flag usage:\n %s\n\nUse 'sbommv transfer --help' for correct usage.", strings.Join(invalidFlags, "\n "))
+	}
+
+	cfg := NewHarborConfig()
+	cfg.URL = harborURL
+	cfg.Projects = projects
+	cfg.Repositories = repositories
+	cfg.Username = username
+	cfg.Password = password
+	cfg.ProcessingMode = h.ProcessingMode
+	cfg.client = NewClient(cfg)
+
+	h.Config = cfg
+	h.Fetcher = fetcher
+
+	return nil
+}
+
+func (h *HarborAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Initializing SBOM fetching", "mode", h.ProcessingMode)
+	return h.Fetcher.Fetch(ctx, h.Config)
+}
+
+func (h *HarborAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	return fmt.Errorf("Harbor adapter does not support SBOM uploading")
+}
+
+func (h *HarborAdapter) DryRun(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	reporter := NewHarborReporter(false, "", h.Config.URL)
+	return reporter.DryRun(ctx, iterator)
+}
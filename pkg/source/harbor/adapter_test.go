@@ -0,0 +1,88 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package harbor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseFlags returns the adapter configured from the given flags, and the validation error
+func parseFlags(t *testing.T, mode types.ProcessingMode, args ...string) (*HarborAdapter, error) {
+	t.Helper()
+	adapter := &HarborAdapter{Role: types.InputAdapterRole, ProcessingMode: mode}
+	cmd := &cobra.Command{Use: "transfer"}
+	cmd.Flags().String("input-adapter", "harbor", "")
+	cmd.Flags().String("in-github-url", "", "flag of another input adapter")
+	adapter.AddCommandParams(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetContext(context.Background())
+	return adapter, adapter.ParseAndValidateParams(cmd)
+}
+
+func TestParseAndValidateParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		invalid string
+	}{
+		{"url missing", nil, "missing flags: in-harbor-url"},
+		{"url without scheme", []string{"--in-harbor-url=harbor.example.com"}, "--in-harbor-url=harbor.example.com (must be a URL"},
+		{"repository without project", []string{"--in-harbor-url=https://harbor.example.com", "--in-harbor-repositories=library/app,app"}, "--in-harbor-repositories=app (must be project/repository)"},
+		{"flag of another adapter", []string{"--in-harbor-url=https://harbor.example.com", "--in-github-url=https://github.com/o/r"}, "flag --in-github-url is invalid"},
+		{"valid", []string{"--in-harbor-url=https://harbor.example.com/", "--in-harbor-projects=library,team"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := parseFlags(t, types.FetchSequential, tt.args...)
+			if tt.invalid != "" {
+				assert.ErrorContains(t, err, tt.invalid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"library", "team"}, adapter.Config.Projects)
+			assert.IsType(t, &HarborSequentialFetcher{}, adapter.Fetcher)
+		})
+	}
+
+	_, err := parseFlags(t, "stream", "--in-harbor-url=https://harbor.example.com")
+	assert.ErrorContains(t, err, "unsupported processing mode")
+}
+
+func TestParseAndValidateParamsCredentials(t *testing.T) {
+	adapter, err := parseFlags(t, types.FetchParallel, "--in-harbor-url=https://harbor.example.com", "--in-harbor-username=robot$flag", "--in-harbor-password=flag-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "robot$flag", adapter.Config.Username)
+	assert.Equal(t, "flag-secret", adapter.Config.Password)
+	assert.IsType(t, &HarborParallelFetcher{}, adapter.Fetcher)
+
+	// the environment wins over the flags
+	viper.Set("HARBOR_USERNAME", "robot$env")
+	viper.Set("HARBOR_PASSWORD", "env-secret")
+	t.Cleanup(func() {
+		viper.Set("HARBOR_USERNAME", "")
+		viper.Set("HARBOR_PASSWORD", "")
+	})
+	adapter, err = parseFlags(t, types.FetchParallel, "--in-harbor-url=https://harbor.example.com", "--in-harbor-username=robot$flag", "--in-harbor-password=flag-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "robot$env", adapter.Config.Username)
+	assert.Equal(t, "env-secret", adapter.Config.Password)
+}
@@ -0,0 +1,356 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package harbor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// accessoryTypeSBOM is the accessory type of SBOMs Harbor generates or that are attached to artifacts
+const accessoryTypeSBOM = "harbor.sbom"
+
+const pageSize = 100
+
+// Project is a Harbor project
+type Project struct {
+	Name string `json:"name"`
+}
+
+// Repository is a repository of a Harbor project, named "project/repo"
+type Repository struct {
+	Name string `json:"name"`
+}
+
+// Tag is a tag of an artifact
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// Accessory is an artifact attached to another one, e.g. its SBOM or signature
+type Accessory struct {
+	Type   string `json:"type"`
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
+
+// Artifact is an image (or other OCI artifact) of a repository with its tags and accessories
+type Artifact struct {
+	Digest      string      `json:"digest"`
+	Tags        []Tag       `json:"tags"`
+	Accessories []Accessory `json:"accessories"`
+}
+
+// SBOMAccessories returns the accessories of the artifact holding SBOMs
+func (a Artifact) SBOMAccessories() []Accessory {
+	var sboms []Accessory
+	for _, acc := range a.Accessories {
+		if acc.Type == accessoryTypeSBOM {
+			sboms = append(sboms, acc)
+		}
+	}
+	return sboms
+}
+
+// Version returns the first tag of the artifact, or its short digest when untagged
+func (a Artifact) Version() string {
+	if len(a.Tags) > 0 {
+		return a.Tags[0].Name
+	}
+	digest := strings.TrimPrefix(a.Digest, "sha256:")
+	if len(digest) > 12 {
+		digest = digest[:12]
+	}
+	return digest
+}
+
+// ociManifest is the part of an OCI image manifest needed to find the SBOM layer
+type ociManifest struct {
+	Layers []struct {
+		MediaType string `json:"mediaType"`
+		Digest    string `json:"digest"`
+	} `json:"layers"`
+}
+
+// Client talks to the Harbor API (/api/v2.0) to list artifacts and to the registry API (/v2)
+// to download SBOM accessories. It authenticates with a robot account (or any user) using basic
+// auth, exchanging it for registry bearer tokens when the registry asks for them.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	username   string
+	password   string
+
+	mu     sync.Mutex
+	tokens map[string]string // registry bearer tokens by repository
+}
+
+// NewClient initializes a Harbor client
+func NewClient(cfg *HarborConfig) *Client {
+	return &Client{
+		httpClient: &http.Client{},
+		baseURL:    strings.TrimSuffix(cfg.URL, "/"),
+		username:   cfg.Username,
+		password:   cfg.Password,
+		tokens:     make(map[string]string),
+	}
+}
+
+// ListProjects returns the names of all projects visible to the account
+func (c *Client) ListProjects(ctx tcontext.TransferMetadata) ([]string, error) {
+	projects, err := getPaged[Project](ctx, c, "/api/v2.0/projects", nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing projects: %w", err)
+	}
+
+	names := make([]string, 0, len(projects))
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return names, nil
+}
+
+// ListRepositories returns the repositories of a project, named "project/repo"
+func (c *Client) ListRepositories(ctx tcontext.TransferMetadata, project string) ([]string, error) {
+	repos, err := getPaged[Repository](ctx, c, fmt.Sprintf("/api/v2.0/projects/%s/repositories", url.PathEscape(project)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing repositories of project %s: %w", project, err)
+	}
+
+	names := make([]string, 0, len(repos))
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	return names, nil
+}
+
+// ListArtifacts returns the artifacts of a repository ("project/repo") with their tags and accessories
+func (c *Client) ListArtifacts(ctx tcontext.TransferMetadata, repository string) ([]Artifact, error) {
+	project, repo, ok := strings.Cut(repository, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository %q, expected project/repository", repository)
+	}
+
+	// nested repository names ("a/b") have to be URL-encoded twice
+	path := fmt.Sprintf("/api/v2.0/projects/%s/repositories/%s/artifacts", url.PathEscape(project), url.PathEscape(url.PathEscape(repo)))
+	query := url.Values{"with_tag": {"true"}, "with_accessory": {"true"}}
+
+	artifacts, err := getPaged[Artifact](ctx, c, path, query)
+	if err != nil {
+		return nil, fmt.Errorf("listing artifacts of %s: %w", repository, err)
+	}
+	return artifacts, nil
+}
+
+// DownloadSBOM downloads the SBOM held by an accessory: the first layer of its manifest
+func (c *Client) DownloadSBOM(ctx tcontext.TransferMetadata, repository, digest string) ([]byte, error) {
+	body, err := c.registryGet(ctx, repository, fmt.Sprintf("/v2/%s/manifests/%s", repository, digest), "application/vnd.oci.image.manifest.v1+json")
+	if err != nil {
+		return nil, fmt.Errorf("fetching manifest %s: %w", digest, err)
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, fmt.Errorf("decoding manifest %s: %w", digest, err)
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, fmt.Errorf("manifest %s has no layers", digest)
+	}
+
+	layer := manifest.Layers[0]
+	logger.LogDebug(ctx.Context, "Downloading SBOM layer", "repository", repository, "digest", layer.Digest, "media_type", layer.MediaType)
+
+	data, err := c.registryGet(ctx, repository, fmt.Sprintf("/v2/%s/blobs/%s", repository, layer.Digest), "")
+	if err != nil {
+		return nil, fmt.Errorf("fetching blob %s: %w", layer.Digest, err)
+	}
+	return data, nil
+}
+
+// getPaged collects all pages of a Harbor API list endpoint
+func getPaged[T any](ctx tcontext.TransferMetadata, c *Client, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	var all []T
+	for page := 1; ; page++ {
+		query.Set("page", fmt.Sprint(page))
+		query.Set("page_size", fmt.Sprint(pageSize))
+
+		req, err := http.NewRequestWithContext(ctx.Context, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		if c.username != "" {
+			req.SetBasicAuth(c.username, c.password)
+		}
+
+		body, err := c.do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []T
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, fmt.Errorf("decoding page %d: %w", page, err)
+		}
+		all = append(all, items...)
+
+		if len(items) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// registryGet fetches a manifest or blob from the registry API, authenticating with a bearer
+// token for the repository when the registry challenges the basic auth credentials
+func (c *Client) registryGet(ctx tcontext.TransferMetadata, repository, path, accept string) ([]byte, error) {
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx.Context, http.MethodGet, c.baseURL+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
+		c.mu.Lock()
+		token := c.tokens[repository]
+		c.mu.Unlock()
+
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else if c.username != "" {
+			req.SetBasicAuth(c.username, c.password)
+		}
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+			return nil, errdefs.Auth(fmt.Errorf("registry returned status %d", http.StatusUnauthorized))
+		}
+		if err := c.fetchToken(ctx, repository, challenge); err != nil {
+			return nil, err
+		}
+
+		if req, err = newRequest(); err != nil {
+			return nil, err
+		}
+		if resp, err = c.httpClient.Do(req); err != nil {
+			return nil, err
+		}
+	}
+
+	return readResponse(resp)
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// fetchToken exchanges the account credentials for a pull token of the repository at the
+// token service named in the registry's Bearer challenge
+func (c *Client) fetchToken(ctx tcontext.TransferMetadata, repository, challenge string) error {
+	params := map[string]string{}
+	for _, m := range challengeParam.FindAllStringSubmatch(challenge, -1) {
+		params[m[1]] = m[2]
+	}
+	if params["realm"] == "" {
+		return fmt.Errorf("registry auth challenge without realm: %s", challenge)
+	}
+
+	query := url.Values{"scope": {fmt.Sprintf("repository:%s:pull", repository)}}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+
+	req, err := http.NewRequestWithContext(ctx.Context, http.MethodGet, params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	body, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("fetching registry token: %w", err)
+	}
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return fmt.Errorf("decoding registry token: %w", err)
+	}
+
+	token := tokenResp.Token
+	if token == "" {
+		token = tokenResp.AccessToken
+	}
+	if token == "" {
+		return fmt.Errorf("registry token service returned no token")
+	}
+
+	c.mu.Lock()
+	c.tokens[repository] = token
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return readResponse(resp)
+}
+
+func readResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d: %s", resp.Request.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
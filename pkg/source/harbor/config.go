@@ -0,0 +1,35 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package harbor
+
+import (
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+type HarborConfig struct {
+	URL            string // e.g. https://harbor.example.com
+	Projects       []string
+	Repositories   []string // "project/repo", empty means all repositories of the projects
+	Username       string   // robot account name, e.g. robot$ci
+	Password       string   // robot account secret
+	ProcessingMode types.ProcessingMode
+	client         *Client
+}
+
+func NewHarborConfig() *HarborConfig {
+	return &HarborConfig{
+		ProcessingMode: types.FetchSequential,
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package harbor
+
+import (
+	"fmt"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// Estimate lists the SBOM accessories a transfer would download, with the sizes Harbor reports
+func (h *HarborAdapter) Estimate(ctx tcontext.TransferMetadata) ([]types.SBOMCandidate, error) {
+	refs, err := listSBOMAccessories(ctx, h.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]types.SBOMCandidate, 0, len(refs))
+	for _, ref := range refs {
+		candidates = append(candidates, types.SBOMCandidate{
+			Name:      fmt.Sprintf("%s:%s", ref.repository, ref.artifact.Version()),
+			Namespace: ref.repository,
+			Size:      ref.accessory.Size,
+		})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package harbor
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+type SBOMFetcher interface {
+	Fetch(ctx tcontext.TransferMetadata, config *HarborConfig) (iterator.SBOMIterator, error)
+}
+
+type (
+	HarborSequentialFetcher struct{}
+	HarborParallelFetcher   struct{}
+)
+
+// sbomRef is an SBOM accessory of an artifact, found while listing the registry
+type sbomRef struct {
+	repository string
+	artifact   Artifact
+	accessory  Accessory
+}
+
+// Fetch downloads the SBOM accessories one by one
+func (f *HarborSequentialFetcher) Fetch(ctx tcontext.TransferMetadata, config *HarborConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Sequentially")
+
+	refs, err := listSBOMAccessories(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var sbomList []*iterator.SBOM
+	for _, ref := range refs {
+		if ctx.Err() != nil {
+			return nil, source.FetchInterrupted(ctx, len(sbomList))
+		}
+
+		sbom, err := fetchSBOM(ctx, config, ref)
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Failed to fetch SBOM accessory", "repository", ref.repository, "digest", ref.accessory.Digest, "error", err)
+			continue
+		}
+		sbomList = append(sbomList, sbom)
+	}
+
+	if len(sbomList) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in Harbor %s", config.URL))
+	}
+	return NewHarborIterator(sbomList), nil
+}
+
+// Fetch downloads the SBOM accessories with a few concurrent downloads
+func (f *HarborParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *HarborConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Concurrently")
+
+	refs, err := listSBOMAccessories(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var sbomList []*iterator.SBOM
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	const maxConcurrency = 3
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	for _, ref := range refs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(ref sbomRef) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			sbom, err := fetchSBOM(ctx, config, ref)
+			if err != nil {
+				logger.LogDebug(ctx.Context, "Failed to fetch SBOM accessory", "repository", ref.repository, "digest", ref.accessory.Digest, "error", err)
+				return
+			}
+
+			mu.Lock()
+			sbomList = append(sbomList, sbom)
+			mu.Unlock()
+		}(ref)
+	}
+
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, source.FetchInterrupted(ctx, len(sbomList))
+	}
+	if len(sbomList) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in Harbor %s", config.URL))
+	}
+	return NewHarborIterator(sbomList), nil
+}
+
+// listSBOMAccessories walks the configured projects and repositories and returns the SBOM
+// accessories of their artifacts, without downloading them
+func listSBOMAccessories(ctx tcontext.TransferMetadata, config *HarborConfig) ([]sbomRef, error) {
+	repositories, err := resolveRepositories(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	logger.LogDebug(ctx.Context, "Total repositories from which SBOMs will be fetched", "count", len(repositories), "repositories", repositories)
+
+	var refs []sbomRef
+	for _, repository := range repositories {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		artifacts, err := config.client.ListArtifacts(ctx, repository)
+		if err != nil {
+			logger.LogInfo(ctx.Context, "Skipping repository, failed to list artifacts", "repository", repository, "error", err)
+			continue
+		}
+
+		for _, artifact := range artifacts {
+			for _, acc := range artifact.SBOMAccessories() {
+				refs = append(refs, sbomRef{repository: repository, artifact: artifact, accessory: acc})
+			}
+		}
+	}
+
+	logger.LogDebug(ctx.Context, "SBOM accessories found", "count", len(refs))
+	return refs, nil
+}
+
+// resolveRepositories returns the configured repositories, or all repositories of the
+// configured projects (all visible projects when none is configured)
+func resolveRepositories(ctx tcontext.TransferMetadata, config *HarborConfig) ([]string, error) {
+	if len(config.Repositories) > 0 {
+		return config.Repositories, nil
+	}
+
+	projects := config.Projects
+	if len(projects) == 0 {
+		var err error
+		projects, err = config.client.ListProjects(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var repositories []string
+	for _, project := range projects {
+		repos, err := config.client.ListRepositories(ctx, project)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, repos...)
+	}
+
+	if len(repositories) == 0 {
+		return nil, fmt.Errorf("no repositories found in projects %v", projects)
+	}
+	return repositories, nil
+}
+
+// fetchSBOM downloads an SBOM accessory. The namespace is the repository and the version
+// the artifact's tag, so each image version gets its own project at the destination.
+func fetchSBOM(ctx tcontext.TransferMetadata, config *HarborConfig, ref sbomRef) (*iterator.SBOM, error) {
+	content, err := config.client.DownloadSBOM(ctx, ref.repository, ref.accessory.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	if !source.IsSBOM(ctx, ref.repository+"@"+ref.accessory.Digest, content) {
+		return nil, fmt.Errorf("accessory %s is not a valid SBOM", ref.accessory.Digest)
+	}
+
+	version := ref.artifact.Version()
+	logger.LogDebug(ctx.Context, "Fetched SBOM", "repository", ref.repository, "version", version, "size", len(content))
+
+	return &iterator.SBOM{
+		Path:              fmt.Sprintf("%s_%s.sbom.json", strings.ReplaceAll(ref.repository, "/", "_"), version),
+		Data:              content,
+		Namespace:         ref.repository,
+		Version:           version,
+		ExplicitNamespace: true,
+	}, nil
+}
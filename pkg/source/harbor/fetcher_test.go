@@ -0,0 +1,200 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package harbor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const appSBOM = `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"metadata":{"component":{"type":"application","name":"app","version":"1.0.0"}}}`
+
+// harborServer serves project "library": a first page of pageSize repositories without
+// SBOMs, then repository library/app with a tagged artifact holding an SBOM and a signature
+// accessory, and an untagged one holding an SBOM that isn't one. Registry requests must
+// carry the bearer token of the token service, which requires the robot account.
+type harborServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	tokens int
+}
+
+func newHarborServer(t *testing.T) *harborServer {
+	s := &harborServer{}
+	s.Server = httptest.NewServer(s)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *harborServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeJSON := func(v any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+	registryAuth := func() bool {
+		if r.Header.Get("Authorization") != "Bearer pull-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/service/token",service="harbor-registry"`, s.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return false
+		}
+		return true
+	}
+
+	switch r.URL.Path {
+	case "/api/v2.0/projects":
+		writeJSON([]Project{{Name: "library"}})
+
+	case "/api/v2.0/projects/library/repositories":
+		var repos []Repository
+		if r.URL.Query().Get("page") == "1" {
+			for i := range pageSize {
+				repos = append(repos, Repository{Name: fmt.Sprintf("library/empty-%d", i)})
+			}
+		} else {
+			repos = []Repository{{Name: "library/app"}}
+		}
+		writeJSON(repos)
+
+	case "/api/v2.0/projects/library/repositories/app/artifacts":
+		writeJSON([]Artifact{
+			{
+				Digest: "sha256:1111", Tags: []Tag{{Name: "v1.0.0"}},
+				Accessories: []Accessory{{Type: accessoryTypeSBOM, Digest: "sha256:sbom"}, {Type: "signature.cosign", Digest: "sha256:sig"}},
+			},
+			{Digest: "sha256:222222222222222", Accessories: []Accessory{{Type: accessoryTypeSBOM, Digest: "sha256:notsbom"}}},
+		})
+
+	case "/service/token":
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "robot$ci" || password != "secret" || r.URL.Query().Get("scope") != "repository:library/app:pull" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		s.mu.Lock()
+		s.tokens++
+		s.mu.Unlock()
+		writeJSON(map[string]string{"token": "pull-token"})
+
+	case "/v2/library/app/manifests/sha256:sbom", "/v2/library/app/manifests/sha256:notsbom":
+		if registryAuth() {
+			layer := "sha256:sbom-layer"
+			if r.URL.Path == "/v2/library/app/manifests/sha256:notsbom" {
+				layer = "sha256:other-layer"
+			}
+			fmt.Fprintf(w, `{"layers":[{"mediaType":"application/vnd.goharbor.harbor.sbom.v1","digest":%q}]}`, layer)
+		}
+
+	case "/v2/library/app/blobs/sha256:sbom-layer":
+		if registryAuth() {
+			io.WriteString(w, appSBOM)
+		}
+
+	case "/v2/library/app/blobs/sha256:other-layer":
+		if registryAuth() {
+			io.WriteString(w, "not an SBOM")
+		}
+
+	default:
+		if strings.HasPrefix(r.URL.Path, "/api/v2.0/projects/library/repositories/empty-") {
+			writeJSON([]Artifact{})
+			return
+		}
+		http.NotFound(w, r)
+	}
+}
+
+func (s *harborServer) tokenRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens
+}
+
+func drain(t *testing.T, ctx tcontext.TransferMetadata, it iterator.SBOMIterator) []*iterator.SBOM {
+	t.Helper()
+	var sboms []*iterator.SBOM
+	for {
+		sbom, err := it.Next(ctx)
+		if err == io.EOF {
+			return sboms
+		}
+		require.NoError(t, err)
+		sboms = append(sboms, sbom)
+	}
+}
+
+func TestFetchSBOMAccessories(t *testing.T) {
+	for _, fetcher := range []SBOMFetcher{&HarborSequentialFetcher{}, &HarborParallelFetcher{}} {
+		t.Run(fmt.Sprintf("%T", fetcher), func(t *testing.T) {
+			ctx := *tcontext.NewTransferMetadata(context.Background())
+			server := newHarborServer(t)
+
+			config := NewHarborConfig()
+			config.URL = server.URL + "/"
+			config.Username, config.Password = "robot$ci", "secret"
+			config.client = NewClient(config)
+
+			it, err := fetcher.Fetch(ctx, config)
+			require.NoError(t, err)
+			sboms := drain(t, ctx, it)
+			sort.Slice(sboms, func(i, j int) bool { return sboms[i].Path < sboms[j].Path })
+
+			require.Len(t, sboms, 1)
+			assert.Equal(t, "library_app_v1.0.0.sbom.json", sboms[0].Path)
+			assert.Equal(t, "library/app", sboms[0].Namespace)
+			assert.Equal(t, "v1.0.0", sboms[0].Version)
+			assert.True(t, sboms[0].ExplicitNamespace)
+			assert.JSONEq(t, appSBOM, string(sboms[0].Data))
+			if _, ok := fetcher.(*HarborSequentialFetcher); ok {
+				assert.Equal(t, 1, server.tokenRequests(), "the pull token is reused for the repository")
+			}
+		})
+	}
+}
+
+func TestFetchWithoutSBOMs(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	server := newHarborServer(t)
+
+	config := NewHarborConfig()
+	config.URL = server.URL
+	config.Repositories = []string{"library/empty-1"}
+	config.client = NewClient(config)
+
+	_, err := (&HarborSequentialFetcher{}).Fetch(ctx, config)
+	assert.ErrorContains(t, err, "no SBOMs found in Harbor")
+}
+
+func TestRegistryTokenRequiresCredentials(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	server := newHarborServer(t)
+
+	client := NewClient(&HarborConfig{URL: server.URL, Username: "robot$ci", Password: "wrong"})
+	_, err := client.DownloadSBOM(ctx, "library/app", "sha256:sbom")
+	assert.ErrorContains(t, err, "fetching registry token")
+}
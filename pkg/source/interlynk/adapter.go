@@ -0,0 +1,135 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interlynk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// allowedProjectEnvs are the environments of an Interlynk project group
+var allowedProjectEnvs = map[string]bool{"default": true, "development": true, "production": true}
+
+// InterlynkAdapter exports the SBOMs of project groups from the Interlynk platform
+type InterlynkAdapter struct {
+	Config         *InterlynkConfig
+	Role           types.AdapterRole // "input" or "output" adapter type
+	ProcessingMode types.ProcessingMode
+	Fetcher        SBOMFetcher
+}
+
+// AddCommandParams adds Interlynk-specific CLI flags
+func (i *InterlynkAdapter) AddCommandParams(cmd *cobra.Command) {
+	cmd.Flags().String("in-interlynk-url", DefaultURL, "Interlynk API URL")
+	cmd.Flags().StringSlice("in-interlynk-project-groups", nil, "Project groups to export SBOMs from (default: all project groups)")
+	cmd.Flags().String("in-interlynk-project-env", "", "Environment to export SBOMs from: default, development or production (default: all environments)")
+	cmd.Flags().Bool("in-interlynk-all-versions", false, "Export every SBOM version of an environment instead of the latest one")
+}
+
+// ParseAndValidateParams validates the Interlynk adapter params
+func (i *InterlynkAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	var (
+		urlFlag, projectGroupsFlag, projectEnvFlag, allVersionsFlag string
+		missingFlags                                                []string
+		invalidFlags                                                []string
+	)
+
+	urlFlag = "in-interlynk-url"
+	projectGroupsFlag = "in-interlynk-project-groups"
+	projectEnvFlag = "in-interlynk-project-env"
+	allVersionsFlag = "in-interlynk-all-versions"
+
+	var fetcher SBOMFetcher
+	if i.ProcessingMode == types.FetchSequential {
+		fetcher = &InterlynkSequentialFetcher{}
+	} else if i.ProcessingMode == types.FetchParallel {
+		fetcher = &InterlynkParallelFetcher{}
+	} else {
+		return fmt.Errorf("unsupported processing mode: %s", i.ProcessingMode)
+	}
+
+	// validate flags for Interlynk adapter, all flags should start with "in-interlynk-"
+	err := utils.FlagValidation(cmd, types.InterlynkAdapterType, types.InputAdapterFlagPrefix)
+	if err != nil {
+		return fmt.Errorf("interlynk flag validation failed: %w", err)
+	}
+
+	apiURL, _ := cmd.Flags().GetString(urlFlag)
+	if !utils.IsValidURL(apiURL) {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be a URL such as %s)", urlFlag, apiURL, DefaultURL))
+	}
+
+	projectGroups, _ := cmd.Flags().GetStringSlice(projectGroupsFlag)
+	projectEnv, _ := cmd.Flags().GetString(projectEnvFlag)
+	if projectEnv != "" && !allowedProjectEnvs[projectEnv] {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (allowed values: default, development, production)", projectEnvFlag, projectEnv))
+	}
+	allVersions, _ := cmd.Flags().GetBool(allVersionsFlag)
+
+	token := viper.GetString("INTERLYNK_SECURITY_TOKEN")
+	if token == "" {
+		missingFlags = append(missingFlags, "INTERLYNK_SECURITY_TOKEN")
+	}
+
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing flags: %s", strings.Join(missingFlags, ", "))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid input adapter flag usage:\n %s\n\nUse 'sbommv transfer --help' for correct usage.", strings.Join(invalidFlags, "\n "))
+	}
+
+	cfg := NewInterlynkConfig()
+	cfg.URL = apiURL
+	cfg.Token = token
+	cfg.ProjectGroups = projectGroups
+	cfg.Env = projectEnv
+	cfg.AllVersions = allVersions
+	cfg.ProcessingMode = i.ProcessingMode
+	cfg.client = NewClient(cfg)
+
+	i.Config = cfg
+	i.Fetcher = fetcher
+
+	logger.LogDebug(cmd.Context(), "Interlynk input parameters validated and assigned",
+		"url", cfg.URL,
+		"project_groups", cfg.ProjectGroups,
+		"project_env", cfg.Env,
+		"all_versions", cfg.AllVersions,
+	)
+	return nil
+}
+
+func (i *InterlynkAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Initializing SBOM fetching", "mode", i.ProcessingMode)
+	return i.Fetcher.Fetch(ctx, i.Config)
+}
+
+func (i *InterlynkAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	return fmt.Errorf("Interlynk input adapter does not support SBOM uploading")
+}
+
+func (i *InterlynkAdapter) DryRun(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	reporter := NewInterlynkReporter(false, "", i.Config.URL)
+	return reporter.DryRun(ctx, iterator)
+}
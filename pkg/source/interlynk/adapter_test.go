@@ -0,0 +1,89 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interlynk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseFlags returns the adapter configured from the given flags, and the validation error
+func parseFlags(t *testing.T, mode types.ProcessingMode, args ...string) (*InterlynkAdapter, error) {
+	t.Helper()
+	adapter := &InterlynkAdapter{Role: types.InputAdapterRole, ProcessingMode: mode}
+	cmd := &cobra.Command{Use: "transfer"}
+	cmd.Flags().String("input-adapter", "interlynk", "")
+	cmd.Flags().String("in-github-url", "", "flag of another input adapter")
+	adapter.AddCommandParams(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetContext(context.Background())
+	return adapter, adapter.ParseAndValidateParams(cmd)
+}
+
+func setToken(t *testing.T, token string) {
+	viper.Set("INTERLYNK_SECURITY_TOKEN", token)
+	t.Cleanup(func() { viper.Set("INTERLYNK_SECURITY_TOKEN", "") })
+}
+
+func TestParseAndValidateParams(t *testing.T) {
+	setToken(t, "lynk_test")
+
+	tests := []struct {
+		name    string
+		args    []string
+		invalid string
+	}{
+		{"invalid url", []string{"--in-interlynk-url=api.interlynk.io"}, "--in-interlynk-url=api.interlynk.io (must be a URL"},
+		{"unknown environment", []string{"--in-interlynk-project-env=staging"}, "--in-interlynk-project-env=staging (allowed values: default, development, production)"},
+		{"flag of another adapter", []string{"--in-github-url=https://github.com/o/r"}, "flag --in-github-url is invalid"},
+		{"defaults", nil, ""},
+		{"environment", []string{"--in-interlynk-project-env=production", "--in-interlynk-project-groups=payments,platform", "--in-interlynk-all-versions"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFlags(t, types.FetchSequential, tt.args...)
+			if tt.invalid != "" {
+				assert.ErrorContains(t, err, tt.invalid)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	_, err := parseFlags(t, "stream")
+	assert.ErrorContains(t, err, "unsupported processing mode")
+}
+
+func TestParseAndValidateParamsConfig(t *testing.T) {
+	setToken(t, "")
+	_, err := parseFlags(t, types.FetchSequential)
+	assert.ErrorContains(t, err, "missing flags: INTERLYNK_SECURITY_TOKEN")
+
+	setToken(t, "lynk_test")
+	adapter, err := parseFlags(t, types.FetchParallel, "--in-interlynk-project-env=production", "--in-interlynk-project-groups=payments,platform", "--in-interlynk-all-versions")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultURL, adapter.Config.URL)
+	assert.Equal(t, "lynk_test", adapter.Config.Token)
+	assert.Equal(t, []string{"payments", "platform"}, adapter.Config.ProjectGroups)
+	assert.Equal(t, "production", adapter.Config.Env)
+	assert.True(t, adapter.Config.AllVersions)
+	assert.IsType(t, &InterlynkParallelFetcher{}, adapter.Fetcher)
+}
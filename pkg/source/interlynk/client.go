@@ -0,0 +1,250 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interlynk
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+const pageSize = 100
+
+const listProjectGroupsQuery = `
+	query ListProjectGroups($search: String, $first: Int, $after: String) {
+		organization {
+			projectGroups(search: $search, first: $first, after: $after) {
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				nodes {
+					id
+					name
+					projects {
+						id
+						name
+						sboms {
+							id
+							projectVersion
+							updatedAt
+							primaryComponent {
+								name
+								version
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+`
+
+const downloadSBOMQuery = `
+	query DownloadSbom($projectId: Uuid!, $sbomId: Uuid!) {
+		sbom(projectId: $projectId, sbomId: $sbomId) {
+			download(sbomId: $sbomId, original: true) {
+				content
+				contentType
+				filename
+			}
+		}
+	}
+`
+
+// ProjectGroup is an Interlynk product, with one project per environment
+type ProjectGroup struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Projects []Project `json:"projects"`
+}
+
+// Project is the environment (default, development, production) of a project group
+type Project struct {
+	ID    string     `json:"id"`
+	Name  string     `json:"name"`
+	SBOMs []SBOMInfo `json:"sboms"`
+}
+
+// SBOMInfo is an SBOM version uploaded to a project
+type SBOMInfo struct {
+	ID               string    `json:"id"`
+	ProjectVersion   string    `json:"projectVersion"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+	PrimaryComponent *struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"primaryComponent"`
+}
+
+// Version returns the version the SBOM was uploaded as, or its primary component's version
+func (s SBOMInfo) Version() string {
+	if s.ProjectVersion != "" {
+		return s.ProjectVersion
+	}
+	if s.PrimaryComponent != nil && s.PrimaryComponent.Version != "" {
+		return s.PrimaryComponent.Version
+	}
+	return s.ID
+}
+
+// Client queries the Interlynk GraphQL API
+type Client struct {
+	httpClient *http.Client
+	url        string
+	token      string
+}
+
+// NewClient initializes an Interlynk client
+func NewClient(cfg *InterlynkConfig) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		url:        cfg.URL,
+		token:      cfg.Token,
+	}
+}
+
+// ListProjectGroups returns the project groups matching search, all of them when search is empty
+func (c *Client) ListProjectGroups(ctx tcontext.TransferMetadata, search string) ([]ProjectGroup, error) {
+	var groups []ProjectGroup
+	after := ""
+
+	for {
+		variables := map[string]interface{}{"first": pageSize}
+		if search != "" {
+			variables["search"] = search
+		}
+		if after != "" {
+			variables["after"] = after
+		}
+
+		var data struct {
+			Organization struct {
+				ProjectGroups struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []ProjectGroup `json:"nodes"`
+				} `json:"projectGroups"`
+			} `json:"organization"`
+		}
+		if err := c.query(ctx, listProjectGroupsQuery, variables, &data); err != nil {
+			return nil, fmt.Errorf("listing project groups: %w", err)
+		}
+
+		page := data.Organization.ProjectGroups
+		groups = append(groups, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return groups, nil
+		}
+		after = page.PageInfo.EndCursor
+	}
+}
+
+// DownloadSBOM downloads an SBOM as it was originally uploaded
+func (c *Client) DownloadSBOM(ctx tcontext.TransferMetadata, projectID, sbomID string) ([]byte, error) {
+	var data struct {
+		SBOM struct {
+			Download struct {
+				Content     string `json:"content"`
+				ContentType string `json:"contentType"`
+				Filename    string `json:"filename"`
+			} `json:"download"`
+		} `json:"sbom"`
+	}
+
+	variables := map[string]interface{}{"projectId": projectID, "sbomId": sbomID}
+	if err := c.query(ctx, downloadSBOMQuery, variables, &data); err != nil {
+		return nil, fmt.Errorf("downloading SBOM %s: %w", sbomID, err)
+	}
+
+	content := data.SBOM.Download.Content
+	if content == "" {
+		return nil, fmt.Errorf("SBOM %s has no content", sbomID)
+	}
+
+	// the API returns the document base64-encoded
+	decoded, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return nil, fmt.Errorf("decoding SBOM %s: %w", sbomID, err)
+	}
+	return decoded, nil
+}
+
+// query runs a GraphQL query and decodes its data into out
+func (c *Client) query(ctx tcontext.TransferMetadata, query string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal GraphQL request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx.Context, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("User-Agent", "sbommv/1.0")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errdefs.Auth(fmt.Errorf("invalid API token: authentication failed"))
+	default:
+		return errdefs.FromStatus(resp.StatusCode, fmt.Errorf("Interlynk API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody))))
+	}
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("GraphQL error: %s", response.Errors[0].Message)
+	}
+
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("parsing response data: %w", err)
+	}
+	return nil
+}
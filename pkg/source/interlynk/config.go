@@ -0,0 +1,39 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interlynk
+
+import (
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// DefaultURL is the Interlynk GraphQL API endpoint
+const DefaultURL = "https://api.interlynk.io/lynkapi"
+
+type InterlynkConfig struct {
+	URL            string   // GraphQL API endpoint
+	Token          string   // security token, INTERLYNK_SECURITY_TOKEN
+	ProjectGroups  []string // project group names, empty means all project groups
+	Env            string   // environment to export, empty means all environments
+	AllVersions    bool     // export every SBOM version instead of the latest one per environment
+	ProcessingMode types.ProcessingMode
+	client         *Client
+}
+
+func NewInterlynkConfig() *InterlynkConfig {
+	return &InterlynkConfig{
+		URL:            DefaultURL,
+		ProcessingMode: types.FetchSequential,
+	}
+}
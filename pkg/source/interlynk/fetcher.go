@@ -0,0 +1,220 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interlynk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+type SBOMFetcher interface {
+	Fetch(ctx tcontext.TransferMetadata, config *InterlynkConfig) (iterator.SBOMIterator, error)
+}
+
+type (
+	InterlynkSequentialFetcher struct{}
+	InterlynkParallelFetcher   struct{}
+)
+
+// sbomRef is an SBOM version of a project group environment, found while listing project groups
+type sbomRef struct {
+	group   string
+	env     string
+	project string // project ID of the environment
+	sbom    SBOMInfo
+}
+
+// Fetch downloads the SBOMs one by one
+func (f *InterlynkSequentialFetcher) Fetch(ctx tcontext.TransferMetadata, config *InterlynkConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Sequentially")
+
+	refs, err := listSBOMs(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var sbomList []*iterator.SBOM
+	for _, ref := range refs {
+		if ctx.Err() != nil {
+			return nil, source.FetchInterrupted(ctx, len(sbomList))
+		}
+
+		sbom, err := fetchSBOM(ctx, config, ref)
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Failed to fetch SBOM", "project_group", ref.group, "env", ref.env, "sbom", ref.sbom.ID, "error", err)
+			continue
+		}
+		sbomList = append(sbomList, sbom)
+	}
+
+	if len(sbomList) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in Interlynk"))
+	}
+	return NewInterlynkIterator(sbomList), nil
+}
+
+// Fetch downloads the SBOMs with a few concurrent downloads
+func (f *InterlynkParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *InterlynkConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Concurrently")
+
+	refs, err := listSBOMs(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var sbomList []*iterator.SBOM
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	const maxConcurrency = 3
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	for _, ref := range refs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(ref sbomRef) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			sbom, err := fetchSBOM(ctx, config, ref)
+			if err != nil {
+				logger.LogDebug(ctx.Context, "Failed to fetch SBOM", "project_group", ref.group, "env", ref.env, "sbom", ref.sbom.ID, "error", err)
+				return
+			}
+
+			mu.Lock()
+			sbomList = append(sbomList, sbom)
+			mu.Unlock()
+		}(ref)
+	}
+
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, source.FetchInterrupted(ctx, len(sbomList))
+	}
+	if len(sbomList) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in Interlynk"))
+	}
+	return NewInterlynkIterator(sbomList), nil
+}
+
+// listSBOMs returns the SBOMs of the configured project groups and environment, without
+// downloading them: the latest SBOM of each environment, or all of them with AllVersions
+func listSBOMs(ctx tcontext.TransferMetadata, config *InterlynkConfig) ([]sbomRef, error) {
+	groups, err := resolveProjectGroups(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	logger.LogDebug(ctx.Context, "Total project groups from which SBOMs will be fetched", "count", len(groups))
+
+	var refs []sbomRef
+	for _, group := range groups {
+		for _, project := range group.Projects {
+			if config.Env != "" && project.Name != config.Env {
+				continue
+			}
+
+			sboms := project.SBOMs
+			sort.Slice(sboms, func(i, j int) bool { return sboms[i].UpdatedAt.After(sboms[j].UpdatedAt) })
+			if !config.AllVersions && len(sboms) > 1 {
+				sboms = sboms[:1]
+			}
+
+			for _, sbom := range sboms {
+				refs = append(refs, sbomRef{group: group.Name, env: project.Name, project: project.ID, sbom: sbom})
+			}
+		}
+	}
+
+	logger.LogDebug(ctx.Context, "SBOMs found", "count", len(refs))
+	return refs, nil
+}
+
+// resolveProjectGroups returns the configured project groups, matched by exact name,
+// or all project groups of the organization when none is configured
+func resolveProjectGroups(ctx tcontext.TransferMetadata, config *InterlynkConfig) ([]ProjectGroup, error) {
+	if len(config.ProjectGroups) == 0 {
+		groups, err := config.client.ListProjectGroups(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		if len(groups) == 0 {
+			return nil, fmt.Errorf("no project groups found")
+		}
+		return groups, nil
+	}
+
+	var groups []ProjectGroup
+	for _, name := range config.ProjectGroups {
+		// search matches substrings, keep the project group of that exact name
+		matches, err := config.client.ListProjectGroups(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, group := range matches {
+			if group.Name == name {
+				groups = append(groups, group)
+				found = true
+				break
+			}
+		}
+		if !found {
+			logger.LogInfo(ctx.Context, "Project group not found", "project_group", name)
+		}
+	}
+
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("none of the project groups %v found", config.ProjectGroups)
+	}
+	return groups, nil
+}
+
+// fetchSBOM downloads an SBOM. The namespace is the project group and the version the
+// SBOM's version, so each project group keeps its name at the destination.
+func fetchSBOM(ctx tcontext.TransferMetadata, config *InterlynkConfig, ref sbomRef) (*iterator.SBOM, error) {
+	content, err := config.client.DownloadSBOM(ctx, ref.project, ref.sbom.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !source.IsSBOM(ctx, ref.sbom.ID, content) {
+		return nil, fmt.Errorf("SBOM %s is not a valid SBOM", ref.sbom.ID)
+	}
+
+	version := ref.sbom.Version()
+	logger.LogDebug(ctx.Context, "Fetched SBOM", "project_group", ref.group, "env", ref.env, "version", version, "size", len(content))
+
+	return &iterator.SBOM{
+		Path:              fmt.Sprintf("%s_%s_%s.sbom.json", strings.ReplaceAll(ref.group, "/", "_"), ref.env, version),
+		Data:              content,
+		Namespace:         ref.group,
+		Version:           version,
+		ExplicitNamespace: true,
+	}, nil
+}
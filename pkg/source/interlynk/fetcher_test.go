@@ -0,0 +1,203 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interlynk
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cycloneDX(name, version string) string {
+	return fmt.Sprintf(`{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"metadata":{"component":{"type":"application","name":%q,"version":%q}}}`, name, version)
+}
+
+// sbomVersion is an SBOM of a project, uploaded days ago
+func sbomVersion(id, version string, days int) SBOMInfo {
+	return SBOMInfo{ID: id, ProjectVersion: version, UpdatedAt: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -days)}
+}
+
+// projectGroups of the organization, listed two per page
+var projectGroups = []ProjectGroup{
+	{Name: "payments", Projects: []Project{
+		{ID: "p-default", Name: "default", SBOMs: []SBOMInfo{sbomVersion("s1", "v1.0.0", 10), sbomVersion("s2", "v1.1.0", 1)}},
+		{ID: "p-production", Name: "production", SBOMs: []SBOMInfo{sbomVersion("s3", "v1.0.0", 5)}},
+	}},
+	{Name: "payments-api", Projects: []Project{
+		{ID: "a-default", Name: "default", SBOMs: []SBOMInfo{sbomVersion("s4", "v3.0.0", 2)}},
+	}},
+	{Name: "platform", Projects: []Project{
+		{ID: "f-development", Name: "development", SBOMs: []SBOMInfo{sbomVersion("s5", "v0.1.0", 3)}},
+	}},
+}
+
+// sbomContents are the SBOMs as uploaded, s5 isn't one
+var sbomContents = map[string]string{
+	"s1": cycloneDX("payments", "1.0.0"),
+	"s2": cycloneDX("payments", "1.1.0"),
+	"s3": cycloneDX("payments", "1.0.0"),
+	"s4": cycloneDX("payments-api", "3.0.0"),
+	"s5": "not an SBOM",
+}
+
+// newGraphQLServer serves the project groups and SBOMs above to requests with token lynk_test
+func newGraphQLServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer lynk_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var data any
+		switch {
+		case strings.Contains(req.Query, "ListProjectGroups"):
+			search, _ := req.Variables["search"].(string)
+			var matches []ProjectGroup
+			for _, group := range projectGroups {
+				if strings.Contains(group.Name, search) {
+					matches = append(matches, group)
+				}
+			}
+
+			start := 0
+			if after, ok := req.Variables["after"].(string); ok {
+				fmt.Sscan(after, &start)
+			}
+			end := min(start+2, len(matches))
+			page := map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": end < len(matches), "endCursor": fmt.Sprint(end)},
+				"nodes":    matches[start:end],
+			}
+			data = map[string]any{"organization": map[string]any{"projectGroups": page}}
+
+		case strings.Contains(req.Query, "DownloadSbom"):
+			content, ok := sbomContents[req.Variables["sbomId"].(string)]
+			if !ok {
+				json.NewEncoder(w).Encode(map[string]any{"errors": []map[string]string{{"message": "sbom not found"}}})
+				return
+			}
+			download := map[string]string{"content": base64.StdEncoding.EncodeToString([]byte(content)), "contentType": "application/json"}
+			data = map[string]any{"sbom": map[string]any{"download": download}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestConfig(url string) *InterlynkConfig {
+	config := NewInterlynkConfig()
+	config.URL = url
+	config.Token = "lynk_test"
+	config.client = NewClient(config)
+	return config
+}
+
+// sbomIDs are the SBOMs by the path they are fetched as
+var sbomIDs = map[string]string{
+	"payments_default_v1.0.0.sbom.json":     "s1",
+	"payments_default_v1.1.0.sbom.json":     "s2",
+	"payments_production_v1.0.0.sbom.json":  "s3",
+	"payments-api_default_v3.0.0.sbom.json": "s4",
+}
+
+func fetchPaths(t *testing.T, fetcher SBOMFetcher, config *InterlynkConfig) []string {
+	t.Helper()
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	it, err := fetcher.Fetch(ctx, config)
+	require.NoError(t, err)
+
+	var paths []string
+	for {
+		sbom, err := it.Next(ctx)
+		if err == io.EOF {
+			sort.Strings(paths)
+			return paths
+		}
+		require.NoError(t, err)
+		assert.Equal(t, sbomContents[sbomIDs[sbom.Path]], string(sbom.Data))
+		assert.True(t, sbom.ExplicitNamespace)
+		paths = append(paths, sbom.Path)
+	}
+}
+
+func TestFetchLatestSBOMs(t *testing.T) {
+	server := newGraphQLServer(t)
+	for _, fetcher := range []SBOMFetcher{&InterlynkSequentialFetcher{}, &InterlynkParallelFetcher{}} {
+		t.Run(fmt.Sprintf("%T", fetcher), func(t *testing.T) {
+			// every project group, across pages, with the latest SBOM of each environment
+			assert.Equal(t, []string{
+				"payments-api_default_v3.0.0.sbom.json",
+				"payments_default_v1.1.0.sbom.json",
+				"payments_production_v1.0.0.sbom.json",
+			}, fetchPaths(t, fetcher, newTestConfig(server.URL)))
+		})
+	}
+}
+
+func TestFetchProjectGroupVersions(t *testing.T) {
+	server := newGraphQLServer(t)
+
+	// the search for payments matches payments-api too, only the exact name is kept
+	config := newTestConfig(server.URL)
+	config.ProjectGroups = []string{"payments", "billing"}
+	config.Env = "default"
+	config.AllVersions = true
+	assert.Equal(t, []string{
+		"payments_default_v1.0.0.sbom.json",
+		"payments_default_v1.1.0.sbom.json",
+	}, fetchPaths(t, &InterlynkSequentialFetcher{}, config))
+}
+
+func TestFetchErrors(t *testing.T) {
+	server := newGraphQLServer(t)
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+
+	config := newTestConfig(server.URL)
+	config.ProjectGroups = []string{"billing"}
+	_, err := (&InterlynkSequentialFetcher{}).Fetch(ctx, config)
+	assert.ErrorContains(t, err, "none of the project groups [billing] found")
+
+	// the only SBOM of the environment isn't one
+	config = newTestConfig(server.URL)
+	config.ProjectGroups = []string{"platform"}
+	_, err = (&InterlynkParallelFetcher{}).Fetch(ctx, config)
+	assert.Equal(t, errdefs.KindNotFound, errdefs.KindOf(err))
+
+	config = newTestConfig(server.URL)
+	config.Token = "expired"
+	config.client = NewClient(config)
+	_, err = (&InterlynkSequentialFetcher{}).Fetch(ctx, config)
+	assert.Equal(t, errdefs.KindAuth, errdefs.KindOf(err))
+}
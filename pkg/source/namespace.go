@@ -0,0 +1,82 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NamespaceTemplate derives an SBOM's namespace and version from its file path or object key.
+// It is a regular expression whose capture groups select parts of the path:
+//   - a group named "namespace" is used as the namespace, otherwise all groups other than
+//     "version" are joined with "-"
+//   - a group named "version" is used as the version
+//
+// e.g. `^(?P<namespace>[^/]+/[^/]+)/(?P<version>[^/]+)/` maps "team/app/v1.2.0/sbom.json"
+// to namespace "team/app" and version "v1.2.0".
+type NamespaceTemplate struct {
+	re *regexp.Regexp
+}
+
+// ParseNamespaceTemplate compiles a namespace template, requiring at least one capture group
+func ParseNamespaceTemplate(template string) (*NamespaceTemplate, error) {
+	re, err := regexp.Compile(template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace template: %w", err)
+	}
+
+	if re.NumSubexp() == 0 {
+		return nil, fmt.Errorf("namespace template must contain at least one capture group")
+	}
+
+	return &NamespaceTemplate{re: re}, nil
+}
+
+// Apply matches the path (with "/" separators) against the template, returning the derived
+// namespace and version. ok is false when the path doesn't match or yields no namespace.
+func (t *NamespaceTemplate) Apply(path string) (namespace, version string, ok bool) {
+	if t == nil {
+		return "", "", false
+	}
+
+	match := t.re.FindStringSubmatch(path)
+	if match == nil {
+		return "", "", false
+	}
+
+	var parts []string
+	for i, name := range t.re.SubexpNames() {
+		if i == 0 || match[i] == "" {
+			continue
+		}
+
+		switch name {
+		case "namespace":
+			namespace = match[i]
+		case "version":
+			version = match[i]
+		default:
+			parts = append(parts, match[i])
+		}
+	}
+
+	if namespace == "" {
+		namespace = strings.Join(parts, "-")
+	}
+
+	return namespace, version, namespace != ""
+}
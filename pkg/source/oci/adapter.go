@@ -0,0 +1,151 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// OCIAdapter fetches the SBOMs attached to container images in any OCI registry
+type OCIAdapter struct {
+	Config         *OCIConfig
+	Role           types.AdapterRole // "input" or "output" adapter type
+	ProcessingMode types.ProcessingMode
+	Fetcher        SBOMFetcher
+}
+
+func (o *OCIAdapter) AddCommandParams(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("in-oci-image", nil, "Images to fetch the attached SBOMs of, e.g. ghcr.io/org/app:1.2.3 or ghcr.io/org/app@sha256:...")
+	cmd.Flags().StringSlice("in-oci-repositories", nil, "Repositories to fetch the SBOMs of every tag of, e.g. ghcr.io/org/app")
+	cmd.Flags().String("in-oci-username", "", "Registry username (or OCI_USERNAME), anonymous when unset")
+	cmd.Flags().String("in-oci-password", "", "Registry password or token (or OCI_PASSWORD)")
+	cmd.Flags().Bool("in-oci-plain-http", false, "Talk to the registry over plain HTTP, e.g. a local registry")
+}
+
+func (o *OCIAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	var (
+		imageFlag, repositoriesFlag, usernameFlag, passwordFlag, plainHTTPFlag string
+		missingFlags                                                           []string
+		invalidFlags                                                           []string
+	)
+
+	imageFlag = "in-oci-image"
+	repositoriesFlag = "in-oci-repositories"
+	usernameFlag = "in-oci-username"
+	passwordFlag = "in-oci-password"
+	plainHTTPFlag = "in-oci-plain-http"
+
+	var fetcher SBOMFetcher
+	if o.ProcessingMode == types.FetchSequential {
+		fetcher = &OCISequentialFetcher{}
+	} else if o.ProcessingMode == types.FetchParallel {
+		fetcher = &OCIParallelFetcher{}
+	} else {
+		return fmt.Errorf("unsupported processing mode: %s", o.ProcessingMode)
+	}
+
+	// validate flags for OCI adapter, all flags should start with "in-oci-"
+	err := utils.FlagValidation(cmd, types.OCIAdapterType, types.InputAdapterFlagPrefix)
+	if err != nil {
+		return fmt.Errorf("oci flag validation failed: %w", err)
+	}
+
+	imageValues, _ := cmd.Flags().GetStringSlice(imageFlag)
+	repositoryValues, _ := cmd.Flags().GetStringSlice(repositoriesFlag)
+	if len(imageValues) == 0 && len(repositoryValues) == 0 {
+		missingFlags = append(missingFlags, imageFlag+" or "+repositoriesFlag)
+	}
+
+	var images, repositories []Reference
+	for _, value := range imageValues {
+		ref, err := ParseReference(value)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (%v)", imageFlag, value, err))
+			continue
+		}
+		images = append(images, ref)
+	}
+	for _, value := range repositoryValues {
+		ref, err := ParseReference(value)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (%v)", repositoriesFlag, value, err))
+			continue
+		}
+		if ref.Tag != "" || ref.Digest != "" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be a repository without tag or digest, use --%s for a single image)", repositoriesFlag, value, imageFlag))
+			continue
+		}
+		repositories = append(repositories, ref)
+	}
+
+	// registry credentials, environment first like the other adapters' tokens
+	username := viper.GetString("OCI_USERNAME")
+	if username == "" {
+		username, _ = cmd.Flags().GetString(usernameFlag)
+	}
+	password := viper.GetString("OCI_PASSWORD")
+	if password == "" {
+		password, _ = cmd.Flags().GetString(passwordFlag)
+	}
+	if username == "" {
+		logger.LogDebug(cmd.Context(), "Registry credentials not provided, only public images are accessible")
+	}
+	plainHTTP, _ := cmd.Flags().GetBool(plainHTTPFlag)
+
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing flags: %s", strings.Join(missingFlags, ", "))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid input adapter flag usage:\n %s\n\nUse 'sbommv transfer --help' for correct usage.", strings.Join(invalidFlags, "\n "))
+	}
+
+	cfg := NewOCIConfig()
+	cfg.Images = images
+	cfg.Repositories = repositories
+	cfg.Username = username
+	cfg.Password = password
+	cfg.PlainHTTP = plainHTTP
+	cfg.ProcessingMode = o.ProcessingMode
+	cfg.client = NewClient(cfg)
+
+	o.Config = cfg
+	o.Fetcher = fetcher
+
+	return nil
+}
+
+func (o *OCIAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Initializing SBOM fetching", "mode", o.ProcessingMode)
+	return o.Fetcher.Fetch(ctx, o.Config)
+}
+
+func (o *OCIAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	return fmt.Errorf("OCI adapter does not support SBOM uploading")
+}
+
+func (o *OCIAdapter) DryRun(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
+	reporter := NewOCIReporter(false, "")
+	return reporter.DryRun(ctx, iterator)
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseFlags returns the adapter configured from the given flags, and the validation error
+func parseFlags(t *testing.T, mode types.ProcessingMode, args ...string) (*OCIAdapter, error) {
+	t.Helper()
+	adapter := &OCIAdapter{Role: types.InputAdapterRole, ProcessingMode: mode}
+	cmd := &cobra.Command{Use: "transfer"}
+	cmd.Flags().String("input-adapter", "oci", "")
+	cmd.Flags().String("in-harbor-url", "", "flag of another input adapter")
+	adapter.AddCommandParams(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetContext(context.Background())
+	return adapter, adapter.ParseAndValidateParams(cmd)
+}
+
+func TestParseAndValidateParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		invalid string
+	}{
+		{"no image nor repository", nil, "missing flags: in-oci-image or in-oci-repositories"},
+		{"uppercase repository", []string{"--in-oci-image=ghcr.io/Org/app:1.0"}, "--in-oci-image=ghcr.io/Org/app:1.0 (invalid repository"},
+		{"digest without algorithm", []string{"--in-oci-image=ghcr.io/org/app@abcd"}, "(invalid digest"},
+		{"repository with tag", []string{"--in-oci-repositories=ghcr.io/org/app:1.0"}, "--in-oci-repositories=ghcr.io/org/app:1.0 (must be a repository without tag or digest"},
+		{"flag of another adapter", []string{"--in-oci-image=nginx", "--in-harbor-url=https://harbor.example.com"}, "flag --in-harbor-url is invalid"},
+		{"images and repositories", []string{"--in-oci-image=nginx:1.27,ghcr.io/org/app@sha256:abcd", "--in-oci-repositories=localhost:5000/org/api", "--in-oci-plain-http"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := parseFlags(t, types.FetchSequential, tt.args...)
+			if tt.invalid != "" {
+				assert.ErrorContains(t, err, tt.invalid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []Reference{
+				{Registry: "docker.io", Repository: "library/nginx", Tag: "1.27"},
+				{Registry: "ghcr.io", Repository: "org/app", Digest: "sha256:abcd"},
+			}, adapter.Config.Images)
+			assert.Equal(t, []Reference{{Registry: "localhost:5000", Repository: "org/api"}}, adapter.Config.Repositories)
+			assert.True(t, adapter.Config.PlainHTTP)
+		})
+	}
+
+	_, err := parseFlags(t, "stream", "--in-oci-image=nginx")
+	assert.ErrorContains(t, err, "unsupported processing mode")
+}
+
+func TestParseAndValidateParamsCredentials(t *testing.T) {
+	viper.Set("OCI_PASSWORD", "env-token")
+	t.Cleanup(func() { viper.Set("OCI_PASSWORD", "") })
+
+	adapter, err := parseFlags(t, types.FetchParallel, "--in-oci-image=nginx", "--in-oci-username=ci", "--in-oci-password=flag-token")
+	require.NoError(t, err)
+	assert.Equal(t, "ci", adapter.Config.Username)
+	assert.Equal(t, "env-token", adapter.Config.Password)
+	assert.IsType(t, &OCIParallelFetcher{}, adapter.Fetcher)
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Reference
+		version string
+	}{
+		{"nginx", Reference{Registry: "docker.io", Repository: "library/nginx"}, "latest"},
+		{"interlynk/sbommv:v1", Reference{Registry: "docker.io", Repository: "interlynk/sbommv", Tag: "v1"}, "v1"},
+		{"localhost/app", Reference{Registry: "localhost", Repository: "app"}, "latest"},
+		{"localhost:5000/org/app:1.0", Reference{Registry: "localhost:5000", Repository: "org/app", Tag: "1.0"}, "1.0"},
+		{"ghcr.io/org/app:1.0@sha256:0123456789abcdef0123", Reference{Registry: "ghcr.io", Repository: "org/app", Tag: "1.0", Digest: "sha256:0123456789abcdef0123"}, "1.0"},
+		{"ghcr.io/org/app@sha256:0123456789abcdef0123", Reference{Registry: "ghcr.io", Repository: "org/app", Digest: "sha256:0123456789abcdef0123"}, "0123456789ab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			ref, err := ParseReference(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ref)
+			assert.Equal(t, tt.version, ref.Version())
+		})
+	}
+
+	ref, _ := ParseReference("nginx")
+	assert.Equal(t, "registry-1.docker.io", ref.host())
+	_, err := ParseReference("")
+	assert.Error(t, err)
+}
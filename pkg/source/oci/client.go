@@ -0,0 +1,353 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// manifestMediaTypes are the manifest and index types the registry may answer with
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// maxBlobSize bounds the size of a downloaded SBOM layer
+const maxBlobSize = 512 << 20
+
+// Descriptor describes a manifest or blob in the registry
+type Descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an image manifest or an image index, whichever the registry returned
+type Manifest struct {
+	MediaType    string       `json:"mediaType"`
+	ArtifactType string       `json:"artifactType,omitempty"`
+	Config       Descriptor   `json:"config"`
+	Layers       []Descriptor `json:"layers"`
+	Manifests    []Descriptor `json:"manifests"`
+}
+
+// IsIndex reports whether the manifest is a multi-platform index
+func (m *Manifest) IsIndex() bool {
+	return len(m.Manifests) > 0 || strings.Contains(m.MediaType, "index") || strings.Contains(m.MediaType, "manifest.list")
+}
+
+// artifactType returns the artifact type of a manifest, which older clients put in the config media type
+func (m *Manifest) artifactType() string {
+	if m.ArtifactType != "" {
+		return m.ArtifactType
+	}
+	return m.Config.MediaType
+}
+
+// StatusError is a non-200 response of the registry API
+type StatusError struct {
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d: %s", e.Path, e.StatusCode, e.Body)
+}
+
+// isNotFound reports whether the registry answered 404, e.g. for a missing cosign tag
+// or a registry without the referrers API
+func isNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// Client talks to the OCI distribution API (/v2) of any registry. It starts anonymous and
+// answers the registry's challenges, with basic auth or with a bearer token for the
+// repository fetched from the registry's token service, as docker does.
+type Client struct {
+	httpClient *http.Client
+	username   string
+	password   string
+	plainHTTP  bool
+
+	mu   sync.Mutex
+	auth map[string]string // Authorization header values by registry/repository
+}
+
+// NewClient initializes a registry client
+func NewClient(cfg *OCIConfig) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+		username:   cfg.Username,
+		password:   cfg.Password,
+		plainHTTP:  cfg.PlainHTTP,
+		auth:       make(map[string]string),
+	}
+}
+
+// GetManifest fetches a manifest or index by tag or digest and returns it with its digest
+func (c *Client) GetManifest(ctx tcontext.TransferMetadata, ref Reference, identifier string) (*Manifest, string, error) {
+	body, header, err := c.get(ctx, ref, fmt.Sprintf("/v2/%s/manifests/%s", ref.Repository, identifier), strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return nil, "", err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, "", fmt.Errorf("decoding manifest %s: %w", identifier, err)
+	}
+
+	digest := header.Get("Docker-Content-Digest")
+	if digest == "" {
+		sum := sha256.Sum256(body)
+		digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	return &manifest, digest, nil
+}
+
+// ListTags returns all tags of the repository
+func (c *Client) ListTags(ctx tcontext.TransferMetadata, ref Reference) ([]string, error) {
+	var tags []string
+	path := fmt.Sprintf("/v2/%s/tags/list?n=1000", ref.Repository)
+	for path != "" {
+		body, header, err := c.get(ctx, ref, path, "application/json")
+		if err != nil {
+			return nil, fmt.Errorf("listing tags of %s: %w", ref.Name(), err)
+		}
+
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("decoding tags of %s: %w", ref.Name(), err)
+		}
+		tags = append(tags, page.Tags...)
+		path = nextPage(header.Get("Link"))
+	}
+	return tags, nil
+}
+
+// Referrers returns the artifacts attached to a manifest through the OCI referrers API.
+// Registries without the API answer 404, which yields no referrers.
+func (c *Client) Referrers(ctx tcontext.TransferMetadata, ref Reference, digest string) ([]Descriptor, error) {
+	body, _, err := c.get(ctx, ref, fmt.Sprintf("/v2/%s/referrers/%s", ref.Repository, digest), "application/vnd.oci.image.index.v1+json")
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing referrers of %s: %w", digest, err)
+	}
+
+	var index Manifest
+	if err := json.Unmarshal(body, &index); err != nil {
+		return nil, fmt.Errorf("decoding referrers of %s: %w", digest, err)
+	}
+	return index.Manifests, nil
+}
+
+// GetBlob downloads a blob of the repository
+func (c *Client) GetBlob(ctx tcontext.TransferMetadata, ref Reference, digest string) ([]byte, error) {
+	body, _, err := c.get(ctx, ref, fmt.Sprintf("/v2/%s/blobs/%s", ref.Repository, digest), "")
+	if err != nil {
+		return nil, fmt.Errorf("fetching blob %s: %w", digest, err)
+	}
+	return body, nil
+}
+
+// get sends a GET to the registry API, answering a 401 challenge once and keeping the
+// resulting Authorization for the following requests to the repository
+func (c *Client) get(ctx tcontext.TransferMetadata, ref Reference, path, accept string) ([]byte, http.Header, error) {
+	scheme := "https"
+	if c.plainHTTP {
+		scheme = "http"
+	}
+	key := ref.Name()
+
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx.Context, http.MethodGet, scheme+"://"+ref.host()+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
+		c.mu.Lock()
+		auth := c.auth[key]
+		c.mu.Unlock()
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		if err := c.authenticate(ctx, ref, challenge); err != nil {
+			return nil, nil, err
+		}
+		if req, err = newRequest(); err != nil {
+			return nil, nil, err
+		}
+		if resp, err = c.httpClient.Do(req); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	body, err := readResponse(resp)
+	return body, resp.Header, err
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authenticate answers a registry challenge: basic auth with the credentials, or a pull token
+// for the repository from the token service named in a Bearer challenge
+func (c *Client) authenticate(ctx tcontext.TransferMetadata, ref Reference, challenge string) error {
+	scheme, _, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.username == "" {
+			return errdefs.Auth(fmt.Errorf("registry %s requires credentials, set --in-oci-username and --in-oci-password", ref.Registry))
+		}
+		c.setAuth(ref, "Basic "+base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password)))
+		return nil
+
+	case "bearer":
+		token, err := c.fetchToken(ctx, ref, challenge)
+		if err != nil {
+			return err
+		}
+		c.setAuth(ref, "Bearer "+token)
+		return nil
+
+	default:
+		return errdefs.Auth(fmt.Errorf("registry %s returned status %d", ref.Registry, http.StatusUnauthorized))
+	}
+}
+
+func (c *Client) setAuth(ref Reference, auth string) {
+	c.mu.Lock()
+	c.auth[ref.Name()] = auth
+	c.mu.Unlock()
+}
+
+// fetchToken gets a pull token for the repository, anonymously unless credentials are set
+func (c *Client) fetchToken(ctx tcontext.TransferMetadata, ref Reference, challenge string) (string, error) {
+	params := map[string]string{}
+	for _, m := range challengeParam.FindAllStringSubmatch(challenge, -1) {
+		params[m[1]] = m[2]
+	}
+	if params["realm"] == "" {
+		return "", fmt.Errorf("registry auth challenge without realm: %s", challenge)
+	}
+
+	query := url.Values{"scope": {fmt.Sprintf("repository:%s:pull", ref.Repository)}}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+
+	req, err := http.NewRequestWithContext(ctx.Context, http.MethodGet, params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching registry token: %w", err)
+	}
+	body, err := readResponse(resp)
+	if err != nil {
+		return "", fmt.Errorf("fetching registry token: %w", err)
+	}
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("decoding registry token: %w", err)
+	}
+
+	token := tokenResp.Token
+	if token == "" {
+		token = tokenResp.AccessToken
+	}
+	if token == "" {
+		return "", fmt.Errorf("registry token service returned no token")
+	}
+	return token, nil
+}
+
+var linkNext = regexp.MustCompile(`<([^>]+)>;\s*rel="?next"?`)
+
+// nextPage returns the path of the next page from a Link header, empty on the last page
+func nextPage(link string) string {
+	m := linkNext.FindStringSubmatch(link)
+	if m == nil {
+		return ""
+	}
+	u, err := url.Parse(m[1])
+	if err != nil {
+		return ""
+	}
+	return u.RequestURI()
+}
+
+func readResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBlobSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errdefs.FromStatus(resp.StatusCode, &StatusError{Path: resp.Request.URL.Path, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))})
+	}
+	return body, nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// sbomMediaTypes are the layer (and referrer artifact) media types of plain SBOM documents,
+// as pushed by cosign attach sbom, oras attach or syft
+var sbomMediaTypes = map[string]bool{
+	"application/spdx+json":          true,
+	"text/spdx+json":                 true,
+	"text/spdx":                      true,
+	"application/vnd.cyclonedx+json": true,
+	"application/vnd.cyclonedx+xml":  true,
+	"application/vnd.cyclonedx":      true,
+}
+
+// attestation layers wrap an in-toto statement, signed in a DSSE envelope (cosign attest)
+// or as is (docker buildx --sbom)
+const (
+	mediaTypeDSSE   = "application/vnd.dsse.envelope.v1+json"
+	mediaTypeInToto = "application/vnd.in-toto+json"
+)
+
+// predicate type annotations of cosign and of docker buildx attestation layers
+var predicateTypeAnnotations = []string{"predicateType", "in-toto.io/predicate-type"}
+
+// attestationManifestAnnotation marks the attestation manifests docker buildx adds to an image index
+const attestationManifestAnnotation = "vnd.docker.reference.type"
+
+// cosign stores attachments and attestations of a manifest under tags derived from its digest
+var cosignTagSuffixes = []string{".sbom", ".att"}
+
+// sbomRef is an SBOM layer attached to an image, found without downloading it
+type sbomRef struct {
+	image Reference
+	layer Descriptor
+	via   string // referrers, cosign or attestation-manifest
+}
+
+// isSBOMPredicate reports whether an in-toto predicate type is an SPDX or CycloneDX document
+func isSBOMPredicate(predicateType string) bool {
+	return strings.HasPrefix(predicateType, "https://spdx.dev/Document") || strings.HasPrefix(predicateType, "https://cyclonedx.org/bom")
+}
+
+// isSBOMLayer reports whether a layer holds an SBOM, or an attestation that may be one.
+// Attestations without a predicate type annotation are checked once downloaded.
+func isSBOMLayer(layer Descriptor) bool {
+	if sbomMediaTypes[layer.MediaType] {
+		return true
+	}
+	if layer.MediaType != mediaTypeDSSE && layer.MediaType != mediaTypeInToto {
+		return false
+	}
+	for _, key := range predicateTypeAnnotations {
+		if pt, ok := layer.Annotations[key]; ok {
+			return isSBOMPredicate(pt)
+		}
+	}
+	return true
+}
+
+// isSBOMArtifact reports whether a referrer's artifact type may carry an SBOM
+func isSBOMArtifact(artifactType string) bool {
+	return sbomMediaTypes[artifactType] || artifactType == mediaTypeDSSE || artifactType == mediaTypeInToto
+}
+
+// discoverSBOMs finds the SBOM layers attached to an image through the OCI referrers API,
+// cosign's digest tags and the attestation manifests of a buildx image index
+func discoverSBOMs(ctx tcontext.TransferMetadata, client *Client, image Reference) ([]sbomRef, error) {
+	manifest, digest, err := client.GetManifest(ctx, image, image.Identifier())
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", image, err)
+	}
+
+	var refs []sbomRef
+	seen := map[string]bool{}
+	add := func(layers []Descriptor, via string) {
+		for _, layer := range layers {
+			if !isSBOMLayer(layer) || seen[layer.Digest] {
+				continue
+			}
+			seen[layer.Digest] = true
+			refs = append(refs, sbomRef{image: image, layer: layer, via: via})
+		}
+	}
+
+	if manifest.IsIndex() {
+		for _, desc := range manifest.Manifests {
+			if desc.Annotations[attestationManifestAnnotation] != "attestation-manifest" {
+				continue
+			}
+			attestation, _, err := client.GetManifest(ctx, image, desc.Digest)
+			if err != nil {
+				logger.LogDebug(ctx.Context, "Skipping attestation manifest", "image", image, "digest", desc.Digest, "error", err)
+				continue
+			}
+			add(attestation.Layers, "attestation-manifest")
+		}
+	}
+
+	referrers, err := client.Referrers(ctx, image, digest)
+	if err != nil {
+		logger.LogDebug(ctx.Context, "Referrers API unavailable", "image", image, "error", err)
+	}
+	for _, desc := range referrers {
+		if !isSBOMArtifact(desc.ArtifactType) {
+			continue
+		}
+		referrer, _, err := client.GetManifest(ctx, image, desc.Digest)
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Skipping referrer", "image", image, "digest", desc.Digest, "error", err)
+			continue
+		}
+		add(referrer.Layers, "referrers")
+	}
+
+	for _, suffix := range cosignTagSuffixes {
+		tag := strings.Replace(digest, ":", "-", 1) + suffix
+		attached, _, err := client.GetManifest(ctx, image, tag)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Skipping cosign tag", "image", image, "tag", tag, "error", err)
+			continue
+		}
+		add(attached.Layers, "cosign")
+	}
+
+	logger.LogDebug(ctx.Context, "SBOMs attached to image", "image", image, "digest", digest, "count", len(refs))
+	return refs, nil
+}
+
+// extractSBOM returns the SBOM held by a layer: the layer itself, or the predicate of the
+// in-toto statement an attestation layer carries
+func extractSBOM(layer Descriptor, data []byte) ([]byte, error) {
+	switch layer.MediaType {
+	case mediaTypeDSSE:
+		var envelope struct {
+			PayloadType string `json:"payloadType"`
+			Payload     string `json:"payload"`
+		}
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return nil, fmt.Errorf("decoding DSSE envelope: %w", err)
+		}
+		payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("decoding DSSE payload: %w", err)
+		}
+		return statementPredicate(payload)
+
+	case mediaTypeInToto:
+		return statementPredicate(data)
+
+	default:
+		return data, nil
+	}
+}
+
+func statementPredicate(data []byte) ([]byte, error) {
+	var statement struct {
+		PredicateType string          `json:"predicateType"`
+		Predicate     json.RawMessage `json:"predicate"`
+	}
+	if err := json.Unmarshal(data, &statement); err != nil {
+		return nil, fmt.Errorf("decoding in-toto statement: %w", err)
+	}
+	if !isSBOMPredicate(statement.PredicateType) {
+		return nil, fmt.Errorf("attestation predicate %s is not an SBOM", statement.PredicateType)
+	}
+	return statement.Predicate, nil
+}
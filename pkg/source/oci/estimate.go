@@ -0,0 +1,39 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"fmt"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+func (o *OCIAdapter) Estimate(ctx tcontext.TransferMetadata) ([]types.SBOMCandidate, error) {
+	refs, err := listSBOMLayers(ctx, o.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]types.SBOMCandidate, 0, len(refs))
+	for _, ref := range refs {
+		candidates = append(candidates, types.SBOMCandidate{
+			Name:      fmt.Sprintf("%s:%s", ref.image.Name(), ref.image.Version()),
+			Namespace: ref.image.Name(),
+			Size:      ref.layer.Size,
+		})
+	}
+	return candidates, nil
+}
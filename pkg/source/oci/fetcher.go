@@ -0,0 +1,205 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+type SBOMFetcher interface {
+	Fetch(ctx tcontext.TransferMetadata, config *OCIConfig) (iterator.SBOMIterator, error)
+}
+
+type (
+	OCISequentialFetcher struct{}
+	OCIParallelFetcher   struct{}
+)
+
+// Fetch downloads the SBOMs attached to the images one by one
+func (f *OCISequentialFetcher) Fetch(ctx tcontext.TransferMetadata, config *OCIConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Sequentially")
+
+	refs, err := listSBOMLayers(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var sbomList []*iterator.SBOM
+	for _, ref := range refs {
+		if ctx.Err() != nil {
+			return nil, source.FetchInterrupted(ctx, len(sbomList))
+		}
+
+		sbom, err := fetchSBOM(ctx, config, ref)
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Failed to fetch SBOM layer", "image", ref.image, "digest", ref.layer.Digest, "error", err)
+			continue
+		}
+		sbomList = append(sbomList, sbom)
+	}
+
+	if len(sbomList) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs attached to the images"))
+	}
+	return NewOCIIterator(sbomList), nil
+}
+
+// Fetch downloads the SBOMs attached to the images with a few concurrent downloads
+func (f *OCIParallelFetcher) Fetch(ctx tcontext.TransferMetadata, config *OCIConfig) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Concurrently")
+
+	refs, err := listSBOMLayers(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var sbomList []*iterator.SBOM
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	const maxConcurrency = 3
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	for _, ref := range refs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(ref sbomRef) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			sbom, err := fetchSBOM(ctx, config, ref)
+			if err != nil {
+				logger.LogDebug(ctx.Context, "Failed to fetch SBOM layer", "image", ref.image, "digest", ref.layer.Digest, "error", err)
+				return
+			}
+
+			mu.Lock()
+			sbomList = append(sbomList, sbom)
+			mu.Unlock()
+		}(ref)
+	}
+
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, source.FetchInterrupted(ctx, len(sbomList))
+	}
+	if len(sbomList) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs attached to the images"))
+	}
+	return NewOCIIterator(sbomList), nil
+}
+
+// listSBOMLayers resolves the configured images and returns the SBOM layers attached to
+// them, without downloading them
+func listSBOMLayers(ctx tcontext.TransferMetadata, config *OCIConfig) ([]sbomRef, error) {
+	images, err := resolveImages(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	logger.LogDebug(ctx.Context, "Total images from which SBOMs will be fetched", "count", len(images))
+
+	var refs []sbomRef
+	for _, image := range images {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		found, err := discoverSBOMs(ctx, config.client, image)
+		if err != nil {
+			logger.LogInfo(ctx.Context, "Skipping image, failed to look up its SBOMs", "image", image, "error", err)
+			continue
+		}
+		if len(found) == 0 {
+			logger.LogDebug(ctx.Context, "No SBOM attached to image", "image", image)
+		}
+		refs = append(refs, found...)
+	}
+
+	logger.LogDebug(ctx.Context, "SBOM layers found", "count", len(refs))
+	return refs, nil
+}
+
+// digestTag matches the tags cosign and the referrers tag schema derive from a digest,
+// e.g. sha256-<hex>.sig, which are artifacts and not images
+var digestTag = regexp.MustCompile(`^sha256-[0-9a-f]{64}`)
+
+// resolveImages returns the configured images followed by every tag of the configured repositories
+func resolveImages(ctx tcontext.TransferMetadata, config *OCIConfig) ([]Reference, error) {
+	images := append([]Reference(nil), config.Images...)
+
+	for _, repo := range config.Repositories {
+		tags, err := config.client.ListTags(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			if digestTag.MatchString(tag) {
+				continue
+			}
+			images = append(images, repo.WithTag(tag))
+		}
+	}
+
+	if len(images) == 0 {
+		return nil, fmt.Errorf("no images found in repositories %v", config.Repositories)
+	}
+	return images, nil
+}
+
+// pathReplacer turns an image name into a file name, e.g. localhost:5000/org/app to localhost_5000_org_app
+var pathReplacer = strings.NewReplacer("/", "_", ":", "_")
+
+// fetchSBOM downloads an SBOM layer. The namespace is the image name and the version its
+// tag, so each image version gets its own project at the destination.
+func fetchSBOM(ctx tcontext.TransferMetadata, config *OCIConfig, ref sbomRef) (*iterator.SBOM, error) {
+	data, err := config.client.GetBlob(ctx, ref.image, ref.layer.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := extractSBOM(ref.layer, data)
+	if err != nil {
+		return nil, fmt.Errorf("layer %s: %w", ref.layer.Digest, err)
+	}
+
+	if !source.IsSBOM(ctx, ref.image.Name()+"@"+ref.layer.Digest, content) {
+		return nil, fmt.Errorf("layer %s is not a valid SBOM", ref.layer.Digest)
+	}
+
+	name := ref.image.Name()
+	version := ref.image.Version()
+	logger.LogDebug(ctx.Context, "Fetched SBOM", "image", name, "version", version, "via", ref.via, "size", len(content))
+
+	return &iterator.SBOM{
+		Path:              fmt.Sprintf("%s_%s_%s.sbom.json", pathReplacer.Replace(name), version, shortDigest(ref.layer.Digest)),
+		Data:              content,
+		Namespace:         name,
+		Version:           version,
+		ExplicitNamespace: true,
+	}, nil
+}
@@ -0,0 +1,320 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cyclonedxPredicate = "https://cyclonedx.org/bom"
+
+func cyclonedxSBOM(name string) string {
+	return fmt.Sprintf(`{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"metadata":{"component":{"type":"application","name":%q,"version":"1.0.0"}}}`, name)
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func statement(predicateType, predicate string) string {
+	return fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","predicateType":%q,"predicate":%s}`, predicateType, predicate)
+}
+
+// registry serves repository org/app with two tags, behind a bearer token. v1 is an image
+// with an SBOM artifact in the referrers API and a cosign attestation tag holding an SBOM
+// and a provenance; v2 is an index with a buildx attestation manifest holding an SBOM and
+// no referrers. The cosign tag shows up in the tag list, which is paged.
+type registry struct {
+	*httptest.Server
+
+	manifests map[string][]byte // by tag and digest
+	blobs     map[string][]byte
+	referrers map[string][]Descriptor
+	v1        string // digest of the v1 manifest
+}
+
+func newRegistry(t *testing.T) *registry {
+	r := &registry{
+		manifests: map[string][]byte{},
+		blobs:     map[string][]byte{},
+		referrers: map[string][]Descriptor{},
+	}
+	r.Server = httptest.NewServer(r)
+	t.Cleanup(r.Close)
+
+	blob := func(mediaType, data string, annotations map[string]string) Descriptor {
+		r.blobs[digestOf([]byte(data))] = []byte(data)
+		return Descriptor{MediaType: mediaType, Digest: digestOf([]byte(data)), Size: int64(len(data)), Annotations: annotations}
+	}
+	manifest := func(m Manifest, tags ...string) Descriptor {
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		digest := digestOf(data)
+		r.manifests[digest] = data
+		for _, tag := range tags {
+			r.manifests[tag] = data
+		}
+		return Descriptor{MediaType: m.MediaType, ArtifactType: m.ArtifactType, Digest: digest, Size: int64(len(data))}
+	}
+	const imageManifest = "application/vnd.oci.image.manifest.v1+json"
+	config := blob("application/vnd.oci.image.config.v1+json", "{}", nil)
+
+	v1 := manifest(Manifest{MediaType: imageManifest, Config: config, Layers: []Descriptor{blob("application/vnd.oci.image.layer.v1.tar+gzip", "v1 layer", nil)}}, "v1")
+	r.v1 = v1.Digest
+	r.referrers[v1.Digest] = []Descriptor{
+		manifest(Manifest{MediaType: imageManifest, ArtifactType: "application/vnd.cyclonedx+json", Config: config, Layers: []Descriptor{
+			blob("application/vnd.cyclonedx+json", cyclonedxSBOM("referrer"), nil),
+		}}),
+		manifest(Manifest{MediaType: imageManifest, ArtifactType: "application/vnd.dev.cosign.artifact.sig.v1+json", Config: config, Layers: []Descriptor{
+			blob("application/vnd.dev.cosign.simplesigning.v1+json", "signature", nil),
+		}}),
+	}
+	envelope := func(s string) string {
+		return fmt.Sprintf(`{"payloadType":"application/vnd.in-toto+json","payload":%q,"signatures":[]}`, base64.StdEncoding.EncodeToString([]byte(s)))
+	}
+	manifest(Manifest{MediaType: imageManifest, Config: config, Layers: []Descriptor{
+		blob(mediaTypeDSSE, envelope(statement(cyclonedxPredicate, cyclonedxSBOM("cosign"))), map[string]string{"predicateType": cyclonedxPredicate}),
+		blob(mediaTypeDSSE, envelope(statement("https://slsa.dev/provenance/v1", "{}")), map[string]string{"predicateType": "https://slsa.dev/provenance/v1"}),
+	}}, strings.Replace(v1.Digest, ":", "-", 1)+".att")
+
+	platform := manifest(Manifest{MediaType: imageManifest, Config: config, Layers: []Descriptor{blob("application/vnd.oci.image.layer.v1.tar+gzip", "v2 layer", nil)}})
+	attestation := manifest(Manifest{MediaType: imageManifest, Config: config, Layers: []Descriptor{
+		blob(mediaTypeInToto, statement(cyclonedxPredicate, cyclonedxSBOM("buildx")), map[string]string{"in-toto.io/predicate-type": cyclonedxPredicate}),
+	}})
+	attestation.Annotations = map[string]string{attestationManifestAnnotation: "attestation-manifest", "vnd.docker.reference.digest": platform.Digest}
+	manifest(Manifest{MediaType: "application/vnd.oci.image.index.v1+json", Manifests: []Descriptor{platform, attestation}}, "v2")
+	return r
+}
+
+func (r *registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if req.URL.Query().Get("scope") != "repository:org/app:pull" || req.URL.Query().Get("service") != "test-registry" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"token":"pull-token"}`)
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer pull-token" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test-registry",scope="repository:org/app:pull"`, r.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path, ok := strings.CutPrefix(req.URL.Path, "/v2/org/app/")
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	kind, id, _ := strings.Cut(path, "/")
+	switch kind {
+	case "tags":
+		if req.URL.Query().Get("last") == "" {
+			w.Header().Set("Link", `</v2/org/app/tags/list?last=v1&n=1000>; rel="next"`)
+			fmt.Fprint(w, `{"name":"org/app","tags":["v1"]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"name":"org/app","tags":["v2",%q]}`, strings.Replace(r.v1, ":", "-", 1)+".att")
+
+	case "manifests":
+		data, ok := r.manifests[id]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", digestOf(data))
+		w.Write(data)
+
+	case "referrers":
+		descs, ok := r.referrers[id]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		json.NewEncoder(w).Encode(Manifest{MediaType: "application/vnd.oci.image.index.v1+json", Manifests: descs})
+
+	case "blobs":
+		data, ok := r.blobs[id]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Write(data)
+
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// repository returns the reference of org/app on the registry, which is served over http
+func (r *registry) repository(t *testing.T) Reference {
+	ref, err := ParseReference(strings.TrimPrefix(r.URL, "http://") + "/org/app")
+	require.NoError(t, err)
+	return ref
+}
+
+// blobOf returns the layer the SBOM was read from, the SBOM itself or its attestation
+func (r *registry) blobOf(t *testing.T, sbom *iterator.SBOM) []byte {
+	t.Helper()
+	for _, data := range r.blobs {
+		if strings.Contains(string(data), string(sbom.Data)) {
+			return data
+		}
+		var envelope struct {
+			Payload []byte `json:"payload"`
+		}
+		if json.Unmarshal(data, &envelope) == nil && strings.Contains(string(envelope.Payload), string(sbom.Data)) {
+			return data
+		}
+	}
+	t.Fatalf("no layer holds SBOM %s", sbom.Path)
+	return nil
+}
+
+func drain(t *testing.T, ctx tcontext.TransferMetadata, it iterator.SBOMIterator) []*iterator.SBOM {
+	t.Helper()
+	var sboms []*iterator.SBOM
+	for {
+		sbom, err := it.Next(ctx)
+		if err == io.EOF {
+			return sboms
+		}
+		require.NoError(t, err)
+		sboms = append(sboms, sbom)
+	}
+}
+
+func TestFetchAttachedSBOMs(t *testing.T) {
+	for _, fetcher := range []SBOMFetcher{&OCISequentialFetcher{}, &OCIParallelFetcher{}} {
+		t.Run(fmt.Sprintf("%T", fetcher), func(t *testing.T) {
+			ctx := *tcontext.NewTransferMetadata(context.Background())
+			server := newRegistry(t)
+			repo := server.repository(t)
+
+			config := NewOCIConfig()
+			config.Repositories = []Reference{repo}
+			config.PlainHTTP = true
+			config.client = NewClient(config)
+
+			it, err := fetcher.Fetch(ctx, config)
+			require.NoError(t, err)
+			sboms := drain(t, ctx, it)
+
+			byComponent := map[string]*iterator.SBOM{}
+			for _, sbom := range sboms {
+				var bom struct {
+					Metadata struct {
+						Component struct {
+							Name string `json:"name"`
+						} `json:"component"`
+					} `json:"metadata"`
+				}
+				require.NoError(t, json.Unmarshal(sbom.Data, &bom))
+				byComponent[bom.Metadata.Component.Name] = sbom
+			}
+			names := make([]string, 0, len(byComponent))
+			for name := range byComponent {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			require.Equal(t, []string{"buildx", "cosign", "referrer"}, names, "SBOMs of the referrers API, cosign and buildx, not the provenance")
+
+			assert.Equal(t, "v1", byComponent["referrer"].Version)
+			assert.Equal(t, "v1", byComponent["cosign"].Version)
+			assert.Equal(t, "v2", byComponent["buildx"].Version)
+			for _, sbom := range sboms {
+				assert.Equal(t, repo.Name(), sbom.Namespace)
+				assert.True(t, sbom.ExplicitNamespace)
+				assert.Equal(t, fmt.Sprintf("%s_%s_%s.sbom.json", pathReplacer.Replace(repo.Name()), sbom.Version, shortDigest(digestOf(server.blobOf(t, sbom)))), sbom.Path)
+			}
+		})
+	}
+}
+
+func TestFetchImageByDigest(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	server := newRegistry(t)
+	image := server.repository(t)
+	image.Digest = server.v1
+
+	config := NewOCIConfig()
+	config.Images = []Reference{image}
+	config.PlainHTTP = true
+	config.client = NewClient(config)
+
+	it, err := (&OCISequentialFetcher{}).Fetch(ctx, config)
+	require.NoError(t, err)
+	sboms := drain(t, ctx, it)
+	require.Len(t, sboms, 2)
+	for _, sbom := range sboms {
+		assert.Equal(t, shortDigest(server.v1), sbom.Version)
+	}
+}
+
+func TestFetchWithoutSBOMs(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	server := newRegistry(t)
+	image := server.repository(t)
+	image.Tag = "v3"
+
+	config := NewOCIConfig()
+	config.Images = []Reference{image}
+	config.PlainHTTP = true
+	config.client = NewClient(config)
+
+	_, err := (&OCISequentialFetcher{}).Fetch(ctx, config)
+	assert.Equal(t, errdefs.KindNotFound, errdefs.KindOf(err))
+}
+
+func TestBasicChallengeRequiresCredentials(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "ci" || password != "secret" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"tags":["v1"]}`)
+	}))
+	t.Cleanup(server.Close)
+	ref, err := ParseReference(strings.TrimPrefix(server.URL, "http://") + "/org/app")
+	require.NoError(t, err)
+
+	_, err = NewClient(&OCIConfig{PlainHTTP: true}).ListTags(ctx, ref)
+	assert.Equal(t, errdefs.KindAuth, errdefs.KindOf(err))
+
+	tags, err := NewClient(&OCIConfig{PlainHTTP: true, Username: "ci", Password: "secret"}).ListTags(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1"}, tags)
+}
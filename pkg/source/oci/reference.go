@@ -0,0 +1,130 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	dockerHubDomain   = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+)
+
+// Reference points to an image (or a whole repository when Tag and Digest are empty)
+// in an OCI registry, e.g. ghcr.io/org/app:1.2.3 or ghcr.io/org/app@sha256:...
+type Reference struct {
+	Registry   string // registry host, e.g. ghcr.io or localhost:5000
+	Repository string // repository path, e.g. org/app
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses an image reference the way docker does: a first path component
+// without a dot, a colon or "localhost" is not a registry, so "nginx" is docker.io/library/nginx
+func ParseReference(s string) (Reference, error) {
+	var ref Reference
+	if s == "" {
+		return ref, fmt.Errorf("empty image reference")
+	}
+
+	name := s
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.Digest = name[:i], name[i+1:]
+		if !strings.Contains(ref.Digest, ":") {
+			return ref, fmt.Errorf("invalid digest in %q", s)
+		}
+	}
+
+	// a tag is the part after the last colon, unless that colon belongs to the registry port
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+	}
+
+	first, rest, ok := strings.Cut(name, "/")
+	if ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry, ref.Repository = first, rest
+	} else {
+		ref.Registry, ref.Repository = dockerHubDomain, name
+	}
+
+	if ref.Registry == dockerHubDomain && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+	if ref.Repository == "" || ref.Repository != strings.ToLower(ref.Repository) {
+		return ref, fmt.Errorf("invalid repository in %q", s)
+	}
+	return ref, nil
+}
+
+// Name returns the registry and repository, e.g. ghcr.io/org/app
+func (r Reference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// Identifier returns the digest, or the tag ("latest" when neither is set) to address the manifest with
+func (r Reference) Identifier() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	if r.Tag != "" {
+		return r.Tag
+	}
+	return "latest"
+}
+
+// Version returns the tag of the image, or its short digest when it is referenced by digest only
+func (r Reference) Version() string {
+	if r.Tag != "" {
+		return r.Tag
+	}
+	if r.Digest != "" {
+		return shortDigest(r.Digest)
+	}
+	return "latest"
+}
+
+// WithTag returns the reference to a tag of the same repository
+func (r Reference) WithTag(tag string) Reference {
+	return Reference{Registry: r.Registry, Repository: r.Repository, Tag: tag}
+}
+
+func (r Reference) String() string {
+	s := r.Name()
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// host returns the host serving the registry API, Docker Hub's API is not on docker.io
+func (r Reference) host() string {
+	if r.Registry == dockerHubDomain {
+		return dockerHubRegistry
+	}
+	return r.Registry
+}
+
+func shortDigest(digest string) string {
+	_, hex, _ := strings.Cut(digest, ":")
+	if len(hex) > 12 {
+		hex = hex[:12]
+	}
+	return hex
+}
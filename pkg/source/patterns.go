@@ -0,0 +1,123 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// regexPatternPrefix marks a file pattern as a regular expression rather than a glob
+const regexPatternPrefix = "regex:"
+
+// FilePatterns selects the files an input adapter transfers by their path relative to the
+// folder or prefix. A pattern is a glob, matched against the file name or, when it contains
+// a "/", against the whole relative path; a pattern starting with "regex:" is a regular
+// expression searched in the relative path. A file is selected when it matches one of the
+// include patterns, or there are none, and matches none of the exclude patterns.
+//
+// e.g. include "*.cdx.json" with exclude "regex:^archive/" transfers the CycloneDX JSON
+// files outside the archive directory.
+type FilePatterns struct {
+	include []filePattern
+	exclude []filePattern
+}
+
+// filePattern is one compiled include or exclude pattern
+type filePattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+// ParseFilePatterns compiles include and exclude patterns, returning nil when there are none
+func ParseFilePatterns(include, exclude []string) (*FilePatterns, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+
+	var patterns FilePatterns
+	var err error
+	if patterns.include, err = parseFilePatterns(include); err != nil {
+		return nil, err
+	}
+	if patterns.exclude, err = parseFilePatterns(exclude); err != nil {
+		return nil, err
+	}
+	return &patterns, nil
+}
+
+func parseFilePatterns(values []string) ([]filePattern, error) {
+	var patterns []filePattern
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if expr, ok := strings.CutPrefix(value, regexPatternPrefix); ok {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", value, err)
+			}
+			patterns = append(patterns, filePattern{re: re})
+			continue
+		}
+
+		// path.Match only reports malformed globs when matching, so check them upfront
+		if _, err := path.Match(value, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", value, err)
+		}
+		patterns = append(patterns, filePattern{glob: value})
+	}
+	return patterns, nil
+}
+
+// Allows reports whether the file at relPath (with "/" separators) is selected. A nil
+// FilePatterns selects every file.
+func (p *FilePatterns) Allows(relPath string) bool {
+	if p == nil {
+		return true
+	}
+
+	relPath = strings.TrimPrefix(relPath, "/")
+	if len(p.include) > 0 && !matchesAny(p.include, relPath) {
+		return false
+	}
+	return !matchesAny(p.exclude, relPath)
+}
+
+func matchesAny(patterns []filePattern, relPath string) bool {
+	for _, pattern := range patterns {
+		if pattern.matches(relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p filePattern) matches(relPath string) bool {
+	if p.re != nil {
+		return p.re.MatchString(relPath)
+	}
+
+	name := relPath
+	if !strings.Contains(p.glob, "/") {
+		name = path.Base(relPath)
+	}
+	ok, _ := path.Match(p.glob, name)
+	return ok
+}
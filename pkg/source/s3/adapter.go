@@ -16,10 +16,12 @@ package s3
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 	"github.com/interlynk-io/sbommv/pkg/types"
 	"github.com/interlynk-io/sbommv/pkg/utils"
@@ -30,6 +32,7 @@ type S3Adapter struct {
 	Config         *S3Config
 	Role           types.AdapterRole // "input" or "output" adapter type
 	ProcessingMode types.ProcessingMode
+	Daemon         bool
 	Fetcher        SBOMFetcher
 }
 
@@ -40,14 +43,22 @@ func (s3 *S3Adapter) AddCommandParams(cmd *cobra.Command) {
 	cmd.Flags().String("in-s3-prefix", "", "S3 prefix")
 	cmd.Flags().String("in-s3-access-key", "", "AWS access key for S3")
 	cmd.Flags().String("in-s3-secret-key", "", "AWS secret key for S3")
+	cmd.Flags().String("in-s3-session-token", "", "AWS session token for temporary credentials, used with the access and secret keys")
+	cmd.Flags().String("in-s3-endpoint-url", "", "Custom S3 endpoint URL for S3-compatible stores such as MinIO, Ceph or Cloudflare R2 (default: the AWS endpoint of the region)")
+	cmd.Flags().Bool("in-s3-path-style", false, "Address buckets as <endpoint>/<bucket> instead of <bucket>.<endpoint>, needed by most S3-compatible stores")
+	cmd.Flags().String("in-s3-poll-interval", "5m", "Polling interval to check the bucket for new or changed SBOMs in daemon mode (supports formats like '60s', '10m', '10hr', or plain seconds)")
+	cmd.Flags().StringSlice("in-s3-include-pattern", nil, "Transfer only objects matching these patterns: globs on the object name, or on the key relative to the prefix when containing '/', or 'regex:<expr>', e.g. '*.cdx.json'")
+	cmd.Flags().StringSlice("in-s3-exclude-pattern", nil, "Skip objects matching these patterns, with the same syntax as --in-s3-include-pattern, e.g. '*.spdx.json'")
+	cmd.Flags().String("in-s3-spool-size", "", "Parallel mode: keep downloaded objects larger than this in temp files until they are transferred, instead of memory (e.g. '50MB')")
+	cmd.Flags().String("in-s3-namespace-template", "", "Regex with capture groups deriving namespace and version from the object key relative to the prefix, e.g. '^(?P<namespace>[^/]+)/(?P<version>[^/]+)/'")
 }
 
 // ParseAndValidateParams validates the S3 adapter params
 func (s *S3Adapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	var (
-		bucketNameFlag, regionFlag, prefixFlag, accessKeyFlag, secretKeyFlag string
-		missingFlags                                                         []string
-		invalidFlags                                                         []string
+		bucketNameFlag, regionFlag, prefixFlag, accessKeyFlag, secretKeyFlag, sessionTokenFlag, endpointURLFlag, pathStyleFlag, namespaceTemplateFlag, pollFlag, includeFlag, excludeFlag, spoolSizeFlag string
+		missingFlags                                                                                                                                                                                     []string
+		invalidFlags                                                                                                                                                                                     []string
 	)
 
 	bucketNameFlag = "in-s3-bucket-name"
@@ -55,11 +66,21 @@ func (s *S3Adapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	prefixFlag = "in-s3-prefix"
 	accessKeyFlag = "in-s3-access-key"
 	secretKeyFlag = "in-s3-secret-key"
+	sessionTokenFlag = "in-s3-session-token"
+	endpointURLFlag = "in-s3-endpoint-url"
+	pathStyleFlag = "in-s3-path-style"
+	namespaceTemplateFlag = "in-s3-namespace-template"
+	pollFlag = "in-s3-poll-interval"
+	includeFlag = "in-s3-include-pattern"
+	excludeFlag = "in-s3-exclude-pattern"
+	spoolSizeFlag = "in-s3-spool-size"
 
 	var bucketName, region, prefix string
 	var fetcher SBOMFetcher
 
-	if s.ProcessingMode == types.FetchSequential {
+	if s.Daemon {
+		fetcher = NewWatcherFetcher()
+	} else if s.ProcessingMode == types.FetchSequential {
 		fetcher = &S3SequentialFetcher{}
 	} else if s.ProcessingMode == types.FetchParallel {
 		fetcher = &S3ParallelFetcher{}
@@ -96,6 +117,68 @@ func (s *S3Adapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	// extract AWS secret Key
 	secretKey, _ := cmd.Flags().GetString(secretKeyFlag)
 
+	// static credentials are used only as a pair, otherwise the default credential chain applies
+	if (accessKey == "") != (secretKey == "") {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s and --%s must be used together", accessKeyFlag, secretKeyFlag))
+	}
+
+	// extract AWS session token, for temporary credentials
+	sessionToken, _ := cmd.Flags().GetString(sessionTokenFlag)
+	if sessionToken != "" && accessKey == "" {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s requires --%s and --%s", sessionTokenFlag, accessKeyFlag, secretKeyFlag))
+	}
+
+	// extract custom S3 endpoint
+	endpointURL, _ := cmd.Flags().GetString(endpointURLFlag)
+	if endpointURL != "" {
+		if u, err := url.Parse(endpointURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be an http or https URL)", endpointURLFlag, endpointURL))
+		}
+	}
+
+	// extract path-style addressing, for S3-compatible stores
+	pathStyle, _ := cmd.Flags().GetBool(pathStyleFlag)
+
+	// extract namespace template
+	var namespaceTemplate *source.NamespaceTemplate
+	if template, _ := cmd.Flags().GetString(namespaceTemplateFlag); template != "" {
+		namespaceTemplate, err = source.ParseNamespaceTemplate(template)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s: %v", namespaceTemplateFlag, err))
+		}
+	}
+
+	// extract include and exclude patterns
+	include, _ := cmd.Flags().GetStringSlice(includeFlag)
+	exclude, _ := cmd.Flags().GetStringSlice(excludeFlag)
+	filePatterns, err := source.ParseFilePatterns(include, exclude)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s/--%s: %v", includeFlag, excludeFlag, err))
+	}
+
+	// extract the spool size, objects are only downloaded ahead of the transfer in parallel mode
+	var spoolSize int64
+	if spoolSizeStr, _ := cmd.Flags().GetString(spoolSizeFlag); spoolSizeStr != "" {
+		spoolSize, err = utils.ParseByteSize(spoolSizeStr)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (%v)", spoolSizeFlag, spoolSizeStr, err))
+		} else if s.Daemon || s.ProcessingMode != types.FetchParallel {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s is only supported with --processing-mode=parallel", spoolSizeFlag))
+		}
+	}
+
+	// extract the polling interval, used in daemon mode only
+	var pollSeconds int64
+	if s.Daemon {
+		pollStr, _ := cmd.Flags().GetString(pollFlag)
+		pollSeconds, err = utils.ParseDuration(pollStr)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s: %v", pollFlag, pollStr, err))
+		} else if pollSeconds <= 0 {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be greater than zero)", pollFlag, pollStr))
+		}
+	}
+
 	if len(missingFlags) > 0 {
 		return fmt.Errorf("missing flags: %s", strings.Join(missingFlags, ", "))
 	}
@@ -111,6 +194,14 @@ func (s *S3Adapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	cfg.SetPrefix(prefix)
 	cfg.SetAccessKey(accessKey)
 	cfg.SetSecretKey(secretKey)
+	cfg.SessionToken = sessionToken
+	cfg.EndpointURL = endpointURL
+	cfg.PathStyle = pathStyle
+	cfg.NamespaceTemplate = namespaceTemplate
+	cfg.FilePatterns = filePatterns
+	cfg.SpoolSize = spoolSize
+	cfg.Daemon = s.Daemon
+	cfg.Poll = pollSeconds
 
 	s.Config = cfg
 	s.Fetcher = fetcher
@@ -123,6 +214,16 @@ func (s3 *S3Adapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIte
 	return s3.Fetcher.Fetch(ctx, s3.Config)
 }
 
+// Monitor watches the bucket for new or changed SBOMs in daemon mode
+func (s3 *S3Adapter) Monitor(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	if !s3.Config.Daemon {
+		return nil, fmt.Errorf("daemon mode not enabled for s3 adapter")
+	}
+
+	logger.LogDebug(ctx.Context, "monitoring", "bucket", s3.Config.BucketName, "prefix", s3.Config.Prefix)
+	return s3.Fetcher.Fetch(ctx, s3.Config)
+}
+
 func (s3 *S3Adapter) UploadSBOMs(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
 	return fmt.Errorf("S3 adapter does not support SBOM uploading when it is in input adapter role")
 }
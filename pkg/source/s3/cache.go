@@ -0,0 +1,94 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/cache"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// CacheName is the cache store of the S3 watcher for an output adapter
+func CacheName(outputAdapter string) string {
+	return fmt.Sprintf("cache_%s_s3", outputAdapter)
+}
+
+// cacheNamespace holds the ETags of the processed objects, keyed by bucket/key
+const cacheNamespace = "s3/objects"
+
+// Cache records the objects the watcher has processed, with the ETag they had, so restarts
+// don't transfer them again and objects are transferred again only when they change.
+type Cache struct {
+	sync.RWMutex
+	store cache.Store
+	etags map[string]string // bucket/key -> ETag
+}
+
+// OpenCache opens, or creates, the cache of the output adapter and loads its objects
+func OpenCache(ctx tcontext.TransferMetadata, outputAdapter string) (*Cache, error) {
+	store, err := cache.Open(ctx, CacheName(outputAdapter))
+	if err != nil {
+		return nil, err
+	}
+
+	// objects processed by versions keeping them in a table of their own
+	err = cache.ImportLegacy(ctx, store, cacheNamespace, "SELECT bucket || '/' || key, etag FROM objects WHERE output_adapter = ?", outputAdapter)
+	if err != nil {
+		logger.LogError(ctx.Context, err, "Failed to import earlier S3 cache")
+	}
+
+	entries, err := store.Entries(ctx.Context, cacheNamespace)
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+
+	c := &Cache{store: store, etags: make(map[string]string, len(entries))}
+	for key, etag := range entries {
+		c.etags[key] = string(etag)
+	}
+
+	logger.LogDebug(ctx.Context, "Loaded S3 cache", "store", CacheName(outputAdapter), "objects", len(c.etags))
+	return c, nil
+}
+
+// IsProcessed reports whether the object was processed with this ETag
+func (c *Cache) IsProcessed(bucket, key, etag string) bool {
+	c.RLock()
+	defer c.RUnlock()
+	cached, ok := c.etags[bucket+"/"+key]
+	return ok && cached == etag
+}
+
+// MarkProcessed records the object as processed with this ETag
+func (c *Cache) MarkProcessed(ctx tcontext.TransferMetadata, bucket, key, etag string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := c.store.Put(ctx.Context, cacheNamespace, bucket+"/"+key, []byte(etag)); err != nil {
+		return fmt.Errorf("failed to save object %s to cache: %w", key, err)
+	}
+
+	c.etags[bucket+"/"+key] = etag
+	return nil
+}
+
+// Close closes the cache store
+func (c *Cache) Close() error {
+	return c.store.Close()
+}
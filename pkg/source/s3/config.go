@@ -17,12 +17,14 @@ package s3
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 	"github.com/interlynk-io/sbommv/pkg/types"
 )
@@ -30,10 +32,25 @@ import (
 type S3Config struct {
 	AccessKey      string
 	SecretKey      string
+	SessionToken   string // temporary credentials, used with AccessKey and SecretKey
+	EndpointURL    string // custom S3 endpoint, empty uses the AWS endpoint of Region
+	PathStyle      bool   // address buckets as endpoint/bucket instead of bucket.endpoint
 	BucketName     string
 	Region         string
 	Prefix         string
 	ProcessingMode types.ProcessingMode
+	Daemon         bool
+	Poll           int64 // seconds between bucket listings in daemon mode
+
+	// NamespaceTemplate derives namespace and version from object keys, nil keeps bucket-prefix
+	NamespaceTemplate *source.NamespaceTemplate
+
+	// FilePatterns selects objects by their key relative to the prefix, nil selects every object
+	FilePatterns *source.FilePatterns
+
+	// SpoolSize is the size above which objects downloaded ahead of being transferred are
+	// kept in temp files instead of memory, 0 keeps them all in memory
+	SpoolSize int64
 }
 
 func NewS3Config() *S3Config {
@@ -42,6 +59,20 @@ func NewS3Config() *S3Config {
 	}
 }
 
+// selected reports whether the object at key matches the include and exclude patterns,
+// which apply to its key relative to the prefix
+func (s *S3Config) selected(key string) bool {
+	if s.FilePatterns == nil {
+		return true
+	}
+
+	prefix := s.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return s.FilePatterns.Allows(strings.TrimPrefix(key, prefix))
+}
+
 func (s *S3Config) GetBucketName() string {
 	return s.BucketName
 }
@@ -83,29 +114,30 @@ func (s *S3Config) SetAccessKey(accessKey string) {
 }
 
 func (s *S3Config) GetAWSClient(ctx tcontext.TransferMetadata) (*s3.Client, error) {
-	logger.LogDebug(ctx.Context, "Initializing AWS S3 client", "region", s.Region, "bucket", s.BucketName, "prefix", s.Prefix)
+	logger.LogDebug(ctx.Context, "Initializing AWS S3 client", "region", s.Region, "bucket", s.BucketName, "prefix", s.Prefix, "endpoint", s.EndpointURL, "path_style", s.PathStyle)
 
-	// Load AWS config
-	var cfg aws.Config
-	var err error
+	// Load AWS config, with static credentials when given and the default credential chain otherwise
+	opts := []func(*config.LoadOptions) error{config.WithRegion(s.Region)}
 	if s.AccessKey != "" && s.SecretKey != "" {
 		creds := aws.Credentials{
 			AccessKeyID:     s.AccessKey,
 			SecretAccessKey: s.SecretKey,
+			SessionToken:    s.SessionToken,
 		}
-		cfg, err = config.LoadDefaultConfig(ctx.Context,
-			config.WithRegion(s.Region),
-			config.WithCredentialsProvider(aws.NewCredentialsCache(credentials.StaticCredentialsProvider{Value: creds})),
-		)
-	} else {
-		cfg, err = config.LoadDefaultConfig(ctx.Context, config.WithRegion(s.Region))
+		opts = append(opts, config.WithCredentialsProvider(aws.NewCredentialsCache(credentials.StaticCredentialsProvider{Value: creds})))
+	}
+	if s.EndpointURL != "" {
+		opts = append(opts, config.WithBaseEndpoint(s.EndpointURL))
 	}
 
+	cfg, err := config.LoadDefaultConfig(ctx.Context, opts...)
 	if err != nil {
 		logger.LogError(ctx.Context, err, "Failed to load AWS config")
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	// Create S3 client
-	return s3.NewFromConfig(cfg), err
+	// Create S3 client, S3-compatible stores such as MinIO usually need path-style addressing
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = s.PathStyle
+	}), nil
 }
@@ -0,0 +1,61 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// Estimate lists the objects under the prefix a transfer would pick up, judging them by key
+// and using the sizes from the listing, without downloading any of them
+func (s *S3Adapter) Estimate(ctx tcontext.TransferMetadata) ([]types.SBOMCandidate, error) {
+	client, err := s.Config.GetAWSClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	var candidates []types.SBOMCandidate
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.Config.BucketName),
+		Prefix: aws.String(s.Config.Prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx.Context)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			if source.IsSidecar(key) || !source.DetectSBOMsFile(path.Base(key)) || !source.AllowsFormatName(ctx, key) || !s.Config.selected(key) {
+				continue
+			}
+			candidates = append(candidates, types.SBOMCandidate{
+				Name:      key,
+				Namespace: s.Config.BucketName + "-" + s.Config.Prefix,
+				Size:      aws.ToInt64(obj.Size),
+			})
+		}
+	}
+
+	return candidates, nil
+}
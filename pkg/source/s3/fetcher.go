@@ -18,11 +18,14 @@ package s3
 import (
 	"fmt"
 	"io"
+	"os"
 	"strings"
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/source"
@@ -38,110 +41,121 @@ type (
 	S3ParallelFetcher   struct{}
 )
 
+// maxParallelDownloads is the number of workers downloading objects with --processing-mode=parallel
+const maxParallelDownloads = 5
+
+// Fetch lists the objects under the prefix and downloads them with a pool of workers. At most
+// one downloaded object per worker waits to be consumed, in a temp file when it is larger
+// than --in-s3-spool-size.
 func (s *S3ParallelFetcher) Fetch(ctx tcontext.TransferMetadata, s3cfg *S3Config) (iterator.SBOMIterator, error) {
 	logger.LogDebug(ctx.Context, "Fetching SBOMs Concurrently...")
 
-	bucketPrefix := s3cfg.Prefix
-
-	client, err := s3cfg.GetAWSClient(ctx)
+	client, bucketPrefix, err := openBucket(ctx, s3cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, err
 	}
 
-	// add "/" to prefix if not present in the end
-	if bucketPrefix != "" && !strings.HasSuffix(bucketPrefix, "/") {
-		bucketPrefix = bucketPrefix + "/"
+	objects, keys, err := listObjects(ctx, client, s3cfg.BucketName, bucketPrefix)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate bucket
-	_, err = client.HeadBucket(ctx.Context, &s3.HeadBucketInput{
-		Bucket: aws.String(s3cfg.BucketName),
-	})
-	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") || strings.Contains(err.Error(), "NoSuchBucket") || strings.Contains(err.Error(), "404") {
-			return nil, fmt.Errorf("bucket %q does not exist", s3cfg.BucketName)
-		}
-		return nil, fmt.Errorf("failed to access bucket %q: %w", s3cfg.BucketName, err)
+	candidates := selectKeys(ctx, s3cfg, objects)
+	if len(candidates) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in s3://%s/%s", s3cfg.BucketName, s3cfg.Prefix))
 	}
 
-	// List objects (single call, no pagination)
-	resp, err := client.ListObjectsV2(ctx.Context, &s3.ListObjectsV2Input{
-		Bucket: aws.String(s3cfg.BucketName),
-		Prefix: aws.String(s3cfg.Prefix),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list objects: %w", err)
+	keyChan := make(chan string, len(candidates))
+	for _, key := range candidates {
+		keyChan <- key
 	}
+	close(keyChan)
 
-	var sboms []*iterator.SBOM
-	var mu sync.Mutex
+	objectChan := make(chan *s3Object, maxParallelDownloads)
 	var wg sync.WaitGroup
-	const maxConcurrency = 3
-	semaphore := make(chan struct{}, maxConcurrency)
 
-	for _, obj := range resp.Contents {
+	for i := 0; i < maxParallelDownloads; i++ {
 		wg.Add(1)
-		semaphore <- struct{}{}
-		go func(key string) {
+		go func() {
 			defer wg.Done()
-			defer func() { <-semaphore }()
-
-			// Download object
-			getResp, err := client.GetObject(ctx.Context, &s3.GetObjectInput{
-				Bucket: aws.String(s3cfg.BucketName),
-				Key:    aws.String(key),
-			})
-			if err != nil {
-				logger.LogDebug(ctx.Context, "Failed to download", "key", key, "error", err)
-				return
+			for key := range keyChan {
+				// drain the remaining keys once cancelled
+				if ctx.Err() != nil {
+					continue
+				}
+
+				obj, err := downloadObject(ctx, client, s3cfg, key, keys, s3cfg.SpoolSize)
+				if err != nil {
+					logger.LogError(ctx.Context, err, "Skipping object", "key", key)
+					continue
+				}
+
+				select {
+				case objectChan <- obj:
+				case <-ctx.Done():
+					obj.discard()
+				}
 			}
+		}()
+	}
 
-			// Read content
-			content, err := io.ReadAll(getResp.Body)
-			getResp.Body.Close()
-			if err != nil {
-				logger.LogDebug(ctx.Context, "Failed to read", "key", key, "error", err)
-				return
-			}
+	// close the objects channel once all workers completed
+	go func() {
+		wg.Wait()
+		close(objectChan)
+	}()
 
-			// Validate SBOM
-			if !source.IsSBOMFile(content) {
-				logger.LogDebug(ctx.Context, "Skipping invalid SBOM", "key", key)
-				return
-			}
+	return &S3ParallelIterator{objects: objectChan, config: s3cfg, bucketPrefix: bucketPrefix, total: len(candidates)}, nil
+}
+
+// Fetch lists the objects under the prefix, leaving the iterator to download them one by one
+func (s *S3SequentialFetcher) Fetch(ctx tcontext.TransferMetadata, s3cfg *S3Config) (iterator.SBOMIterator, error) {
+	logger.LogDebug(ctx.Context, "Fetching SBOMs Sequentially")
 
-			// Store SBOM
-			mu.Lock()
-			sboms = append(sboms, &iterator.SBOM{
-				Path:      strings.TrimPrefix(*obj.Key, *resp.Prefix),
-				Data:      content,
-				Namespace: s3cfg.BucketName + "-" + s3cfg.Prefix,
-			})
-			mu.Unlock()
-			logger.LogDebug(ctx.Context, "Fetched SBOM", "key", key, "size", len(content))
-		}(*obj.Key)
+	client, bucketPrefix, err := openBucket(ctx, s3cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	wg.Wait()
+	logger.LogDebug(ctx.Context, "Fetching SBOMs from S3 bucket", "bucket", s3cfg.BucketName, "prefix", s3cfg.Prefix, "region", s3cfg.Region)
 
-	if len(sboms) == 0 {
-		return nil, fmt.Errorf("no SBOMs found in s3://%s/%s", s3cfg.BucketName, s3cfg.Prefix)
+	objects, keys, err := listObjects(ctx, client, s3cfg.BucketName, bucketPrefix)
+	if err != nil {
+		return nil, err
 	}
 
-	return NewS3Iterator(sboms), nil
+	candidates := selectKeys(ctx, s3cfg, objects)
+	if len(candidates) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("no SBOMs found in s3://%s/%s", s3cfg.BucketName, s3cfg.Prefix))
+	}
+	return NewS3Iterator(client, s3cfg, bucketPrefix, candidates, keys), nil
 }
 
-// Fetching SBOMs from S3 bucket sequentially
-func (s *S3SequentialFetcher) Fetch(ctx tcontext.TransferMetadata, s3cfg *S3Config) (iterator.SBOMIterator, error) {
-	logger.LogDebug(ctx.Context, "Fetching SBOMs Sequentially")
-	bucketPrefix := s3cfg.Prefix
+// selectKeys returns the keys of the listed objects that may hold SBOMs: no sidecar files,
+// and matching the format filter and the include/exclude patterns
+func selectKeys(ctx tcontext.TransferMetadata, s3cfg *S3Config, objects []s3types.Object) []string {
+	var candidates []string
+	for _, obj := range objects {
+		key := aws.ToString(obj.Key)
+		if source.IsSidecar(key) || !source.AllowsFormatName(ctx, key) || !s3cfg.selected(key) {
+			continue
+		}
+		candidates = append(candidates, key)
+	}
+	logger.LogDebug(ctx.Context, "Objects selected for download", "listed", len(objects), "selected", len(candidates))
+	return candidates
+}
 
+// openBucket creates the S3 client and checks the bucket is accessible. It returns the
+// prefix to list, ending with "/" when set.
+func openBucket(ctx tcontext.TransferMetadata, s3cfg *S3Config) (*s3.Client, string, error) {
 	client, err := s3cfg.GetAWSClient(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, "", fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
 	// add "/" to prefix if not present in the end
+	bucketPrefix := s3cfg.Prefix
 	if bucketPrefix != "" && !strings.HasSuffix(bucketPrefix, "/") {
 		bucketPrefix = bucketPrefix + "/"
 	}
@@ -150,63 +164,197 @@ func (s *S3SequentialFetcher) Fetch(ctx tcontext.TransferMetadata, s3cfg *S3Conf
 	_, err = client.HeadBucket(ctx.Context, &s3.HeadBucketInput{Bucket: aws.String(s3cfg.BucketName)})
 	if err != nil {
 		if strings.Contains(err.Error(), "NotFound") || strings.Contains(err.Error(), "NoSuchBucket") || strings.Contains(err.Error(), "404") {
-			return nil, fmt.Errorf("bucket %q does not exist", s3cfg.BucketName)
+			return nil, "", fmt.Errorf("bucket %q does not exist", s3cfg.BucketName)
 		}
-		return nil, fmt.Errorf("failed to access bucket %q: %w", s3cfg.BucketName, err)
+		return nil, "", fmt.Errorf("failed to access bucket %q: %w", s3cfg.BucketName, err)
 	}
 
-	logger.LogDebug(ctx.Context, "Fetching SBOMs from S3 bucket", "bucket", s3cfg.BucketName, "prefix", s3cfg.Prefix, "region", s3cfg.Region)
+	return client, bucketPrefix, nil
+}
 
-	// List objects
-	resp, err := client.ListObjectsV2(ctx.Context, &s3.ListObjectsV2Input{
-		Bucket: aws.String(s3cfg.BucketName),
-		Prefix: aws.String(bucketPrefix),
+// listObjects lists every object under prefix, following ListObjectsV2 pagination (at most
+// 1000 keys per page), and returns them with the set of listed keys
+func listObjects(ctx tcontext.TransferMetadata, client *s3.Client, bucket, prefix string) ([]s3types.Object, map[string]bool, error) {
+	var objects []s3types.Object
+	keys := map[string]bool{}
+
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
 	})
+	for page := 1; paginator.HasMorePages(); page++ {
+		resp, err := paginator.NextPage(ctx.Context)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, obj := range resp.Contents {
+			objects = append(objects, obj)
+			keys[aws.ToString(obj.Key)] = true
+		}
+		logger.LogDebug(ctx.Context, "Listed objects page", "bucket", bucket, "prefix", prefix, "page", page, "objects", len(resp.Contents), "total_so_far", len(objects))
+	}
+
+	return objects, keys, nil
+}
+
+// fetchObject downloads the object at key and builds its SBOM. It returns nil without an
+// error when the object isn't an SBOM.
+func fetchObject(ctx tcontext.TransferMetadata, client *s3.Client, s3cfg *S3Config, bucketPrefix, key string, keys map[string]bool) (*iterator.SBOM, error) {
+	obj, err := downloadObject(ctx, client, s3cfg, key, keys, 0)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list objects: %w", err)
+		return nil, err
 	}
+	return obj.load(ctx, s3cfg, bucketPrefix)
+}
 
-	// Process objects
-	var sbomList []*iterator.SBOM
-	for _, obj := range resp.Contents {
+// s3Object is a downloaded object with its sidecar metadata, held in memory or, when larger
+// than the spool size, in a temp file until it is loaded
+type s3Object struct {
+	key         string
+	content     []byte
+	spoolFile   string
+	annotations *iterator.Annotations
+}
 
-		// Download object
-		getResp, err := client.GetObject(ctx.Context, &s3.GetObjectInput{
-			Bucket: aws.String(s3cfg.BucketName),
-			Key:    obj.Key,
-		})
+// downloadObject downloads the object at key and its metadata file. Objects larger than
+// spoolSize are written to a temp file, 0 keeps every object in memory.
+func downloadObject(ctx tcontext.TransferMetadata, client *s3.Client, s3cfg *S3Config, key string, keys map[string]bool, spoolSize int64) (*s3Object, error) {
+	// Download object
+	getResp, err := client.GetObject(ctx.Context, &s3.GetObjectInput{
+		Bucket: aws.String(s3cfg.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	defer getResp.Body.Close()
+	logger.LogDebug(ctx.Context, "Get Object Response", "content_length", getResp.ContentLength, "content_type", getResp.ContentType)
+
+	obj := &s3Object{key: key}
+	if size := aws.ToInt64(getResp.ContentLength); spoolSize > 0 && size > spoolSize {
+		obj.spoolFile, err = spool(getResp.Body)
 		if err != nil {
-			logger.LogDebug(ctx.Context, "Failed to download", "key", *obj.Key, "error", err)
-			continue
+			return nil, fmt.Errorf("failed to spool %s: %w", key, err)
 		}
-		logger.LogDebug(ctx.Context, "Get Object Response", "content_length", getResp.ContentLength, "content_type", getResp.ContentType)
+		logger.LogDebug(ctx.Context, "Spooled object to temp file", "key", key, "size", size, "file", obj.spoolFile)
+	} else {
+		obj.content, err = io.ReadAll(getResp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", key, err)
+		}
+	}
+
+	obj.annotations, err = fetchSidecar(ctx, client, s3cfg.BucketName, key, keys)
+	if err != nil {
+		obj.discard()
+		return nil, fmt.Errorf("invalid metadata file for %s: %w", key, err)
+	}
+	return obj, nil
+}
 
-		content, err := io.ReadAll(getResp.Body)
-		getResp.Body.Close()
+// spool writes the body to a temp file and returns its path
+func spool(body io.Reader) (string, error) {
+	file, err := os.CreateTemp("", "sbommv-s3-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// load builds the SBOM of the object, reading it back from its temp file when spooled. It
+// returns nil without an error when the object isn't an SBOM.
+func (o *s3Object) load(ctx tcontext.TransferMetadata, s3cfg *S3Config, bucketPrefix string) (*iterator.SBOM, error) {
+	content := o.content
+	if o.spoolFile != "" {
+		data, err := os.ReadFile(o.spoolFile)
+		o.discard()
 		if err != nil {
-			logger.LogDebug(ctx.Context, "Failed to read", "key", *obj.Key, "error", err)
-			getResp.Body.Close()
-			continue
+			return nil, fmt.Errorf("failed to read spooled %s: %w", o.key, err)
 		}
-		getResp.Body.Close()
+		content = data
+	}
 
-		// check whether it's a SBOM content or not
-		if !source.IsSBOMFile(content) {
-			logger.LogDebug(ctx.Context, "Skipping invalid SBOM", "key", *obj.Key, "content_sample", string(content[:min(100, len(content))]))
+	// check whether it's a SBOM content or not
+	if !source.IsSBOM(ctx, o.key, content) {
+		logger.LogDebug(ctx.Context, "Skipping invalid SBOM", "key", o.key, "content_sample", string(content[:min(100, len(content))]))
+		return nil, nil
+	}
+
+	logger.LogDebug(ctx.Context, "Fetched SBOM", "key", o.key, "size", len(content))
+	return newS3SBOM(ctx, s3cfg, strings.TrimPrefix(o.key, bucketPrefix), content, o.annotations), nil
+}
+
+// discard removes the temp file of a spooled object
+func (o *s3Object) discard() {
+	if o.spoolFile != "" {
+		os.Remove(o.spoolFile)
+		o.spoolFile = ""
+	}
+}
+
+// fetchSidecar downloads and parses the metadata file of the object at key. It returns nil
+// when the listing has no metadata file for it.
+func fetchSidecar(ctx tcontext.TransferMetadata, client *s3.Client, bucket, key string, keys map[string]bool) (*iterator.Annotations, error) {
+	for _, name := range source.SidecarNames(key) {
+		if !keys[name] {
 			continue
 		}
 
-		sbomList = append(sbomList, &iterator.SBOM{
-			Path:      strings.TrimPrefix(*obj.Key, *resp.Prefix),
-			Data:      content,
-			Namespace: s3cfg.BucketName + "-" + s3cfg.Prefix,
+		resp, err := client.GetObject(ctx.Context, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(name),
 		})
-		logger.LogDebug(ctx.Context, "Fetched SBOM", "key", *obj.Key, "size", len(content))
+		if err != nil {
+			return nil, fmt.Errorf("downloading %s: %w", name, err)
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
 
+		annotations, err := source.ParseSidecar(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", name, err)
+		}
+		return annotations, nil
 	}
+	return nil, nil
+}
 
-	if len(sbomList) == 0 {
-		return nil, fmt.Errorf("no SBOMs found in s3://%s/%s", s3cfg.BucketName, s3cfg.Prefix)
+// newS3SBOM builds the SBOM for an object, keyed relative to the prefix. The namespace is
+// bucket and prefix, unless a namespace template derives namespace and version from the key.
+func newS3SBOM(ctx tcontext.TransferMetadata, s3cfg *S3Config, relKey string, content []byte, annotations *iterator.Annotations) *iterator.SBOM {
+	sbom := &iterator.SBOM{
+		Path:        relKey,
+		Data:        content,
+		Namespace:   s3cfg.BucketName + "-" + s3cfg.Prefix,
+		Annotations: annotations,
 	}
-	return NewS3Iterator(sbomList), nil
+
+	if s3cfg.NamespaceTemplate == nil {
+		return sbom
+	}
+
+	namespace, version, ok := s3cfg.NamespaceTemplate.Apply(strings.TrimPrefix(relKey, "/"))
+	if !ok {
+		logger.LogDebug(ctx.Context, "Key doesn't match namespace template, keeping default namespace", "key", relKey)
+		return sbom
+	}
+
+	sbom.Namespace = namespace
+	sbom.Version = version
+	sbom.ExplicitNamespace = true
+	logger.LogDebug(ctx.Context, "Namespace derived from template", "key", relKey, "namespace", namespace, "version", version)
+	return sbom
 }
@@ -15,32 +15,134 @@
 package s3
 
 import (
+	"fmt"
 	"io"
 
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 )
 
-// S3Iterator implements SBOMIterator
+// S3Iterator downloads the listed objects lazily, one per call to Next, so only the SBOM
+// being transferred is held in memory
 type S3Iterator struct {
-	sboms []*iterator.SBOM
-	index int
+	client       *s3.Client
+	config       *S3Config
+	bucketPrefix string
+	keys         []string        // objects not downloaded yet
+	listed       map[string]bool // every listed key, to find metadata files
+	total        int             // objects listed
+	fetched      int
 }
 
-// NewS3Iterator creates an S3 iterator
-func NewS3Iterator(sboms []*iterator.SBOM) *S3Iterator {
+// NewS3Iterator creates an S3 iterator over the objects at keys
+func NewS3Iterator(client *s3.Client, config *S3Config, bucketPrefix string, keys []string, listed map[string]bool) *S3Iterator {
 	return &S3Iterator{
-		sboms: sboms,
-		index: 0,
+		client:       client,
+		config:       config,
+		bucketPrefix: bucketPrefix,
+		keys:         keys,
+		listed:       listed,
+		total:        len(keys),
 	}
 }
 
-// Next yields the next SBOM
+// Total returns the number of objects listed, those that turn out not to be SBOMs are skipped
+func (it *S3Iterator) Total() int {
+	return it.total
+}
+
+// Next downloads and yields the next object that is an SBOM
 func (it *S3Iterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
-	if it.index >= len(it.sboms) {
-		return nil, io.EOF
+	for len(it.keys) > 0 {
+		if ctx.Err() != nil {
+			return nil, source.FetchInterrupted(ctx, it.fetched)
+		}
+
+		key := it.keys[0]
+		it.keys = it.keys[1:]
+
+		sbom, err := fetchObject(ctx, it.client, it.config, it.bucketPrefix, key, it.listed)
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Skipping object", "key", key)
+			continue
+		}
+		if sbom == nil {
+			continue
+		}
+
+		it.fetched++
+		return sbom, nil
+	}
+
+	if it.fetched == 0 {
+		logger.LogInfo(ctx.Context, "No SBOMs found", "bucket", it.config.BucketName, "prefix", it.config.Prefix)
+	}
+	return nil, io.EOF
+}
+
+// S3ParallelIterator yields the objects downloaded ahead by the workers of S3ParallelFetcher
+type S3ParallelIterator struct {
+	objects      <-chan *s3Object
+	config       *S3Config
+	bucketPrefix string
+	total        int // objects listed
+	fetched      int
+}
+
+// Total returns the number of objects listed, those that turn out not to be SBOMs are skipped
+func (it *S3ParallelIterator) Total() int {
+	return it.total
+}
+
+// Next yields the next downloaded object that is an SBOM
+func (it *S3ParallelIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
+	for {
+		select {
+		case obj, ok := <-it.objects:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil, source.FetchInterrupted(ctx, it.fetched)
+				}
+				if it.fetched == 0 {
+					logger.LogInfo(ctx.Context, "No SBOMs found", "bucket", it.config.BucketName, "prefix", it.config.Prefix)
+				}
+				return nil, io.EOF
+			}
+
+			sbom, err := obj.load(ctx, it.config, it.bucketPrefix)
+			if err != nil {
+				logger.LogError(ctx.Context, err, "Skipping object", "key", obj.key)
+				continue
+			}
+			if sbom == nil {
+				continue
+			}
+
+			it.fetched++
+			return sbom, nil
+
+		case <-ctx.Done():
+			return nil, source.FetchInterrupted(ctx, it.fetched)
+		}
+	}
+}
+
+// S3WatcherIterator yields the SBOMs found by the bucket watcher as they arrive
+type S3WatcherIterator struct {
+	sbomChan chan *iterator.SBOM
+}
+
+func (it *S3WatcherIterator) Next(ctx tcontext.TransferMetadata) (*iterator.SBOM, error) {
+	select {
+	case sbom, ok := <-it.sbomChan:
+		if !ok {
+			return nil, fmt.Errorf("watcher channel closed")
+		}
+		return sbom, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-	sbom := it.sboms[it.index]
-	it.index++
-	return sbom, nil
 }
@@ -51,6 +51,9 @@ func (s *S3Reporter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIte
 		if err == io.EOF {
 			break
 		}
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
 			return err
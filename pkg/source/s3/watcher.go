@@ -0,0 +1,153 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/source"
+	"github.com/interlynk-io/sbommv/pkg/status"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// S3WatcherFetcher polls the bucket prefix in daemon mode and streams the SBOMs that
+// were added, or changed, since they were last transferred
+type S3WatcherFetcher struct{}
+
+func NewWatcherFetcher() *S3WatcherFetcher {
+	return &S3WatcherFetcher{}
+}
+
+func (f *S3WatcherFetcher) Fetch(ctx tcontext.TransferMetadata, s3cfg *S3Config) (iterator.SBOMIterator, error) {
+	logger.LogInfo(ctx.Context, "Starting S3 daemon watcher", "bucket", s3cfg.BucketName, "prefix", s3cfg.Prefix, "interval", s3cfg.Poll)
+
+	outputAdapter := ctx.DestinationAdapter()
+
+	cache, err := OpenCache(ctx, outputAdapter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	client, bucketPrefix, err := openBucket(ctx, s3cfg)
+	if err != nil {
+		cache.Close()
+		return nil, err
+	}
+
+	sbomChan := make(chan *iterator.SBOM, 10)
+
+	watched := "s3://" + s3cfg.BucketName + "/" + s3cfg.Prefix
+	status.Watch(ctx, watched)
+	status.TrackPending(ctx, func() int { return len(sbomChan) })
+
+	// start polling loop in a goroutine, the first listing runs right away
+	go func() {
+		defer close(sbomChan)
+		defer cache.Close()
+
+		ticker := time.NewTicker(time.Duration(s3cfg.Poll) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			err := pollBucket(ctx, client, s3cfg, bucketPrefix, cache, sbomChan)
+			if err != nil && ctx.Err() == nil {
+				logger.LogError(ctx.Context, err, "Failed to poll bucket", "bucket", s3cfg.BucketName, "prefix", s3cfg.Prefix)
+			}
+			status.Polled(ctx, watched, err)
+
+			select {
+			case <-ctx.Context.Done():
+				logger.LogInfo(ctx.Context, "Polling stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return &S3WatcherIterator{sbomChan: sbomChan}, nil
+}
+
+// pollBucket lists the prefix once and sends the SBOMs not yet processed with their current
+// ETag. Objects that fail to download are retried on the next poll.
+func pollBucket(ctx tcontext.TransferMetadata, client *s3.Client, s3cfg *S3Config, bucketPrefix string, cache *Cache, sbomChan chan *iterator.SBOM) error {
+	logger.LogInfo(ctx.Context, "Polling bucket", "bucket", s3cfg.BucketName, "prefix", s3cfg.Prefix, "time", time.Now().Format(time.RFC3339))
+
+	objects, keys, err := listObjects(ctx, client, s3cfg.BucketName, bucketPrefix)
+	if err != nil {
+		return err
+	}
+
+	etags := make(map[string]string, len(objects))
+	for _, obj := range objects {
+		etags[aws.ToString(obj.Key)] = aws.ToString(obj.ETag)
+	}
+
+	sent := 0
+	for _, obj := range objects {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		key := aws.ToString(obj.Key)
+		if source.IsSidecar(key) || !source.AllowsFormatName(ctx, key) || !s3cfg.selected(key) {
+			continue
+		}
+		etag := objectVersion(key, etags)
+		if cache.IsProcessed(s3cfg.BucketName, key, etag) {
+			continue
+		}
+
+		sbom, err := fetchObject(ctx, client, s3cfg, bucketPrefix, key, keys)
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Skipping object", "key", key)
+			continue
+		}
+
+		if sbom != nil {
+			logger.LogInfo(ctx.Context, "New SBOM detected", "key", key, "etag", etag)
+			select {
+			case sbomChan <- sbom:
+				sent++
+			case <-ctx.Context.Done():
+				return ctx.Err()
+			}
+		}
+
+		// objects that aren't SBOMs are cached too, so they aren't downloaded on every poll
+		if err := cache.MarkProcessed(ctx, s3cfg.BucketName, key, etag); err != nil {
+			logger.LogError(ctx.Context, err, "Failed to update cache", "key", key)
+		}
+	}
+
+	logger.LogDebug(ctx.Context, "Bucket polled", "objects", len(objects), "new_sboms", sent)
+	return nil
+}
+
+// objectVersion is the ETag of the object combined with the ETag of its metadata file, so
+// editing the metadata file transfers the SBOM again
+func objectVersion(key string, etags map[string]string) string {
+	version := etags[key]
+	for _, name := range source.SidecarNames(key) {
+		if sidecarETag, ok := etags[name]; ok {
+			version += "+" + sidecarETag
+		}
+	}
+	return version
+}
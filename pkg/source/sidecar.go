@@ -0,0 +1,88 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"sigs.k8s.io/yaml"
+)
+
+// sidecarSuffixes name the metadata file of an SBOM, e.g. app.cdx.json.meta.yaml
+var sidecarSuffixes = []string{".meta.yaml", ".meta.yml"}
+
+// IsSidecar reports whether the file or object name is an SBOM's metadata file
+func IsSidecar(name string) bool {
+	for _, suffix := range sidecarSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SidecarNames returns the names the metadata file of the SBOM may have, in order of precedence
+func SidecarNames(sbomName string) []string {
+	names := make([]string, 0, len(sidecarSuffixes))
+	for _, suffix := range sidecarSuffixes {
+		names = append(names, sbomName+suffix)
+	}
+	return names
+}
+
+// SidecarSBOMName returns the name of the SBOM a metadata file belongs to
+func SidecarSBOMName(sidecarName string) string {
+	for _, suffix := range sidecarSuffixes {
+		if name, ok := strings.CutSuffix(sidecarName, suffix); ok {
+			return name
+		}
+	}
+	return sidecarName
+}
+
+// ParseSidecar parses the content of an SBOM's metadata file. Unknown fields are rejected,
+// so a misspelled override doesn't silently send the SBOM to the wrong project.
+func ParseSidecar(data []byte) (*iterator.Annotations, error) {
+	var annotations iterator.Annotations
+	if err := yaml.UnmarshalStrict(data, &annotations); err != nil {
+		return nil, err
+	}
+	return &annotations, nil
+}
+
+// LoadSidecar reads the metadata file next to the SBOM at path. It returns nil, and no
+// error, when the SBOM has none.
+func LoadSidecar(path string) (*iterator.Annotations, error) {
+	for _, name := range SidecarNames(path) {
+		data, err := os.ReadFile(name)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+
+		annotations, err := ParseSidecar(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", name, err)
+		}
+		return annotations, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// signatureSuffixes name the files published along with an SBOM to verify it: a cosign
+// signature of the SBOM, the certificate of a keyless signature, and an in-toto attestation,
+// e.g. app.cdx.json.sig, app.cdx.json.pem and app.cdx.json.intoto.jsonl
+var signatureSuffixes = []string{".sig", ".pem", ".intoto.jsonl"}
+
+// fetchSignaturesKey is the TransferMetadata key set when SBOM signatures are verified
+const fetchSignaturesKey = "fetch_signatures"
+
+// AttachSignatureFetching tells sources to fetch the signature files of SBOMs, for --verify-signatures
+func AttachSignatureFetching(ctx *tcontext.TransferMetadata) {
+	ctx.WithValue(fetchSignaturesKey, true)
+}
+
+// FetchesSignatures reports whether sources should fetch the signature files of SBOMs
+func FetchesSignatures(ctx tcontext.TransferMetadata) bool {
+	fetch, _ := ctx.Value(fetchSignaturesKey).(bool)
+	return fetch
+}
+
+// IsSignature reports whether the file or asset name is a signature file of an SBOM
+func IsSignature(name string) bool {
+	_, _, ok := SignatureOf(name)
+	return ok
+}
+
+// SignatureOf returns the name of the SBOM a signature file belongs to and the suffix
+// naming the kind of signature, ok is false when name is no signature file
+func SignatureOf(name string) (sbomName, suffix string, ok bool) {
+	for _, suffix := range signatureSuffixes {
+		if sbomName, ok := strings.CutSuffix(name, suffix); ok && sbomName != "" {
+			return sbomName, suffix, true
+		}
+	}
+	return "", "", false
+}
+
+// LoadSignatures reads the signature files next to the SBOM at path, keyed by suffix.
+// It returns nil, and no error, when the SBOM has none.
+func LoadSignatures(path string) (map[string][]byte, error) {
+	var signatures map[string][]byte
+	for _, suffix := range signatureSuffixes {
+		data, err := os.ReadFile(path + suffix)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path+suffix, err)
+		}
+		if signatures == nil {
+			signatures = make(map[string][]byte)
+		}
+		signatures[suffix] = data
+	}
+	return signatures, nil
+}
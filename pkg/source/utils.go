@@ -22,12 +22,13 @@ import (
 	"strings"
 
 	"github.com/interlynk-io/sbomasm/v2/pkg/sbom"
+	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
 )
 
 var sbomRegex *regexp.Regexp
 
 func init() {
-	sbomRegex = regexp.MustCompile(`(sbom|bom|spdx|cdx)[-_\.].+\.(json|xml|yaml|yml|txt)$`)
+	sbomRegex = regexp.MustCompile(`(sbom|bom|spdx|cdx)[-_\.].+\.(json|xml|yaml|yml|txt|bin|pb)$`)
 }
 
 // IsSBOMFile simply detect SBOMs file format and spec after reading the file.
@@ -35,7 +36,8 @@ func IsSBOMFile(content []byte) bool {
 	reader := bytes.NewReader(content)
 	spec, format, err := sbom.Detect(reader)
 	if err != nil {
-		return false
+		// sbomasm doesn't detect the CycloneDX protobuf encoding
+		return sbomd.IsCycloneDXProtobuf(content)
 	}
 
 	if format == sbom.FileFormatUnknown {
@@ -84,6 +86,8 @@ func DetectSBOMsFile(name string) bool {
 		".yaml",
 		".yml",
 		".txt", // for SPDX tag-value
+		".bin", // for CycloneDX protobuf
+		".pb",
 	}
 
 	// Regular expression for detecting known SBOM file naming conventions
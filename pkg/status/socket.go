@@ -0,0 +1,137 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+)
+
+// DefaultSocket is the unix socket a daemon serves its status on by default
+var DefaultSocket = filepath.Join(".sbommv", "status.sock")
+
+// Status is the state of a running daemon, as returned by `sbommv status`
+type Status struct {
+	RunID           string        `json:"run_id"`
+	Input           string        `json:"input_adapter"`
+	Output          string        `json:"output_adapter"`
+	StartedAt       time.Time     `json:"started_at"`
+	Watched         []Watched     `json:"watched"`
+	PendingSBOMs    int           `json:"pending_sboms"`
+	InFlightUploads int64         `json:"in_flight_uploads"`
+	QueuedUploads   int64         `json:"queued_uploads"`
+	Transferred     int           `json:"transferred"`
+	Failed          int           `json:"failed"`
+	RecentErrors    []RecentError `json:"recent_errors,omitempty"`
+}
+
+// RecentError is one of the last SBOMs that failed to transfer
+type RecentError struct {
+	Time  time.Time `json:"time"`
+	File  string    `json:"file,omitempty"`
+	Stage string    `json:"stage"`
+	Error string    `json:"error"`
+}
+
+// Serve serves the status returned by snapshot on a unix socket at path until ctx is done.
+// A socket file left behind by a daemon that is no longer running is replaced; a socket a
+// running daemon listens on is an error.
+func Serve(ctx context.Context, path string, snapshot func() Status) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating status socket directory: %w", err)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return fmt.Errorf("status socket %s is in use by another daemon", path)
+		}
+		// stale socket of a daemon that didn't shut down cleanly
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("removing stale status socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("listening on status socket: %w", err)
+	}
+	// only the user running the daemon may query it
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return fmt.Errorf("restricting status socket permissions: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(snapshot())
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	logger.LogDebug(ctx, "Serving daemon status", "socket", path)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving status socket: %w", err)
+	}
+	return nil
+}
+
+// Query returns the status of the daemon serving it on the unix socket at path
+func Query(ctx context.Context, path string) (Status, error) {
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://sbommv/status", nil)
+	if err != nil {
+		return Status{}, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Status{}, fmt.Errorf("no daemon is serving its status on %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Status{}, fmt.Errorf("status socket %s returned HTTP %d", path, resp.StatusCode)
+	}
+
+	var s Status
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return Status{}, fmt.Errorf("decoding daemon status: %w", err)
+	}
+	return s, nil
+}
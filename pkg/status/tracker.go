@@ -0,0 +1,145 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// contextKey is the TransferMetadata key under which the engine stores the tracker
+const contextKey = "daemon_status"
+
+// Watched is a repository, folder or bucket a daemon watches for new SBOMs
+type Watched struct {
+	Name      string     `json:"name"`
+	LastPoll  *time.Time `json:"last_poll,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// Tracker records what the input adapter of a daemon watches and how many SBOMs it has
+// fetched that are not yet picked up for upload. It is safe for concurrent use, and a nil
+// *Tracker ignores records.
+type Tracker struct {
+	mu      sync.Mutex
+	watched map[string]*Watched
+	pending func() int
+}
+
+// NewTracker returns an empty tracker
+func NewTracker() *Tracker {
+	return &Tracker{watched: map[string]*Watched{}}
+}
+
+// Attach stores the tracker in the transfer context for input adapters to pick up
+func Attach(ctx *tcontext.TransferMetadata, t *Tracker) {
+	if t != nil {
+		ctx.WithValue(contextKey, t)
+	}
+}
+
+// FromContext returns the tracker of the transfer, or nil when none is attached
+func FromContext(ctx tcontext.TransferMetadata) *Tracker {
+	t, _ := ctx.Value(contextKey).(*Tracker)
+	return t
+}
+
+// Watch records the repositories, folders or buckets the daemon watches
+func Watch(ctx tcontext.TransferMetadata, names ...string) {
+	t := FromContext(ctx)
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, name := range names {
+		if _, ok := t.watched[name]; !ok {
+			t.watched[name] = &Watched{Name: name}
+		}
+	}
+}
+
+// Polled records a poll of a watched repository, folder or bucket, and its error if it failed
+func Polled(ctx tcontext.TransferMetadata, name string, err error) {
+	t := FromContext(ctx)
+	if t == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	w, ok := t.watched[name]
+	if !ok {
+		w = &Watched{Name: name}
+		t.watched[name] = w
+	}
+	w.LastPoll = &now
+	w.LastError = ""
+	if err != nil {
+		w.LastError = err.Error()
+	}
+}
+
+// TrackPending registers the function counting the SBOMs fetched but not yet picked up
+// for upload, typically the length of the watcher's channel
+func TrackPending(ctx tcontext.TransferMetadata, pending func() int) {
+	t := FromContext(ctx)
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = pending
+}
+
+// Watched returns the watched repositories, folders or buckets, sorted by name
+func (t *Tracker) Watched() []Watched {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	watched := make([]Watched, 0, len(t.watched))
+	for _, w := range t.watched {
+		watched = append(watched, *w)
+	}
+	sort.Slice(watched, func(i, j int) bool { return watched[i].Name < watched[j].Name })
+	return watched
+}
+
+// Pending returns the number of SBOMs fetched but not yet picked up for upload
+func (t *Tracker) Pending() int {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	pending := t.pending
+	t.mu.Unlock()
+
+	if pending == nil {
+		return 0
+	}
+	return pending()
+}
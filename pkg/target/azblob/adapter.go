@@ -0,0 +1,190 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type AzureBlobAdapter struct {
+	Config         *AzureBlobConfig
+	Role           types.AdapterRole
+	ProcessingMode types.ProcessingMode
+	Uploader       SBOMUploader
+	batchUploader  AzureBlobBatchUploader
+}
+
+// AddCommandParams adds Azure Blob Storage-specific CLI flags
+func (a *AzureBlobAdapter) AddCommandParams(cmd *cobra.Command) {
+	cmd.Flags().String("out-azblob-account-name", "", "Storage account name (or AZURE_STORAGE_ACCOUNT)")
+	cmd.Flags().String("out-azblob-container", "", "Blob container name")
+	cmd.Flags().String("out-azblob-prefix", "", "Blob name prefix")
+	cmd.Flags().String("out-azblob-endpoint-url", "", "Custom blob service URL, e.g. Azurite or a sovereign cloud (default: https://<account>.blob.core.windows.net)")
+	cmd.Flags().String("out-azblob-sas-token", "", "Shared access signature of the account or container (or AZURE_STORAGE_SAS_TOKEN)")
+	cmd.Flags().String("out-azblob-account-key", "", "Storage account key (or AZURE_STORAGE_KEY)")
+	cmd.Flags().String("out-azblob-connection-string", "", "Storage account connection string (or AZURE_STORAGE_CONNECTION_STRING)")
+	cmd.Flags().Bool("out-azblob-format-extensions", false, "Name blobs after their detected format, e.g. .cdx.json, .spdx.json, .cdx.xml")
+	cmd.Flags().StringSlice("out-azblob-extension-map", nil, "Extensions of formats as format=extension, e.g. cyclonedx-json=.json (implies --out-azblob-format-extensions)")
+}
+
+// ParseAndValidateParams validates the Azure Blob Storage adapter params
+func (a *AzureBlobAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	var (
+		accountNameFlag, containerFlag, prefixFlag, endpointURLFlag, sasTokenFlag, accountKeyFlag, connectionStringFlag string
+		missingFlags                                                                                                    []string
+		invalidFlags                                                                                                    []string
+	)
+
+	accountNameFlag = "out-azblob-account-name"
+	containerFlag = "out-azblob-container"
+	prefixFlag = "out-azblob-prefix"
+	endpointURLFlag = "out-azblob-endpoint-url"
+	sasTokenFlag = "out-azblob-sas-token"
+	accountKeyFlag = "out-azblob-account-key"
+	connectionStringFlag = "out-azblob-connection-string"
+	formatExtensionsFlag := "out-azblob-format-extensions"
+	extensionMapFlag := "out-azblob-extension-map"
+
+	var uploader SBOMUploader
+	if a.ProcessingMode == types.ProcessingMode(types.UploadSequential) {
+		uploader = &AzureBlobSequentialUploader{}
+	} else if a.ProcessingMode == types.ProcessingMode(types.UploadParallel) {
+		uploader = &AzureBlobParallelUploader{}
+	} else {
+		return fmt.Errorf("unsupported processing mode: %s", a.ProcessingMode)
+	}
+
+	// validate flags for Azure Blob Storage adapter, all flags should start with "out-azblob-"
+	err := utils.FlagValidation(cmd, types.AzureBlobAdapterType, types.OutputAdapterFlagPrefix)
+	if err != nil {
+		return fmt.Errorf("azblob flag validation failed: %w", err)
+	}
+
+	containerName, _ := cmd.Flags().GetString(containerFlag)
+	if containerName == "" {
+		missingFlags = append(missingFlags, containerFlag)
+	}
+
+	// if prefix is empty, blobs are uploaded at the root of the container
+	prefix, _ := cmd.Flags().GetString(prefixFlag)
+
+	// extract custom blob service endpoint
+	endpointURL, _ := cmd.Flags().GetString(endpointURLFlag)
+	if endpointURL != "" {
+		if u, err := url.Parse(endpointURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be an http or https URL)", endpointURLFlag, endpointURL))
+		}
+	}
+
+	accountName, _ := cmd.Flags().GetString(accountNameFlag)
+	if accountName == "" {
+		accountName = viper.GetString("AZURE_STORAGE_ACCOUNT")
+	}
+
+	// credentials from the flags, or from the environment when no credential flag is set,
+	// and the default Azure credential chain (e.g. managed identity) when neither has any
+	sasToken, _ := cmd.Flags().GetString(sasTokenFlag)
+	accountKey, _ := cmd.Flags().GetString(accountKeyFlag)
+	connectionString, _ := cmd.Flags().GetString(connectionStringFlag)
+	if sasToken == "" && accountKey == "" && connectionString == "" {
+		sasToken = viper.GetString("AZURE_STORAGE_SAS_TOKEN")
+		accountKey = viper.GetString("AZURE_STORAGE_KEY")
+		connectionString = viper.GetString("AZURE_STORAGE_CONNECTION_STRING")
+	}
+
+	credentials := 0
+	for _, c := range []string{sasToken, accountKey, connectionString} {
+		if c != "" {
+			credentials++
+		}
+	}
+	if credentials > 1 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("only one of --%s, --%s and --%s can be used", sasTokenFlag, accountKeyFlag, connectionStringFlag))
+	}
+	if connectionString == "" && accountName == "" && (endpointURL == "" || accountKey != "") {
+		missingFlags = append(missingFlags, accountNameFlag)
+	}
+	if credentials == 0 {
+		logger.LogDebug(cmd.Context(), "Azure Blob Storage credentials not provided, using the default Azure credential chain")
+	}
+
+	// extract the extensions blobs are named with
+	var extensions *sbom.ExtensionMap
+	formatExtensions, _ := cmd.Flags().GetBool(formatExtensionsFlag)
+	extensionMap, _ := cmd.Flags().GetStringSlice(extensionMapFlag)
+	if formatExtensions || len(extensionMap) > 0 {
+		if extensions, err = sbom.ParseExtensionMap(extensionMap); err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s: %v", extensionMapFlag, err))
+		}
+	}
+
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing flags: %s", strings.Join(missingFlags, ", "))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid output adapter flag usage:\n %s\n\nUse 'sbommv transfer --help' for correct usage.", strings.Join(invalidFlags, "\n "))
+	}
+
+	cfg := NewAzureBlobConfig()
+	cfg.ProcessingMode = a.ProcessingMode
+	cfg.AccountName = accountName
+	cfg.EndpointURL = endpointURL
+	cfg.ContainerName = containerName
+	cfg.Prefix = prefix
+	cfg.SASToken = sasToken
+	cfg.AccountKey = accountKey
+	cfg.ConnectionString = connectionString
+	cfg.Extensions = extensions
+
+	a.Config = cfg
+	a.Uploader = uploader
+
+	return nil
+}
+
+// FetchSBOMs is not supported by the output adapter
+func (a *AzureBlobAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	return nil, fmt.Errorf("Azure Blob Storage adapter does not support SBOM Fetching when it is in output adapter role")
+}
+
+// UploadSBOMs uploads SBOMs to the container
+func (a *AzureBlobAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Starting SBOM upload", "mode", a.ProcessingMode)
+	return a.Uploader.Upload(ctx, a.Config, iter)
+}
+
+// UploadBatch uploads a chunk of SBOMs with concurrent uploads, used with --batch-size
+func (a *AzureBlobAdapter) UploadBatch(ctx tcontext.TransferMetadata, sboms []*iterator.SBOM) error {
+	return a.batchUploader.UploadBatch(ctx, a.Config, sboms)
+}
+
+// DryRun for Output Adapter: Simulates uploading SBOMs to the container
+func (a *AzureBlobAdapter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
+	reporter := NewAzureBlobReporter(false, "", a.Config.ContainerName, a.Config.Prefix)
+	reporter.config = a.Config
+	return reporter.DryRun(ctx, iter)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseFlags returns the adapter configured from the given flags, and the validation error
+func parseFlags(t *testing.T, mode types.UploadMode, args ...string) (*AzureBlobAdapter, error) {
+	t.Helper()
+	adapter := &AzureBlobAdapter{Role: types.OutputAdapterRole, ProcessingMode: types.ProcessingMode(mode)}
+	cmd := &cobra.Command{Use: "transfer"}
+	cmd.Flags().String("output-adapter", "azblob", "")
+	cmd.Flags().String("out-s3-bucket-name", "", "flag of another output adapter")
+	adapter.AddCommandParams(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetContext(context.Background())
+	return adapter, adapter.ParseAndValidateParams(cmd)
+}
+
+func TestParseAndValidateParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		invalid string
+	}{
+		{"no container", []string{"--out-azblob-account-name=acct"}, "missing flags: out-azblob-container"},
+		{"no account", []string{"--out-azblob-container=sboms", "--out-azblob-sas-token=sig=x"}, "missing flags: out-azblob-account-name"},
+		{"endpoint without scheme", []string{"--out-azblob-account-name=acct", "--out-azblob-container=sboms", "--out-azblob-endpoint-url=127.0.0.1:10000"}, "(must be an http or https URL)"},
+		{"two credentials", []string{"--out-azblob-account-name=acct", "--out-azblob-container=sboms", "--out-azblob-connection-string=UseDevelopmentStorage=true", "--out-azblob-account-key=a2V5"}, "only one of --out-azblob-sas-token, --out-azblob-account-key and --out-azblob-connection-string can be used"},
+		{"unknown format in extension map", []string{"--out-azblob-account-name=acct", "--out-azblob-container=sboms", "--out-azblob-extension-map=cyclonedx-yaml=.yaml"}, "--out-azblob-extension-map:"},
+		{"flag of another adapter", []string{"--out-azblob-account-name=acct", "--out-azblob-container=sboms", "--out-s3-bucket-name=bucket"}, "flag --out-s3-bucket-name is invalid"},
+		{"account key", []string{"--out-azblob-account-name=acct", "--out-azblob-container=sboms", "--out-azblob-account-key=a2V5", "--out-azblob-format-extensions"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := parseFlags(t, types.UploadSequential, tt.args...)
+			if tt.invalid != "" {
+				assert.ErrorContains(t, err, tt.invalid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "acct", adapter.Config.AccountName)
+			assert.Equal(t, "a2V5", adapter.Config.AccountKey)
+			assert.NotNil(t, adapter.Config.Extensions)
+			assert.IsType(t, &AzureBlobSequentialUploader{}, adapter.Uploader)
+		})
+	}
+
+	adapter, err := parseFlags(t, types.UploadParallel, "--out-azblob-account-name=acct", "--out-azblob-container=sboms")
+	require.NoError(t, err)
+	assert.Nil(t, adapter.Config.Extensions)
+	assert.IsType(t, &AzureBlobParallelUploader{}, adapter.Uploader)
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+)
+
+type AzureBlobReporter struct {
+	verbose       bool
+	inputDir      string
+	containerName string
+	prefix        string
+
+	// config, when set, makes the dry run look up every blob in the container
+	config *AzureBlobConfig
+}
+
+func NewAzureBlobReporter(verbose bool, inputDir, containerName, prefix string) *AzureBlobReporter {
+	return &AzureBlobReporter{
+		verbose:       verbose,
+		inputDir:      inputDir,
+		containerName: containerName,
+		prefix:        prefix,
+	}
+}
+
+func (s *AzureBlobReporter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Dry-run mode: Displaying SBOMs uploaded to Azure Blob Storage")
+	processor := sbom.NewSBOMProcessor(s.inputDir, s.verbose)
+	sbomCount := 0
+	fmt.Println("\n📦 Azure Blob Storage Output Adapter Dry-Run")
+
+	preflight := utils.NewPreflight()
+	collisions := utils.NewNameCollisions()
+	var client *azblob.Client
+	var clientErr error
+	if s.config != nil {
+		if client, clientErr = s.config.GetAzureClient(ctx); clientErr != nil {
+			logger.LogInfo(ctx.Context, "Cannot look up existing blobs", "container", s.containerName, "error", clientErr)
+		}
+	}
+
+	for {
+		sbom, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
+			return err
+		}
+		processor.Update(sbom.Data, "", sbom.Path)
+		doc, err := processor.ProcessSBOMs()
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Failed to process SBOM")
+			return err
+		}
+		if s.inputDir != "" {
+			if err := processor.WriteSBOM(doc, ""); err != nil {
+				logger.LogError(ctx.Context, err, "Failed to write SBOM")
+				return err
+			}
+		}
+		if s.verbose {
+			fmt.Printf("\n-------------------- 📜 SBOM Content --------------------\n")
+			fmt.Printf("📂 Filename: %s\n", doc.Filename)
+			fmt.Printf("📦 Format %s | SpecVersion: %s\n\n", doc.Format, doc.SpecVersion)
+			fmt.Println(string(doc.Content))
+			fmt.Println("------------------------------------------------------")
+		}
+
+		if s.config == nil {
+			fmt.Printf(" - 📁 Would Upload to Container: %s | Prefix: %s \n",
+				s.containerName, s.prefix)
+			sbomCount++
+			continue
+		}
+
+		// same blob name as the upload, including the suffix of colliding names
+		name := blobName(s.config, sbom)
+		renamedFrom := ""
+		if resolved, collided := collisions.Resolve(name, sbom.Data); collided {
+			renamedFrom, name = name, resolved
+		}
+		name = path.Join(blobPrefix(s.config), name)
+
+		exists, err := false, clientErr
+		if client != nil {
+			exists, err = blobExists(ctx, client, s.containerName, name)
+		}
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Failed to look up blob", "container", s.containerName, "blob", name, "error", err)
+		}
+
+		// block blob uploads replace existing blobs
+		status := preflight.Status(exists, err, true)
+		fmt.Printf(" - 📁 Would Upload to Container: %s | Blob: %s [%s]%s\n",
+			s.containerName, name, status, preflight.Notes(sbom.Conversion(), renamedFrom))
+		sbomCount++
+	}
+
+	fmt.Printf("\n📊 Total SBOMs to be uploaded: %d\n", sbomCount)
+	if s.config != nil {
+		preflight.Print()
+	}
+	logger.LogDebug(ctx.Context, "Dry-run completed", "total_sboms", sbomCount)
+
+	return nil
+}
+
+// blobExists reports whether the container holds a blob under name
+func blobExists(ctx tcontext.TransferMetadata, client *azblob.Client, containerName, name string) (bool, error) {
+	_, err := client.ServiceClient().NewContainerClient(containerName).NewBlobClient(name).GetProperties(ctx.Context, nil)
+	if err == nil {
+		return true, nil
+	}
+
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,272 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/limiter"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/retry"
+	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/timing"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+)
+
+type SBOMUploader interface {
+	Upload(ctx tcontext.TransferMetadata, config *AzureBlobConfig, iter iterator.SBOMIterator) error
+}
+
+type (
+	AzureBlobSequentialUploader struct{}
+	AzureBlobParallelUploader   struct{}
+)
+
+// Upload uploads SBOMs to the container in parallel
+func (u *AzureBlobParallelUploader) Upload(ctx tcontext.TransferMetadata, config *AzureBlobConfig, iter iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Writing SBOMs concurrently", "container", config.ContainerName, "prefix", config.Prefix)
+
+	client, err := config.GetAzureClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Azure Blob Storage client: %w", err)
+	}
+
+	// retrieve all SBOMs from iterator
+	var sbomList []*iterator.SBOM
+	for {
+		sbom, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
+			continue
+		}
+		sbomList = append(sbomList, sbom)
+	}
+
+	totalSBOMs, successfullyUploaded := uploadBlobs(ctx, client, config, blobPrefix(config), utils.NewNameCollisions(), sbomList)
+
+	logger.LogInfo(ctx.Context, "upload", "total", totalSBOMs, "success", successfullyUploaded, "failed", totalSBOMs-successfullyUploaded)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded, err)
+	}
+	if totalSBOMs == 0 {
+		return errdefs.NotFound(fmt.Errorf("no SBOMs found to upload"))
+	}
+
+	return nil
+}
+
+// Upload uploads SBOMs to the container one by one
+func (u *AzureBlobSequentialUploader) Upload(ctx tcontext.TransferMetadata, config *AzureBlobConfig, iter iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Writing SBOMs sequentially", "container", config.ContainerName, "prefix", config.Prefix)
+	totalSBOMs := 0
+	successfullyUploaded := 0
+
+	client, err := config.GetAzureClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Azure Blob Storage client: %w", err)
+	}
+
+	prefix := blobPrefix(config)
+	collisions := utils.NewNameCollisions()
+
+	for {
+		sbom, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		totalSBOMs++
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
+			continue
+		}
+
+		fileName := resolveBlobName(ctx, config, collisions, sbom)
+		name := path.Join(prefix, fileName)
+
+		if err := uploadBlob(ctx, client, config.ContainerName, name, sbom); err != nil {
+			logger.LogError(ctx.Context, err, "Failed to upload SBOM", "container", config.ContainerName, "name", name)
+			report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: config.ContainerName + "/" + name, Stage: report.StageUpload}, err)
+			continue
+		}
+
+		successfullyUploaded++
+		report.RecordTransfer(ctx, sbom, config.ContainerName+"/"+name)
+		logger.LogDebug(ctx.Context, "Uploaded SBOM", "container", config.ContainerName, "name", name, "size", len(sbom.Data))
+		logger.LogInfo(ctx.Context, "upload", "success", true, "container", config.ContainerName, "prefix", config.Prefix, "filename", fileName)
+	}
+
+	logger.LogInfo(ctx.Context, "upload", "total", totalSBOMs, "success", successfullyUploaded, "failed", totalSBOMs-successfullyUploaded)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded, err)
+	}
+
+	return nil
+}
+
+// AzureBlobBatchUploader uploads the batches the engine hands over with --batch-size, each batch
+// as concurrent uploads. The client and the blob name collisions are shared by all batches of a run.
+type AzureBlobBatchUploader struct {
+	client     *azblob.Client
+	prefix     string
+	collisions *utils.NameCollisions
+}
+
+// UploadBatch uploads one batch of SBOMs, failing when any of them couldn't be uploaded
+func (u *AzureBlobBatchUploader) UploadBatch(ctx tcontext.TransferMetadata, config *AzureBlobConfig, sboms []*iterator.SBOM) error {
+	if u.client == nil {
+		client, err := config.GetAzureClient(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create Azure Blob Storage client: %w", err)
+		}
+		u.client = client
+		u.collisions = utils.NewNameCollisions()
+		u.prefix = blobPrefix(config)
+	}
+
+	attempted, uploaded := uploadBlobs(ctx, u.client, config, u.prefix, u.collisions, sboms)
+	if uploaded < attempted {
+		return fmt.Errorf("failed to upload %d of %d SBOMs", attempted-uploaded, attempted)
+	}
+	return nil
+}
+
+// blobPrefix returns the prefix blobs are named with, ending with "/" when set
+func blobPrefix(config *AzureBlobConfig) string {
+	prefix := config.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix = prefix + "/"
+	}
+	return prefix
+}
+
+// uploadBlobs uploads SBOMs with a few concurrent uploads, stopping early when the transfer is
+// cancelled. It returns how many uploads were attempted and how many succeeded.
+func uploadBlobs(ctx tcontext.TransferMetadata, client *azblob.Client, config *AzureBlobConfig, prefix string, collisions *utils.NameCollisions, sboms []*iterator.SBOM) (int, int) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	attempted, uploaded := 0, 0
+	const maxConcurrency = 3
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	for _, sbom := range sboms {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(sbom *iterator.SBOM) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			fileName := resolveBlobName(ctx, config, collisions, sbom)
+			name := path.Join(prefix, fileName)
+
+			err := uploadBlob(ctx, client, config.ContainerName, name, sbom)
+
+			mu.Lock()
+			defer mu.Unlock()
+			attempted++
+			if err != nil {
+				logger.LogError(ctx.Context, err, "Failed to upload SBOM", "container", config.ContainerName, "name", name)
+				report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: config.ContainerName + "/" + name, Stage: report.StageUpload}, err)
+				return
+			}
+			uploaded++
+			report.RecordTransfer(ctx, sbom, config.ContainerName+"/"+name)
+			logger.LogDebug(ctx.Context, "Uploaded SBOM", "container", config.ContainerName, "name", name, "size", len(sbom.Data))
+			logger.LogInfo(ctx.Context, "upload", "success", true, "container", config.ContainerName, "prefix", config.Prefix, "filename", fileName)
+		}(sbom)
+	}
+
+	wg.Wait()
+	return attempted, uploaded
+}
+
+// blobMetadata returns the metadata attached to every uploaded blob, currently the run ID so
+// blobs can be traced back to the transfer that wrote them. Azure metadata names must be
+// C# identifiers, hence the underscores.
+func blobMetadata(ctx tcontext.TransferMetadata) map[string]*string {
+	runID := ctx.RunID()
+	if runID == "" {
+		return nil
+	}
+	return map[string]*string{"sbommv_run_id": to.Ptr(runID)}
+}
+
+// resolveBlobName returns the blob name for the SBOM, renamed after its detected format when
+// --out-azblob-format-extensions is set, adding a content-hash suffix when a different SBOM was
+// already uploaded under the same name in this run.
+func resolveBlobName(ctx tcontext.TransferMetadata, config *AzureBlobConfig, collisions *utils.NameCollisions, sbom *iterator.SBOM) string {
+	resolved, collided := collisions.Resolve(blobName(config, sbom), sbom.Data)
+	if collided {
+		logger.LogInfo(ctx.Context, "Duplicate blob name with different content, uploading with content-hash suffix", "file", sbom.Path, "uploaded_as", resolved, "namespace", sbom.Namespace)
+	}
+	return resolved
+}
+
+// blobName returns the blob name of an SBOM before name collisions are resolved
+func blobName(config *AzureBlobConfig, sbom *iterator.SBOM) string {
+	if config.Extensions != nil {
+		return config.Extensions.Rename(sbom.Path, sbom.Data)
+	}
+	return sbom.Path
+}
+
+// uploadBlob uploads a single SBOM as a block blob within the engine-wide transfer budget
+func uploadBlob(ctx tcontext.TransferMetadata, client *azblob.Client, containerName, name string, sbom *iterator.SBOM) error {
+	return timing.Time(ctx, timing.StageUpload, sbom.Path, func() error {
+		return retry.Do(ctx, containerName+"/"+name, func() error {
+			return limiter.Transfer(ctx, func() error {
+				_, err := client.UploadBuffer(ctx.Context, containerName, name, sbom.Data, &azblob.UploadBufferOptions{
+					HTTPHeaders: &blob.HTTPHeaders{BlobContentType: to.Ptr(sbomd.ContentType(sbom.Data))},
+					Metadata:    blobMetadata(ctx),
+				})
+				return withStatus(err)
+			})
+		})
+	})
+}
+
+// withStatus exposes the HTTP status of an Azure error to the retry policy
+func withStatus(err error) error {
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) {
+		return &retry.HTTPError{StatusCode: respErr.StatusCode, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azblob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const appSBOM = `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"metadata":{"component":{"type":"application","name":"app","version":"1.0.0"}}}`
+
+type storedBlob struct {
+	data        string
+	contentType string
+	runID       string
+}
+
+// blobService accepts blob uploads of account "acct" signed with its shared key, and refuses
+// blobs named locked*, as for a blob under an infinite lease
+type blobService struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	blobs map[string]storedBlob
+}
+
+func newBlobService(t *testing.T) *blobService {
+	s := &blobService{blobs: map[string]storedBlob{}}
+	s.Server = httptest.NewServer(s)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *blobService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutPrefix(r.URL.Path, "/acct/")
+	if r.Method != http.MethodPut || !ok || r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey acct:") {
+		w.Header().Set("x-ms-error-code", "NoAuthenticationInformation")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if strings.HasPrefix(name, "sboms/prod/locked") {
+		w.Header().Set("x-ms-error-code", "LeaseIdMissing")
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	data, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	s.blobs[name] = storedBlob{data: string(data), contentType: r.Header.Get("x-ms-blob-content-type"), runID: r.Header.Get("x-ms-meta-sbommv_run_id")}
+	s.mu.Unlock()
+	w.Header().Set("ETag", `"0x1"`)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func TestUploadBlobs(t *testing.T) {
+	spdx := `{"spdxVersion":"SPDX-2.3","SPDXID":"SPDXRef-DOCUMENT","name":"api","dataLicense":"CC0-1.0","documentNamespace":"https://example.com/api","creationInfo":{"created":"2025-01-01T00:00:00Z","creators":["Tool: test"]}}`
+
+	for _, uploader := range []SBOMUploader{&AzureBlobSequentialUploader{}, &AzureBlobParallelUploader{}} {
+		t.Run(fmt.Sprintf("%T", uploader), func(t *testing.T) {
+			ctx := *tcontext.NewTransferMetadata(context.Background())
+			ctx.SetRunID("run-1")
+			server := newBlobService(t)
+
+			extensions, err := sbom.ParseExtensionMap(nil)
+			require.NoError(t, err)
+			config := NewAzureBlobConfig()
+			config.AccountName = "acct"
+			config.AccountKey = "a2V5"
+			config.EndpointURL = server.URL + "/acct"
+			config.ContainerName = "sboms"
+			config.Prefix = "prod"
+			config.Extensions = extensions
+
+			err = uploader.Upload(ctx, config, iterator.NewMemoryIterator([]*iterator.SBOM{
+				{Path: "app/bom.json", Data: []byte(appSBOM)},
+				{Path: "api.json", Data: []byte(spdx)},
+				{Path: "locked.json", Data: []byte(appSBOM)},
+			}))
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string]storedBlob{
+				"sboms/prod/app/bom.cdx.json": {data: appSBOM, contentType: "application/vnd.cyclonedx+json", runID: "run-1"},
+				"sboms/prod/api.spdx.json":    {data: spdx, contentType: "application/spdx+json", runID: "run-1"},
+			}, server.blobs)
+		})
+	}
+}
+
+func TestBatchUploadReportsFailures(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	server := newBlobService(t)
+
+	config := NewAzureBlobConfig()
+	config.AccountName = "acct"
+	config.AccountKey = "a2V5"
+	config.EndpointURL = server.URL + "/acct/"
+	config.ContainerName = "sboms"
+	config.Prefix = "prod/"
+
+	var uploader AzureBlobBatchUploader
+	require.NoError(t, uploader.UploadBatch(ctx, config, []*iterator.SBOM{{Path: "app.json", Data: []byte(appSBOM)}}))
+	err := uploader.UploadBatch(ctx, config, []*iterator.SBOM{
+		{Path: "app.json", Data: []byte(strings.Replace(appSBOM, "1.0.0", "2.0.0", 1))},
+		{Path: "locked.json", Data: []byte(appSBOM)},
+	})
+	assert.EqualError(t, err, "failed to upload 1 of 2 SBOMs")
+
+	require.Len(t, server.blobs, 2)
+	assert.Equal(t, appSBOM, server.blobs["sboms/prod/app.json"].data, "a different SBOM of the same name doesn't overwrite the first one")
+	assert.Empty(t, server.blobs["sboms/prod/app.json"].runID)
+}
@@ -15,11 +15,16 @@
 package dependencytrack
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/report"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 	"github.com/interlynk-io/sbommv/pkg/types"
 	"github.com/interlynk-io/sbommv/pkg/utils"
@@ -27,6 +32,12 @@ import (
 	"github.com/spf13/viper"
 )
 
+// projectClassifiers are the classifiers Dependency-Track accepts for projects
+var projectClassifiers = []string{
+	"APPLICATION", "FRAMEWORK", "LIBRARY", "CONTAINER", "PLATFORM", "OPERATING_SYSTEM",
+	"DEVICE", "DEVICE_DRIVER", "FIRMWARE", "FILE", "MACHINE_LEARNING_MODEL", "DATA",
+}
+
 type DependencyTrackAdapter struct {
 	Config         *DependencyTrackConfig
 	client         *DependencyTrackClient
@@ -34,6 +45,11 @@ type DependencyTrackAdapter struct {
 	Role           types.AdapterRole
 	ProcessingMode types.ProcessingMode
 	Overwrite      bool
+	Daemon         bool
+
+	// server version and the configured features it doesn't support, checked at startup
+	serverVersion       string
+	unsupportedFeatures []string
 }
 
 // func NewDependencyTrackAdapter(config *DependencyTrackConfig, client *DependencyTrackClient) *DependencyTrackAdapter {
@@ -49,14 +65,26 @@ func (d *DependencyTrackAdapter) AddCommandParams(cmd *cobra.Command) {
 	cmd.Flags().String("out-dtrack-url", "", "Dependency Track API URL")
 	cmd.Flags().String("out-dtrack-project-name", "", "Project name to upload SBOMs to")
 	cmd.Flags().String("out-dtrack-project-version", "", "Project version (default: latest)")
+	cmd.Flags().String("out-dtrack-project-name-template", "", "Go template naming the project of each SBOM, e.g. '{{.Owner}}/{{.Repo}}-{{.Version}}' (fields: Owner, Repo, Namespace, Version, Component, ComponentVersion, Filename, Path, Format, Source)")
+	cmd.Flags().Bool("out-dtrack-auto-create", false, "Let Dependency-Track create missing projects during BOM upload instead of creating them upfront (falls back if not permitted)")
+	cmd.Flags().String("out-dtrack-project-mapping", "", "YAML file mapping SBOM namespaces and primary components to existing project UUIDs; projects are never created and unmapped SBOMs fail")
+	cmd.Flags().String("out-dtrack-hierarchy", "", "YAML file declaring parent projects and dependencies, created before uploading")
+	cmd.Flags().String("out-dtrack-aggregate-into", "", "Merge all SBOMs into one BOM, deduplicating components, and upload it to this project")
+	cmd.Flags().String("out-dtrack-parent-project", "", "Parent of the projects created for SBOMs, as name or name@version (created when missing)")
+	cmd.Flags().StringSlice("out-dtrack-project-tags", nil, "Tags set on the projects created for SBOMs, e.g. team-payments,prod")
+	cmd.Flags().String("out-dtrack-classifier", "", "Classifier of the projects created for SBOMs, e.g. APPLICATION, LIBRARY, CONTAINER")
+	cmd.Flags().Bool("out-dtrack-wait", false, "Wait until Dependency-Track has processed each uploaded BOM, failing the transfer when it rejects one")
+	cmd.Flags().String("out-dtrack-wait-timeout", "10m", "With --out-dtrack-wait, how long to wait for a BOM to be processed, e.g. '60s', '10m'")
+	cmd.Flags().String("out-dtrack-reconcile-interval", "1hr", "In daemon mode, how often to check that uploaded projects still exist and upload their SBOM again when deleted on the server ('0' disables)")
 }
 
 // ParseAndValidateParams validates the Dependency-Track adapter params
 func (d *DependencyTrackAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	var (
-		urlFlag, projectNameFlag, projectVersionFlag string
-		missingFlags                                 []string
-		invalidFlags                                 []string
+		urlFlag, projectNameFlag, projectVersionFlag, autoCreateFlag, hierarchyFlag, reconcileFlag, aggregateFlag string
+		parentFlag, tagsFlag, classifierFlag, waitFlag, waitTimeoutFlag, mappingFlag, nameTemplateFlag            string
+		missingFlags                                                                                              []string
+		invalidFlags                                                                                              []string
 	)
 
 	switch d.Role {
@@ -67,6 +95,17 @@ func (d *DependencyTrackAdapter) ParseAndValidateParams(cmd *cobra.Command) erro
 		urlFlag = "out-dtrack-url"
 		projectNameFlag = "out-dtrack-project-name"
 		projectVersionFlag = "out-dtrack-project-version"
+		autoCreateFlag = "out-dtrack-auto-create"
+		hierarchyFlag = "out-dtrack-hierarchy"
+		reconcileFlag = "out-dtrack-reconcile-interval"
+		aggregateFlag = "out-dtrack-aggregate-into"
+		parentFlag = "out-dtrack-parent-project"
+		tagsFlag = "out-dtrack-project-tags"
+		classifierFlag = "out-dtrack-classifier"
+		waitFlag = "out-dtrack-wait"
+		waitTimeoutFlag = "out-dtrack-wait-timeout"
+		mappingFlag = "out-dtrack-project-mapping"
+		nameTemplateFlag = "out-dtrack-project-name-template"
 
 	default:
 		return fmt.Errorf("The adapter is neither an input type nor an output type")
@@ -95,7 +134,102 @@ func (d *DependencyTrackAdapter) ParseAndValidateParams(cmd *cobra.Command) erro
 	}
 	projectName, _ := cmd.Flags().GetString(projectNameFlag)
 	projectVersion, _ := cmd.Flags().GetString(projectVersionFlag)
+	autoCreate, _ := cmd.Flags().GetBool(autoCreateFlag)
 	projectOverwrite := d.Overwrite
+
+	nameTemplateStr, _ := cmd.Flags().GetString(nameTemplateFlag)
+	nameTemplate, err := utils.ParseProjectNameTemplate(nameTemplateStr)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s: %v", nameTemplateFlag, err))
+	}
+	if nameTemplate != nil && projectName != "" {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s and --%s are mutually exclusive", nameTemplateFlag, projectNameFlag))
+	}
+
+	var hierarchy *Hierarchy
+	if hierarchyFile, _ := cmd.Flags().GetString(hierarchyFlag); hierarchyFile != "" {
+		hierarchy, err = LoadHierarchy(hierarchyFile)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s: %v", hierarchyFlag, err))
+		}
+	}
+
+	aggregateInto, _ := cmd.Flags().GetString(aggregateFlag)
+	if aggregateInto != "" {
+		if d.Daemon {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s can't be used in daemon mode, the aggregated BOM is uploaded once all SBOMs are fetched", aggregateFlag))
+		}
+		if projectName != "" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s and --%s are mutually exclusive", aggregateFlag, projectNameFlag))
+		}
+		if nameTemplate != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s and --%s are mutually exclusive", aggregateFlag, nameTemplateFlag))
+		}
+	}
+
+	parentProject, _ := cmd.Flags().GetString(parentFlag)
+	parentProject = strings.TrimSpace(parentProject)
+	if parentProject != "" {
+		parentName, parentVersion := ParseProjectRef(parentProject)
+		if parentName == projectName && parentVersion == cmp.Or(projectVersion, "latest") {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s: a project can't be its own parent", parentFlag, parentProject))
+		}
+	}
+
+	var projectTags []string
+	tags, _ := cmd.Flags().GetStringSlice(tagsFlag)
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			projectTags = append(projectTags, tag)
+		}
+	}
+
+	classifier, _ := cmd.Flags().GetString(classifierFlag)
+	classifier = strings.ToUpper(strings.TrimSpace(classifier))
+	if classifier != "" && !slices.Contains(projectClassifiers, classifier) {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s: must be one of %s", classifierFlag, classifier, strings.Join(projectClassifiers, ", ")))
+	}
+
+	// with a project mapping, SBOMs only go to existing projects, nothing creates one
+	var projectMapping *ProjectMapping
+	if mappingFile, _ := cmd.Flags().GetString(mappingFlag); mappingFile != "" {
+		projectMapping, err = LoadProjectMapping(mappingFile)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s: %v", mappingFlag, err))
+		}
+		for _, flag := range []string{projectNameFlag, nameTemplateFlag, hierarchyFlag, aggregateFlag, parentFlag} {
+			if cmd.Flags().Changed(flag) {
+				invalidFlags = append(invalidFlags, fmt.Sprintf("--%s and --%s are mutually exclusive", mappingFlag, flag))
+			}
+		}
+		// --out-dtrack-auto-create=false asks for no project creation, as the mapping does
+		if autoCreate {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s and --%s are mutually exclusive", mappingFlag, autoCreateFlag))
+		}
+	}
+
+	var waitTimeout time.Duration
+	if wait, _ := cmd.Flags().GetBool(waitFlag); wait {
+		waitTimeoutStr, _ := cmd.Flags().GetString(waitTimeoutFlag)
+		waitSeconds, err := utils.ParseDuration(waitTimeoutStr)
+		if err != nil || waitSeconds <= 0 {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s: must be in format like '60s', '10m' or '1hr'", waitTimeoutFlag, waitTimeoutStr))
+		}
+		waitTimeout = time.Duration(waitSeconds) * time.Second
+	}
+
+	// reconciliation only runs in daemon mode, and restores deleted projects by creating them
+	var reconcileSeconds int64
+	if d.Daemon && projectMapping != nil {
+		logger.LogDebug(cmd.Context(), "Reconciliation disabled, mapped projects are never created", "flag", mappingFlag)
+	} else if d.Daemon {
+		reconcileStr, _ := cmd.Flags().GetString(reconcileFlag)
+		reconcileSeconds, err = utils.ParseDuration(reconcileStr)
+		if err != nil || reconcileSeconds < 0 {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s: must be in format like '60s', '10m', '10hr', or '0' to disable", reconcileFlag, reconcileStr))
+		}
+	}
+
 	// Validate DTrack connectivity before proceeding
 	if err := ValidateDTrackConnection(apiURL, token); err != nil {
 		return fmt.Errorf("DTrack API %s validation failed: %w", apiURL, err)
@@ -111,7 +245,9 @@ func (d *DependencyTrackAdapter) ParseAndValidateParams(cmd *cobra.Command) erro
 
 	var uploader SBOMUploader
 	// SequentialFetcher
-	if d.ProcessingMode == types.FetchSequential {
+	if aggregateInto != "" {
+		uploader = NewAggregatingUploader(aggregateInto)
+	} else if d.ProcessingMode == types.FetchSequential {
 		uploader = NewSequentialUploader()
 	} else if d.ProcessingMode == types.FetchParallel {
 		uploader = NewParallelUploader()
@@ -120,6 +256,23 @@ func (d *DependencyTrackAdapter) ParseAndValidateParams(cmd *cobra.Command) erro
 	cfg := NewDependencyTrackConfig(apiURL, projectVersion, projectOverwrite)
 	cfg.APIKey = token
 	cfg.ProjectName = projectName
+	cfg.NameTemplate = nameTemplate
+	cfg.AutoCreate = autoCreate
+	cfg.Hierarchy = hierarchy
+	cfg.AggregateInto = aggregateInto
+	cfg.ParentProject = parentProject
+	cfg.ProjectTags = projectTags
+	cfg.Classifier = classifier
+	cfg.WaitTimeout = waitTimeout
+	cfg.ProjectMapping = projectMapping
+
+	if reconcileSeconds > 0 {
+		cfg.ReconcileInterval = time.Duration(reconcileSeconds) * time.Second
+		cfg.Uploads, err = OpenUploadCache(cmd.Context(), apiURL)
+		if err != nil {
+			return fmt.Errorf("failed to initialize upload cache: %w", err)
+		}
+	}
 
 	// Set values to struct
 	d.Config = cfg
@@ -132,11 +285,23 @@ func (d *DependencyTrackAdapter) ParseAndValidateParams(cmd *cobra.Command) erro
 	d.client = client
 	d.Uploader = uploader
 
+	d.serverVersion, d.unsupportedFeatures = checkServerCompatibility(cmd.Context(), client, cfg)
+
 	logger.LogDebug(cmd.Context(), "Dependency-Track parameters validated and assigned",
 		"url", d.Config.APIURL,
 		"apiKey", d.Config.APIKey,
 		"project_name", d.Config.ProjectName,
+		"project_name_template", d.Config.NameTemplate.String(),
 		"project_version", d.Config.ProjectVersion,
+		"auto_create", d.Config.AutoCreate,
+		"hierarchy", d.Config.Hierarchy != nil,
+		"aggregate_into", d.Config.AggregateInto,
+		"parent_project", d.Config.ParentProject,
+		"project_tags", d.Config.ProjectTags,
+		"classifier", d.Config.Classifier,
+		"wait_timeout", d.Config.WaitTimeout,
+		"project_mapping", d.Config.ProjectMapping != nil,
+		"reconcile_interval", d.Config.ReconcileInterval,
 	)
 	return nil
 }
@@ -147,10 +312,64 @@ func (d *DependencyTrackAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iter
 }
 
 func (d *DependencyTrackAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
-	return d.Uploader.Upload(ctx, d.Config, d.client, iter)
+	if d.Config.Hierarchy != nil {
+		if err := d.Config.Hierarchy.Ensure(ctx, d.client); err != nil {
+			return fmt.Errorf("creating project hierarchy: %w", err)
+		}
+	}
+
+	// resolved after the hierarchy, which may declare the parent
+	if d.Config.ParentProject != "" {
+		if err := d.client.ResolveParent(ctx, d.Config.ParentProject); err != nil {
+			return err
+		}
+	}
+
+	if d.Config.Uploads != nil {
+		defer d.Config.Uploads.Close()
+
+		stop := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runReconciler(ctx, d.Config, d.client, stop)
+		}()
+		defer func() {
+			close(stop)
+			wg.Wait()
+		}()
+	}
+
+	if err := d.Uploader.Upload(ctx, d.Config, d.client, iter); err != nil {
+		return err
+	}
+
+	if d.Config.WaitTimeout > 0 {
+		processed, failed := d.client.ProcessingResults()
+		logger.LogInfo(ctx.Context, "processing", "processed", processed, "failed", failed)
+		if failed > 0 {
+			return fmt.Errorf("%w: Dependency-Track failed to process %d of the uploaded SBOMs", report.ErrRejectedByDestination, failed)
+		}
+	}
+	return nil
 }
 
 func (d *DependencyTrackAdapter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
 	reporter := NewDependencyTrackReporter(d.Config.APIURL, d.Config.ProjectName, d.Config.ProjectVersion)
+	reporter.serverVersion = d.serverVersion
+	reporter.unsupportedFeatures = d.unsupportedFeatures
+	reporter.aggregateInto = d.Config.AggregateInto
+	reporter.nameTemplate = d.Config.NameTemplate
+	reporter.parentProject = d.Config.ParentProject
+	reporter.projectTags = d.Config.ProjectTags
+	reporter.classifier = d.Config.Classifier
+	reporter.waitTimeout = d.Config.WaitTimeout
+	reporter.client = d.client
+	reporter.overwrite = d.Config.Overwrite
+	reporter.projectMapping = d.Config.ProjectMapping
+	if d.Config.Hierarchy != nil {
+		d.Config.Hierarchy.Print()
+	}
 	return reporter.DryRun(ctx, iter)
 }
@@ -0,0 +1,92 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencytrack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer is a Dependency-Track server answering its health and version checks
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/version":
+			fmt.Fprint(w, `{"version":"4.12.0"}`)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	viper.Set("DTRACK_API_KEY", "token")
+	t.Cleanup(func() { viper.Set("DTRACK_API_KEY", "") })
+	return server
+}
+
+// parseFlags returns the adapter configured from the given flags, and the validation error
+func parseFlags(t *testing.T, args ...string) (*DependencyTrackAdapter, error) {
+	t.Helper()
+	adapter := &DependencyTrackAdapter{Role: types.OutputAdapterRole, ProcessingMode: types.FetchSequential}
+	cmd := &cobra.Command{Use: "transfer"}
+	cmd.Flags().String("output-adapter", "dtrack", "")
+	adapter.AddCommandParams(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetContext(context.Background())
+	return adapter, adapter.ParseAndValidateParams(cmd)
+}
+
+func TestProjectMappingFlags(t *testing.T) {
+	server := newServer(t)
+	mapping := filepath.Join(t.TempDir(), "mapping.yaml")
+	require.NoError(t, os.WriteFile(mapping, []byte("projects:\n  - namespace: interlynk-io/sbommv\n    uuid: 4f2b5a7e-0b1c-4c7e-9a51-2d7f4e8c1a90\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		args    []string
+		invalid string
+	}{
+		{"mapping alone", nil, ""},
+		{"auto-create disabled", []string{"--out-dtrack-auto-create=false"}, ""},
+		{"auto-create", []string{"--out-dtrack-auto-create"}, "--out-dtrack-project-mapping and --out-dtrack-auto-create are mutually exclusive"},
+		{"project name", []string{"--out-dtrack-project-name=app"}, "--out-dtrack-project-mapping and --out-dtrack-project-name are mutually exclusive"},
+		{"parent project", []string{"--out-dtrack-parent-project=platform"}, "--out-dtrack-project-mapping and --out-dtrack-parent-project are mutually exclusive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"--out-dtrack-url=" + server.URL, "--out-dtrack-project-mapping=" + mapping}, tt.args...)
+			adapter, err := parseFlags(t, args...)
+			if tt.invalid != "" {
+				assert.ErrorContains(t, err, tt.invalid)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, adapter.Config.ProjectMapping)
+			assert.False(t, adapter.Config.AutoCreate)
+		})
+	}
+}
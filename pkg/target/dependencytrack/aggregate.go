@@ -0,0 +1,108 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencytrack
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/interlynk-io/sbommv/pkg/converter"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/timing"
+)
+
+// AggregatingUploader merges every SBOM of the transfer into one BOM, deduplicating their
+// components, and uploads it to a single project.
+type AggregatingUploader struct {
+	project string
+}
+
+// NewAggregatingUploader returns an uploader aggregating all SBOMs into the given project
+func NewAggregatingUploader(project string) *AggregatingUploader {
+	return &AggregatingUploader{project: project}
+}
+
+// Upload implements the SBOMUploader interface for AggregatingUploader. The aggregated BOM
+// replaces the project's BOM on every run, whatever --overwrite is set to.
+func (u *AggregatingUploader) Upload(ctx tcontext.TransferMetadata, config *DependencyTrackConfig, client *DependencyTrackClient, iter iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Initializing SBOMs aggregation for Dependency-Track", "project", u.project)
+
+	projectVersion := "latest"
+	if config.ProjectVersion != "" {
+		projectVersion = config.ProjectVersion
+	}
+
+	var sboms []*iterator.SBOM
+	for {
+		sbom, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("aggregation interrupted after %d SBOMs: %w", len(sboms), ctx.Err())
+		}
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Next: failed to get next SBOM continuing", "error", err)
+			continue
+		}
+		sboms = append(sboms, sbom)
+	}
+
+	if len(sboms) == 0 {
+		logger.LogInfo(ctx.Context, "No SBOMs to aggregate", "project", u.project)
+		return nil
+	}
+
+	docs := make([][]byte, 0, len(sboms))
+	for _, s := range sboms {
+		docs = append(docs, s.Data)
+	}
+
+	targetVersion := converter.CycloneDXTargetVersionFromContext(ctx)
+	aggregated, err := sbom.MergeCycloneDX(u.project, projectVersion, docs, targetVersion)
+	if err != nil {
+		u.recordFailures(ctx, report.StageConvert, sboms, projectVersion, err)
+		return fmt.Errorf("aggregating %d SBOMs: %w", len(sboms), err)
+	}
+	logger.LogDebug(ctx.Context, "Aggregated SBOMs", "sboms", len(sboms), "size", len(aggregated), "spec_version", targetVersion)
+
+	if _, err := client.FindOrCreateProject(ctx, u.project, projectVersion); err != nil {
+		u.recordFailures(ctx, report.StageProject, sboms, projectVersion, err)
+		return fmt.Errorf("creating aggregate project %s: %w", u.project, err)
+	}
+
+	err = timing.Time(ctx, timing.StageUpload, u.project, func() error {
+		return client.UploadSBOM(ctx, u.project, projectVersion, aggregated)
+	})
+	if err != nil {
+		u.recordFailures(ctx, report.StageUpload, sboms, projectVersion, err)
+		return fmt.Errorf("uploading aggregated SBOM to %s: %w", u.project, err)
+	}
+
+	report.RecordTransfer(ctx, &iterator.SBOM{Namespace: u.project, Data: aggregated}, u.project+"@"+projectVersion)
+	logger.LogInfo(ctx.Context, "upload", "success", true, "project", u.project, "version", projectVersion, "aggregated_sboms", len(sboms))
+	return nil
+}
+
+// recordFailures records every aggregated SBOM as failed, as none of them reached the project
+func (u *AggregatingUploader) recordFailures(ctx tcontext.TransferMetadata, stage string, sboms []*iterator.SBOM, projectVersion string, err error) {
+	for _, s := range sboms {
+		recordFailure(ctx, stage, s, u.project, projectVersion, err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencytrack
+
+import (
+	"fmt"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
+
+// Cleanup deletes, or deactivates, the projects created by the run opts.RunID.
+// Projects that existed before the run are never touched, even when the run uploaded to them.
+func (d *DependencyTrackAdapter) Cleanup(ctx tcontext.TransferMetadata, opts types.CleanupOptions) error {
+	projects, err := d.client.ProjectsCreatedByRun(ctx, opts.RunID)
+	if err != nil {
+		return err
+	}
+
+	action := "Deleting"
+	if opts.Deactivate {
+		action = "Deactivating"
+	}
+
+	fmt.Println()
+	fmt.Printf("📦 Dependency-Track URL: %s\n", d.Config.APIURL)
+	fmt.Printf("📌 Projects created by run %s: %d\n", opts.RunID, len(projects))
+	if opts.DryRun {
+		for _, project := range projects {
+			fmt.Printf("   - %s@%s (%s)\n", project.Name, project.Version, project.UUID)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	failed := 0
+	for _, project := range projects {
+		logger.LogDebug(ctx.Context, action+" project", "project", project.Name, "version", project.Version, "uuid", project.UUID)
+
+		if opts.Deactivate {
+			err = d.client.DeactivateProject(ctx, project)
+		} else {
+			err = d.client.DeleteProject(ctx, project)
+		}
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Failed to clean up project", "project", project.Name, "version", project.Version)
+			failed++
+			continue
+		}
+		logger.LogInfo(ctx.Context, "cleanup", "project", project.Name, "version", project.Version, "deactivated", opts.Deactivate)
+	}
+
+	logger.LogInfo(ctx.Context, "cleanup", "run_id", opts.RunID, "projects", len(projects), "success", len(projects)-failed, "failed", failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to clean up %d of %d projects", failed, len(projects))
+	}
+	return nil
+}
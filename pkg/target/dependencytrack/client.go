@@ -17,24 +17,90 @@ package dependencytrack
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
+	"github.com/interlynk-io/sbommv/pkg/limiter"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/retry"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 )
 
+const (
+	// propertyGroup is the Dependency-Track project property group used by sbommv
+	propertyGroup = "sbommv"
+
+	// runIDProperty names the run ID both as a project property and as a CycloneDX property
+	runIDProperty = "run-id"
+
+	// contentHashProperty names the project property holding the SHA-256 of the last SBOM
+	// sbommv uploaded to it, so that --overwrite re-uploads only SBOMs that changed
+	contentHashProperty = "content-hash"
+
+	// runTagPrefix prefixes the tag marking projects created by a run, see RunTag
+	runTagPrefix = "sbommv-run-"
+)
+
+// RunTag is the tag set on projects created by the given run. Unlike the run ID property,
+// which every upload overwrites, it is only set at creation, so cleanup never touches
+// projects that merely received SBOMs from the run.
+func RunTag(runID string) string {
+	return strings.ToLower(runTagPrefix + runID)
+}
+
+// creationTags returns the tags of projects created by sbommv in this run, along with
+// the extra tags of the SBOM the project is created for
+func creationTags(ctx tcontext.TransferMetadata, extra ...string) []dtrack.Tag {
+	tags := []dtrack.Tag{{Name: "sbommv"}}
+	// one tag per input adapter, e.g. "folder,s3" for a transfer with several
+	if sourceAdapter := ctx.SourceAdapter(); sourceAdapter != "" {
+		for _, name := range strings.Split(sourceAdapter, ",") {
+			tags = append(tags, dtrack.Tag{Name: name})
+		}
+	}
+	if runID := ctx.RunID(); runID != "" {
+		tags = append(tags, dtrack.Tag{Name: RunTag(runID)})
+	}
+	for _, tag := range extra {
+		tags = append(tags, dtrack.Tag{Name: tag})
+	}
+	return tags
+}
+
 type DependencyTrackClient struct {
 	Client *dtrack.Client
+
+	// set on the projects created for SBOMs, from --out-dtrack-parent-project,
+	// --out-dtrack-project-tags and --out-dtrack-classifier
+	parent      *dtrack.ParentRef
+	projectTags []string
+	classifier  string
+
+	// with --out-dtrack-wait, how long to wait for each uploaded BOM to be processed,
+	// and how many were processed or failed to
+	waitTimeout      time.Duration
+	processed        atomic.Int64
+	processingFailed atomic.Int64
+
+	runIDMu       sync.Mutex
+	runIDProjects map[string]bool // projects already tagged with the current run ID
 }
 
 func NewDependencyTrackClient(config *DependencyTrackConfig) (*DependencyTrackClient, error) {
 	client, err := dtrack.NewClient(
 		config.APIURL,
+		// the API key option wraps the transport, so the client goes first
+		dtrack.WithHttpClient(&http.Client{Timeout: 30 * time.Second, Transport: &retry.Transport{}}),
 		dtrack.WithAPIKey(config.APIKey),
-		dtrack.WithTimeout(30*time.Second),
 	)
 	if err != nil {
 		logger.LogError(context.Background(), err, "Failed to create Dependency-Track client")
@@ -47,7 +113,13 @@ func NewDependencyTrackClient(config *DependencyTrackConfig) (*DependencyTrackCl
 		return nil, fmt.Errorf("failed to create Dependency-Track client: %w", err)
 	}
 
-	return &DependencyTrackClient{Client: client}, nil
+	return &DependencyTrackClient{
+		Client:        client,
+		projectTags:   config.ProjectTags,
+		classifier:    config.Classifier,
+		waitTimeout:   config.WaitTimeout,
+		runIDProjects: make(map[string]bool),
+	}, nil
 }
 
 type Project struct {
@@ -91,21 +163,160 @@ func (c *DependencyTrackClient) UploadSBOM(ctx tcontext.TransferMetadata, projec
 	bomReq := dtrack.BOMUploadRequest{
 		ProjectName:    projectName,
 		ProjectVersion: projectVersion,
-		BOM:            base64.StdEncoding.EncodeToString(sbomData),
+		BOM:            base64.StdEncoding.EncodeToString(withRunIDProperty(ctx, sbomData)),
 	}
 
 	// dtrack client will upload SBOM
-	token, err := c.Client.BOM.Upload(ctx.Context, bomReq)
+	var token dtrack.BOMUploadToken
+	uploadedAt := time.Now()
+	err := retry.Do(ctx, projectName+"@"+projectVersion, func() error {
+		return limiter.Transfer(ctx, func() error {
+			var uploadErr error
+			token, uploadErr = c.Client.BOM.Upload(ctx.Context, bomReq)
+			return withStatus(uploadErr)
+		})
+	})
 	if err != nil {
 		return err
 	}
 
 	logger.LogDebug(ctx.Context, "SBOM uploaded successfully", "project", projectName, "token", token)
+	c.tagProjectWithRunID(ctx, projectName, projectVersion)
+	if err := c.awaitProcessing(ctx, projectName, projectVersion, token, uploadedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UploadSBOMWithAutoCreate uploads an SBOM and lets Dependency-Track create the project
+// when it doesn't exist yet, saving the separate lookup and creation calls. Extra tags are
+// set on a project created this way.
+func (c *DependencyTrackClient) UploadSBOMWithAutoCreate(ctx tcontext.TransferMetadata, projectName, projectVersion string, sbomData []byte, tags ...string) error {
+	logger.LogDebug(ctx.Context, "Processing Uploading SBOMs with auto-create", "project", projectName, "version", projectVersion)
+
+	bomReq := dtrack.BOMUploadRequest{
+		ProjectName:    projectName,
+		ProjectVersion: projectVersion,
+		ProjectTags:    creationTags(ctx, slices.Concat(c.projectTags, tags)...),
+		AutoCreate:     true,
+		BOM:            base64.StdEncoding.EncodeToString(withRunIDProperty(ctx, sbomData)),
+	}
+	if c.parent != nil {
+		bomReq.ParentUUID = &c.parent.UUID
+	}
+
+	var token dtrack.BOMUploadToken
+	uploadedAt := time.Now()
+	err := retry.Do(ctx, projectName+"@"+projectVersion, func() error {
+		return limiter.Transfer(ctx, func() error {
+			var uploadErr error
+			token, uploadErr = c.Client.BOM.Upload(ctx.Context, bomReq)
+			return withStatus(uploadErr)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.LogDebug(ctx.Context, "SBOM uploaded successfully with auto-create", "project", projectName, "token", token)
+	c.tagProjectWithRunID(ctx, projectName, projectVersion)
+	c.classifyCreatedProject(ctx, projectName, projectVersion)
+	if err := c.awaitProcessing(ctx, projectName, projectVersion, token, uploadedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// classifyCreatedProject sets the classifier of a project Dependency-Track created during this
+// run's upload, as BOM uploads can't set it. Failures are logged only.
+func (c *DependencyTrackClient) classifyCreatedProject(ctx tcontext.TransferMetadata, projectName, projectVersion string) {
+	runID := ctx.RunID()
+	if c.classifier == "" || runID == "" {
+		return
+	}
+
+	project, err := c.LookupProject(ctx, projectName, projectVersion)
+	if err != nil || project == nil {
+		logger.LogDebug(ctx.Context, "Unable to look up project to set its classifier", "project", projectName, "error", err)
+		return
+	}
+	if project.Classifier == c.classifier || !slices.ContainsFunc(project.Tags, func(tag dtrack.Tag) bool { return tag.Name == RunTag(runID) }) {
+		return
+	}
+
+	project.Classifier = c.classifier
+	if _, err := c.Client.Project.Update(ctx.Context, *project); err != nil {
+		logger.LogDebug(ctx.Context, "Failed to set project classifier", "project", projectName, "classifier", c.classifier, "error", err)
+	}
+}
+
+// ResolveParent looks up the parent project of the projects created for SBOMs, given as name
+// or name@version, creating it when it doesn't exist yet.
+func (c *DependencyTrackClient) ResolveParent(ctx tcontext.TransferMetadata, ref string) error {
+	name, version := ParseProjectRef(ref)
+
+	project, err := c.LookupProject(ctx, name, version)
+	if err != nil {
+		return fmt.Errorf("looking up parent project %s@%s: %w", name, version, err)
+	}
+
+	if project == nil {
+		created, err := c.createProject(ctx, name, version, nil, "")
+		if err != nil {
+			return fmt.Errorf("creating parent project %s@%s: %w", name, version, err)
+		}
+		project = &created
+		logger.LogInfo(ctx.Context, "parent", "project", name, "version", version, "created", true)
+	}
+
+	c.parent = &dtrack.ParentRef{UUID: project.UUID}
+	logger.LogDebug(ctx.Context, "Parent project resolved", "project", name, "version", version, "uuid", project.UUID)
 	return nil
 }
 
-// FindOrCreateProject ensures a project exists, returning its UUID after finding or creating project
-func (c *DependencyTrackClient) FindOrCreateProject(ctx tcontext.TransferMetadata, finalProjectName, projectVersion string) (string, error) {
+// ParseProjectRef splits a project reference of the form name or name@version, the version
+// defaulting to "latest"
+func ParseProjectRef(ref string) (string, string) {
+	if i := strings.LastIndex(ref, "@"); i > 0 && i < len(ref)-1 {
+		return ref[:i], ref[i+1:]
+	}
+	return strings.TrimSuffix(ref, "@"), "latest"
+}
+
+// LookupProject returns the project matching name and version, or nil when it doesn't exist.
+func (c *DependencyTrackClient) LookupProject(ctx tcontext.TransferMetadata, projectName, projectVersion string) (*dtrack.Project, error) {
+	project, err := c.Client.Project.Lookup(ctx.Context, projectName, projectVersion)
+	if err != nil {
+		var apiErr *dtrack.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+// withStatus exposes the HTTP status of a Dependency-Track API error to the retry policy
+func withStatus(err error) error {
+	var apiErr *dtrack.APIError
+	if errors.As(err, &apiErr) {
+		return &retry.HTTPError{StatusCode: apiErr.StatusCode, Err: err}
+	}
+	return err
+}
+
+// isPermissionError reports whether err is an authorization failure returned by Dependency-Track
+func isPermissionError(err error) bool {
+	var apiErr *dtrack.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// FindOrCreateProject ensures a project exists, returning its UUID after finding or creating project.
+// Extra tags are set on the project when it is created.
+func (c *DependencyTrackClient) FindOrCreateProject(ctx tcontext.TransferMetadata, finalProjectName, projectVersion string, tags ...string) (string, error) {
 	logger.LogDebug(ctx.Context, "Processing finding or Creating Project", "project", finalProjectName, "version", projectVersion)
 
 	// find project using project name and project version
@@ -120,38 +331,184 @@ func (c *DependencyTrackClient) FindOrCreateProject(ctx tcontext.TransferMetadat
 	logger.LogDebug(ctx.Context, "New project will be created", "name", finalProjectName, "version", projectVersion)
 
 	// create project using project name and project version
-	return c.CreateProject(ctx, finalProjectName, projectVersion)
+	return c.CreateProject(ctx, finalProjectName, projectVersion, tags...)
 }
 
 // CreateProject creates a new project if it doesn’t exist
-func (c *DependencyTrackClient) CreateProject(ctx tcontext.TransferMetadata, finalProjectName, projectVersion string) (string, error) {
-	logger.LogDebug(ctx.Context, "Initializing Project Creation", "project", finalProjectName, "version", projectVersion)
+func (c *DependencyTrackClient) CreateProject(ctx tcontext.TransferMetadata, finalProjectName, projectVersion string, tags ...string) (string, error) {
+	created, err := c.createProject(ctx, finalProjectName, projectVersion, c.parent, c.classifier, slices.Concat(c.projectTags, tags)...)
+	if err != nil {
+		return "", err
+	}
+	return created.UUID.String(), nil
+}
 
-	sourceAdapter := ctx.Value("source")
+// createProject creates a project, as a child of parent and with the classifier when they are set
+func (c *DependencyTrackClient) createProject(ctx tcontext.TransferMetadata, finalProjectName, projectVersion string, parent *dtrack.ParentRef, classifier string, tags ...string) (dtrack.Project, error) {
+	logger.LogDebug(ctx.Context, "Initializing Project Creation", "project", finalProjectName, "version", projectVersion)
 
 	active := true
 	description := "Created & uploaded by sbommv"
-	sbommvTag := "sbommv"
-	sourceTag := sourceAdapter.(string)
 
 	project := dtrack.Project{
 		Name:        finalProjectName,
 		Version:     projectVersion,
 		Active:      active,
 		Description: description,
-		Tags: []dtrack.Tag{
-			{Name: sbommvTag},
-			{Name: sourceTag},
-		},
+		Tags:        creationTags(ctx, tags...),
+		ParentRef:   parent,
+		Classifier:  classifier,
 	}
-	logger.LogDebug(ctx.Context, "Project is created with following parameters", "name", finalProjectName, "version", projectVersion, "active", active, "description", description, "tag1", sbommvTag, "tag2", sourceTag)
+	logger.LogDebug(ctx.Context, "Project is created with following parameters", "name", finalProjectName, "version", projectVersion, "active", active, "description", description, "tags", project.Tags, "classifier", classifier)
 
 	// dtrack client will create a new project
 	created, err := c.Client.Project.Create(ctx.Context, project)
 	if err != nil {
-		return "", err
+		return dtrack.Project{}, err
 	}
 
 	logger.LogDebug(ctx.Context, "New Project created", "project", created.Name, "version", created.Version, "uuid", created.UUID)
-	return created.UUID.String(), nil
+	return created, nil
+}
+
+// withRunIDProperty records the run ID as a CycloneDX metadata property, returning the
+// original content when there is no run ID or the SBOM can't be updated.
+func withRunIDProperty(ctx tcontext.TransferMetadata, data []byte) []byte {
+	runID := ctx.RunID()
+	if runID == "" {
+		return data
+	}
+
+	stamped, err := sbom.AddCycloneDXProperty(data, propertyGroup+":"+runIDProperty, runID)
+	if err != nil {
+		logger.LogDebug(ctx.Context, "Failed to add run ID property to SBOM, uploading as is", "error", err)
+		return data
+	}
+	return stamped
+}
+
+// tagProjectWithRunID sets the run ID as a property of the project, once per project and run.
+// Failures are logged only, since the property is informational.
+func (c *DependencyTrackClient) tagProjectWithRunID(ctx tcontext.TransferMetadata, projectName, projectVersion string) {
+	runID := ctx.RunID()
+	if runID == "" {
+		return
+	}
+
+	key := projectName + "@" + projectVersion
+	c.runIDMu.Lock()
+	if c.runIDProjects[key] {
+		c.runIDMu.Unlock()
+		return
+	}
+	c.runIDProjects[key] = true
+	c.runIDMu.Unlock()
+
+	project, err := c.LookupProject(ctx, projectName, projectVersion)
+	if err != nil || project == nil {
+		logger.LogDebug(ctx.Context, "Unable to look up project for run ID property", "project", projectName, "error", err)
+		return
+	}
+
+	if err := c.setProjectProperty(ctx, project.UUID, runIDProperty, runID); err != nil {
+		logger.LogDebug(ctx.Context, "Failed to set run ID project property", "project", projectName, "error", err)
+		return
+	}
+
+	logger.LogDebug(ctx.Context, "Run ID recorded as project property", "project", projectName, "run_id", runID)
+}
+
+// ContentHash returns the content hash of the last SBOM sbommv uploaded to the project,
+// or "" when the project doesn't exist or has no hash recorded.
+func (c *DependencyTrackClient) ContentHash(ctx tcontext.TransferMetadata, projectName, projectVersion string) (string, error) {
+	project, err := c.LookupProject(ctx, projectName, projectVersion)
+	if err != nil || project == nil {
+		return "", err
+	}
+
+	properties, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.ProjectProperty], error) {
+		return c.Client.ProjectProperty.GetAll(ctx.Context, project.UUID, po)
+	})
+	if err != nil {
+		return "", fmt.Errorf("listing properties of project %s@%s: %w", projectName, projectVersion, err)
+	}
+
+	for _, property := range properties {
+		if property.Group == propertyGroup && property.Name == contentHashProperty {
+			return property.Value, nil
+		}
+	}
+	return "", nil
+}
+
+// RecordContentHash sets the content hash of the SBOM just uploaded as a property of the
+// project. The hash is iterator.SBOM.SourceHash, of the SBOM as fetched, since conversion
+// regenerates serial numbers and timestamps and would change it on every run. Failures are
+// logged only, the next --overwrite run then uploads it again.
+func (c *DependencyTrackClient) RecordContentHash(ctx tcontext.TransferMetadata, projectName, projectVersion, hash string) {
+	project, err := c.LookupProject(ctx, projectName, projectVersion)
+	if err != nil || project == nil {
+		logger.LogDebug(ctx.Context, "Unable to look up project for content hash property", "project", projectName, "error", err)
+		return
+	}
+
+	if err := c.setProjectProperty(ctx, project.UUID, contentHashProperty, hash); err != nil {
+		logger.LogDebug(ctx.Context, "Failed to set content hash project property", "project", projectName, "error", err)
+		return
+	}
+	logger.LogDebug(ctx.Context, "Content hash recorded as project property", "project", projectName, "hash", hash)
+}
+
+// setProjectProperty sets a string property of the sbommv group on the project,
+// updating it when it already exists
+func (c *DependencyTrackClient) setProjectProperty(ctx tcontext.TransferMetadata, projectUUID uuid.UUID, name, value string) error {
+	property := dtrack.ProjectProperty{
+		Group: propertyGroup,
+		Name:  name,
+		Value: value,
+		Type:  "STRING",
+	}
+
+	_, err := c.Client.ProjectProperty.Create(ctx.Context, projectUUID, property)
+	var apiErr *dtrack.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		_, err = c.Client.ProjectProperty.Update(ctx.Context, projectUUID, property)
+	}
+	return err
+}
+
+// ProjectsCreatedByRun returns the projects tagged as created by the given run
+func (c *DependencyTrackClient) ProjectsCreatedByRun(ctx tcontext.TransferMetadata, runID string) ([]dtrack.Project, error) {
+	tag := RunTag(runID)
+	logger.LogDebug(ctx.Context, "Looking up projects created by run", "run_id", runID, "tag", tag)
+
+	projects, err := dtrack.FetchAll(func(po dtrack.PageOptions) (dtrack.Page[dtrack.Project], error) {
+		return c.Client.Project.GetAllByTag(ctx.Context, tag, false, false, po)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing projects tagged %s: %w", tag, err)
+	}
+	return projects, nil
+}
+
+// DeactivateProject marks the project inactive, keeping its data
+func (c *DependencyTrackClient) DeactivateProject(ctx tcontext.TransferMetadata, project dtrack.Project) error {
+	current, err := c.Client.Project.Get(ctx.Context, project.UUID)
+	if err != nil {
+		return fmt.Errorf("fetching project %s: %w", project.UUID, err)
+	}
+
+	current.Active = false
+	if _, err := c.Client.Project.Update(ctx.Context, current); err != nil {
+		return fmt.Errorf("deactivating project %s: %w", project.UUID, err)
+	}
+	return nil
+}
+
+// DeleteProject deletes the project along with its components and findings
+func (c *DependencyTrackClient) DeleteProject(ctx tcontext.TransferMetadata, project dtrack.Project) error {
+	if err := c.Client.Project.Delete(ctx.Context, project.UUID); err != nil {
+		return fmt.Errorf("deleting project %s: %w", project.UUID, err)
+	}
+	return nil
 }
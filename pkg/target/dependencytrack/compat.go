@@ -0,0 +1,142 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencytrack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+)
+
+// serverFeature is a Dependency-Track feature sbommv relies on, available from minVersion
+type serverFeature struct {
+	name       string
+	option     string // flag enabling the feature, empty when always used
+	minVersion string
+	enabled    func(config *DependencyTrackConfig) bool
+}
+
+// serverFeatures lists the features sbommv may use with the Dependency-Track version introducing them
+var serverFeatures = []serverFeature{
+	{
+		name:       "BOM upload by project name and version",
+		minVersion: "4.0.0",
+		enabled:    func(*DependencyTrackConfig) bool { return true },
+	},
+	{
+		name:       "parent projects",
+		option:     "--out-dtrack-hierarchy",
+		minVersion: "4.7.0",
+		enabled:    func(config *DependencyTrackConfig) bool { return config.Hierarchy != nil },
+	},
+	{
+		name:       "parent projects",
+		option:     "--out-dtrack-parent-project",
+		minVersion: "4.7.0",
+		enabled:    func(config *DependencyTrackConfig) bool { return config.ParentProject != "" },
+	},
+	{
+		name:       "parent projects of auto-created projects",
+		option:     "--out-dtrack-parent-project with --out-dtrack-auto-create",
+		minVersion: "4.8.0",
+		enabled:    func(config *DependencyTrackConfig) bool { return config.ParentProject != "" && config.AutoCreate },
+	},
+}
+
+// ServerVersion returns the version of the Dependency-Track server, from /api/version
+func (c *DependencyTrackClient) ServerVersion(ctx context.Context) (string, error) {
+	about, err := c.Client.About.Get(ctx)
+	if err != nil {
+		return "", fmt.Errorf("fetching Dependency-Track version: %w", err)
+	}
+	return about.Version, nil
+}
+
+// unsupportedFeatures returns the features in use by config the server version doesn't
+// support yet, as "<feature> (<option>) needs Dependency-Track <version>"
+func unsupportedFeatures(serverVersion string, config *DependencyTrackConfig) []string {
+	var unsupported []string
+	for _, feature := range serverFeatures {
+		if !feature.enabled(config) || compareVersions(serverVersion, feature.minVersion) >= 0 {
+			continue
+		}
+
+		name := feature.name
+		if feature.option != "" {
+			name = fmt.Sprintf("%s (%s)", feature.name, feature.option)
+		}
+		unsupported = append(unsupported, fmt.Sprintf("%s needs Dependency-Track %s", name, feature.minVersion))
+	}
+	return unsupported
+}
+
+// checkServerCompatibility warns about the configured features the server is too old for.
+// It returns the server version and those features; failing to read the version only logs.
+func checkServerCompatibility(ctx context.Context, client *DependencyTrackClient, config *DependencyTrackConfig) (string, []string) {
+	version, err := client.ServerVersion(ctx)
+	if err != nil {
+		logger.LogDebug(ctx, "Unable to check Dependency-Track server compatibility", "error", err)
+		return "", nil
+	}
+
+	unsupported := unsupportedFeatures(version, config)
+	for _, feature := range unsupported {
+		logger.LogInfo(ctx, "Dependency-Track server too old, option would be ignored", "server_version", version, "feature", feature)
+	}
+	logger.LogDebug(ctx, "Dependency-Track server version", "version", version, "unsupported_features", len(unsupported))
+	return version, unsupported
+}
+
+// compareVersions compares dotted numeric versions such as "4.11.4", ignoring pre-release
+// suffixes ("4.12.0-SNAPSHOT" is 4.12.0). It returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	var parts []int
+	for _, field := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
@@ -17,14 +17,30 @@ package dependencytrack
 import (
 	"encoding/json"
 	"fmt"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/utils"
 )
 
 type DependencyTrackConfig struct {
 	APIURL         string
 	APIKey         string
 	ProjectName    string
-	ProjectVersion string // Added field for project version
+	ProjectVersion string                     // Added field for project version
+	NameTemplate   *utils.ProjectNameTemplate // names each SBOM's project, nil keeps name-version naming
 	Overwrite      bool
+	AutoCreate     bool            // let the BOM upload create missing projects
+	Hierarchy      *Hierarchy      // projects created, parents first, before uploading
+	AggregateInto  string          // project all SBOMs are merged into, instead of one project per SBOM
+	ParentProject  string          // parent of the projects created for SBOMs, as name or name@version
+	ProjectTags    []string        // tags set on the projects created for SBOMs
+	Classifier     string          // classifier of the projects created for SBOMs, e.g. APPLICATION
+	WaitTimeout    time.Duration   // with --out-dtrack-wait, how long to wait for each BOM to be processed
+	ProjectMapping *ProjectMapping // existing projects SBOMs are uploaded to, nil creates projects as needed
+
+	// daemon mode: projects deleted on the server are restored from Uploads every ReconcileInterval
+	Uploads           *UploadCache
+	ReconcileInterval time.Duration
 }
 
 func NewDependencyTrackConfig(apiURL, version string, overwite bool) *DependencyTrackConfig {
@@ -39,8 +55,8 @@ func NewDependencyTrackConfig(apiURL, version string, overwite bool) *Dependency
 // The API key is masked for security
 func (c *DependencyTrackConfig) String() string {
 	apiKeyMasked := maskAPIKey(c.APIKey)
-	return fmt.Sprintf("{APIURL:%s APIKey:%s ProjectName:%s ProjectVersion:%s Overwrite:%t}",
-		c.APIURL, apiKeyMasked, c.ProjectName, c.ProjectVersion, c.Overwrite)
+	return fmt.Sprintf("{APIURL:%s APIKey:%s ProjectName:%s ProjectVersion:%s Overwrite:%t AutoCreate:%t}",
+		c.APIURL, apiKeyMasked, c.ProjectName, c.ProjectVersion, c.Overwrite, c.AutoCreate)
 }
 
 // MarshalJSON returns a JSON representation with masked API key
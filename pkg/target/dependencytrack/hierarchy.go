@@ -0,0 +1,245 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencytrack
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/google/uuid"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"sigs.k8s.io/yaml"
+)
+
+// dependsOnProperty names the project property recording the projects a project depends on
+const dependsOnProperty = "depends-on"
+
+// HierarchyProject is a project of the hierarchy mapping file. Parent and DependsOn refer
+// to other projects of the file, as name or name@version.
+type HierarchyProject struct {
+	Name      string   `json:"name"`
+	Version   string   `json:"version,omitempty"`
+	Parent    string   `json:"parent,omitempty"`
+	DependsOn []string `json:"depends_on,omitempty"`
+}
+
+// key identifies the project as name@version
+func (p HierarchyProject) key() string {
+	return p.Name + "@" + p.Version
+}
+
+// Hierarchy is the parent/child structure of Dependency-Track projects declared in a
+// mapping file, with its projects in creation order: parents before their children.
+type Hierarchy struct {
+	projects []HierarchyProject
+	parents  map[string]string   // project key -> parent key
+	deps     map[string][]string // project key -> keys of the projects it depends on
+}
+
+// LoadHierarchy reads and validates a hierarchy mapping file
+func LoadHierarchy(path string) (*Hierarchy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Projects []HierarchyProject `json:"projects"`
+	}
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(file.Projects) == 0 {
+		return nil, fmt.Errorf("%s declares no projects", path)
+	}
+
+	return newHierarchy(file.Projects)
+}
+
+func newHierarchy(projects []HierarchyProject) (*Hierarchy, error) {
+	byKey := make(map[string]HierarchyProject, len(projects))
+	byName := make(map[string][]string)
+	for i := range projects {
+		p := &projects[i]
+		if p.Name == "" {
+			return nil, fmt.Errorf("project %d has no name", i+1)
+		}
+		if p.Version == "" {
+			p.Version = "latest"
+		}
+		if _, dup := byKey[p.key()]; dup {
+			return nil, fmt.Errorf("project %s is declared twice", p.key())
+		}
+		byKey[p.key()] = *p
+		byName[p.Name] = append(byName[p.Name], p.key())
+	}
+
+	// resolve refers to a declared project by name@version, or by name when only one version is declared
+	resolve := func(ref string) (string, error) {
+		if _, ok := byKey[ref]; ok {
+			return ref, nil
+		}
+		switch keys := byName[ref]; len(keys) {
+		case 0:
+			return "", fmt.Errorf("%q is not a declared project", ref)
+		case 1:
+			return keys[0], nil
+		default:
+			return "", fmt.Errorf("%q is ambiguous, use one of %s", ref, strings.Join(keys, ", "))
+		}
+	}
+
+	h := &Hierarchy{parents: map[string]string{}, deps: map[string][]string{}}
+	for _, p := range projects {
+		if p.Parent != "" {
+			parent, err := resolve(p.Parent)
+			if err != nil {
+				return nil, fmt.Errorf("parent of %s: %w", p.key(), err)
+			}
+			if parent == p.key() {
+				return nil, fmt.Errorf("project %s is its own parent", p.key())
+			}
+			h.parents[p.key()] = parent
+		}
+		for _, ref := range p.DependsOn {
+			dep, err := resolve(ref)
+			if err != nil {
+				return nil, fmt.Errorf("depends_on of %s: %w", p.key(), err)
+			}
+			h.deps[p.key()] = append(h.deps[p.key()], dep)
+		}
+	}
+
+	// order parents before children, keeping the file order otherwise
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var visit func(key string) error
+	visit = func(key string) error {
+		switch state[key] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("parent cycle through project %s", key)
+		}
+		state[key] = visiting
+		if parent, ok := h.parents[key]; ok {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		state[key] = done
+		h.projects = append(h.projects, byKey[key])
+		return nil
+	}
+	for _, p := range projects {
+		if err := visit(p.key()); err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// Ensure creates the projects of the hierarchy that don't exist yet, parents first, links
+// existing projects to their declared parent and records the projects each one depends on
+// as a project property, as Dependency-Track has no project-to-project dependencies.
+func (h *Hierarchy) Ensure(ctx tcontext.TransferMetadata, client *DependencyTrackClient) error {
+	uuids := make(map[string]uuid.UUID, len(h.projects))
+
+	for _, p := range h.projects {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var parentRef *dtrack.ParentRef
+		if parentKey, ok := h.parents[p.key()]; ok {
+			parentRef = &dtrack.ParentRef{UUID: uuids[parentKey]}
+		}
+
+		project, err := client.LookupProject(ctx, p.Name, p.Version)
+		if err != nil {
+			return fmt.Errorf("looking up project %s: %w", p.key(), err)
+		}
+
+		if project == nil {
+			created, err := client.createProject(ctx, p.Name, p.Version, parentRef, "")
+			if err != nil {
+				return fmt.Errorf("creating project %s: %w", p.key(), err)
+			}
+			uuids[p.key()] = created.UUID
+			logger.LogInfo(ctx.Context, "hierarchy", "project", p.Name, "version", p.Version, "parent", h.parents[p.key()], "created", true)
+			continue
+		}
+
+		uuids[p.key()] = project.UUID
+		if parentRef != nil && (project.ParentRef == nil || project.ParentRef.UUID != parentRef.UUID) {
+			project.ParentRef = parentRef
+			if _, err := client.Client.Project.Update(ctx.Context, *project); err != nil {
+				return fmt.Errorf("linking project %s to parent %s: %w", p.key(), h.parents[p.key()], err)
+			}
+			logger.LogInfo(ctx.Context, "hierarchy", "project", p.Name, "version", p.Version, "parent", h.parents[p.key()], "linked", true)
+		}
+	}
+
+	for _, p := range h.projects {
+		deps := h.deps[p.key()]
+		if len(deps) == 0 {
+			continue
+		}
+		if err := client.setProjectProperty(ctx, uuids[p.key()], dependsOnProperty, strings.Join(deps, ",")); err != nil {
+			logger.LogInfo(ctx.Context, "Failed to record project dependencies", "project", p.key(), "error", err)
+		}
+	}
+
+	logger.LogInfo(ctx.Context, "hierarchy", "projects", len(h.projects))
+	return nil
+}
+
+// Print writes the hierarchy as an indented tree, for dry runs
+func (h *Hierarchy) Print() {
+	children := map[string][]HierarchyProject{}
+	var roots []HierarchyProject
+	for _, p := range h.projects {
+		if parent, ok := h.parents[p.key()]; ok {
+			children[parent] = append(children[parent], p)
+			continue
+		}
+		roots = append(roots, p)
+	}
+
+	var printTree func(p HierarchyProject, depth int)
+	printTree = func(p HierarchyProject, depth int) {
+		line := fmt.Sprintf("%s- %s", strings.Repeat("   ", depth+1), p.key())
+		if deps := h.deps[p.key()]; len(deps) > 0 {
+			line += " (depends on " + strings.Join(deps, ", ") + ")"
+		}
+		fmt.Println(line)
+		for _, child := range children[p.key()] {
+			printTree(child, depth+1)
+		}
+	}
+
+	fmt.Println("🌳 Project hierarchy, created before uploading:")
+	for _, root := range roots {
+		printTree(root, 0)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencytrack
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrUnmappedSBOM is reported for SBOMs no entry of the project mapping file matches
+var ErrUnmappedSBOM = errors.New("no project mapping for SBOM")
+
+// ProjectMappingEntry maps the SBOMs of a namespace and/or primary component to an existing
+// project. Empty fields match any SBOM.
+type ProjectMappingEntry struct {
+	Namespace string `json:"namespace,omitempty"`
+	Component string `json:"component,omitempty"`
+	Version   string `json:"version,omitempty"` // version of the primary component
+	UUID      string `json:"uuid"`
+}
+
+// matches reports whether the entry applies to an SBOM of the namespace and primary component
+func (e ProjectMappingEntry) matches(namespace string, component sbomd.PrimaryComponent) bool {
+	if e.Namespace != "" && e.Namespace != namespace {
+		return false
+	}
+	if e.Component != "" && e.Component != component.Name {
+		return false
+	}
+	if e.Version != "" && e.Version != component.Version {
+		return false
+	}
+	return true
+}
+
+// mappedProject is the project a mapping entry points to, as found on the server
+type mappedProject struct {
+	name    string
+	version string
+	err     error
+}
+
+// ProjectMapping translates SBOMs to the existing projects they are uploaded to, read from
+// the --out-dtrack-project-mapping file. With a mapping, sbommv never creates projects.
+type ProjectMapping struct {
+	entries []ProjectMappingEntry
+
+	mu       sync.Mutex
+	projects map[string]mappedProject // project UUID -> name and version on the server
+}
+
+// LoadProjectMapping reads and validates a project mapping file
+func LoadProjectMapping(path string) (*ProjectMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Projects []ProjectMappingEntry `json:"projects"`
+	}
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(file.Projects) == 0 {
+		return nil, fmt.Errorf("%s maps no projects", path)
+	}
+
+	for i, entry := range file.Projects {
+		if entry.Namespace == "" && entry.Component == "" {
+			return nil, fmt.Errorf("mapping %d has neither a namespace nor a component", i+1)
+		}
+		if _, err := uuid.Parse(entry.UUID); err != nil {
+			return nil, fmt.Errorf("mapping %d has an invalid project uuid %q", i+1, entry.UUID)
+		}
+	}
+
+	return &ProjectMapping{entries: file.Projects, projects: map[string]mappedProject{}}, nil
+}
+
+// Resolve returns the name, version and UUID of the project the SBOM is mapped to, the
+// first entry of the file matching it. SBOMs no entry matches fail with ErrUnmappedSBOM.
+func (m *ProjectMapping) Resolve(ctx tcontext.TransferMetadata, client *DependencyTrackClient, sbom *iterator.SBOM) (string, string, string, error) {
+	component := sbomd.ExtractPrimaryComponentName(sbom.Data)
+
+	for _, entry := range m.entries {
+		if !entry.matches(sbom.Namespace, component) {
+			continue
+		}
+
+		project := m.project(ctx, client, entry.UUID)
+		if project.err != nil {
+			return "", "", "", project.err
+		}
+		logger.LogDebug(ctx.Context, "SBOM mapped to project", "file", sbom.Path, "namespace", sbom.Namespace, "component", component.Name, "project", project.name, "version", project.version, "uuid", entry.UUID)
+		return project.name, project.version, entry.UUID, nil
+	}
+
+	return "", "", "", fmt.Errorf("%w: namespace %q, primary component %q", ErrUnmappedSBOM, sbom.Namespace, strings.TrimSpace(component.Name+" "+component.Version))
+}
+
+// project looks the mapped project up on the server, once per run
+func (m *ProjectMapping) project(ctx tcontext.TransferMetadata, client *DependencyTrackClient, projectUUID string) mappedProject {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if project, ok := m.projects[projectUUID]; ok {
+		return project
+	}
+
+	found, err := client.Client.Project.Get(ctx.Context, uuid.MustParse(projectUUID))
+	if err != nil {
+		// a transient failure is looked up again for the next SBOM, a missing project isn't
+		project := mappedProject{err: fmt.Errorf("mapped project %s: %w", projectUUID, withStatus(err))}
+		if isNotFoundError(err) {
+			m.projects[projectUUID] = project
+		}
+		return project
+	}
+
+	project := mappedProject{name: found.Name, version: found.Version}
+	m.projects[projectUUID] = project
+	return project
+}
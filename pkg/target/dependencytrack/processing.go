@@ -0,0 +1,98 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencytrack
+
+import (
+	"fmt"
+	"time"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// processingPollInterval is how often the BOM processing token of an upload is checked
+var processingPollInterval = 2 * time.Second
+
+// ProcessingError is returned for an uploaded BOM that Dependency-Track failed to process
+// or didn't process in time, with --out-dtrack-wait
+type ProcessingError struct {
+	Project string
+	Version string
+	Token   dtrack.BOMUploadToken
+	Reason  string
+}
+
+func (e *ProcessingError) Error() string {
+	return fmt.Sprintf("Dependency-Track processing of BOM %s for project %s@%s failed: %s", e.Token, e.Project, e.Version, e.Reason)
+}
+
+// awaitProcessing waits, with --out-dtrack-wait, until Dependency-Track has processed the BOM
+// uploaded at the given time, and checks that the project imported it. Dependency-Track
+// drops BOMs it can't process without telling, so the project's last import is all there is
+// to tell a rejected BOM from a processed one.
+func (c *DependencyTrackClient) awaitProcessing(ctx tcontext.TransferMetadata, projectName, projectVersion string, token dtrack.BOMUploadToken, uploadedAt time.Time) error {
+	if c.waitTimeout == 0 || token == "" {
+		return nil
+	}
+
+	processingErr := func(reason string) error {
+		c.processingFailed.Add(1)
+		return &ProcessingError{Project: projectName, Version: projectVersion, Token: token, Reason: reason}
+	}
+
+	logger.LogDebug(ctx.Context, "Waiting for BOM processing", "project", projectName, "version", projectVersion, "token", token)
+	deadline := time.Now().Add(c.waitTimeout)
+	for {
+		processing, err := c.Client.BOM.IsBeingProcessed(ctx.Context, token)
+		if err != nil {
+			return fmt.Errorf("checking BOM processing status: %w", err)
+		}
+		if !processing {
+			break
+		}
+		if time.Now().After(deadline) {
+			return processingErr(fmt.Sprintf("still processing after %s", c.waitTimeout))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(processingPollInterval):
+		}
+	}
+
+	project, err := c.LookupProject(ctx, projectName, projectVersion)
+	if err != nil {
+		return fmt.Errorf("checking BOM import: %w", err)
+	}
+	if project == nil {
+		return processingErr("project no longer exists")
+	}
+	// the server's clock may differ from ours by a little
+	if time.UnixMilli(int64(project.LastBOMImport)).Before(uploadedAt.Add(-time.Minute)) {
+		return processingErr("BOM was not imported, check the Dependency-Track logs for the reason")
+	}
+
+	c.processed.Add(1)
+	logger.LogInfo(ctx.Context, "processed", "success", true, "project", projectName, "version", projectVersion, "components", project.Metrics.Components)
+	return nil
+}
+
+// ProcessingResults returns the number of uploaded BOMs Dependency-Track processed and failed
+// to process, with --out-dtrack-wait
+func (c *DependencyTrackClient) ProcessingResults() (processed, failed int64) {
+	return c.processed.Load(), c.processingFailed.Load()
+}
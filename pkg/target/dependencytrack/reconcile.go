@@ -0,0 +1,217 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencytrack
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	dtrack "github.com/DependencyTrack/client-go"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	_ "modernc.org/sqlite"
+)
+
+// UploadCachePath is the file recording the SBOMs uploaded to Dependency-Track in daemon mode
+func UploadCachePath() string {
+	return filepath.Join(".sbommv", "cache_dtrack_uploads.db")
+}
+
+const createUploadsTable string = `
+	CREATE TABLE IF NOT EXISTS uploads (
+		api_url TEXT,
+		project TEXT,
+		version TEXT,
+		data BLOB,
+		uploaded_at TEXT,
+		PRIMARY KEY (api_url, project, version)
+	);
+`
+
+// UploadCache keeps the last SBOM uploaded to each project of a Dependency-Track server, so
+// projects deleted on the server can be restored. A nil *UploadCache records nothing.
+type UploadCache struct {
+	mu     sync.Mutex
+	db     *sql.DB
+	apiURL string
+}
+
+// cachedUpload is the last SBOM uploaded to a project
+type cachedUpload struct {
+	Project string
+	Version string
+	Data    []byte
+}
+
+// OpenUploadCache opens, or creates, the upload cache of the server at apiURL
+func OpenUploadCache(ctx context.Context, apiURL string) (*UploadCache, error) {
+	path := UploadCachePath()
+	logger.LogDebug(ctx, "Initializing SQLite cache", "path", path)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := db.ExecContext(dbCtx, createUploadsTable); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+
+	return &UploadCache{db: db, apiURL: apiURL}, nil
+}
+
+// Record stores data as the last SBOM uploaded to the project. Failures are logged only,
+// as they only weaken reconciliation.
+func (c *UploadCache) Record(ctx tcontext.TransferMetadata, projectName, projectVersion string, data []byte) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := c.db.ExecContext(ctx.Context, `
+		INSERT INTO uploads (api_url, project, version, data, uploaded_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (api_url, project, version) DO UPDATE SET data = excluded.data, uploaded_at = excluded.uploaded_at`,
+		c.apiURL, projectName, projectVersion, data, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		logger.LogError(ctx.Context, err, "Failed to save upload to cache", "project", projectName, "version", projectVersion)
+	}
+}
+
+// uploads returns the cached uploads of the server
+func (c *UploadCache) uploads(ctx tcontext.TransferMetadata) ([]cachedUpload, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rows, err := c.db.QueryContext(ctx.Context, "SELECT project, version, data FROM uploads WHERE api_url = ? ORDER BY project, version", c.apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cached uploads: %w", err)
+	}
+	defer rows.Close()
+
+	var uploads []cachedUpload
+	for rows.Next() {
+		var u cachedUpload
+		if err := rows.Scan(&u.Project, &u.Version, &u.Data); err != nil {
+			return nil, fmt.Errorf("failed to load cached uploads: %w", err)
+		}
+		uploads = append(uploads, u)
+	}
+	return uploads, rows.Err()
+}
+
+// Close closes the cache database
+func (c *UploadCache) Close() error {
+	if c == nil {
+		return nil
+	}
+	return c.db.Close()
+}
+
+// runReconciler reconciles the server with the upload cache every config.ReconcileInterval
+// until stop is closed or the transfer is cancelled
+func runReconciler(ctx tcontext.TransferMetadata, config *DependencyTrackConfig, client *DependencyTrackClient, stop <-chan struct{}) {
+	logger.LogDebug(ctx.Context, "Started reconciling with Dependency-Track", "interval", config.ReconcileInterval)
+
+	ticker := time.NewTicker(config.ReconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := reconcile(ctx, config, client); err != nil && ctx.Err() == nil {
+				logger.LogError(ctx.Context, err, "Failed to reconcile with Dependency-Track")
+			}
+		}
+	}
+}
+
+// reconcile uploads again the cached SBOM of every project that no longer exists on the server
+func reconcile(ctx tcontext.TransferMetadata, config *DependencyTrackConfig, client *DependencyTrackClient) error {
+	uploads, err := config.Uploads.uploads(ctx)
+	if err != nil {
+		return err
+	}
+
+	restored, failed := 0, 0
+	for _, u := range uploads {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		project, err := client.LookupProject(ctx, u.Project, u.Version)
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Failed to look up project, skipping", "project", u.Project, "version", u.Version, "error", err)
+			failed++
+			continue
+		}
+		if project != nil {
+			continue
+		}
+
+		logger.LogInfo(ctx.Context, "Project deleted on the server, uploading the cached SBOM again", "project", u.Project, "version", u.Version)
+		if err := restoreProject(ctx, config, client, u); err != nil {
+			logger.LogError(ctx.Context, err, "Failed to restore project", "project", u.Project, "version", u.Version)
+			failed++
+			continue
+		}
+		restored++
+	}
+
+	logger.LogInfo(ctx.Context, "reconcile", "projects", len(uploads), "restored", restored, "failed", failed)
+	return nil
+}
+
+// restoreProject creates the project again and uploads its cached SBOM
+func restoreProject(ctx tcontext.TransferMetadata, config *DependencyTrackConfig, client *DependencyTrackClient, u cachedUpload) error {
+	if config.AutoCreate {
+		err := client.UploadSBOMWithAutoCreate(ctx, u.Project, u.Version, u.Data)
+		if err == nil || !isPermissionError(err) {
+			return err
+		}
+	}
+
+	if _, err := client.FindOrCreateProject(ctx, u.Project, u.Version); err != nil {
+		return fmt.Errorf("creating project: %w", err)
+	}
+	return client.UploadSBOM(ctx, u.Project, u.Version, u.Data)
+}
+
+// isNotFoundError reports whether err is a Dependency-Track 404, e.g. an upload to a
+// project deleted on the server
+func isNotFoundError(err error) bool {
+	var apiErr *dtrack.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
@@ -17,10 +17,12 @@ package dependencytrack
 import (
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
-	"github.com/interlynk-io/sbommv/pkg/sbom"
+	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 	"github.com/interlynk-io/sbommv/pkg/utils"
 )
@@ -29,6 +31,21 @@ type DependencyTrackReporter struct {
 	apiURL         string
 	projectName    string
 	projectVersion string
+
+	serverVersion       string
+	unsupportedFeatures []string
+	aggregateInto       string
+	nameTemplate        *utils.ProjectNameTemplate
+	parentProject       string
+	projectTags         []string
+	classifier          string
+	waitTimeout         time.Duration
+
+	// with a client, the dry run looks up every target project and reports whether it
+	// exists and what the upload would do to it
+	client         *DependencyTrackClient
+	overwrite      bool
+	projectMapping *ProjectMapping
 }
 
 func NewDependencyTrackReporter(apiURL, projectName, projectVersion string) *DependencyTrackReporter {
@@ -43,14 +60,37 @@ func (r *DependencyTrackReporter) DryRun(ctx tcontext.TransferMetadata, iter ite
 	logger.LogDebug(ctx.Context, "Dry-run mode: Simulating SBOM upload to Dependency-Track")
 	fmt.Println("\n📦 Dependency-Track Output Adapter Dry-Run")
 	fmt.Printf("📦 DTrack API Endpoint: %s\n", r.apiURL)
+	if r.serverVersion != "" {
+		fmt.Printf("📦 DTrack Server Version: %s\n", r.serverVersion)
+	}
+	if r.parentProject != "" {
+		fmt.Printf("📦 Parent Project: %s\n", r.parentProject)
+	}
+	if len(r.projectTags) > 0 {
+		fmt.Printf("📦 Project Tags: %s\n", strings.Join(r.projectTags, ", "))
+	}
+	if r.classifier != "" {
+		fmt.Printf("📦 Project Classifier: %s\n", r.classifier)
+	}
+	if r.waitTimeout > 0 {
+		fmt.Printf("📦 Wait for BOM Processing: up to %s per SBOM\n", r.waitTimeout)
+	}
+	for _, feature := range r.unsupportedFeatures {
+		fmt.Printf("⚠️  Would be ignored: %s\n", feature)
+	}
 	sbomCount := 0
+	preflight := utils.NewPreflight()
+	targeted := make(map[string]bool) // projects targeted by earlier SBOMs of the run
 
-	processor := sbom.NewSBOMProcessor("", false)
+	processor := sbomd.NewSBOMProcessor("", false)
 	for {
 		sbom, err := iter.Next(ctx)
 		if err == io.EOF {
 			break
 		}
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Error retrieving SBOM")
 			return err
@@ -63,15 +103,88 @@ func (r *DependencyTrackReporter) DryRun(ctx tcontext.TransferMetadata, iter ite
 			return err
 		}
 
-		sourceAdapter := ctx.Value("source")
+		projectVersion := "latest"
+		if r.projectVersion != "" {
+			projectVersion = r.projectVersion
+		}
+		finalProjectName, err := constructProjectName(ctx, r.nameTemplate, r.projectName, r.projectVersion, sbom)
+		if err != nil {
+			fmt.Printf("- ⚠️  Would fail to name the project of %s: %v\n", sbom.Path, err)
+			continue
+		}
+		finalProjectName, projectVersion = sbom.Annotations.Project(finalProjectName, projectVersion)
+		if r.aggregateInto != "" {
+			finalProjectName = r.aggregateInto
+		}
+		if r.projectMapping != nil && r.client != nil {
+			mappedName, mappedVersion, _, err := r.projectMapping.Resolve(ctx, r.client, sbom)
+			if err != nil {
+				fmt.Printf("- ⚠️  Would fail to map %s to a project: %v\n", sbom.Path, err)
+				continue
+			}
+			finalProjectName, projectVersion = mappedName, mappedVersion
+		}
 
-		finalProjectName, _ := utils.ConstructDTProjectName(ctx, r.projectName, r.projectVersion, sbom.Namespace, sbom.Version, sbom.Path, sbom.Data, sourceAdapter.(string))
+		if r.client == nil {
+			fmt.Printf("- 📁 Would upload to project '%s' | Format: %s | SpecVersion: %s | Filename: %s\n",
+				finalProjectName, doc.Format, doc.SpecVersion, sbom.Path)
+			sbomCount++
+			continue
+		}
+
+		status := r.projectStatus(ctx, preflight, finalProjectName, projectVersion, sbom)
+		notes := preflight.Notes(sbom.Conversion(), "")
+		key := finalProjectName + "@" + projectVersion
+		if targeted[key] && r.aggregateInto == "" {
+			notes += " | same project as an earlier SBOM of this run"
+		}
+		targeted[key] = true
 
-		fmt.Printf("- 📁 Would upload to project '%s' | Format: %s | SpecVersion: %s | Filename: %s\n",
-			finalProjectName, doc.Format, doc.SpecVersion, sbom.Path)
+		fmt.Printf("- 📁 Would upload to project '%s@%s' [%s] | Format: %s | SpecVersion: %s | Filename: %s%s\n",
+			finalProjectName, projectVersion, status, doc.Format, doc.SpecVersion, sbom.Path, notes)
 		sbomCount++
 	}
-	fmt.Printf("\n 📊 Total SBOMs to upload: %d\n", sbomCount)
+	if r.aggregateInto != "" {
+		fmt.Printf("\n 📊 Total SBOMs to aggregate into project '%s': %d\n", r.aggregateInto, sbomCount)
+	} else {
+		fmt.Printf("\n 📊 Total SBOMs to upload: %d\n", sbomCount)
+	}
+	if r.client != nil {
+		preflight.Print()
+	}
 	fmt.Println("\n✅ Dry-run completed. No data was uploaded to DTrack.")
 	return nil
 }
+
+// Dependency-Track specific preflight statuses
+const (
+	preflightNewProject = "new project, would create"
+	preflightNoSBOM     = "existing project without SBOM, would upload"
+	preflightUnchanged  = "unchanged, would skip"
+)
+
+// projectStatus looks up the project an SBOM would be uploaded to and returns what the upload
+// would do, going by the same rules as the uploaders: without --overwrite a project
+// holding an SBOM is skipped, with it only an unchanged SBOM is.
+func (r *DependencyTrackReporter) projectStatus(ctx tcontext.TransferMetadata, preflight *utils.Preflight, projectName, projectVersion string, sbom *iterator.SBOM) string {
+	project, err := r.client.LookupProject(ctx, projectName, projectVersion)
+	if err != nil {
+		logger.LogDebug(ctx.Context, "Failed to look up project", "project", projectName, "version", projectVersion, "error", err)
+		return preflight.Status(false, err, r.overwrite)
+	}
+	if project == nil {
+		preflight.Count(preflightNewProject)
+		return preflightNewProject
+	}
+	if !project.Active || (project.LastBOMImport == 0 && project.Metrics.Components == 0) {
+		preflight.Count(preflightNoSBOM)
+		return preflightNoSBOM
+	}
+	if r.overwrite {
+		if stored, err := r.client.ContentHash(ctx, projectName, projectVersion); err == nil && stored == sbom.SourceHash() {
+			preflight.Count(preflightUnchanged)
+			return preflightUnchanged
+		}
+	}
+	return preflight.Status(true, nil, r.overwrite)
+}
@@ -14,14 +14,18 @@
 package dependencytrack
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/report"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/timing"
 	"github.com/interlynk-io/sbommv/pkg/utils"
 )
 
@@ -30,8 +34,9 @@ type SBOMUploader interface {
 }
 
 type SequentialUploader struct {
-	createdProjects map[string]bool // Cache of created project names
-	mu              sync.Mutex      // Protect map access
+	createdProjects    map[string]bool // Cache of created project names
+	mu                 sync.Mutex      // Protect map access
+	autoCreateDisabled atomic.Bool     // set once the server rejects auto-create uploads
 }
 
 func NewSequentialUploader() *SequentialUploader {
@@ -43,9 +48,6 @@ func NewSequentialUploader() *SequentialUploader {
 func (u *SequentialUploader) Upload(ctx tcontext.TransferMetadata, config *DependencyTrackConfig, client *DependencyTrackClient, iter iterator.SBOMIterator) error {
 	logger.LogDebug(ctx.Context, "Initializing SBOMs uploading to Dependency-Track sequentially")
 
-	// space for proper logging
-	fmt.Println()
-
 	totalSBOMs := 0
 	successfullyUploaded := 0
 	for {
@@ -53,52 +55,98 @@ func (u *SequentialUploader) Upload(ctx tcontext.TransferMetadata, config *Depen
 		if err == io.EOF {
 			break
 		}
-
-		totalSBOMs++
+		if ctx.Err() != nil {
+			break
+		}
 
 		if err != nil {
 			logger.LogDebug(ctx.Context, "Next: failed to get next SBOM continuing", "error", err)
 			continue
 		}
-
-		sourceAdapter := ctx.Value("source")
-
-		// Construct project name and version
-		finalProjectName, _ := utils.ConstructDTProjectName(ctx, config.ProjectName, config.ProjectVersion, sbom.Namespace, sbom.Version, sbom.Path, sbom.Data, sourceAdapter.(string))
+		totalSBOMs++
 
 		projectVersion := "latest"
 		if config.ProjectVersion != "" {
 			projectVersion = config.ProjectVersion
 		}
+
+		// Construct project name and version
+		finalProjectName, err := constructProjectName(ctx, config.NameTemplate, config.ProjectName, config.ProjectVersion, sbom)
+		if err != nil {
+			logger.LogInfo(ctx.Context, "error", "file", sbom.Path, "error", err)
+			recordFailure(ctx, report.StageProject, sbom, finalProjectName, projectVersion, err)
+			continue
+		}
+		finalProjectName, projectVersion = sbom.Annotations.Project(finalProjectName, projectVersion)
 		// finalProjectName := fmt.Sprintf("%s-%s", projectName, projectVersion)
-		logger.LogDebug(ctx.Context, "Project Details", "project_name", finalProjectName)
 
-		// Find or create project and get UUID
+		// with a project mapping, SBOMs only go to the existing projects they are mapped to
 		var projectUUID string
-		if !u.createdProjects[finalProjectName] {
-			projectUUID, err = client.FindOrCreateProject(ctx, finalProjectName, projectVersion)
+		if config.ProjectMapping != nil {
+			mappedName, mappedVersion, mappedUUID, err := config.ProjectMapping.Resolve(ctx, client, sbom)
 			if err != nil {
-				logger.LogInfo(ctx.Context, "error", "project", finalProjectName, "error", err)
+				logger.LogInfo(ctx.Context, "error", "file", sbom.Path, "error", err)
+				recordFailure(ctx, report.StageMapping, sbom, finalProjectName, projectVersion, err)
 				continue
 			}
-			u.createdProjects[finalProjectName] = true
-		} else {
-			// If already created, fetch the UUID (assuming FindOrCreateProject caches or retrieves it)
-			projectUUID, err = client.FindOrCreateProject(ctx, finalProjectName, projectVersion)
-			if err != nil {
-				logger.LogDebug(ctx.Context, "Failed to retrieve existing project UUID", "project", finalProjectName, "error", err)
+			finalProjectName, projectVersion, projectUUID = mappedName, mappedVersion, mappedUUID
+		}
+		logger.LogDebug(ctx.Context, "Project Details", "project_name", finalProjectName)
+
+		if config.AutoCreate && !u.autoCreateDisabled.Load() {
+			skipped, err := uploadWithAutoCreate(ctx, config, client, finalProjectName, projectVersion, sbom)
+			if err == nil {
+				successfullyUploaded++
+				config.Uploads.Record(ctx, finalProjectName, projectVersion, sbom.Data)
+				if !skipped {
+					report.RecordTransfer(ctx, sbom, finalProjectName+"@"+projectVersion)
+					logger.LogInfo(ctx.Context, "upload", "success", true, "project", finalProjectName, "version", projectVersion, "file", sbom.Path)
+				}
+				continue
+			}
+			if isProcessingError(err) {
+				recordFailure(ctx, report.StageUpload, sbom, finalProjectName, projectVersion, err)
 				continue
 			}
+			fallbackFromAutoCreate(ctx, &u.autoCreateDisabled, finalProjectName, err)
+		}
+
+		// Find or create project and get UUID, mapped projects exist already
+		if projectUUID == "" {
+			if !u.createdProjects[finalProjectName] {
+				projectUUID, err = client.FindOrCreateProject(ctx, finalProjectName, projectVersion, annotatedTags(sbom)...)
+				if err != nil {
+					logger.LogInfo(ctx.Context, "error", "project", finalProjectName, "error", err)
+					recordFailure(ctx, report.StageProject, sbom, finalProjectName, projectVersion, err)
+					continue
+				}
+				u.createdProjects[finalProjectName] = true
+			} else {
+				// If already created, fetch the UUID (assuming FindOrCreateProject caches or retrieves it)
+				projectUUID, err = client.FindOrCreateProject(ctx, finalProjectName, projectVersion, annotatedTags(sbom)...)
+				if err != nil {
+					logger.LogDebug(ctx.Context, "Failed to retrieve existing project UUID", "project", finalProjectName, "error", err)
+					recordFailure(ctx, report.StageProject, sbom, finalProjectName, projectVersion, err)
+					continue
+				}
+			}
 		}
 
 		logger.LogDebug(ctx.Context, "Initializing uploading SBOM content", "size", len(sbom.Data), "file", sbom.Path)
 
+		if config.Overwrite && unchanged(ctx, client, finalProjectName, projectVersion, sbom) {
+			successfullyUploaded++
+			config.Uploads.Record(ctx, finalProjectName, projectVersion, sbom.Data)
+			continue
+		}
+
 		if !config.Overwrite {
 
 			// default behavior: only upload if no SBOM exists
 			parsedUUID, err := uuid.Parse(projectUUID)
 			if err != nil {
 				logger.LogDebug(ctx.Context, "Failed to parse project UUID", "projectUUID", projectUUID, "error", err)
+				recordFailure(ctx, report.StageProject, sbom, finalProjectName, projectVersion, err)
 				continue
 			}
 
@@ -106,9 +154,10 @@ func (u *SequentialUploader) Upload(ctx tcontext.TransferMetadata, config *Depen
 			project, err := client.Client.Project.Get(ctx.Context, parsedUUID)
 			if err != nil {
 				logger.LogDebug(ctx.Context, "Failed to fetch project, assuming it’s new", "project", finalProjectName, "error", err)
-				err = client.UploadSBOM(ctx, finalProjectName, projectVersion, sbom.Data)
+				err = uploadSBOM(ctx, client, finalProjectName, projectVersion, sbom)
 				if err != nil {
 					logger.LogDebug(ctx.Context, "Upload Failed for", "project", finalProjectName, "size", len(sbom.Data), "file", sbom.Path, "error", err)
+					recordFailure(ctx, report.StageUpload, sbom, finalProjectName, projectVersion, err)
 					continue
 				}
 			} else {
@@ -128,6 +177,7 @@ func (u *SequentialUploader) Upload(ctx tcontext.TransferMetadata, config *Depen
 				if project.Active && hasSBOM {
 					logger.LogInfo(ctx.Context, "exists", "skip upload", true, "project", finalProjectName, "uuid", projectUUID)
 					successfullyUploaded++
+					config.Uploads.Record(ctx, finalProjectName, projectVersion, sbom.Data)
 					continue
 				}
 				logger.LogDebug(ctx.Context, "Project exists but no SBOM detected, proceeding with upload", "project", finalProjectName)
@@ -135,23 +185,30 @@ func (u *SequentialUploader) Upload(ctx tcontext.TransferMetadata, config *Depen
 		}
 
 		// Upload SBOM (either overwrite is true or no SBOM exists)
-		err = client.UploadSBOM(ctx, finalProjectName, projectVersion, sbom.Data)
+		err = uploadSBOM(ctx, client, finalProjectName, projectVersion, sbom)
 		if err != nil {
 			logger.LogDebug(ctx.Context, "Upload Failed for", "project", finalProjectName, "size", len(sbom.Data), "file", sbom.Path, "error", err)
+			recordFailure(ctx, report.StageUpload, sbom, finalProjectName, projectVersion, err)
 			continue
 		}
 
 		successfullyUploaded++
+		config.Uploads.Record(ctx, finalProjectName, projectVersion, sbom.Data)
+		report.RecordTransfer(ctx, sbom, finalProjectName+"@"+projectVersion)
 		logger.LogInfo(ctx.Context, "upload", "success", true, "project", finalProjectName, "version", projectVersion, "file", sbom.Path)
 	}
 	logger.LogInfo(ctx.Context, "upload", "sboms", totalSBOMs, "success", successfullyUploaded, "failed", totalSBOMs-successfullyUploaded)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded, err)
+	}
 	return nil
 }
 
 // ParallelUploader uploads SBOMs to Dependency-Track concurrently.
 type ParallelUploader struct {
-	createdProjects map[string]bool
-	mu              sync.Mutex // Protects access to createdProjects.
+	createdProjects    map[string]bool
+	mu                 sync.Mutex  // Protects access to createdProjects.
+	autoCreateDisabled atomic.Bool // set once the server rejects auto-create uploads
 }
 
 // NewParallelUploader returns a new instance of ParallelUploader.
@@ -167,10 +224,7 @@ func (u *ParallelUploader) Upload(ctx tcontext.TransferMetadata, config *Depende
 
 	sbomChan := make(chan *iterator.SBOM, 100)
 	totalSBOMs := 0
-	successfullyUploaded := 0
-
-	// space for proper logging
-	fmt.Println()
+	var successfullyUploaded atomic.Int64 // incremented by all workers
 
 	// multiple goroutines will read SBOMs from the iterator.
 	go func() {
@@ -179,11 +233,14 @@ func (u *ParallelUploader) Upload(ctx tcontext.TransferMetadata, config *Depende
 			if err == io.EOF {
 				break
 			}
-			totalSBOMs++
+			if ctx.Err() != nil {
+				break
+			}
 			if err != nil {
 				logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
 				continue
 			}
+			totalSBOMs++
 			sbomChan <- sbom
 		}
 		close(sbomChan)
@@ -197,39 +254,94 @@ func (u *ParallelUploader) Upload(ctx tcontext.TransferMetadata, config *Depende
 		go func() {
 			defer wg.Done()
 			for sbom := range sbomChan {
-
-				sourceAdapter := ctx.Value("source")
-				finalProjectName, _ := utils.ConstructDTProjectName(ctx, config.ProjectName, config.ProjectVersion, sbom.Namespace, sbom.Version, sbom.Path, sbom.Data, sourceAdapter.(string))
+				// drain the queued SBOMs once cancelled
+				if ctx.Err() != nil {
+					continue
+				}
 
 				projectVersion := "latest"
 				if config.ProjectVersion != "" {
 					projectVersion = config.ProjectVersion
 				}
 
+				finalProjectName, err := constructProjectName(ctx, config.NameTemplate, config.ProjectName, config.ProjectVersion, sbom)
+				if err != nil {
+					logger.LogInfo(ctx.Context, "error", "file", sbom.Path, "error", err)
+					recordFailure(ctx, report.StageProject, sbom, finalProjectName, projectVersion, err)
+					continue
+				}
+				finalProjectName, projectVersion = sbom.Annotations.Project(finalProjectName, projectVersion)
+
+				// with a project mapping, SBOMs only go to the existing projects they are mapped to
+				mapped := config.ProjectMapping != nil
+				if mapped {
+					mappedName, mappedVersion, _, err := config.ProjectMapping.Resolve(ctx, client, sbom)
+					if err != nil {
+						logger.LogInfo(ctx.Context, "error", "file", sbom.Path, "error", err)
+						recordFailure(ctx, report.StageMapping, sbom, finalProjectName, projectVersion, err)
+						continue
+					}
+					finalProjectName, projectVersion = mappedName, mappedVersion
+				}
+
 				logger.LogDebug(ctx.Context, "Project Details", "name", finalProjectName, "version", projectVersion)
 
+				if config.AutoCreate && !u.autoCreateDisabled.Load() {
+					skipped, err := uploadWithAutoCreate(ctx, config, client, finalProjectName, projectVersion, sbom)
+					if err == nil {
+						successfullyUploaded.Add(1)
+						config.Uploads.Record(ctx, finalProjectName, projectVersion, sbom.Data)
+						if !skipped {
+							report.RecordTransfer(ctx, sbom, finalProjectName+"@"+projectVersion)
+						}
+						continue
+					}
+					if isProcessingError(err) {
+						recordFailure(ctx, report.StageUpload, sbom, finalProjectName, projectVersion, err)
+						continue
+					}
+					fallbackFromAutoCreate(ctx, &u.autoCreateDisabled, finalProjectName, err)
+				}
+
 				// Ensure the project exists (using a shared cache to avoid duplicate creation).
 				u.mu.Lock()
-				if !u.createdProjects[finalProjectName] {
-					_, err := client.FindOrCreateProject(ctx, finalProjectName, projectVersion)
+				if !mapped && !u.createdProjects[finalProjectName] {
+					_, err := client.FindOrCreateProject(ctx, finalProjectName, projectVersion, annotatedTags(sbom)...)
 					if err != nil {
 						logger.LogInfo(ctx.Context, "error", "project", finalProjectName, "error", err)
 						u.mu.Unlock()
+						recordFailure(ctx, report.StageProject, sbom, finalProjectName, projectVersion, err)
 						continue
 					}
 					u.createdProjects[finalProjectName] = true
 				}
 				u.mu.Unlock()
 
+				if config.Overwrite && unchanged(ctx, client, finalProjectName, projectVersion, sbom) {
+					successfullyUploaded.Add(1)
+					config.Uploads.Record(ctx, finalProjectName, projectVersion, sbom.Data)
+					continue
+				}
+
 				logger.LogDebug(ctx.Context, "Uploading SBOM file", "file", sbom.Path)
 
 				// Upload the SBOM.
-				err := client.UploadSBOM(ctx, finalProjectName, projectVersion, sbom.Data)
+				err = uploadSBOM(ctx, client, finalProjectName, projectVersion, sbom)
+				if isNotFoundError(err) && !mapped {
+					// the cached project was deleted on the server since it was created, create it again
+					logger.LogDebug(ctx.Context, "Project no longer exists, creating it again", "project", finalProjectName, "version", projectVersion)
+					if _, err = client.FindOrCreateProject(ctx, finalProjectName, projectVersion, annotatedTags(sbom)...); err == nil {
+						err = uploadSBOM(ctx, client, finalProjectName, projectVersion, sbom)
+					}
+				}
 				if err != nil {
 					logger.LogDebug(ctx.Context, "Failed to upload SBOM", "project", finalProjectName, "file", sbom.Path, "error", err)
+					recordFailure(ctx, report.StageUpload, sbom, finalProjectName, projectVersion, err)
 					continue
 				}
-				successfullyUploaded++
+				successfullyUploaded.Add(1)
+				config.Uploads.Record(ctx, finalProjectName, projectVersion, sbom.Data)
+				report.RecordTransfer(ctx, sbom, finalProjectName+"@"+projectVersion)
 				logger.LogDebug(ctx.Context, "Successfully uploaded SBOM file", "file", sbom.Path)
 			}
 		}()
@@ -237,6 +349,114 @@ func (u *ParallelUploader) Upload(ctx tcontext.TransferMetadata, config *Depende
 
 	// wait for all workers to complete.
 	wg.Wait()
-	logger.LogInfo(ctx.Context, "upload", "sboms", totalSBOMs, "success", successfullyUploaded, "failed", totalSBOMs-successfullyUploaded)
+	logger.LogInfo(ctx.Context, "upload", "sboms", totalSBOMs, "success", successfullyUploaded.Load(), "failed", int64(totalSBOMs)-successfullyUploaded.Load())
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded.Load(), err)
+	}
+	return nil
+}
+
+// uploadWithAutoCreate uploads an SBOM relying on Dependency-Track to create the project.
+// Without overwrite, a single lookup checks whether the project already holds an SBOM,
+// in which case the upload is skipped and skipped=true is returned. With overwrite, the
+// upload is skipped only when the project already holds this exact SBOM.
+func uploadWithAutoCreate(ctx tcontext.TransferMetadata, config *DependencyTrackConfig, client *DependencyTrackClient, projectName, projectVersion string, sbom *iterator.SBOM) (bool, error) {
+	if config.Overwrite && unchanged(ctx, client, projectName, projectVersion, sbom) {
+		return true, nil
+	}
+
+	if !config.Overwrite {
+		project, err := client.LookupProject(ctx, projectName, projectVersion)
+		if err != nil {
+			return false, err
+		}
+
+		if project != nil && project.Active && (project.LastBOMImport != 0 || project.Metrics.Components > 0) {
+			logger.LogInfo(ctx.Context, "exists", "skip upload", true, "project", projectName, "uuid", project.UUID)
+			return true, nil
+		}
+	}
+
+	err := timing.Time(ctx, timing.StageUpload, sbom.Path, func() error {
+		return client.UploadSBOMWithAutoCreate(ctx, projectName, projectVersion, sbom.Data, annotatedTags(sbom)...)
+	})
+	if err != nil {
+		return false, err
+	}
+	client.RecordContentHash(ctx, projectName, projectVersion, sbom.SourceHash())
+	return false, nil
+}
+
+// unchanged reports whether the SBOM is the one last uploaded to the project, going by the
+// content hash recorded as a project property, which is of the SBOM as fetched. A failed
+// lookup counts as changed, so the SBOM is uploaded again.
+func unchanged(ctx tcontext.TransferMetadata, client *DependencyTrackClient, projectName, projectVersion string, sbom *iterator.SBOM) bool {
+	stored, err := client.ContentHash(ctx, projectName, projectVersion)
+	if err != nil {
+		logger.LogDebug(ctx.Context, "Unable to read content hash of project, uploading", "project", projectName, "error", err)
+		return false
+	}
+	if stored == "" || stored != sbom.SourceHash() {
+		return false
+	}
+
+	logger.LogInfo(ctx.Context, "unchanged", "skip upload", true, "project", projectName, "version", projectVersion, "file", sbom.Path)
+	return true
+}
+
+// uploadSBOM uploads the SBOM to an existing project, timing it as the SBOM's upload stage
+func uploadSBOM(ctx tcontext.TransferMetadata, client *DependencyTrackClient, projectName, projectVersion string, sbom *iterator.SBOM) error {
+	err := timing.Time(ctx, timing.StageUpload, sbom.Path, func() error {
+		return client.UploadSBOM(ctx, projectName, projectVersion, sbom.Data)
+	})
+	if err != nil {
+		return err
+	}
+	client.RecordContentHash(ctx, projectName, projectVersion, sbom.SourceHash())
 	return nil
 }
+
+// constructProjectName names the project of sbom, from the name template when one is set
+func constructProjectName(ctx tcontext.TransferMetadata, nameTemplate *utils.ProjectNameTemplate, projectName, projectVersion string, sbom *iterator.SBOM) (string, error) {
+	if nameTemplate != nil {
+		return nameTemplate.Render(sbom.Namespace, sbom.Version, sbom.Path, sbom.Data, sbom.SourceAdapter(ctx))
+	}
+	finalProjectName, _ := utils.ConstructDTProjectName(ctx, projectName, projectVersion, sbom.Namespace, sbom.Version, sbom.Path, sbom.Data, utils.NamingSource(sbom.SourceAdapter(ctx), sbom.ExplicitNamespace))
+	return finalProjectName, nil
+}
+
+// recordFailure records an SBOM that failed to reach its project for the end of run report.
+// An upload Dependency-Track failed to process is recorded at the processing stage.
+func recordFailure(ctx tcontext.TransferMetadata, stage string, sbom *iterator.SBOM, projectName, projectVersion string, err error) {
+	if isProcessingError(err) {
+		stage = report.StageProcess
+	}
+	report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: projectName + "@" + projectVersion, Stage: stage}, err)
+}
+
+// annotatedTags returns the project tags set by the SBOM's metadata file
+func annotatedTags(sbom *iterator.SBOM) []string {
+	if sbom.Annotations == nil {
+		return nil
+	}
+	return sbom.Annotations.Tags
+}
+
+// isProcessingError reports whether err is an uploaded BOM Dependency-Track failed to
+// process, which uploading it again wouldn't fix
+func isProcessingError(err error) bool {
+	var processingErr *ProcessingError
+	return errors.As(err, &processingErr)
+}
+
+// fallbackFromAutoCreate logs an auto-create failure and, when the API key lacks the
+// permission for it, disables auto-create for the rest of the run.
+func fallbackFromAutoCreate(ctx tcontext.TransferMetadata, disabled *atomic.Bool, projectName string, err error) {
+	if isPermissionError(err) {
+		if !disabled.Swap(true) {
+			logger.LogInfo(ctx.Context, "Auto-create not permitted for this API key, falling back to explicit project creation", "error", err)
+		}
+		return
+	}
+	logger.LogDebug(ctx.Context, "Auto-create upload failed, falling back to explicit project creation", "project", projectName, "error", err)
+}
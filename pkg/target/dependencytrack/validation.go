@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
 )
 
 func ValidateDTrackConnection(apiURL, token string) error {
@@ -41,19 +43,19 @@ func ValidateDTrackConnection(apiURL, token string) error {
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to reach DTrack at %s: %w", baseURL, err)
+		return errdefs.Unreachable(fmt.Errorf("failed to reach DTrack at %s: %w", baseURL, err))
 	}
 
 	defer resp.Body.Close()
 
 	// provided token is invalid
 	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-		return fmt.Errorf("invalid API token: authentication failed")
+		return errdefs.Auth(fmt.Errorf("invalid API token: authentication failed"))
 	}
 
 	// DTrack looks to down
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("DTrack API returned unexpected status: %d", resp.StatusCode)
+		return errdefs.Unreachable(fmt.Errorf("DTrack API returned unexpected status: %d", resp.StatusCode))
 	}
 	return nil
 }
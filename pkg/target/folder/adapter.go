@@ -17,9 +17,11 @@ package folder
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 	"github.com/interlynk-io/sbommv/pkg/types"
 	"github.com/interlynk-io/sbommv/pkg/utils"
@@ -32,18 +34,27 @@ type FolderAdapter struct {
 	config    *FolderConfig
 	Uploader  SBOMUploader
 	Overwrite bool
+	Daemon    bool
 }
 
 // AddCommandParams defines folder adapter CLI flags
 func (f *FolderAdapter) AddCommandParams(cmd *cobra.Command) {
 	cmd.Flags().String("out-folder-path", "", "The folder where SBOMs should be stored")
 	cmd.Flags().String("out-folder-processing-mode", "sequential", "Folder processing mode (sequential/parallel)")
+	cmd.Flags().Int("out-folder-keep-versions", 0, "Daemon mode: keep only the last N versions per namespace (0 keeps all)")
+	cmd.Flags().String("out-folder-max-size", "", "Daemon mode: total size cap of written SBOMs, oldest evicted first (e.g. '500MB', '2GB')")
+	cmd.Flags().String("out-folder-compress-after", "", "Daemon mode: gzip written SBOMs older than this age (e.g. '30m', '24hr')")
+	cmd.Flags().Bool("out-folder-format-extensions", false, "Name files after their detected format, e.g. .cdx.json, .spdx.json, .cdx.xml")
+	cmd.Flags().String("out-folder-structure", "", "Directory layout of the written SBOMs: flat, namespace, versioned, or a template of {namespace}, {version}, {filename}, {path} and {format}, e.g. '{namespace}/{version}/{filename}' (default: the source path)")
+	cmd.Flags().StringSlice("out-folder-extension-map", nil, "Extensions of formats as format=extension, e.g. cyclonedx-json=.json (implies --out-folder-format-extensions)")
 }
 
 // ParseAndValidateParams validates the folder path
 func (f *FolderAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	var pathFlag string
 	var processingModeFlag string
+	var keepVersionsFlag, maxSizeFlag, compressAfterFlag string
+	var formatExtensionsFlag, extensionMapFlag, structureFlag string
 	var missingFlags []string
 	var invalidFlags []string
 
@@ -54,6 +65,12 @@ func (f *FolderAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	case types.OutputAdapterRole:
 		pathFlag = "out-folder-path"
 		processingModeFlag = "out-folder-processing-mode"
+		keepVersionsFlag = "out-folder-keep-versions"
+		maxSizeFlag = "out-folder-max-size"
+		compressAfterFlag = "out-folder-compress-after"
+		formatExtensionsFlag = "out-folder-format-extensions"
+		extensionMapFlag = "out-folder-extension-map"
+		structureFlag = "out-folder-structure"
 
 	default:
 		return fmt.Errorf("The adapter is neither an input type nor an output type")
@@ -79,6 +96,51 @@ func (f *FolderAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 
 	projectOverwrite := f.Overwrite
 
+	// retention options bound a long-running mirror, so they only apply in daemon mode
+	var retention RetentionPolicy
+
+	keepVersions, _ := cmd.Flags().GetInt(keepVersionsFlag)
+	if keepVersions < 0 {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%d (must be 0 or greater)", keepVersionsFlag, keepVersions))
+	}
+	retention.KeepVersions = keepVersions
+
+	if maxSizeStr, _ := cmd.Flags().GetString(maxSizeFlag); maxSizeStr != "" {
+		maxSize, err := utils.ParseByteSize(maxSizeStr)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (%v)", maxSizeFlag, maxSizeStr, err))
+		}
+		retention.MaxSizeBytes = maxSize
+	}
+
+	if compressAfterStr, _ := cmd.Flags().GetString(compressAfterFlag); compressAfterStr != "" {
+		compressAfter, err := utils.ParseDuration(compressAfterStr)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s=%s (%v)", compressAfterFlag, compressAfterStr, err))
+		}
+		retention.CompressAfter = time.Duration(compressAfter) * time.Second
+	}
+
+	if retention.Enabled() && !f.Daemon {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s, --%s and --%s are only supported with --daemon", keepVersionsFlag, maxSizeFlag, compressAfterFlag))
+	}
+
+	var extensions *sbom.ExtensionMap
+	formatExtensions, _ := cmd.Flags().GetBool(formatExtensionsFlag)
+	extensionMap, _ := cmd.Flags().GetStringSlice(extensionMapFlag)
+	if formatExtensions || len(extensionMap) > 0 {
+		extensions, err = sbom.ParseExtensionMap(extensionMap)
+		if err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("%s: %v", extensionMapFlag, err))
+		}
+	}
+
+	structureStr, _ := cmd.Flags().GetString(structureFlag)
+	structure, err := ParseStructure(structureStr)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("%s: %v", structureFlag, err))
+	}
+
 	// Validate required flags
 	if len(missingFlags) > 0 {
 		return fmt.Errorf("missing output adapter required flags: %v\n\nUse 'sbommv transfer --help' for usage details.", missingFlags)
@@ -93,10 +155,13 @@ func (f *FolderAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 		FolderPath: folderPath,
 		Settings:   types.UploadSettings{ProcessingMode: types.UploadMode(mode)},
 		Overwrite:  projectOverwrite,
+		Retention:  retention,
+		Extensions: extensions,
+		Structure:  structure,
 	}
 	f.config = &cfg
 
-	logger.LogDebug(cmd.Context(), "Folder Output Adapter Initialized", "path", f.config.FolderPath)
+	logger.LogDebug(cmd.Context(), "Folder Output Adapter Initialized", "path", f.config.FolderPath, "structure", f.config.Structure, "retention", f.config.Retention)
 	return nil
 }
 
@@ -113,6 +178,6 @@ func (f *FolderAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iter iterator
 
 // DryRun for Output Adapter: Simulates writing SBOMs to a folder
 func (f *FolderAdapter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
-	reporter := NewFolderOutputReporter(f.config.FolderPath)
+	reporter := NewFolderOutputReporter(f.config)
 	return reporter.DryRun(ctx, iter)
 }
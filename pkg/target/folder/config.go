@@ -14,12 +14,25 @@
 
 package folder
 
-import "github.com/interlynk-io/sbommv/pkg/types"
+import (
+	"fmt"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/types"
+)
 
 type FolderConfig struct {
 	FolderPath string
 	Settings   types.UploadSettings
 	Overwrite  bool
+	Retention  RetentionPolicy
+
+	// Extensions renames files after their detected format, nil keeps their source names
+	Extensions *sbom.ExtensionMap
+
+	// Structure is the directory layout of the written files, nil writes them at their source paths
+	Structure *Structure
 }
 
 func NewFolderConfig() *FolderConfig {
@@ -27,3 +40,20 @@ func NewFolderConfig() *FolderConfig {
 		Settings: types.UploadSettings{ProcessingMode: types.UploadSequential},
 	}
 }
+
+// fileName returns the name an SBOM is written as: its source name, with the extension of its
+// detected format when Extensions is set, or one derived from its content when it has none
+func (c *FolderConfig) fileName(path string, data []byte) string {
+	if path == "" {
+		path = fmt.Sprintf("sbom-%s.json", sbom.ComputeContentHash(data)[:12])
+	}
+	if c.Extensions != nil {
+		return c.Extensions.Rename(path, data)
+	}
+	return path
+}
+
+// outputPath returns where an SBOM is written, relative to the folder and laid out by Structure
+func (c *FolderConfig) outputPath(s *iterator.SBOM) string {
+	return c.Structure.Path(s, c.fileName(s.Path, s.Data))
+}
@@ -17,49 +17,64 @@ package folder
 import (
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 
-	"github.com/google/uuid"
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/utils"
 )
 
 type FolderOutputReporter struct {
-	folderPath string
+	config *FolderConfig
 }
 
-func NewFolderOutputReporter(folderPath string) *FolderOutputReporter {
-	return &FolderOutputReporter{folderPath: folderPath}
+func NewFolderOutputReporter(config *FolderConfig) *FolderOutputReporter {
+	return &FolderOutputReporter{config: config}
 }
 
 func (r *FolderOutputReporter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
 	logger.LogDebug(ctx.Context, "Dry-run mode: Displaying SBOMs for folder output")
 	fmt.Println("\n📦 Folder Output Adapter Dry-Run")
 	sbomCount := 0
+	preflight := utils.NewPreflight()
+	collisions := utils.NewNameCollisions()
 
 	for {
 		sbom, err := iter.Next(ctx)
 		if err == io.EOF {
 			break
 		}
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
 			return err
 		}
 
-		outputFolder := r.folderPath
+		// same naming as the upload, including the suffix of colliding names
+		fileName := r.config.outputPath(sbom)
+		renamedFrom := ""
+		if resolved, collided := collisions.Resolve(fileName, sbom.Data); collided {
+			renamedFrom, fileName = fileName, resolved
+		}
+		outputFile := filepath.Join(r.config.FolderPath, filepath.FromSlash(fileName))
 
-		outputFile := filepath.Join(outputFolder, sbom.Path)
-		if sbom.Path == "" {
-			outputFile = filepath.Join(outputFolder, fmt.Sprintf("%s.sbom.json", uuid.New().String()))
+		_, statErr := os.Stat(outputFile)
+		exists := statErr == nil
+		if os.IsNotExist(statErr) {
+			statErr = nil
 		}
+		status := preflight.Status(exists, statErr, r.config.Overwrite)
 
-		fmt.Printf("- 📂 Would write: %s\n", outputFile)
+		fmt.Printf("- 📂 Would write: %s [%s]%s\n", outputFile, status, preflight.Notes(sbom.Conversion(), renamedFrom))
 		sbomCount++
 	}
 
 	fmt.Printf("\n📊 Total SBOMs to be stored: %d\n", sbomCount)
+	preflight.Print()
 	logger.LogDebug(ctx.Context, "Dry-run completed", "total_sboms", sbomCount)
 	return nil
 }
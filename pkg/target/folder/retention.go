@@ -0,0 +1,262 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package folder
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/verify"
+)
+
+// retentionIndexFile tracks the SBOMs written to the output folder across daemon restarts
+const retentionIndexFile = ".sbommv-retention.json"
+
+// RetentionPolicy bounds the content of the output folder in daemon mode.
+// Zero values disable the respective rule.
+type RetentionPolicy struct {
+	KeepVersions  int           // versions kept per namespace
+	MaxSizeBytes  int64         // total size of written SBOMs, oldest evicted first
+	CompressAfter time.Duration // age after which written SBOMs are gzipped
+}
+
+// Enabled reports whether any retention rule is configured
+func (p RetentionPolicy) Enabled() bool {
+	return p.KeepVersions > 0 || p.MaxSizeBytes > 0 || p.CompressAfter > 0
+}
+
+type retentionEntry struct {
+	File       string    `json:"file"`
+	Namespace  string    `json:"namespace"`
+	Version    string    `json:"version"`
+	Size       int64     `json:"size"`
+	WrittenAt  time.Time `json:"written_at"`
+	Compressed bool      `json:"compressed"`
+}
+
+type retentionIndex struct {
+	dir     string
+	Entries []retentionEntry `json:"entries"`
+}
+
+// loadRetentionIndex reads the index of the output folder, starting empty when none exists
+func loadRetentionIndex(dir string) (*retentionIndex, error) {
+	idx := &retentionIndex{dir: dir}
+
+	data, err := os.ReadFile(filepath.Join(dir, retentionIndexFile))
+	if os.IsNotExist(err) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading retention index: %w", err)
+	}
+
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, fmt.Errorf("parsing retention index: %w", err)
+	}
+	return idx, nil
+}
+
+func (idx *retentionIndex) save() error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling retention index: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(idx.dir, retentionIndexFile), data, 0o644); err != nil {
+		return fmt.Errorf("writing retention index: %w", err)
+	}
+	return nil
+}
+
+// record adds a freshly written file, replacing any previous entry for the same file
+func (idx *retentionIndex) record(file, namespace, version string, size int64) {
+	entries := idx.Entries[:0]
+	for _, e := range idx.Entries {
+		if e.File != file {
+			entries = append(entries, e)
+		}
+	}
+	idx.Entries = append(entries, retentionEntry{
+		File:      file,
+		Namespace: namespace,
+		Version:   version,
+		Size:      size,
+		WrittenAt: time.Now(),
+	})
+}
+
+// apply enforces the policy on the tracked files: older versions per namespace are removed,
+// aged files are gzipped, and the oldest files are evicted while the size cap is exceeded.
+func (idx *retentionIndex) apply(ctx tcontext.TransferMetadata, policy RetentionPolicy) error {
+	sort.SliceStable(idx.Entries, func(i, j int) bool {
+		return idx.Entries[i].WrittenAt.Before(idx.Entries[j].WrittenAt)
+	})
+
+	if policy.KeepVersions > 0 {
+		idx.pruneVersions(ctx, policy.KeepVersions)
+	}
+
+	if policy.CompressAfter > 0 {
+		idx.compressAged(ctx, policy.CompressAfter)
+	}
+
+	if policy.MaxSizeBytes > 0 {
+		idx.evictOverCap(ctx, policy.MaxSizeBytes)
+	}
+
+	return idx.save()
+}
+
+// pruneVersions removes files of all but the newest keep versions of every namespace.
+// Entries are expected in oldest-first order.
+func (idx *retentionIndex) pruneVersions(ctx tcontext.TransferMetadata, keep int) {
+	kept := map[string]map[string]bool{}
+
+	// walk newest-first, keeping the first `keep` distinct versions seen per namespace
+	for i := len(idx.Entries) - 1; i >= 0; i-- {
+		e := idx.Entries[i]
+		versions := kept[e.Namespace]
+		if versions == nil {
+			versions = map[string]bool{}
+			kept[e.Namespace] = versions
+		}
+		if len(versions) < keep {
+			versions[e.Version] = true
+		}
+	}
+
+	idx.removeWhere(ctx, "version retention", func(e retentionEntry) bool {
+		return !kept[e.Namespace][e.Version]
+	})
+}
+
+// compressAged gzips files written longer ago than maxAge
+func (idx *retentionIndex) compressAged(ctx tcontext.TransferMetadata, maxAge time.Duration) {
+	cutoff := time.Now().Add(-maxAge)
+
+	for i, e := range idx.Entries {
+		if e.Compressed || e.WrittenAt.After(cutoff) {
+			continue
+		}
+
+		size, err := gzipFile(filepath.Join(idx.dir, e.File))
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Failed to compress aged SBOM", "file", e.File)
+			continue
+		}
+
+		idx.Entries[i].File = e.File + ".gz"
+		idx.Entries[i].Size = size
+		idx.Entries[i].Compressed = true
+		logger.LogDebug(ctx.Context, "Compressed aged SBOM", "file", idx.Entries[i].File, "size", size)
+	}
+}
+
+// evictOverCap removes the oldest files until the total size fits maxBytes,
+// always keeping the most recently written one.
+func (idx *retentionIndex) evictOverCap(ctx tcontext.TransferMetadata, maxBytes int64) {
+	var total int64
+	for _, e := range idx.Entries {
+		total += e.Size
+	}
+
+	evict := map[string]bool{}
+	for i := 0; i < len(idx.Entries)-1 && total > maxBytes; i++ {
+		evict[idx.Entries[i].File] = true
+		total -= idx.Entries[i].Size
+	}
+
+	idx.removeWhere(ctx, "size cap", func(e retentionEntry) bool {
+		return evict[e.File]
+	})
+}
+
+// removeWhere deletes the files of matching entries and drops them from the index
+func (idx *retentionIndex) removeWhere(ctx tcontext.TransferMetadata, reason string, match func(retentionEntry) bool) {
+	entries := idx.Entries[:0]
+	for _, e := range idx.Entries {
+		if !match(e) {
+			entries = append(entries, e)
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(idx.dir, e.File)); err != nil && !os.IsNotExist(err) {
+			logger.LogError(ctx.Context, err, "Failed to remove SBOM during retention", "file", e.File)
+			entries = append(entries, e)
+			continue
+		}
+		removeSignatures(filepath.Join(idx.dir, e.File))
+		logger.LogInfo(ctx.Context, "Removed SBOM by retention policy", "file", e.File, "namespace", e.Namespace, "version", e.Version, "reason", reason)
+	}
+	idx.Entries = entries
+}
+
+// removeSignatures deletes the signature files written next to a removed SBOM, if any
+func removeSignatures(path string) {
+	for _, suffix := range []string{verify.SuffixSignature, verify.SuffixCertificate, verify.SuffixAttestation} {
+		os.Remove(path + suffix)
+	}
+}
+
+// gzipFile replaces path with path.gz and returns the compressed size
+func gzipFile(path string) (int64, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return 0, err
+	}
+
+	gz := gzip.NewWriter(dst)
+	gz.Name = filepath.Base(path)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		os.Remove(path + ".gz")
+		return 0, err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return 0, err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path + ".gz")
+		return 0, err
+	}
+
+	info, err := os.Stat(path + ".gz")
+	if err != nil {
+		return 0, err
+	}
+
+	src.Close()
+	if err := os.Remove(path); err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package folder
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
+)
+
+// structurePresets are the named layouts --out-folder-structure accepts besides templates
+var structurePresets = map[string]string{
+	"flat":      "{filename}",
+	"namespace": "{namespace}/{filename}",
+	"versioned": "{namespace}/{version}/{filename}",
+}
+
+// structurePlaceholders are the values a layout template can refer to
+var structurePlaceholders = map[string]bool{
+	"namespace": true, // SBOM namespace, e.g. the owner/repo of GitHub SBOMs
+	"version":   true, // SBOM version, e.g. the release tag
+	"filename":  true, // base name of the file
+	"path":      true, // source path of the file, with its directories
+	"format":    true, // detected format, e.g. cyclonedx-json
+}
+
+var placeholderRe = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// Structure is the directory layout SBOMs are written in under the folder: a template of
+// slash-separated path segments with {placeholders}. A nil *Structure writes each SBOM at
+// its source path.
+type Structure struct {
+	template string
+}
+
+// ParseStructure parses a layout template or preset name, nil for an empty one. The template
+// must name the file with {filename} or {path}.
+func ParseStructure(structure string) (*Structure, error) {
+	if structure == "" {
+		return nil, nil
+	}
+	if preset, ok := structurePresets[structure]; ok {
+		structure = preset
+	}
+
+	for _, match := range placeholderRe.FindAllStringSubmatch(structure, -1) {
+		if !structurePlaceholders[match[1]] {
+			return nil, fmt.Errorf("unknown placeholder {%s} in %q (supported: {namespace}, {version}, {filename}, {path}, {format})", match[1], structure)
+		}
+	}
+	if !strings.Contains(structure, "{filename}") && !strings.Contains(structure, "{path}") {
+		return nil, fmt.Errorf("%q must contain {filename} or {path}, or be one of: flat, namespace, versioned", structure)
+	}
+	if strings.HasPrefix(structure, "/") {
+		return nil, fmt.Errorf("%q must be relative to the folder", structure)
+	}
+	return &Structure{template: structure}, nil
+}
+
+// String returns the layout template
+func (s *Structure) String() string {
+	if s == nil {
+		return "{path}"
+	}
+	return s.template
+}
+
+// Path returns where sbom is written under the folder, relative to it and slash-separated,
+// given the name the file is written as. Placeholders without a value render as "unknown".
+func (s *Structure) Path(sbom *iterator.SBOM, name string) string {
+	if s == nil {
+		return cleanRelative(name)
+	}
+
+	values := map[string]string{
+		"namespace": sbom.Namespace,
+		"version":   sbom.Version,
+		"filename":  path.Base(strings.ReplaceAll(name, "\\", "/")),
+		"path":      name,
+		"format":    strings.ToLower(string(sbomd.DetectFormat(sbom.Data))),
+	}
+	rendered := placeholderRe.ReplaceAllStringFunc(s.template, func(placeholder string) string {
+		value := cleanRelative(values[strings.Trim(placeholder, "{}")])
+		if value == "" {
+			return "unknown"
+		}
+		return value
+	})
+	return cleanRelative(rendered)
+}
+
+// cleanRelative turns p into a slash-separated path that stays within the folder, dropping
+// empty, "." and ".." segments
+func cleanRelative(p string) string {
+	var segments []string
+	for _, segment := range strings.Split(strings.ReplaceAll(p, "\\", "/"), "/") {
+		segment = strings.TrimSpace(segment)
+		if segment == "" || segment == "." || segment == ".." {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, "/")
+}
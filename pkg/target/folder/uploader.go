@@ -20,11 +20,14 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/google/uuid"
 	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/limiter"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/report"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/timing"
 	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
 )
 
 type SBOMUploader interface {
@@ -43,32 +46,50 @@ func (u *SequentialUploader) Upload(ctx tcontext.TransferMetadata, config *Folde
 	totalSBOMs := 0
 	successfullyUploaded := 0
 	failed := 0
+	collisions := utils.NewNameCollisions()
 
-	// space for proper logging
-	fmt.Println()
+	var retention *retentionIndex
+	if config.Retention.Enabled() {
+		if err := os.MkdirAll(config.FolderPath, 0o755); err != nil {
+			return fmt.Errorf("failed to create folder %s: %w", config.FolderPath, err)
+		}
+		idx, err := loadRetentionIndex(config.FolderPath)
+		if err != nil {
+			return fmt.Errorf("failed to load retention index: %w", err)
+		}
+		retention = idx
+	}
 
 	for {
 		sbom, err := iter.Next(ctx)
 		if err == io.EOF {
 			break
 		}
-		totalSBOMs++
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
 			return err
 		}
-		outputDir := config.FolderPath
+		totalSBOMs++
+		fileName := config.outputPath(sbom)
+
+		// another SBOM with different content was already written under this name in this run
+		if resolved, collided := collisions.Resolve(fileName, sbom.Data); collided {
+			logger.LogInfo(ctx.Context, "Duplicate file name with different content, writing with content-hash suffix", "file", fileName, "written_as", resolved, "namespace", sbom.Namespace)
+			fileName = resolved
+		}
+
+		outputFile := filepath.Join(config.FolderPath, filepath.FromSlash(fileName))
 
+		// the layout may place files in directories of their own
+		outputDir := filepath.Dir(outputFile)
 		if err := os.MkdirAll(outputDir, 0o755); err != nil {
 			logger.LogError(ctx.Context, err, "Failed to create folder", "path", outputDir)
 			return err
 		}
 
-		outputFile := filepath.Join(outputDir, sbom.Path)
-		if sbom.Path == "" {
-			outputFile = filepath.Join(outputDir, fmt.Sprintf("%s.sbom.json", uuid.New().String()))
-		}
-
 		if !config.Overwrite {
 
 			// skip if file exists(default behavior)
@@ -83,6 +104,7 @@ func (u *SequentialUploader) Upload(ctx tcontext.TransferMetadata, config *Folde
 
 				// unexpected error (not just "file doesn’t exist")
 				logger.LogError(ctx.Context, err, "Failed to check file existence", "path", outputFile)
+				report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: outputFile, Stage: report.StageUpload}, err)
 				continue
 			}
 
@@ -90,17 +112,47 @@ func (u *SequentialUploader) Upload(ctx tcontext.TransferMetadata, config *Folde
 		}
 
 		// write the SBOM file (either overwrite is true or file doesn’t exist)
-		if err := os.WriteFile(outputFile, sbom.Data, 0o644); err != nil {
+		err = timing.Time(ctx, timing.StageUpload, sbom.Path, func() error {
+			return limiter.Transfer(ctx, func() error {
+				if err := os.WriteFile(outputFile, sbom.Data, 0o644); err != nil {
+					return err
+				}
+				return writeSignatures(outputFile, sbom)
+			})
+		})
+		if err != nil {
 			logger.LogError(ctx.Context, err, "Failed to write SBOM file", "path", outputFile)
+			report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: outputFile, Stage: report.StageUpload}, err)
 			failed++
 			continue // Continue to next SBOM instead of returning error
 		}
 
 		successfullyUploaded++
+		report.RecordTransfer(ctx, sbom, outputFile)
 		logger.LogInfo(ctx.Context, "wrote", "path", outputFile)
+
+		if retention != nil {
+			retention.record(fileName, sbom.Namespace, sbom.Version, int64(len(sbom.Data)))
+			if err := retention.apply(ctx, config.Retention); err != nil {
+				logger.LogError(ctx.Context, err, "Failed to apply retention policy", "folder", config.FolderPath)
+			}
+		}
 	}
 
 	logger.LogInfo(ctx.Context, "wrote", "total", totalSBOMs, "success", successfullyUploaded, "failed", failed)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded, err)
+	}
 
 	return nil
 }
+
+// writeSignatures writes the signature files of the SBOM next to it, e.g. app.cdx.json.sig
+func writeSignatures(outputFile string, sbom *iterator.SBOM) error {
+	for _, suffix := range sbom.SignatureSuffixes() {
+		if err := os.WriteFile(outputFile+suffix, sbom.Signatures[suffix], 0o644); err != nil {
+			return fmt.Errorf("writing signature: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type GCSAdapter struct {
+	Config         *GCSConfig
+	Role           types.AdapterRole
+	ProcessingMode types.ProcessingMode
+	Overwrite      bool
+	Uploader       SBOMUploader
+	batchUploader  GCSBatchUploader
+}
+
+// AddCommandParams adds Google Cloud Storage-specific CLI flags
+func (a *GCSAdapter) AddCommandParams(cmd *cobra.Command) {
+	cmd.Flags().String("out-gcs-bucket", "", "Bucket name")
+	cmd.Flags().String("out-gcs-prefix", "", "Object name prefix")
+	cmd.Flags().String("out-gcs-credentials-file", "", "Service account key file (default: Application Default Credentials)")
+	cmd.Flags().String("out-gcs-endpoint-url", "", "Custom JSON API URL, e.g. fake-gcs-server (default: https://storage.googleapis.com)")
+	cmd.Flags().Bool("out-gcs-format-extensions", false, "Name objects after their detected format, e.g. .cdx.json, .spdx.json, .cdx.xml")
+	cmd.Flags().StringSlice("out-gcs-extension-map", nil, "Extensions of formats as format=extension, e.g. cyclonedx-json=.json (implies --out-gcs-format-extensions)")
+}
+
+// ParseAndValidateParams validates the Google Cloud Storage adapter params
+func (a *GCSAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
+	var (
+		bucketFlag, prefixFlag, credentialsFileFlag, endpointURLFlag string
+		missingFlags                                                 []string
+		invalidFlags                                                 []string
+	)
+
+	bucketFlag = "out-gcs-bucket"
+	prefixFlag = "out-gcs-prefix"
+	credentialsFileFlag = "out-gcs-credentials-file"
+	endpointURLFlag = "out-gcs-endpoint-url"
+	formatExtensionsFlag := "out-gcs-format-extensions"
+	extensionMapFlag := "out-gcs-extension-map"
+
+	var uploader SBOMUploader
+	if a.ProcessingMode == types.ProcessingMode(types.UploadSequential) {
+		uploader = &GCSSequentialUploader{}
+	} else if a.ProcessingMode == types.ProcessingMode(types.UploadParallel) {
+		uploader = &GCSParallelUploader{}
+	} else {
+		return fmt.Errorf("unsupported processing mode: %s", a.ProcessingMode)
+	}
+
+	// validate flags for Google Cloud Storage adapter, all flags should start with "out-gcs-"
+	err := utils.FlagValidation(cmd, types.GCSAdapterType, types.OutputAdapterFlagPrefix)
+	if err != nil {
+		return fmt.Errorf("gcs flag validation failed: %w", err)
+	}
+
+	bucketName, _ := cmd.Flags().GetString(bucketFlag)
+	if bucketName == "" {
+		missingFlags = append(missingFlags, bucketFlag)
+	}
+
+	// if prefix is empty, objects are uploaded at the root of the bucket
+	prefix, _ := cmd.Flags().GetString(prefixFlag)
+
+	// extract custom JSON API endpoint
+	endpointURL, _ := cmd.Flags().GetString(endpointURLFlag)
+	if endpointURL != "" {
+		if u, err := url.Parse(endpointURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be an http or https URL)", endpointURLFlag, endpointURL))
+		}
+	}
+
+	// without a key file, Application Default Credentials are used
+	credentialsFile, _ := cmd.Flags().GetString(credentialsFileFlag)
+	if credentialsFile != "" {
+		if _, err := os.Stat(credentialsFile); err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (file does not exist)", credentialsFileFlag, credentialsFile))
+		}
+	} else if endpointURL == "" {
+		logger.LogDebug(cmd.Context(), "Google Cloud Storage credentials file not provided, using Application Default Credentials")
+	}
+
+	// extract the extensions objects are named with
+	var extensions *sbom.ExtensionMap
+	formatExtensions, _ := cmd.Flags().GetBool(formatExtensionsFlag)
+	extensionMap, _ := cmd.Flags().GetStringSlice(extensionMapFlag)
+	if formatExtensions || len(extensionMap) > 0 {
+		if extensions, err = sbom.ParseExtensionMap(extensionMap); err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s: %v", extensionMapFlag, err))
+		}
+	}
+
+	if len(missingFlags) > 0 {
+		return fmt.Errorf("missing flags: %s", strings.Join(missingFlags, ", "))
+	}
+
+	if len(invalidFlags) > 0 {
+		return fmt.Errorf("invalid output adapter flag usage:\n %s\n\nUse 'sbommv transfer --help' for correct usage.", strings.Join(invalidFlags, "\n "))
+	}
+
+	cfg := NewGCSConfig()
+	cfg.ProcessingMode = a.ProcessingMode
+	cfg.BucketName = bucketName
+	cfg.Prefix = prefix
+	cfg.CredentialsFile = credentialsFile
+	cfg.EndpointURL = endpointURL
+	cfg.Overwrite = a.Overwrite
+	cfg.Extensions = extensions
+
+	a.Config = cfg
+	a.Uploader = uploader
+
+	return nil
+}
+
+// FetchSBOMs is not supported by the output adapter
+func (a *GCSAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.SBOMIterator, error) {
+	return nil, fmt.Errorf("Google Cloud Storage adapter does not support SBOM Fetching when it is in output adapter role")
+}
+
+// UploadSBOMs uploads SBOMs to the bucket
+func (a *GCSAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Starting SBOM upload", "mode", a.ProcessingMode, "overwrite", a.Config.Overwrite)
+	return a.Uploader.Upload(ctx, a.Config, iter)
+}
+
+// UploadBatch uploads a chunk of SBOMs with concurrent uploads, used with --batch-size
+func (a *GCSAdapter) UploadBatch(ctx tcontext.TransferMetadata, sboms []*iterator.SBOM) error {
+	return a.batchUploader.UploadBatch(ctx, a.Config, sboms)
+}
+
+// DryRun for Output Adapter: Simulates uploading SBOMs to the bucket
+func (a *GCSAdapter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
+	reporter := NewGCSReporter(false, "", a.Config.BucketName, a.Config.Prefix)
+	reporter.config = a.Config
+	return reporter.DryRun(ctx, iter)
+}
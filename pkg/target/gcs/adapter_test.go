@@ -0,0 +1,69 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseFlags returns the adapter configured from the given flags, and the validation error
+func parseFlags(t *testing.T, args ...string) (*GCSAdapter, error) {
+	t.Helper()
+	adapter := &GCSAdapter{Role: types.OutputAdapterRole, ProcessingMode: types.ProcessingMode(types.UploadSequential), Overwrite: true}
+	cmd := &cobra.Command{Use: "transfer"}
+	cmd.Flags().String("output-adapter", "gcs", "")
+	cmd.Flags().String("out-azblob-container", "", "flag of another output adapter")
+	adapter.AddCommandParams(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetContext(context.Background())
+	return adapter, adapter.ParseAndValidateParams(cmd)
+}
+
+func TestParseAndValidateParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		invalid string
+	}{
+		{"no bucket", []string{"--out-gcs-prefix=prod"}, "missing flags: out-gcs-bucket"},
+		{"missing credentials file", []string{"--out-gcs-bucket=sboms", "--out-gcs-credentials-file=" + filepath.Join(t.TempDir(), "absent.json")}, "absent.json (file does not exist)"},
+		{"endpoint without scheme", []string{"--out-gcs-bucket=sboms", "--out-gcs-endpoint-url=localhost:4443"}, "(must be an http or https URL)"},
+		{"malformed extension map", []string{"--out-gcs-bucket=sboms", "--out-gcs-extension-map=cyclonedx-json"}, "--out-gcs-extension-map:"},
+		{"flag of another adapter", []string{"--out-gcs-bucket=sboms", "--out-azblob-container=sboms"}, "flag --out-azblob-container is invalid"},
+		{"extension map", []string{"--out-gcs-bucket=sboms", "--out-gcs-endpoint-url=http://localhost:4443", "--out-gcs-extension-map=cyclonedx-json=.json"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, err := parseFlags(t, tt.args...)
+			if tt.invalid != "" {
+				assert.ErrorContains(t, err, tt.invalid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "sboms", adapter.Config.BucketName)
+			assert.True(t, adapter.Config.Overwrite)
+			require.NotNil(t, adapter.Config.Extensions)
+			assert.Equal(t, "app.json", adapter.Config.Extensions.Rename("app.cdx.json", []byte(appSBOM)))
+			assert.IsType(t, &GCSSequentialUploader{}, adapter.Uploader)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+
+	"github.com/interlynk-io/sbommv/pkg/retry"
+)
+
+// ErrObjectExists is returned by Upload when the object exists and may not be replaced
+var ErrObjectExists = errors.New("object already exists")
+
+// Client is a minimal client of the Cloud Storage JSON API, covering what the output adapter needs
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// Object is an object to upload
+type Object struct {
+	Name        string            `json:"name"`
+	ContentType string            `json:"contentType,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// Upload creates the object with data in a single multipart request, carrying its metadata
+// along. Unless overwrite is set, an existing object is left untouched and ErrObjectExists is
+// returned. Other failures are returned as *retry.HTTPError.
+func (c *Client) Upload(ctx context.Context, bucket string, object Object, data []byte, overwrite bool) error {
+	query := url.Values{}
+	query.Set("uploadType", "multipart")
+	if !overwrite {
+		// generation 0 matches only when no live object has the name
+		query.Set("ifGenerationMatch", "0")
+	}
+
+	body, contentType, err := multipartBody(object, data)
+	if err != nil {
+		return err
+	}
+
+	reqURL := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?%s", c.baseURL, url.PathEscape(bucket), query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPreconditionFailed && !overwrite {
+		return ErrObjectExists
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		httpErr := retry.NewHTTPError(resp)
+		if msg := errorMessage(resp); msg != "" {
+			httpErr.Err = fmt.Errorf("status: %d: %s", resp.StatusCode, msg)
+		}
+		return httpErr
+	}
+	return nil
+}
+
+// Exists reports whether the bucket holds a live object under name, reading its metadata.
+// Failures are returned as *retry.HTTPError.
+func (c *Client) Exists(ctx context.Context, bucket, name string) (bool, error) {
+	reqURL := fmt.Sprintf("%s/storage/v1/b/%s/o/%s", c.baseURL, url.PathEscape(bucket), url.PathEscape(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		httpErr := retry.NewHTTPError(resp)
+		if msg := errorMessage(resp); msg != "" {
+			httpErr.Err = fmt.Errorf("status: %d: %s", resp.StatusCode, msg)
+		}
+		return false, httpErr
+	}
+	return true, nil
+}
+
+// multipartBody builds the multipart/related body of an upload: the JSON object resource
+// followed by the content
+func multipartBody(object Object, data []byte) ([]byte, string, error) {
+	resource, err := json.Marshal(object)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json; charset=UTF-8"}})
+	if err != nil {
+		return nil, "", err
+	}
+	part.Write(resource)
+
+	part, err = writer.CreatePart(textproto.MIMEHeader{"Content-Type": {object.ContentType}})
+	if err != nil {
+		return nil, "", err
+	}
+	part.Write(data)
+
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "multipart/related; boundary=" + writer.Boundary(), nil
+}
+
+// errorMessage reads the message of a JSON API error response
+func errorMessage(resp *http.Response) string {
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	_ = json.Unmarshal(data, &body)
+	return body.Error.Message
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+)
+
+type GCSReporter struct {
+	verbose    bool
+	inputDir   string
+	bucketName string
+	prefix     string
+
+	// config, when set, makes the dry run look up every object in the bucket
+	config *GCSConfig
+}
+
+func NewGCSReporter(verbose bool, inputDir, bucketName, prefix string) *GCSReporter {
+	return &GCSReporter{
+		verbose:    verbose,
+		inputDir:   inputDir,
+		bucketName: bucketName,
+		prefix:     prefix,
+	}
+}
+
+func (s *GCSReporter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Dry-run mode: Displaying SBOMs uploaded to Google Cloud Storage")
+	processor := sbom.NewSBOMProcessor(s.inputDir, s.verbose)
+	sbomCount := 0
+	fmt.Println("\n📦 Google Cloud Storage Output Adapter Dry-Run")
+
+	preflight := utils.NewPreflight()
+	collisions := utils.NewNameCollisions()
+	var client *Client
+	var clientErr error
+	if s.config != nil {
+		if client, clientErr = s.config.GetGCSClient(ctx); clientErr != nil {
+			logger.LogInfo(ctx.Context, "Cannot look up existing objects", "bucket", s.bucketName, "error", clientErr)
+		}
+	}
+
+	for {
+		sbom, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
+			return err
+		}
+		processor.Update(sbom.Data, "", sbom.Path)
+		doc, err := processor.ProcessSBOMs()
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Failed to process SBOM")
+			return err
+		}
+		if s.inputDir != "" {
+			if err := processor.WriteSBOM(doc, ""); err != nil {
+				logger.LogError(ctx.Context, err, "Failed to write SBOM")
+				return err
+			}
+		}
+		if s.verbose {
+			fmt.Printf("\n-------------------- 📜 SBOM Content --------------------\n")
+			fmt.Printf("📂 Filename: %s\n", doc.Filename)
+			fmt.Printf("📦 Format %s | SpecVersion: %s\n\n", doc.Format, doc.SpecVersion)
+			fmt.Println(string(doc.Content))
+			fmt.Println("------------------------------------------------------")
+		}
+
+		if s.config == nil {
+			fmt.Printf(" - 📁 Would Upload to Bucket: %s | Prefix: %s \n",
+				s.bucketName, s.prefix)
+			sbomCount++
+			continue
+		}
+
+		// same object name as the upload, including the suffix of colliding names
+		name := objectName(s.config, sbom)
+		renamedFrom := ""
+		if resolved, collided := collisions.Resolve(name, sbom.Data); collided {
+			renamedFrom, name = name, resolved
+		}
+		name = path.Join(objectPrefix(s.config), name)
+
+		exists, err := false, clientErr
+		if client != nil {
+			exists, err = client.Exists(ctx.Context, s.bucketName, name)
+		}
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Failed to look up object", "bucket", s.bucketName, "object", name, "error", err)
+		}
+
+		status := preflight.Status(exists, err, s.config.Overwrite)
+		fmt.Printf(" - 📁 Would Upload to Bucket: %s | Object: %s [%s]%s\n",
+			s.bucketName, name, status, preflight.Notes(sbom.Conversion(), renamedFrom))
+		sbomCount++
+	}
+
+	fmt.Printf("\n📊 Total SBOMs to be uploaded: %d\n", sbomCount)
+	if s.config != nil {
+		preflight.Print()
+	}
+	logger.LogDebug(ctx.Context, "Dry-run completed", "total_sboms", sbomCount)
+
+	return nil
+}
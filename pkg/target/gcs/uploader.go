@@ -0,0 +1,267 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/limiter"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/retry"
+	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/timing"
+	"github.com/interlynk-io/sbommv/pkg/utils"
+)
+
+type SBOMUploader interface {
+	Upload(ctx tcontext.TransferMetadata, config *GCSConfig, iter iterator.SBOMIterator) error
+}
+
+type (
+	GCSSequentialUploader struct{}
+	GCSParallelUploader   struct{}
+)
+
+// Upload uploads SBOMs to the bucket in parallel
+func (u *GCSParallelUploader) Upload(ctx tcontext.TransferMetadata, config *GCSConfig, iter iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Writing SBOMs concurrently", "bucket", config.BucketName, "prefix", config.Prefix)
+
+	client, err := config.GetGCSClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Google Cloud Storage client: %w", err)
+	}
+
+	// retrieve all SBOMs from iterator
+	var sbomList []*iterator.SBOM
+	for {
+		sbom, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
+			continue
+		}
+		sbomList = append(sbomList, sbom)
+	}
+
+	totalSBOMs, successfullyUploaded := uploadObjects(ctx, client, config, objectPrefix(config), utils.NewNameCollisions(), sbomList)
+
+	logger.LogInfo(ctx.Context, "upload", "total", totalSBOMs, "success", successfullyUploaded, "failed", totalSBOMs-successfullyUploaded)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded, err)
+	}
+	if totalSBOMs == 0 {
+		return errdefs.NotFound(fmt.Errorf("no SBOMs found to upload"))
+	}
+
+	return nil
+}
+
+// Upload uploads SBOMs to the bucket one by one
+func (u *GCSSequentialUploader) Upload(ctx tcontext.TransferMetadata, config *GCSConfig, iter iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Writing SBOMs sequentially", "bucket", config.BucketName, "prefix", config.Prefix)
+	totalSBOMs := 0
+	successfullyUploaded := 0
+
+	client, err := config.GetGCSClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Google Cloud Storage client: %w", err)
+	}
+
+	prefix := objectPrefix(config)
+	collisions := utils.NewNameCollisions()
+
+	for {
+		sbom, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		totalSBOMs++
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
+			continue
+		}
+
+		fileName := resolveObjectName(ctx, config, collisions, sbom)
+		name := path.Join(prefix, fileName)
+
+		err = uploadObject(ctx, client, config, name, sbom)
+		if errors.Is(err, ErrObjectExists) {
+			logger.LogDebug(ctx.Context, "Object already exists, skipping upload (overwrite=false)", "bucket", config.BucketName, "name", name)
+			successfullyUploaded++
+			continue
+		}
+		if err != nil {
+			logger.LogError(ctx.Context, err, "Failed to upload SBOM", "bucket", config.BucketName, "name", name)
+			report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: config.BucketName + "/" + name, Stage: report.StageUpload}, err)
+			continue
+		}
+
+		successfullyUploaded++
+		report.RecordTransfer(ctx, sbom, config.BucketName+"/"+name)
+		logger.LogDebug(ctx.Context, "Uploaded SBOM", "bucket", config.BucketName, "name", name, "size", len(sbom.Data))
+		logger.LogInfo(ctx.Context, "upload", "success", true, "bucket", config.BucketName, "prefix", config.Prefix, "filename", fileName)
+	}
+
+	logger.LogInfo(ctx.Context, "upload", "total", totalSBOMs, "success", successfullyUploaded, "failed", totalSBOMs-successfullyUploaded)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded, err)
+	}
+
+	return nil
+}
+
+// GCSBatchUploader uploads the batches the engine hands over with --batch-size, each batch
+// as concurrent uploads. The client and the object name collisions are shared by all batches of a run.
+type GCSBatchUploader struct {
+	client     *Client
+	prefix     string
+	collisions *utils.NameCollisions
+}
+
+// UploadBatch uploads one batch of SBOMs, failing when any of them couldn't be uploaded
+func (u *GCSBatchUploader) UploadBatch(ctx tcontext.TransferMetadata, config *GCSConfig, sboms []*iterator.SBOM) error {
+	if u.client == nil {
+		client, err := config.GetGCSClient(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create Google Cloud Storage client: %w", err)
+		}
+		u.client = client
+		u.collisions = utils.NewNameCollisions()
+		u.prefix = objectPrefix(config)
+	}
+
+	attempted, uploaded := uploadObjects(ctx, u.client, config, u.prefix, u.collisions, sboms)
+	if uploaded < attempted {
+		return fmt.Errorf("failed to upload %d of %d SBOMs", attempted-uploaded, attempted)
+	}
+	return nil
+}
+
+// objectPrefix returns the prefix objects are named with, ending with "/" when set
+func objectPrefix(config *GCSConfig) string {
+	prefix := config.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix = prefix + "/"
+	}
+	return prefix
+}
+
+// uploadObjects uploads SBOMs with a few concurrent uploads, stopping early when the transfer is
+// cancelled. It returns how many uploads were attempted and how many succeeded.
+func uploadObjects(ctx tcontext.TransferMetadata, client *Client, config *GCSConfig, prefix string, collisions *utils.NameCollisions, sboms []*iterator.SBOM) (int, int) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	attempted, uploaded := 0, 0
+	const maxConcurrency = 3
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	for _, sbom := range sboms {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(sbom *iterator.SBOM) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			fileName := resolveObjectName(ctx, config, collisions, sbom)
+			name := path.Join(prefix, fileName)
+
+			err := uploadObject(ctx, client, config, name, sbom)
+
+			mu.Lock()
+			defer mu.Unlock()
+			attempted++
+			if errors.Is(err, ErrObjectExists) {
+				logger.LogDebug(ctx.Context, "Object already exists, skipping upload (overwrite=false)", "bucket", config.BucketName, "name", name)
+				uploaded++
+				return
+			}
+			if err != nil {
+				logger.LogError(ctx.Context, err, "Failed to upload SBOM", "bucket", config.BucketName, "name", name)
+				report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: config.BucketName + "/" + name, Stage: report.StageUpload}, err)
+				return
+			}
+			uploaded++
+			report.RecordTransfer(ctx, sbom, config.BucketName+"/"+name)
+			logger.LogDebug(ctx.Context, "Uploaded SBOM", "bucket", config.BucketName, "name", name, "size", len(sbom.Data))
+			logger.LogInfo(ctx.Context, "upload", "success", true, "bucket", config.BucketName, "prefix", config.Prefix, "filename", fileName)
+		}(sbom)
+	}
+
+	wg.Wait()
+	return attempted, uploaded
+}
+
+// objectMetadata returns the custom metadata attached to every uploaded object, currently the
+// run ID so objects can be traced back to the transfer that wrote them
+func objectMetadata(ctx tcontext.TransferMetadata) map[string]string {
+	runID := ctx.RunID()
+	if runID == "" {
+		return nil
+	}
+	return map[string]string{"sbommv-run-id": runID}
+}
+
+// resolveObjectName returns the object name for the SBOM, renamed after its detected format when
+// --out-gcs-format-extensions is set, adding a content-hash suffix when a different SBOM was
+// already uploaded under the same name in this run.
+func resolveObjectName(ctx tcontext.TransferMetadata, config *GCSConfig, collisions *utils.NameCollisions, sbom *iterator.SBOM) string {
+	resolved, collided := collisions.Resolve(objectName(config, sbom), sbom.Data)
+	if collided {
+		logger.LogInfo(ctx.Context, "Duplicate object name with different content, uploading with content-hash suffix", "file", sbom.Path, "uploaded_as", resolved, "namespace", sbom.Namespace)
+	}
+	return resolved
+}
+
+// objectName returns the object name of an SBOM before name collisions are resolved
+func objectName(config *GCSConfig, sbom *iterator.SBOM) string {
+	if config.Extensions != nil {
+		return config.Extensions.Rename(sbom.Path, sbom.Data)
+	}
+	return sbom.Path
+}
+
+// uploadObject uploads a single SBOM within the engine-wide transfer budget. Without
+// --overwrite an existing object is kept and ErrObjectExists is returned.
+func uploadObject(ctx tcontext.TransferMetadata, client *Client, config *GCSConfig, name string, sbom *iterator.SBOM) error {
+	object := Object{Name: name, ContentType: sbomd.ContentType(sbom.Data), Metadata: objectMetadata(ctx)}
+	return timing.Time(ctx, timing.StageUpload, sbom.Path, func() error {
+		return retry.Do(ctx, config.BucketName+"/"+name, func() error {
+			return limiter.Transfer(ctx, func() error {
+				return client.Upload(ctx.Context, config.BucketName, object, sbom.Data, config.Overwrite)
+			})
+		})
+	})
+}
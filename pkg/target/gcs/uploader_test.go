@@ -0,0 +1,158 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const appSBOM = `{"bomFormat":"CycloneDX","specVersion":"1.5","version":1,"metadata":{"component":{"type":"application","name":"app","version":"1.0.0"}}}`
+
+type storedObject struct {
+	Object
+	data string
+}
+
+// gcsServer accepts multipart uploads to bucket "sboms" the way fake-gcs-server does, honoring
+// ifGenerationMatch=0, and refuses objects named locked*, as for an object under retention
+type gcsServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]storedObject
+}
+
+func newGCSServer(t *testing.T, objects map[string]storedObject) *gcsServer {
+	s := &gcsServer{objects: objects}
+	s.Server = httptest.NewServer(s)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *gcsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fail := func(status int, message string) {
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"error":{"code":%d,"message":%q}}`, status, message)
+	}
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Method != http.MethodPost || r.URL.Path != "/upload/storage/v1/b/sboms/o" || r.URL.Query().Get("uploadType") != "multipart" || mediaType != "multipart/related" {
+		fail(http.StatusBadRequest, "unexpected request")
+		return
+	}
+
+	parts := multipart.NewReader(r.Body, params["boundary"])
+	var object storedObject
+	part, err := parts.NextPart()
+	if err != nil || json.NewDecoder(part).Decode(&object.Object) != nil {
+		fail(http.StatusBadRequest, "invalid object resource")
+		return
+	}
+	part, err = parts.NextPart()
+	if err != nil || part.Header.Get("Content-Type") != object.ContentType {
+		fail(http.StatusBadRequest, "invalid object data")
+		return
+	}
+	data, _ := io.ReadAll(part)
+	object.data = string(data)
+
+	if strings.HasPrefix(object.Name, "prod/locked") {
+		fail(http.StatusForbidden, "Object 'sboms/"+object.Name+"' is under active Event-Based hold")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[object.Name]; ok && r.URL.Query().Get("ifGenerationMatch") == "0" {
+		fail(http.StatusPreconditionFailed, "At least one of the pre-conditions you specified did not hold.")
+		return
+	}
+	s.objects[object.Name] = object
+	json.NewEncoder(w).Encode(object.Object)
+}
+
+func TestUploadObjects(t *testing.T) {
+	stale := storedObject{Object{Name: "prod/kept.json", ContentType: "application/vnd.cyclonedx+json"}, "stale"}
+	updated := strings.Replace(appSBOM, "1.0.0", "1.0.1", 1)
+
+	for _, uploader := range []SBOMUploader{&GCSSequentialUploader{}, &GCSParallelUploader{}} {
+		for _, overwrite := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%T overwrite=%t", uploader, overwrite), func(t *testing.T) {
+				ctx := *tcontext.NewTransferMetadata(context.Background())
+				ctx.SetRunID("run-1")
+				server := newGCSServer(t, map[string]storedObject{stale.Name: stale})
+
+				config := NewGCSConfig()
+				config.EndpointURL = server.URL
+				config.BucketName = "sboms"
+				config.Prefix = "prod"
+				config.Overwrite = overwrite
+
+				err := uploader.Upload(ctx, config, iterator.NewMemoryIterator([]*iterator.SBOM{
+					{Path: "app/bom.json", Data: []byte(appSBOM)},
+					{Path: "kept.json", Data: []byte(updated)},
+					{Path: "locked.json", Data: []byte(appSBOM)},
+				}))
+				require.NoError(t, err)
+
+				kept := stale
+				if overwrite {
+					kept = storedObject{Object{Name: "prod/kept.json", ContentType: "application/vnd.cyclonedx+json", Metadata: map[string]string{"sbommv-run-id": "run-1"}}, updated}
+				}
+				assert.Equal(t, map[string]storedObject{
+					"prod/app/bom.json": {Object{Name: "prod/app/bom.json", ContentType: "application/vnd.cyclonedx+json", Metadata: map[string]string{"sbommv-run-id": "run-1"}}, appSBOM},
+					"prod/kept.json":    kept,
+				}, server.objects)
+			})
+		}
+	}
+}
+
+func TestBatchUploadReportsFailures(t *testing.T) {
+	ctx := *tcontext.NewTransferMetadata(context.Background())
+	server := newGCSServer(t, map[string]storedObject{})
+
+	config := NewGCSConfig()
+	config.EndpointURL = server.URL + "/"
+	config.BucketName = "sboms"
+	config.Prefix = "prod/"
+
+	var uploader GCSBatchUploader
+	err := uploader.UploadBatch(ctx, config, []*iterator.SBOM{
+		{Path: "app.json", Data: []byte(appSBOM)},
+		{Path: "locked.json", Data: []byte(appSBOM)},
+	})
+	assert.EqualError(t, err, "failed to upload 1 of 2 SBOMs")
+	assert.Contains(t, server.objects, "prod/app.json")
+
+	client, err := config.GetGCSClient(ctx)
+	require.NoError(t, err)
+	err = client.Upload(ctx.Context, "sboms", Object{Name: "prod/locked.json", ContentType: "application/json"}, []byte("{}"), true)
+	assert.EqualError(t, err, "status: 403: Object 'sboms/prod/locked.json' is under active Event-Based hold")
+}
@@ -15,6 +15,7 @@
 package interlynk
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -30,11 +31,15 @@ import (
 	"github.com/spf13/viper"
 )
 
+// allowedProjectEnvs are the project environments of an Interlynk project group
+var allowedProjectEnvs = map[string]bool{"default": true, "development": true, "production": true}
+
 // InterlynkAdapter manages SBOM uploads to the Interlynk service.
 type InterlynkAdapter struct {
 	// Config fields
 	ProjectName    string
 	ProjectVersion string
+	NameTemplate   *utils.ProjectNameTemplate // names each SBOM's project group, nil names it after the SBOM
 
 	ProjectEnv string
 
@@ -48,18 +53,23 @@ type InterlynkAdapter struct {
 	ProcessingMode types.ProcessingMode
 
 	Overwrite bool
+
+	// shared by the batches the engine hands over with --batch-size
+	batchClient *Client
+	batchGroups *projectGroups
 }
 
 // AddCommandParams adds GitHub-specific CLI flags
 func (i *InterlynkAdapter) AddCommandParams(cmd *cobra.Command) {
 	cmd.Flags().String("out-interlynk-url", "https://api.interlynk.io/lynkapi", "Interlynk API URL")
 	cmd.Flags().String("out-interlynk-project-name", "", "Interlynk Project Name")
+	cmd.Flags().String("out-interlynk-project-name-template", "", "Go template naming the project group of each SBOM, e.g. '{{.Owner}}/{{.Repo}}' (fields: Owner, Repo, Namespace, Version, Component, ComponentVersion, Filename, Path, Format, Source)")
 	cmd.Flags().String("out-interlynk-project-env", "default", "Interlynk Project Environment")
 }
 
 // ParseAndValidateParams validates the Interlynk adapter params
 func (i *InterlynkAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
-	var urlFlag, projectNameFlag, projectEnvFlag string
+	var urlFlag, projectNameFlag, projectNameTemplateFlag, projectEnvFlag string
 	var missingFlags []string
 	var invalidFlags []string
 
@@ -71,6 +81,7 @@ func (i *InterlynkAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	case types.OutputAdapterRole:
 		urlFlag = "out-interlynk-url"
 		projectNameFlag = "out-interlynk-project-name"
+		projectNameTemplateFlag = "out-interlynk-project-name-template"
 		projectEnvFlag = "out-interlynk-project-env"
 
 	default:
@@ -86,6 +97,7 @@ func (i *InterlynkAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	// Get flags
 	url, _ := cmd.Flags().GetString(urlFlag)
 	projectName, _ := cmd.Flags().GetString(projectNameFlag)
+	projectNameTemplate, _ := cmd.Flags().GetString(projectNameTemplateFlag)
 	projectEnv, _ := cmd.Flags().GetString(projectEnvFlag)
 
 	// Check if INTERLYNK_SECURITY_TOKEN is set
@@ -99,9 +111,16 @@ func (i *InterlynkAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 		invalidFlags = append(invalidFlags, fmt.Sprintf("invalid Interlynk API URL format: %s", url))
 	}
 
+	nameTemplate, err := utils.ParseProjectNameTemplate(projectNameTemplate)
+	if err != nil {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s: %v", projectNameTemplateFlag, err))
+	}
+	if nameTemplate != nil && projectName != "" {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s and --%s are mutually exclusive", projectNameTemplateFlag, projectNameFlag))
+	}
+
 	// Restrict `--out-interlynk-project-env` to only allowed values
-	allowedEnvs := map[string]bool{"default": true, "development": true, "production": true}
-	if !allowedEnvs[projectEnv] {
+	if !allowedProjectEnvs[projectEnv] {
 		invalidFlags = append(invalidFlags, fmt.Sprintf("invalid project environment: %s (allowed values: default, development, production)", projectEnv))
 	}
 
@@ -121,13 +140,18 @@ func (i *InterlynkAdapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	// Assign values to struct
 	i.BaseURL = url
 	i.ProjectName = projectName
+	i.NameTemplate = nameTemplate
 	i.ProjectEnv = projectEnv
 	i.ApiKey = token
-	i.settings = types.UploadSettings{ProcessingMode: types.UploadMode(types.UploadSequential)}
+	i.settings = types.UploadSettings{ProcessingMode: types.UploadMode(i.ProcessingMode)}
+	if i.settings.ProcessingMode == "" {
+		i.settings.ProcessingMode = types.UploadSequential
+	}
 
 	logger.LogDebug(cmd.Context(), "Interlynk parameters validated and assigned",
 		"url", i.BaseURL,
 		"project_name", i.ProjectName,
+		"project_name_template", i.NameTemplate.String(),
 		"project_env", i.ProjectEnv,
 		"overwrite", i.Overwrite,
 		"processing_mode", i.settings.ProcessingMode,
@@ -144,32 +168,23 @@ func (i *InterlynkAdapter) FetchSBOMs(ctx tcontext.TransferMetadata) (iterator.S
 func (i *InterlynkAdapter) UploadSBOMs(ctx tcontext.TransferMetadata, iterator iterator.SBOMIterator) error {
 	logger.LogDebug(ctx.Context, "Starting SBOM upload", "mode", i.settings.ProcessingMode)
 
-	if i.settings.ProcessingMode != "sequential" {
-		return fmt.Errorf("unsupported processing mode: %s", i.settings.ProcessingMode) // Future-proofed for parallel & batch
-	}
-
 	switch i.settings.ProcessingMode {
 
 	case types.UploadParallel:
-		// TODO: cuncurrent upload: As soon as we get the SBOM, upload it
-		// i.uploadParallel()
-		return fmt.Errorf("processing mode %q not yet implemented", i.settings.ProcessingMode)
+		// Parallel Processing: a pool of workers uploads SBOMs as soon as they are fetched
+		return i.uploadParallel(ctx, iterator)
 
 	case types.UploadBatching:
-		// TODO: hybrid of sequential + parallel
-		// i.uploadBatch()
-		return fmt.Errorf("processing mode %q not yet implemented", i.settings.ProcessingMode)
+		// Batch Processing: read a chunk of SBOMs, upload it concurrently, repeat
+		return i.uploadBatch(ctx, iterator)
 
 	case types.UploadSequential:
 		// Sequential Processing: Fetch SBOM → Upload → Repeat
-		i.uploadSequential(ctx, iterator)
+		return i.uploadSequential(ctx, iterator)
 
 	default:
-		//
 		return fmt.Errorf("invalid processing mode: %q", i.settings.ProcessingMode)
 	}
-
-	return nil
 }
 
 // uploadSequential handles sequential SBOM processing and uploading
@@ -177,63 +192,36 @@ func (i *InterlynkAdapter) uploadSequential(ctx tcontext.TransferMetadata, sboms
 	logger.LogDebug(ctx.Context, "Uploading SBOMs in sequential mode")
 
 	// Initialize Interlynk API client
-	client := NewClient(Config{
-		Token:       i.ApiKey,
-		APIURL:      i.BaseURL,
-		ProjectName: i.ProjectName,
-		ProjectEnv:  i.ProjectEnv,
-	})
-
-	errorCount := 0
-	maxRetries := 5
+	client := i.newClient()
+	groups := newProjectGroups()
+
 	totalSBOMs := 0
 	successfullyUploaded := 0
 
-	// space for proper logging
-	fmt.Println()
-
 	for {
 		sbom, err := sboms.Next(ctx)
 		if err == io.EOF {
 			break
 		}
-		totalSBOMs++
-		if err != nil {
-			logger.LogInfo(ctx.Context, "error", err)
-			errorCount++
-			if errorCount >= maxRetries {
-				break
-			}
-			continue
+		if ctx.Err() != nil {
+			break
 		}
-		errorCount = 0 // Reset error counter on successful iteration
-
-		logger.LogDebug(ctx.Context, "Uploading SBOM", "file", sbom.Path, "data size", len(sbom.Data))
-
-		sourceAdapter := ctx.Value("source")
-
-		fmt.Println("++++ sbom.Namespace: ", sbom.Namespace)
-		finalProjectName := ConstructInterlynkProjectName(ctx, i.ProjectName, sbom.Namespace, sbom.Path, sbom.Data, sourceAdapter.(string))
-		projectID, projectName, err := client.FindOrCreateProjectGroup(ctx, finalProjectName)
 		if err != nil {
+			// iterator errors are budgeted by the engine, not counted as failed uploads
 			logger.LogInfo(ctx.Context, "error", err)
 			continue
 		}
-		logger.LogDebug(ctx.Context, "SBOMs preparing to upload", "name", projectName, "id", projectID)
+		totalSBOMs++
 
-		// Upload SBOM content (stored in memory)
-		err = client.UploadSBOM(ctx, projectID, sbom.Data)
-		if err != nil {
-			logger.LogInfo(ctx.Context, "error", "file", sbom.Path, "project name", projectName)
-		} else {
+		if i.uploadOne(ctx, client, groups, sbom) {
 			successfullyUploaded++
-			logger.LogDebug(ctx.Context, "upload", "file", sbom.Path, "project name", projectName)
 		}
-		logger.LogInfo(ctx.Context, "upload", "success", true, "project", finalProjectName, "file", sbom.Path)
-
 	}
 
-	logger.LogInfo(ctx.Context, "upload", "sboms", totalSBOMs, "success", successfullyUploaded, "failed", errorCount)
+	logger.LogInfo(ctx.Context, "upload", "sboms", totalSBOMs, "success", successfullyUploaded, "failed", totalSBOMs-successfullyUploaded)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded, err)
+	}
 	return nil
 }
 
@@ -251,7 +239,13 @@ func (i *InterlynkAdapter) DryRun(ctx tcontext.TransferMetadata, sbomIterator it
 	processor := sbom.NewSBOMProcessor("", false)
 
 	// Step 3: Organize SBOMs into Projects
-	projectSBOMs := make(map[string][]sbom.SBOMDocument)
+	type projectKey struct{ name, env string }
+	type projectSBOM struct {
+		doc        sbom.SBOMDocument
+		conversion string
+	}
+	projectSBOMs := make(map[projectKey][]projectSBOM)
+	var projects []projectKey
 	totalSBOMs := 0
 	uniqueFormats := make(map[string]struct{})
 
@@ -260,6 +254,9 @@ func (i *InterlynkAdapter) DryRun(ctx tcontext.TransferMetadata, sbomIterator it
 		if err == io.EOF {
 			break
 		}
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
 			continue
@@ -275,11 +272,27 @@ func (i *InterlynkAdapter) DryRun(ctx tcontext.TransferMetadata, sbomIterator it
 			continue
 		}
 
-		sourceAdapter := ctx.Value("source")
+		finalProjectName, err := i.projectName(ctx, sbom)
+		if err != nil {
+			fmt.Printf("- ⚠️  Would fail to name the project group of %s: %v\n", sbom.Path, err)
+			continue
+		}
+		finalProjectName, _ = sbom.Annotations.Project(finalProjectName, "")
+
+		env := i.ProjectEnv
+		if sbom.Annotations != nil && sbom.Annotations.Environment != "" {
+			env = sbom.Annotations.Environment
+			if !allowedProjectEnvs[env] {
+				fmt.Printf("- ⚠️  Would fail to upload %s: invalid project environment %q in metadata file\n", sbom.Path, env)
+				continue
+			}
+		}
 
-		finalProjectName := ConstructInterlynkProjectName(ctx, i.ProjectName, sbom.Namespace, sbom.Path, sbom.Data, sourceAdapter.(string))
-		projectKey := fmt.Sprintf("%s", finalProjectName)
-		projectSBOMs[projectKey] = append(projectSBOMs[projectKey], doc)
+		key := projectKey{name: finalProjectName, env: env}
+		if _, ok := projectSBOMs[key]; !ok {
+			projects = append(projects, key)
+		}
+		projectSBOMs[key] = append(projectSBOMs[key], projectSBOM{doc: doc, conversion: sbom.Conversion()})
 		totalSBOMs++
 		uniqueFormats[string(doc.Format)] = struct{}{}
 	}
@@ -293,15 +306,43 @@ func (i *InterlynkAdapter) DryRun(ctx tcontext.TransferMetadata, sbomIterator it
 	fmt.Printf("📦 Unique Formats: %s\n", formatSetToString(uniqueFormats))
 	fmt.Println()
 
-	// Step 5: Print Project Details
-	for project, sboms := range projectSBOMs {
-		fmt.Printf("📌 Project: %s → %d SBOMs\n", project, len(sboms))
-		for _, doc := range sboms {
-			fmt.Printf("   - 📁  | Format: %s | SpecVersion: %s | Size: %d KB | Filename: %s\n",
-				doc.Format, doc.SpecVersion, len(doc.Content)/1024, doc.Filename)
+	// Step 5: Print Project Details, looking up whether each project group exists. Uploads
+	// add a version to the project group, nothing is replaced.
+	client := i.newClient()
+	preflight := utils.NewPreflight()
+	for _, project := range projects {
+		status := projectGroupStatus(ctx, client, project.name, project.env)
+		fmt.Printf("📌 Project: %s (%s) → %d SBOMs [%s]\n", project.name, project.env, len(projectSBOMs[project]), status)
+		for _, entry := range projectSBOMs[project] {
+			preflight.Count(status)
+			fmt.Printf("   - 📁  | Format: %s | SpecVersion: %s | Size: %d KB | Filename: %s%s\n",
+				entry.doc.Format, entry.doc.SpecVersion, len(entry.doc.Content)/1024, entry.doc.Filename, preflight.Notes(entry.conversion, ""))
 		}
 	}
+	fmt.Println()
+	preflight.Print()
 
 	fmt.Println("\n✅ Dry-run completed. No data was uploaded to Interlynk.")
 	return nil
 }
+
+// Interlynk specific preflight statuses
+const (
+	preflightNewGroup      = "new project group, would create"
+	preflightExistingGroup = "existing project group, would add a version"
+)
+
+// projectGroupStatus looks up the project group SBOMs would be uploaded to and returns what
+// the upload would do to it
+func projectGroupStatus(ctx tcontext.TransferMetadata, client *Client, name, env string) string {
+	_, err := client.FindProjectGroup(ctx, name, env)
+	switch {
+	case err == nil:
+		return preflightExistingGroup
+	case errors.Is(err, errProjectGroupNotFound):
+		return preflightNewGroup
+	default:
+		logger.LogDebug(ctx.Context, "Failed to look up project group", "name", name, "env", env, "error", err)
+		return utils.PreflightUnknown
+	}
+}
@@ -18,6 +18,7 @@ package interlynk
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -25,7 +26,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/interlynk-io/sbommv/pkg/limiter"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/retry"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 )
 
@@ -42,6 +45,10 @@ mutation uploadSbom($doc: Upload!, $projectId: ID!) {
 }
 `
 
+// errProjectGroupNotFound is returned by FindProjectGroup when no project group has the name,
+// the only case a project group is created for
+var errProjectGroupNotFound = errors.New("project group not found")
+
 type graphQLRequest struct {
 	Query     string                 `json:"query"`
 	Variables map[string]interface{} `json:"variables,omitempty"`
@@ -94,21 +101,36 @@ func NewClient(config Config) *Client {
 	}
 }
 
-func (c *Client) FindOrCreateProjectGroup(ctx tcontext.TransferMetadata, finalProjectName string) (string, string, error) {
+// FindOrCreateProjectGroup returns the ID of the project group's environment, creating the
+// project group when needed. An empty env is the client's configured environment.
+func (c *Client) FindOrCreateProjectGroup(ctx tcontext.TransferMetadata, finalProjectName, env string) (string, string, error) {
 	logger.LogDebug(ctx.Context, "Finding or creating project group", "name", finalProjectName)
 
 	logger.LogDebug(ctx.Context, "Project Details", "name", finalProjectName)
 
-	env := c.ProjectEnv
+	if env == "" {
+		env = c.ProjectEnv
+	}
 
 	envID, err := c.FindProjectGroup(ctx, finalProjectName, env)
+	if err == nil {
+		logger.LogDebug(ctx.Context, "Reusing existing project group", "name", finalProjectName, "env", env, "id", envID)
+		return envID, finalProjectName, nil
+	}
+	// failed lookups must not create duplicates, only missing project groups are created
+	if !errors.Is(err, errProjectGroupNotFound) {
+		return "", "", fmt.Errorf("failed to look up project %s on env %s: %w", finalProjectName, env, err)
+	}
+
+	envID, err = c.CreateProjectGroup(ctx, finalProjectName, env)
 	if err != nil {
-		// create project if the project is not present in the interlynk
-		envID, err = c.CreateProjectGroup(ctx, finalProjectName, env)
-		if err != nil {
-			return "", "", fmt.Errorf("failed to create project: %s on env %s ", finalProjectName, env)
+		// another run may have created it since the lookup
+		if existingID, findErr := c.FindProjectGroup(ctx, finalProjectName, env); findErr == nil {
+			return existingID, finalProjectName, nil
 		}
+		return "", "", fmt.Errorf("failed to create project: %s on env %s: %w", finalProjectName, env, err)
 	}
+	logger.LogDebug(ctx.Context, "Created project group", "name", finalProjectName, "env", env, "id", envID)
 
 	return envID, finalProjectName, nil
 }
@@ -121,14 +143,17 @@ func (c *Client) UploadSBOM(ctx tcontext.TransferMetadata, envID string, sbomDat
 		return fmt.Errorf("SBOM data is empty")
 	}
 
-	// Create a context-aware request with appropriate timeout
-	req, err := c.createUploadRequest(ctx, envID, sbomData)
-	if err != nil {
-		return fmt.Errorf("preparing request: %w", err)
-	}
-
-	// Execute request with retry logic
-	return c.executeUploadRequest(ctx, req)
+	// Execute request with retry logic, within the engine-wide transfer budget
+	return retry.Do(ctx, envID, func() error {
+		return limiter.Transfer(ctx, func() error {
+			// a fresh request per attempt, the previous one consumed the body
+			req, err := c.createUploadRequest(ctx, envID, sbomData)
+			if err != nil {
+				return fmt.Errorf("preparing request: %w", err)
+			}
+			return c.executeUploadRequest(ctx, req)
+		})
+	})
 }
 
 func (c *Client) createUploadRequest(ctx tcontext.TransferMetadata, projectID string, sbomData []byte) (*http.Request, error) {
@@ -229,6 +254,10 @@ func (c *Client) executeUploadRequest(ctx tcontext.TransferMetadata, req *http.R
 		return fmt.Errorf("reading response: %w", err)
 	}
 
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("upload request failed with %w", retry.NewHTTPError(resp))
+	}
+
 	// Parse response
 	var response struct {
 		Data struct {
@@ -258,6 +287,8 @@ func (c *Client) executeUploadRequest(ctx tcontext.TransferMetadata, req *http.R
 	return nil
 }
 
+// FindProjectGroup returns the ID of the env environment of the project group with exactly this
+// name, errProjectGroupNotFound when there is none
 func (c *Client) FindProjectGroup(ctx tcontext.TransferMetadata, name string, env string) (string, error) {
 	logger.LogDebug(ctx.Context, "Finding project group", "name", name, "env", env)
 	const findProjectGroupMutation = `
@@ -312,6 +343,10 @@ func (c *Client) FindProjectGroup(ctx tcontext.TransferMetadata, name string, en
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("project group lookup failed with %w", retry.NewHTTPError(resp))
+	}
+
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("failed to read response body: %w", err)
@@ -337,35 +372,34 @@ func (c *Client) FindProjectGroup(ctx tcontext.TransferMetadata, name string, en
 				} `json:"projectGroups"`
 			} `json:"organization"`
 		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
 	}
 
 	if err := json.Unmarshal(respBody, &response); err != nil {
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	if len(response.Data.Organization.ProjectGroups.Nodes) == 0 {
-		return "", fmt.Errorf("no project groups found")
+	if len(response.Errors) > 0 {
+		return "", fmt.Errorf("GraphQL error: %s", response.Errors[0].Message)
 	}
 
-	projectGroupEnvID := ""
-
+	// the search matches partial names, only an exact match is the project group
 	for _, node := range response.Data.Organization.ProjectGroups.Nodes {
-		if node.Name == name {
-			for _, project := range node.Projects {
-				if project.Name == env {
-					projectGroupEnvID = project.ID
-					break
-				}
+		if node.Name != name {
+			continue
+		}
+		for _, project := range node.Projects {
+			if project.Name == env {
+				return project.ID, nil
 			}
-			break
 		}
+		// creating the project group again would only duplicate it
+		return "", fmt.Errorf("project group %s has no %s environment", name, env)
 	}
 
-	if projectGroupEnvID == "" {
-		return "", fmt.Errorf("no project group found with the specified environment")
-	}
-
-	return projectGroupEnvID, nil
+	return "", fmt.Errorf("%w: %s", errProjectGroupNotFound, name)
 }
 
 // CreateProjectGroup creates a new project group and returns the default project's ID
@@ -423,6 +457,10 @@ func (c *Client) CreateProjectGroup(ctx tcontext.TransferMetadata, name, env str
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("project group creation failed with %w", retry.NewHTTPError(resp))
+	}
+
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("failed to read response body: %w", err)
@@ -440,12 +478,22 @@ func (c *Client) CreateProjectGroup(ctx tcontext.TransferMetadata, name, env str
 				Errors []string `json:"errors"`
 			} `json:"projectGroupCreate"`
 		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
 	}
 
 	if err := json.Unmarshal(respBody, &response); err != nil {
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	if len(response.Errors) > 0 {
+		return "", fmt.Errorf("GraphQL error: %s", response.Errors[0].Message)
+	}
+	if len(response.Data.ProjectGroupCreate.Errors) > 0 {
+		return "", fmt.Errorf("project group creation failed: %s", response.Data.ProjectGroupCreate.Errors[0])
+	}
+
 	if len(response.Data.ProjectGroupCreate.ProjectGroup.Projects) == 0 {
 		return "", fmt.Errorf("no projects found in the created project group")
 	}
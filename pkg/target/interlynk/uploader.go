@@ -0,0 +1,269 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interlynk
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/timing"
+)
+
+const (
+	// numWorkers is the number of concurrent uploads in parallel and batch mode
+	numWorkers = 5
+
+	// defaultBatchSize is the chunk size of batch mode when the engine doesn't hand over batches
+	defaultBatchSize = 20
+)
+
+// projectGroups caches the IDs of the project group environments found or created during a
+// run, so concurrent uploads to the same project group create it only once
+type projectGroups struct {
+	mu  sync.Mutex
+	ids map[string]string // "<name>/<env>" -> project ID
+}
+
+func newProjectGroups() *projectGroups {
+	return &projectGroups{ids: make(map[string]string)}
+}
+
+// resolve returns the project ID of the project group environment, finding or creating it on
+// first use. Lookups are serialized, uploads aren't.
+func (p *projectGroups) resolve(ctx tcontext.TransferMetadata, client *Client, name, env string) (string, error) {
+	key := name + "/" + env
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id, ok := p.ids[key]; ok {
+		return id, nil
+	}
+
+	id, _, err := client.FindOrCreateProjectGroup(ctx, name, env)
+	if err != nil {
+		return "", err
+	}
+	p.ids[key] = id
+	return id, nil
+}
+
+// newClient creates the Interlynk API client of the adapter
+func (i *InterlynkAdapter) newClient() *Client {
+	return NewClient(Config{
+		Token:       i.ApiKey,
+		APIURL:      i.BaseURL,
+		ProjectName: i.ProjectName,
+		ProjectEnv:  i.ProjectEnv,
+	})
+}
+
+// uploadParallel uploads SBOMs with a pool of workers as soon as the iterator yields them
+func (i *InterlynkAdapter) uploadParallel(ctx tcontext.TransferMetadata, sboms iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Uploading SBOMs in parallel mode", "workers", numWorkers)
+
+	client := i.newClient()
+	groups := newProjectGroups()
+
+	sbomChan := make(chan *iterator.SBOM, numWorkers)
+	totalSBOMs := 0
+	var successfullyUploaded atomic.Int64 // incremented by all workers
+
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for sbom := range sbomChan {
+				// drain the queued SBOMs once cancelled
+				if ctx.Err() != nil {
+					continue
+				}
+				if i.uploadOne(ctx, client, groups, sbom) {
+					successfullyUploaded.Add(1)
+				}
+			}
+		}()
+	}
+
+	for {
+		sbom, err := sboms.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			// iterator errors are budgeted by the engine, not counted as failed uploads
+			logger.LogInfo(ctx.Context, "error", err)
+			continue
+		}
+		totalSBOMs++
+		sbomChan <- sbom
+	}
+	close(sbomChan)
+	wg.Wait()
+
+	logger.LogInfo(ctx.Context, "upload", "sboms", totalSBOMs, "success", successfullyUploaded.Load(), "failed", int64(totalSBOMs)-successfullyUploaded.Load())
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded.Load(), err)
+	}
+	return nil
+}
+
+// uploadBatch drains the iterator into chunks of defaultBatchSize SBOMs and uploads each chunk
+// concurrently before reading the next one, bounding the SBOMs held in memory
+func (i *InterlynkAdapter) uploadBatch(ctx tcontext.TransferMetadata, sboms iterator.SBOMIterator) error {
+	logger.LogDebug(ctx.Context, "Uploading SBOMs in batch mode", "batch_size", defaultBatchSize, "workers", numWorkers)
+
+	client := i.newClient()
+	groups := newProjectGroups()
+	totalSBOMs, successfullyUploaded := 0, 0
+
+	batch := make([]*iterator.SBOM, 0, defaultBatchSize)
+	flush := func() {
+		totalSBOMs += len(batch)
+		successfullyUploaded += i.uploadConcurrently(ctx, client, groups, batch)
+		batch = batch[:0]
+	}
+
+	for {
+		sbom, err := sboms.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			// iterator errors are budgeted by the engine, not counted as failed uploads
+			logger.LogInfo(ctx.Context, "error", err)
+			continue
+		}
+
+		batch = append(batch, sbom)
+		if len(batch) >= defaultBatchSize {
+			flush()
+		}
+	}
+	if ctx.Err() == nil {
+		flush()
+	}
+
+	logger.LogInfo(ctx.Context, "upload", "sboms", totalSBOMs, "success", successfullyUploaded, "failed", totalSBOMs-successfullyUploaded)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded, err)
+	}
+	return nil
+}
+
+// UploadBatch uploads one batch of SBOMs handed over by the engine with --batch-size,
+// concurrently, failing when any of them couldn't be uploaded. The client and the project
+// group cache are shared by all batches of a run.
+func (i *InterlynkAdapter) UploadBatch(ctx tcontext.TransferMetadata, sboms []*iterator.SBOM) error {
+	if i.batchClient == nil {
+		i.batchClient = i.newClient()
+		i.batchGroups = newProjectGroups()
+	}
+
+	uploaded := i.uploadConcurrently(ctx, i.batchClient, i.batchGroups, sboms)
+	if uploaded < len(sboms) {
+		return fmt.Errorf("failed to upload %d of %d SBOMs", len(sboms)-uploaded, len(sboms))
+	}
+	return nil
+}
+
+// uploadConcurrently uploads SBOMs with at most numWorkers uploads at a time, stopping early
+// when the transfer is cancelled. It returns how many were uploaded.
+func (i *InterlynkAdapter) uploadConcurrently(ctx tcontext.TransferMetadata, client *Client, groups *projectGroups, sboms []*iterator.SBOM) int {
+	var wg sync.WaitGroup
+	var uploaded atomic.Int64
+	semaphore := make(chan struct{}, numWorkers)
+
+	for _, sbom := range sboms {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(sbom *iterator.SBOM) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			if i.uploadOne(ctx, client, groups, sbom) {
+				uploaded.Add(1)
+			}
+		}(sbom)
+	}
+
+	wg.Wait()
+	return int(uploaded.Load())
+}
+
+// uploadOne uploads an SBOM to the project group it maps to, recording the outcome in the
+// transfer report. It reports whether the SBOM was uploaded.
+func (i *InterlynkAdapter) uploadOne(ctx tcontext.TransferMetadata, client *Client, groups *projectGroups, sbom *iterator.SBOM) bool {
+	logger.LogDebug(ctx.Context, "Uploading SBOM", "file", sbom.Path, "data size", len(sbom.Data))
+
+	finalProjectName, err := i.projectName(ctx, sbom)
+	if err != nil {
+		logger.LogInfo(ctx.Context, "error", "file", sbom.Path, "error", err)
+		report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Stage: report.StageProject}, err)
+		return false
+	}
+	finalProjectName, _ = sbom.Annotations.Project(finalProjectName, "")
+
+	// the SBOM's metadata file may place it in another environment
+	env := i.ProjectEnv
+	if sbom.Annotations != nil && sbom.Annotations.Environment != "" {
+		env = sbom.Annotations.Environment
+		if !allowedProjectEnvs[env] {
+			err := fmt.Errorf("invalid project environment %q in metadata file (allowed values: default, development, production)", env)
+			logger.LogInfo(ctx.Context, "error", "file", sbom.Path, "error", err.Error())
+			report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: finalProjectName, Stage: report.StageProject}, err)
+			return false
+		}
+	}
+
+	projectID, err := groups.resolve(ctx, client, finalProjectName, env)
+	if err != nil {
+		logger.LogInfo(ctx.Context, "error", err)
+		report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: finalProjectName, Stage: report.StageProject}, err)
+		return false
+	}
+	logger.LogDebug(ctx.Context, "SBOMs preparing to upload", "name", finalProjectName, "id", projectID)
+
+	// Upload SBOM content (stored in memory)
+	err = timing.Time(ctx, timing.StageUpload, sbom.Path, func() error {
+		return client.UploadSBOM(ctx, projectID, sbom.Data)
+	})
+	if err != nil {
+		logger.LogInfo(ctx.Context, "error", "file", sbom.Path, "project name", finalProjectName, "error", err)
+		report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: finalProjectName, Stage: report.StageUpload}, err)
+		return false
+	}
+
+	// every upload adds a version to the project group, nothing is replaced
+	report.RecordTransfer(ctx, sbom, "")
+	logger.LogDebug(ctx.Context, "upload", "file", sbom.Path, "project name", finalProjectName)
+	logger.LogInfo(ctx.Context, "upload", "success", true, "project", finalProjectName, "file", sbom.Path)
+	return true
+}
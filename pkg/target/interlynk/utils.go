@@ -21,10 +21,13 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
+	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/source"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/utils"
 )
 
 // ValidateInterlynkConnection chesks whether Interlynk ssytem is up and running
@@ -48,18 +51,18 @@ func ValidateInterlynkConnection(url, token string) error {
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to reach Interlynk at %s: %w", baseURL, err)
+		return errdefs.Unreachable(fmt.Errorf("failed to reach Interlynk at %s: %w", baseURL, err))
 	}
 	defer resp.Body.Close()
 
 	// provided token is invalid
 	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-		return fmt.Errorf("invalid API token: authentication failed")
+		return errdefs.Auth(fmt.Errorf("invalid API token: authentication failed"))
 	}
 
 	// interlynk looks to down
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Interlynk API returned unexpected status: %d", resp.StatusCode)
+		return errdefs.Unreachable(fmt.Errorf("Interlynk API returned unexpected status: %d", resp.StatusCode))
 	}
 
 	return nil
@@ -93,8 +96,8 @@ func ConstructInterlynkProjectName(ctx tcontext.TransferMetadata, extProjectName
 		return extProjectName
 	}
 
-	if source == "github" {
-		logger.LogDebug(ctx.Context, "Source is a github")
+	if source == "github" || source == utils.NamespaceSource {
+		logger.LogDebug(ctx.Context, "Project named after namespace", "source", source)
 		return ownerAndGithubRepoName
 	}
 
@@ -102,6 +105,14 @@ func ConstructInterlynkProjectName(ctx tcontext.TransferMetadata, extProjectName
 	return GetProjectNameAndVersion(ctx, SbomData, sbomPath)
 }
 
+// projectName names the project group of sbom, from the name template when one is set
+func (i *InterlynkAdapter) projectName(ctx tcontext.TransferMetadata, sbom *iterator.SBOM) (string, error) {
+	if i.NameTemplate != nil {
+		return i.NameTemplate.Render(sbom.Namespace, sbom.Version, sbom.Path, sbom.Data, sbom.SourceAdapter(ctx))
+	}
+	return ConstructInterlynkProjectName(ctx, i.ProjectName, sbom.Namespace, sbom.Path, sbom.Data, utils.NamingSource(sbom.SourceAdapter(ctx), sbom.ExplicitNamespace)), nil
+}
+
 // construct project name from it's primary comp name and it's version by reading sbom content
 func GetProjectNameAndVersion(ctx tcontext.TransferMetadata, content []byte, assetPath string) string {
 	// ONLY APPLICABLE FOR JSON FILE FORMAT SBOM
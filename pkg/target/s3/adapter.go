@@ -16,10 +16,12 @@ package s3
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 	"github.com/interlynk-io/sbommv/pkg/types"
 	"github.com/interlynk-io/sbommv/pkg/utils"
@@ -31,6 +33,7 @@ type S3Adapter struct {
 	Role           types.AdapterRole
 	ProcessingMode types.ProcessingMode
 	Uploader       SBOMUploader
+	batchUploader  S3BatchUploader
 }
 
 // AddCommandParams adds S3-specific CLI flags
@@ -40,14 +43,19 @@ func (s3 *S3Adapter) AddCommandParams(cmd *cobra.Command) {
 	cmd.Flags().String("out-s3-prefix", "", "S3 prefix")
 	cmd.Flags().String("out-s3-access-key", "", "AWS access key for S3")
 	cmd.Flags().String("out-s3-secret-key", "", "AWS secret key for S3")
+	cmd.Flags().String("out-s3-session-token", "", "AWS session token for temporary credentials, used with the access and secret keys")
+	cmd.Flags().String("out-s3-endpoint-url", "", "Custom S3 endpoint URL for S3-compatible stores such as MinIO, Ceph or Cloudflare R2 (default: the AWS endpoint of the region)")
+	cmd.Flags().Bool("out-s3-path-style", false, "Address buckets as <endpoint>/<bucket> instead of <bucket>.<endpoint>, needed by most S3-compatible stores")
+	cmd.Flags().Bool("out-s3-format-extensions", false, "Name objects after their detected format, e.g. .cdx.json, .spdx.json, .cdx.xml")
+	cmd.Flags().StringSlice("out-s3-extension-map", nil, "Extensions of formats as format=extension, e.g. cyclonedx-json=.json (implies --out-s3-format-extensions)")
 }
 
 // ParseAndValidateParams validates the S3 adapter params
 func (s *S3Adapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	var (
-		bucketNameFlag, regionFlag, prefixFlag, accessKeyFlag, secretKeyFlag string
-		missingFlags                                                         []string
-		invalidFlags                                                         []string
+		bucketNameFlag, regionFlag, prefixFlag, accessKeyFlag, secretKeyFlag, sessionTokenFlag, endpointURLFlag, pathStyleFlag string
+		missingFlags                                                                                                           []string
+		invalidFlags                                                                                                           []string
 	)
 
 	bucketNameFlag = "out-s3-bucket-name"
@@ -55,6 +63,11 @@ func (s *S3Adapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	prefixFlag = "out-s3-prefix"
 	accessKeyFlag = "out-s3-access-key"
 	secretKeyFlag = "out-s3-secret-key"
+	sessionTokenFlag = "out-s3-session-token"
+	endpointURLFlag = "out-s3-endpoint-url"
+	pathStyleFlag = "out-s3-path-style"
+	formatExtensionsFlag := "out-s3-format-extensions"
+	extensionMapFlag := "out-s3-extension-map"
 
 	var bucketName, region, prefix string
 	var uploader SBOMUploader
@@ -96,6 +109,38 @@ func (s *S3Adapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	// extract AWS secret Key
 	secretKey, _ := cmd.Flags().GetString(secretKeyFlag)
 
+	// static credentials are used only as a pair, otherwise the default credential chain applies
+	if (accessKey == "") != (secretKey == "") {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s and --%s must be used together", accessKeyFlag, secretKeyFlag))
+	}
+
+	// extract AWS session token, for temporary credentials
+	sessionToken, _ := cmd.Flags().GetString(sessionTokenFlag)
+	if sessionToken != "" && accessKey == "" {
+		invalidFlags = append(invalidFlags, fmt.Sprintf("--%s requires --%s and --%s", sessionTokenFlag, accessKeyFlag, secretKeyFlag))
+	}
+
+	// extract custom S3 endpoint
+	endpointURL, _ := cmd.Flags().GetString(endpointURLFlag)
+	if endpointURL != "" {
+		if u, err := url.Parse(endpointURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s=%s (must be an http or https URL)", endpointURLFlag, endpointURL))
+		}
+	}
+
+	// extract path-style addressing, for S3-compatible stores
+	pathStyle, _ := cmd.Flags().GetBool(pathStyleFlag)
+
+	// extract the extensions objects are named with
+	var extensions *sbom.ExtensionMap
+	formatExtensions, _ := cmd.Flags().GetBool(formatExtensionsFlag)
+	extensionMap, _ := cmd.Flags().GetStringSlice(extensionMapFlag)
+	if formatExtensions || len(extensionMap) > 0 {
+		if extensions, err = sbom.ParseExtensionMap(extensionMap); err != nil {
+			invalidFlags = append(invalidFlags, fmt.Sprintf("--%s: %v", extensionMapFlag, err))
+		}
+	}
+
 	if len(missingFlags) > 0 {
 		return fmt.Errorf("missing flags: %s", strings.Join(missingFlags, ", "))
 	}
@@ -111,6 +156,10 @@ func (s *S3Adapter) ParseAndValidateParams(cmd *cobra.Command) error {
 	cfg.SetPrefix(prefix)
 	cfg.SetAccessKey(accessKey)
 	cfg.SetSecretKey(secretKey)
+	cfg.SessionToken = sessionToken
+	cfg.EndpointURL = endpointURL
+	cfg.PathStyle = pathStyle
+	cfg.Extensions = extensions
 
 	s.Config = cfg
 	s.Uploader = uploader
@@ -129,8 +178,14 @@ func (s *S3Adapter) UploadSBOMs(ctx tcontext.TransferMetadata, iter iterator.SBO
 	return s.Uploader.Upload(ctx, s.Config, iter)
 }
 
+// UploadBatch uploads a chunk of SBOMs with concurrent puts, used with --batch-size
+func (s *S3Adapter) UploadBatch(ctx tcontext.TransferMetadata, sboms []*iterator.SBOM) error {
+	return s.batchUploader.UploadBatch(ctx, s.Config, sboms)
+}
+
 // DryRun for Output Adapter: Simulates writing SBOMs to a folder
 func (s *S3Adapter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIterator) error {
 	reporter := NewS3Reporter(false, "", s.Config.BucketName, s.Config.Prefix)
+	reporter.config = s.Config
 	return reporter.DryRun(ctx, iter)
 }
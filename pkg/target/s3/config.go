@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 	"github.com/interlynk-io/sbommv/pkg/types"
 )
@@ -29,10 +30,16 @@ import (
 type S3Config struct {
 	AccessKey      string
 	SecretKey      string
+	SessionToken   string // temporary credentials, used with AccessKey and SecretKey
+	EndpointURL    string // custom S3 endpoint, empty uses the AWS endpoint of Region
+	PathStyle      bool   // address buckets as endpoint/bucket instead of bucket.endpoint
 	BucketName     string
 	Region         string
 	Prefix         string
 	ProcessingMode types.ProcessingMode
+
+	// Extensions renames objects after their detected format, nil keeps their source names
+	Extensions *sbom.ExtensionMap
 }
 
 func NewS3Config() *S3Config {
@@ -82,29 +89,30 @@ func (s *S3Config) SetAccessKey(accessKey string) {
 }
 
 func (s *S3Config) GetAWSClient(ctx tcontext.TransferMetadata) (*s3.Client, error) {
-	logger.LogDebug(ctx.Context, "Initializing AWS S3 client", "region", s.Region, "bucket", s.BucketName, "prefix", s.Prefix)
+	logger.LogDebug(ctx.Context, "Initializing AWS S3 client", "region", s.Region, "bucket", s.BucketName, "prefix", s.Prefix, "endpoint", s.EndpointURL, "path_style", s.PathStyle)
 
-	// Load AWS config
-	var cfg aws.Config
-	var err error
+	// Load AWS config, with static credentials when given and the default credential chain otherwise
+	opts := []func(*config.LoadOptions) error{config.WithRegion(s.Region)}
 	if s.AccessKey != "" && s.SecretKey != "" {
 		creds := aws.Credentials{
 			AccessKeyID:     s.AccessKey,
 			SecretAccessKey: s.SecretKey,
+			SessionToken:    s.SessionToken,
 		}
-		cfg, err = config.LoadDefaultConfig(ctx.Context,
-			config.WithRegion(s.Region),
-			config.WithCredentialsProvider(aws.NewCredentialsCache(credentials.StaticCredentialsProvider{Value: creds})),
-		)
-	} else {
-		cfg, err = config.LoadDefaultConfig(ctx.Context, config.WithRegion(s.Region))
+		opts = append(opts, config.WithCredentialsProvider(aws.NewCredentialsCache(credentials.StaticCredentialsProvider{Value: creds})))
+	}
+	if s.EndpointURL != "" {
+		opts = append(opts, config.WithBaseEndpoint(s.EndpointURL))
 	}
 
+	cfg, err := config.LoadDefaultConfig(ctx.Context, opts...)
 	if err != nil {
 		logger.LogError(ctx.Context, err, "Failed to load AWS config")
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	// Create S3 client
-	return s3.NewFromConfig(cfg), err
+	// Create S3 client, S3-compatible stores such as MinIO usually need path-style addressing
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = s.PathStyle
+	}), nil
 }
@@ -15,13 +15,22 @@
 package s3
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 
 	"github.com/interlynk-io/sbommv/pkg/iterator"
 	"github.com/interlynk-io/sbommv/pkg/logger"
 	"github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/utils"
 )
 
 type S3Reporter struct {
@@ -29,6 +38,9 @@ type S3Reporter struct {
 	inputDir   string
 	bucketName string
 	prefix     string
+
+	// config, when set, makes the dry run look up every key in the bucket
+	config *S3Config
 }
 
 func NewS3Reporter(verbose bool, inputDir, bucketName, prefix string) *S3Reporter {
@@ -45,11 +57,25 @@ func (s *S3Reporter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIte
 	processor := sbom.NewSBOMProcessor(s.inputDir, s.verbose)
 	sbomCount := 0
 	fmt.Println("\n📦 S3 Output Adapter Dry-Run")
+
+	preflight := utils.NewPreflight()
+	collisions := utils.NewNameCollisions()
+	var client *s3.Client
+	var clientErr error
+	if s.config != nil {
+		if client, clientErr = s.config.GetAWSClient(ctx); clientErr != nil {
+			logger.LogInfo(ctx.Context, "Cannot look up existing objects", "bucket", s.bucketName, "error", clientErr)
+		}
+	}
+
 	for {
 		sbom, err := iter.Next(ctx)
 		if err == io.EOF {
 			break
 		}
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
 			return err
@@ -74,13 +100,56 @@ func (s *S3Reporter) DryRun(ctx tcontext.TransferMetadata, iter iterator.SBOMIte
 			fmt.Println("------------------------------------------------------")
 		}
 
-		fmt.Printf(" - 📁 Would Upload to Bucket: %s | Prefix: %s \n",
-			s.bucketName, s.prefix)
+		if s.config == nil {
+			fmt.Printf(" - 📁 Would Upload to Bucket: %s | Prefix: %s \n",
+				s.bucketName, s.prefix)
+			sbomCount++
+			continue
+		}
+
+		// same key as the upload, including the suffix of colliding names
+		name := keyName(s.config, sbom)
+		renamedFrom := ""
+		if resolved, collided := collisions.Resolve(name, sbom.Data); collided {
+			renamedFrom, name = name, resolved
+		}
+		key := filepath.Join(keyPrefix(s.config), name)
+
+		exists, err := false, clientErr
+		if client != nil {
+			exists, err = objectExists(ctx, client, s.bucketName, key)
+		}
+		if err != nil {
+			logger.LogDebug(ctx.Context, "Failed to look up object", "bucket", s.bucketName, "key", key, "error", err)
+		}
+
+		// puts replace existing objects
+		status := preflight.Status(exists, err, true)
+		fmt.Printf(" - 📁 Would Upload to Bucket: %s | Key: %s [%s]%s\n",
+			s.bucketName, key, status, preflight.Notes(sbom.Conversion(), renamedFrom))
 		sbomCount++
 	}
 
 	fmt.Printf("\n📊 Total SBOMs to be uploaded: %d\n", sbomCount)
+	if s.config != nil {
+		preflight.Print()
+	}
 	logger.LogDebug(ctx.Context, "Dry-run completed", "total_sboms", sbomCount)
 
 	return nil
 }
+
+// objectExists reports whether the bucket holds an object under key
+func objectExists(ctx tcontext.TransferMetadata, client *s3.Client, bucket, key string) (bool, error) {
+	_, err := client.HeadObject(ctx.Context, &s3.HeadObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err == nil {
+		return true, nil
+	}
+
+	var notFound *types.NotFound
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &notFound) || (errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound) {
+		return false, nil
+	}
+	return false, err
+}
@@ -24,9 +24,16 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/interlynk-io/sbommv/pkg/errdefs"
 	"github.com/interlynk-io/sbommv/pkg/iterator"
+	"github.com/interlynk-io/sbommv/pkg/limiter"
 	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/report"
+	"github.com/interlynk-io/sbommv/pkg/retry"
+	sbomd "github.com/interlynk-io/sbommv/pkg/sbom"
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
+	"github.com/interlynk-io/sbommv/pkg/timing"
+	"github.com/interlynk-io/sbommv/pkg/utils"
 )
 
 type SBOMUploader interface {
@@ -42,23 +49,14 @@ type (
 func (u *S3ParallelUploader) Upload(ctx tcontext.TransferMetadata, config *S3Config, iter iterator.SBOMIterator) error {
 	logger.LogDebug(ctx.Context, "Writing SBOMs in concurrently", "bucket", config.BucketName, "prefix", config.Prefix)
 
-	totalSBOMs := 0
-	successfullyUploaded := 0
-	prefix := config.Prefix
+	var totalSBOMs, successfullyUploaded int
+	prefix := keyPrefix(config)
 
 	client, err := config.GetAWSClient(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	// add "/" to prefix if not present in the end
-	if prefix != "" && !strings.HasSuffix(prefix, "/") {
-		prefix = prefix + "/"
-	}
-
-	// space for proper logging
-	fmt.Println()
-
 	// retrieve all SBOMs from iterator
 	var sbomList []*iterator.SBOM
 	for {
@@ -66,6 +64,9 @@ func (u *S3ParallelUploader) Upload(ctx tcontext.TransferMetadata, config *S3Con
 		if err == io.EOF {
 			break
 		}
+		if ctx.Err() != nil {
+			break
+		}
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Error retrieving SBOM from iterator")
 			continue
@@ -73,50 +74,14 @@ func (u *S3ParallelUploader) Upload(ctx tcontext.TransferMetadata, config *S3Con
 		sbomList = append(sbomList, sbom)
 	}
 
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	const maxConcurrency = 3
-	semaphore := make(chan struct{}, maxConcurrency)
-
-	for _, sbom := range sbomList {
-		wg.Add(1)
-		semaphore <- struct{}{}
-		go func(sbom *iterator.SBOM) {
-			defer wg.Done()
-			defer func() { <-semaphore }()
-
-			// sourceAdapter := ctx.Value("source")
-			// finalProjectName, _ := utils.ConstructProjectName(ctx, "", "", sbom.Namespace, sbom.Version, sbom.Path, sbom.Data, sourceAdapter.(string))
-			fileName := sbom.Path
-			key := filepath.Join(prefix, fileName)
-
-			// Upload to S3
-			_, err := client.PutObject(ctx.Context, &s3.PutObjectInput{
-				Bucket: aws.String(config.BucketName),
-				Key:    aws.String(key),
-				Body:   bytes.NewReader(sbom.Data),
-			})
-
-			mu.Lock()
-			totalSBOMs++
-			if err != nil {
-				logger.LogError(ctx.Context, err, "Failed to upload SBOM", "bucket", config.BucketName, "key", key)
-				mu.Unlock()
-				return
-			}
-			successfullyUploaded++
-			logger.LogDebug(ctx.Context, "Uploaded SBOM", "bucket", config.BucketName, "key", key, "size", len(sbom.Data))
-			logger.LogInfo(ctx.Context, "upload", "success", true, "bucket", config.BucketName, "prefix", config.Prefix, "filename", fileName)
-
-			mu.Unlock()
-		}(sbom)
-	}
-
-	wg.Wait()
+	totalSBOMs, successfullyUploaded = putObjects(ctx, client, config, prefix, utils.NewNameCollisions(), sbomList)
 
 	logger.LogInfo(ctx.Context, "upload", "total", totalSBOMs, "success", successfullyUploaded, "failed", totalSBOMs-successfullyUploaded)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded, err)
+	}
 	if totalSBOMs == 0 {
-		return fmt.Errorf("no SBOMs found to upload")
+		return errdefs.NotFound(fmt.Errorf("no SBOMs found to upload"))
 	}
 
 	return nil
@@ -126,30 +91,26 @@ func (u *S3SequentialUploader) Upload(ctx tcontext.TransferMetadata, s3cfg *S3Co
 	logger.LogDebug(ctx.Context, "Writing SBOMs sequentially", "bucketName", s3cfg.BucketName, "prefix", s3cfg.Prefix)
 	totalSBOMs := 0
 	successfullyUploaded := 0
-	bucketPrefix := s3cfg.Prefix
+	bucketPrefix := keyPrefix(s3cfg)
 
 	client, err := s3cfg.GetAWSClient(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
-	// add "/" to prefix if not present in the end
-	if bucketPrefix != "" && !strings.HasSuffix(bucketPrefix, "/") {
-		bucketPrefix = bucketPrefix + "/"
-	}
-
-	// space for proper logging
-	fmt.Println()
+	collisions := utils.NewNameCollisions()
 
 	for {
 		sbom, err := iter.Next(ctx)
 		if err == io.EOF {
 			break
 		}
-		// sourceAdapter := ctx.Value("source")
-		// destinationAdapter := ctx.Value("destination")
+		if ctx.Err() != nil {
+			break
+		}
+		// sourceAdapter := ctx.SourceAdapter()
+		// destinationAdapter := ctx.DestinationAdapter()
 
-		fileName := sbom.Path
 		// // if the source adapter is local folder cloud storage(s3), and the o/p adapter is local folder or cloud storage(s3),
 		// // use the SBOM file name as the project name instead of primary comp and version
 		// // because at the end they have to save the SBOM file as it is.
@@ -165,25 +126,171 @@ func (u *S3SequentialUploader) Upload(ctx tcontext.TransferMetadata, s3cfg *S3Co
 			continue
 		}
 
+		fileName := resolveKeyName(ctx, s3cfg, collisions, sbom)
 		key := filepath.Join(bucketPrefix, fileName)
 
 		// Upload to S3
-		_, err = client.PutObject(ctx.Context, &s3.PutObjectInput{
-			Bucket: aws.String(s3cfg.BucketName),
-			Key:    aws.String(key),
-			Body:   bytes.NewReader(sbom.Data),
-		})
+		err = putObject(ctx, client, s3cfg.BucketName, key, sbom)
 		if err != nil {
 			logger.LogError(ctx.Context, err, "Failed to upload SBOM", "bucket", s3cfg.BucketName, "key", key)
+			report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: s3cfg.BucketName + "/" + key, Stage: report.StageUpload}, err)
 			continue
 		}
 
 		successfullyUploaded++
+		report.RecordTransfer(ctx, sbom, s3cfg.BucketName+"/"+key)
 		logger.LogDebug(ctx.Context, "Uploaded SBOM", "bucket", s3cfg.BucketName, "key", key, "size", len(sbom.Data))
 		logger.LogInfo(ctx.Context, "upload", "success", true, "bucket", s3cfg.BucketName, "prefix", s3cfg.Prefix, "filename", fileName)
 
 	}
 	logger.LogInfo(ctx.Context, "upload", "total", totalSBOMs, "success", successfullyUploaded, "failed", totalSBOMs-successfullyUploaded)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("upload interrupted after %d SBOMs: %w", successfullyUploaded, err)
+	}
+
+	return nil
+}
 
+// S3BatchUploader uploads the batches the engine hands over with --batch-size, each batch as
+// concurrent puts. The client and the object name collisions are shared by all batches of a run.
+type S3BatchUploader struct {
+	client     *s3.Client
+	prefix     string
+	collisions *utils.NameCollisions
+}
+
+// UploadBatch uploads one batch of SBOMs, failing when any of them couldn't be uploaded
+func (u *S3BatchUploader) UploadBatch(ctx tcontext.TransferMetadata, config *S3Config, sboms []*iterator.SBOM) error {
+	if u.client == nil {
+		client, err := config.GetAWSClient(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		u.client = client
+		u.collisions = utils.NewNameCollisions()
+		u.prefix = keyPrefix(config)
+	}
+
+	attempted, uploaded := putObjects(ctx, u.client, config, u.prefix, u.collisions, sboms)
+	if uploaded < attempted {
+		return fmt.Errorf("failed to upload %d of %d SBOMs", attempted-uploaded, attempted)
+	}
 	return nil
 }
+
+// keyPrefix returns the prefix object keys are named with, ending with "/" when set
+func keyPrefix(config *S3Config) string {
+	prefix := config.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix = prefix + "/"
+	}
+	return prefix
+}
+
+// putObjects uploads SBOMs with a few concurrent puts, stopping early when the transfer is
+// cancelled. It returns how many uploads were attempted and how many succeeded.
+func putObjects(ctx tcontext.TransferMetadata, client *s3.Client, config *S3Config, prefix string, collisions *utils.NameCollisions, sboms []*iterator.SBOM) (int, int) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	attempted, uploaded := 0, 0
+	const maxConcurrency = 3
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	for _, sbom := range sboms {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(sbom *iterator.SBOM) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			fileName := resolveKeyName(ctx, config, collisions, sbom)
+			key := filepath.Join(prefix, fileName)
+
+			// Upload to S3
+			err := putObject(ctx, client, config.BucketName, key, sbom)
+
+			mu.Lock()
+			defer mu.Unlock()
+			attempted++
+			if err != nil {
+				logger.LogError(ctx.Context, err, "Failed to upload SBOM", "bucket", config.BucketName, "key", key)
+				report.RecordFailure(ctx, report.Failure{File: sbom.Path, Namespace: sbom.Namespace, Project: config.BucketName + "/" + key, Stage: report.StageUpload}, err)
+				return
+			}
+			uploaded++
+			report.RecordTransfer(ctx, sbom, config.BucketName+"/"+key)
+			logger.LogDebug(ctx.Context, "Uploaded SBOM", "bucket", config.BucketName, "key", key, "size", len(sbom.Data))
+			logger.LogInfo(ctx.Context, "upload", "success", true, "bucket", config.BucketName, "prefix", config.Prefix, "filename", fileName)
+		}(sbom)
+	}
+
+	wg.Wait()
+	return attempted, uploaded
+}
+
+// objectMetadata returns the user-defined metadata attached to every uploaded object,
+// currently the run ID so objects can be traced back to the transfer that wrote them.
+func objectMetadata(ctx tcontext.TransferMetadata) map[string]string {
+	runID := ctx.RunID()
+	if runID == "" {
+		return nil
+	}
+	return map[string]string{"sbommv-run-id": runID}
+}
+
+// resolveKeyName returns the object name for the SBOM, renamed after its detected format when
+// --out-s3-format-extensions is set, adding a content-hash suffix when a different SBOM was
+// already uploaded under the same name in this run.
+func resolveKeyName(ctx tcontext.TransferMetadata, config *S3Config, collisions *utils.NameCollisions, sbom *iterator.SBOM) string {
+	resolved, collided := collisions.Resolve(keyName(config, sbom), sbom.Data)
+	if collided {
+		logger.LogInfo(ctx.Context, "Duplicate object name with different content, uploading with content-hash suffix", "file", sbom.Path, "uploaded_as", resolved, "namespace", sbom.Namespace)
+	}
+	return resolved
+}
+
+// keyName returns the object name of an SBOM before name collisions are resolved
+func keyName(config *S3Config, sbom *iterator.SBOM) string {
+	if config.Extensions != nil {
+		return config.Extensions.Rename(sbom.Path, sbom.Data)
+	}
+	return sbom.Path
+}
+
+// putObject uploads a single SBOM object, and its signature files next to it, within the
+// engine-wide transfer budget
+func putObject(ctx tcontext.TransferMetadata, client *s3.Client, bucket, key string, sbom *iterator.SBOM) error {
+	return timing.Time(ctx, timing.StageUpload, sbom.Path, func() error {
+		if err := putData(ctx, client, bucket, key, sbom.Data, sbomd.ContentType(sbom.Data)); err != nil {
+			return err
+		}
+		for _, suffix := range sbom.SignatureSuffixes() {
+			if err := putData(ctx, client, bucket, key+suffix, sbom.Signatures[suffix], "application/octet-stream"); err != nil {
+				return fmt.Errorf("uploading signature: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// putData uploads one object, retrying transient errors
+func putData(ctx tcontext.TransferMetadata, client *s3.Client, bucket, key string, data []byte, contentType string) error {
+	return retry.Do(ctx, bucket+"/"+key, func() error {
+		return limiter.Transfer(ctx, func() error {
+			_, err := client.PutObject(ctx.Context, &s3.PutObjectInput{
+				Bucket:      aws.String(bucket),
+				Key:         aws.String(key),
+				Body:        bytes.NewReader(data),
+				ContentType: aws.String(contentType),
+				Metadata:    objectMetadata(ctx),
+			}, func(o *s3.Options) {
+				// retried per --retries, not by the SDK as well
+				o.Retryer = aws.NopRetryer{}
+			})
+			return err
+		})
+	})
+}
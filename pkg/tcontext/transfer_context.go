@@ -32,6 +32,48 @@ func (tm *TransferMetadata) Value(key string) interface{} {
 	return tm.values[key]
 }
 
+// keys of the values every transfer carries, read and written through the typed accessors
+const (
+	sourceKey      = "source"
+	destinationKey = "destination"
+	runIDKey       = "run_id"
+)
+
+// SetSourceAdapter records the input adapter SBOMs are fetched from, e.g. "github"
+func (tm *TransferMetadata) SetSourceAdapter(name string) {
+	tm.WithValue(sourceKey, name)
+}
+
+// SourceAdapter returns the input adapter SBOMs are fetched from, empty when none is set.
+// With several inputs, each input's metadata has its own.
+func (tm *TransferMetadata) SourceAdapter() string {
+	name, _ := tm.Value(sourceKey).(string)
+	return name
+}
+
+// SetDestinationAdapter records the output adapter SBOMs are transferred to, e.g. "dtrack"
+func (tm *TransferMetadata) SetDestinationAdapter(name string) {
+	tm.WithValue(destinationKey, name)
+}
+
+// DestinationAdapter returns the output adapter SBOMs are transferred to, empty when none is
+// set. With several outputs, each output's metadata has its own.
+func (tm *TransferMetadata) DestinationAdapter() string {
+	name, _ := tm.Value(destinationKey).(string)
+	return name
+}
+
+// SetRunID records the identifier of the transfer run
+func (tm *TransferMetadata) SetRunID(id string) {
+	tm.WithValue(runIDKey, id)
+}
+
+// RunID returns the identifier of the transfer run, empty when none is set
+func (tm *TransferMetadata) RunID() string {
+	id, _ := tm.Value(runIDKey).(string)
+	return id
+}
+
 // NewTransferMetadata initializes TransferMetadata
 func NewTransferMetadata(ctx context.Context) *TransferMetadata {
 	return &TransferMetadata{
@@ -39,3 +81,13 @@ func NewTransferMetadata(ctx context.Context) *TransferMetadata {
 		values:  make(map[string]interface{}),
 	}
 }
+
+// Clone returns a copy of the metadata sharing the context, whose values can be changed
+// without affecting the original
+func (tm *TransferMetadata) Clone() *TransferMetadata {
+	values := make(map[string]interface{}, len(tm.values))
+	for k, v := range tm.values {
+		values[k] = v
+	}
+	return &TransferMetadata{Context: tm.Context, values: values}
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timing measures how long each SBOM spends in the fetch, conversion and upload
+// stages of a transfer, so slowdowns between releases can be traced from debug logs.
+package timing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+	"github.com/interlynk-io/sbommv/pkg/tcontext"
+)
+
+// contextKey is the TransferMetadata key under which the engine stores the recorder
+const contextKey = "stage_timings"
+
+// Pipeline stages an SBOM goes through
+const (
+	StageFetch   = "fetch"
+	StageConvert = "convert"
+	StageUpload  = "upload"
+)
+
+// stages lists the stages in pipeline order, for the summary
+var stages = []string{StageFetch, StageConvert, StageUpload}
+
+// sampleEvery bounds the debug log: the first sampleEvery SBOMs of a stage are logged,
+// then one in sampleEvery. The summary covers every SBOM.
+const sampleEvery = 25
+
+// Stats sums up the timings of a stage
+type Stats struct {
+	Count       int           `json:"count"`
+	Total       time.Duration `json:"total"`
+	Max         time.Duration `json:"max"`
+	SlowestSBOM string        `json:"slowest_sbom"`
+}
+
+// Recorder accumulates stage timings of a transfer run. It is safe for concurrent use,
+// and a nil *Recorder ignores records.
+type Recorder struct {
+	mu    sync.Mutex
+	setup time.Duration // source set up before the first SBOM, e.g. listing and upfront downloads
+	stats map[string]*Stats
+}
+
+// NewRecorder returns an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{stats: map[string]*Stats{}}
+}
+
+// Attach stores the recorder in the transfer context for iterators and uploaders to pick up
+func Attach(ctx *tcontext.TransferMetadata, r *Recorder) {
+	if r != nil {
+		ctx.WithValue(contextKey, r)
+	}
+}
+
+// FromContext returns the recorder of the transfer, or nil when none is attached
+func FromContext(ctx tcontext.TransferMetadata) *Recorder {
+	r, _ := ctx.Value(contextKey).(*Recorder)
+	return r
+}
+
+// Record records that the SBOM identified by sbomID, usually its path, spent d in stage
+func Record(ctx tcontext.TransferMetadata, stage, sbomID string, d time.Duration) {
+	r := FromContext(ctx)
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	s, ok := r.stats[stage]
+	if !ok {
+		s = &Stats{}
+		r.stats[stage] = s
+	}
+	s.Count++
+	s.Total += d
+	if d > s.Max {
+		s.Max = d
+		s.SlowestSBOM = sbomID
+	}
+	count := s.Count
+	r.mu.Unlock()
+
+	if count <= sampleEvery || count%sampleEvery == 0 {
+		logger.LogDebug(ctx.Context, "Stage timing", stage+"_ms", ms(d), "sbom_id", sbomID, "sample", count)
+	}
+}
+
+// Time runs fn and records its duration as the stage of the SBOM identified by sbomID
+func Time(ctx tcontext.TransferMetadata, stage, sbomID string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	Record(ctx, stage, sbomID, time.Since(start))
+	return err
+}
+
+// RecordSetup records the time the source took before yielding its first SBOM
+func RecordSetup(ctx tcontext.TransferMetadata, d time.Duration) {
+	r := FromContext(ctx)
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	r.setup = d
+	r.mu.Unlock()
+	logger.LogDebug(ctx.Context, "Stage timing", "fetch_setup_ms", ms(d))
+}
+
+// Summary returns a snapshot of the timings by stage
+func (r *Recorder) Summary() map[string]Stats {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summary := make(map[string]Stats, len(r.stats))
+	for stage, s := range r.stats {
+		summary[stage] = *s
+	}
+	return summary
+}
+
+// Log writes the timings of each stage to the debug log, one line per stage
+func (r *Recorder) Log(ctx tcontext.TransferMetadata) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	setup := r.setup
+	r.mu.Unlock()
+	summary := r.Summary()
+
+	logger.LogDebug(ctx.Context, "Stage timings", "fetch_setup_ms", ms(setup))
+	for _, stage := range stages {
+		s, ok := summary[stage]
+		if !ok || s.Count == 0 {
+			continue
+		}
+		logger.LogDebug(ctx.Context, "Stage timings", "stage", stage, "sboms", s.Count, "total_ms", ms(s.Total), "avg_ms", ms(s.Total/time.Duration(s.Count)), "max_ms", ms(s.Max), "slowest_sbom_id", s.SlowestSBOM)
+	}
+}
+
+// ms returns d in milliseconds, to the microsecond
+func ms(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
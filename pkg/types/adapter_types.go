@@ -14,6 +14,8 @@
 
 package types
 
+import "time"
+
 // AdapterRole defines whether the adapter is input or output
 type AdapterRole string
 
@@ -30,6 +32,14 @@ const (
 	FolderAdapterType    AdapterType = "folder"
 	DtrackAdapterType    AdapterType = "dtrack"
 	S3AdapterType        AdapterType = "s3"
+	HarborAdapterType    AdapterType = "harbor"
+	ECRAdapterType       AdapterType = "ecr"
+	OCIAdapterType       AdapterType = "oci"
+	AzureBlobAdapterType AdapterType = "azblob"
+	GCSAdapterType       AdapterType = "gcs"
+
+	// SBOMs given to `sbommv upload` as files or on stdin, not selectable as --input-adapter
+	FilesAdapterType AdapterType = "files"
 )
 
 type ProcessingMode string
@@ -58,3 +68,33 @@ const (
 	InputAdapterFlagPrefix  FlagPrefix = "in"
 	OutputAdapterFlagPrefix FlagPrefix = "out"
 )
+
+// CleanupOptions selects what `sbommv cleanup` removes from a destination
+type CleanupOptions struct {
+	RunID      string // run whose created projects are removed
+	Deactivate bool   // deactivate instead of deleting
+	DryRun     bool   // only list what would be removed
+}
+
+// SBOMCandidate is an SBOM an input adapter would fetch, as far as it can tell without
+// downloading it. Size is 0 when unknown, e.g. for SBOMs generated during the transfer.
+type SBOMCandidate struct {
+	Name      string // file, object key or release asset name
+	Namespace string // repository, folder or bucket the SBOM comes from
+	Size      int64
+}
+
+// RateLimitCheck compares the API calls a transfer is expected to make against the rate
+// limit the source has left, as reported by an input adapter's preflight
+type RateLimitCheck struct {
+	Source    string    // source whose API is rate limited, e.g. "github/interlynk-io"
+	Calls     int       // approximate API calls the transfer needs
+	Remaining int       // calls left in the current rate limit window
+	Limit     int       // calls allowed per window
+	Reset     time.Time // when the window resets
+}
+
+// Sufficient reports whether the calls left cover the transfer
+func (c RateLimitCheck) Sufficient() bool {
+	return c.Calls <= c.Remaining
+}
@@ -15,11 +15,22 @@
 
 package types
 
+import (
+	"strings"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/cache"
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+	"github.com/interlynk-io/sbommv/pkg/schedule"
+	"github.com/interlynk-io/sbommv/pkg/sign"
+	"github.com/interlynk-io/sbommv/pkg/verify"
+)
+
 type Config struct {
-	// source adapter type(folder, github)
+	// source adapter type(folder, github), comma-separated when SBOMs come from several
 	SourceAdapter string
 
-	// destination adapter type(folder, dtrack, interlynk)
+	// destination adapter type(folder, dtrack, interlynk), comma-separated when SBOMs go to several
 	DestinationAdapter string
 
 	// processing strategy(parallel, sequential)
@@ -33,4 +44,169 @@ type Config struct {
 
 	// overwrite mode
 	Overwrite bool
+
+	// unique identifier of this transfer run, used to correlate logs and uploaded artifacts
+	RunID string
+
+	// engine-wide cap on SBOM transfers in flight across all uploaders, 0 means unlimited
+	MaxConcurrentTransfers int
+
+	// retries of uploads failing with a transient error, and the wait before the first
+	// retry, doubled for each next one
+	Retries      int
+	RetryBackoff time.Duration
+
+	// consecutive iterator errors tolerated before the run is aborted, 0 means unlimited
+	MaxIteratorErrors int
+
+	// formats selected by --include-formats/--exclude-formats, nil allows all
+	FormatFilter *sbom.FormatFilter
+
+	// spec versions selected by --include-spec-versions/--exclude-spec-versions, nil allows all
+	SpecVersionFilter *sbom.SpecVersionFilter
+
+	// skip SBOMs whose content was already seen in the run
+	Dedup bool
+
+	// how --verify-signatures checks the signatures of fetched SBOMs, nil doesn't check them
+	VerifySignatures *verify.Options
+
+	// metadata --enrich-* adds to SBOMs before they are uploaded, nil leaves them as they are
+	Enrichment *sbom.Enrichment
+
+	// NTIA minimum elements score, 0 to 10, SBOMs need to be uploaded, 0 doesn't score them
+	MinNTIAScore float64
+
+	// how --sign-key/--sign-keyless sign SBOMs before they are stored, nil doesn't sign them
+	Signing *sign.Options
+
+	// merge the SBOMs of each project, e.g. a repository's release, into one before upload
+	MergePerProject bool
+
+	// split each SBOM into one SBOM per top-level component before upload
+	SplitByComponent bool
+
+	// SBOMs transferred at most, 0 means no limit
+	Limit int
+
+	// cron schedule the transfer repeats on, nil runs it once
+	Schedule *schedule.Schedule
+
+	// SBOMs handed at once to output adapters supporting batch uploads, 0 disables batching
+	BatchSize int
+
+	// SBOMs fetched and processed ahead of the uploads, 0 fetches each SBOM when it is uploaded
+	PipelineBuffer int
+
+	// JSON file the SBOMs that failed to transfer are written to, empty disables it
+	ErrorsFile string
+
+	// JSON file every SBOM of the run, transferred or failed, is written to, empty disables it
+	ReportFile string
+
+	// what happens to SBOMs failing schema validation, ValidationOff doesn't validate them
+	Validate ValidationMode
+
+	// how file, object and asset contents are recognized as SBOMs
+	Detection DetectionMode
+
+	// whether SPDX 2.2 documents are upgraded to SPDX 2.3 before conversion
+	SPDXUpgrade SPDXUpgradeMode
+
+	// spec SBOMs are converted to before upload, OutputFormatOriginal keeps the adapter's default
+	OutputFormat OutputFormat
+
+	// CycloneDX spec version SBOMs are converted to: "1.4", "1.5" or "1.6"
+	ConversionTargetVersion string
+
+	// unix socket a daemon serves its status on for `sbommv status`, empty disables it
+	StatusSocket string
+
+	// how daemon-mode watchers cache what they transferred across restarts
+	Cache cache.Options
+
+	// what happens when the input's API rate limit can't cover the transfer
+	Preflight PreflightMode
+
+	// how the progress of a one-shot transfer is shown
+	Progress ProgressMode
+
+	// file recording the SBOMs transferred so far, and whether to skip those recorded by
+	// the previous, interrupted, run
+	CheckpointFile string
+	Resume         bool
+}
+
+// SourceAdapters returns the source adapters of the transfer, one per input
+func (c Config) SourceAdapters() []string {
+	return splitAdapters(c.SourceAdapter)
+}
+
+// DestinationAdapters returns the destination adapters of the transfer, one per output
+func (c Config) DestinationAdapters() []string {
+	return splitAdapters(c.DestinationAdapter)
+}
+
+func splitAdapters(list string) []string {
+	var adapters []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			adapters = append(adapters, name)
+		}
+	}
+	return adapters
 }
+
+// OutputFormat is the spec --output-format converts SBOMs to before upload
+type OutputFormat string
+
+const (
+	OutputFormatOriginal  OutputFormat = "original"  // leave SBOMs in their spec, unless the output adapter requires one
+	OutputFormatSPDX      OutputFormat = "spdx"      // convert CycloneDX SBOMs to SPDX 2.3 JSON
+	OutputFormatCycloneDX OutputFormat = "cyclonedx" // convert SPDX SBOMs to CycloneDX 1.5 JSON
+)
+
+// SPDXUpgradeMode is how --spdx-upgrade treats SPDX 2.2 documents that need converting
+type SPDXUpgradeMode string
+
+const (
+	SPDXUpgradeOn   SPDXUpgradeMode = "on"   // upgrade to SPDX 2.3
+	SPDXUpgradeOff  SPDXUpgradeMode = "off"  // never modify them, they fail conversion instead
+	SPDXUpgradeDiff SPDXUpgradeMode = "diff" // upgrade and log every field the upgrade changed
+)
+
+// PreflightMode is what --preflight does when the rate limit left can't cover the transfer
+type PreflightMode string
+
+const (
+	PreflightOff    PreflightMode = "off"    // don't check the rate limit
+	PreflightWarn   PreflightMode = "warn"   // warn and transfer anyway
+	PreflightStrict PreflightMode = "strict" // abort before fetching anything
+)
+
+// ProgressMode is how --progress shows the progress of a one-shot transfer
+type ProgressMode string
+
+const (
+	ProgressAuto ProgressMode = "auto" // a bar on a terminal, periodic log lines otherwise
+	ProgressBar  ProgressMode = "bar"  // a bar redrawn on stderr
+	ProgressLog  ProgressMode = "log"  // a log line every few seconds
+	ProgressOff  ProgressMode = "off"  // no progress
+)
+
+// DetectionMode is how --detection recognizes SBOMs by content
+type DetectionMode string
+
+const (
+	DetectionLenient DetectionMode = "lenient" // accept documents that look like CycloneDX or SPDX
+	DetectionStrict  DetectionMode = "strict"  // require the spec's identifying fields and a released version
+)
+
+// ValidationMode is what --validate does with SBOMs failing schema validation
+type ValidationMode string
+
+const (
+	ValidationOff  ValidationMode = ""
+	ValidationSkip ValidationMode = "skip" // skip invalid SBOMs and transfer the rest
+	ValidationFail ValidationMode = "fail" // stop the transfer at the first invalid SBOM
+)
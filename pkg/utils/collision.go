@@ -0,0 +1,64 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+)
+
+// NameCollisions tracks the output names written during a run, so that two different SBOMs
+// sharing a file name (e.g. sbom.cdx.json from many repos) don't overwrite each other.
+type NameCollisions struct {
+	mu      sync.Mutex
+	written map[string]string // output name -> content hash
+}
+
+// NewNameCollisions returns an empty collision tracker
+func NewNameCollisions() *NameCollisions {
+	return &NameCollisions{written: make(map[string]string)}
+}
+
+// Resolve returns the name data should be written under. When name was already used in this
+// run for different content, a short content-hash suffix is added and collided is true.
+func (n *NameCollisions) Resolve(name string, data []byte) (resolved string, collided bool) {
+	hash := sbom.ComputeContentHash(data)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	existing, ok := n.written[name]
+	if !ok || existing == hash {
+		n.written[name] = hash
+		return name, false
+	}
+
+	resolved = suffixName(name, hash[:8])
+	n.written[resolved] = hash
+	return resolved, true
+}
+
+// suffixName inserts suffix before the extensions of the base name,
+// e.g. "dir/sbom.cdx.json" becomes "dir/sbom-<suffix>.cdx.json".
+func suffixName(name, suffix string) string {
+	dir, base := path.Split(name)
+	if i := strings.Index(base, "."); i > 0 {
+		return dir + base[:i] + "-" + suffix + base[i:]
+	}
+	return dir + base + "-" + suffix
+}
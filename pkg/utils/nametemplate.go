@@ -0,0 +1,112 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/interlynk-io/sbommv/pkg/sbom"
+)
+
+// ProjectNameFields are the values a project name template can refer to
+type ProjectNameFields struct {
+	Owner            string // first segment of the namespace, e.g. the GitHub owner
+	Repo             string // rest of the namespace, e.g. the GitHub repository
+	Namespace        string // SBOM namespace
+	Version          string // SBOM version, else the primary component version, else "latest"
+	Component        string // primary component name
+	ComponentVersion string // primary component version
+	Filename         string // base name of the SBOM file
+	Path             string // source path of the SBOM file
+	Format           string // detected format, e.g. cyclonedx-json
+	Source           string // input adapter the SBOM was fetched by
+}
+
+// ProjectNameTemplate names destination projects per SBOM from a Go template such as
+// "{{.Owner}}/{{.Repo}}-{{.Version}}", in place of the default name-version naming
+type ProjectNameTemplate struct {
+	text string
+	tmpl *template.Template
+}
+
+// ParseProjectNameTemplate parses a project name template, nil for an empty one. Unknown
+// fields are rejected up front rather than on the first SBOM.
+func ParseProjectNameTemplate(text string) (*ProjectNameTemplate, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("project-name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project name template %q: %w", text, err)
+	}
+	if err := tmpl.Execute(io.Discard, ProjectNameFields{}); err != nil {
+		return nil, fmt.Errorf("invalid project name template %q: %w", text, err)
+	}
+	return &ProjectNameTemplate{text: text, tmpl: tmpl}, nil
+}
+
+// String returns the template text
+func (t *ProjectNameTemplate) String() string {
+	if t == nil {
+		return ""
+	}
+	return t.text
+}
+
+// Render returns the project name of an SBOM, failing when the template renders an empty one
+func (t *ProjectNameTemplate) Render(namespace, version, assetPath string, content []byte, source string) (string, error) {
+	fields := NewProjectNameFields(namespace, version, assetPath, content, source)
+
+	var name bytes.Buffer
+	if err := t.tmpl.Execute(&name, fields); err != nil {
+		return "", fmt.Errorf("rendering project name template for %s: %w", assetPath, err)
+	}
+	rendered := strings.TrimSpace(name.String())
+	if rendered == "" {
+		return "", fmt.Errorf("project name template %q rendered an empty name for %s", t.text, assetPath)
+	}
+	return rendered, nil
+}
+
+// NewProjectNameFields collects the template values of an SBOM
+func NewProjectNameFields(namespace, version, assetPath string, content []byte, source string) ProjectNameFields {
+	fields := ProjectNameFields{
+		Namespace: namespace,
+		Path:      assetPath,
+		Filename:  path.Base(strings.ReplaceAll(assetPath, "\\", "/")),
+		Format:    strings.ToLower(string(sbom.DetectFormat(content))),
+		Source:    source,
+	}
+	fields.Owner, fields.Repo, _ = strings.Cut(namespace, "/")
+
+	primaryComp := sbom.ExtractPrimaryComponentName(content)
+	fields.Component = primaryComp.Name
+	fields.ComponentVersion = primaryComp.Version
+
+	fields.Version = version
+	if fields.Version == "" {
+		fields.Version = primaryComp.Version
+	}
+	if fields.Version == "" {
+		fields.Version = "latest"
+	}
+	return fields
+}
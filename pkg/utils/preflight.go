@@ -0,0 +1,121 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Destination statuses reported by a dry-run preflight
+const (
+	PreflightNew       = "new"
+	PreflightOverwrite = "exists, would overwrite"
+	PreflightSkip      = "exists, would skip"
+	PreflightUnknown   = "existence unknown"
+)
+
+// preflightOrder is the order statuses are listed in the summary
+var preflightOrder = []string{PreflightNew, PreflightOverwrite, PreflightSkip, PreflightUnknown}
+
+// Preflight tallies what a dry run found at the destination: which targets are new, which
+// already exist and would be overwritten or skipped, how many SBOMs would be converted and
+// how many would be renamed because their name collides with another SBOM of the run.
+type Preflight struct {
+	mu        sync.Mutex
+	counts    map[string]int
+	converted int
+	renamed   int
+}
+
+// NewPreflight returns an empty preflight tally
+func NewPreflight() *Preflight {
+	return &Preflight{counts: make(map[string]int)}
+}
+
+// Status returns what the transfer would do to a target, given whether the dry run found it
+// at the destination and whether the output overwrites existing targets, and counts it.
+// err is the error of the existence check, the target is then reported as unknown.
+func (p *Preflight) Status(exists bool, err error, overwrite bool) string {
+	status := PreflightNew
+	switch {
+	case err != nil:
+		status = PreflightUnknown
+	case exists && overwrite:
+		status = PreflightOverwrite
+	case exists:
+		status = PreflightSkip
+	}
+	p.Count(status)
+	return status
+}
+
+// Count counts a status, for outputs describing their targets in words of their own
+func (p *Preflight) Count(status string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.counts[status]++
+}
+
+// Notes returns the details printed after an SBOM's dry-run line: the conversion applied to it,
+// see iterator.SBOM.Conversion, and the name it was renamed from to avoid a collision.
+func (p *Preflight) Notes(conversion, renamedFrom string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var notes []string
+	if conversion != "" {
+		p.converted++
+		notes = append(notes, "converted "+conversion)
+	}
+	if renamedFrom != "" {
+		p.renamed++
+		notes = append(notes, fmt.Sprintf("renamed from %s, the name collides with another SBOM", renamedFrom))
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return " | " + strings.Join(notes, " | ")
+}
+
+// Print prints the preflight summary below the dry-run listing
+func (p *Preflight) Print() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// the known statuses first, then those of the output in name order
+	statuses := append([]string(nil), preflightOrder...)
+	var others []string
+	for status := range p.counts {
+		if !slices.Contains(preflightOrder, status) {
+			others = append(others, status)
+		}
+	}
+	sort.Strings(others)
+
+	var parts []string
+	for _, status := range append(statuses, others...) {
+		if n := p.counts[status]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", status, n))
+		}
+	}
+	if len(parts) > 0 {
+		fmt.Printf("🔎 Destination: %s\n", strings.Join(parts, " | "))
+	}
+	fmt.Printf("🔁 Conversions: %d | Renamed to avoid collisions: %d\n", p.converted, p.renamed)
+}
@@ -21,6 +21,19 @@ import (
 	"github.com/interlynk-io/sbommv/pkg/tcontext"
 )
 
+// NamespaceSource is the naming source of SBOMs whose namespace and version were set
+// explicitly by the input adapter (e.g. via a namespace template)
+const NamespaceSource = "namespace"
+
+// NamingSource returns the source that decides how projects are named: the input adapter,
+// or NamespaceSource when the SBOM carries an explicit namespace.
+func NamingSource(sourceAdapter string, explicitNamespace bool) string {
+	if explicitNamespace {
+		return NamespaceSource
+	}
+	return sourceAdapter
+}
+
 func ConstructDTProjectName(ctx tcontext.TransferMetadata, extProjectName, extProjectVersion, ownerRepoGithubName, repoVersion, assetPath string, content []byte, source string) (string, string) {
 	logger.LogDebug(ctx.Context, "Constructing Project Name and Version", "providedProjectName", extProjectName, "providedProjectVersion", extProjectVersion, "ownerRepoName", ownerRepoGithubName, "repoVersion", repoVersion, "source", source, "assetpath", assetPath)
 
@@ -29,6 +42,14 @@ func ConstructDTProjectName(ctx tcontext.TransferMetadata, extProjectName, extPr
 		return getExplicitProjectVersion(extProjectName, extProjectVersion)
 	}
 
+	// namespace and version explicitly derived by the input adapter
+	if source == NamespaceSource {
+		if repoVersion == "" {
+			repoVersion = "latest"
+		}
+		return getImplicitProjectVersion(ownerRepoGithubName, repoVersion, "")
+	}
+
 	// no project name provided
 	// if source is github, then naming would be different
 	if source == "github" {
@@ -0,0 +1,232 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/interlynk-io/sbommv/pkg/logger"
+)
+
+// SyftVersion is the Syft release installed when no version is pinned
+const SyftVersion = "1.20.0"
+
+// syftChecksums are the SHA-256 digests of the SyftVersion release archives, in the format of
+// the release's checksums file. Being built in, they catch a tampered release and not only a
+// corrupt download. `make syft-checksums` updates them, and must be run whenever SyftVersion
+// changes.
+//
+//go:embed syft_checksums.txt
+var syftChecksums []byte
+
+// syftReleaseURL is where Syft release assets are downloaded from
+var syftReleaseURL = "https://github.com/anchore/syft/releases/download"
+
+// GetBinaryPath returns the Syft binary of the given version, SyftVersion when empty,
+// cached under ~/.sbommv/tools/syft/<version>. On first use, the release archive for the
+// current OS and architecture is downloaded and verified before the binary is extracted:
+// against the digests built into sbommv for SyftVersion, against the release checksums for
+// other versions.
+func GetBinaryPath(version string) (string, error) {
+	version = strings.TrimPrefix(version, "v")
+	if version == "" {
+		version = SyftVersion
+	}
+
+	binary := "syft"
+	if runtime.GOOS == "windows" {
+		binary = "syft.exe"
+	}
+	binDir := filepath.Join(os.Getenv("HOME"), ".sbommv", "tools", "syft", version)
+	syftBinary := filepath.Join(binDir, binary)
+
+	// Check if Syft already exists
+	if _, err := os.Stat(syftBinary); err == nil {
+		return syftBinary, nil
+	}
+
+	if err := os.MkdirAll(binDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	archive := syftArchive(version, runtime.GOOS, runtime.GOARCH)
+	checksum, err := syftChecksum(ctx, version, archive)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := download(ctx, fmt.Sprintf("%s/v%s/%s", syftReleaseURL, version, archive))
+	if err != nil {
+		return "", fmt.Errorf("failed to download Syft %s: %w", version, err)
+	}
+
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != checksum {
+		return "", fmt.Errorf("checksum mismatch for %s: expected %s, got %s", archive, checksum, got)
+	}
+
+	content, err := extractBinary(archive, data, binary)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract Syft from %s: %w", archive, err)
+	}
+
+	// write to a temporary file first, so an interrupted install never leaves a partial binary
+	tmp, err := os.CreateTemp(binDir, binary+".*")
+	if err != nil {
+		return "", fmt.Errorf("failed to install Syft: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to install Syft: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to install Syft: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return "", fmt.Errorf("failed to install Syft: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), syftBinary); err != nil {
+		return "", fmt.Errorf("failed to install Syft: %w", err)
+	}
+
+	return syftBinary, nil
+}
+
+// syftArchive is the name of the release archive of Syft for an OS and architecture
+func syftArchive(version, goos, goarch string) string {
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+	return fmt.Sprintf("syft_%s_%s_%s.%s", version, goos, goarch, ext)
+}
+
+// syftChecksum returns the SHA-256 the archive must have: the digest pinned in syftChecksums
+// for SyftVersion, and for other versions the one listed in the checksums of the release.
+// Those are downloaded from the release they check, so they catch a corrupt download but not
+// a tampered release.
+func syftChecksum(ctx context.Context, version, archive string) (string, error) {
+	if version == SyftVersion {
+		if checksum, ok := findChecksum(syftChecksums, archive); ok {
+			return checksum, nil
+		}
+		return "", fmt.Errorf("no pinned checksum for %s: Syft %s has no release for %s/%s, or sbommv was built without its checksums", archive, version, runtime.GOOS, runtime.GOARCH)
+	}
+
+	logger.LogInfo(ctx, "Syft version isn't the one sbommv pins, its download is only checked against the checksums of its own release, not against a pinned digest",
+		"version", version, "pinned_version", SyftVersion)
+	checksums, err := download(ctx, fmt.Sprintf("%s/v%s/syft_%s_checksums.txt", syftReleaseURL, version, version))
+	if err != nil {
+		return "", fmt.Errorf("failed to download Syft %s checksums: %w", version, err)
+	}
+	if checksum, ok := findChecksum(checksums, archive); ok {
+		return checksum, nil
+	}
+	return "", fmt.Errorf("no Syft %s release for %s/%s: %s isn't in the release checksums", version, runtime.GOOS, runtime.GOARCH, archive)
+}
+
+// findChecksum returns the SHA-256 of archive listed in a checksums file
+func findChecksum(checksums []byte, archive string) (string, bool) {
+	// each line is "<sha256>  <file>"
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == archive {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	return "", false
+}
+
+// download returns the body of a GET request to url
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// extractBinary returns the content of the named file at the root of a .tar.gz or .zip archive
+func extractBinary(archive string, data []byte, name string) ([]byte, error) {
+	if strings.HasSuffix(archive, ".zip") {
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range zr.File {
+			if f.Name != name {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+		return nil, fmt.Errorf("%s not found in archive", name)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in archive", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeReg && strings.TrimPrefix(hdr.Name, "./") == name {
+			return io.ReadAll(tr)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syftRelease is a Syft release archive holding content as its binary
+func syftRelease(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "syft", Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func checksumLine(archive string, data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), archive)
+}
+
+// serveSyftRelease serves archive as the release of version for the current OS and
+// architecture, with checksums as its checksums file, and counts the checksums requests
+func serveSyftRelease(t *testing.T, version string, archive []byte, checksums string) *int {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("release archives are zip files on Windows")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	var checksumRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/v%s/%s", version, syftArchive(version, runtime.GOOS, runtime.GOARCH)), func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+	mux.HandleFunc(fmt.Sprintf("/v%s/syft_%s_checksums.txt", version, version), func(w http.ResponseWriter, r *http.Request) {
+		checksumRequests++
+		fmt.Fprint(w, checksums)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	releaseURL := syftReleaseURL
+	syftReleaseURL = server.URL
+	t.Cleanup(func() { syftReleaseURL = releaseURL })
+	return &checksumRequests
+}
+
+// pinChecksums replaces the built-in digests for the test
+func pinChecksums(t *testing.T, checksums string) {
+	pinned := syftChecksums
+	syftChecksums = []byte(checksums)
+	t.Cleanup(func() { syftChecksums = pinned })
+}
+
+func TestGetBinaryPathPinnedVersion(t *testing.T) {
+	release := syftRelease(t, "syft binary")
+	archive := syftArchive(SyftVersion, runtime.GOOS, runtime.GOARCH)
+	requests := serveSyftRelease(t, SyftVersion, release, checksumLine(archive, release))
+	pinChecksums(t, checksumLine(archive, release))
+
+	path, err := GetBinaryPath("")
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "syft binary", string(content))
+	assert.Zero(t, *requests, "the release checksums are not used for the pinned version")
+}
+
+func TestGetBinaryPathRejectsTamperedRelease(t *testing.T) {
+	// the release and its checksums agree, but not with the pinned digest
+	tampered := syftRelease(t, "tampered binary")
+	archive := syftArchive(SyftVersion, runtime.GOOS, runtime.GOARCH)
+	serveSyftRelease(t, SyftVersion, tampered, checksumLine(archive, tampered))
+	pinChecksums(t, checksumLine(archive, syftRelease(t, "syft binary")))
+
+	_, err := GetBinaryPath(SyftVersion)
+	assert.ErrorContains(t, err, "checksum mismatch")
+
+	// without a pinned digest for the archive, the release checksums aren't trusted either
+	pinChecksums(t, "")
+	_, err = GetBinaryPath(SyftVersion)
+	assert.ErrorContains(t, err, "no pinned checksum")
+}
+
+func TestGetBinaryPathOtherVersion(t *testing.T) {
+	release := syftRelease(t, "older syft")
+	archive := syftArchive("1.0.0", runtime.GOOS, runtime.GOARCH)
+	requests := serveSyftRelease(t, "1.0.0", release, checksumLine(archive, release))
+
+	path, err := GetBinaryPath("v1.0.0")
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "older syft", string(content))
+	assert.Equal(t, 1, *requests)
+
+	// a corrupt download is still caught
+	serveSyftRelease(t, "1.0.0", syftRelease(t, "corrupt"), checksumLine(archive, release))
+	_, err = GetBinaryPath("1.0.0")
+	assert.ErrorContains(t, err, "checksum mismatch")
+}
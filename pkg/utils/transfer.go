@@ -15,60 +15,11 @@
 package utils
 
 import (
-	"context"
 	"fmt"
 	"net/url"
-	"os"
-	"os/exec"
-	"path/filepath"
 	"strings"
 )
 
-func GetBinaryPath() (string, error) {
-	ctx := context.Background()
-
-	cacheDir := filepath.Join(os.Getenv("HOME"), ".sbommv/tools")
-	syftBinary := filepath.Join(cacheDir, "bin/syft")
-
-	// Check if Syft already exists and is executable
-	if _, err := os.Stat(syftBinary); err == nil {
-		return syftBinary, nil
-	}
-
-	// If not cached, clone and install Syft
-	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
-		return "", fmt.Errorf("failed to create cache directory: %w", err)
-	}
-
-	// Clone Syft using Git
-	syftRepo := "https://github.com/anchore/syft"
-	cmd := exec.CommandContext(ctx, "git", "clone", "--depth=1", syftRepo, cacheDir)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to clone Syft: %w", err)
-	}
-	fmt.Println("cacheDir: ", cacheDir)
-	fmt.Println("syftBinary: ", syftBinary)
-
-	// Install Syft
-	installScript := filepath.Join(cacheDir, "install.sh")
-	cmd = exec.Command("/bin/sh", installScript)
-	cmd.Dir = cacheDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to install Syft: %w", err)
-	}
-
-	// Verify Syft installation
-	if _, err := os.Stat(syftBinary); err != nil {
-		return "", fmt.Errorf("Syft binary not found after installation")
-	}
-
-	return syftBinary, nil
-}
-
 // ParseGithubURL extracts the repository owner, repo name.
 // For URLs like "https://github.com/interlynk-io/sbomqs", returns "interlynk-io", "sbomqs", nil).
 func ParseGithubURL(githubURL string) (owner, repo string, err error) {
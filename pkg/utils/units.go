@@ -0,0 +1,109 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses a duration string (e.g., "10s", "10m", "10hr") into seconds.
+func ParseDuration(durationStr string) (int64, error) {
+	// Normalize the input
+	durationStr = strings.TrimSpace(durationStr)
+	durationStr = strings.ToLower(durationStr)
+	durationStr = strings.ReplaceAll(durationStr, " ", "")
+
+	// Parse the duration
+	var duration time.Duration
+	var err error
+
+	switch {
+	case strings.HasSuffix(durationStr, "s"): // Seconds: xs (e.g., "60s")
+		duration, err = time.ParseDuration(durationStr)
+	case strings.HasSuffix(durationStr, "m"): // Minutes: xm (e.g., "10m")
+		duration, err = time.ParseDuration(durationStr)
+	case strings.HasSuffix(durationStr, "hr"): // Hours: xhr (e.g., "10hr")
+		// Normalize "hr" to "h" for time.ParseDuration
+		durationStr = strings.TrimSuffix(durationStr, "hr") + "h"
+		duration, err = time.ParseDuration(durationStr)
+	default: // Backward compatibility: plain seconds (e.g., "60")
+		seconds, err := strconv.Atoi(durationStr)
+		if err != nil {
+			return 0, fmt.Errorf("must be in format like '60s', '10m', '10hr', or plain seconds (e.g., '60'): %w", err)
+		}
+		duration = time.Duration(seconds) * time.Second
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("must be in format like '60s', '10m', '10hr', or plain seconds (e.g., '60'): %w", err)
+	}
+
+	return int64(duration.Seconds()), nil
+}
+
+// ParseByteSize parses a size string (e.g., "512KB", "100MB", "2GB", or plain bytes) into bytes.
+func ParseByteSize(sizeStr string) (int64, error) {
+	sizeStr = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(sizeStr), " ", ""))
+
+	units := []struct {
+		suffix     string
+		multiplier int64
+	}{
+		{"TB", 1 << 40},
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+
+	multiplier := int64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(sizeStr, unit.suffix) {
+			sizeStr = strings.TrimSuffix(sizeStr, unit.suffix)
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(sizeStr, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("must be in format like '512KB', '100MB', '2GB', or plain bytes")
+	}
+
+	return int64(value * float64(multiplier)), nil
+}
+
+// FormatByteSize renders bytes in the largest binary unit that keeps the value at or above 1 (e.g. "1.5MB").
+func FormatByteSize(bytes int64) string {
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"TB", 1 << 40},
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+	}
+
+	for _, unit := range units {
+		if bytes >= unit.size {
+			return strconv.FormatFloat(float64(bytes)/float64(unit.size), 'f', 1, 64) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(bytes, 10) + "B"
+}
@@ -26,22 +26,43 @@ import (
 // FlagValidation validates that each adapter should contain flag of respective adapters only
 // if a adapter "X" of type Input(in)/Output(out),
 // then the flag name should be of the form "out-X-<flag-name>" or "in-X-<flag-name>"
-// where X is the adapter name
+// where X is the adapter name. When several adapters of the role are selected, e.g.
+// --output-adapter=dtrack,s3, the flags of all of them are valid.
 func FlagValidation(cmd *cobra.Command, adapter types.AdapterType, adapterPrefix types.FlagPrefix) error {
-	var err error
-	cmd.Flags().Visit(func(f *pflag.Flag) {
-		// out-
-		flagPrefix := fmt.Sprintf("%s"+"-", string(adapterPrefix))
+	role := string(adapterPrefix) + "put"
 
-		// out-folder-
-		flagType := fmt.Sprintf("%s%s-", flagPrefix, string(adapter))
+	// out-
+	flagPrefix := fmt.Sprintf("%s"+"-", string(adapterPrefix))
 
-		// f.Name: out-interlynk-url
+	// out-folder-, out-s3-
+	flagTypes := adapterFlagPrefixes(flagPrefix, string(adapter))
+	if selected, ferr := cmd.Flags().GetString(role + "-adapter"); ferr == nil {
+		flagTypes = append(flagTypes, adapterFlagPrefixes(flagPrefix, selected)...)
+	}
 
-		if strings.HasPrefix(f.Name, flagPrefix) && !strings.HasPrefix(f.Name, flagType) {
-			// logger.LogError(cmd.Context(), fmt.Errorf("invalid flag for %s adapter 'github': %s", string(adapterPrefix)+"put", f.Name), "flag", f.Name)
-			err = fmt.Errorf("Error: flag --%s is invalid for %s adapter %s", f.Name, string(adapterPrefix)+"put", string(adapter))
+	var err error
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		// f.Name: out-interlynk-url
+		if !strings.HasPrefix(f.Name, flagPrefix) {
+			return
+		}
+		for _, flagType := range flagTypes {
+			if strings.HasPrefix(f.Name, flagType) {
+				return
+			}
 		}
+		err = fmt.Errorf("Error: flag --%s is invalid for %s adapter %s", f.Name, role, string(adapter))
 	})
 	return err
 }
+
+// adapterFlagPrefixes returns the flag prefix of each adapter in a comma-separated list
+func adapterFlagPrefixes(flagPrefix, adapters string) []string {
+	var prefixes []string
+	for _, name := range strings.Split(adapters, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			prefixes = append(prefixes, flagPrefix+name+"-")
+		}
+	}
+	return prefixes
+}
@@ -0,0 +1,415 @@
+// Copyright 2025 Interlynk.io
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package verify checks the cosign signatures and in-toto attestations published along with
+// SBOMs, e.g. as .sig, .pem and .intoto.jsonl release assets, against a public key or, for
+// keyless signatures, the signing certificate chained to trusted roots.
+package verify
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Suffixes of the signature files of an SBOM, as sources key them
+const (
+	SuffixSignature   = ".sig"
+	SuffixCertificate = ".pem"
+	SuffixAttestation = ".intoto.jsonl"
+)
+
+// inTotoPayloadType is the DSSE payload type of in-toto statements
+const inTotoPayloadType = "application/vnd.in-toto+json"
+
+// OIDs of the OIDC issuer recorded by Fulcio in signing certificates
+var (
+	oidIssuerV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+var (
+	// ErrNoSignature is returned for SBOMs published without a signature or attestation
+	ErrNoSignature = errors.New("no signature or attestation found")
+
+	// ErrInvalidSignature is returned when no signature verifies
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Options configure how signatures are verified: against a public key, or against the
+// certificate of a keyless signature, which must chain to the roots and be issued to the identity
+type Options struct {
+	KeyPath    string // PEM public key, e.g. cosign.pub
+	RootsPath  string // PEM certificates of the CAs issuing signing certificates, e.g. Fulcio's
+	Identity   string // email or URI the signing certificate must be issued to
+	OIDCIssuer string // OIDC issuer the signing certificate must record, empty accepts any
+}
+
+// Verifier verifies SBOMs against the signatures published along with them. It is safe
+// for concurrent use.
+type Verifier struct {
+	key           crypto.PublicKey
+	roots         *x509.CertPool
+	intermediates *x509.CertPool
+	identity      string
+	issuer        string
+}
+
+// New returns a verifier for the options. It needs either a public key, or roots and an identity.
+func New(opts Options) (*Verifier, error) {
+	v := &Verifier{identity: opts.Identity, issuer: opts.OIDCIssuer}
+
+	switch {
+	case opts.KeyPath != "":
+		key, err := loadPublicKey(opts.KeyPath)
+		if err != nil {
+			return nil, err
+		}
+		v.key = key
+	case opts.RootsPath != "" && opts.Identity != "":
+		roots, intermediates, err := loadRoots(opts.RootsPath)
+		if err != nil {
+			return nil, err
+		}
+		v.roots, v.intermediates = roots, intermediates
+	default:
+		return nil, errors.New("a public key, or certificate roots and an identity, are required")
+	}
+	return v, nil
+}
+
+// Verify checks the SBOM data against its signature files, keyed by suffix. An attestation
+// must verify and name the SBOM's SHA-256 digest among its subjects; without one, the
+// signature must verify over the SBOM itself.
+func (v *Verifier) Verify(data []byte, signatures map[string][]byte) error {
+	if attestation, ok := signatures[SuffixAttestation]; ok {
+		return v.verifyAttestation(data, attestation, signatures[SuffixCertificate])
+	}
+	if signature, ok := signatures[SuffixSignature]; ok {
+		key, err := v.signer(signatures[SuffixCertificate])
+		if err != nil {
+			return err
+		}
+		return verifySignature(key, data, decodeSignature(signature))
+	}
+	return ErrNoSignature
+}
+
+// envelope is a DSSE envelope, as written by cosign attest-blob
+type envelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		Sig string `json:"sig"`
+	} `json:"signatures"`
+}
+
+// statement is the part of an in-toto statement naming what it attests
+type statement struct {
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// verifyAttestation checks the DSSE envelopes of an attestation, one per line, and accepts
+// the SBOM when one of them verifies and has the SBOM as subject
+func (v *Verifier) verifyAttestation(data, attestation, certificate []byte) error {
+	key, err := v.signer(certificate)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(data)
+	want := hex.EncodeToString(digest[:])
+
+	lastErr := fmt.Errorf("%w: empty attestation", ErrInvalidSignature)
+	for _, line := range bytes.Split(attestation, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var env envelope
+		if err := json.Unmarshal(line, &env); err != nil {
+			lastErr = fmt.Errorf("parsing attestation: %w", err)
+			continue
+		}
+		if env.PayloadType != inTotoPayloadType {
+			lastErr = fmt.Errorf("unexpected attestation payload type %q", env.PayloadType)
+			continue
+		}
+		payload, err := decodeBase64(env.Payload)
+		if err != nil {
+			lastErr = fmt.Errorf("decoding attestation payload: %w", err)
+			continue
+		}
+
+		if lastErr = verifyEnvelope(key, env, payload); lastErr != nil {
+			continue
+		}
+
+		var stmt statement
+		if err := json.Unmarshal(payload, &stmt); err != nil {
+			lastErr = fmt.Errorf("parsing in-toto statement: %w", err)
+			continue
+		}
+		for _, subject := range stmt.Subject {
+			if strings.EqualFold(subject.Digest["sha256"], want) {
+				return nil
+			}
+		}
+		lastErr = fmt.Errorf("attestation doesn't cover the SBOM, sha256:%s", want)
+	}
+	return lastErr
+}
+
+// verifyEnvelope checks that one of the envelope's signatures verifies over its
+// pre-authentication encoding
+func verifyEnvelope(key crypto.PublicKey, env envelope, payload []byte) error {
+	pae := fmt.Appendf(nil, "DSSEv1 %d %s %d ", len(env.PayloadType), env.PayloadType, len(payload))
+	pae = append(pae, payload...)
+
+	for _, sig := range env.Signatures {
+		signature, err := decodeBase64(sig.Sig)
+		if err != nil {
+			continue
+		}
+		if verifySignature(key, pae, signature) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no attestation signature verifies", ErrInvalidSignature)
+}
+
+// signer returns the key signatures are verified with: the configured public key or, for
+// keyless signatures, that of the signing certificate once it is trusted
+func (v *Verifier) signer(certificate []byte) (crypto.PublicKey, error) {
+	if v.key != nil {
+		return v.key, nil
+	}
+	if certificate == nil {
+		return nil, errors.New("no signing certificate (.pem) for the keyless signature")
+	}
+
+	certs, err := parseCertificates(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing certificate: %w", err)
+	}
+	leaf := certs[0]
+
+	// certificates of keyless signatures live for minutes, so the chain is checked as of issuance
+	intermediates := v.intermediates.Clone()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   leaf.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("untrusted signing certificate: %w", err)
+	}
+
+	if !hasIdentity(leaf, v.identity) {
+		return nil, fmt.Errorf("signing certificate not issued to %s", v.identity)
+	}
+	if v.issuer != "" {
+		if issuer := certificateIssuer(leaf); issuer != v.issuer {
+			return nil, fmt.Errorf("signing certificate issued by %q, not %s", issuer, v.issuer)
+		}
+	}
+	return leaf.PublicKey, nil
+}
+
+// hasIdentity reports whether the certificate is issued to the email or URI
+func hasIdentity(cert *x509.Certificate, identity string) bool {
+	if slices.Contains(cert.EmailAddresses, identity) {
+		return true
+	}
+	for _, uri := range cert.URIs {
+		if uri.String() == identity {
+			return true
+		}
+	}
+	return false
+}
+
+// certificateIssuer returns the OIDC issuer Fulcio recorded in the certificate
+func certificateIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidIssuerV2):
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		case ext.Id.Equal(oidIssuerV1):
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
+
+// verifySignature verifies a signature made the way cosign signs: ECDSA over the digest of
+// the message matching the curve, RSA PKCS #1 v1.5 over its SHA-256 digest, or Ed25519
+func verifySignature(key crypto.PublicKey, message, signature []byte) error {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if ecdsa.VerifyASN1(k, ecdsaDigest(k.Curve, message), signature) {
+			return nil
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		if rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) == nil {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if ed25519.Verify(k, message, signature) {
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+	return ErrInvalidSignature
+}
+
+// ecdsaDigest hashes the message with the hash paired with the curve
+func ecdsaDigest(curve elliptic.Curve, message []byte) []byte {
+	switch curve {
+	case elliptic.P384():
+		digest := sha512.Sum384(message)
+		return digest[:]
+	case elliptic.P521():
+		digest := sha512.Sum512(message)
+		return digest[:]
+	}
+	digest := sha256.Sum256(message)
+	return digest[:]
+}
+
+// decodeSignature decodes a signature file, base64 as cosign writes it, or raw bytes
+func decodeSignature(data []byte) []byte {
+	if decoded, err := decodeBase64(string(bytes.TrimSpace(data))); err == nil {
+		return decoded
+	}
+	return data
+}
+
+// decodeBase64 decodes standard or URL-safe base64, both allowed in DSSE envelopes
+func decodeBase64(s string) ([]byte, error) {
+	if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return data, nil
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
+
+// loadPublicKey reads a PEM public key, or the key of a PEM certificate
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading public key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM public key in %s", path)
+	}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate %s: %w", path, err)
+		}
+		return cert.PublicKey, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// loadRoots reads the PEM certificates of the CAs issuing signing certificates, sorting
+// self-signed ones into the roots and the others into the intermediates
+func loadRoots(path string) (*x509.CertPool, *x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading certificate roots: %w", err)
+	}
+	certs, err := parseCertificates(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing certificate roots %s: %w", path, err)
+	}
+
+	roots, intermediates := x509.NewCertPool(), x509.NewCertPool()
+	for _, cert := range certs {
+		if bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil {
+			roots.AddCert(cert)
+		} else {
+			intermediates.AddCert(cert)
+		}
+	}
+	return roots, intermediates, nil
+}
+
+// parseCertificates parses PEM certificates, base64 encoded as cosign writes them or not
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte("-----BEGIN")) {
+		decoded, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return nil, errors.New("neither PEM nor base64 encoded PEM")
+		}
+		data = decoded
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM certificate found")
+	}
+	return certs, nil
+}